@@ -0,0 +1,114 @@
+// Package broker fans out the updates of the candidacies to the streams of
+// their participants. Postgres notifies every hermione replica of each
+// update, so a participant gets it irrespective of the replica it is
+// connected to.
+package broker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+)
+
+type Listener interface {
+	ListenCandidacyUpdates(
+		ctx context.Context,
+		notify func(candidacyID string),
+	) error
+}
+
+type Broker struct {
+	listener Listener
+	log      util.Logger
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+func New(listener Listener, log util.Logger) *Broker {
+	return &Broker{
+		listener:    listener,
+		log:         log,
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+	}
+}
+
+// Subscribe returns a channel that is signalled whenever the candidacy is
+// updated, and a func to be called once the subscriber is done with it. The
+// signals of the updates that happen before the subscriber reads the channel
+// are coalesced into one, so the subscriber should fetch all the updates
+// after its cursor on each signal.
+func (b *Broker) Subscribe(candidacyID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.subscribers[candidacyID] == nil {
+		b.subscribers[candidacyID] = make(map[chan struct{}]struct{})
+	}
+	b.subscribers[candidacyID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		delete(b.subscribers[candidacyID], ch)
+		if len(b.subscribers[candidacyID]) == 0 {
+			delete(b.subscribers, candidacyID)
+		}
+		b.mu.Unlock()
+	}
+
+	return ch, unsubscribe
+}
+
+// Run listens to the updates of the candidacies until ctx is done, listening
+// again whenever the listening connection fails
+func (b *Broker) Run(ctx context.Context) {
+	for {
+		err := b.listener.ListenCandidacyUpdates(ctx, b.signal)
+		if ctx.Err() != nil {
+			return
+		}
+		b.log.Err("stopped listening to candidacy updates", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(vetchi.CandidacyUpdatesRelistenDelay):
+		}
+
+		// The updates while the broker was not listening are not notified,
+		// so all the subscribers are made to check for them
+		b.signalAll()
+	}
+}
+
+func (b *Broker) signal(candidacyID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[candidacyID] {
+		notify(ch)
+	}
+}
+
+func (b *Broker) signalAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subscribers := range b.subscribers {
+		for ch := range subscribers {
+			notify(ch)
+		}
+	}
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+		// A signal is already pending for the subscriber
+	}
+}
@@ -0,0 +1,199 @@
+// Package caldav is a minimal client of the RFC 4791 CalDAV calendars of the
+// org users. Only the parts of the standard that every compliant server has
+// to support are used, so that any CalDAV server can be connected.
+package caldav
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	icalTimeFormat = "20060102T150405Z"
+
+	// Upper limit on the size of a free-busy-query response
+	maxResponseBytes = 4 * 1024 * 1024
+)
+
+var ErrUnauthorized = errors.New("caldav server rejected the credentials")
+
+// ErrForbiddenAddress is returned when the calendar URL resolves to a
+// loopback, private, link-local or otherwise non-public address. The
+// calendars are fetched from within the cluster and such URLs could reach
+// the internal services.
+var ErrForbiddenAddress = errors.New(
+	"calendar URL resolves to a non-public address",
+)
+
+// Period is the half open interval [Start, End) during which the owner of
+// the calendar is busy
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+type Client struct {
+	calendarURL string
+	username    string
+	password    string
+	httpClient  *http.Client
+}
+
+// NewClient returns a client of the calendar collection at calendarURL,
+// which is authenticated with HTTP Basic authentication. The client refuses
+// to connect to the non-public addresses, except for those of the
+// allowedHosts, which are meant for the CalDAV servers of dev and CI.
+func NewClient(
+	calendarURL string,
+	username string,
+	password string,
+	timeout time.Duration,
+	allowedHosts []string,
+) (*Client, error) {
+	u, err := url.Parse(calendarURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calendar URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported calendar URL scheme %q", u.Scheme)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	return &Client{
+		calendarURL: u.String(),
+		username:    username,
+		password:    password,
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: newTransport(allowedHosts),
+		},
+	}, nil
+}
+
+// FreeBusy runs the CALDAV:free-busy-query REPORT of RFC 4791 section 7.10
+// on the calendar and returns the busy periods between start and end
+func (c *Client) FreeBusy(
+	ctx context.Context,
+	start time.Time,
+	end time.Time,
+) ([]Period, error) {
+	body := `<?xml version="1.0" encoding="utf-8" ?>
+<C:free-busy-query xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <C:time-range start="` + start.UTC().Format(icalTimeFormat) +
+		`" end="` + end.UTC().Format(icalTimeFormat) + `"/>
+</C:free-busy-query>`
+
+	resp, err := c.do(ctx, "REPORT", c.calendarURL, "application/xml", body,
+		map[string]string{"Depth": "1"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("free-busy-query", resp)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read free-busy response: %w", err)
+	}
+
+	return parseFreeBusy(string(data))
+}
+
+// PutEvent creates or replaces the calendar object resource of the event.
+// The calendar should not have an iTIP METHOD, as per RFC 4791 section 4.1.
+func (c *Client) PutEvent(ctx context.Context, uid, calendar string) error {
+	resp, err := c.do(
+		ctx,
+		http.MethodPut,
+		c.eventURL(uid),
+		"text/calendar; charset=utf-8",
+		calendar,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	}
+	return statusError("put", resp)
+}
+
+// DeleteEvent removes the calendar object resource of the event. Deleting
+// an event that does not exist is not an error.
+func (c *Client) DeleteEvent(ctx context.Context, uid string) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.eventURL(uid), "", "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	return statusError("delete", resp)
+}
+
+func (c *Client) eventURL(uid string) string {
+	return c.calendarURL + url.PathEscape(uid) + ".ics"
+}
+
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	target string,
+	contentType string,
+	body string,
+	headers map[string]string,
+) (*http.Response, error) {
+	var reader io.Reader
+	if body != "" {
+		reader = bytes.NewBufferString(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.username, c.password)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("caldav %s failed: %w", method, err)
+	}
+	return resp, nil
+}
+
+func statusError(operation string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusUnauthorized ||
+		resp.StatusCode == http.StatusForbidden {
+		return ErrUnauthorized
+	}
+	return fmt.Errorf(
+		"caldav %s returned status %d",
+		operation,
+		resp.StatusCode,
+	)
+}
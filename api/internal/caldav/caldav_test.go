@@ -0,0 +1,140 @@
+package caldav
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseFreeBusy(t *testing.T) {
+	calendar := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VFREEBUSY\r\n" +
+		"FREEBUSY:20300101T100000Z/20300101T110000Z,\r\n" +
+		" 20300101T120000Z/PT30M\r\n" +
+		"FREEBUSY;FBTYPE=FREE:20300101T130000Z/20300101T140000Z\r\n" +
+		"FREEBUSY:20300101T150000Z/20300101T150000Z\r\n" +
+		"FREEBUSY;FBTYPE=BUSY-TENTATIVE:20300101T170000Z/20300101T160000Z\r\n" +
+		"FREEBUSY:20300101T180000Z/PT0S\r\n" +
+		"END:VFREEBUSY\r\n" +
+		"END:VCALENDAR\r\n"
+
+	periods, err := parseFreeBusy(calendar)
+	if err != nil {
+		t.Fatalf("parseFreeBusy() error = %v", err)
+	}
+
+	day := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := []Period{
+		{Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)},
+		{
+			Start: day.Add(12 * time.Hour),
+			End:   day.Add(12*time.Hour + 30*time.Minute),
+		},
+	}
+	if len(periods) != len(want) {
+		t.Fatalf("parseFreeBusy() = %v, want %v", periods, want)
+	}
+	for i := range want {
+		if !periods[i].Start.Equal(want[i].Start) ||
+			!periods[i].End.Equal(want[i].End) {
+			t.Errorf("period %d = %v, want %v", i, periods[i], want[i])
+		}
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.215.14", want: true},
+		{addr: "2606:2800:21f:cb07:6820:80da:af6b:8b2c", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "::1", want: false},
+		{addr: "10.1.2.3", want: false},
+		{addr: "172.16.0.1", want: false},
+		{addr: "192.168.1.1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "fe80::1", want: false},
+		{addr: "fd00::1", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "0.0.0.0", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+		{addr: "::ffff:10.0.0.1", want: false},
+		{addr: "224.0.0.1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			got := isPublic(netip.MustParseAddr(tt.addr))
+			if got != tt.want {
+				t.Errorf("isPublic(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRefusesNonPublicAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+		},
+	))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		allowedHosts []string
+		wantErr      error
+	}{
+		{name: "not allowed", wantErr: ErrForbiddenAddress},
+		{
+			name:         "other host allowed",
+			allowedHosts: []string{"radicale"},
+			wantErr:      ErrForbiddenAddress,
+		},
+		{
+			name:         "allowed",
+			allowedHosts: []string{"radicale", serverURL.Hostname()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(
+				server.URL+"/calendars/user/work",
+				"user",
+				"secret",
+				5*time.Second,
+				tt.allowedHosts,
+			)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			now := time.Now()
+			_, err = client.FreeBusy(context.Background(), now, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FreeBusy() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitHosts(t *testing.T) {
+	got := SplitHosts(" radicale, caldav.internal ,,")
+	if len(got) != 2 || got[0] != "radicale" || got[1] != "caldav.internal" {
+		t.Errorf("SplitHosts() = %q", got)
+	}
+}
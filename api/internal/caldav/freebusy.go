@@ -0,0 +1,130 @@
+package caldav
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseFreeBusy extracts the busy periods from the VFREEBUSY components of
+// an iCalendar object. FBTYPE=FREE periods are skipped and all the other
+// types, including BUSY-TENTATIVE, are considered busy. The periods that do
+// not end after they start are dropped.
+func parseFreeBusy(calendar string) ([]Period, error) {
+	// Unfold the content lines, RFC 5545 section 3.1
+	calendar = strings.ReplaceAll(calendar, "\r\n", "\n")
+	calendar = strings.ReplaceAll(calendar, "\n ", "")
+	calendar = strings.ReplaceAll(calendar, "\n\t", "")
+
+	var periods []Period
+	for _, line := range strings.Split(calendar, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		params := strings.Split(name, ";")
+		if !strings.EqualFold(params[0], "FREEBUSY") {
+			continue
+		}
+
+		free := false
+		for _, param := range params[1:] {
+			if strings.EqualFold(param, "FBTYPE=FREE") {
+				free = true
+			}
+		}
+		if free {
+			continue
+		}
+
+		for _, period := range strings.Split(value, ",") {
+			p, err := parsePeriod(strings.TrimSpace(period))
+			if err != nil {
+				return nil, err
+			}
+			// Some servers report the zero length periods of the events
+			// that end when they start. Such a period is not busy at all.
+			if !p.End.After(p.Start) {
+				continue
+			}
+			periods = append(periods, p)
+		}
+	}
+
+	return periods, nil
+}
+
+// parsePeriod parses a PERIOD value, which is either "start/end" or
+// "start/duration" in UTC, RFC 5545 section 3.3.9
+func parsePeriod(value string) (Period, error) {
+	startStr, endStr, ok := strings.Cut(value, "/")
+	if !ok {
+		return Period{}, fmt.Errorf("invalid period %q", value)
+	}
+
+	start, err := time.Parse(icalTimeFormat, startStr)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid period start %q", value)
+	}
+
+	if strings.HasPrefix(endStr, "P") || strings.HasPrefix(endStr, "+P") {
+		duration, err := parseDuration(strings.TrimPrefix(endStr, "+"))
+		if err != nil {
+			return Period{}, err
+		}
+		return Period{Start: start, End: start.Add(duration)}, nil
+	}
+
+	end, err := time.Parse(icalTimeFormat, endStr)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid period end %q", value)
+	}
+	return Period{Start: start, End: end}, nil
+}
+
+// parseDuration parses a positive DURATION value like P1W, P1DT2H or PT30M,
+// RFC 5545 section 3.3.6
+func parseDuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(value, "P")
+	if !ok || rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := map[byte]time.Duration{
+		'W': 7 * 24 * time.Hour,
+		'D': 24 * time.Hour,
+	}
+	var duration time.Duration
+	number := ""
+	for i := 0; i < len(rest); i++ {
+		ch := rest[i]
+		switch {
+		case ch >= '0' && ch <= '9':
+			number += string(ch)
+		case ch == 'T':
+			units = map[byte]time.Duration{
+				'H': time.Hour,
+				'M': time.Minute,
+				'S': time.Second,
+			}
+		default:
+			unit, ok := units[ch]
+			if !ok || number == "" {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			duration += time.Duration(n) * unit
+			number = ""
+		}
+	}
+
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return duration, nil
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/vetchium/vetchium/api/internal/caldav"
+	"github.com/vetchium/vetchium/api/internal/util"
 )
 
 // Granger's url within the k8s cluster, resolveable from the hermione pod
@@ -34,6 +36,9 @@ type HermioneConfigOnDisk struct {
 
 	TimingAttackDelay    string `json:"timing_attack_delay"     validate:"required"`
 	PasswordResetTokLife string `json:"password_reset_tok_life" validate:"required"`
+
+	JitsiBaseURL string `json:"jitsi_base_url" validate:"omitempty,url"`
+	ClamdAddress string `json:"clamd_address"  validate:"omitempty,hostname_port"`
 }
 
 type Hermione struct {
@@ -61,6 +66,29 @@ type Hermione struct {
 		SecretKey string
 	}
 
+	// Name of the e-signature provider for the offer letters. Optional; the
+	// offers cannot be sent for signature without it. The "stub" provider,
+	// which signs by itself, is only for development and tests.
+	ESignProvider string
+
+	// AES-256 key that encrypts the credentials of the CalDAV calendars of
+	// the org users
+	CalendarCredentialsKey []byte
+
+	// Host names of the CalDAV servers that may resolve to non-public
+	// addresses. Optional and meant only for the in-cluster servers of dev
+	// and CI.
+	CalDAVAllowedHosts []string
+
+	// Base URL of the Jitsi server on which the rooms of the VIDEO_CALL
+	// interviews are created. Optional and the Jitsi meeting provider is
+	// unavailable without it.
+	JitsiBaseURL string
+
+	// host:port of the ClamAV daemon with which the uploaded files are
+	// scanned. Optional and the files are not scanned for malware without it.
+	ClamdAddress string
+
 	Port                 int
 	TimingAttackDelay    time.Duration
 	PasswordResetTokLife time.Duration
@@ -115,6 +143,21 @@ func LoadHermioneConfig() (*Hermione, error) {
 		return nil, fmt.Errorf("S3_SECRET_KEY environment variable is required")
 	}
 
+	hc.ESignProvider = os.Getenv("ESIGN_PROVIDER")
+
+	hc.CalendarCredentialsKey, err = util.ParseSecretKey(
+		os.Getenv("CALENDAR_CREDENTIALS_KEY"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("CALENDAR_CREDENTIALS_KEY: %w", err)
+	}
+	hc.CalDAVAllowedHosts = caldav.SplitHosts(
+		os.Getenv("CALDAV_ALLOWED_HOSTS"),
+	)
+
+	hc.JitsiBaseURL = cmap.JitsiBaseURL
+	hc.ClamdAddress = cmap.ClamdAddress
+
 	hc.Port, err = strconv.Atoi(cmap.Port)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert port to int: %w", err)
@@ -0,0 +1,10 @@
+package db
+
+import "github.com/vetchium/vetchium/typespec/hub"
+
+type AddBookmarkReq struct {
+	BookmarkID string
+	Target     hub.BookmarkTarget
+	Folder     *string
+	Note       *string
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ConnectCalDAVReq struct {
+	CalendarURL       string
+	Username          string
+	EncryptedPassword []byte
+}
+
+// DueCalDAVConnection is the CalDAV calendar of an org user that is due to
+// be synced
+type DueCalDAVConnection struct {
+	OrgUserID         uuid.UUID
+	CalendarURL       string
+	Username          string
+	EncryptedPassword []byte
+}
+
+// CalDAVEventChanges are the changes to be made to the CalDAV calendar of an
+// org user, so that it has all the upcoming interviews that the org user
+// has RSVPed YES to. The email addresses of the participants are not
+// populated.
+type CalDAVEventChanges struct {
+	// The interviews that are new or have changed after they were written
+	WriteInterviews []CalendarFeedInterview
+
+	// The interviews that were written before but are cancelled now or
+	// are no longer confirmed by the org user
+	DeleteInterviewIDs []string
+}
+
+type BusyPeriod struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type WrittenCalDAVEvent struct {
+	InterviewID  string
+	ICalSequence int
+}
+
+type SaveCalDAVSyncReq struct {
+	OrgUserID uuid.UUID
+
+	// The BusyPeriods replace the earlier ones only if FreeBusySynced
+	FreeBusySynced bool
+	BusyPeriods    []BusyPeriod
+
+	WrittenEvents       []WrittenCalDAVEvent
+	DeletedInterviewIDs []string
+
+	// Empty if the sync succeeded
+	SyncError  string
+	NextSyncAt time.Time
+}
@@ -1,8 +1,12 @@
 package db
 
 import (
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/vetchium/vetchium/typespec/common"
 	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
 )
 
 const (
@@ -27,8 +31,67 @@ type AddInterviewRequest struct {
 	employer.AddInterviewRequest
 	InterviewID string
 
+	// Empty for the interviews without a meeting room
+	MeetingProvider employer.MeetingProvider
+	RoomURL         string
+
 	InterviewerNotificationEmail Email
 	WatcherNotificationEmail     Email
 	ApplicantNotificationEmail   Email
 	CandidacyComment             string
 }
+
+// CandidacyEvent is a state change of a candidacy whose hooks are yet to be
+// run, along with what the hooks need to know about the candidacy
+type CandidacyEvent struct {
+	ID          uuid.UUID
+	CandidacyID string
+	EmployerID  uuid.UUID
+	FromState   *common.CandidacyState
+	ToState     common.CandidacyState
+	ActorType   common.CandidacyActorType
+	Reason      *string
+	CreatedAt   time.Time
+
+	CandidateName string
+	CompanyName   string
+	OpeningTitle  string
+
+	// Emails of the hiring manager, the recruiter and the watchers of the
+	// Opening of the candidacy, except the org user who made the change
+	WatcherEmails []string
+}
+
+type DispatchCandidacyEventReq struct {
+	EventID uuid.UUID
+
+	// Emails generated by the hooks of the event
+	Emails []Email
+}
+
+// CandidacyCommentFile is a file attached to a candidacy comment, stored in
+// S3 under the FilePath
+type CandidacyCommentFile struct {
+	Filename    string
+	FilePath    string
+	ContentType string
+	SizeBytes   int64
+}
+
+type AddEmployerCandidacyCommentReq struct {
+	employer.AddEmployerCandidacyCommentRequest
+	Files []CandidacyCommentFile
+}
+
+type AddHubCandidacyCommentReq struct {
+	hub.AddHubCandidacyCommentRequest
+	Files []CandidacyCommentFile
+}
+
+type GetCandidacyUpdatesReq struct {
+	CandidacyID string
+
+	// The updates with ids greater than this are returned, oldest first
+	After int64
+	Limit int
+}
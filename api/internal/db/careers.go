@@ -0,0 +1,38 @@
+package db
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type CareersLocation struct {
+	Title         string
+	CountryCode   string
+	PostalAddress string
+	PostalCode    string
+}
+
+// CareersOpening is a publicly listed ACTIVE Opening. Served to the
+// unauthenticated clients, so should not carry anything private to the
+// employer.
+type CareersOpening struct {
+	ID                 string
+	Title              string
+	JD                 string
+	CompanyName        string
+	OpeningType        common.OpeningType
+	YoeMin             int
+	YoeMax             int
+	MinEducationLevel  common.EducationLevel
+	Locations          []CareersLocation
+	RemoteCountryCodes []common.CountryCode
+	Salary             *common.Salary
+	CloseAt            *time.Time
+	CreatedAt          time.Time
+}
+
+type CareersPage struct {
+	CompanyName string
+	Openings    []CareersOpening
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/vetchium/vetchium/typespec/careers"
 	"github.com/vetchium/vetchium/typespec/common"
 	"github.com/vetchium/vetchium/typespec/employer"
 	"github.com/vetchium/vetchium/typespec/hub"
@@ -29,6 +30,7 @@ const (
 
 type OfferToCandidateReq struct {
 	CandidacyID string
+	Offer       OfferDetails
 	Comment     string
 	Email       Email
 }
@@ -38,6 +40,7 @@ type CandidateInfo struct {
 	CandidateEmail string
 	CompanyName    string
 	OpeningTitle   string
+	RecruiterEmail string
 }
 
 type UpdateOfficialEmailVerificationCodeReq struct {
@@ -77,6 +80,7 @@ type ApplicationScore struct {
 	ApplicationID string
 	ModelName     string
 	Score         int
+	Explanation   *common.ScoreExplanation
 	CreatedAt     time.Time
 }
 
@@ -84,6 +88,26 @@ type ApplicationScore struct {
 type ApplicationForScoring struct {
 	ApplicationID string
 	ResumeSHA     string
+	Profile       CandidateProfile
+
+	// The models that are due to score the application, whose leases are
+	// held by the batch
+	Models []string
+}
+
+// CandidateProfile is what is known of an applicant other than their
+// resume, for scoring the application on more than the resume
+type CandidateProfile struct {
+	LongBio string
+
+	// The title and the description of each of the certifications
+	Certifications []string
+
+	// The institute, the degree and the description of each education
+	Education []string
+
+	// The domains of the official emails that the applicant has verified
+	VerifiedEmployerDomains []string
 }
 
 // UnscoredApplicationBatch represents a batch of unscored applications for a single opening
@@ -91,9 +115,38 @@ type UnscoredApplicationBatch struct {
 	EmployerID   string
 	OpeningID    string
 	JD           string
+	YoeMin       int
+	YoeMax       int
+	MatchWeights employer.MatchScoreWeights
 	Applications []ApplicationForScoring // max 10 elements
 }
 
+// ApplicationScoringResult is the outcome of scoring a claimed batch. The
+// leases of all the models of all the applications of the batch are
+// released. A model that fails on an application is retried and dead
+// lettered on its own, without holding back the scores of the other models.
+type ApplicationScoringResult struct {
+	Scores []ApplicationScore
+
+	// The models that scored the applications
+	Scored []ApplicationModel
+
+	// The models that failed to score the applications, which are retried
+	// after a backoff or dead lettered
+	Failed []ApplicationScoringFailure
+}
+
+// ApplicationModel is the scoring of an application by a model
+type ApplicationModel struct {
+	ApplicationID string
+	ModelName     string
+}
+
+type ApplicationScoringFailure struct {
+	ApplicationModel
+	Error string
+}
+
 // Incognito Posts request types - only for functions that generate IDs
 type AddIncognitoPostRequest struct {
 	Context             context.Context
@@ -218,6 +271,93 @@ type DB interface {
 		context.Context,
 		employer.ChangeOpeningStateRequest,
 	) error
+	SetOpeningSchedule(
+		context.Context,
+		employer.SetOpeningScheduleRequest,
+	) error
+	SetOpeningPublicListing(
+		context.Context,
+		employer.SetOpeningPublicListingRequest,
+	) error
+
+	// Used by hermione - Sourcing related methods
+	GetSuggestedCandidates(
+		context.Context,
+		employer.GetSuggestedCandidatesRequest,
+	) ([]employer.SuggestedCandidate, error)
+	GetOpeningInvitationMailInfo(
+		context.Context,
+		employer.InviteToApplyRequest,
+	) (OpeningInvitationMailInfo, error)
+	InviteToApply(context.Context, InviteToApplyReq) error
+
+	// Used by hermione - Careers page related methods. Not authenticated.
+	GetCareersPage(
+		context.Context,
+		careers.GetCareersOpeningsRequest,
+	) (CareersPage, error)
+	GetCareersOpening(
+		context.Context,
+		careers.GetCareersOpeningRequest,
+	) (CareersOpening, error)
+
+	// Used by hermione - Headcount related methods
+	RecordOfferAcceptance(
+		context.Context,
+		employer.RecordOfferAcceptanceRequest,
+	) error
+	UpdateOpeningHire(context.Context, employer.UpdateOpeningHireRequest) error
+	GetOpeningHires(
+		context.Context,
+		employer.GetOpeningHiresRequest,
+	) ([]employer.OpeningHire, error)
+	GetHeadcountReport(
+		context.Context,
+		employer.GetHeadcountReportRequest,
+	) ([]employer.HeadcountReportRow, error)
+
+	// Used by hermione - Opening approvals related methods
+	SetCostCenterApprovalChain(
+		context.Context,
+		employer.SetCostCenterApprovalChainRequest,
+	) error
+	GetCostCenterApprovalChain(
+		context.Context,
+		employer.GetCostCenterApprovalChainRequest,
+	) (employer.CostCenterApprovalChain, error)
+	SubmitOpeningForApproval(context.Context, SubmitOpeningForApprovalReq) error
+	GetOpeningApprovals(
+		context.Context,
+		employer.GetOpeningApprovalsRequest,
+	) (OpeningApprovals, error)
+	ApproveOpening(context.Context, ApproveOpeningReq) error
+	RejectOpening(context.Context, RejectOpeningReq) error
+	GetMyOpeningApprovals(
+		context.Context,
+		employer.GetMyOpeningApprovalsRequest,
+	) ([]employer.MyOpeningApproval, error)
+
+	// Used by hermione - Opening templates related methods
+	CreateOpeningTemplate(
+		context.Context,
+		employer.CreateOpeningTemplateRequest,
+	) (employer.OpeningTemplateID, error)
+	UpdateOpeningTemplate(
+		context.Context,
+		employer.UpdateOpeningTemplateRequest,
+	) error
+	GetOpeningTemplate(
+		context.Context,
+		employer.GetOpeningTemplateRequest,
+	) (employer.OpeningTemplate, error)
+	FilterOpeningTemplates(
+		context.Context,
+		employer.FilterOpeningTemplatesRequest,
+	) ([]employer.OpeningTemplate, error)
+	DeleteOpeningTemplate(
+		context.Context,
+		employer.DeleteOpeningTemplateRequest,
+	) error
 
 	// Used by hermione - Applications related methods for employers
 	GetApplicationsForEmployer(
@@ -228,6 +368,10 @@ type DB interface {
 		context.Context,
 		employer.GetResumeRequest,
 	) (ResumeDetails, error)
+	GetApplicationScoreDetails(
+		context.Context,
+		employer.GetApplicationScoreDetailsRequest,
+	) ([]employer.ApplicationScoreDetails, error)
 	SetApplicationColorTag(
 		context.Context,
 		employer.SetApplicationColorTagRequest,
@@ -246,11 +390,11 @@ type DB interface {
 	// Used by hermione - Candidacies related methods
 	AddEmployerCandidacyComment(
 		context.Context,
-		employer.AddEmployerCandidacyCommentRequest,
+		AddEmployerCandidacyCommentReq,
 	) (uuid.UUID, error)
 	AddHubCandidacyComment(
 		context.Context,
-		hub.AddHubCandidacyCommentRequest,
+		AddHubCandidacyCommentReq,
 	) (uuid.UUID, error)
 	GetEmployerCandidacyComments(
 		context.Context,
@@ -260,6 +404,38 @@ type DB interface {
 		context.Context,
 		common.GetCandidacyCommentsRequest,
 	) ([]common.CandidacyComment, error)
+	EditEmployerCandidacyComment(
+		context.Context,
+		common.EditCandidacyCommentRequest,
+	) error
+	EditHubCandidacyComment(
+		context.Context,
+		common.EditCandidacyCommentRequest,
+	) error
+	RetractEmployerCandidacyComment(
+		context.Context,
+		common.RetractCandidacyCommentRequest,
+	) error
+	RetractHubCandidacyComment(
+		context.Context,
+		common.RetractCandidacyCommentRequest,
+	) error
+	MarkEmployerCandidacyCommentsRead(
+		context.Context,
+		common.MarkCandidacyCommentsReadRequest,
+	) error
+	MarkHubCandidacyCommentsRead(
+		context.Context,
+		common.MarkCandidacyCommentsReadRequest,
+	) error
+	GetEmployerCommentAttachment(
+		context.Context,
+		common.GetCandidacyCommentAttachmentRequest,
+	) (CandidacyCommentFile, error)
+	GetHubCommentAttachment(
+		context.Context,
+		common.GetCandidacyCommentAttachmentRequest,
+	) (CandidacyCommentFile, error)
 	FilterEmployerCandidacyInfos(
 		context.Context,
 		employer.FilterCandidacyInfosRequest,
@@ -272,6 +448,18 @@ type DB interface {
 		context.Context,
 		common.GetCandidacyInfoRequest,
 	) (hub.MyCandidacy, error)
+	GetEmployerCandidacyUpdates(
+		context.Context,
+		GetCandidacyUpdatesReq,
+	) ([]common.CandidacyUpdate, error)
+	GetHubCandidacyUpdates(
+		context.Context,
+		GetCandidacyUpdatesReq,
+	) ([]common.CandidacyUpdate, error)
+	ListenCandidacyUpdates(
+		ctx context.Context,
+		notify func(candidacyID string),
+	) error
 	AddInterview(context.Context, AddInterviewRequest) error
 	AddInterviewer(context.Context, AddInterviewerRequest) error
 	RemoveInterviewer(context.Context, RemoveInterviewerRequest) error
@@ -297,8 +485,133 @@ type DB interface {
 		context.Context,
 		employer.PutAssessmentRequest,
 	) error
+	GetInterviewParticipants(
+		ctx context.Context,
+		interviewID string,
+	) (InterviewParticipants, error)
+	RescheduleInterview(context.Context, RescheduleInterviewReq) error
+	CancelInterview(context.Context, CancelInterviewReq) error
+	GetOrgUserCalendarFeed(ctx context.Context, newToken string) (string, error)
+	ResetOrgUserCalendarFeed(ctx context.Context, newToken string) error
+
+	// Used by hermione - Interview scheduling related methods for employers
+	SetOrgUserAvailability(context.Context, employer.OrgUserAvailability) error
+	GetOrgUserAvailability(context.Context) (employer.OrgUserAvailability, error)
+	CreateSchedulingRequest(context.Context, CreateSchedulingRequestReq) error
+	CancelSchedulingRequest(
+		context.Context,
+		employer.CancelSchedulingRequestRequest,
+	) error
+	ConnectCalDAV(context.Context, ConnectCalDAVReq) error
+	GetCalDAVConnection(context.Context) (employer.CalDAVConnection, error)
+	DisconnectCalDAV(context.Context) error
+
+	// Used by hermione - Take-home assignments related methods for employers
+	SetTakeHomeAssignment(context.Context, SetTakeHomeAssignmentReq) error
+	GetEmployerTakeHomeAssignment(
+		ctx context.Context,
+		interviewID string,
+	) (common.TakeHomeAssignment, error)
+	GetEmployerTakeHomeFile(
+		context.Context,
+		common.GetTakeHomeFileRequest,
+	) (TakeHomeFile, error)
+	GetEmployerTakeHomeSubmission(
+		context.Context,
+		common.GetTakeHomeSubmissionRequest,
+	) (TakeHomeFile, error)
+
+	// Used by hermione - Scorecards related methods
+	SetOpeningCompetencies(
+		context.Context,
+		employer.SetOpeningCompetenciesRequest,
+	) error
+	GetOpeningCompetencies(
+		context.Context,
+		employer.GetOpeningCompetenciesRequest,
+	) ([]employer.Competency, error)
+	PutScorecard(context.Context, employer.PutScorecardRequest) error
+	GetScorecards(
+		context.Context,
+		employer.GetScorecardsRequest,
+	) (employer.InterviewScorecards, error)
+	GetCandidacyDebrief(
+		context.Context,
+		employer.GetCandidacyDebriefRequest,
+	) (employer.CandidacyDebrief, error)
+
 	OfferToCandidate(context.Context, OfferToCandidateReq) error
 
+	// Used by hermione - Offers related methods for employers
+	ReviseOffer(context.Context, ReviseOfferReq) error
+	RescindOffer(context.Context, RescindOfferReq) error
+	GetEmployerOffers(
+		context.Context,
+		common.GetOffersRequest,
+	) ([]common.Offer, error)
+	GetEmployerOfferDocumentPath(
+		context.Context,
+		common.GetOfferDocumentRequest,
+	) (string, error)
+	GetOfferForSignature(
+		ctx context.Context,
+		candidacyID string,
+	) (OfferForSignature, error)
+	SaveOfferSignature(context.Context, SaveOfferSignatureReq) error
+
+	// Used by hermione - E-signature provider webhooks. Not authenticated.
+	PollOfferSignatureNow(ctx context.Context, provider, envelopeID string) error
+
+	// Used by hermione - Calendar feeds of interviews. Not authenticated.
+	GetCalendarFeed(ctx context.Context, token string) (CalendarFeed, error)
+
+	// Used by hermione - Unsubscribe links of the saved search alerts. Not
+	// authenticated.
+	UnsubscribeSavedSearch(ctx context.Context, token string) error
+
+	// Used by hermione - Offers related methods for hub users
+	RespondToOffer(context.Context, RespondToOfferReq) error
+	GetHubOffers(
+		context.Context,
+		common.GetOffersRequest,
+	) ([]common.Offer, error)
+	GetHubOfferDocumentPath(
+		context.Context,
+		common.GetOfferDocumentRequest,
+	) (string, error)
+
+	// Used by granger
+	GetExpiredOffers(ctx context.Context, limit int) ([]ExpiredOffer, error)
+	ExpireOffers(context.Context, ExpireOffersReq) error
+	GetDueOfferSignatures(
+		ctx context.Context,
+		provider string,
+		limit int,
+	) ([]DueOfferSignature, error)
+	DeferOfferSignaturePoll(context.Context, DeferOfferSignaturePollReq) error
+	CompleteOfferSignature(context.Context, CompleteOfferSignatureReq) error
+	DeclineOfferSignature(context.Context, DeclineOfferSignatureReq) error
+	GetDueCalDAVConnections(
+		ctx context.Context,
+		limit int,
+	) ([]DueCalDAVConnection, error)
+	GetCalDAVEventChanges(
+		ctx context.Context,
+		orgUserID uuid.UUID,
+	) (CalDAVEventChanges, error)
+	SaveCalDAVSync(context.Context, SaveCalDAVSyncReq) error
+	GetDueTakeHomeReminders(
+		ctx context.Context,
+		leadTime time.Duration,
+		limit int,
+	) ([]DueTakeHomeReminder, error)
+	SendTakeHomeReminders(context.Context, TakeHomeRemindersReq) error
+	GetUndispatchedCandidacyEvents(
+		ctx context.Context,
+		limit int,
+	) ([]CandidacyEvent, error)
+	DispatchCandidacyEvent(context.Context, DispatchCandidacyEventReq) error
+
 	// Used by hermione - for Hub users
 	AuthHubUser(c context.Context, token string) (HubUserTO, error)
 	ChangeHubUserPassword(context.Context, uuid.UUID, string) error
@@ -317,6 +630,40 @@ type DB interface {
 		context.Context,
 		hub.GetHubOpeningDetailsRequest,
 	) (hub.HubOpeningDetails, error)
+	GetRecommendedOpenings(
+		context.Context,
+		hub.GetRecommendedOpeningsRequest,
+	) ([]hub.RecommendedOpening, error)
+	DismissRecommendedOpening(
+		context.Context,
+		hub.DismissRecommendedOpeningRequest,
+	) error
+	GetOpeningInvitations(
+		context.Context,
+		hub.GetOpeningInvitationsRequest,
+	) ([]hub.OpeningInvitation, error)
+	SetDiscoverability(ctx context.Context, discoverable bool) error
+	GetDiscoverability(ctx context.Context) (bool, error)
+	SaveSearch(context.Context, SaveSearchReq) error
+	ListSavedSearches(context.Context) ([]hub.SavedSearch, error)
+	UpdateSavedSearch(context.Context, hub.UpdateSavedSearchRequest) error
+	SetSavedSearchPaused(
+		ctx context.Context,
+		savedSearchID string,
+		paused bool,
+	) error
+	DeleteSavedSearch(ctx context.Context, savedSearchID string) error
+	ViewSavedSearch(
+		ctx context.Context,
+		savedSearchID string,
+	) ([]hub.SavedSearchOpening, error)
+	AddBookmark(ctx context.Context, req AddBookmarkReq) error
+	RemoveBookmark(ctx context.Context, target hub.BookmarkTarget) error
+	ListBookmarks(
+		ctx context.Context,
+		req hub.ListBookmarksRequest,
+	) (hub.ListBookmarksResponse, error)
+	GetBookmarkFolders(ctx context.Context) ([]hub.BookmarkFolder, error)
 	GetMyCandidacies(
 		context.Context,
 		hub.MyCandidaciesRequest,
@@ -329,6 +676,42 @@ type DB interface {
 	Logout(c context.Context, token string) error
 	ResetHubUserPassword(context.Context, HubUserPasswordReset) error
 	HubRSVPInterview(context.Context, hub.HubRSVPInterviewRequest) error
+	RequestInterviewReschedule(
+		context.Context,
+		RequestInterviewRescheduleReq,
+	) error
+	GetHubUserCalendarFeed(ctx context.Context, newToken string) (string, error)
+	ResetHubUserCalendarFeed(ctx context.Context, newToken string) error
+	GetInterviewSlots(
+		context.Context,
+		hub.GetInterviewSlotsRequest,
+	) (hub.InterviewSlots, error)
+	HoldInterviewSlot(
+		context.Context,
+		hub.HoldInterviewSlotRequest,
+	) (time.Time, error)
+	GetSchedulingRequestParticipants(
+		ctx context.Context,
+		schedulingRequestID string,
+	) (SchedulingRequestParticipants, error)
+	BookInterviewSlot(context.Context, BookInterviewSlotReq) error
+	GetHubTakeHomeAssignment(
+		ctx context.Context,
+		interviewID string,
+	) (common.TakeHomeAssignment, error)
+	StartTakeHomeAssignment(ctx context.Context, interviewID string) error
+	GetHubTakeHomeFile(
+		context.Context,
+		common.GetTakeHomeFileRequest,
+	) (TakeHomeFile, error)
+	SubmitTakeHomeAssignment(
+		context.Context,
+		SubmitTakeHomeReq,
+	) (common.TakeHomeSubmission, error)
+	GetHubTakeHomeSubmission(
+		context.Context,
+		common.GetTakeHomeSubmissionRequest,
+	) (TakeHomeFile, error)
 	GetCandidateInfo(context.Context, string) (CandidateInfo, error)
 
 	// Opening tags
@@ -461,15 +844,40 @@ type DB interface {
 	SignupHubUser(context.Context, SignupHubUserReq) error
 	ChangeEmailAddress(ctx context.Context, email string) error
 
-	GetUnscoredApplication(
+	ClaimUnscoredApplications(
 		ctx context.Context,
+		models []string,
 		limit int,
 	) (*UnscoredApplicationBatch, error)
-	SaveApplicationScores(ctx context.Context, scores []ApplicationScore) error
+	FinishApplicationScoring(
+		ctx context.Context,
+		result ApplicationScoringResult,
+	) ([]ApplicationModel, error)
+
+	// Used by granger
+	GetDueOpeningScheduleChanges(
+		ctx context.Context,
+		limit int,
+	) (OpeningScheduleChanges, error)
+	ApplyOpeningScheduleChanges(
+		ctx context.Context,
+		req ApplyOpeningScheduleChangesReq,
+	) error
 
 	// Employer settings
 	ChangeCoolOffPeriod(ctx context.Context, coolOffPeriod int32) error
 	GetCoolOffPeriod(ctx context.Context) (int32, error)
+	ChangeApplicationExpiryPeriod(ctx context.Context, days int32) error
+	GetApplicationExpiryPeriod(ctx context.Context) (int32, error)
+	ChangeAutoCloseFilledOpenings(ctx context.Context, autoClose bool) error
+	GetAutoCloseFilledOpenings(ctx context.Context) (bool, error)
+	ChangeMatchScoreWeights(
+		ctx context.Context,
+		weights employer.MatchScoreWeights,
+	) error
+	GetMatchScoreWeights(ctx context.Context) (employer.MatchScoreWeights, error)
+	ChangeMeetingProvider(context.Context, employer.MeetingProvider) error
+	GetMeetingProvider(context.Context) (employer.MeetingProvider, error)
 
 	// Used by hermione - Posts related methods
 	AddPost(req AddPostRequest) error
@@ -546,6 +954,45 @@ type DB interface {
 	GetTimelinesToRefresh(ctx context.Context, limit int) ([]uuid.UUID, error)
 	RefreshTimeline(ctx context.Context, timelineID uuid.UUID) error
 
+	// Used by granger
+	GetStaleRecommendationProfiles(
+		ctx context.Context,
+		limit int,
+	) ([]RecommendationProfile, error)
+	GetRecommendationCandidates(
+		ctx context.Context,
+		profile RecommendationProfile,
+		limit int,
+	) ([]RecommendationCandidate, error)
+	SaveRecommendations(ctx context.Context, req SaveRecommendationsReq) error
+
+	// Used by granger
+	GetStaleSuggestionOpenings(
+		ctx context.Context,
+		limit int,
+	) ([]SuggestionOpening, error)
+	GetSuggestionCandidates(
+		ctx context.Context,
+		opening SuggestionOpening,
+		limit int,
+	) ([]SuggestionCandidate, error)
+	SaveCandidateSuggestions(
+		ctx context.Context,
+		req SaveCandidateSuggestionsReq,
+	) error
+
+	// Used by granger
+	GetSavedSearchesToEvaluate(
+		ctx context.Context,
+		limit int,
+	) ([]SavedSearchEvaluation, error)
+	EvaluateSavedSearch(ctx context.Context, search SavedSearchEvaluation) error
+	GetDueSavedSearchAlerts(
+		ctx context.Context,
+		limit int,
+	) ([]SavedSearchAlert, error)
+	SaveSavedSearchAlert(ctx context.Context, req SaveSavedSearchAlertReq) error
+
 	// Used by granger
 	GetEmployerActiveJobCount(
 		ctx context.Context,
@@ -27,6 +27,9 @@ type Email struct {
 	EmailState    EmailState `db:"email_state"`
 	CreatedAt     time.Time  `db:"created_at"`
 	ProcessedAt   time.Time  `db:"processed_at"`
+
+	// Optional iCalendar object, sent as an attachment
+	EmailCalendarEvent string `db:"email_calendar_event"`
 }
 
 type EmailStateChange struct {
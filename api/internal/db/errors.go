@@ -25,6 +25,27 @@ var (
 	ErrNoOpening       = errors.New("opening not found")
 	ErrTooManyWatchers = errors.New("too many watchers")
 
+	ErrNoApprovalChain  = errors.New("cost center has no approval chain")
+	ErrInvalidApprovers = errors.New(
+		"one or more approvers are not active org users",
+	)
+	ErrNoOpeningApproval = errors.New(
+		"opening is not waiting for the approval of the org user",
+	)
+	ErrOpeningApprovalPending = errors.New("opening is not approved yet")
+
+	ErrAllPositionsFilled = errors.New("all positions of the opening are filled")
+	ErrNoOpeningHire      = errors.New("opening hire not found")
+	ErrNoOffer            = errors.New("offer not found")
+
+	ErrOfferSentForSignature = errors.New("offer already sent for signature")
+	ErrNoOfferSignature      = errors.New("offer signature not found")
+
+	ErrNoOpeningTemplate      = errors.New("opening template not found")
+	ErrDupOpeningTemplateName = errors.New(
+		"opening template name already exists",
+	)
+
 	ErrNoRecruiter          = errors.New("recruiter not found")
 	ErrNoHiringManager      = errors.New("hiring manager not found")
 	ErrNoStateChangeWaiting = errors.New("no state change waiting")
@@ -54,10 +75,30 @@ var (
 	ErrNoInterview           = errors.New("interview not found")
 	ErrInvalidInterviewState = errors.New("interview not in valid state")
 	ErrNoCandidacy           = errors.New("candidacy not found")
+	ErrIllegalTransition     = errors.New("illegal candidacy state change")
 	ErrInterviewerNotActive  = errors.New("interviewer is not in active state")
 	ErrNotAnInterviewer      = errors.New(
 		"user is not an interviewer for this interview",
 	)
+	ErrUnknownCompetency = errors.New(
+		"competency is not in the rubric of the opening",
+	)
+	ErrScorecardSubmitted = errors.New(
+		"scorecard already submitted by the interviewer",
+	)
+	ErrNoAvailability                = errors.New("availability not found")
+	ErrNoSchedulingRequest           = errors.New("scheduling request not found")
+	ErrInvalidSchedulingRequestState = errors.New(
+		"scheduling request not in valid state",
+	)
+	ErrSlotUnavailable         = errors.New("interview slot is not available")
+	ErrNotTakeHome             = errors.New("interview is not a take-home")
+	ErrNoTakeHomeAssignment    = errors.New("take-home assignment not found")
+	ErrNoTakeHomeFile          = errors.New("take-home file not found")
+	ErrTakeHomeNotOpen         = errors.New("take-home assignment not open")
+	ErrTooManySubmissions      = errors.New("too many take-home submissions")
+	ErrNoCalDAVConnection      = errors.New("caldav connection not found")
+	ErrNoCalendarFeed          = errors.New("calendar feed not found")
 	ErrInvalidPaginationKey    = fmt.Errorf("invalid pagination key")
 	ErrNoWorkHistory           = errors.New("work history not found")
 	ErrDuplicateOfficialEmail  = errors.New("official email already exists")
@@ -122,4 +163,31 @@ var (
 	)
 	ErrInvalidParentComment   = errors.New("invalid parent comment")
 	ErrMaxCommentDepthReached = errors.New("maximum comment depth reached")
+
+	// Sourcing related errors
+	ErrOpeningNotActive = errors.New("opening is not active")
+	ErrAlreadyInvited   = errors.New(
+		"hub user already invited to or applied for the opening",
+	)
+	ErrInvitationCapReached = errors.New(
+		"hub user got too many invitations lately",
+	)
+	ErrInvitationQuotaExceeded = errors.New(
+		"employer sent too many invitations lately",
+	)
+
+	// Saved search related errors
+	ErrNoSavedSearch        = errors.New("saved search not found")
+	ErrDupSavedSearchName   = errors.New("saved search name already in use")
+	ErrTooManySavedSearches = errors.New("too many saved searches")
+
+	// Bookmark related errors
+	ErrNoBookmarkTarget = errors.New("item to bookmark not found")
+
+	// Candidacy comment related errors
+	ErrNoCandidacyComment = errors.New("candidacy comment not found")
+	ErrCommentNotEditable = errors.New(
+		"candidacy comment is retracted or past its edit window",
+	)
+	ErrNoCommentAttachment = errors.New("comment attachment not found")
 )
@@ -1,5 +1,13 @@
 package db
 
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
 type AddInterviewerRequest struct {
 	InterviewID string
 
@@ -19,3 +27,85 @@ type RemoveInterviewerRequest struct {
 
 	// TODO: Add EmailNotification for watchers ?
 }
+
+// InterviewParticipants are the people who are notified of the changes to
+// an Interview
+type InterviewParticipants struct {
+	InterviewType  common.InterviewType
+	InterviewState common.InterviewState
+	StartTime      time.Time
+	EndTime        time.Time
+	Description    string
+	ICalSequence   int
+
+	// Empty for the interviews without a meeting room
+	MeetingProvider employer.MeetingProvider
+	MeetingURL      string
+
+	CandidacyID    string
+	CandidateName  string
+	CandidateEmail string
+	CompanyName    string
+	OpeningTitle   string
+
+	InterviewerEmails []string
+
+	// The hiring manager, the recruiter and the watchers of the Opening
+	WatcherEmails []string
+}
+
+// CalendarFeed is the list of the upcoming interviews of the owner of a
+// calendar feed. The email addresses of the participants are not populated.
+type CalendarFeed struct {
+	// OrgUserAuthorType or HubUserAuthorType
+	OwnerType  string
+	Interviews []CalendarFeedInterview
+}
+
+type CalendarFeedInterview struct {
+	InterviewID string
+	InterviewParticipants
+}
+
+type RescheduleInterviewReq struct {
+	employer.RescheduleInterviewRequest
+	Emails []Email
+
+	// The link to the meeting room after the reschedule
+	RoomURL string
+}
+
+type CancelInterviewReq struct {
+	employer.CancelInterviewRequest
+	Emails []Email
+}
+
+type RequestInterviewRescheduleReq struct {
+	hub.HubRSVPInterviewRequest
+	Email Email
+}
+
+type CreateSchedulingRequestReq struct {
+	employer.CreateSchedulingRequestRequest
+	SchedulingRequestID string
+	CandidateEmail      Email
+
+	// Empty for the interviews without a meeting room
+	MeetingProvider employer.MeetingProvider
+}
+
+// SchedulingRequestParticipants are the people who are notified when the
+// candidate books a slot of a scheduling request. The StartTime, EndTime and
+// ICalSequence of the InterviewParticipants are not populated and the
+// MeetingURL is the link pasted for the manual meeting provider, if any.
+type SchedulingRequestParticipants struct {
+	InterviewParticipants
+	DurationMinutes int
+}
+
+type BookInterviewSlotReq struct {
+	hub.BookInterviewSlotRequest
+	InterviewID string
+	Emails      []Email
+	RoomURL     string
+}
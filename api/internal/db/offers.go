@@ -0,0 +1,110 @@
+package db
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// OfferDocument is a document of an offer that is already uploaded to the
+// object storage
+type OfferDocument struct {
+	Filename string
+	FilePath string
+}
+
+// OfferDetails is a version of an offer. The offer letter and the
+// attachments are uploaded by hermione before the offer is saved.
+type OfferDetails struct {
+	Compensation    common.OfferCompensation
+	StartDate       string
+	ExpiresAt       time.Time
+	OfferLetterPath string
+	Attachments     []OfferDocument
+}
+
+type ReviseOfferReq struct {
+	CandidacyID string
+	Offer       OfferDetails
+	Comment     string
+	Email       Email
+}
+
+type RescindOfferReq struct {
+	CandidacyID string
+	Reason      string
+	Email       Email
+}
+
+type RespondToOfferReq struct {
+	Request hub.RespondToOfferRequest
+
+	// Email to the recruiter of the opening about the response
+	Email Email
+}
+
+// ExpiredOffer is a PENDING offer whose expires_at has passed
+type ExpiredOffer struct {
+	CandidacyID    string
+	Version        int
+	CandidateName  string
+	CandidateEmail string
+	CompanyName    string
+	OpeningTitle   string
+}
+
+type ExpireOffersReq struct {
+	Offers []ExpiredOffer
+
+	// Emails to the candidates about the expiry of their Offers
+	Emails []Email
+}
+
+// OfferForSignature is the PENDING offer of a candidacy that is not sent
+// for signature yet
+type OfferForSignature struct {
+	Version         int
+	OfferLetterPath string
+}
+
+type SaveOfferSignatureReq struct {
+	CandidacyID string
+	Version     int
+	Provider    string
+	EnvelopeID  string
+}
+
+// DueOfferSignature is an offer sent for signature whose status should be
+// checked with the provider
+type DueOfferSignature struct {
+	CandidacyID    string
+	Version        int
+	EnvelopeID     string
+	CandidateName  string
+	OpeningTitle   string
+	RecruiterEmail string
+}
+
+type DeferOfferSignaturePollReq struct {
+	CandidacyID string
+	Version     int
+	NextPollAt  time.Time
+}
+
+type CompleteOfferSignatureReq struct {
+	CandidacyID        string
+	Version            int
+	SignedDocumentPath string
+
+	// Email to the recruiter of the opening about the signature
+	Email Email
+}
+
+type DeclineOfferSignatureReq struct {
+	CandidacyID string
+	Version     int
+
+	// Email to the recruiter of the opening about the declined signature
+	Email Email
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+type SubmitOpeningForApprovalReq struct {
+	OpeningID string
+
+	// Sent to the approver of the first step of the approval chain
+	ApprovalEmail Email
+}
+
+type OpeningApprovals struct {
+	// The OrgUser who submitted the opening for approval
+	RequestedBy employer.OrgUserShort
+	Steps       []employer.OpeningApprovalStep
+}
+
+type ApproveOpeningReq struct {
+	OpeningID  string
+	StepNumber int
+	Comment    *string
+
+	// Sent to the approver of the next step, nil if this is the last step
+	NextApprovalEmail *Email
+}
+
+type RejectOpeningReq struct {
+	OpeningID  string
+	StepNumber int
+	Comment    string
+
+	// Sent to the OrgUser who submitted the opening for approval
+	RejectionEmail Email
+}
+
+// OpeningScheduleTransition is a state change of an Opening that is due as
+// per its publish_at, close_at or max_applications
+type OpeningScheduleTransition struct {
+	EmployerID   uuid.UUID
+	OpeningID    string
+	OpeningTitle string
+	FromState    common.OpeningState
+	ToState      common.OpeningState
+
+	// Email addresses of the watchers of the opening
+	Watchers []string
+}
+
+// ExpiredApplication is an application that has stayed in the APPLIED state
+// for longer than the application_expiry_days of the employer
+type ExpiredApplication struct {
+	EmployerID    uuid.UUID
+	ApplicationID string
+	OpeningID     string
+	OpeningTitle  string
+
+	CompanyName   string
+	PrimaryDomain string
+
+	HubUserFullName string
+	HubUserEmail    string
+
+	// Email addresses of the watchers of the opening
+	Watchers []string
+}
+
+type OpeningScheduleChanges struct {
+	Transitions         []OpeningScheduleTransition
+	ExpiredApplications []ExpiredApplication
+}
+
+type ApplyOpeningScheduleChangesReq struct {
+	Changes OpeningScheduleChanges
+
+	// Emails to the candidates and the watchers about the Changes
+	Emails []Email
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// RecommendationProfile is what the Openings are matched against, to
+// recommend them to a HubUser
+type RecommendationProfile struct {
+	HubUserID           uuid.UUID
+	ResidentCountryCode string
+	ResidentCity        string
+	ShortBio            string
+	LongBio             string
+
+	// The titles and the descriptions of the work history
+	WorkHistoryTitles       []string
+	WorkHistoryDescriptions []string
+
+	// The titles of the Openings that the HubUser applied to
+	AppliedOpeningTitles []string
+
+	// The display names of the tags of the posts of the HubUser and of the
+	// Openings that the HubUser applied to, except those of the Openings
+	// that the HubUser is not interested in
+	Tags []string
+}
+
+// RecommendationCandidate is an ACTIVE Opening that may be recommended to a
+// HubUser. It is not one that the HubUser applied to or gave a feedback on.
+type RecommendationCandidate struct {
+	EmployerID uuid.UUID
+	OpeningID  string
+	Title      string
+	JD         string
+
+	// The display names of the tags of the Opening
+	Tags []string
+
+	// Whether the Opening has a location in, or is open to remote workers
+	// from, the country of the HubUser
+	InResidentCountry bool
+	// Whether the Opening has a location in the city of the HubUser
+	InResidentCity bool
+}
+
+type OpeningRecommendation struct {
+	EmployerID uuid.UUID
+	OpeningID  string
+	Score      int
+	Reasons    []hub.RecommendationReason
+}
+
+type SaveRecommendationsReq struct {
+	HubUserID uuid.UUID
+
+	// Replace the earlier recommendations of the HubUser
+	Recommendations []OpeningRecommendation
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+type SaveSearchReq struct {
+	SavedSearchID    string
+	UnsubscribeToken string
+	Name             string
+	Search           hub.FindHubOpeningsRequest
+	Frequency        hub.SavedSearchFrequency
+}
+
+// SavedSearchEvaluation is a saved search that is to be matched against the
+// Openings that became ACTIVE after it was last matched
+type SavedSearchEvaluation struct {
+	SavedSearchID string
+	HubUserID     uuid.UUID
+	Search        hub.FindHubOpeningsRequest
+	EvaluatedAt   time.Time
+}
+
+// SavedSearchAlert is a saved search whose matches are due to be alerted to
+// the HubUser, as per its frequency
+type SavedSearchAlert struct {
+	SavedSearchID    string
+	Name             string
+	Frequency        hub.SavedSearchFrequency
+	UnsubscribeToken string
+	HubUserFullName  string
+	HubUserEmail     string
+
+	// The latest of the matches that are alerted. Those that match later
+	// are left for the next alert.
+	LastMatchedAt time.Time
+	// The number of the matched Openings that are still ACTIVE, of which
+	// the latest few are in Openings
+	MatchCount int
+	Openings   []SavedSearchAlertOpening
+}
+
+type SavedSearchAlertOpening struct {
+	OpeningID     string
+	CompanyDomain string
+	CompanyName   string
+	JobTitle      string
+}
+
+type SaveSavedSearchAlertReq struct {
+	SavedSearchID string
+	LastMatchedAt time.Time
+
+	// nil when none of the matched Openings is ACTIVE anymore
+	Email *Email
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+// SuggestionOpening is an ACTIVE Opening that the discoverable HubUsers are
+// matched against, to suggest them to the employer
+type SuggestionOpening struct {
+	EmployerID uuid.UUID
+	OpeningID  string
+	Title      string
+	JD         string
+	YoeMin     int
+	YoeMax     int
+
+	// The display names of the tags of the Opening
+	Tags []string
+}
+
+// SuggestionCandidate is a discoverable HubUser who may be suggested for an
+// Opening. It is not one who applied to the Opening.
+type SuggestionCandidate struct {
+	Profile RecommendationProfile
+
+	// The years since the start of the first job in the work history, nil
+	// without any work history
+	Yoe *int
+
+	// Whether the HubUser lives in a country that the Opening has a
+	// location in, or is open to remote workers from
+	InOpeningCountry bool
+	// Whether the HubUser lives in a city that the Opening has a location in
+	InOpeningCity bool
+}
+
+type CandidateSuggestion struct {
+	HubUserID uuid.UUID
+	Score     int
+	Yoe       *int
+	Reasons   []employer.CandidateSuggestionReason
+}
+
+type SaveCandidateSuggestionsReq struct {
+	EmployerID uuid.UUID
+	OpeningID  string
+
+	// Replace the earlier suggestions for the Opening
+	Suggestions []CandidateSuggestion
+}
+
+type OpeningInvitationMailInfo struct {
+	Employer EmployerMailInfo
+	HubUser  HubUserMailInfo
+	Opening  OpeningMailInfo
+}
+
+type InviteToApplyReq struct {
+	InvitationID string
+	OpeningID    string
+	HubUserID    uuid.UUID
+	Message      *string
+
+	// Sent to the HubUser
+	Email Email
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// TakeHomeFile is a file of the brief of a take-home assignment or a
+// submission of the candidate, stored in S3 under the FilePath
+type TakeHomeFile struct {
+	Filename    string
+	FilePath    string
+	ContentType string
+	SizeBytes   int64
+}
+
+type SetTakeHomeAssignmentReq struct {
+	InterviewID          string
+	Brief                string
+	Files                []TakeHomeFile
+	TimeLimitMinutes     *int
+	LateSubmissionPolicy common.LateSubmissionPolicy
+	GracePeriodMinutes   int
+
+	// Email to the candidate about the assignment
+	Email Email
+}
+
+type SubmitTakeHomeReq struct {
+	InterviewID string
+	Submission  TakeHomeFile
+
+	// Email to the interviewers and the watchers about the submission
+	Email Email
+}
+
+// DueTakeHomeReminder is a take-home assignment that is not submitted yet
+// and whose deadline is near
+type DueTakeHomeReminder struct {
+	InterviewID    string
+	CandidacyID    string
+	CandidateName  string
+	CandidateEmail string
+	CompanyName    string
+	OpeningTitle   string
+	Deadline       time.Time
+}
+
+type TakeHomeRemindersReq struct {
+	InterviewIDs []string
+
+	// Emails to the candidates about the deadlines
+	Emails []Email
+}
@@ -0,0 +1,33 @@
+package esign
+
+import (
+	"context"
+	"net/http"
+)
+
+// disabled is the provider when none is configured. No offer can be sent for
+// signature, so that an offer is never taken as signed without a real
+// signature.
+type disabled struct{}
+
+func (disabled) Name() string {
+	return DisabledProvider
+}
+
+func (disabled) SendForSignature(
+	ctx context.Context,
+	req SignatureRequest,
+) (string, error) {
+	return "", ErrESignDisabled
+}
+
+func (disabled) GetStatus(
+	ctx context.Context,
+	envelopeID string,
+) (EnvelopeStatus, error) {
+	return EnvelopeStatus{}, ErrUnknownEnvelope
+}
+
+func (disabled) ParseWebhook(r *http.Request) (string, error) {
+	return "", ErrESignDisabled
+}
@@ -0,0 +1,85 @@
+package esign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+)
+
+const (
+	// List of supported providers. The provider is chosen by the
+	// ESIGN_PROVIDER environment variable. The offers cannot be sent for
+	// signature if it is not set.
+	DisabledProvider = "disabled"
+
+	// The stub signs every envelope by itself and must be chosen explicitly,
+	// only in development and in tests
+	StubProvider = "stub"
+)
+
+var (
+	ErrUnknownEnvelope = errors.New("unknown envelope")
+	ErrESignDisabled   = errors.New("e-signature provider not configured")
+)
+
+type EnvelopeState string
+
+const (
+	// The signer has not acted on the envelope yet
+	PendingEnvelope  EnvelopeState = "PENDING"
+	SignedEnvelope   EnvelopeState = "SIGNED"
+	DeclinedEnvelope EnvelopeState = "DECLINED"
+)
+
+type SignatureRequest struct {
+	// The PDF document to be signed
+	Document []byte
+
+	SignerName  string
+	SignerEmail string
+
+	// Subject of the email sent by the provider to the signer
+	Subject string
+}
+
+type EnvelopeStatus struct {
+	State EnvelopeState
+
+	// The signed PDF document. Present only for the SIGNED state.
+	SignedDocument []byte
+}
+
+// ESignProvider is implemented by each of the e-signature services
+// (Docusign, Zohosign, etc.) that an offer letter can be sent through
+type ESignProvider interface {
+	Name() string
+
+	// SendForSignature creates an envelope for the document and returns the
+	// id of the envelope with the provider
+	SendForSignature(ctx context.Context, req SignatureRequest) (string, error)
+
+	// GetStatus returns ErrUnknownEnvelope if the provider does not know of
+	// the envelope
+	GetStatus(ctx context.Context, envelopeID string) (EnvelopeStatus, error)
+
+	// ParseWebhook returns the id of the envelope that a webhook call from
+	// the provider is about. The webhook is only a hint that the status
+	// may have changed and the status is always fetched with GetStatus.
+	ParseWebhook(r *http.Request) (string, error)
+}
+
+func NewESignProvider(name string, log util.Logger) (ESignProvider, error) {
+	switch name {
+	case "", DisabledProvider:
+		log.Inf("no e-signature provider, sending for signature is disabled")
+		return disabled{}, nil
+	case StubProvider:
+		log.Inf("using the stub e-signature provider, which signs by itself")
+		return &stub{log: log}, nil
+	}
+
+	return nil, fmt.Errorf("unknown e-signature provider %q", name)
+}
@@ -0,0 +1,65 @@
+package esign
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+)
+
+var testLogger = util.Logger{
+	Log: slog.New(slog.NewTextHandler(io.Discard, nil)),
+}
+
+func TestNewESignProvider(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		provider string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "unset", provider: "", wantName: DisabledProvider},
+		{name: "disabled", provider: "disabled", wantName: DisabledProvider},
+		{name: "stub", provider: "stub", wantName: StubProvider},
+		{name: "unknown", provider: "docusign-typo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewESignProvider(tt.provider, testLogger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewESignProvider() error = %v, want error %v",
+					err, tt.wantErr)
+			}
+			if err == nil && provider.Name() != tt.wantName {
+				t.Errorf("Name() = %q, want %q", provider.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+// Without a configured provider, no offer is ever taken as signed
+func TestDisabledProviderNeverSigns(t *testing.T) {
+	provider, err := NewESignProvider("", testLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = provider.SendForSignature(
+		context.Background(),
+		SignatureRequest{SignerEmail: "candidate@example.com"},
+	)
+	if !errors.Is(err, ErrESignDisabled) {
+		t.Errorf("SendForSignature() error = %v, want %v",
+			err, ErrESignDisabled)
+	}
+
+	_, err = provider.GetStatus(context.Background(), "stub-abcdefgh")
+	if !errors.Is(err, ErrUnknownEnvelope) {
+		t.Errorf("GetStatus() error = %v, want %v", err, ErrUnknownEnvelope)
+	}
+}
@@ -0,0 +1,88 @@
+package esign
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+)
+
+const (
+	stubEnvelopePrefix = "stub-"
+	stubDeclineSuffix  = "-decline"
+
+	// Signers whose email address begins with this, decline to sign
+	StubDeclinePrefix = "decline"
+)
+
+// stub is a provider that simulates signing without any external service,
+// for use in development and in tests. Envelopes are signed as soon as they
+// are sent. As hermione sends the envelopes and granger checks their status,
+// the stub keeps no state and everything needed is encoded in the envelope
+// id itself.
+type stub struct {
+	log util.Logger
+}
+
+func (s *stub) Name() string {
+	return StubProvider
+}
+
+func (s *stub) SendForSignature(
+	ctx context.Context,
+	req SignatureRequest,
+) (string, error) {
+	envelopeID := stubEnvelopePrefix + util.RandomUniqueID(8)
+	if strings.HasPrefix(strings.ToLower(req.SignerEmail), StubDeclinePrefix) {
+		envelopeID += stubDeclineSuffix
+	}
+
+	s.log.Dbg("stub envelope sent", "envelope_id", envelopeID)
+	return envelopeID, nil
+}
+
+func (s *stub) GetStatus(
+	ctx context.Context,
+	envelopeID string,
+) (EnvelopeStatus, error) {
+	if !strings.HasPrefix(envelopeID, stubEnvelopePrefix) {
+		return EnvelopeStatus{}, ErrUnknownEnvelope
+	}
+
+	if strings.HasSuffix(envelopeID, stubDeclineSuffix) {
+		return EnvelopeStatus{State: DeclinedEnvelope}, nil
+	}
+
+	return EnvelopeStatus{
+		State: SignedEnvelope,
+		SignedDocument: util.TextPDF([]string{
+			"Certificate of Signature",
+			"",
+			"Envelope: " + envelopeID,
+			"Signed at: " + time.Now().UTC().Format(time.RFC1123),
+			"",
+			"This document was signed with the stub e-signature provider",
+			"and is not legally binding.",
+		}),
+	}, nil
+}
+
+func (s *stub) ParseWebhook(r *http.Request) (string, error) {
+	var body struct {
+		EnvelopeID string `json:"envelope_id"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode webhook: %w", err)
+	}
+
+	if !strings.HasPrefix(body.EnvelopeID, stubEnvelopePrefix) {
+		return "", ErrUnknownEnvelope
+	}
+
+	return body.EnvelopeID, nil
+}
@@ -0,0 +1,169 @@
+package granger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// alertSavedSearches matches the saved searches of the HubUsers against the
+// newly ACTIVE Openings and sends the alerts of the matches, as often as
+// each of the saved searches asks for
+func (g *Granger) alertSavedSearches(quit <-chan struct{}) {
+	g.log.Dbg("Starting alertSavedSearches job")
+	defer g.log.Dbg("alertSavedSearches job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.AlertSavedSearchesInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("alertSavedSearches received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			err := g.evaluateSavedSearches()
+			if err != nil {
+				g.log.Err("failed to evaluate saved searches", "error", err)
+			}
+
+			err = g.sendSavedSearchAlerts()
+			if err != nil {
+				g.log.Err("failed to send saved search alerts", "error", err)
+			}
+		}
+	}
+}
+
+func (g *Granger) evaluateSavedSearches() error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	searches, err := g.db.GetSavedSearchesToEvaluate(
+		ctx,
+		vetchi.MaxSavedSearchEvaluationsPerBatch,
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	if len(searches) == 0 {
+		return nil
+	}
+	g.log.Dbg("saved searches to evaluate", "count", len(searches))
+
+	for _, search := range searches {
+		ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+		err := g.db.EvaluateSavedSearch(ctx, search)
+		cancel()
+		if err != nil {
+			return fmt.Errorf(
+				"failed to evaluate saved search %s: %w",
+				search.SavedSearchID,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (g *Granger) sendSavedSearchAlerts() error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	alerts, err := g.db.GetDueSavedSearchAlerts(
+		ctx,
+		vetchi.MaxSavedSearchAlertsPerBatch,
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	if len(alerts) == 0 {
+		return nil
+	}
+	g.log.Dbg("saved search alerts due", "count", len(alerts))
+
+	for _, alert := range alerts {
+		req := db.SaveSavedSearchAlertReq{
+			SavedSearchID: alert.SavedSearchID,
+			LastMatchedAt: alert.LastMatchedAt,
+		}
+
+		// The matches whose Openings are not ACTIVE anymore are marked as
+		// alerted, without an email
+		if len(alert.Openings) > 0 {
+			email, err := g.savedSearchAlertEmail(alert)
+			if err != nil {
+				return err
+			}
+			req.Email = &email
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+		err := g.db.SaveSavedSearchAlert(ctx, req)
+		cancel()
+		if err != nil {
+			return fmt.Errorf(
+				"failed to save alert of saved search %s: %w",
+				alert.SavedSearchID,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (g *Granger) savedSearchAlertEmail(
+	alert db.SavedSearchAlert,
+) (db.Email, error) {
+	lines := make([]string, 0, len(alert.Openings)+1)
+	for _, opening := range alert.Openings {
+		lines = append(lines, fmt.Sprintf(
+			"%s at %s: %s",
+			opening.JobTitle,
+			opening.CompanyName,
+			g.hubBaseURL+"/org/"+opening.CompanyDomain+
+				"/opening/"+opening.OpeningID,
+		))
+	}
+	if alert.MatchCount > len(alert.Openings) {
+		lines = append(lines, fmt.Sprintf(
+			"and %d more",
+			alert.MatchCount-len(alert.Openings),
+		))
+	}
+
+	frequency := "as soon as new openings match"
+	switch alert.Frequency {
+	case hub.DailySavedSearch:
+		frequency = "daily"
+	case hub.WeeklySavedSearch:
+		frequency = "weekly"
+	}
+
+	return g.hedwig.GenerateEmail(hedwig.GenerateEmailReq{
+		TemplateName: hedwig.SavedSearchAlert,
+		Args: map[string]string{
+			"HubUserFullName": alert.HubUserFullName,
+			"SearchName":      alert.Name,
+			"MatchCount":      strconv.Itoa(alert.MatchCount),
+			"Openings":        "- " + strings.Join(lines, "\n- "),
+			"Frequency":       frequency,
+			"UnsubscribeURL": g.hubBaseURL +
+				"/saved-searches/unsubscribe?token=" +
+				alert.UnsubscribeToken,
+		},
+		EmailFrom: vetchi.EmailFrom,
+		EmailTo:   []string{alert.HubUserEmail},
+		Subject:   "New openings for your saved search " + alert.Name,
+	})
+}
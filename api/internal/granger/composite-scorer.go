@@ -0,0 +1,140 @@
+package granger
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+const (
+	compositeModelName = "Vetchium-Composite-Match"
+
+	// A single verified employer is half the evidence of a work history
+	verifiedEmployersForFullScore = 2
+)
+
+// compositeScorer scores the whole of the applicant and not just the
+// resume, as a resume can be written to match a JD. The resume, the long
+// bio, the certifications and the education are each scored by their BM25
+// similarity to the JD, the same way as the lexical scorer does. The work
+// history counts only as much as it is verified by the official emails.
+// The parts are weighted as per the settings of the employer.
+type compositeScorer struct {
+	resumes *lexicalScorer
+}
+
+func newCompositeScorer(resumes *lexicalScorer) *compositeScorer {
+	return &compositeScorer{resumes: resumes}
+}
+
+func (s *compositeScorer) Models() []string {
+	return []string{compositeModelName}
+}
+
+func (s *compositeScorer) Score(
+	ctx context.Context,
+	batch db.UnscoredApplicationBatch,
+) ([]db.ApplicationScore, error) {
+	query := termFrequencies(lexicalTerms(batch.JD))
+
+	scores := make([]db.ApplicationScore, 0, len(batch.Applications))
+	for _, app := range batch.Applications {
+		resume, err := s.resumes.resumeText(ctx, app.ResumeSHA)
+		if err != nil {
+			if !errors.Is(err, errUnreadableResume) {
+				return nil, err
+			}
+			// The rest of the profile is still worth scoring
+			s.resumes.log.Dbg("unreadable resume", "app_id", app.ApplicationID)
+		}
+
+		score, explanation := compositeScore(
+			query,
+			resume,
+			app.Profile,
+			batch.MatchWeights,
+		)
+		scores = append(scores, db.ApplicationScore{
+			ApplicationID: app.ApplicationID,
+			ModelName:     compositeModelName,
+			Score:         score,
+			Explanation:   explanation,
+		})
+	}
+
+	return scores, nil
+}
+
+// compositeScore is the weighted average of the scores of the parts of the
+// applicant, explained by the scores of the parts and by the terms of the
+// JD that are found anywhere in them
+func compositeScore(
+	query map[string]int,
+	resume string,
+	profile db.CandidateProfile,
+	weights employer.MatchScoreWeights,
+) (int, *common.ScoreExplanation) {
+	certifications := strings.Join(profile.Certifications, "\n")
+	education := strings.Join(profile.Education, "\n")
+
+	workHistory := 100 * min(
+		len(profile.VerifiedEmployerDomains),
+		verifiedEmployersForFullScore,
+	) / verifiedEmployersForFullScore
+
+	parts := []struct {
+		section string
+		score   int
+		weight  int
+	}{
+		{"resume", bm25Score(query, lexicalTerms(resume)), weights.Resume},
+		{
+			"long_bio",
+			bm25Score(query, lexicalTerms(profile.LongBio)),
+			weights.LongBio,
+		},
+		{
+			"certifications",
+			bm25Score(query, lexicalTerms(certifications)),
+			weights.Certifications,
+		},
+		{
+			"education",
+			bm25Score(query, lexicalTerms(education)),
+			weights.Education,
+		},
+		{"verified_work_history", workHistory, weights.VerifiedWorkHistory},
+	}
+
+	explanation := &common.ScoreExplanation{}
+	var weighted, totalWeight int
+	for _, part := range parts {
+		explanation.SectionScores = append(
+			explanation.SectionScores,
+			common.SectionScore{Section: part.section, Score: part.score},
+		)
+		weighted += part.score * part.weight
+		totalWeight += part.weight
+	}
+
+	everything := strings.Join(
+		[]string{resume, profile.LongBio, certifications, education},
+		"\n",
+	)
+	explanation.MatchedTerms, explanation.MissingTerms = explainTerms(
+		query,
+		termFrequencies(lexicalTerms(everything)),
+	)
+
+	if totalWeight == 0 {
+		// Not allowed by the settings, but the resume is the fallback
+		return parts[0].score, explanation
+	}
+	return int(math.Round(float64(weighted) / float64(totalWeight))),
+		explanation
+}
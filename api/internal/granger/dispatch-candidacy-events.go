@@ -0,0 +1,147 @@
+package granger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+)
+
+// candidacyHook is run for every state change of every candidacy, at least
+// once. The emails returned by the hooks are queued along with marking the
+// event as dispatched. A hook that fails gets the event again in the next
+// run, so a hook with side effects outside of the database should be
+// idempotent on the ID of the event.
+type candidacyHook func(context.Context, db.CandidacyEvent) ([]db.Email, error)
+
+func (g *Granger) candidacyHooks() []candidacyHook {
+	return []candidacyHook{
+		g.notifyWatchers,
+	}
+}
+
+// dispatchCandidacyEvents runs the hooks for the state changes of the
+// candidacies, in the order in which the changes happened
+func (g *Granger) dispatchCandidacyEvents(quit <-chan struct{}) {
+	g.log.Dbg("Starting dispatchCandidacyEvents job")
+	defer g.log.Dbg("dispatchCandidacyEvents job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.DispatchCandidacyEventsInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("dispatchCandidacyEvents received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+			err := g.processCandidacyEvents(ctx)
+			cancel()
+			if err != nil {
+				g.log.Err("failed to dispatch candidacy events", "error", err)
+			}
+		}
+	}
+}
+
+func (g *Granger) processCandidacyEvents(ctx context.Context) error {
+	events, err := g.db.GetUndispatchedCandidacyEvents(
+		ctx,
+		vetchi.MaxCandidacyEventsPerBatch,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+	g.log.Dbg("undispatched candidacy events", "count", len(events))
+
+	// A failed event holds back the later events of its candidacy, so that
+	// the hooks never see the changes of a candidacy out of order
+	failed := make(map[string]bool)
+	for _, event := range events {
+		if failed[event.CandidacyID] {
+			continue
+		}
+
+		err := g.dispatchCandidacyEvent(ctx, event)
+		if err != nil {
+			g.log.Err(
+				"failed to dispatch candidacy event",
+				"event_id", event.ID,
+				"error", err,
+			)
+			failed[event.CandidacyID] = true
+		}
+	}
+
+	return nil
+}
+
+func (g *Granger) dispatchCandidacyEvent(
+	ctx context.Context,
+	event db.CandidacyEvent,
+) error {
+	req := db.DispatchCandidacyEventReq{EventID: event.ID}
+	for _, hook := range g.candidacyHooks() {
+		emails, err := hook(ctx, event)
+		if err != nil {
+			return err
+		}
+		req.Emails = append(req.Emails, emails...)
+	}
+
+	err := g.db.DispatchCandidacyEvent(ctx, req)
+	if err != nil && !errors.Is(err, db.ErrStateMismatch) {
+		return err
+	}
+
+	return nil
+}
+
+// notifyWatchers lets the hiring team know of every state change of the
+// candidacy, whether made by an org user, the candidate or the system, like
+// the expiry of an offer. The org user who made the change is not notified.
+func (g *Granger) notifyWatchers(
+	ctx context.Context,
+	event db.CandidacyEvent,
+) ([]db.Email, error) {
+	// The creation of a candidacy is not a change of its state
+	if event.FromState == nil || len(event.WatcherEmails) == 0 {
+		return nil, nil
+	}
+
+	reason := ""
+	if event.Reason != nil {
+		reason = *event.Reason
+	}
+
+	email, err := g.hedwig.GenerateEmail(hedwig.GenerateEmailReq{
+		TemplateName: hedwig.CandidacyStateChanged,
+		Args: map[string]string{
+			"CandidateName": event.CandidateName,
+			"OpeningTitle":  event.OpeningTitle,
+			"FromState":     string(*event.FromState),
+			"ToState":       string(event.ToState),
+			"Reason":        reason,
+			"CandidacyURL": g.employerBaseURL + "/candidacy/" +
+				event.CandidacyID,
+		},
+		EmailFrom: vetchi.EmailFrom,
+		EmailTo:   event.WatcherEmails,
+		Subject: "Candidacy of " + event.CandidateName + " for " +
+			event.OpeningTitle + " is now " + string(event.ToState),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []db.Email{email}, nil
+}
@@ -0,0 +1,72 @@
+package granger
+
+import (
+	"context"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+)
+
+// expireOffers expires the offers that are not responded to by their
+// expires_at and moves their candidacies to CANDIDATE_NOT_RESPONDING
+func (g *Granger) expireOffers(quit <-chan struct{}) {
+	g.log.Dbg("Starting expireOffers job")
+	defer g.log.Dbg("expireOffers job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.ExpireOffersInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("expireOffers received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+			err := g.processExpiredOffers(ctx)
+			cancel()
+			if err != nil {
+				g.log.Err("failed to expire offers", "error", err)
+			}
+		}
+	}
+}
+
+func (g *Granger) processExpiredOffers(ctx context.Context) error {
+	offers, err := g.db.GetExpiredOffers(ctx, vetchi.MaxExpiredOffersPerBatch)
+	if err != nil {
+		return err
+	}
+
+	if len(offers) == 0 {
+		return nil
+	}
+	g.log.Dbg("expired offers", "count", len(offers))
+
+	var emails []db.Email
+	for _, offer := range offers {
+		email, err := g.hedwig.GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.OfferExpired,
+			Args: map[string]string{
+				"CandidateName": offer.CandidateName,
+				"CompanyName":   offer.CompanyName,
+				"OpeningTitle":  offer.OpeningTitle,
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{offer.CandidateEmail},
+			Subject:   "Offer from " + offer.CompanyName + " expired",
+		})
+		if err != nil {
+			return err
+		}
+		emails = append(emails, email)
+	}
+
+	return g.db.ExpireOffers(ctx, db.ExpireOffersReq{
+		Offers: offers,
+		Emails: emails,
+	})
+}
@@ -12,9 +12,16 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	ristretto "github.com/dgraph-io/ristretto/v2"
 	"github.com/go-playground/validator/v10"
+	"github.com/vetchium/vetchium/api/internal/caldav"
 	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/esign"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
 	"github.com/vetchium/vetchium/api/internal/postgres"
 	"github.com/vetchium/vetchium/api/internal/util"
 	"github.com/vetchium/vetchium/api/pkg/vetchi"
@@ -32,6 +39,9 @@ type Config struct {
 	Port             string `json:"port"               validate:"required,min=1,number"`
 	EmployerBaseURL  string `json:"employer_base_url"  validate:"required"`
 	HubBaseURL       string `json:"hub_base_url"       validate:"required"`
+
+	// The applications are scored by the sortinghat models too, if set
+	SortingHatURL string `json:"sortinghat_url"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -71,20 +81,36 @@ type smtpCredentials struct {
 	password string
 }
 
+type s3Credentials struct {
+	accessKey string
+	bucket    string
+	endpoint  string
+	region    string
+	secretKey string
+}
+
 type Granger struct {
 	// These are initialized from configmap
 	env              string
 	onboardTokenLife time.Duration
 	port             string
 	smtp             smtpCredentials
+	s3               s3Credentials
 
 	employerBaseURL string
 	hubBaseURL      string
 
+	calendarCredentialsKey []byte
+	caldavAllowedHosts     []string
+
 	// These are initialized programatically in NewGranger()
-	db  db.DB
-	log util.Logger
-	wg  sync.WaitGroup
+	db     db.DB
+	hedwig hedwig.Hedwig
+	esign  esign.ESignProvider
+	log    util.Logger
+
+	scorers []Scorer
+	wg      sync.WaitGroup
 
 	employerActiveJobCountCache *ristretto.Cache[string, uint32]
 	employerEmployeeCountCache  *ristretto.Cache[string, uint32]
@@ -134,11 +160,54 @@ func NewGranger() (*Granger, error) {
 		return nil, fmt.Errorf("SMTP_PASSWORD not set")
 	}
 
+	var s3c s3Credentials
+	s3c.accessKey = os.Getenv("S3_ACCESS_KEY")
+	if s3c.accessKey == "" {
+		return nil, fmt.Errorf("S3_ACCESS_KEY not set")
+	}
+	s3c.bucket = os.Getenv("S3_BUCKET")
+	if s3c.bucket == "" {
+		return nil, fmt.Errorf("S3_BUCKET not set")
+	}
+	s3c.endpoint = os.Getenv("S3_ENDPOINT")
+	if s3c.endpoint == "" {
+		return nil, fmt.Errorf("S3_ENDPOINT not set")
+	}
+	s3c.region = os.Getenv("S3_REGION")
+	if s3c.region == "" {
+		return nil, fmt.Errorf("S3_REGION not set")
+	}
+	s3c.secretKey = os.Getenv("S3_SECRET_KEY")
+	if s3c.secretKey == "" {
+		return nil, fmt.Errorf("S3_SECRET_KEY not set")
+	}
+
 	db, err := postgres.New(pgConnStr, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	hedwig, err := hedwig.NewHedwig(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize hedwig: %w", err)
+	}
+
+	esignProvider, err := esign.NewESignProvider(
+		os.Getenv("ESIGN_PROVIDER"),
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize esign: %w", err)
+	}
+
+	calendarCredentialsKey, err := util.ParseSecretKey(
+		os.Getenv("CALENDAR_CREDENTIALS_KEY"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("CALENDAR_CREDENTIALS_KEY: %w", err)
+	}
+	caldavAllowedHosts := caldav.SplitHosts(os.Getenv("CALDAV_ALLOWED_HOSTS"))
+
 	tokenDuration, err := time.ParseDuration(config.OnboardTokenLife)
 	if err != nil {
 		return nil, fmt.Errorf("OnboardTokenLife is invalid: %w", err)
@@ -168,17 +237,47 @@ func NewGranger() (*Granger, error) {
 		return nil, fmt.Errorf("employerEmployeeCountCache: %w", err)
 	}
 
+	lexical := newLexicalScorer(
+		s3.New(session.Must(session.NewSession(&aws.Config{
+			Credentials: credentials.NewStaticCredentials(
+				s3c.accessKey,
+				s3c.secretKey,
+				"",
+			),
+			Endpoint:         aws.String(s3c.endpoint),
+			Region:           aws.String(s3c.region),
+			S3ForcePathStyle: aws.Bool(true), // Required for MinIO
+		}))),
+		s3c.bucket,
+		logger,
+	)
+	scorers := []Scorer{lexical, newCompositeScorer(lexical)}
+	if config.SortingHatURL != "" {
+		scorers = append(
+			scorers,
+			newSortingHatScorer(config.SortingHatURL, s3c.bucket, logger),
+		)
+	}
+
 	g := &Granger{
 		env:              config.Env,
 		port:             fmt.Sprintf(":%s", config.Port),
 		smtp:             sc,
+		s3:               s3c,
 		onboardTokenLife: tokenDuration,
 
 		employerBaseURL: config.EmployerBaseURL,
 		hubBaseURL:      config.HubBaseURL,
 
-		db:  db,
-		log: logger,
+		calendarCredentialsKey: calendarCredentialsKey,
+		caldavAllowedHosts:     caldavAllowedHosts,
+
+		db:     db,
+		hedwig: hedwig,
+		esign:  esignProvider,
+		log:    logger,
+
+		scorers: scorers,
 
 		employerActiveJobCountCache: employerActiveJobCountCache,
 		employerEmployeeCountCache:  employerEmployeeCountCache,
@@ -213,6 +312,42 @@ func (g *Granger) Run() error {
 	timelineRefresherQuit := make(chan struct{})
 	go g.TimelineRefresher(timelineRefresherQuit)
 
+	g.wg.Add(1)
+	applyOpeningSchedulesQuit := make(chan struct{})
+	go g.applyOpeningSchedules(applyOpeningSchedulesQuit)
+
+	g.wg.Add(1)
+	expireOffersQuit := make(chan struct{})
+	go g.expireOffers(expireOffersQuit)
+
+	g.wg.Add(1)
+	pollOfferSignaturesQuit := make(chan struct{})
+	go g.pollOfferSignatures(pollOfferSignaturesQuit)
+
+	g.wg.Add(1)
+	syncCalDAVQuit := make(chan struct{})
+	go g.syncCalDAV(syncCalDAVQuit)
+
+	g.wg.Add(1)
+	remindTakeHomesQuit := make(chan struct{})
+	go g.remindTakeHomes(remindTakeHomesQuit)
+
+	g.wg.Add(1)
+	dispatchCandidacyEventsQuit := make(chan struct{})
+	go g.dispatchCandidacyEvents(dispatchCandidacyEventsQuit)
+
+	g.wg.Add(1)
+	refreshRecommendationsQuit := make(chan struct{})
+	go g.refreshRecommendations(refreshRecommendationsQuit)
+
+	g.wg.Add(1)
+	suggestCandidatesQuit := make(chan struct{})
+	go g.suggestCandidates(suggestCandidatesQuit)
+
+	g.wg.Add(1)
+	alertSavedSearchesQuit := make(chan struct{})
+	go g.alertSavedSearches(alertSavedSearchesQuit)
+
 	go func() {
 		http.HandleFunc(
 			"/internal/get-employer-counts",
@@ -236,6 +371,15 @@ func (g *Granger) Run() error {
 		close(pruneOfficialEmailCodesQuit)
 		close(mailSenderQuit)
 		close(scoreApplicationsQuit)
+		close(applyOpeningSchedulesQuit)
+		close(expireOffersQuit)
+		close(pollOfferSignaturesQuit)
+		close(syncCalDAVQuit)
+		close(remindTakeHomesQuit)
+		close(dispatchCandidacyEventsQuit)
+		close(refreshRecommendationsQuit)
+		close(suggestCandidatesQuit)
+		close(alertSavedSearchesQuit)
 	}()
 
 	g.wg.Wait()
@@ -0,0 +1,248 @@
+package granger
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// Only the heaviest terms of the JD are listed, as the rest are noise to
+// the hiring team
+const maxExplainedTerms = 20
+
+// explainLexicalScore tells which terms of the JD the resume has and lacks,
+// how each section of the resume matches the JD and how the years of
+// experience in the resume fit the range of the Opening
+func explainLexicalScore(
+	query map[string]int,
+	resume string,
+	yoeMin int,
+	yoeMax int,
+) *common.ScoreExplanation {
+	explanation := &common.ScoreExplanation{}
+	explanation.MatchedTerms, explanation.MissingTerms = explainTerms(
+		query,
+		termFrequencies(lexicalTerms(resume)),
+	)
+
+	sections := resumeSections(resume)
+	for _, section := range sections {
+		explanation.SectionScores = append(
+			explanation.SectionScores,
+			common.SectionScore{
+				Section: section.name,
+				Score:   bm25Score(query, lexicalTerms(section.text)),
+			},
+		)
+	}
+
+	// The years in the other sections, like those of the education, are
+	// not the experience
+	experience := resume
+	for _, section := range sections {
+		if section.name == experienceSection {
+			experience = section.text
+		}
+	}
+
+	explanation.YoeMatch = &common.YoeMatch{
+		ResumeYoe: resumeYoe(experience),
+		YoeMin:    yoeMin,
+		YoeMax:    yoeMax,
+		Fit:       common.UnknownYoeFit,
+	}
+	if yoe := explanation.YoeMatch.ResumeYoe; yoe != nil {
+		switch {
+		case *yoe < yoeMin:
+			explanation.YoeMatch.Fit = common.BelowYoeFit
+		case *yoe > yoeMax:
+			explanation.YoeMatch.Fit = common.AboveYoeFit
+		default:
+			explanation.YoeMatch.Fit = common.WithinYoeFit
+		}
+	}
+
+	return explanation
+}
+
+// explainTerms lists the heaviest terms of the query that are found and
+// not found in the document with the term frequencies tf
+func explainTerms(
+	query map[string]int,
+	tf map[string]int,
+) (matched []string, missing []string) {
+	matched, missing = []string{}, []string{}
+	for _, term := range termsByWeight(query) {
+		if tf[term] > 0 {
+			if len(matched) < maxExplainedTerms {
+				matched = append(matched, term)
+			}
+		} else if len(missing) < maxExplainedTerms {
+			missing = append(missing, term)
+		}
+	}
+	return matched, missing
+}
+
+// termsByWeight orders the terms of the query by their weight, the
+// heaviest first, and by name among the equally heavy
+func termsByWeight(query map[string]int) []string {
+	terms := make([]string, 0, len(query))
+	for term := range query {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		wi := termWeight(terms[i], query[terms[i]])
+		wj := termWeight(terms[j], query[terms[j]])
+		if wi != wj {
+			return wi > wj
+		}
+		return terms[i] < terms[j]
+	})
+	return terms
+}
+
+const experienceSection = "experience"
+
+// sectionHeadings are the usual headings of the resumes and the sections
+// that they begin
+var sectionHeadings = map[string]string{
+	"summary":                   "summary",
+	"professional summary":      "summary",
+	"career summary":            "summary",
+	"profile":                   "summary",
+	"professional profile":      "summary",
+	"objective":                 "summary",
+	"career objective":          "summary",
+	"about me":                  "summary",
+	"experience":                experienceSection,
+	"work experience":           experienceSection,
+	"professional experience":   experienceSection,
+	"relevant experience":       experienceSection,
+	"employment":                experienceSection,
+	"employment history":        experienceSection,
+	"work history":              experienceSection,
+	"career history":            experienceSection,
+	"skills":                    "skills",
+	"technical skills":          "skills",
+	"key skills":                "skills",
+	"core competencies":         "skills",
+	"technologies":              "skills",
+	"education":                 "education",
+	"academic background":       "education",
+	"academic qualifications":   "education",
+	"qualifications":            "education",
+	"projects":                  "projects",
+	"personal projects":         "projects",
+	"key projects":              "projects",
+	"certifications":            "certifications",
+	"certificates":              "certifications",
+	"licenses & certifications": "certifications",
+}
+
+type resumeSection struct {
+	name string
+	text string
+}
+
+// resumeSections splits the resume on its headings, which are the lines
+// that have nothing else on them. The text before the first heading, which
+// usually is the contact details, belongs to no section. The sections are
+// in the order of their first heading.
+func resumeSections(resume string) []resumeSection {
+	var sections []resumeSection
+	current := -1
+	for _, line := range strings.Split(resume, "\n") {
+		heading := strings.TrimRight(strings.TrimSpace(line), ":")
+		heading = strings.ToLower(strings.Join(strings.Fields(heading), " "))
+		if name, ok := sectionHeadings[heading]; ok {
+			current = -1
+			for i := range sections {
+				if sections[i].name == name {
+					current = i
+				}
+			}
+			if current < 0 {
+				sections = append(sections, resumeSection{name: name})
+				current = len(sections) - 1
+			}
+			continue
+		}
+
+		if current >= 0 {
+			sections[current].text += line + "\n"
+		}
+	}
+	return sections
+}
+
+var (
+	// Spans like "2018 - 2022", "Jan 2019 – Present" and "03/2017 to
+	// 11/2020"
+	yearSpanRegex = regexp.MustCompile(
+		`(?i)\b((?:19|20)\d{2})\s*(?:-|–|—|to)\s*(?:[a-z]{3,9}\.?\s+)?` +
+			`(?:\d{1,2}/)?((?:19|20)\d{2}|present|current|now|date)\b`,
+	)
+
+	// Claims like "8+ years of experience" and "5 yrs of industry
+	// experience"
+	yoeClaimRegex = regexp.MustCompile(
+		`(?i)\b(\d{1,2})\+?\s*(?:years?|yrs?)\s+(?:of\s+)?` +
+			`(?:[a-z-]+\s+){0,2}experience`,
+	)
+)
+
+// resumeYoe estimates the years of experience from the spans of years in
+// the text, counting the overlapping spans once. Without any spans, the
+// largest claim of the years of experience is taken. Nil if neither is
+// found.
+func resumeYoe(text string) *int {
+	thisYear := time.Now().Year()
+
+	type span struct{ from, to int }
+	var spans []span
+	for _, match := range yearSpanRegex.FindAllStringSubmatch(text, -1) {
+		from, _ := strconv.Atoi(match[1])
+		to, err := strconv.Atoi(match[2])
+		if err != nil {
+			// present, current and the like
+			to = thisYear
+		}
+		if from > to || to > thisYear {
+			continue
+		}
+		spans = append(spans, span{from, to})
+	}
+
+	if len(spans) > 0 {
+		sort.Slice(spans, func(i, j int) bool {
+			return spans[i].from < spans[j].from
+		})
+
+		yoe := 0
+		merged := spans[0]
+		for _, s := range spans[1:] {
+			if s.from <= merged.to {
+				merged.to = max(merged.to, s.to)
+				continue
+			}
+			yoe += merged.to - merged.from
+			merged = s
+		}
+		yoe += merged.to - merged.from
+		return &yoe
+	}
+
+	var claimed *int
+	for _, match := range yoeClaimRegex.FindAllStringSubmatch(text, -1) {
+		years, _ := strconv.Atoi(match[1])
+		if claimed == nil || years > *claimed {
+			claimed = &years
+		}
+	}
+	return claimed
+}
@@ -0,0 +1,312 @@
+package granger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+)
+
+const (
+	lexicalModelName = "Vetchium-Lexical-BM25"
+
+	// BM25 parameters. The average length is that of a typical resume in
+	// the terms, after the stop words are dropped.
+	bm25K1        = 1.2
+	bm25B         = 0.75
+	bm25AvgLength = 400
+
+	// The skills and the tools are what the JDs are really about, unlike
+	// the rest of their words
+	skillTermWeight = 3
+
+	// The resumes are limited to 10MB on upload
+	maxResumeSize = 10 * 1024 * 1024
+)
+
+// lexicalScorer scores the resumes by the BM25 similarity of their text to
+// the JD, without depending on any other service. The terms of the JD are
+// the query, weighted by their frequency in the JD and by whether they are
+// skills. The document frequencies of the terms are not used, as the score
+// of an application must not depend on which other applications happen to
+// be in its batch; the stop words, including the boilerplate of the JDs,
+// are dropped instead.
+type lexicalScorer struct {
+	s3     *s3.S3
+	bucket string
+	log    util.Logger
+}
+
+func newLexicalScorer(
+	s3Client *s3.S3,
+	bucket string,
+	log util.Logger,
+) *lexicalScorer {
+	return &lexicalScorer{s3: s3Client, bucket: bucket, log: log}
+}
+
+func (s *lexicalScorer) Models() []string {
+	return []string{lexicalModelName}
+}
+
+func (s *lexicalScorer) Score(
+	ctx context.Context,
+	batch db.UnscoredApplicationBatch,
+) ([]db.ApplicationScore, error) {
+	query := termFrequencies(lexicalTerms(batch.JD))
+
+	scores := make([]db.ApplicationScore, 0, len(batch.Applications))
+	for _, app := range batch.Applications {
+		resume, err := s.resumeText(ctx, app.ResumeSHA)
+		switch {
+		case errors.Is(err, errUnreadableResume):
+			// Retrying would not help, so the resume is scored as empty
+			s.log.Dbg("unreadable resume", "app_id", app.ApplicationID)
+		case errors.Is(err, errResumeTextPanic):
+			// Left unscored, so that the application is retried with a
+			// backoff and then dead lettered, instead of the resume
+			// taking down granger on every claim of its batch
+			s.log.Err(
+				"failed to extract resume text",
+				"app_id", app.ApplicationID,
+				"error", err,
+			)
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		scores = append(scores, db.ApplicationScore{
+			ApplicationID: app.ApplicationID,
+			ModelName:     lexicalModelName,
+			Score:         bm25Score(query, lexicalTerms(resume)),
+			Explanation: explainLexicalScore(
+				query,
+				resume,
+				batch.YoeMin,
+				batch.YoeMax,
+			),
+		})
+	}
+
+	return scores, nil
+}
+
+var (
+	errUnreadableResume = errors.New("unreadable resume")
+	errResumeTextPanic  = errors.New("resume text extraction panicked")
+)
+
+func (s *lexicalScorer) resumeText(
+	ctx context.Context,
+	key string,
+) (string, error) {
+	result, err := s.s3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get resume %s: %w", key, err)
+	}
+	defer result.Body.Close()
+
+	pdf, err := io.ReadAll(io.LimitReader(result.Body, maxResumeSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read resume %s: %w", key, err)
+	}
+
+	return extractResumeText(pdf, util.PDFText)
+}
+
+// extractResumeText runs the extractor on the resume, recovering from its
+// panics as the resumes are uploaded by the applicants
+func extractResumeText(
+	pdf []byte,
+	extract func([]byte) (string, error),
+) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("%w: %v", errResumeTextPanic, r)
+		}
+	}()
+
+	text, err = extract(pdf)
+	if err != nil {
+		return "", errUnreadableResume
+	}
+
+	return text, nil
+}
+
+// bm25Score is the BM25 score of the document for the query, as a
+// percentage of the score of a document that saturates every query term
+func bm25Score(query map[string]int, document []string) int {
+	tf := termFrequencies(document)
+	lengthNorm := 1 - bm25B + bm25B*float64(len(document))/bm25AvgLength
+
+	var score, maxScore float64
+	for term, qtf := range query {
+		weight := termWeight(term, qtf)
+		maxScore += weight
+
+		if f := float64(tf[term]); f > 0 {
+			saturation := f * (bm25K1 + 1) / (f + bm25K1*lengthNorm)
+			score += weight * saturation / (bm25K1 + 1)
+		}
+	}
+
+	if maxScore == 0 {
+		return 0
+	}
+	return int(math.Round(100 * score / maxScore))
+}
+
+// termWeight is the weight of a term of the JD that occurs qtf times in it
+func termWeight(term string, qtf int) float64 {
+	weight := 1 + math.Log(float64(qtf))
+	if skillTerms[term] {
+		weight *= skillTermWeight
+	}
+	return weight
+}
+
+func termFrequencies(terms []string) map[string]int {
+	frequencies := make(map[string]int)
+	for _, term := range terms {
+		frequencies[term]++
+	}
+	return frequencies
+}
+
+// lexicalTerms splits the text into the normalized terms, keeping the
+// names of the skills like C++, C# and Node.js intact
+func lexicalTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) &&
+			r != '+' && r != '#' && r != '.'
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		term := normalizeTerm(strings.Trim(word, "."))
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func normalizeTerm(word string) string {
+	if alias, ok := termAliases[word]; ok {
+		word = alias
+	}
+	if skillTerms[word] {
+		return word
+	}
+
+	// Drops the numbers and the stray characters
+	if len(word) < 3 || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+		return ""
+	}
+	if stopWords[word] {
+		return ""
+	}
+
+	// A crude plural folding, so that "databases" matches "database"
+	if len(word) > 4 && strings.HasSuffix(word, "s") &&
+		!strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") &&
+		!strings.HasSuffix(word, "is") {
+		word = word[:len(word)-1]
+	}
+	return word
+}
+
+var termAliases = map[string]string{
+	"golang":     "go",
+	"k8s":        "kubernetes",
+	"postgres":   "postgresql",
+	"js":         "javascript",
+	"ts":         "typescript",
+	"nodejs":     "node.js",
+	"node":       "node.js",
+	"reactjs":    "react",
+	"react.js":   "react",
+	"vuejs":      "vue",
+	"vue.js":     "vue",
+	"nextjs":     "next.js",
+	"py":         "python",
+	"ml":         "machine-learning",
+	"gcp":        "google-cloud",
+	"csharp":     "c#",
+	"cpp":        "c++",
+	"dotnet":     ".net",
+	"asp.net":    ".net",
+	"tf":         "terraform",
+	"mongo":      "mongodb",
+	"elastic":    "elasticsearch",
+	"sklearn":    "scikit-learn",
+	"springboot": "spring",
+}
+
+var skillTerms = toSet(
+	"go", "python", "java", "javascript", "typescript", "c", "c++", "c#",
+	"rust", "ruby", "php", "scala", "kotlin", "swift", "perl", "bash",
+	"haskell", "elixir", "erlang", "clojure", "lua", "dart", "sql",
+	"node.js", "react", "vue", "angular", "svelte", "next.js", "django",
+	"flask", "fastapi", "rails", "spring", "express", ".net", "graphql",
+	"grpc", "html", "css", "tailwind", "redux", "jquery",
+	"postgresql", "mysql", "sqlite", "oracle", "mongodb", "redis",
+	"cassandra", "dynamodb", "elasticsearch", "kafka", "rabbitmq", "nats",
+	"spark", "hadoop", "airflow", "snowflake", "bigquery", "dbt", "flink",
+	"kubernetes", "docker", "helm", "terraform", "ansible", "jenkins",
+	"aws", "azure", "google-cloud", "linux", "git", "nginx", "prometheus",
+	"grafana", "ci", "cd", "devops", "sre", "microservices",
+	"machine-learning", "pytorch", "tensorflow", "scikit-learn", "pandas",
+	"numpy", "nlp", "llm", "figma", "selenium", "cypress", "jest",
+	"android", "ios", "flutter", "unity", "excel", "tableau", "salesforce",
+	"sap", "jira", "agile", "scrum",
+)
+
+var stopWords = toSet(
+	// The English function words
+	"the", "and", "for", "with", "that", "this", "are", "was", "were",
+	"will", "would", "should", "can", "could", "may", "might", "must",
+	"have", "has", "had", "not", "but", "our", "your", "you", "their",
+	"they", "them", "who", "whom", "which", "what", "when", "where", "why",
+	"how", "all", "any", "both", "each", "few", "more", "most", "other",
+	"some", "such", "only", "own", "same", "than", "too", "very", "also",
+	"from", "into", "onto", "about", "above", "below", "over", "under",
+	"between", "through", "during", "before", "after", "again", "further",
+	"then", "once", "here", "there", "these", "those", "its", "his", "her",
+	"she", "him", "been", "being", "does", "did", "doing", "out", "off",
+	"per", "via", "etc", "well", "able", "within", "across", "including",
+	"using", "use", "used", "like", "whose", "while",
+
+	// The boilerplate of the JDs and the resumes
+	"job", "role", "position", "candidate", "team", "work", "working",
+	"experience", "experienced", "year", "years", "skill", "skills",
+	"strong", "good", "great", "excellent", "ability", "knowledge",
+	"responsibilities", "responsibility", "requirement", "requirements",
+	"required", "preferred", "plus", "bonus", "opportunity", "company",
+	"looking", "join", "help", "new", "build", "building",
+	"understanding", "familiarity", "proficiency", "proficient",
+	"background", "environment", "ideal", "successful", "apply",
+	"equal", "employer", "benefits", "salary", "location", "remote",
+	"resume", "email", "phone",
+)
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
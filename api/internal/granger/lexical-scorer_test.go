@@ -0,0 +1,104 @@
+package granger
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+)
+
+func TestExtractResumeText(t *testing.T) {
+	resume := util.TextPDF([]string{"Go and Kubernetes engineer"})
+
+	tests := []struct {
+		name     string
+		extract  func([]byte) (string, error)
+		wantText string
+		wantErr  error
+	}{
+		{
+			name:     "readable resume",
+			extract:  util.PDFText,
+			wantText: "Go and Kubernetes engineer",
+		},
+		{
+			name: "unreadable resume",
+			extract: func([]byte) (string, error) {
+				return "", util.ErrMalformedPDF
+			},
+			wantErr: errUnreadableResume,
+		},
+		{
+			name: "panicking extractor",
+			extract: func([]byte) (string, error) {
+				panic("slice bounds out of range [:-3]")
+			},
+			wantErr: errResumeTextPanic,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := extractResumeText(resume, tt.extract)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("extractResumeText() error = %v, want %v",
+					err, tt.wantErr)
+			}
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("extractResumeText() = %q, want it to contain %q",
+					text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestLexicalTerms(t *testing.T) {
+	got := lexicalTerms("Golang, C++ and Node.js on k8s; 5 years of Databases.")
+	want := []string{"go", "c++", "node.js", "kubernetes", "database"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lexicalTerms() = %v, want %v", got, want)
+	}
+}
+
+func TestBM25Score(t *testing.T) {
+	query := termFrequencies(lexicalTerms("Go, Kubernetes and PostgreSQL"))
+
+	tests := []struct {
+		name     string
+		document string
+		wantMin  int
+		wantMax  int
+	}{
+		{name: "empty resume", document: "", wantMin: 0, wantMax: 0},
+		{
+			name:     "unrelated resume",
+			document: "Pastry chef with a flair for desserts",
+			wantMin:  0,
+			wantMax:  0,
+		},
+		{
+			name:     "partly matching resume",
+			document: "Go developer",
+			wantMin:  1,
+			wantMax:  60,
+		},
+		{
+			name:     "fully matching resume",
+			document: "Go Go Kubernetes Kubernetes Postgres Postgres",
+			wantMin:  60,
+			wantMax:  100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := bm25Score(query, lexicalTerms(tt.document))
+			if score < tt.wantMin || score > tt.wantMax {
+				t.Errorf("bm25Score() = %d, want within [%d, %d]",
+					score, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
@@ -3,11 +3,13 @@ package granger
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/wneessen/go-mail"
 
 	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
 	"github.com/vetchium/vetchium/api/pkg/vetchi"
 )
 
@@ -86,6 +88,20 @@ func (g *Granger) sendEmail(email db.Email) error {
 	m.SetBodyString(mail.TypeTextHTML, email.EmailHTMLBody)
 	m.AddAlternativeString(mail.TypeTextPlain, email.EmailTextBody)
 
+	if email.EmailCalendarEvent != "" {
+		err = m.AttachReader(
+			"invite.ics",
+			strings.NewReader(email.EmailCalendarEvent),
+			mail.WithFileContentType(mail.ContentType(
+				util.ICalContentType(email.EmailCalendarEvent),
+			)),
+		)
+		if err != nil {
+			g.log.Err("failed to attach calendar event", "error", err)
+			return err
+		}
+	}
+
 	g.log.Inf("sending email", "email", email.EmailKey, "env", g.env)
 	var c *mail.Client
 	if g.env == vetchi.ProdEnv {
@@ -0,0 +1,196 @@
+package granger
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// applyOpeningSchedules publishes, closes and suspends the openings as per
+// their publish_at, close_at and max_applications and expires the stale
+// applications of the employers that have an application expiry period.
+func (g *Granger) applyOpeningSchedules(quit <-chan struct{}) {
+	g.log.Dbg("Starting applyOpeningSchedules job")
+	defer g.log.Dbg("applyOpeningSchedules job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.ApplyOpeningSchedulesInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("applyOpeningSchedules received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+			err := g.processOpeningSchedules(ctx)
+			cancel()
+			if err != nil {
+				g.log.Err("failed to apply opening schedules", "error", err)
+			}
+		}
+	}
+}
+
+func (g *Granger) processOpeningSchedules(ctx context.Context) error {
+	changes, err := g.db.GetDueOpeningScheduleChanges(
+		ctx,
+		vetchi.MaxOpeningScheduleChangesPerBatch,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(changes.Transitions) == 0 &&
+		len(changes.ExpiredApplications) == 0 {
+		return nil
+	}
+
+	g.log.Dbg("due opening schedule changes",
+		"transitions", len(changes.Transitions),
+		"expired_applications", len(changes.ExpiredApplications))
+
+	var emails []db.Email
+	for _, application := range changes.ExpiredApplications {
+		email, err := g.hedwig.GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.ApplicationExpired,
+			Args: map[string]string{
+				"hub_user_full_name":      application.HubUserFullName,
+				"employer_company_name":   application.CompanyName,
+				"employer_primary_domain": application.PrimaryDomain,
+				"job_title":               application.OpeningTitle,
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{application.HubUserEmail},
+			// TODO: This should be dynamic and come from hedwig
+			Subject: fmt.Sprintf(
+				"%s - Application Expired",
+				application.CompanyName,
+			),
+		})
+		if err != nil {
+			return err
+		}
+		emails = append(emails, email)
+	}
+
+	digests, err := g.watcherDigests(changes)
+	if err != nil {
+		return err
+	}
+	emails = append(emails, digests...)
+
+	return g.db.ApplyOpeningScheduleChanges(
+		ctx,
+		db.ApplyOpeningScheduleChangesReq{
+			Changes: changes,
+			Emails:  emails,
+		},
+	)
+}
+
+// watcherDigests generates one email per watcher that lists all the changes
+// to the openings watched by them
+func (g *Granger) watcherDigests(
+	changes db.OpeningScheduleChanges,
+) ([]db.Email, error) {
+	lines := make(map[string][]string)
+
+	for _, t := range changes.Transitions {
+		var line string
+		switch t.ToState {
+		case common.ActiveOpening:
+			line = fmt.Sprintf(
+				"%s (%s) was published as scheduled",
+				t.OpeningTitle,
+				t.OpeningID,
+			)
+		case common.ClosedOpening:
+			line = fmt.Sprintf(
+				"%s (%s) was closed as scheduled",
+				t.OpeningTitle,
+				t.OpeningID,
+			)
+		case common.SuspendedOpening:
+			line = fmt.Sprintf(
+				"%s (%s) was suspended as it reached the maximum number of applications",
+				t.OpeningTitle,
+				t.OpeningID,
+			)
+		default:
+			g.log.Err("unexpected opening transition", "transition", t)
+			continue
+		}
+
+		for _, watcher := range t.Watchers {
+			lines[watcher] = append(lines[watcher], line)
+		}
+	}
+
+	// Expired applications are summarized per opening
+	type openingKey struct {
+		employerID string
+		openingID  string
+	}
+	expiredCounts := make(map[openingKey]int)
+	var expiredOpenings []db.ExpiredApplication
+	for _, application := range changes.ExpiredApplications {
+		key := openingKey{
+			employerID: application.EmployerID.String(),
+			openingID:  application.OpeningID,
+		}
+		if expiredCounts[key] == 0 {
+			expiredOpenings = append(expiredOpenings, application)
+		}
+		expiredCounts[key]++
+	}
+	for _, application := range expiredOpenings {
+		key := openingKey{
+			employerID: application.EmployerID.String(),
+			openingID:  application.OpeningID,
+		}
+		line := fmt.Sprintf(
+			"%d application(s) to %s (%s) expired",
+			expiredCounts[key],
+			application.OpeningTitle,
+			application.OpeningID,
+		)
+		for _, watcher := range application.Watchers {
+			lines[watcher] = append(lines[watcher], line)
+		}
+	}
+
+	watchers := make([]string, 0, len(lines))
+	for watcher := range lines {
+		watchers = append(watchers, watcher)
+	}
+	slices.Sort(watchers)
+
+	var emails []db.Email
+	for _, watcher := range watchers {
+		email, err := g.hedwig.GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.OpeningScheduleDigest,
+			Args: map[string]string{
+				"Changes": "- " + strings.Join(lines[watcher], "\n- "),
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{watcher},
+			// TODO: This should be dynamic and come from hedwig
+			Subject: "Updates to the openings you watch",
+		})
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+
+	return emails, nil
+}
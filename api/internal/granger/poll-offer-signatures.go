@@ -0,0 +1,172 @@
+package granger
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/esign"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+)
+
+// The provider calls and the upload of the signed document take longer
+// than the db calls
+const esignTimeout = 30 * time.Second
+
+// pollOfferSignatures checks the status of the offers that are sent for
+// signature with the e-signature provider. Signed offers are accepted and
+// the signed document is stored. Webhooks from the provider only make the
+// signatures due for the next poll.
+func (g *Granger) pollOfferSignatures(quit <-chan struct{}) {
+	g.log.Dbg("Starting pollOfferSignatures job")
+	defer g.log.Dbg("pollOfferSignatures job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.PollOfferSignaturesInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("pollOfferSignatures received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+			signatures, err := g.db.GetDueOfferSignatures(
+				ctx,
+				g.esign.Name(),
+				vetchi.MaxOfferSignaturesPerPoll,
+			)
+			cancel()
+			if err != nil {
+				g.log.Err("failed to get due offer signatures", "error", err)
+				continue
+			}
+
+			for _, signature := range signatures {
+				ctx, cancel := context.WithTimeout(
+					context.Background(),
+					esignTimeout,
+				)
+				err := g.processOfferSignature(ctx, signature)
+				cancel()
+				if err != nil {
+					g.log.Err(
+						"failed to process offer signature",
+						"candidacy_id", signature.CandidacyID,
+						"version", signature.Version,
+						"error", err,
+					)
+				}
+			}
+		}
+	}
+}
+
+func (g *Granger) processOfferSignature(
+	ctx context.Context,
+	signature db.DueOfferSignature,
+) error {
+	status, err := g.esign.GetStatus(ctx, signature.EnvelopeID)
+	if err != nil && !errors.Is(err, esign.ErrUnknownEnvelope) {
+		return err
+	}
+
+	if err != nil || status.State == esign.PendingEnvelope {
+		// Unknown envelopes are retried too, as the provider may not have
+		// caught up with the envelope yet
+		return g.db.DeferOfferSignaturePoll(ctx, db.DeferOfferSignaturePollReq{
+			CandidacyID: signature.CandidacyID,
+			Version:     signature.Version,
+			NextPollAt:  time.Now().Add(vetchi.OfferSignatureRepollDelay),
+		})
+	}
+
+	response := "signed and accepted"
+	if status.State == esign.DeclinedEnvelope {
+		response = "declined to sign"
+	}
+
+	email, err := g.hedwig.GenerateEmail(hedwig.GenerateEmailReq{
+		TemplateName: hedwig.OfferResponse,
+		Args: map[string]string{
+			"CandidateName": signature.CandidateName,
+			"OpeningTitle":  signature.OpeningTitle,
+			"Response":      response,
+			"CandidacyURL": g.employerBaseURL + "/candidacy/" +
+				signature.CandidacyID,
+		},
+		EmailFrom: vetchi.EmailFrom,
+		EmailTo:   []string{signature.RecruiterEmail},
+		Subject: signature.CandidateName + " has " + response +
+			" the offer for " + signature.OpeningTitle,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status.State == esign.DeclinedEnvelope {
+		return g.db.DeclineOfferSignature(ctx, db.DeclineOfferSignatureReq{
+			CandidacyID: signature.CandidacyID,
+			Version:     signature.Version,
+			Email:       email,
+		})
+	}
+
+	path, err := g.uploadSignedOffer(ctx, status.SignedDocument)
+	if err != nil {
+		return err
+	}
+
+	return g.db.CompleteOfferSignature(ctx, db.CompleteOfferSignatureReq{
+		CandidacyID:        signature.CandidacyID,
+		Version:            signature.Version,
+		SignedDocumentPath: path,
+		Email:              email,
+	})
+}
+
+// uploadSignedOffer stores the signed document under its SHA-512, the same
+// way as hermione stores the offer documents
+func (g *Granger) uploadSignedOffer(
+	ctx context.Context,
+	pdfBytes []byte,
+) (string, error) {
+	s3Client := s3.New(session.Must(session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(
+			g.s3.accessKey,
+			g.s3.secretKey,
+			"",
+		),
+		Endpoint:         aws.String(g.s3.endpoint),
+		Region:           aws.String(g.s3.region),
+		S3ForcePathStyle: aws.Bool(true), // Required for MinIO
+	})))
+
+	hash := sha512.Sum512(pdfBytes)
+	filename := fmt.Sprintf("%s%x.pdf", util.OffersPath, hash)
+
+	_, err := s3Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(g.s3.bucket),
+		Key:           aws.String(filename),
+		Body:          bytes.NewReader(pdfBytes),
+		ContentType:   aws.String("application/pdf"),
+		ContentLength: aws.Int64(int64(len(pdfBytes))),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload signed offer: %w", err)
+	}
+
+	g.log.Dbg("uploaded signed offer", "filename", filename)
+	return filename, nil
+}
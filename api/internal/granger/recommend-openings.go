@@ -0,0 +1,274 @@
+package granger
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+const (
+	// The JD is matched against the whole of the profile and the title of
+	// the Opening against each of the titles in it
+	profileMatchWeight = 0.6
+	titleMatchWeight   = 0.4
+
+	// Bonuses on top of the match, as the HubUser chose these
+	tagMatchBonus        = 10
+	maxTagMatchesCounted = 3
+	countryMatchBonus    = 10
+	cityMatchBonus       = 5
+
+	// The matches that are worth telling the HubUser about
+	profileMatchReasonScore = 30
+	titleMatchReasonScore   = 50
+)
+
+// refreshRecommendations computes the Openings that match each HubUser
+// best, for the HubUsers whose recommendations are stale
+func (g *Granger) refreshRecommendations(quit <-chan struct{}) {
+	g.log.Dbg("Starting refreshRecommendations job")
+	defer g.log.Dbg("refreshRecommendations job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.RefreshRecommendationsInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("refreshRecommendations received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			// A full batch means that there may be more, which are not
+			// kept waiting for another interval
+			for {
+				refreshed, err := g.processStaleRecommendations()
+				if err != nil {
+					g.log.Err("failed to refresh recommendations", "error", err)
+					break
+				}
+				if refreshed < vetchi.MaxRecommendationRefreshesPerBatch {
+					break
+				}
+				select {
+				case <-quit:
+					g.log.Dbg("refreshRecommendations received quit signal")
+					return
+				default:
+				}
+			}
+		}
+	}
+}
+
+// processStaleRecommendations refreshes a batch of the stale
+// recommendations and returns the number of HubUsers in the batch
+func (g *Granger) processStaleRecommendations() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	profiles, err := g.db.GetStaleRecommendationProfiles(
+		ctx,
+		vetchi.MaxRecommendationRefreshesPerBatch,
+	)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(profiles) == 0 {
+		return 0, nil
+	}
+	g.log.Dbg("stale recommendations", "count", len(profiles))
+
+	for _, profile := range profiles {
+		err := g.refreshHubUserRecommendations(profile)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"failed to refresh recommendations of %s: %w",
+				profile.HubUserID,
+				err,
+			)
+		}
+	}
+
+	return len(profiles), nil
+}
+
+func (g *Granger) refreshHubUserRecommendations(
+	profile db.RecommendationProfile,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	candidates, err := g.db.GetRecommendationCandidates(
+		ctx,
+		profile,
+		vetchi.MaxRecommendationCandidates,
+	)
+	if err != nil {
+		return err
+	}
+
+	recommendations := recommendOpenings(profile, candidates)
+	g.log.Dbg("recommended openings",
+		"hub_user_id", profile.HubUserID,
+		"candidates", len(candidates),
+		"recommendations", len(recommendations))
+
+	return g.db.SaveRecommendations(ctx, db.SaveRecommendationsReq{
+		HubUserID:       profile.HubUserID,
+		Recommendations: recommendations,
+	})
+}
+
+// recommendOpenings scores the candidates by how well they match the
+// profile and returns the best of them, each with the reasons why
+func recommendOpenings(
+	profile db.RecommendationProfile,
+	candidates []db.RecommendationCandidate,
+) []db.OpeningRecommendation {
+	profileTerms := recommendationProfileTerms(profile)
+
+	tags := make(map[string]bool, len(profile.Tags))
+	for _, tag := range profile.Tags {
+		tags[tag] = true
+	}
+
+	recommendations := make([]db.OpeningRecommendation, 0, len(candidates))
+	for _, candidate := range candidates {
+		recommendations = append(
+			recommendations,
+			recommendOpening(profile, profileTerms, tags, candidate),
+		)
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+	if len(recommendations) > vetchi.MaxRecommendedOpenings {
+		recommendations = recommendations[:vetchi.MaxRecommendedOpenings]
+	}
+	return recommendations
+}
+
+func recommendOpening(
+	profile db.RecommendationProfile,
+	profileTerms []string,
+	tags map[string]bool,
+	candidate db.RecommendationCandidate,
+) db.OpeningRecommendation {
+	reasons := []hub.RecommendationReason{}
+
+	var matchedTags []string
+	for _, tag := range candidate.Tags {
+		if tags[tag] {
+			matchedTags = append(matchedTags, tag)
+		}
+	}
+	matchedTags = matchedTags[:min(len(matchedTags), maxTagMatchesCounted)]
+	for _, tag := range matchedTags {
+		reasons = append(reasons, hub.RecommendationReason{
+			ReasonType: hub.MatchesTagReason,
+			Detail:     &tag,
+			Text:       "Matches your tag " + tag,
+		})
+	}
+
+	title := termFrequencies(lexicalTerms(candidate.Title))
+	workHistoryTitle, workHistoryScore := bestTitleMatch(
+		title,
+		profile.WorkHistoryTitles,
+	)
+	appliedTitle, appliedScore := bestTitleMatch(
+		title,
+		profile.AppliedOpeningTitles,
+	)
+	if workHistoryScore >= titleMatchReasonScore {
+		reasons = append(reasons, hub.RecommendationReason{
+			ReasonType: hub.MatchesWorkHistoryReason,
+			Detail:     &workHistoryTitle,
+			Text:       "Similar to your role " + workHistoryTitle,
+		})
+	}
+	if appliedScore >= titleMatchReasonScore {
+		reasons = append(reasons, hub.RecommendationReason{
+			ReasonType: hub.SimilarToAppliedReason,
+			Detail:     &appliedTitle,
+			Text:       "Similar to " + appliedTitle + " that you applied to",
+		})
+	}
+
+	query := termFrequencies(
+		lexicalTerms(candidate.Title + "\n" + candidate.JD),
+	)
+	profileScore := bm25Score(query, profileTerms)
+	if profileScore >= profileMatchReasonScore {
+		reasons = append(reasons, hub.RecommendationReason{
+			ReasonType: hub.MatchesProfileReason,
+			Text:       "Matches your profile",
+		})
+	}
+
+	score := profileMatchWeight*float64(profileScore) +
+		titleMatchWeight*float64(max(workHistoryScore, appliedScore)) +
+		float64(tagMatchBonus*len(matchedTags))
+
+	switch {
+	case candidate.InResidentCity:
+		score += cityMatchBonus + countryMatchBonus
+		city := profile.ResidentCity
+		reasons = append(reasons, hub.RecommendationReason{
+			ReasonType: hub.HiringInYourCityReason,
+			Detail:     &city,
+			Text:       "Hiring in your city " + city,
+		})
+	case candidate.InResidentCountry:
+		score += countryMatchBonus
+		country := profile.ResidentCountryCode
+		reasons = append(reasons, hub.RecommendationReason{
+			ReasonType: hub.HiringInYourCountryReason,
+			Detail:     &country,
+			Text:       "Hiring in your country",
+		})
+	}
+
+	return db.OpeningRecommendation{
+		EmployerID: candidate.EmployerID,
+		OpeningID:  candidate.OpeningID,
+		Score:      min(int(math.Round(score)), 100),
+		Reasons:    reasons,
+	}
+}
+
+// recommendationProfileTerms returns the terms of all that the HubUser
+// wrote about themselves and the titles of the Openings they applied to
+func recommendationProfileTerms(profile db.RecommendationProfile) []string {
+	texts := []string{profile.ShortBio, profile.LongBio}
+	texts = append(texts, profile.WorkHistoryTitles...)
+	texts = append(texts, profile.WorkHistoryDescriptions...)
+	texts = append(texts, profile.AppliedOpeningTitles...)
+	return lexicalTerms(strings.Join(texts, "\n"))
+}
+
+// bestTitleMatch returns the title that is most like the title of the
+// Opening, with the BM25 similarity of the two
+func bestTitleMatch(
+	openingTitle map[string]int,
+	titles []string,
+) (string, int) {
+	var best string
+	bestScore := 0
+	for _, title := range titles {
+		score := bm25Score(openingTitle, lexicalTerms(title))
+		if score > bestScore {
+			best, bestScore = title, score
+		}
+	}
+	return best, bestScore
+}
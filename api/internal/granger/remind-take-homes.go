@@ -0,0 +1,77 @@
+package granger
+
+import (
+	"context"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+)
+
+// remindTakeHomes reminds the candidates of the take-home assignments that
+// are due soon and are not submitted yet
+func (g *Granger) remindTakeHomes(quit <-chan struct{}) {
+	g.log.Dbg("Starting remindTakeHomes job")
+	defer g.log.Dbg("remindTakeHomes job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.RemindTakeHomesInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("remindTakeHomes received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+			err := g.processTakeHomeReminders(ctx)
+			cancel()
+			if err != nil {
+				g.log.Err("failed to remind take-homes", "error", err)
+			}
+		}
+	}
+}
+
+func (g *Granger) processTakeHomeReminders(ctx context.Context) error {
+	reminders, err := g.db.GetDueTakeHomeReminders(
+		ctx,
+		vetchi.TakeHomeReminderLeadTime,
+		vetchi.MaxTakeHomeRemindersPerBatch,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(reminders) == 0 {
+		return nil
+	}
+	g.log.Dbg("due take-home reminders", "count", len(reminders))
+
+	req := db.TakeHomeRemindersReq{}
+	for _, reminder := range reminders {
+		email, err := g.hedwig.GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.TakeHomeReminder,
+			Args: map[string]string{
+				"CandidateName": reminder.CandidateName,
+				"CompanyName":   reminder.CompanyName,
+				"OpeningTitle":  reminder.OpeningTitle,
+				"Deadline":      reminder.Deadline.UTC().Format(time.RFC1123),
+				"InterviewURL": g.hubBaseURL + "/candidacy/" +
+					reminder.CandidacyID,
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{reminder.CandidateEmail},
+			Subject:   "Take-home assignment due for " + reminder.OpeningTitle,
+		})
+		if err != nil {
+			return err
+		}
+		req.InterviewIDs = append(req.InterviewIDs, reminder.InterviewID)
+		req.Emails = append(req.Emails, email)
+	}
+
+	return g.db.SendTakeHomeReminders(ctx, req)
+}
@@ -1,17 +1,13 @@
 package granger
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"os"
+	"slices"
 	"time"
 
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/pkg/vetchi"
-	"github.com/vetchium/vetchium/typespec/sortinghat"
 )
 
 func (g *Granger) scoreApplications(quit <-chan struct{}) {
@@ -36,9 +32,16 @@ func (g *Granger) scoreApplications(quit <-chan struct{}) {
 }
 
 func (g *Granger) processApplicationsForScoring(ctx context.Context) error {
-	// Get an unscored application batch
-	batch, err := g.db.GetUnscoredApplication(
+	var models []string
+	for _, scorer := range g.scorers {
+		models = append(models, scorer.Models()...)
+	}
+
+	// Claim an unscored application batch, which no other replica scores
+	// till its lease expires
+	batch, err := g.db.ClaimUnscoredApplications(
 		ctx,
+		models,
 		vetchi.MaxApplicationsToScorePerBatch,
 	)
 	if err != nil {
@@ -56,136 +59,129 @@ func (g *Granger) processApplicationsForScoring(ctx context.Context) error {
 		"opening_id", batch.OpeningID,
 		"app_count", len(batch.Applications))
 
-	// Score the batch of applications
-	err = g.scoreApplicationBatch(ctx, batch.Applications, batch.JD)
-	if err != nil {
-		g.log.Dbg("failed to score application batch", "err", err)
-		return err
-	}
-
-	return nil
-}
-
-func (g *Granger) scoreApplicationBatch(
-	ctx context.Context,
-	applications []db.ApplicationForScoring,
-	jd string,
-) error {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
-	// Get S3 bucket name from environment variable
-	bucket := os.Getenv("S3_BUCKET")
-	if bucket == "" {
-		g.log.Err("S3_BUCKET environment variable not set")
-		return fmt.Errorf("S3_BUCKET environment variable not set")
-	}
-
-	// Prepare batch request
-	appSortRequests := make(
-		[]sortinghat.ApplicationSortRequest,
-		0,
-		len(applications),
-	)
-	appIDMap := make(map[string]string) // Map resume paths to application IDs
-
-	for _, app := range applications {
-		// Format fileurl as expected by sortinghat: s3://bucket/key
-		fileurl := fmt.Sprintf("s3://%s/%s", bucket, app.ResumeSHA)
-		appSortRequests = append(
-			appSortRequests,
-			sortinghat.ApplicationSortRequest{
-				ApplicationID: app.ApplicationID,
-				ResumePath:    fileurl,
-			},
-		)
-		appIDMap[fileurl] = app.ApplicationID
-	}
-
-	g.log.Dbg("Scoring batch of resumes", "count", len(appSortRequests))
-
-	// Create request payload
-	request := sortinghat.SortingHatRequest{
-		JobDescription:          jd,
-		ApplicationSortRequests: appSortRequests,
-	}
-
-	// Convert request to JSON
-	requestBody, err := json.Marshal(request)
-	if err != nil {
-		g.log.Err("failed to marshal request", "err", err)
-		return fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Build request
-	apiURL := "http://sortinghat:8080/score-batch"
-	req, err := http.NewRequestWithContext(
+	// The scoring must be over before the lease expires
+	scoreCtx, cancel := context.WithTimeout(
 		ctx,
-		"POST",
-		apiURL,
-		bytes.NewBuffer(requestBody),
+		vetchi.ApplicationScoringLease/2,
 	)
-	if err != nil {
-		g.log.Err("failed to create request", "err", err)
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
+	result := g.scoreApplicationBatch(scoreCtx, *batch)
+	cancel()
 
-	// Execute request
-	resp, err := client.Do(req)
+	// The leases are released even if the scoring timed out
+	finishCtx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	deadLettered, err := g.db.FinishApplicationScoring(finishCtx, result)
 	if err != nil {
-		g.log.Err("failed to call sortinghat API", "err", err)
-		return fmt.Errorf("failed to call sortinghat API: %w", err)
+		g.log.Err("failed to finish application scoring", "err", err)
+		return fmt.Errorf("failed to finish application scoring: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	failures := make(map[db.ApplicationModel]string, len(result.Failed))
+	for _, failure := range result.Failed {
+		failures[failure.ApplicationModel] = failure.Error
+	}
+	for _, dead := range deadLettered {
 		g.log.Err(
-			"sortinghat API returned non-OK status",
-			"status",
-			resp.Status,
+			"application scoring dead lettered",
+			"application_id", dead.ApplicationID,
+			"model", dead.ModelName,
+			"error", failures[dead],
 		)
-		return fmt.Errorf("sortinghat API returned status %s", resp.Status)
 	}
 
-	// Parse response
-	var response sortinghat.SortingHatResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		g.log.Err("failed to decode sortinghat response", "err", err)
-		return fmt.Errorf("failed to decode sortinghat response: %w", err)
+	if len(result.Failed) > 0 {
+		return fmt.Errorf(
+			"failed to score %d application models",
+			len(result.Failed),
+		)
 	}
+	return nil
+}
 
-	g.log.Dbg("Sortinghat response", "response", response)
+// scoreApplicationBatch runs each scorer on the applications that any of
+// its models is due for. A scorer that fails, or does not return a score
+// for an application, fails only its own models on the application, which
+// are retried later. The scores of the other models are kept.
+func (g *Granger) scoreApplicationBatch(
+	ctx context.Context,
+	batch db.UnscoredApplicationBatch,
+) db.ApplicationScoringResult {
+	var result db.ApplicationScoringResult
+
+	for _, scorer := range g.scorers {
+		models := make(map[string]bool)
+		for _, model := range scorer.Models() {
+			models[model] = true
+		}
 
-	// Collect all scores to save in a single transaction
-	var allScores []db.ApplicationScore
+		// The due models of the scorer, of each application
+		due := make(map[string][]string)
+		sub := batch
+		sub.Applications = nil
+		for _, app := range batch.Applications {
+			for _, model := range app.Models {
+				if models[model] {
+					due[app.ApplicationID] = append(
+						due[app.ApplicationID],
+						model,
+					)
+				}
+			}
+			if len(due[app.ApplicationID]) > 0 {
+				sub.Applications = append(sub.Applications, app)
+			}
+		}
+		if len(sub.Applications) == 0 {
+			continue
+		}
 
-	// Process scores from the response
-	for _, score := range response.Scores {
-		appID := score.ApplicationID
+		scores, err := scorer.Score(ctx, sub)
+		if err != nil {
+			g.log.Err(
+				"scorer failed",
+				"models", scorer.Models(),
+				"err", err,
+			)
+		}
 
-		// Map model scores to database scores
-		for _, modelScore := range score.ModelScores {
-			allScores = append(allScores, db.ApplicationScore{
-				ApplicationID: appID,
-				ModelName:     modelScore.ModelName,
-				Score:         int(modelScore.Score),
-			})
+		scored := make(map[db.ApplicationModel]bool, len(scores))
+		for _, score := range scores {
+			key := db.ApplicationModel{
+				ApplicationID: score.ApplicationID,
+				ModelName:     score.ModelName,
+			}
+			if !slices.Contains(due[key.ApplicationID], key.ModelName) {
+				continue
+			}
+			result.Scores = append(result.Scores, score)
+			scored[key] = true
 		}
-	}
 
-	// Save all scores in a single transaction
-	if len(allScores) > 0 {
-		err := g.db.SaveApplicationScores(ctx, allScores)
-		if err != nil {
-			g.log.Err("failed to save application scores", "err", err)
-			return fmt.Errorf("failed to save application scores: %w", err)
+		for _, app := range sub.Applications {
+			for _, model := range due[app.ApplicationID] {
+				key := db.ApplicationModel{
+					ApplicationID: app.ApplicationID,
+					ModelName:     model,
+				}
+				if scored[key] {
+					result.Scored = append(result.Scored, key)
+					continue
+				}
+
+				failure := db.ApplicationScoringFailure{
+					ApplicationModel: key,
+					Error:            "no score from the model",
+				}
+				if err != nil {
+					failure.Error = err.Error()
+				}
+				result.Failed = append(result.Failed, failure)
+			}
 		}
-		g.log.Dbg("Saved all application scores", "count", len(allScores))
-	} else {
-		g.log.Dbg("No scores to save")
 	}
 
-	return nil
+	g.log.Dbg("scored application batch",
+		"scores", len(result.Scores),
+		"failed", len(result.Failed))
+	return result
 }
@@ -0,0 +1,158 @@
+package granger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+)
+
+// fakeScorer scores every application it is given with all of its models,
+// except for the applications in skip, or fails if err is set
+type fakeScorer struct {
+	models []string
+	skip   map[string]bool
+	err    error
+
+	scored []string
+}
+
+func (s *fakeScorer) Models() []string {
+	return s.models
+}
+
+func (s *fakeScorer) Score(
+	ctx context.Context,
+	batch db.UnscoredApplicationBatch,
+) ([]db.ApplicationScore, error) {
+	var scores []db.ApplicationScore
+	for _, app := range batch.Applications {
+		s.scored = append(s.scored, app.ApplicationID)
+		if s.skip[app.ApplicationID] {
+			continue
+		}
+		for _, model := range s.models {
+			scores = append(scores, db.ApplicationScore{
+				ApplicationID: app.ApplicationID,
+				ModelName:     model,
+				Score:         50,
+			})
+		}
+	}
+
+	if s.err != nil {
+		return nil, s.err
+	}
+	return scores, nil
+}
+
+func TestScoreApplicationBatch(t *testing.T) {
+	lexical := &fakeScorer{models: []string{"lexical"}}
+	composite := &fakeScorer{
+		models: []string{"composite"},
+		skip:   map[string]bool{"APP-2": true},
+	}
+	sortinghat := &fakeScorer{
+		models: []string{"e5", "bge"},
+		err:    errors.New("sortinghat returned status 500"),
+	}
+	g := &Granger{
+		log: util.Logger{
+			Log: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+		scorers: []Scorer{lexical, composite, sortinghat},
+	}
+
+	result := g.scoreApplicationBatch(
+		context.Background(),
+		db.UnscoredApplicationBatch{
+			Applications: []db.ApplicationForScoring{
+				{
+					ApplicationID: "APP-1",
+					Models: []string{
+						"bge",
+						"composite",
+						"e5",
+						"lexical",
+					},
+				},
+				{
+					ApplicationID: "APP-2",
+					Models:        []string{"composite", "lexical"},
+				},
+				{
+					// Already scored by all but sortinghat
+					ApplicationID: "APP-3",
+					Models:        []string{"e5"},
+				},
+			},
+		},
+	)
+
+	// The scorers are given only the applications that they are due for
+	if !reflect.DeepEqual(lexical.scored, []string{"APP-1", "APP-2"}) {
+		t.Errorf("lexical scored %v", lexical.scored)
+	}
+	if !reflect.DeepEqual(sortinghat.scored, []string{"APP-1", "APP-3"}) {
+		t.Errorf("sortinghat scored %v", sortinghat.scored)
+	}
+
+	var scores []db.ApplicationModel
+	for _, score := range result.Scores {
+		scores = append(scores, db.ApplicationModel{
+			ApplicationID: score.ApplicationID,
+			ModelName:     score.ModelName,
+		})
+	}
+	wantScored := []db.ApplicationModel{
+		{ApplicationID: "APP-1", ModelName: "lexical"},
+		{ApplicationID: "APP-2", ModelName: "lexical"},
+		{ApplicationID: "APP-1", ModelName: "composite"},
+	}
+	if !reflect.DeepEqual(scores, wantScored) {
+		t.Errorf("scores = %v, want %v", scores, wantScored)
+	}
+	if !reflect.DeepEqual(result.Scored, wantScored) {
+		t.Errorf("scored = %v, want %v", result.Scored, wantScored)
+	}
+
+	// Only the models that failed are retried
+	wantFailed := []db.ApplicationScoringFailure{
+		{
+			ApplicationModel: db.ApplicationModel{
+				ApplicationID: "APP-2",
+				ModelName:     "composite",
+			},
+			Error: "no score from the model",
+		},
+		{
+			ApplicationModel: db.ApplicationModel{
+				ApplicationID: "APP-1",
+				ModelName:     "bge",
+			},
+			Error: "sortinghat returned status 500",
+		},
+		{
+			ApplicationModel: db.ApplicationModel{
+				ApplicationID: "APP-1",
+				ModelName:     "e5",
+			},
+			Error: "sortinghat returned status 500",
+		},
+		{
+			ApplicationModel: db.ApplicationModel{
+				ApplicationID: "APP-3",
+				ModelName:     "e5",
+			},
+			Error: "sortinghat returned status 500",
+		},
+	}
+	if !reflect.DeepEqual(result.Failed, wantFailed) {
+		t.Errorf("failed = %v, want %v", result.Failed, wantFailed)
+	}
+}
@@ -0,0 +1,22 @@
+package granger
+
+import (
+	"context"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+)
+
+// Scorer scores the resumes of the applications of an Opening against its
+// JD. Each Scorer fills the scores of its own models, which must be
+// registered in the application_scoring_models, so that the scores of all
+// the models appear side by side on the applications. The scores may carry
+// an explanation of what the model made of the resume.
+type Scorer interface {
+	// Models are the names of the models whose scores are returned
+	Models() []string
+
+	Score(
+		ctx context.Context,
+		batch db.UnscoredApplicationBatch,
+	) ([]db.ApplicationScore, error)
+}
@@ -0,0 +1,142 @@
+package granger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/typespec/sortinghat"
+)
+
+// sortingHatScorer scores the resumes with the embedding models of the
+// sortinghat service, which reads the resumes from S3 on its own
+type sortingHatScorer struct {
+	url    string
+	bucket string
+	client *http.Client
+	log    util.Logger
+}
+
+func newSortingHatScorer(
+	url string,
+	bucket string,
+	log util.Logger,
+) *sortingHatScorer {
+	return &sortingHatScorer{
+		url:    url,
+		bucket: bucket,
+		client: &http.Client{Timeout: 30 * time.Second},
+		log:    log,
+	}
+}
+
+func (s *sortingHatScorer) Models() []string {
+	return []string{"Microsoft-E5-Research", "Beijing-Academy-BGE"}
+}
+
+func (s *sortingHatScorer) Score(
+	ctx context.Context,
+	batch db.UnscoredApplicationBatch,
+) ([]db.ApplicationScore, error) {
+	// Prepare batch request
+	appSortRequests := make(
+		[]sortinghat.ApplicationSortRequest,
+		0,
+		len(batch.Applications),
+	)
+
+	for _, app := range batch.Applications {
+		// Format fileurl as expected by sortinghat: s3://bucket/key
+		fileurl := fmt.Sprintf("s3://%s/%s", s.bucket, app.ResumeSHA)
+		appSortRequests = append(
+			appSortRequests,
+			sortinghat.ApplicationSortRequest{
+				ApplicationID: app.ApplicationID,
+				ResumePath:    fileurl,
+			},
+		)
+	}
+
+	s.log.Dbg("Scoring batch of resumes", "count", len(appSortRequests))
+
+	// Create request payload
+	request := sortinghat.SortingHatRequest{
+		JobDescription:          batch.JD,
+		ApplicationSortRequests: appSortRequests,
+		YoeMin:                  &batch.YoeMin,
+		YoeMax:                  &batch.YoeMax,
+	}
+
+	// Convert request to JSON
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		s.log.Err("failed to marshal request", "err", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Build request
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		s.url+"/score-batch",
+		bytes.NewBuffer(requestBody),
+	)
+	if err != nil {
+		s.log.Err("failed to create request", "err", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute request
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.log.Err("failed to call sortinghat API", "err", err)
+		return nil, fmt.Errorf("failed to call sortinghat API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		s.log.Err(
+			"sortinghat API returned non-OK status",
+			"status",
+			resp.Status,
+		)
+		return nil, fmt.Errorf(
+			"sortinghat API returned status %s",
+			resp.Status,
+		)
+	}
+
+	// Parse response
+	var response sortinghat.SortingHatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		s.log.Err("failed to decode sortinghat response", "err", err)
+		return nil, fmt.Errorf(
+			"failed to decode sortinghat response: %w",
+			err,
+		)
+	}
+
+	s.log.Dbg("Sortinghat response", "response", response)
+
+	// Map model scores to database scores
+	var scores []db.ApplicationScore
+	for _, score := range response.Scores {
+		for _, modelScore := range score.ModelScores {
+			scores = append(scores, db.ApplicationScore{
+				ApplicationID: score.ApplicationID,
+				ModelName:     modelScore.ModelName,
+				Score:         int(modelScore.Score),
+				// Older versions of sortinghat do not explain the scores
+				Explanation: modelScore.Explanation,
+			})
+		}
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,231 @@
+package granger
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+// The bonus for a HubUser whose years of experience are within those that
+// the Opening asks for
+const yoeMatchBonus = 10
+
+// suggestCandidates computes the discoverable HubUsers that match each
+// ACTIVE Opening best, for the Openings whose suggestions are stale
+func (g *Granger) suggestCandidates(quit <-chan struct{}) {
+	g.log.Dbg("Starting suggestCandidates job")
+	defer g.log.Dbg("suggestCandidates job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.SuggestCandidatesInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("suggestCandidates received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			for {
+				refreshed, err := g.processStaleSuggestions()
+				if err != nil {
+					g.log.Err("failed to suggest candidates", "error", err)
+					break
+				}
+				if refreshed < vetchi.MaxCandidateSuggestionRefreshesPerBatch {
+					break
+				}
+				select {
+				case <-quit:
+					g.log.Dbg("suggestCandidates received quit signal")
+					return
+				default:
+				}
+			}
+		}
+	}
+}
+
+// processStaleSuggestions refreshes the suggestions of a batch of the
+// Openings and returns the number of Openings in the batch
+func (g *Granger) processStaleSuggestions() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	openings, err := g.db.GetStaleSuggestionOpenings(
+		ctx,
+		vetchi.MaxCandidateSuggestionRefreshesPerBatch,
+	)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(openings) == 0 {
+		return 0, nil
+	}
+	g.log.Dbg("stale candidate suggestions", "count", len(openings))
+
+	for _, opening := range openings {
+		err := g.refreshOpeningSuggestions(opening)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"failed to suggest candidates for %s: %w",
+				opening.OpeningID,
+				err,
+			)
+		}
+	}
+
+	return len(openings), nil
+}
+
+func (g *Granger) refreshOpeningSuggestions(
+	opening db.SuggestionOpening,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	candidates, err := g.db.GetSuggestionCandidates(
+		ctx,
+		opening,
+		vetchi.MaxCandidateSuggestionCandidates,
+	)
+	if err != nil {
+		return err
+	}
+
+	suggestions := suggestCandidates(opening, candidates)
+	g.log.Dbg("suggested candidates",
+		"employer_id", opening.EmployerID,
+		"opening_id", opening.OpeningID,
+		"candidates", len(candidates),
+		"suggestions", len(suggestions))
+
+	return g.db.SaveCandidateSuggestions(ctx, db.SaveCandidateSuggestionsReq{
+		EmployerID:  opening.EmployerID,
+		OpeningID:   opening.OpeningID,
+		Suggestions: suggestions,
+	})
+}
+
+// suggestCandidates scores the candidates by how well they match the
+// Opening and returns the best of them, each with the reasons why
+func suggestCandidates(
+	opening db.SuggestionOpening,
+	candidates []db.SuggestionCandidate,
+) []db.CandidateSuggestion {
+	query := termFrequencies(lexicalTerms(opening.Title + "\n" + opening.JD))
+	title := termFrequencies(lexicalTerms(opening.Title))
+
+	tags := make(map[string]bool, len(opening.Tags))
+	for _, tag := range opening.Tags {
+		tags[tag] = true
+	}
+
+	suggestions := make([]db.CandidateSuggestion, 0, len(candidates))
+	for _, candidate := range candidates {
+		suggestions = append(
+			suggestions,
+			suggestCandidate(opening, query, title, tags, candidate),
+		)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if len(suggestions) > vetchi.MaxSuggestedCandidates {
+		suggestions = suggestions[:vetchi.MaxSuggestedCandidates]
+	}
+	return suggestions
+}
+
+func suggestCandidate(
+	opening db.SuggestionOpening,
+	query map[string]int,
+	title map[string]int,
+	tags map[string]bool,
+	candidate db.SuggestionCandidate,
+) db.CandidateSuggestion {
+	profile := candidate.Profile
+	reasons := []employer.CandidateSuggestionReason{}
+
+	var matchedTags []string
+	for _, tag := range profile.Tags {
+		if tags[tag] {
+			matchedTags = append(matchedTags, tag)
+		}
+	}
+	matchedTags = matchedTags[:min(len(matchedTags), maxTagMatchesCounted)]
+	for _, tag := range matchedTags {
+		reasons = append(reasons, employer.CandidateSuggestionReason{
+			ReasonType: employer.MatchesTagReason,
+			Detail:     &tag,
+			Text:       "Has the tag " + tag,
+		})
+	}
+
+	roleTitle, roleScore := bestTitleMatch(title, profile.WorkHistoryTitles)
+	if roleScore >= titleMatchReasonScore {
+		reasons = append(reasons, employer.CandidateSuggestionReason{
+			ReasonType: employer.SimilarRoleReason,
+			Detail:     &roleTitle,
+			Text:       "Worked as " + roleTitle,
+		})
+	}
+
+	jdScore := bm25Score(query, recommendationProfileTerms(profile))
+	if jdScore >= profileMatchReasonScore {
+		reasons = append(reasons, employer.CandidateSuggestionReason{
+			ReasonType: employer.MatchesJDReason,
+			Text:       "Matches the JD",
+		})
+	}
+
+	score := profileMatchWeight*float64(jdScore) +
+		titleMatchWeight*float64(roleScore) +
+		float64(tagMatchBonus*len(matchedTags))
+
+	if candidate.Yoe != nil &&
+		*candidate.Yoe >= opening.YoeMin &&
+		*candidate.Yoe <= opening.YoeMax {
+		score += yoeMatchBonus
+		yoe := strconv.Itoa(*candidate.Yoe)
+		reasons = append(reasons, employer.CandidateSuggestionReason{
+			ReasonType: employer.YoeWithinRangeReason,
+			Detail:     &yoe,
+			Text:       yoe + " years of experience",
+		})
+	}
+
+	switch {
+	case candidate.InOpeningCity:
+		score += cityMatchBonus + countryMatchBonus
+		city := profile.ResidentCity
+		reasons = append(reasons, employer.CandidateSuggestionReason{
+			ReasonType: employer.InOpeningCityReason,
+			Detail:     &city,
+			Text:       "Lives in " + city,
+		})
+	case candidate.InOpeningCountry:
+		score += countryMatchBonus
+		country := profile.ResidentCountryCode
+		reasons = append(reasons, employer.CandidateSuggestionReason{
+			ReasonType: employer.InOpeningCountryReason,
+			Detail:     &country,
+			Text:       "Lives in a country of the Opening",
+		})
+	}
+
+	return db.CandidateSuggestion{
+		HubUserID: profile.HubUserID,
+		Score:     min(int(math.Round(score)), 100),
+		Yoe:       candidate.Yoe,
+		Reasons:   reasons,
+	}
+}
@@ -0,0 +1,195 @@
+package granger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/caldav"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+)
+
+// A sync makes several requests to the CalDAV server
+const caldavSyncTimeout = 2 * time.Minute
+
+// syncCalDAV fetches the free/busy of the CalDAV calendars of the org users,
+// which is used when the interview slots are proposed to the candidates. The
+// interviews that an org user has RSVPed YES to are written to the calendar
+// and the ones that are cancelled or declined later are removed from it.
+func (g *Granger) syncCalDAV(quit <-chan struct{}) {
+	g.log.Dbg("Starting syncCalDAV job")
+	defer g.log.Dbg("syncCalDAV job finished")
+	defer g.wg.Done()
+
+	for {
+		ticker := time.NewTicker(vetchi.SyncCalDAVInterval)
+		select {
+		case <-quit:
+			ticker.Stop()
+			g.log.Dbg("syncCalDAV received quit signal")
+			return
+		case <-ticker.C:
+			ticker.Stop()
+			ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+			connections, err := g.db.GetDueCalDAVConnections(
+				ctx,
+				vetchi.MaxCalDAVSyncsPerBatch,
+			)
+			cancel()
+			if err != nil {
+				g.log.Err("failed to get due caldav connections", "error", err)
+				continue
+			}
+
+			for _, connection := range connections {
+				ctx, cancel := context.WithTimeout(
+					context.Background(),
+					caldavSyncTimeout,
+				)
+				err := g.syncCalDAVConnection(ctx, connection)
+				cancel()
+				if err != nil {
+					g.log.Err(
+						"failed to sync caldav connection",
+						"org_user_id", connection.OrgUserID,
+						"error", err,
+					)
+				}
+			}
+		}
+	}
+}
+
+// syncCalDAVConnection records the failures with the CalDAV server against
+// the connection, so that the org user gets to see them. Only the db
+// failures are returned.
+func (g *Granger) syncCalDAVConnection(
+	ctx context.Context,
+	connection db.DueCalDAVConnection,
+) error {
+	req := db.SaveCalDAVSyncReq{
+		OrgUserID:  connection.OrgUserID,
+		NextSyncAt: time.Now().Add(vetchi.CalDAVResyncDelay),
+	}
+
+	var syncErrors []error
+	client, err := g.caldavClient(connection)
+	if err != nil {
+		req.SyncError = err.Error()
+		return g.saveCalDAVSync(ctx, req)
+	}
+
+	now := time.Now()
+	periods, err := client.FreeBusy(
+		ctx,
+		now,
+		now.Add(vetchi.MaxSchedulingWindow),
+	)
+	if err != nil {
+		syncErrors = append(syncErrors, err)
+	} else {
+		req.FreeBusySynced = true
+		req.BusyPeriods = []db.BusyPeriod{}
+		for _, period := range periods {
+			req.BusyPeriods = append(req.BusyPeriods, db.BusyPeriod{
+				StartTime: period.Start,
+				EndTime:   period.End,
+			})
+		}
+	}
+
+	changes, err := g.db.GetCalDAVEventChanges(ctx, connection.OrgUserID)
+	if err != nil {
+		return err
+	}
+
+	for _, interview := range changes.WriteInterviews {
+		err := client.PutEvent(
+			ctx,
+			interview.InterviewID,
+			util.ICalendar("", []util.ICalEvent{g.caldavEvent(interview)}),
+		)
+		if err != nil {
+			syncErrors = append(syncErrors, err)
+			continue
+		}
+		req.WrittenEvents = append(req.WrittenEvents, db.WrittenCalDAVEvent{
+			InterviewID:  interview.InterviewID,
+			ICalSequence: interview.ICalSequence,
+		})
+	}
+
+	for _, interviewID := range changes.DeleteInterviewIDs {
+		err := client.DeleteEvent(ctx, interviewID)
+		if err != nil {
+			syncErrors = append(syncErrors, err)
+			continue
+		}
+		req.DeletedInterviewIDs = append(req.DeletedInterviewIDs, interviewID)
+	}
+
+	if len(syncErrors) > 0 {
+		req.SyncError = errors.Join(syncErrors...).Error()
+	}
+	return g.saveCalDAVSync(ctx, req)
+}
+
+func (g *Granger) caldavClient(
+	connection db.DueCalDAVConnection,
+) (*caldav.Client, error) {
+	password, err := util.DecryptSecret(
+		g.calendarCredentialsKey,
+		connection.EncryptedPassword,
+	)
+	if err != nil {
+		// Most likely the key is rotated and the org user has to connect
+		// the calendar again
+		g.log.Err("failed to decrypt caldav password", "error", err)
+		return nil, errors.New("stored credentials are unusable, reconnect")
+	}
+
+	return caldav.NewClient(
+		connection.CalendarURL,
+		connection.Username,
+		password,
+		vetchi.CalDAVRequestTimeout,
+		g.caldavAllowedHosts,
+	)
+}
+
+// caldavEvent has neither an organizer nor attendees, as the CalDAV server
+// would otherwise send its own invites to the participants
+func (g *Granger) caldavEvent(
+	interview db.CalendarFeedInterview,
+) util.ICalEvent {
+	return util.ICalEvent{
+		UID:       interview.InterviewID + "@" + vetchi.ICalUIDDomain,
+		Sequence:  interview.ICalSequence,
+		StartTime: interview.StartTime,
+		EndTime:   interview.EndTime,
+		Summary: fmt.Sprintf(
+			"%s interview of %s for %s",
+			interview.InterviewType,
+			interview.CandidateName,
+			interview.OpeningTitle,
+		),
+		Description: interview.Description,
+		Location:    interview.MeetingURL,
+		URL:         g.employerBaseURL + "/interviews/" + interview.InterviewID,
+	}
+}
+
+func (g *Granger) saveCalDAVSync(
+	ctx context.Context,
+	req db.SaveCalDAVSyncReq,
+) error {
+	err := g.db.SaveCalDAVSync(ctx, req)
+	if errors.Is(err, db.ErrNoCalDAVConnection) {
+		// Disconnected during the sync
+		return nil
+	}
+	return err
+}
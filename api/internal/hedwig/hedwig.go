@@ -28,6 +28,24 @@ const (
 	NotifyCandidateOffer         = "notify-candidate-offer"
 	AddOfficialEmail             = "add-official-email"
 	EndorsementRequest           = "endorsement-request"
+	OpeningApprovalRequest       = "opening-approval-request"
+	OpeningApprovalRejected      = "opening-approval-rejected"
+	ApplicationExpired           = "application-expired"
+	OpeningScheduleDigest        = "opening-schedule-digest"
+	OfferRevised                 = "offer-revised"
+	OfferRescinded               = "offer-rescinded"
+	OfferExpired                 = "offer-expired"
+	OfferResponse                = "offer-response"
+	InterviewRescheduled         = "interview-rescheduled"
+	InterviewCancelled           = "interview-cancelled"
+	InterviewRescheduleRequested = "interview-reschedule-requested"
+	InterviewSchedulingRequest   = "interview-scheduling-request"
+	TakeHomeAssignment           = "take-home-assignment"
+	TakeHomeSubmitted            = "take-home-submitted"
+	TakeHomeReminder             = "take-home-reminder"
+	CandidacyStateChanged        = "candidacy-state-changed"
+	InviteToApply                = "invite-to-apply"
+	SavedSearchAlert             = "saved-search-alert"
 )
 
 type Hedwig interface {
@@ -53,6 +71,24 @@ func NewHedwig(log util.Logger) (Hedwig, error) {
 		NotifyCandidateOffer,
 		AddOfficialEmail,
 		EndorsementRequest,
+		OpeningApprovalRequest,
+		OpeningApprovalRejected,
+		ApplicationExpired,
+		OpeningScheduleDigest,
+		OfferRevised,
+		OfferRescinded,
+		OfferExpired,
+		OfferResponse,
+		InterviewRescheduled,
+		InterviewCancelled,
+		InterviewRescheduleRequested,
+		InterviewSchedulingRequest,
+		TakeHomeAssignment,
+		TakeHomeSubmitted,
+		TakeHomeReminder,
+		CandidacyStateChanged,
+		InviteToApply,
+		SavedSearchAlert,
 	} {
 		fi, err := os.Stat(filepath.Join("hedwig", "templates", tmpl+".txt"))
 		if err != nil {
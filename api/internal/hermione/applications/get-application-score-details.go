@@ -0,0 +1,51 @@
+package applications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetApplicationScoreDetails(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetApplicationScoreDetails")
+		var getScoreDetailsReq employer.GetApplicationScoreDetailsRequest
+		err := json.NewDecoder(r.Body).Decode(&getScoreDetailsReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getScoreDetailsReq) {
+			h.Dbg("invalid request", "error", err)
+			return
+		}
+		h.Dbg("validated", "req", getScoreDetailsReq)
+
+		scores, err := h.DB().
+			GetApplicationScoreDetails(r.Context(), getScoreDetailsReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoApplication) {
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get application score details", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("got application score details", "count", len(scores))
+		err = json.NewEncoder(w).Encode(scores)
+		if err != nil {
+			h.Err("failed to encode application scores", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func AddBookmark(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered AddBookmark")
+		var addBookmarkReq hub.AddBookmarkRequest
+		err := json.NewDecoder(r.Body).Decode(&addBookmarkReq)
+		if err != nil {
+			h.Dbg("failed to decode add bookmark request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &addBookmarkReq) {
+			h.Dbg("validation failed", "req", addBookmarkReq)
+			return
+		}
+
+		if !isTargetComplete(addBookmarkReq.Target) {
+			h.Dbg("incomplete target", "target", addBookmarkReq.Target)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+		h.Dbg("validated", "req", addBookmarkReq)
+
+		err = h.DB().AddBookmark(r.Context(), db.AddBookmarkReq{
+			BookmarkID: util.RandomUniqueID(vetchi.BookmarkIDLenBytes),
+			Target:     addBookmarkReq.Target,
+			Folder:     addBookmarkReq.Folder,
+			Note:       addBookmarkReq.Note,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoBookmarkTarget) {
+				h.Dbg("bookmark target not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to add bookmark", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("added bookmark", "target", addBookmarkReq.Target)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// isTargetComplete checks that the target has the fields that its
+// bookmark type needs
+func isTargetComplete(target hub.BookmarkTarget) bool {
+	present := func(s *string) bool {
+		return s != nil && *s != ""
+	}
+
+	switch target.BookmarkType {
+	case hub.OpeningBookmark:
+		return present(target.CompanyDomain) &&
+			present(target.OpeningIDWithinCompany)
+	case hub.PostBookmark, hub.EmployerPostBookmark, hub.IncognitoPostBookmark:
+		return present(target.PostID)
+	case hub.PostCommentBookmark, hub.IncognitoPostCommentBookmark:
+		return present(target.CommentID)
+	}
+
+	return false
+}
@@ -0,0 +1,29 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+)
+
+func GetBookmarkFolders(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetBookmarkFolders")
+
+		folders, err := h.DB().GetBookmarkFolders(r.Context())
+		if err != nil {
+			h.Dbg("failed to get bookmark folders", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("got bookmark folders", "count", len(folders))
+		err = json.NewEncoder(w).Encode(folders)
+		if err != nil {
+			h.Err("failed to encode bookmark folders", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
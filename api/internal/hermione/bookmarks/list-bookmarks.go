@@ -0,0 +1,47 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func ListBookmarks(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ListBookmarks")
+		var listBookmarksReq hub.ListBookmarksRequest
+		err := json.NewDecoder(r.Body).Decode(&listBookmarksReq)
+		if err != nil {
+			h.Dbg("failed to decode list bookmarks request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &listBookmarksReq) {
+			h.Dbg("validation failed", "req", listBookmarksReq)
+			return
+		}
+		h.Dbg("validated", "req", listBookmarksReq)
+
+		if listBookmarksReq.Limit == 0 {
+			listBookmarksReq.Limit = 40
+		}
+
+		bookmarks, err := h.DB().ListBookmarks(r.Context(), listBookmarksReq)
+		if err != nil {
+			h.Dbg("failed to list bookmarks", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("listed bookmarks", "count", len(bookmarks.Bookmarks))
+		err = json.NewEncoder(w).Encode(bookmarks)
+		if err != nil {
+			h.Err("failed to encode bookmarks", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
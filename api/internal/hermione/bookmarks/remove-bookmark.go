@@ -0,0 +1,44 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func RemoveBookmark(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered RemoveBookmark")
+		var removeBookmarkReq hub.RemoveBookmarkRequest
+		err := json.NewDecoder(r.Body).Decode(&removeBookmarkReq)
+		if err != nil {
+			h.Dbg("failed to decode remove bookmark request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &removeBookmarkReq) {
+			h.Dbg("validation failed", "req", removeBookmarkReq)
+			return
+		}
+
+		if !isTargetComplete(removeBookmarkReq.Target) {
+			h.Dbg("incomplete target", "target", removeBookmarkReq.Target)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+		h.Dbg("validated", "req", removeBookmarkReq)
+
+		err = h.DB().RemoveBookmark(r.Context(), removeBookmarkReq.Target)
+		if err != nil {
+			h.Dbg("failed to remove bookmark", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("removed bookmark", "target", removeBookmarkReq.Target)
+		w.WriteHeader(http.StatusOK)
+	}
+}
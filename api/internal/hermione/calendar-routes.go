@@ -0,0 +1,17 @@
+package hermione
+
+import (
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/hermione/interview"
+)
+
+// RegisterCalendarRoutes registers the iCalendar subscription feeds. These
+// are authenticated only by the secret token in the path, as the calendar
+// clients poll them without any session.
+func RegisterCalendarRoutes(h *Hermione) {
+	http.HandleFunc(
+		"GET /calendar-feeds/{token}/interviews.ics",
+		interview.GetCalendarFeed(h),
+	)
+}
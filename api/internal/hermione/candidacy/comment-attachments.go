@@ -0,0 +1,197 @@
+package candidacy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/scanner"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// errBadCommentAttachment is returned for the attachments that are not
+// PDFs, ZIP archives, JPEG or PNG images, are too large, or are found to be
+// infected
+var errBadCommentAttachment = errors.New("bad comment attachment")
+
+var attachmentExtensions = map[string]string{
+	util.PDFContentType:  "pdf",
+	util.ZIPContentType:  "zip",
+	util.JPEGContentType: "jpg",
+	util.PNGContentType:  "png",
+}
+
+// prepareCommentFiles validates and scans all the attachments before
+// uploading any of them. Returns errBadCommentAttachment if any of them is
+// rejected.
+func prepareCommentFiles(
+	ctx context.Context,
+	h wand.Wand,
+	attachments []common.CandidacyCommentAttachment,
+) ([]db.CandidacyCommentFile, error) {
+	var contents [][]byte
+	var contentTypes []string
+	for _, attachment := range attachments {
+		content, contentType, err := util.ValidateAttachment(
+			attachment.Document,
+			vetchi.MaxCandidacyCommentAttachmentSize,
+		)
+		if err != nil {
+			h.Dbg("invalid comment attachment", "error", err)
+			return nil, errBadCommentAttachment
+		}
+
+		err = h.Scanner().Scan(ctx, content)
+		if err != nil {
+			if errors.Is(err, scanner.ErrInfected) {
+				h.Inf(
+					"infected comment attachment",
+					"filename",
+					attachment.Filename,
+				)
+				return nil, errBadCommentAttachment
+			}
+			h.Err("failed to scan comment attachment", "error", err)
+			return nil, err
+		}
+
+		contents = append(contents, content)
+		contentTypes = append(contentTypes, contentType)
+	}
+
+	if len(contents) == 0 {
+		return nil, nil
+	}
+
+	s3Client := newS3Client(h)
+
+	var files []db.CandidacyCommentFile
+	for i, content := range contents {
+		path, err := uploadCommentFile(
+			ctx,
+			h,
+			s3Client,
+			content,
+			contentTypes[i],
+		)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, db.CandidacyCommentFile{
+			Filename:    attachments[i].Filename,
+			FilePath:    path,
+			ContentType: contentTypes[i],
+			SizeBytes:   int64(len(content)),
+		})
+	}
+
+	return files, nil
+}
+
+// uploadCommentFile stores the file under its SHA-512 so that the same file
+// attached to multiple comments is stored only once
+func uploadCommentFile(
+	ctx context.Context,
+	h wand.Wand,
+	s3Client *s3.S3,
+	content []byte,
+	contentType string,
+) (string, error) {
+	hash := sha512.Sum512(content)
+	path := fmt.Sprintf(
+		"%s%x.%s",
+		util.CandidacyCommentsPath,
+		hash,
+		attachmentExtensions[contentType],
+	)
+
+	bucket := h.Config().S3.Bucket
+	_, err := s3Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		h.Dbg("bucket does not exist, attempting to create", "bucket", bucket)
+		_, err = s3Client.CreateBucketWithContext(ctx, &s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			h.Err("failed to create bucket", "error", err)
+			return "", fmt.Errorf("failed to create bucket: %w", err)
+		}
+	}
+
+	_, err = s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(path),
+	})
+	if err == nil {
+		h.Dbg("comment attachment already exists", "path", path)
+		return path, nil
+	}
+
+	_, err = s3Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(path),
+		Body:          bytes.NewReader(content),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(int64(len(content))),
+	})
+	if err != nil {
+		h.Err("failed to upload comment attachment", "error", err)
+		return "", fmt.Errorf("failed to upload comment attachment: %w", err)
+	}
+
+	h.Dbg("uploaded comment attachment", "path", path)
+	return path, nil
+}
+
+// serveCommentAttachment streams the file as an attachment, so that the
+// browsers do not render what the other participants have uploaded
+func serveCommentAttachment(
+	w http.ResponseWriter,
+	r *http.Request,
+	h wand.Wand,
+	file db.CandidacyCommentFile,
+) {
+	result, err := newS3Client(h).GetObjectWithContext(
+		r.Context(),
+		&s3.GetObjectInput{
+			Bucket: aws.String(h.Config().S3.Bucket),
+			Key:    aws.String(file.FilePath),
+		},
+	)
+	if err != nil {
+		h.Err("failed to get comment attachment from S3", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	defer result.Body.Close()
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", file.Filename),
+	)
+	if result.ContentLength != nil {
+		w.Header().
+			Set("Content-Length", fmt.Sprintf("%d", *result.ContentLength))
+	}
+
+	_, err = io.Copy(w, result.Body)
+	if err != nil {
+		// Headers might have been sent already
+		h.Err("failed to stream comment attachment", "error", err)
+		return
+	}
+	h.Dbg("served comment attachment", "path", file.FilePath)
+}
@@ -0,0 +1,25 @@
+package candidacy
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+)
+
+// writeCommentChangeError writes the response for the errors of an edit or
+// a retraction of a comment
+func writeCommentChangeError(h wand.Wand, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, db.ErrNoCandidacyComment):
+		h.Dbg("Comment not found", "error", err)
+		http.Error(w, "", http.StatusNotFound)
+	case errors.Is(err, db.ErrCommentNotEditable):
+		h.Dbg("Comment not editable", "error", err)
+		http.Error(w, "", http.StatusUnprocessableEntity)
+	default:
+		h.Dbg("Internal error while changing comment", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+	}
+}
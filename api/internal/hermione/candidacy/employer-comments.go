@@ -2,6 +2,7 @@ package candidacy
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/vetchium/vetchium/api/internal/db"
@@ -27,8 +28,27 @@ func EmployerAddComment(h wand.Wand) http.HandlerFunc {
 		}
 		h.Dbg("validated", "addCommentReq", addCommentReq)
 
-		commentID, err := h.DB().
-			AddEmployerCandidacyComment(r.Context(), addCommentReq)
+		files, err := prepareCommentFiles(
+			r.Context(),
+			h,
+			addCommentReq.Attachments,
+		)
+		if err != nil {
+			if errors.Is(err, errBadCommentAttachment) {
+				http.Error(w, "", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		commentID, err := h.DB().AddEmployerCandidacyComment(
+			r.Context(),
+			db.AddEmployerCandidacyCommentReq{
+				AddEmployerCandidacyCommentRequest: addCommentReq,
+				Files:                              files,
+			},
+		)
 		if err != nil {
 			switch err {
 			case db.ErrNoOpening:
@@ -86,3 +106,130 @@ func EmployerGetComments(h wand.Wand) http.HandlerFunc {
 		}
 	}
 }
+
+func EmployerEditComment(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered EmployerEditComment")
+		var editCommentReq common.EditCandidacyCommentRequest
+		err := json.NewDecoder(r.Body).Decode(&editCommentReq)
+		if err != nil {
+			h.Dbg("Error decoding request body", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &editCommentReq) {
+			h.Dbg("Validation failed")
+			return
+		}
+		h.Dbg("validated", "editCommentReq", editCommentReq)
+
+		err = h.DB().EditEmployerCandidacyComment(r.Context(), editCommentReq)
+		if err != nil {
+			writeCommentChangeError(h, w, err)
+			return
+		}
+
+		h.Dbg("Edited comment", "commentID", editCommentReq.CommentID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func EmployerRetractComment(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered EmployerRetractComment")
+		var retractCommentReq common.RetractCandidacyCommentRequest
+		err := json.NewDecoder(r.Body).Decode(&retractCommentReq)
+		if err != nil {
+			h.Dbg("Error decoding request body", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &retractCommentReq) {
+			h.Dbg("Validation failed")
+			return
+		}
+		h.Dbg("validated", "retractCommentReq", retractCommentReq)
+
+		err = h.DB().
+			RetractEmployerCandidacyComment(r.Context(), retractCommentReq)
+		if err != nil {
+			writeCommentChangeError(h, w, err)
+			return
+		}
+
+		h.Dbg("Retracted comment", "commentID", retractCommentReq.CommentID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func EmployerMarkCommentsRead(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered EmployerMarkCommentsRead")
+		var markReadReq common.MarkCandidacyCommentsReadRequest
+		err := json.NewDecoder(r.Body).Decode(&markReadReq)
+		if err != nil {
+			h.Dbg("Error decoding request body", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &markReadReq) {
+			h.Dbg("Validation failed")
+			return
+		}
+		h.Dbg("validated", "markReadReq", markReadReq)
+
+		err = h.DB().MarkEmployerCandidacyCommentsRead(r.Context(), markReadReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacyComment) {
+				h.Dbg("Comment not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("Internal error while marking comments read", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("Marked comments read", "commentID", markReadReq.CommentID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func EmployerGetCommentAttachment(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered EmployerGetCommentAttachment")
+		var getAttachmentReq common.GetCandidacyCommentAttachmentRequest
+		err := json.NewDecoder(r.Body).Decode(&getAttachmentReq)
+		if err != nil {
+			h.Dbg("Error decoding request body", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getAttachmentReq) {
+			h.Dbg("Validation failed")
+			return
+		}
+		h.Dbg("validated", "getAttachmentReq", getAttachmentReq)
+
+		file, err := h.DB().
+			GetEmployerCommentAttachment(r.Context(), getAttachmentReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCommentAttachment) {
+				h.Dbg("Comment attachment not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("Internal error while getting attachment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		serveCommentAttachment(w, r, h, file)
+	}
+}
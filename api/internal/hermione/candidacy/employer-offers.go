@@ -0,0 +1,84 @@
+package candidacy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+func GetEmployerOffers(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetEmployerOffers")
+		var getOffersReq common.GetOffersRequest
+		err := json.NewDecoder(r.Body).Decode(&getOffersReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getOffersReq) {
+			h.Dbg("failed to validate request", "request", getOffersReq)
+			return
+		}
+		h.Dbg("validated", "getOffersReq", getOffersReq)
+
+		offers, err := h.DB().GetEmployerOffers(r.Context(), getOffersReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("candidacy not found", "request", getOffersReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get offers", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("got offers", "count", len(offers))
+		err = json.NewEncoder(w).Encode(offers)
+		if err != nil {
+			h.Err("failed to encode offers", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func GetEmployerOfferDocument(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetEmployerOfferDocument")
+		var getOfferDocumentReq common.GetOfferDocumentRequest
+		err := json.NewDecoder(r.Body).Decode(&getOfferDocumentReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getOfferDocumentReq) {
+			h.Dbg("failed to validate request", "request", getOfferDocumentReq)
+			return
+		}
+		h.Dbg("validated", "getOfferDocumentReq", getOfferDocumentReq)
+
+		path, err := h.DB().
+			GetEmployerOfferDocumentPath(r.Context(), getOfferDocumentReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOffer) {
+				h.Dbg("no offer document", "request", getOfferDocumentReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get offer document path", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		serveOfferDocument(w, r, h, getOfferDocumentReq, path)
+	}
+}
@@ -0,0 +1,44 @@
+package candidacy
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+)
+
+// ESignWebhook is called by the e-signature provider when the status of an
+// envelope changes. The status itself is not trusted from the webhook. The
+// signature is only queued for granger to fetch the status from the
+// provider right away, so the webhook does not need to be authenticated.
+func ESignWebhook(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ESignWebhook")
+		envelopeID, err := h.ESign().ParseWebhook(r)
+		if err != nil {
+			h.Dbg("failed to parse webhook", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		err = h.DB().PollOfferSignatureNow(
+			r.Context(),
+			h.ESign().Name(),
+			envelopeID,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOfferSignature) {
+				h.Dbg("no offer signature", "envelope_id", envelopeID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to queue offer signature", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("queued offer signature", "envelope_id", envelopeID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,174 @@
+package candidacy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func RespondToOffer(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered RespondToOffer")
+		var respondToOfferReq hub.RespondToOfferRequest
+		err := json.NewDecoder(r.Body).Decode(&respondToOfferReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &respondToOfferReq) {
+			h.Dbg("failed to validate request", "request", respondToOfferReq)
+			return
+		}
+		h.Dbg("validated", "respondToOfferReq", respondToOfferReq)
+
+		candidateInfo, err := h.DB().
+			GetCandidateInfo(r.Context(), respondToOfferReq.CandidacyID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("candidacy not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get candidate info", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		response := "accepted"
+		if respondToOfferReq.Response == hub.DeclineOffer {
+			response = "declined"
+		}
+		args := map[string]string{
+			"CandidateName": candidateInfo.CandidateName,
+			"OpeningTitle":  candidateInfo.OpeningTitle,
+			"Response":      response,
+			"CandidacyURL": h.Config().Employer.WebURL + "/candidacy/" +
+				respondToOfferReq.CandidacyID,
+		}
+		if respondToOfferReq.Reason != nil {
+			args["Reason"] = *respondToOfferReq.Reason
+		}
+
+		email, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.OfferResponse,
+			Args:         args,
+			EmailFrom:    vetchi.EmailFrom,
+			EmailTo:      []string{candidateInfo.RecruiterEmail},
+			Subject: candidateInfo.CandidateName + " has " + response +
+				" the offer for " + candidateInfo.OpeningTitle,
+		})
+		if err != nil {
+			h.Err("failed to generate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().RespondToOffer(r.Context(), db.RespondToOfferReq{
+			Request: respondToOfferReq,
+			Email:   email,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoOffer) ||
+				errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("no pending offer", "request", respondToOfferReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrAllPositionsFilled) {
+				h.Dbg("all positions filled", "request", respondToOfferReq)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to respond to offer", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("responded to offer", "request", respondToOfferReq)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func GetHubOffers(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetHubOffers")
+		var getOffersReq common.GetOffersRequest
+		err := json.NewDecoder(r.Body).Decode(&getOffersReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getOffersReq) {
+			h.Dbg("failed to validate request", "request", getOffersReq)
+			return
+		}
+		h.Dbg("validated", "getOffersReq", getOffersReq)
+
+		offers, err := h.DB().GetHubOffers(r.Context(), getOffersReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("candidacy not found", "request", getOffersReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get offers", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("got offers", "count", len(offers))
+		err = json.NewEncoder(w).Encode(offers)
+		if err != nil {
+			h.Err("failed to encode offers", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func GetHubOfferDocument(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetHubOfferDocument")
+		var getOfferDocumentReq common.GetOfferDocumentRequest
+		err := json.NewDecoder(r.Body).Decode(&getOfferDocumentReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getOfferDocumentReq) {
+			h.Dbg("failed to validate request", "request", getOfferDocumentReq)
+			return
+		}
+		h.Dbg("validated", "getOfferDocumentReq", getOfferDocumentReq)
+
+		path, err := h.DB().
+			GetHubOfferDocumentPath(r.Context(), getOfferDocumentReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOffer) {
+				h.Dbg("no offer document", "request", getOfferDocumentReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get offer document path", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		serveOfferDocument(w, r, h, getOfferDocumentReq, path)
+	}
+}
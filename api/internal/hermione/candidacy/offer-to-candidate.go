@@ -3,12 +3,14 @@ package candidacy
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/hedwig"
 	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
 	"github.com/vetchium/vetchium/typespec/employer"
 )
 
@@ -28,28 +30,69 @@ func OfferToCandidate(h wand.Wand) http.HandlerFunc {
 		}
 		h.Dbg("Validated")
 
+		if !offerToCandidateRequest.ExpiresAt.After(time.Now()) {
+			h.Dbg("expires_at is not in the future")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"expires_at"},
+			})
+			return
+		}
+
+		attachments := offerToCandidateRequest.Attachments
+		if offerToCandidateRequest.OfferDocument != "" {
+			attachments = append(attachments, common.OfferAttachment{
+				Filename: "offer-document.pdf",
+				Document: offerToCandidateRequest.OfferDocument,
+			})
+		}
+
 		// Get candidate and opening details for email
 		candidateInfo, err := h.DB().
 			GetCandidateInfo(r.Context(), offerToCandidateRequest.CandidacyID)
 		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("Candidacy not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
 			h.Dbg("Error getting candidate info", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
 
+		offer, err := prepareOffer(
+			r.Context(),
+			h,
+			candidateInfo,
+			offerToCandidateRequest.Compensation,
+			offerToCandidateRequest.StartDate,
+			offerToCandidateRequest.ExpiresAt,
+			attachments,
+		)
+		if err != nil {
+			if errors.Is(err, errBadOfferDocument) {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"attachments"},
+				})
+				return
+			}
+			h.Err("Error preparing offer", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
 		// Generate email using hedwig
 		emailReq := hedwig.GenerateEmailReq{
 			TemplateName: hedwig.NotifyCandidateOffer,
-			Args: map[string]string{
-				"CandidateName": candidateInfo.CandidateName,
-				"CompanyName":   candidateInfo.CompanyName,
-				"OpeningTitle":  candidateInfo.OpeningTitle,
-				"CandidacyURL": fmt.Sprintf(
-					"http://localhost:3001/candidacy/%s",
-					offerToCandidateRequest.CandidacyID,
-				),
-			},
-			EmailFrom: "notifications@vetchi.com",
+			Args: offerEmailArgs(
+				h,
+				candidateInfo,
+				offerToCandidateRequest.CandidacyID,
+				offer,
+			),
+			EmailFrom: vetchi.EmailFrom,
 			EmailTo:   []string{candidateInfo.CandidateEmail},
 			Subject:   "Congratulations! Offer from " + candidateInfo.CompanyName,
 		}
@@ -64,6 +107,7 @@ func OfferToCandidate(h wand.Wand) http.HandlerFunc {
 		// Create request with comment and email
 		req := db.OfferToCandidateReq{
 			CandidacyID: offerToCandidateRequest.CandidacyID,
+			Offer:       offer,
 			Comment:     "Offer extended to candidate",
 			Email:       email,
 		}
@@ -0,0 +1,251 @@
+package candidacy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+var errBadOfferDocument = errors.New("bad offer document")
+
+func newS3Client(h wand.Wand) *s3.S3 {
+	cfg := h.Config()
+	s3Config := &aws.Config{
+		Credentials: credentials.NewStaticCredentials(
+			cfg.S3.AccessKey,
+			cfg.S3.SecretKey,
+			"",
+		),
+		Endpoint:         aws.String(cfg.S3.Endpoint),
+		Region:           aws.String(cfg.S3.Region),
+		S3ForcePathStyle: aws.Bool(true), // Required for MinIO
+	}
+	return s3.New(session.Must(session.NewSession(s3Config)))
+}
+
+// uploadOfferDocument stores the PDF under its SHA-512 so that the same
+// document attached to multiple versions of an offer is stored only once
+func uploadOfferDocument(
+	ctx context.Context,
+	h wand.Wand,
+	s3Client *s3.S3,
+	pdfBytes []byte,
+) (string, error) {
+	hash := sha512.Sum512(pdfBytes)
+	filename := fmt.Sprintf("%s%x.pdf", util.OffersPath, hash)
+
+	bucket := h.Config().S3.Bucket
+	_, err := s3Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		h.Dbg("bucket does not exist, attempting to create", "bucket", bucket)
+		_, err = s3Client.CreateBucketWithContext(ctx, &s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			h.Err("failed to create bucket", "error", err)
+			return "", fmt.Errorf("failed to create bucket: %w", err)
+		}
+	}
+
+	_, err = s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(filename),
+	})
+	if err == nil {
+		h.Dbg("offer document already exists", "filename", filename)
+		return filename, nil
+	}
+
+	_, err = s3Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(filename),
+		Body:          bytes.NewReader(pdfBytes),
+		ContentType:   aws.String("application/pdf"),
+		ContentLength: aws.Int64(int64(len(pdfBytes))),
+	})
+	if err != nil {
+		h.Err("failed to upload offer document", "error", err)
+		return "", fmt.Errorf("failed to upload offer document: %w", err)
+	}
+
+	h.Dbg("uploaded offer document", "filename", filename)
+	return filename, nil
+}
+
+// prepareOffer generates the offer letter and uploads it along with the
+// attachments. Returns errBadOfferDocument if any attachment is not a PDF.
+func prepareOffer(
+	ctx context.Context,
+	h wand.Wand,
+	info db.CandidateInfo,
+	compensation common.OfferCompensation,
+	startDate string,
+	expiresAt time.Time,
+	attachments []common.OfferAttachment,
+) (db.OfferDetails, error) {
+	// Validate all the attachments before uploading any
+	var documents [][]byte
+	for _, attachment := range attachments {
+		pdfBytes, err := util.ValidateAndSanitizePDF(attachment.Document)
+		if err != nil {
+			h.Dbg("invalid offer attachment", "error", err)
+			return db.OfferDetails{}, errBadOfferDocument
+		}
+		documents = append(documents, pdfBytes)
+	}
+
+	offer := db.OfferDetails{
+		Compensation: compensation,
+		StartDate:    startDate,
+		ExpiresAt:    expiresAt,
+	}
+
+	s3Client := newS3Client(h)
+
+	letter := offerLetter(info, offer)
+	path, err := uploadOfferDocument(ctx, h, s3Client, letter)
+	if err != nil {
+		return db.OfferDetails{}, err
+	}
+	offer.OfferLetterPath = path
+
+	for i, document := range documents {
+		path, err := uploadOfferDocument(ctx, h, s3Client, document)
+		if err != nil {
+			return db.OfferDetails{}, err
+		}
+		offer.Attachments = append(offer.Attachments, db.OfferDocument{
+			Filename: attachments[i].Filename,
+			FilePath: path,
+		})
+	}
+
+	return offer, nil
+}
+
+func compensationText(compensation common.OfferCompensation) string {
+	return strconv.FormatFloat(compensation.Amount, 'f', -1, 64) + " " +
+		string(compensation.Currency)
+}
+
+func offerLetter(info db.CandidateInfo, offer db.OfferDetails) []byte {
+	lines := []string{
+		info.CompanyName,
+		"",
+		"Offer of Employment",
+		"",
+		"Dear " + info.CandidateName + ",",
+		"",
+		fmt.Sprintf(
+			"%s is pleased to offer you the position of %s.",
+			info.CompanyName,
+			info.OpeningTitle,
+		),
+		"",
+		"Compensation: " + compensationText(offer.Compensation),
+	}
+	if offer.Compensation.Notes != nil {
+		lines = append(lines, *offer.Compensation.Notes)
+	}
+	lines = append(
+		lines,
+		"Start date: "+offer.StartDate,
+		"",
+		fmt.Sprintf(
+			"This offer is valid until %s. Please respond to it on Vetchium.",
+			offer.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+		"",
+		"Sincerely,",
+		info.CompanyName,
+	)
+	return util.TextPDF(lines)
+}
+
+func offerEmailArgs(
+	h wand.Wand,
+	info db.CandidateInfo,
+	candidacyID string,
+	offer db.OfferDetails,
+) map[string]string {
+	return map[string]string{
+		"CandidateName": info.CandidateName,
+		"CompanyName":   info.CompanyName,
+		"OpeningTitle":  info.OpeningTitle,
+		"Compensation":  compensationText(offer.Compensation),
+		"StartDate":     offer.StartDate,
+		"ExpiresAt":     offer.ExpiresAt.UTC().Format(time.RFC1123),
+		"CandidacyURL":  h.Config().Hub.WebURL + "/candidacy/" + candidacyID,
+	}
+}
+
+func serveOfferDocument(
+	w http.ResponseWriter,
+	r *http.Request,
+	h wand.Wand,
+	req common.GetOfferDocumentRequest,
+	path string,
+) {
+	result, err := newS3Client(h).GetObjectWithContext(
+		r.Context(),
+		&s3.GetObjectInput{
+			Bucket: aws.String(h.Config().S3.Bucket),
+			Key:    aws.String(path),
+		},
+	)
+	if err != nil {
+		h.Err("failed to get offer document from S3", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	defer result.Body.Close()
+
+	filename := fmt.Sprintf("%s-offer-v%d.pdf", req.CandidacyID, req.Version)
+	if req.Signed != nil && *req.Signed {
+		filename = fmt.Sprintf(
+			"%s-offer-v%d-signed.pdf",
+			req.CandidacyID,
+			req.Version,
+		)
+	} else if req.AttachmentNumber != nil {
+		filename = fmt.Sprintf(
+			"%s-offer-v%d-%d.pdf",
+			req.CandidacyID,
+			req.Version,
+			*req.AttachmentNumber,
+		)
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().
+		Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	if result.ContentLength != nil {
+		w.Header().
+			Set("Content-Length", fmt.Sprintf("%d", *result.ContentLength))
+	}
+
+	_, err = io.Copy(w, result.Body)
+	if err != nil {
+		// Headers might have been sent already
+		h.Err("failed to stream offer document", "error", err)
+		return
+	}
+	h.Dbg("served offer document", "filename", filename)
+}
@@ -0,0 +1,84 @@
+package candidacy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func RescindOffer(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered RescindOffer")
+		var rescindOfferReq employer.RescindOfferRequest
+		err := json.NewDecoder(r.Body).Decode(&rescindOfferReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &rescindOfferReq) {
+			h.Dbg("failed to validate request", "request", rescindOfferReq)
+			return
+		}
+		h.Dbg("validated", "candidacy_id", rescindOfferReq.CandidacyID)
+
+		candidateInfo, err := h.DB().
+			GetCandidateInfo(r.Context(), rescindOfferReq.CandidacyID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("candidacy not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get candidate info", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		email, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.OfferRescinded,
+			Args: map[string]string{
+				"CandidateName": candidateInfo.CandidateName,
+				"CompanyName":   candidateInfo.CompanyName,
+				"OpeningTitle":  candidateInfo.OpeningTitle,
+				"Reason":        rescindOfferReq.Reason,
+				"CandidacyURL": h.Config().Hub.WebURL + "/candidacy/" +
+					rescindOfferReq.CandidacyID,
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{candidateInfo.CandidateEmail},
+			Subject:   "Offer from " + candidateInfo.CompanyName + " rescinded",
+		})
+		if err != nil {
+			h.Err("failed to generate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().RescindOffer(r.Context(), db.RescindOfferReq{
+			CandidacyID: rescindOfferReq.CandidacyID,
+			Reason:      rescindOfferReq.Reason,
+			Email:       email,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoOffer) {
+				h.Dbg("no pending offer", "request", rescindOfferReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to rescind offer", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("rescinded offer", "candidacy_id", rescindOfferReq.CandidacyID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,116 @@
+package candidacy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func ReviseOffer(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ReviseOffer")
+		var reviseOfferReq employer.ReviseOfferRequest
+		err := json.NewDecoder(r.Body).Decode(&reviseOfferReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &reviseOfferReq) {
+			h.Dbg("failed to validate request", "request", reviseOfferReq)
+			return
+		}
+		h.Dbg("validated", "candidacy_id", reviseOfferReq.CandidacyID)
+
+		if !reviseOfferReq.ExpiresAt.After(time.Now()) {
+			h.Dbg("expires_at is not in the future")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"expires_at"},
+			})
+			return
+		}
+
+		candidateInfo, err := h.DB().
+			GetCandidateInfo(r.Context(), reviseOfferReq.CandidacyID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("candidacy not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get candidate info", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		offer, err := prepareOffer(
+			r.Context(),
+			h,
+			candidateInfo,
+			reviseOfferReq.Compensation,
+			reviseOfferReq.StartDate,
+			reviseOfferReq.ExpiresAt,
+			reviseOfferReq.Attachments,
+		)
+		if err != nil {
+			if errors.Is(err, errBadOfferDocument) {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"attachments"},
+				})
+				return
+			}
+			h.Err("failed to prepare offer", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		email, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.OfferRevised,
+			Args: offerEmailArgs(
+				h,
+				candidateInfo,
+				reviseOfferReq.CandidacyID,
+				offer,
+			),
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{candidateInfo.CandidateEmail},
+			Subject:   "Revised offer from " + candidateInfo.CompanyName,
+		})
+		if err != nil {
+			h.Err("failed to generate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().ReviseOffer(r.Context(), db.ReviseOfferReq{
+			CandidacyID: reviseOfferReq.CandidacyID,
+			Offer:       offer,
+			Comment:     "Offer revised",
+			Email:       email,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoOffer) {
+				h.Dbg("no pending offer", "request", reviseOfferReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to revise offer", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("revised offer", "candidacy_id", reviseOfferReq.CandidacyID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
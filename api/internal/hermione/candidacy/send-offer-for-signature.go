@@ -0,0 +1,134 @@
+package candidacy
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/esign"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func SendOfferForSignature(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SendOfferForSignature")
+		var sendReq employer.SendOfferForSignatureRequest
+		err := json.NewDecoder(r.Body).Decode(&sendReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &sendReq) {
+			h.Dbg("failed to validate request", "request", sendReq)
+			return
+		}
+		h.Dbg("validated", "candidacy_id", sendReq.CandidacyID)
+
+		offer, err := h.DB().
+			GetOfferForSignature(r.Context(), sendReq.CandidacyID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOffer) {
+				h.Dbg("no pending offer", "request", sendReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, db.ErrOfferSentForSignature) {
+				h.Dbg("already sent for signature", "request", sendReq)
+				http.Error(w, "", http.StatusConflict)
+				return
+			}
+			h.Dbg("failed to get offer for signature", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		candidateInfo, err := h.DB().
+			GetCandidateInfo(r.Context(), sendReq.CandidacyID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("candidacy not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get candidate info", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		result, err := newS3Client(h).GetObjectWithContext(
+			r.Context(),
+			&s3.GetObjectInput{
+				Bucket: aws.String(h.Config().S3.Bucket),
+				Key:    aws.String(offer.OfferLetterPath),
+			},
+		)
+		if err != nil {
+			h.Err("failed to get offer letter from S3", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		defer result.Body.Close()
+
+		letter, err := io.ReadAll(result.Body)
+		if err != nil {
+			h.Err("failed to read offer letter", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		envelopeID, err := h.ESign().SendForSignature(
+			r.Context(),
+			esign.SignatureRequest{
+				Document:    letter,
+				SignerName:  candidateInfo.CandidateName,
+				SignerEmail: candidateInfo.CandidateEmail,
+				Subject: "Please sign your offer letter from " +
+					candidateInfo.CompanyName,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, esign.ErrESignDisabled) {
+				h.Dbg("e-signature provider not configured")
+				http.Error(w, "", http.StatusServiceUnavailable)
+				return
+			}
+			h.Err("failed to send offer for signature", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		h.Dbg("sent for signature", "envelope_id", envelopeID)
+
+		err = h.DB().SaveOfferSignature(r.Context(), db.SaveOfferSignatureReq{
+			CandidacyID: sendReq.CandidacyID,
+			Version:     offer.Version,
+			Provider:    h.ESign().Name(),
+			EnvelopeID:  envelopeID,
+		})
+		if err != nil {
+			// TODO: The envelope should be voided with the provider
+			if errors.Is(err, db.ErrNoOffer) {
+				h.Dbg("offer changed meanwhile", "request", sendReq)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, db.ErrOfferSentForSignature) {
+				h.Dbg("already sent for signature", "request", sendReq)
+				http.Error(w, "", http.StatusConflict)
+				return
+			}
+			h.Dbg("failed to save offer signature", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("offer sent for signature", "candidacy_id", sendReq.CandidacyID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
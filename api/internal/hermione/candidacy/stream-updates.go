@@ -0,0 +1,165 @@
+package candidacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type getUpdatesFunc func(
+	context.Context,
+	db.GetCandidacyUpdatesReq,
+) ([]common.CandidacyUpdate, error)
+
+// EmployerStreamUpdates streams the updates of a candidacy of the employer
+// as Server-Sent Events
+func EmployerStreamUpdates(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered EmployerStreamUpdates")
+		streamUpdates(h, w, r, h.DB().GetEmployerCandidacyUpdates)
+	}
+}
+
+// HubStreamUpdates streams the updates of a candidacy of the HubUser as
+// Server-Sent Events
+func HubStreamUpdates(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered HubStreamUpdates")
+		streamUpdates(h, w, r, h.DB().GetHubCandidacyUpdates)
+	}
+}
+
+// streamUpdates sends the updates of the candidacy after the cursor, which is
+// the Last-Event-ID header set by the clients when they reconnect, or else
+// the cursor query parameter. Without a cursor, the stream starts with all
+// the past updates of the candidacy. The stream then sends the new updates as
+// they happen, until the client disconnects.
+func streamUpdates(
+	h wand.Wand,
+	w http.ResponseWriter,
+	r *http.Request,
+	getUpdates getUpdatesFunc,
+) {
+	candidacyID := r.URL.Query().Get("candidacy_id")
+	if candidacyID == "" {
+		h.Dbg("candidacy_id not specified")
+		http.Error(w, "candidacy_id is required", http.StatusBadRequest)
+		return
+	}
+
+	cursor := r.Header.Get("Last-Event-ID")
+	if cursor == "" {
+		cursor = r.URL.Query().Get("cursor")
+	}
+
+	var after int64
+	if cursor != "" {
+		var err error
+		after, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil || after < 0 {
+			h.Dbg("invalid cursor", "cursor", cursor)
+			http.Error(w, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.Err("streaming not supported by the response writer")
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	// Subscribed before the first fetch, so that no update is missed
+	// between the fetch and the subscription
+	signals, unsubscribe := h.Broker().Subscribe(candidacyID)
+	defer unsubscribe()
+
+	ctx := r.Context()
+	updates, err := getUpdates(ctx, db.GetCandidacyUpdatesReq{
+		CandidacyID: candidacyID,
+		After:       after,
+		Limit:       vetchi.MaxCandidacyUpdatesPerBatch,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrNoCandidacy) {
+			h.Dbg("candidacy not found", "candidacy_id", candidacyID)
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		h.Dbg("failed to get candidacy updates", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(vetchi.CandidacyUpdatesKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, update := range updates {
+			data, err := json.Marshal(update)
+			if err != nil {
+				h.Err("failed to marshal candidacy update", "error", err)
+				return
+			}
+
+			_, err = fmt.Fprintf(
+				w,
+				"id: %s\nevent: candidacy-update\ndata: %s\n\n",
+				update.UpdateID,
+				data,
+			)
+			if err != nil {
+				h.Dbg("failed to write candidacy update", "error", err)
+				return
+			}
+
+			after, _ = strconv.ParseInt(update.UpdateID, 10, 64)
+		}
+		flusher.Flush()
+
+		// A full batch may be followed by more updates
+		if len(updates) < vetchi.MaxCandidacyUpdatesPerBatch {
+			select {
+			case <-ctx.Done():
+				h.Dbg("candidacy updates stream closed", "id", candidacyID)
+				return
+			case <-signals:
+			case <-ticker.C:
+				// Comments keep the idle connections from being dropped by
+				// the proxies and are ignored by the clients
+				_, err = fmt.Fprint(w, ": keep-alive\n\n")
+				if err != nil {
+					h.Dbg("failed to write keep-alive", "error", err)
+					return
+				}
+			}
+		}
+
+		updates, err = getUpdates(ctx, db.GetCandidacyUpdatesReq{
+			CandidacyID: candidacyID,
+			After:       after,
+			Limit:       vetchi.MaxCandidacyUpdatesPerBatch,
+		})
+		if err != nil {
+			// The client reconnects and resumes from where it was
+			h.Dbg("failed to get candidacy updates", "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package hermione
+
+import (
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/hermione/careerspage"
+)
+
+// RegisterCareersRoutes registers the public careers page endpoints. These do
+// not need any authentication and are served only for GET requests, so that
+// the responses could be cached.
+func RegisterCareersRoutes(h *Hermione) {
+	http.HandleFunc(
+		"GET /careers/{domain}/openings",
+		careerspage.GetCareersOpenings(h),
+	)
+	http.HandleFunc(
+		"GET /careers/{domain}/openings/{opening_id}",
+		careerspage.GetCareersOpening(h),
+	)
+	http.HandleFunc(
+		"GET /careers/{domain}/feed.xml",
+		careerspage.GetCareersFeed(h),
+	)
+}
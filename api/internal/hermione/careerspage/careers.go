@@ -0,0 +1,145 @@
+package careerspage
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/careers"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// setPublicHeaders makes the response cacheable by the browsers, CDNs and
+// the aggregators and embeddable in the websites of the employers
+func setPublicHeaders(w http.ResponseWriter) {
+	w.Header().Set(
+		"Cache-Control",
+		fmt.Sprintf(
+			"public, max-age=%d",
+			int(vetchi.CareersCacheMaxAge.Seconds()),
+		),
+	)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+}
+
+func openingURL(h wand.Wand, domain string, openingID string) string {
+	return fmt.Sprintf(
+		"%s/org/%s/opening/%s",
+		h.Config().Hub.WebURL,
+		url.PathEscape(domain),
+		url.PathEscape(openingID),
+	)
+}
+
+func careersOpening(
+	h wand.Wand,
+	domain string,
+	opening db.CareersOpening,
+) careers.CareersOpening {
+	var locationTitles []string
+	for _, location := range opening.Locations {
+		locationTitles = append(locationTitles, location.Title)
+	}
+
+	return careers.CareersOpening{
+		OpeningID:          opening.ID,
+		Title:              opening.Title,
+		OpeningType:        opening.OpeningType,
+		YoeMin:             opening.YoeMin,
+		YoeMax:             opening.YoeMax,
+		MinEducationLevel:  opening.MinEducationLevel,
+		LocationTitles:     locationTitles,
+		RemoteCountryCodes: opening.RemoteCountryCodes,
+		Salary:             opening.Salary,
+		URL:                openingURL(h, domain, opening.ID),
+		CloseAt:            opening.CloseAt,
+		CreatedAt:          opening.CreatedAt,
+	}
+}
+
+func jobPosting(
+	h wand.Wand,
+	domain string,
+	opening db.CareersOpening,
+) careers.JobPosting {
+	posting := careers.JobPosting{
+		Context:     "https://schema.org",
+		Type:        "JobPosting",
+		Title:       opening.Title,
+		Description: opening.JD,
+		Identifier: careers.SchemaOrgPropertyValue{
+			Type:  "PropertyValue",
+			Name:  opening.CompanyName,
+			Value: opening.ID,
+		},
+		DatePosted:   opening.CreatedAt.UTC().Format("2006-01-02"),
+		ValidThrough: opening.CloseAt,
+		HiringOrganization: careers.SchemaOrgOrganization{
+			Type:   "Organization",
+			Name:   opening.CompanyName,
+			SameAs: "https://" + domain,
+		},
+		URL: openingURL(h, domain, opening.ID),
+	}
+
+	switch opening.OpeningType {
+	case common.FullTimeOpening:
+		posting.EmploymentType = "FULL_TIME"
+	case common.PartTimeOpening:
+		posting.EmploymentType = "PART_TIME"
+	case common.ContractOpening:
+		posting.EmploymentType = "CONTRACTOR"
+	case common.InternshipOpening:
+		posting.EmploymentType = "INTERN"
+	}
+
+	for _, location := range opening.Locations {
+		place := careers.SchemaOrgPlace{
+			Type: "Place",
+			Name: location.Title,
+			Address: careers.SchemaOrgPostalAddress{
+				Type:           "PostalAddress",
+				StreetAddress:  location.PostalAddress,
+				PostalCode:     location.PostalCode,
+				AddressCountry: location.CountryCode,
+			},
+		}
+		posting.JobLocation = append(posting.JobLocation, place)
+	}
+
+	if len(opening.RemoteCountryCodes) > 0 {
+		posting.JobLocationType = "TELECOMMUTE"
+		global := false
+		var countries []careers.SchemaOrgCountry
+		for _, countryCode := range opening.RemoteCountryCodes {
+			if countryCode == common.GlobalCountryCode {
+				global = true
+				break
+			}
+			countries = append(countries, careers.SchemaOrgCountry{
+				Type: "Country",
+				Name: string(countryCode),
+			})
+		}
+		if !global {
+			posting.ApplicantLocationRequirements = countries
+		}
+	}
+
+	if opening.Salary != nil {
+		posting.BaseSalary = &careers.SchemaOrgMonetaryAmount{
+			Type:     "MonetaryAmount",
+			Currency: string(opening.Salary.Currency),
+			Value: careers.SchemaOrgQuantitativeValue{
+				Type:     "QuantitativeValue",
+				MinValue: opening.Salary.MinAmount,
+				MaxValue: opening.Salary.MaxAmount,
+			},
+		}
+	}
+
+	return posting
+}
@@ -0,0 +1,114 @@
+package careerspage
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/careers"
+)
+
+// RSS 2.0, https://www.rssboard.org/rss-specification
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	Category    string  `xml:"category"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func GetCareersFeed(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetCareersFeed")
+		getCareersOpeningsReq := careers.GetCareersOpeningsRequest{
+			Domain: r.PathValue("domain"),
+		}
+
+		if !h.Vator().Struct(w, &getCareersOpeningsReq) {
+			h.Dbg("validation failed", "req", getCareersOpeningsReq)
+			return
+		}
+		h.Dbg("validated", "req", getCareersOpeningsReq)
+
+		careersPage, err := h.DB().
+			GetCareersPage(r.Context(), getCareersOpeningsReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEmployer) {
+				h.Dbg("no careers feed", "domain", getCareersOpeningsReq.Domain)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get careers page", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		domain := getCareersOpeningsReq.Domain
+		feed := rss{
+			Version: "2.0",
+			Channel: rssChannel{
+				Title: fmt.Sprintf("%s - Openings", careersPage.CompanyName),
+				Link: fmt.Sprintf(
+					"%s/org/%s",
+					h.Config().Hub.WebURL,
+					url.PathEscape(domain),
+				),
+				Description: fmt.Sprintf(
+					"Openings at %s",
+					careersPage.CompanyName,
+				),
+				LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+			},
+		}
+		for _, opening := range careersPage.Openings {
+			link := openingURL(h, domain, opening.ID)
+			feed.Channel.Items = append(feed.Channel.Items, rssItem{
+				Title:       opening.Title,
+				Link:        link,
+				Description: opening.JD,
+				Category:    string(opening.OpeningType),
+				GUID:        rssGUID{IsPermaLink: true, Value: link},
+				PubDate:     opening.CreatedAt.UTC().Format(time.RFC1123Z),
+			})
+		}
+
+		setPublicHeaders(w)
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		_, err = w.Write([]byte(xml.Header))
+		if err != nil {
+			h.Err("failed to write feed", "error", err)
+			return
+		}
+		err = xml.NewEncoder(w).Encode(feed)
+		if err != nil {
+			h.Err("failed to encode feed", "error", err)
+			return
+		}
+	}
+}
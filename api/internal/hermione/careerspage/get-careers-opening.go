@@ -0,0 +1,52 @@
+package careerspage
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/careers"
+)
+
+func GetCareersOpening(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetCareersOpening")
+		getCareersOpeningReq := careers.GetCareersOpeningRequest{
+			Domain:    r.PathValue("domain"),
+			OpeningID: r.PathValue("opening_id"),
+		}
+
+		if !h.Vator().Struct(w, &getCareersOpeningReq) {
+			h.Dbg("validation failed", "req", getCareersOpeningReq)
+			return
+		}
+		h.Dbg("validated", "req", getCareersOpeningReq)
+
+		opening, err := h.DB().
+			GetCareersOpening(r.Context(), getCareersOpeningReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEmployer) ||
+				errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("no careers opening", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get careers opening", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		setPublicHeaders(w)
+		w.Header().Set("Content-Type", "application/ld+json")
+		err = json.NewEncoder(w).Encode(
+			jobPosting(h, getCareersOpeningReq.Domain, opening),
+		)
+		if err != nil {
+			h.Err("failed to encode job posting", "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package careerspage
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/careers"
+)
+
+func GetCareersOpenings(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetCareersOpenings")
+		getCareersOpeningsReq := careers.GetCareersOpeningsRequest{
+			Domain: r.PathValue("domain"),
+		}
+
+		if !h.Vator().Struct(w, &getCareersOpeningsReq) {
+			h.Dbg("validation failed", "req", getCareersOpeningsReq)
+			return
+		}
+		h.Dbg("validated", "req", getCareersOpeningsReq)
+
+		careersPage, err := h.DB().
+			GetCareersPage(r.Context(), getCareersOpeningsReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEmployer) {
+				h.Dbg("no careers page", "domain", getCareersOpeningsReq.Domain)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get careers page", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		openings := make([]careers.CareersOpening, 0, len(careersPage.Openings))
+		for _, opening := range careersPage.Openings {
+			openings = append(
+				openings,
+				careersOpening(h, getCareersOpeningsReq.Domain, opening),
+			)
+		}
+
+		setPublicHeaders(w)
+		err = json.NewEncoder(w).Encode(careers.CareersPage{
+			CompanyName:   careersPage.CompanyName,
+			CompanyDomain: getCareersOpeningsReq.Domain,
+			Openings:      openings,
+		})
+		if err != nil {
+			h.Err("failed to encode careers page", "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package costcenter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetCostCenterApprovalChain(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetCostCenterApprovalChain")
+		var getChainReq employer.GetCostCenterApprovalChainRequest
+		err := json.NewDecoder(r.Body).Decode(&getChainReq)
+		if err != nil {
+			h.Dbg("failed to decode get approval chain request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getChainReq) {
+			h.Dbg("validation failed", "getChainReq", getChainReq)
+			return
+		}
+		h.Dbg("validated", "getChainReq", getChainReq)
+
+		chain, err := h.DB().GetCostCenterApprovalChain(r.Context(), getChainReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCostCenter) {
+				h.Dbg("CC not found", "name", getChainReq.CostCenterName)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get approval chain", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(chain)
+		if err != nil {
+			h.Err("failed to encode approval chain", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package costcenter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func SetCostCenterApprovalChain(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SetCostCenterApprovalChain")
+		var setChainReq employer.SetCostCenterApprovalChainRequest
+		err := json.NewDecoder(r.Body).Decode(&setChainReq)
+		if err != nil {
+			h.Dbg("failed to decode set approval chain request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &setChainReq) {
+			h.Dbg("validation failed", "setChainReq", setChainReq)
+			return
+		}
+		h.Dbg("validated", "setChainReq", setChainReq)
+
+		// An approver cannot appear more than once in the chain
+		seen := make(map[common.EmailAddress]struct{})
+		for _, approver := range setChainReq.Approvers {
+			if _, ok := seen[approver]; ok {
+				h.Dbg("duplicate approver", "approver", approver)
+				w.WriteHeader(http.StatusBadRequest)
+				err = json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"approvers"},
+				})
+				if err != nil {
+					h.Err("failed to encode validation errors", "error", err)
+				}
+				return
+			}
+			seen[approver] = struct{}{}
+		}
+
+		err = h.DB().SetCostCenterApprovalChain(r.Context(), setChainReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCostCenter) {
+				h.Dbg("CC not found", "name", setChainReq.CostCenterName)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrInvalidApprovers) {
+				h.Dbg("invalid approvers", "approvers", setChainReq.Approvers)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to set approval chain", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("set approval chain", "name", setChainReq.CostCenterName)
+		w.WriteHeader(http.StatusOK)
+	}
+}
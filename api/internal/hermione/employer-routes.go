@@ -11,6 +11,7 @@ import (
 	ea "github.com/vetchium/vetchium/api/internal/hermione/employerauth"
 	"github.com/vetchium/vetchium/api/internal/hermione/employersettings"
 	ep "github.com/vetchium/vetchium/api/internal/hermione/empposts"
+	"github.com/vetchium/vetchium/api/internal/hermione/headcount"
 	he "github.com/vetchium/vetchium/api/internal/hermione/hubemp"
 	"github.com/vetchium/vetchium/api/internal/hermione/interview"
 	"github.com/vetchium/vetchium/api/internal/hermione/locations"
@@ -81,6 +82,20 @@ func RegisterEmployerRoutes(h *Hermione) {
 		costcenter.GetCostCenter(h),
 		[]common.OrgUserRole{common.Admin, common.CostCentersViewer},
 	)
+	h.mw.Protect(
+		"/employer/set-cost-center-approval-chain",
+		costcenter.SetCostCenterApprovalChain(h),
+		[]common.OrgUserRole{common.Admin, common.CostCentersCRUD},
+	)
+	h.mw.Protect(
+		"/employer/get-cost-center-approval-chain",
+		costcenter.GetCostCenterApprovalChain(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.CostCentersCRUD,
+			common.CostCentersViewer,
+		},
+	)
 
 	// Location related endpoints
 	h.mw.Protect(
@@ -207,6 +222,116 @@ func RegisterEmployerRoutes(h *Hermione) {
 		openings.ChangeOpeningState(h),
 		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
 	)
+	h.mw.Protect(
+		"/employer/set-opening-schedule",
+		openings.SetOpeningSchedule(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/set-opening-public-listing",
+		openings.SetOpeningPublicListing(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/clone-opening",
+		openings.CloneOpening(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
+
+	// Sourcing related endpoints
+	h.mw.Protect(
+		"/employer/get-suggested-candidates",
+		openings.GetSuggestedCandidates(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.OpeningsCRUD,
+			common.OpeningsViewer,
+			common.ApplicationsCRUD,
+			common.ApplicationsViewer,
+		},
+	)
+	h.mw.Protect(
+		"/employer/invite-to-apply",
+		openings.InviteToApply(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.OpeningsCRUD,
+			common.ApplicationsCRUD,
+		},
+	)
+
+	// Opening approvals related endpoints
+	h.mw.Protect(
+		"/employer/submit-opening-for-approval",
+		openings.SubmitOpeningForApproval(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/get-opening-approvals",
+		openings.GetOpeningApprovals(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.OpeningsCRUD,
+			common.OpeningsViewer,
+		},
+	)
+	// Approvers are chosen per cost center and need not have any of the
+	// openings roles
+	h.mw.Protect(
+		"/employer/approve-opening",
+		openings.ApproveOpening(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/reject-opening",
+		openings.RejectOpening(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/get-my-opening-approvals",
+		openings.GetMyOpeningApprovals(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	// Opening templates related endpoints
+	h.mw.Protect(
+		"/employer/create-opening-template",
+		openings.CreateOpeningTemplate(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/update-opening-template",
+		openings.UpdateOpeningTemplate(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/get-opening-template",
+		openings.GetOpeningTemplate(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.OpeningsCRUD,
+			common.OpeningsViewer,
+		},
+	)
+	h.mw.Protect(
+		"/employer/filter-opening-templates",
+		openings.FilterOpeningTemplates(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.OpeningsCRUD,
+			common.OpeningsViewer,
+		},
+	)
+	h.mw.Protect(
+		"/employer/delete-opening-template",
+		openings.DeleteOpeningTemplate(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/create-opening-from-template",
+		openings.CreateOpeningFromTemplate(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
 
 	// Opening tags related endpoints
 	h.mw.Protect(
@@ -240,6 +365,16 @@ func RegisterEmployerRoutes(h *Hermione) {
 		},
 	)
 
+	h.mw.Protect(
+		"/employer/get-application-score-details",
+		app.GetApplicationScoreDetails(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.ApplicationsCRUD,
+			common.ApplicationsViewer,
+		},
+	)
+
 	h.mw.Protect(
 		"/employer/set-application-color-tag",
 		app.SetApplicationColorTag(h),
@@ -289,6 +424,36 @@ func RegisterEmployerRoutes(h *Hermione) {
 		[]common.OrgUserRole{common.AnyOrgUser},
 	)
 
+	h.mw.Protect(
+		"/employer/edit-candidacy-comment",
+		candidacy.EmployerEditComment(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	h.mw.Protect(
+		"/employer/retract-candidacy-comment",
+		candidacy.EmployerRetractComment(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	h.mw.Protect(
+		"/employer/mark-candidacy-comments-read",
+		candidacy.EmployerMarkCommentsRead(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	h.mw.Protect(
+		"/employer/get-candidacy-comment-attachment",
+		candidacy.EmployerGetCommentAttachment(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	h.mw.Protect(
+		"/employer/stream-candidacy-updates",
+		candidacy.EmployerStreamUpdates(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
 	h.mw.Protect(
 		"/employer/filter-candidacy-infos",
 		candidacy.FilterCandidacyInfos(h),
@@ -307,6 +472,73 @@ func RegisterEmployerRoutes(h *Hermione) {
 		candidacy.OfferToCandidate(h),
 		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
 	)
+	h.mw.Protect(
+		"/employer/revise-offer",
+		candidacy.ReviseOffer(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/send-offer-for-signature",
+		candidacy.SendOfferForSignature(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/rescind-offer",
+		candidacy.RescindOffer(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/get-offers",
+		candidacy.GetEmployerOffers(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.ApplicationsCRUD,
+			common.ApplicationsViewer,
+		},
+	)
+	h.mw.Protect(
+		"/employer/get-offer-document",
+		candidacy.GetEmployerOfferDocument(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.ApplicationsCRUD,
+			common.ApplicationsViewer,
+		},
+	)
+
+	// Headcount related endpoints
+	h.mw.Protect(
+		"/employer/record-offer-acceptance",
+		headcount.RecordOfferAcceptance(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/update-opening-hire",
+		headcount.UpdateOpeningHire(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/get-opening-hires",
+		headcount.GetOpeningHires(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.OpeningsCRUD,
+			common.OpeningsViewer,
+			common.ApplicationsCRUD,
+			common.ApplicationsViewer,
+		},
+	)
+	h.mw.Protect(
+		"/employer/get-headcount-report",
+		headcount.GetHeadcountReport(h),
+		[]common.OrgUserRole{
+			common.Admin,
+			common.OpeningsCRUD,
+			common.OpeningsViewer,
+			common.CostCentersCRUD,
+			common.CostCentersViewer,
+		},
+	)
 
 	// Used by employer - Interviews
 	h.mw.Protect(
@@ -359,6 +591,112 @@ func RegisterEmployerRoutes(h *Hermione) {
 		interview.EmployerPutAssessment(h),
 		[]common.OrgUserRole{common.AnyOrgUser},
 	)
+	h.mw.Protect(
+		"/employer/reschedule-interview",
+		interview.RescheduleInterview(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/cancel-interview",
+		interview.CancelInterview(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/get-calendar-feed",
+		interview.EmployerGetCalendarFeed(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/reset-calendar-feed",
+		interview.EmployerResetCalendarFeed(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	// Used by employer - Interview scheduling
+	h.mw.Protect(
+		"/employer/set-my-availability",
+		interview.SetMyAvailability(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/get-my-availability",
+		interview.GetMyAvailability(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/create-scheduling-request",
+		interview.CreateSchedulingRequest(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/cancel-scheduling-request",
+		interview.CancelSchedulingRequest(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/connect-caldav",
+		interview.ConnectCalDAV(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/get-caldav-connection",
+		interview.GetCalDAVConnection(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/disconnect-caldav",
+		interview.DisconnectCalDAV(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	// Used by employer - Take-home assignments
+	h.mw.Protect(
+		"/employer/set-take-home-assignment",
+		interview.SetTakeHomeAssignment(h),
+		[]common.OrgUserRole{common.Admin, common.ApplicationsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/get-take-home-assignment",
+		interview.EmployerGetTakeHomeAssignment(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/get-take-home-file",
+		interview.EmployerGetTakeHomeFile(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/get-take-home-submission",
+		interview.EmployerGetTakeHomeSubmission(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	// Used by employer - Scorecards
+	h.mw.Protect(
+		"/employer/set-opening-competencies",
+		interview.SetOpeningCompetencies(h),
+		[]common.OrgUserRole{common.Admin, common.OpeningsCRUD},
+	)
+	h.mw.Protect(
+		"/employer/get-opening-competencies",
+		interview.GetOpeningCompetencies(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/put-scorecard",
+		interview.PutScorecard(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/get-scorecards",
+		interview.GetScorecards(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+	h.mw.Protect(
+		"/employer/get-candidacy-debrief",
+		interview.GetCandidacyDebrief(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
 
 	// Hub user profile related endpoints for employer
 	h.mw.Protect(
@@ -409,6 +747,54 @@ func RegisterEmployerRoutes(h *Hermione) {
 		[]common.OrgUserRole{common.Admin},
 	)
 
+	h.mw.Protect(
+		"/employer/change-application-expiry-period",
+		employersettings.ChangeApplicationExpiryPeriod(h),
+		[]common.OrgUserRole{common.Admin},
+	)
+
+	h.mw.Protect(
+		"/employer/get-application-expiry-period",
+		employersettings.GetApplicationExpiryPeriod(h),
+		[]common.OrgUserRole{common.Admin},
+	)
+
+	h.mw.Protect(
+		"/employer/change-auto-close-filled-openings",
+		employersettings.ChangeAutoCloseFilledOpenings(h),
+		[]common.OrgUserRole{common.Admin},
+	)
+
+	h.mw.Protect(
+		"/employer/get-auto-close-filled-openings",
+		employersettings.GetAutoCloseFilledOpenings(h),
+		[]common.OrgUserRole{common.Admin},
+	)
+
+	h.mw.Protect(
+		"/employer/change-meeting-provider",
+		employersettings.ChangeMeetingProvider(h),
+		[]common.OrgUserRole{common.Admin},
+	)
+
+	h.mw.Protect(
+		"/employer/get-meeting-provider",
+		employersettings.GetMeetingProvider(h),
+		[]common.OrgUserRole{common.AnyOrgUser},
+	)
+
+	h.mw.Protect(
+		"/employer/change-match-score-weights",
+		employersettings.ChangeMatchScoreWeights(h),
+		[]common.OrgUserRole{common.Admin},
+	)
+
+	h.mw.Protect(
+		"/employer/get-match-score-weights",
+		employersettings.GetMatchScoreWeights(h),
+		[]common.OrgUserRole{common.Admin},
+	)
+
 	// Posts related endpoints
 	h.mw.Protect(
 		"/employer/add-post",
@@ -0,0 +1,44 @@
+package employersettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func ChangeApplicationExpiryPeriod(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ChangeApplicationExpiryPeriod")
+		var req employer.ChangeApplicationExpiryPeriodRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &req) {
+			h.Dbg("validation failed", "req", req)
+			http.Error(w, "validation failed", http.StatusBadRequest)
+			return
+		}
+
+		err := h.DB().ChangeApplicationExpiryPeriod(
+			r.Context(),
+			req.ApplicationExpiryDays,
+		)
+		if err != nil {
+			h.Dbg("failed to change application expiry period", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg(
+			"application expiry period changed",
+			"days",
+			req.ApplicationExpiryDays,
+		)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,38 @@
+package employersettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func ChangeAutoCloseFilledOpenings(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ChangeAutoCloseFilledOpenings")
+		var req employer.AutoCloseFilledOpenings
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err := h.DB().ChangeAutoCloseFilledOpenings(
+			r.Context(),
+			req.AutoCloseFilledOpenings,
+		)
+		if err != nil {
+			h.Dbg("failed to change auto close filled openings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg(
+			"auto close filled openings changed",
+			"autoClose",
+			req.AutoCloseFilledOpenings,
+		)
+		w.WriteHeader(http.StatusOK)
+	}
+}
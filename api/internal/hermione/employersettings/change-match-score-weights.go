@@ -0,0 +1,47 @@
+package employersettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func ChangeMatchScoreWeights(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ChangeMatchScoreWeights")
+		var req employer.MatchScoreWeights
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &req) {
+			h.Dbg("validation failed", "req", req)
+			return
+		}
+
+		// The weights are relative to each other
+		if req.Total() == 0 {
+			h.Dbg("all the weights are 0", "req", req)
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"resume"},
+			})
+			return
+		}
+
+		err := h.DB().ChangeMatchScoreWeights(r.Context(), req)
+		if err != nil {
+			h.Dbg("failed to change match score weights", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("match score weights changed", "weights", req)
+		w.WriteHeader(http.StatusOK)
+	}
+}
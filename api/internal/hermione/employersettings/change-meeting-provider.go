@@ -0,0 +1,43 @@
+package employersettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func ChangeMeetingProvider(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ChangeMeetingProvider")
+		var req employer.MeetingProviderSetting
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &req) {
+			h.Dbg("validation failed", "req", req)
+			return
+		}
+
+		_, err := h.Meetings().Get(req.MeetingProvider)
+		if err != nil {
+			h.Dbg("meeting provider not available", "error", err)
+			http.Error(w, "", http.StatusUnprocessableEntity)
+			return
+		}
+
+		err = h.DB().ChangeMeetingProvider(r.Context(), req.MeetingProvider)
+		if err != nil {
+			h.Dbg("failed to change meeting provider", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("meeting provider changed", "provider", req.MeetingProvider)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,23 @@
+package employersettings
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+)
+
+func GetApplicationExpiryPeriod(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetApplicationExpiryPeriod")
+		period, err := h.DB().GetApplicationExpiryPeriod(r.Context())
+		if err != nil {
+			h.Dbg("failed to get application expiry period", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf("%d", period)))
+	}
+}
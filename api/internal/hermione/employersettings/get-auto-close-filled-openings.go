@@ -0,0 +1,29 @@
+package employersettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetAutoCloseFilledOpenings(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetAutoCloseFilledOpenings")
+		autoClose, err := h.DB().GetAutoCloseFilledOpenings(r.Context())
+		if err != nil {
+			h.Dbg("failed to get auto close filled openings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(employer.AutoCloseFilledOpenings{
+			AutoCloseFilledOpenings: autoClose,
+		})
+		if err != nil {
+			h.Err("failed to encode auto close filled openings", "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package employersettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+)
+
+func GetMatchScoreWeights(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetMatchScoreWeights")
+		weights, err := h.DB().GetMatchScoreWeights(r.Context())
+		if err != nil {
+			h.Dbg("failed to get match score weights", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(weights)
+		if err != nil {
+			h.Err("failed to encode match score weights", "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package employersettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetMeetingProvider(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetMeetingProvider")
+		provider, err := h.DB().GetMeetingProvider(r.Context())
+		if err != nil {
+			h.Dbg("failed to get meeting provider", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(employer.MeetingProviderSetting{
+			MeetingProvider: provider,
+		})
+		if err != nil {
+			h.Err("failed to encode meeting provider", "error", err)
+			return
+		}
+	}
+}
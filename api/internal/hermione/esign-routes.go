@@ -0,0 +1,13 @@
+package hermione
+
+import (
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/hermione/candidacy"
+)
+
+// RegisterESignRoutes registers the endpoints that are called by the
+// e-signature providers. These are not authenticated.
+func RegisterESignRoutes(h *Hermione) {
+	http.HandleFunc("POST /esign/webhook", candidacy.ESignWebhook(h))
+}
@@ -0,0 +1,42 @@
+package headcount
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetHeadcountReport(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetHeadcountReport")
+		var getHeadcountReportReq employer.GetHeadcountReportRequest
+		err := json.NewDecoder(r.Body).Decode(&getHeadcountReportReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getHeadcountReportReq) {
+			h.Dbg("validation failed", "req", getHeadcountReportReq)
+			return
+		}
+		h.Dbg("validated", "req", getHeadcountReportReq)
+
+		report, err := h.DB().
+			GetHeadcountReport(r.Context(), getHeadcountReportReq)
+		if err != nil {
+			h.Dbg("failed to get headcount report", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(report)
+		if err != nil {
+			h.Err("failed to encode headcount report", "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package headcount
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetOpeningHires(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetOpeningHires")
+		var getOpeningHiresReq employer.GetOpeningHiresRequest
+		err := json.NewDecoder(r.Body).Decode(&getOpeningHiresReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getOpeningHiresReq) {
+			h.Dbg("validation failed", "req", getOpeningHiresReq)
+			return
+		}
+		h.Dbg("validated", "req", getOpeningHiresReq)
+
+		hires, err := h.DB().GetOpeningHires(r.Context(), getOpeningHiresReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get opening hires", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(hires)
+		if err != nil {
+			h.Err("failed to encode opening hires", "error", err)
+			return
+		}
+	}
+}
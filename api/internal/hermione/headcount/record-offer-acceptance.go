@@ -0,0 +1,56 @@
+package headcount
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func RecordOfferAcceptance(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered RecordOfferAcceptance")
+		var recordOfferAcceptanceReq employer.RecordOfferAcceptanceRequest
+		err := json.NewDecoder(r.Body).Decode(&recordOfferAcceptanceReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &recordOfferAcceptanceReq) {
+			h.Dbg("validation failed", "req", recordOfferAcceptanceReq)
+			return
+		}
+		h.Dbg("validated", "req", recordOfferAcceptanceReq)
+
+		err = h.DB().RecordOfferAcceptance(r.Context(), recordOfferAcceptanceReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("no offered candidacy", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrAllPositionsFilled) {
+				h.Dbg("all positions filled", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to record offer acceptance", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg(
+			"recorded offer acceptance",
+			"candidacyID",
+			recordOfferAcceptanceReq.CandidacyID,
+		)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,46 @@
+package headcount
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func UpdateOpeningHire(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered UpdateOpeningHire")
+		var updateOpeningHireReq employer.UpdateOpeningHireRequest
+		err := json.NewDecoder(r.Body).Decode(&updateOpeningHireReq)
+		if err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &updateOpeningHireReq) {
+			h.Dbg("validation failed", "req", updateOpeningHireReq)
+			return
+		}
+		h.Dbg("validated", "req", updateOpeningHireReq)
+
+		err = h.DB().UpdateOpeningHire(r.Context(), updateOpeningHireReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpeningHire) {
+				h.Dbg("opening hire not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to update opening hire", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("updated", "candidacyID", updateOpeningHireReq.CandidacyID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -5,11 +5,15 @@ import (
 	"log/slog"
 	"os"
 
+	"github.com/vetchium/vetchium/api/internal/broker"
 	"github.com/vetchium/vetchium/api/internal/config"
 	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/esign"
 	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/meeting"
 	"github.com/vetchium/vetchium/api/internal/middleware"
 	"github.com/vetchium/vetchium/api/internal/postgres"
+	"github.com/vetchium/vetchium/api/internal/scanner"
 	"github.com/vetchium/vetchium/api/internal/util"
 	"github.com/vetchium/vetchium/api/pkg/vetchi"
 )
@@ -19,6 +23,10 @@ type Hermione struct {
 
 	// These are initialized programmatically in New()
 	hedwig hedwig.Hedwig
+	esign  esign.ESignProvider
+	meet   meeting.Providers
+	scan   scanner.Scanner
+	brk    *broker.Broker
 	pg     *postgres.PG
 	log    util.Logger
 	mw     *middleware.Middleware
@@ -65,6 +73,16 @@ func NewHermione() (*Hermione, error) {
 		return nil, fmt.Errorf("Hedwig initialisation failure: %w", err)
 	}
 
+	esignProvider, err := esign.NewESignProvider(config.ESignProvider, logger)
+	if err != nil {
+		return nil, fmt.Errorf("ESign initialisation failure: %w", err)
+	}
+
+	meetingProviders, err := meeting.NewProviders(config.JitsiBaseURL, logger)
+	if err != nil {
+		return nil, fmt.Errorf("Meeting initialisation failure: %w", err)
+	}
+
 	hermione = &Hermione{
 		config: config,
 
@@ -75,6 +93,10 @@ func NewHermione() (*Hermione, error) {
 		vator: vator,
 
 		hedwig: hedwig,
+		esign:  esignProvider,
+		meet:   meetingProviders,
+		scan:   scanner.New(config.ClamdAddress, logger),
+		brk:    broker.New(pg, logger),
 	}
 
 	return hermione, nil
@@ -96,6 +118,22 @@ func (h *Hermione) Hedwig() hedwig.Hedwig {
 	return h.hedwig
 }
 
+func (h *Hermione) ESign() esign.ESignProvider {
+	return h.esign
+}
+
+func (h *Hermione) Meetings() meeting.Providers {
+	return h.meet
+}
+
+func (h *Hermione) Scanner() scanner.Scanner {
+	return h.scan
+}
+
+func (h *Hermione) Broker() *broker.Broker {
+	return h.brk
+}
+
 func (h *Hermione) Err(msg string, args ...any) {
 	h.log.Err(msg, args...)
 }
@@ -5,6 +5,7 @@ import (
 
 	ach "github.com/vetchium/vetchium/api/internal/hermione/achievements"
 	app "github.com/vetchium/vetchium/api/internal/hermione/applications"
+	bm "github.com/vetchium/vetchium/api/internal/hermione/bookmarks"
 	ca "github.com/vetchium/vetchium/api/internal/hermione/candidacy"
 	co "github.com/vetchium/vetchium/api/internal/hermione/colleagues"
 	com "github.com/vetchium/vetchium/api/internal/hermione/comments"
@@ -30,6 +31,10 @@ func RegisterHubRoutes(h *Hermione) {
 	http.HandleFunc("/hub/reset-password", ha.ResetPassword(h))
 	http.HandleFunc("/hub/onboard-user", hu.OnboardHubUser(h))
 	http.HandleFunc("/hub/signup", hu.SignupHubUser(h))
+	http.HandleFunc(
+		"/hub/unsubscribe-saved-search",
+		ho.UnsubscribeSavedSearch(h),
+	)
 
 	h.mw.Guard(
 		"/hub/change-email-address",
@@ -63,6 +68,16 @@ func RegisterHubRoutes(h *Hermione) {
 		hu.SetHandle(h),
 		[]hub.HubUserTier{hub.PaidHubUserTier},
 	)
+	h.mw.Guard(
+		"/hub/set-discoverability",
+		hu.SetDiscoverability(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-discoverability",
+		hu.GetDiscoverability(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
 
 	// Official Email related endpoints
 	h.mw.Guard(
@@ -185,6 +200,56 @@ func RegisterHubRoutes(h *Hermione) {
 		ho.ApplyForOpening(h),
 		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
 	)
+	h.mw.Guard(
+		"/hub/get-recommended-openings",
+		ho.GetRecommendedOpenings(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/dismiss-recommended-opening",
+		ho.DismissRecommendedOpening(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-opening-invitations",
+		ho.GetOpeningInvitations(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/save-search",
+		ho.SaveSearch(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/list-saved-searches",
+		ho.ListSavedSearches(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/update-saved-search",
+		ho.UpdateSavedSearch(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/pause-saved-search",
+		ho.PauseSavedSearch(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/resume-saved-search",
+		ho.ResumeSavedSearch(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/delete-saved-search",
+		ho.DeleteSavedSearch(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/view-saved-search",
+		ho.ViewSavedSearch(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
 	h.mw.Guard(
 		"/hub/my-applications",
 		app.MyApplications(h),
@@ -205,6 +270,31 @@ func RegisterHubRoutes(h *Hermione) {
 		ca.HubGetComments(h),
 		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
 	)
+	h.mw.Guard(
+		"/hub/edit-candidacy-comment",
+		ca.HubEditComment(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/retract-candidacy-comment",
+		ca.HubRetractComment(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/mark-candidacy-comments-read",
+		ca.HubMarkCommentsRead(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-candidacy-comment-attachment",
+		ca.HubGetCommentAttachment(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/stream-candidacy-updates",
+		ca.HubStreamUpdates(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
 	h.mw.Guard(
 		"/hub/get-my-candidacies",
 		ca.MyCandidacies(h),
@@ -215,6 +305,21 @@ func RegisterHubRoutes(h *Hermione) {
 		ca.GetHubCandidacyInfo(h),
 		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
 	)
+	h.mw.Guard(
+		"/hub/respond-to-offer",
+		ca.RespondToOffer(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-offers",
+		ca.GetHubOffers(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-offer-document",
+		ca.GetHubOfferDocument(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
 	h.mw.Guard(
 		"/hub/get-interviews-by-candidacy",
 		in.GetHubInterviewsByCandidacy(h),
@@ -225,6 +330,56 @@ func RegisterHubRoutes(h *Hermione) {
 		in.HubRSVPInterview(h),
 		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
 	)
+	h.mw.Guard(
+		"/hub/get-calendar-feed",
+		in.HubGetCalendarFeed(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/reset-calendar-feed",
+		in.HubResetCalendarFeed(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-interview-slots",
+		in.GetInterviewSlots(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/hold-interview-slot",
+		in.HoldInterviewSlot(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/book-interview-slot",
+		in.BookInterviewSlot(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-take-home-assignment",
+		in.HubGetTakeHomeAssignment(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/start-take-home-assignment",
+		in.StartTakeHomeAssignment(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-take-home-file",
+		in.HubGetTakeHomeFile(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/submit-take-home-assignment",
+		in.SubmitTakeHomeAssignment(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-take-home-submission",
+		in.HubGetTakeHomeSubmission(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
 	h.mw.Guard(
 		"/hub/filter-employers",
 		he.FilterEmployers(h),
@@ -503,4 +658,26 @@ func RegisterHubRoutes(h *Hermione) {
 		ic.GetIncognitoPostCommentPermalink(h),
 		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
 	)
+
+	// Bookmarks related endpoints
+	h.mw.Guard(
+		"/hub/add-bookmark",
+		bm.AddBookmark(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/remove-bookmark",
+		bm.RemoveBookmark(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/list-bookmarks",
+		bm.ListBookmarks(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
+	h.mw.Guard(
+		"/hub/get-bookmark-folders",
+		bm.GetBookmarkFolders(h),
+		[]hub.HubUserTier{hub.FreeHubUserTier, hub.PaidHubUserTier},
+	)
 }
@@ -0,0 +1,49 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func DeleteSavedSearch(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered DeleteSavedSearch")
+		var savedSearchReq hub.SavedSearchRequest
+		err := json.NewDecoder(r.Body).Decode(&savedSearchReq)
+		if err != nil {
+			h.Dbg("failed to decode delete saved search", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &savedSearchReq) {
+			h.Dbg("validation failed", "req", savedSearchReq)
+			return
+		}
+		h.Dbg("validated", "req", savedSearchReq)
+
+		err = h.DB().DeleteSavedSearch(
+			r.Context(),
+			savedSearchReq.SavedSearchID,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoSavedSearch) {
+				h.Dbg("saved search not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to delete saved search", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("deleted saved search", "req", savedSearchReq)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,46 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func DismissRecommendedOpening(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered DismissRecommendedOpening")
+		var dismissReq hub.DismissRecommendedOpeningRequest
+		err := json.NewDecoder(r.Body).Decode(&dismissReq)
+		if err != nil {
+			h.Dbg("failed to decode dismiss recommended opening", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &dismissReq) {
+			h.Dbg("validation failed", "req", dismissReq)
+			return
+		}
+		h.Dbg("validated", "req", dismissReq)
+
+		err = h.DB().DismissRecommendedOpening(r.Context(), dismissReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("either domain or opening does not exist", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to dismiss recommended opening", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("dismissed recommended opening", "req", dismissReq)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,48 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func GetOpeningInvitations(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetOpeningInvitations")
+		var getOpeningInvitationsReq hub.GetOpeningInvitationsRequest
+		err := json.NewDecoder(r.Body).Decode(&getOpeningInvitationsReq)
+		if err != nil {
+			h.Dbg("failed to decode get opening invitations", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getOpeningInvitationsReq) {
+			h.Dbg("validation failed", "req", getOpeningInvitationsReq)
+			return
+		}
+		h.Dbg("validated", "req", getOpeningInvitationsReq)
+
+		if getOpeningInvitationsReq.Limit == 0 {
+			getOpeningInvitationsReq.Limit = 40
+		}
+
+		invitations, err := h.DB().
+			GetOpeningInvitations(r.Context(), getOpeningInvitationsReq)
+		if err != nil {
+			h.Dbg("failed to get opening invitations", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("got opening invitations", "count", len(invitations))
+		err = json.NewEncoder(w).Encode(invitations)
+		if err != nil {
+			h.Err("failed to encode opening invitations", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func GetRecommendedOpenings(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetRecommendedOpenings")
+		var getRecommendedOpeningsReq hub.GetRecommendedOpeningsRequest
+		err := json.NewDecoder(r.Body).Decode(&getRecommendedOpeningsReq)
+		if err != nil {
+			h.Dbg("failed to decode get recommended openings", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getRecommendedOpeningsReq) {
+			h.Dbg("validation failed", "req", getRecommendedOpeningsReq)
+			return
+		}
+		h.Dbg("validated", "req", getRecommendedOpeningsReq)
+
+		openings, err := h.DB().
+			GetRecommendedOpenings(r.Context(), getRecommendedOpeningsReq)
+		if err != nil {
+			h.Dbg("failed to get recommended openings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("got recommended openings", "count", len(openings))
+		err = json.NewEncoder(w).Encode(openings)
+		if err != nil {
+			h.Err("failed to encode recommended openings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
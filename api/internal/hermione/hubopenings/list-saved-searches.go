@@ -0,0 +1,29 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+)
+
+func ListSavedSearches(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ListSavedSearches")
+
+		savedSearches, err := h.DB().ListSavedSearches(r.Context())
+		if err != nil {
+			h.Dbg("failed to list saved searches", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("listed saved searches", "count", len(savedSearches))
+		err = json.NewEncoder(w).Encode(savedSearches)
+		if err != nil {
+			h.Err("failed to encode saved searches", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func PauseSavedSearch(h wand.Wand) http.HandlerFunc {
+	return setSavedSearchPaused(h, true)
+}
+
+func ResumeSavedSearch(h wand.Wand) http.HandlerFunc {
+	return setSavedSearchPaused(h, false)
+}
+
+func setSavedSearchPaused(h wand.Wand, paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered setSavedSearchPaused", "paused", paused)
+		var savedSearchReq hub.SavedSearchRequest
+		err := json.NewDecoder(r.Body).Decode(&savedSearchReq)
+		if err != nil {
+			h.Dbg("failed to decode saved search request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &savedSearchReq) {
+			h.Dbg("validation failed", "req", savedSearchReq)
+			return
+		}
+		h.Dbg("validated", "req", savedSearchReq)
+
+		err = h.DB().SetSavedSearchPaused(
+			r.Context(),
+			savedSearchReq.SavedSearchID,
+			paused,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoSavedSearch) {
+				h.Dbg("saved search not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to set saved search paused", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("set saved search paused", "paused", paused)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,74 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func SaveSearch(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SaveSearch")
+		var saveSearchReq hub.SaveSearchRequest
+		err := json.NewDecoder(r.Body).Decode(&saveSearchReq)
+		if err != nil {
+			h.Dbg("failed to decode save search request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &saveSearchReq) {
+			h.Dbg("validation failed", "req", saveSearchReq)
+			return
+		}
+		h.Dbg("validated", "req", saveSearchReq)
+
+		// A saved search matches all the new Openings, not a page of them
+		saveSearchReq.Search.PaginationKey = 0
+		saveSearchReq.Search.Limit = 0
+
+		savedSearchID := util.RandomUniqueID(vetchi.SavedSearchIDLenBytes)
+		err = h.DB().SaveSearch(r.Context(), db.SaveSearchReq{
+			SavedSearchID: savedSearchID,
+			UnsubscribeToken: util.RandomString(
+				vetchi.SavedSearchUnsubscribeTokenLenBytes,
+			),
+			Name:      saveSearchReq.Name,
+			Search:    saveSearchReq.Search,
+			Frequency: saveSearchReq.Frequency,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrDupSavedSearchName) {
+				h.Dbg("saved search name already exists", "error", err)
+				http.Error(w, "", http.StatusConflict)
+				return
+			}
+
+			if errors.Is(err, db.ErrTooManySavedSearches) {
+				h.Dbg("too many saved searches", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to save search", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("saved search", "saved_search_id", savedSearchID)
+		err = json.NewEncoder(w).Encode(hub.SaveSearchResponse{
+			SavedSearchID: savedSearchID,
+		})
+		if err != nil {
+			h.Err("failed to encode save search response", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// UnsubscribeSavedSearch is not authenticated, as it is reached from the
+// link in the alert emails. The token in the link identifies the search.
+func UnsubscribeSavedSearch(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered UnsubscribeSavedSearch")
+		var unsubscribeReq hub.UnsubscribeSavedSearchRequest
+		err := json.NewDecoder(r.Body).Decode(&unsubscribeReq)
+		if err != nil {
+			h.Dbg("failed to decode unsubscribe saved search", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &unsubscribeReq) {
+			h.Dbg("validation failed")
+			return
+		}
+
+		err = h.DB().UnsubscribeSavedSearch(r.Context(), unsubscribeReq.Token)
+		if err != nil {
+			if errors.Is(err, db.ErrNoSavedSearch) {
+				h.Dbg("saved search not found for the token")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to unsubscribe saved search", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("unsubscribed saved search")
+		w.WriteHeader(http.StatusOK)
+	}
+}
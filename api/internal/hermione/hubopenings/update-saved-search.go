@@ -0,0 +1,57 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func UpdateSavedSearch(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered UpdateSavedSearch")
+		var updateReq hub.UpdateSavedSearchRequest
+		err := json.NewDecoder(r.Body).Decode(&updateReq)
+		if err != nil {
+			h.Dbg("failed to decode update saved search", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &updateReq) {
+			h.Dbg("validation failed", "req", updateReq)
+			return
+		}
+		h.Dbg("validated", "req", updateReq)
+
+		if updateReq.Search != nil {
+			updateReq.Search.PaginationKey = 0
+			updateReq.Search.Limit = 0
+		}
+
+		err = h.DB().UpdateSavedSearch(r.Context(), updateReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoSavedSearch) {
+				h.Dbg("saved search not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrDupSavedSearchName) {
+				h.Dbg("saved search name already exists", "error", err)
+				http.Error(w, "", http.StatusConflict)
+				return
+			}
+
+			h.Dbg("failed to update saved search", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("updated saved search", "req", updateReq)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,54 @@
+package hubopenings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func ViewSavedSearch(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ViewSavedSearch")
+		var savedSearchReq hub.SavedSearchRequest
+		err := json.NewDecoder(r.Body).Decode(&savedSearchReq)
+		if err != nil {
+			h.Dbg("failed to decode view saved search", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &savedSearchReq) {
+			h.Dbg("validation failed", "req", savedSearchReq)
+			return
+		}
+		h.Dbg("validated", "req", savedSearchReq)
+
+		openings, err := h.DB().ViewSavedSearch(
+			r.Context(),
+			savedSearchReq.SavedSearchID,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoSavedSearch) {
+				h.Dbg("saved search not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to view saved search", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("viewed saved search", "count", len(openings))
+		err = json.NewEncoder(w).Encode(openings)
+		if err != nil {
+			h.Err("failed to encode saved search openings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package hubusers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func GetDiscoverability(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		discoverable, err := h.DB().GetDiscoverability(r.Context())
+		if err != nil {
+			h.Dbg("failed to get discoverability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("got discoverability", "discoverable", discoverable)
+		err = json.NewEncoder(w).Encode(hub.Discoverability{
+			Discoverable: discoverable,
+		})
+		if err != nil {
+			h.Err("failed to encode discoverability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
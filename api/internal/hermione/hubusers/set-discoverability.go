@@ -0,0 +1,37 @@
+package hubusers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func SetDiscoverability(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req hub.Discoverability
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.Dbg("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &req) {
+			h.Dbg("request failed validation", "request", req)
+			return
+		}
+
+		h.Dbg("discoverability validated", "request", req)
+
+		err := h.DB().SetDiscoverability(r.Context(), req.Discoverable)
+		if err != nil {
+			h.Dbg("failed to set discoverability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("discoverability set", "discoverable", req.Discoverable)
+		w.WriteHeader(http.StatusOK)
+	}
+}
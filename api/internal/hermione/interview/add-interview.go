@@ -8,6 +8,7 @@ import (
 
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/meeting"
 	"github.com/vetchium/vetchium/api/internal/middleware"
 	"github.com/vetchium/vetchium/api/internal/util"
 	"github.com/vetchium/vetchium/api/internal/wand"
@@ -55,18 +56,89 @@ func AddInterview(h wand.Wand) http.HandlerFunc {
 			return
 		}
 
+		candidate, err := h.DB().
+			GetCandidateInfo(r.Context(), addInterviewReq.CandidacyID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("no candidacy found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get candidate info", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		meetingProvider, err := interviewMeetingProvider(
+			r.Context(),
+			h,
+			addInterviewReq.InterviewType,
+			addInterviewReq.MeetingURL,
+		)
+		if err != nil {
+			if errors.Is(err, errMeetingURLNotAllowed) {
+				h.Dbg("meeting_url not allowed", "error", err)
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"meeting_url"},
+				})
+				return
+			}
+
+			h.Err("failed to get meeting provider", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		meetingURL, err := createMeetingRoom(
+			r.Context(),
+			h,
+			meetingProvider,
+			meeting.Room{
+				InterviewID: interviewID,
+				StartTime:   addInterviewReq.StartTime,
+				EndTime:     addInterviewReq.EndTime,
+				ManualURL:   addInterviewReq.MeetingURL,
+			},
+		)
+		if err != nil {
+			h.Err("failed to create meeting room", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		// Used for the calendar invites sent along with the notifications
+		participants := db.InterviewParticipants{
+			InterviewType:     addInterviewReq.InterviewType,
+			StartTime:         addInterviewReq.StartTime,
+			EndTime:           addInterviewReq.EndTime,
+			Description:       addInterviewReq.Description,
+			CandidacyID:       addInterviewReq.CandidacyID,
+			CandidateName:     candidate.CandidateName,
+			CandidateEmail:    candidate.CandidateEmail,
+			CompanyName:       candidate.CompanyName,
+			OpeningTitle:      candidate.OpeningTitle,
+			InterviewerEmails: addInterviewReq.InterviewerEmails,
+			MeetingProvider:   meetingProvider,
+			MeetingURL:        meetingURL,
+		}
+		employerInterviewURL := h.Config().Employer.WebURL + "/interviews/" +
+			interviewID
+
 		// Prepare email notifications
 		var interviewerNotification, watcherNotification, applicantNotification db.Email
-		var err error
 
 		// Prepare applicant notification
 		emailArgs := map[string]string{
-			"InterviewURL":    "TODO",
+			"InterviewURL": h.Config().Hub.WebURL + "/candidacy/" +
+				addInterviewReq.CandidacyID,
 			"InterviewType":   string(addInterviewReq.InterviewType),
 			"StartTime":       addInterviewReq.StartTime.String(),
 			"EndTime":         addInterviewReq.EndTime.String(),
 			"Description":     addInterviewReq.Description,
 			"InterviewerName": orgUser.Name,
+			"MeetingURL":      meetingURL,
 		}
 
 		if len(addInterviewReq.InterviewerEmails) > 0 {
@@ -87,13 +159,18 @@ func AddInterview(h wand.Wand) http.HandlerFunc {
 			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
+		applicantNotification.EmailCalendarEvent = icalInvite(
+			util.ICalMethodRequest,
+			candidateICalEvent(h, interviewID, participants),
+		)
 
 		if len(addInterviewReq.InterviewerEmails) > 0 {
 			interviewerNotification, err = h.Hedwig().
 				GenerateEmail(hedwig.GenerateEmailReq{
 					TemplateName: hedwig.NotifyNewInterviewer,
 					Args: map[string]string{
-						"InterviewURL": "TODO",
+						"InterviewURL": employerInterviewURL,
+						"MeetingURL":   meetingURL,
 					},
 					EmailFrom: vetchi.EmailFrom,
 					EmailTo:   addInterviewReq.InterviewerEmails,
@@ -104,12 +181,16 @@ func AddInterview(h wand.Wand) http.HandlerFunc {
 				http.Error(w, "", http.StatusInternalServerError)
 				return
 			}
+			interviewerNotification.EmailCalendarEvent = icalInvite(
+				util.ICalMethodRequest,
+				employeeICalEvent(h, interviewID, participants),
+			)
 
 			watcherNotification, err = h.Hedwig().
 				GenerateEmail(hedwig.GenerateEmailReq{
 					TemplateName: hedwig.NotifyWatchersNewInterviewer,
 					Args: map[string]string{
-						"InterviewURL": "TODO",
+						"InterviewURL": employerInterviewURL,
 					},
 					EmailFrom: vetchi.EmailFrom,
 					EmailTo: []string{
@@ -137,8 +218,12 @@ func AddInterview(h wand.Wand) http.HandlerFunc {
 			WatcherNotificationEmail:     watcherNotification,
 			ApplicantNotificationEmail:   applicantNotification,
 			CandidacyComment:             candidacyComment,
+			MeetingProvider:              meetingProvider,
+			RoomURL:                      meetingURL,
 		})
 		if err != nil {
+			deleteMeetingRoom(r.Context(), h, meetingProvider, meetingURL)
+
 			if errors.Is(err, db.ErrNoCandidacy) {
 				h.Dbg("no candidacy found", "error", err)
 				http.Error(w, "", http.StatusNotFound)
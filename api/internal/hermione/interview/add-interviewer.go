@@ -9,6 +9,7 @@ import (
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/hedwig"
 	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/internal/util"
 	"github.com/vetchium/vetchium/api/internal/wand"
 	"github.com/vetchium/vetchium/api/pkg/vetchi"
 	"github.com/vetchium/vetchium/typespec/employer"
@@ -75,6 +76,21 @@ func AddInterviewer(h wand.Wand) http.HandlerFunc {
 		}
 		h.Dbg("got stakeholders", "stakeholders", stakeholders)
 
+		participants, err := h.DB().
+			GetInterviewParticipants(ctx, addInterviewerReq.InterviewID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get participants", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		interviewURL := h.Config().Employer.WebURL + "/interviews/" +
+			addInterviewerReq.InterviewID
+
 		watcherEmailMap := make(map[string]struct{})
 		watcherEmailMap[addInterviewerReq.OrgUserEmail] = struct{}{}
 		watcherEmailMap[stakeholders.HiringManager.Email] = struct{}{}
@@ -103,7 +119,7 @@ func AddInterviewer(h wand.Wand) http.HandlerFunc {
 				TemplateName: hedwig.NotifyNewInterviewer,
 				Args: map[string]string{
 					"InterviewerName": interviewer.Name,
-					"InterviewURL":    "TODO",
+					"InterviewURL":    interviewURL,
 				},
 				EmailFrom: vetchi.EmailFrom,
 				EmailTo:   watcherEmailRecipients,
@@ -121,7 +137,7 @@ func AddInterviewer(h wand.Wand) http.HandlerFunc {
 			GenerateEmail(hedwig.GenerateEmailReq{
 				TemplateName: hedwig.NotifyNewInterviewer,
 				Args: map[string]string{
-					"InterviewURL": "TODO",
+					"InterviewURL": interviewURL,
 				},
 				EmailFrom: vetchi.EmailFrom,
 				EmailTo:   []string{addInterviewerReq.OrgUserEmail},
@@ -135,6 +151,17 @@ func AddInterviewer(h wand.Wand) http.HandlerFunc {
 			return
 		}
 
+		// The new interviewer gets the calendar entry as it is now, while
+		// the existing attendees keep theirs
+		participants.InterviewerEmails = append(
+			participants.InterviewerEmails,
+			addInterviewerReq.OrgUserEmail,
+		)
+		interviewerNotification.EmailCalendarEvent = icalInvite(
+			util.ICalMethodRequest,
+			employeeICalEvent(h, addInterviewerReq.InterviewID, participants),
+		)
+
 		candidacyComment := fmt.Sprintf(
 			"%s added %s as an interviewer for %s TODO: i18n",
 			orgUser.Name,
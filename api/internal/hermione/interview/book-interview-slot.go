@@ -0,0 +1,162 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/meeting"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func BookInterviewSlot(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered BookInterviewSlot")
+		var bookReq hub.BookInterviewSlotRequest
+		if err := json.NewDecoder(r.Body).Decode(&bookReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &bookReq) {
+			h.Dbg("validation failed", "bookReq", bookReq)
+			return
+		}
+		h.Dbg("validated", "bookReq", bookReq)
+
+		srParticipants, err := h.DB().GetSchedulingRequestParticipants(
+			r.Context(),
+			bookReq.SchedulingRequestID,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoSchedulingRequest) {
+				h.Dbg("scheduling request not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get participants", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		interviewID := util.RandomUniqueID(vetchi.InterviewIDLenBytes)
+
+		// The slot itself is verified to be free in the transaction
+		participants := srParticipants.InterviewParticipants
+		participants.StartTime = bookReq.StartTime
+		participants.EndTime = bookReq.StartTime.Add(
+			time.Duration(srParticipants.DurationMinutes) * time.Minute,
+		)
+
+		meetingURL, err := createMeetingRoom(
+			r.Context(),
+			h,
+			participants.MeetingProvider,
+			meeting.Room{
+				InterviewID: interviewID,
+				StartTime:   participants.StartTime,
+				EndTime:     participants.EndTime,
+				ManualURL:   srParticipants.MeetingURL,
+			},
+		)
+		if err != nil {
+			h.Err("failed to create meeting room", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		participants.MeetingURL = meetingURL
+
+		candidateEmail, err := h.Hedwig().
+			GenerateEmail(hedwig.GenerateEmailReq{
+				TemplateName: hedwig.NotifyApplicantInterview,
+				Args: map[string]string{
+					"InterviewURL": h.Config().Hub.WebURL + "/candidacy/" +
+						participants.CandidacyID,
+					"InterviewType": string(participants.InterviewType),
+					"StartTime": participants.StartTime.UTC().
+						Format(time.RFC1123),
+					"EndTime": participants.EndTime.UTC().
+						Format(time.RFC1123),
+					"Description":     participants.Description,
+					"InterviewerName": participants.CompanyName,
+					"MeetingURL":      meetingURL,
+				},
+				EmailFrom: vetchi.EmailFrom,
+				EmailTo:   []string{participants.CandidateEmail},
+				Subject:   "Interview Scheduled",
+			})
+		if err != nil {
+			h.Err("failed to generate candidate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		candidateEmail.EmailCalendarEvent = icalInvite(
+			util.ICalMethodRequest,
+			candidateICalEvent(h, interviewID, participants),
+		)
+
+		interviewerEmail, err := h.Hedwig().
+			GenerateEmail(hedwig.GenerateEmailReq{
+				TemplateName: hedwig.NotifyNewInterviewer,
+				Args: map[string]string{
+					"InterviewURL": h.Config().Employer.WebURL +
+						"/interviews/" + interviewID,
+					"MeetingURL": meetingURL,
+				},
+				EmailFrom: vetchi.EmailFrom,
+				EmailTo:   participants.InterviewerEmails,
+				Subject:   "Added as an Interviewer",
+			})
+		if err != nil {
+			h.Err("failed to generate interviewer email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		interviewerEmail.EmailCalendarEvent = icalInvite(
+			util.ICalMethodRequest,
+			employeeICalEvent(h, interviewID, participants),
+		)
+
+		err = h.DB().BookInterviewSlot(r.Context(), db.BookInterviewSlotReq{
+			BookInterviewSlotRequest: bookReq,
+			InterviewID:              interviewID,
+			Emails: []db.Email{
+				candidateEmail,
+				interviewerEmail,
+			},
+			RoomURL: meetingURL,
+		})
+		if err != nil {
+			deleteMeetingRoom(
+				r.Context(),
+				h,
+				participants.MeetingProvider,
+				meetingURL,
+			)
+
+			if writeSlotError(h, w, err) {
+				return
+			}
+			h.Dbg("failed to book interview slot", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("booked interview slot", "interviewID", interviewID)
+		err = json.NewEncoder(w).Encode(hub.BookInterviewSlotResponse{
+			InterviewID: interviewID,
+		})
+		if err != nil {
+			h.Err("failed to encode response", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
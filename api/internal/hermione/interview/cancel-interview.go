@@ -0,0 +1,96 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func CancelInterview(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered CancelInterview")
+		var cancelReq employer.CancelInterviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&cancelReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &cancelReq) {
+			h.Dbg("validation failed", "cancelReq", cancelReq)
+			return
+		}
+		h.Dbg("validated", "cancelReq", cancelReq)
+
+		participants, err := h.DB().
+			GetInterviewParticipants(r.Context(), cancelReq.InterviewID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("interview not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get participants", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		args := interviewChangeArgs(participants)
+		args["Reason"] = cancelReq.Reason
+
+		participants.ICalSequence++
+
+		emails, err := interviewChangeEmails(
+			h,
+			cancelReq.InterviewID,
+			participants,
+			util.ICalMethodCancel,
+			hedwig.InterviewCancelled,
+			"Interview cancelled for "+participants.OpeningTitle,
+			args,
+		)
+		if err != nil {
+			h.Err("failed to generate emails", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().CancelInterview(r.Context(), db.CancelInterviewReq{
+			CancelInterviewRequest: cancelReq,
+			Emails:                 emails,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("interview not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrInvalidInterviewState) {
+				h.Dbg("interview not scheduled", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to cancel interview", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		deleteMeetingRoom(
+			r.Context(),
+			h,
+			participants.MeetingProvider,
+			participants.MeetingURL,
+		)
+
+		h.Dbg("cancelled interview", "id", cancelReq.InterviewID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
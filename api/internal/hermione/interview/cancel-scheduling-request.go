@@ -0,0 +1,51 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func CancelSchedulingRequest(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered CancelSchedulingRequest")
+		var cancelReq employer.CancelSchedulingRequestRequest
+		if err := json.NewDecoder(r.Body).Decode(&cancelReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &cancelReq) {
+			h.Dbg("validation failed", "cancelReq", cancelReq)
+			return
+		}
+		h.Dbg("validated", "cancelReq", cancelReq)
+
+		err := h.DB().CancelSchedulingRequest(r.Context(), cancelReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoSchedulingRequest) {
+				h.Dbg("scheduling request not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrInvalidSchedulingRequestState) {
+				h.Dbg("scheduling request not open", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to cancel scheduling request", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("cancelled scheduling request", "cancelReq", cancelReq)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,94 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/caldav"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func ConnectCalDAV(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ConnectCalDAV")
+		var connectReq employer.ConnectCalDAVRequest
+		err := json.NewDecoder(r.Body).Decode(&connectReq)
+		if err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &connectReq) {
+			// Do not log the request, as it has the password
+			h.Dbg("validation failed")
+			return
+		}
+		h.Dbg("validated", "calendar_url", connectReq.CalendarURL)
+
+		client, err := caldav.NewClient(
+			connectReq.CalendarURL,
+			connectReq.Username,
+			connectReq.Password,
+			vetchi.CalDAVRequestTimeout,
+			h.Config().CalDAVAllowedHosts,
+		)
+		if err != nil {
+			h.Dbg("invalid calendar URL", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"calendar_url"},
+			})
+			return
+		}
+
+		// Checks that the calendar is reachable with the credentials, before
+		// they are stored
+		now := time.Now()
+		_, err = client.FreeBusy(r.Context(), now, now.Add(24*time.Hour))
+		if errors.Is(err, caldav.ErrForbiddenAddress) {
+			h.Dbg("calendar URL is not public", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"calendar_url"},
+			})
+			return
+		}
+		if err != nil {
+			h.Dbg("failed to query the calendar", "error", err)
+			http.Error(w, "", http.StatusUnprocessableEntity)
+			return
+		}
+
+		encryptedPassword, err := util.EncryptSecret(
+			h.Config().CalendarCredentialsKey,
+			connectReq.Password,
+		)
+		if err != nil {
+			h.Err("failed to encrypt the password", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().ConnectCalDAV(r.Context(), db.ConnectCalDAVReq{
+			CalendarURL:       connectReq.CalendarURL,
+			Username:          connectReq.Username,
+			EncryptedPassword: encryptedPassword,
+		})
+		if err != nil {
+			h.Dbg("failed to connect caldav", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("connected caldav", "calendar_url", connectReq.CalendarURL)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,158 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func CreateSchedulingRequest(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered CreateSchedulingRequest")
+		var createReq employer.CreateSchedulingRequestRequest
+		if err := json.NewDecoder(r.Body).Decode(&createReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &createReq) {
+			h.Dbg("validation failed", "createReq", createReq)
+			return
+		}
+		h.Dbg("validated", "createReq", createReq)
+
+		duration := time.Duration(createReq.DurationMinutes) * time.Minute
+		window := createReq.WindowEnd.Sub(createReq.WindowStart)
+		if window < duration || window > vetchi.MaxSchedulingWindow ||
+			!createReq.WindowEnd.After(time.Now()) {
+			h.Dbg("invalid window", "createReq", createReq)
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"window_end"},
+			})
+			return
+		}
+
+		candidate, err := h.DB().
+			GetCandidateInfo(r.Context(), createReq.CandidacyID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("no candidacy found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get candidate info", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		// The room is created only when the candidate books a slot
+		meetingProvider, err := interviewMeetingProvider(
+			r.Context(),
+			h,
+			createReq.InterviewType,
+			createReq.MeetingURL,
+		)
+		if err != nil {
+			if errors.Is(err, errMeetingURLNotAllowed) {
+				h.Dbg("meeting_url not allowed", "error", err)
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"meeting_url"},
+				})
+				return
+			}
+
+			h.Err("failed to get meeting provider", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		schedulingRequestID := util.RandomUniqueID(
+			vetchi.SchedulingRequestIDLenBytes,
+		)
+
+		schedulingURL := h.Config().Hub.WebURL + "/schedule-interview/" +
+			schedulingRequestID
+		candidateEmail, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.InterviewSchedulingRequest,
+			Args: map[string]string{
+				"CandidateName":   candidate.CandidateName,
+				"CompanyName":     candidate.CompanyName,
+				"OpeningTitle":    candidate.OpeningTitle,
+				"InterviewType":   string(createReq.InterviewType),
+				"DurationMinutes": strconv.Itoa(createReq.DurationMinutes),
+				"SchedulingURL":   schedulingURL,
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{candidate.CandidateEmail},
+			Subject: "Pick a slot for your interview for " +
+				candidate.OpeningTitle,
+		})
+		if err != nil {
+			h.Err("failed to generate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().CreateSchedulingRequest(
+			r.Context(),
+			db.CreateSchedulingRequestReq{
+				CreateSchedulingRequestRequest: createReq,
+				SchedulingRequestID:            schedulingRequestID,
+				CandidateEmail:                 candidateEmail,
+				MeetingProvider:                meetingProvider,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("no candidacy found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrInvalidCandidacyState) {
+				h.Dbg("candidacy not in valid state", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			if errors.Is(err, db.ErrNoOrgUser) ||
+				errors.Is(err, db.ErrNoAvailability) {
+				h.Dbg("invalid interviewers", "error", err)
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"interviewer_emails"},
+				})
+				return
+			}
+
+			h.Dbg("failed to create scheduling request", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("created scheduling request", "id", schedulingRequestID)
+		err = json.NewEncoder(w).Encode(
+			employer.CreateSchedulingRequestResponse{
+				SchedulingRequestID: schedulingRequestID,
+			},
+		)
+		if err != nil {
+			h.Err("failed to encode response", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
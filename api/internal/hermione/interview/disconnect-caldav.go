@@ -0,0 +1,30 @@
+package interview
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+)
+
+func DisconnectCalDAV(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered DisconnectCalDAV")
+
+		err := h.DB().DisconnectCalDAV(r.Context())
+		if err != nil {
+			if errors.Is(err, db.ErrNoCalDAVConnection) {
+				h.Dbg("caldav not connected")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to disconnect caldav", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("disconnected caldav")
+		w.WriteHeader(http.StatusOK)
+	}
+}
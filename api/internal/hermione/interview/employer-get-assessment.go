@@ -2,8 +2,10 @@ package interview
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/wand"
 	"github.com/vetchium/vetchium/typespec/employer"
 )
@@ -27,8 +29,14 @@ func EmployerGetAssessment(h wand.Wand) http.HandlerFunc {
 		assessment, err := h.DB().
 			GetAssessment(r.Context(), getAssessmentReq)
 		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("no interview found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
 			h.Dbg("error getting assessment", "error", err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
 		h.Dbg("got assessment", "assessment", assessment)
@@ -0,0 +1,50 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+func EmployerGetTakeHomeAssignment(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered EmployerGetTakeHomeAssignment")
+		var getReq common.GetTakeHomeAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&getReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getReq) {
+			h.Dbg("validation failed", "getReq", getReq)
+			return
+		}
+		h.Dbg("validated", "getReq", getReq)
+
+		assignment, err := h.DB().
+			GetEmployerTakeHomeAssignment(r.Context(), getReq.InterviewID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoTakeHomeAssignment) {
+				h.Dbg("take-home assignment not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get take-home assignment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(assignment)
+		if err != nil {
+			h.Err("failed to encode take-home assignment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+func EmployerGetTakeHomeFile(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered EmployerGetTakeHomeFile")
+		var getReq common.GetTakeHomeFileRequest
+		if err := json.NewDecoder(r.Body).Decode(&getReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getReq) {
+			h.Dbg("validation failed", "getReq", getReq)
+			return
+		}
+		h.Dbg("validated", "getReq", getReq)
+
+		file, err := h.DB().GetEmployerTakeHomeFile(r.Context(), getReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoTakeHomeFile) {
+				h.Dbg("take-home file not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get take-home file", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		serveTakeHomeFile(w, r, h, file)
+	}
+}
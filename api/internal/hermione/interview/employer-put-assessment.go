@@ -7,6 +7,7 @@ import (
 
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
 	"github.com/vetchium/vetchium/typespec/employer"
 )
 
@@ -26,6 +27,17 @@ func EmployerPutAssessment(h wand.Wand) http.HandlerFunc {
 			return
 		}
 
+		// The assessment is submitted as the scorecard of the interviewer,
+		// which must have a decision
+		if assessment.Decision == "" {
+			h.Dbg("no decision", "interview_id", assessment.InterviewID)
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"decision"},
+			})
+			return
+		}
+
 		if err := h.DB().PutAssessment(r.Context(), assessment); err != nil {
 			if errors.Is(err, db.ErrNoInterview) {
 				h.Dbg("no interview found", "error", err)
@@ -39,6 +51,12 @@ func EmployerPutAssessment(h wand.Wand) http.HandlerFunc {
 				return
 			}
 
+			if errors.Is(err, db.ErrScorecardSubmitted) {
+				h.Dbg("scorecard already submitted", "error", err)
+				http.Error(w, "", http.StatusConflict)
+				return
+			}
+
 			if errors.Is(err, db.ErrStateMismatch) {
 				h.Dbg("interview state mismatch", "error", err)
 				http.Error(w, "", http.StatusUnprocessableEntity)
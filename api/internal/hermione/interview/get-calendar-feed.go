@@ -0,0 +1,69 @@
+package interview
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+)
+
+// calendarFeedPath is the path of the feed relative to the API server. The
+// clients prefix it with the URL of the API server to subscribe to the feed.
+func calendarFeedPath(token string) string {
+	return "/calendar-feeds/" + token + "/interviews.ics"
+}
+
+// GetCalendarFeed serves the upcoming interviews of an org user or a hub user
+// as an iCalendar subscription feed. The secret token in the path is the only
+// authentication, as the calendar clients cannot send the session tokens.
+func GetCalendarFeed(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetCalendarFeed")
+		token := r.PathValue("token")
+		if token == "" {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		feed, err := h.DB().GetCalendarFeed(r.Context(), token)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCalendarFeed) {
+				h.Dbg("no calendar feed")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get calendar feed", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		events := make([]util.ICalEvent, 0, len(feed.Interviews))
+		for _, interview := range feed.Interviews {
+			if feed.OwnerType == db.HubUserAuthorType {
+				events = append(events, candidateICalEvent(
+					h,
+					interview.InterviewID,
+					interview.InterviewParticipants,
+				))
+			} else {
+				events = append(events, employeeICalEvent(
+					h,
+					interview.InterviewID,
+					interview.InterviewParticipants,
+				))
+			}
+		}
+
+		calendar := util.ICalendar(util.ICalMethodPublish, events)
+		w.Header().Set("Content-Type", util.ICalContentType(calendar))
+		w.Header().Set("Cache-Control", "private, no-store")
+		_, err = w.Write([]byte(calendar))
+		if err != nil {
+			h.Err("failed to write calendar feed", "error", err)
+			return
+		}
+	}
+}
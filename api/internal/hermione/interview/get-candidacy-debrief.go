@@ -0,0 +1,49 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetCandidacyDebrief(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetCandidacyDebrief")
+		var getReq employer.GetCandidacyDebriefRequest
+		if err := json.NewDecoder(r.Body).Decode(&getReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getReq) {
+			h.Dbg("validation failed", "getReq", getReq)
+			return
+		}
+		h.Dbg("validated", "getReq", getReq)
+
+		debrief, err := h.DB().GetCandidacyDebrief(r.Context(), getReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoCandidacy) {
+				h.Dbg("candidacy not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("error getting debrief", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		h.Dbg("got debrief", "interviews", len(debrief.Interviews))
+
+		if err := json.NewEncoder(w).Encode(debrief); err != nil {
+			h.Err("error encoding debrief", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
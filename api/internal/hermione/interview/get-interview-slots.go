@@ -0,0 +1,48 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func GetInterviewSlots(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetInterviewSlots")
+		var getSlotsReq hub.GetInterviewSlotsRequest
+		if err := json.NewDecoder(r.Body).Decode(&getSlotsReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getSlotsReq) {
+			h.Dbg("validation failed", "getSlotsReq", getSlotsReq)
+			return
+		}
+		h.Dbg("validated", "getSlotsReq", getSlotsReq)
+
+		slots, err := h.DB().GetInterviewSlots(r.Context(), getSlotsReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoSchedulingRequest) {
+				h.Dbg("scheduling request not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get interview slots", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(slots)
+		if err != nil {
+			h.Err("failed to encode response", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetOpeningCompetencies(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetOpeningCompetencies")
+		var getReq employer.GetOpeningCompetenciesRequest
+		if err := json.NewDecoder(r.Body).Decode(&getReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getReq) {
+			h.Dbg("validation failed", "getReq", getReq)
+			return
+		}
+		h.Dbg("validated", "getReq", getReq)
+
+		competencies, err := h.DB().GetOpeningCompetencies(r.Context(), getReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "opening_id", getReq.OpeningID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("error getting competencies", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		h.Dbg("got competencies", "count", len(competencies))
+
+		if err := json.NewEncoder(w).Encode(competencies); err != nil {
+			h.Err("error encoding competencies", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
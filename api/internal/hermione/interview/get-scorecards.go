@@ -0,0 +1,49 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetScorecards(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetScorecards")
+		var getReq employer.GetScorecardsRequest
+		if err := json.NewDecoder(r.Body).Decode(&getReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getReq) {
+			h.Dbg("validation failed", "getReq", getReq)
+			return
+		}
+		h.Dbg("validated", "getReq", getReq)
+
+		scorecards, err := h.DB().GetScorecards(r.Context(), getReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("no interview found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("error getting scorecards", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		h.Dbg("got scorecards", "count", len(scorecards.Scorecards))
+
+		if err := json.NewEncoder(w).Encode(scorecards); err != nil {
+			h.Err("error encoding scorecards", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
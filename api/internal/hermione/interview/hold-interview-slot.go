@@ -0,0 +1,68 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func HoldInterviewSlot(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered HoldInterviewSlot")
+		var holdReq hub.HoldInterviewSlotRequest
+		if err := json.NewDecoder(r.Body).Decode(&holdReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &holdReq) {
+			h.Dbg("validation failed", "holdReq", holdReq)
+			return
+		}
+		h.Dbg("validated", "holdReq", holdReq)
+
+		expiresAt, err := h.DB().HoldInterviewSlot(r.Context(), holdReq)
+		if err != nil {
+			if writeSlotError(h, w, err) {
+				return
+			}
+			h.Dbg("failed to hold interview slot", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("held interview slot", "expiresAt", expiresAt)
+		err = json.NewEncoder(w).Encode(hub.HoldInterviewSlotResponse{
+			HoldExpiresAt: expiresAt,
+		})
+		if err != nil {
+			h.Err("failed to encode response", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// writeSlotError writes the response for the errors common to holding and
+// booking a slot and returns false if the error is not one of them
+func writeSlotError(h wand.Wand, w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, db.ErrNoSchedulingRequest):
+		h.Dbg("scheduling request not found", "error", err)
+		http.Error(w, "", http.StatusNotFound)
+	case errors.Is(err, db.ErrSlotUnavailable):
+		h.Dbg("slot not available", "error", err)
+		http.Error(w, "", http.StatusConflict)
+	case errors.Is(err, db.ErrInvalidSchedulingRequestState):
+		h.Dbg("scheduling request not open", "error", err)
+		http.Error(w, "", http.StatusUnprocessableEntity)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package interview
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+func HubGetCalendarFeed(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered HubGetCalendarFeed")
+
+		newToken := util.RandomUniqueID(vetchi.CalendarFeedTokenLenBytes)
+		token, err := h.DB().GetHubUserCalendarFeed(r.Context(), newToken)
+		if err != nil {
+			h.Dbg("failed to get calendar feed", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(common.CalendarFeed{
+			FeedPath: calendarFeedPath(token),
+		})
+		if err != nil {
+			h.Err("failed to encode response", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package interview
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// HubResetCalendarFeed replaces the token of the calendar feed, so that
+// the previously shared feed URL stops working
+func HubResetCalendarFeed(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered HubResetCalendarFeed")
+
+		newToken := util.RandomUniqueID(vetchi.CalendarFeedTokenLenBytes)
+		err := h.DB().ResetHubUserCalendarFeed(r.Context(), newToken)
+		if err != nil {
+			h.Dbg("failed to reset calendar feed", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(common.CalendarFeed{
+			FeedPath: calendarFeedPath(newToken),
+		})
+		if err != nil {
+			h.Err("failed to encode response", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
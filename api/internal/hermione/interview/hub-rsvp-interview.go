@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
 	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
 	"github.com/vetchium/vetchium/typespec/hub"
 )
 
@@ -24,6 +28,11 @@ func HubRSVPInterview(h wand.Wand) http.HandlerFunc {
 		}
 		h.Dbg("Validated", "rsvpReq", rsvpReq)
 
+		if len(rsvpReq.ProposedSlots) > 0 {
+			requestReschedule(h, w, r, rsvpReq)
+			return
+		}
+
 		err := h.DB().HubRSVPInterview(r.Context(), rsvpReq)
 		if err != nil {
 			if errors.Is(err, db.ErrNoInterview) {
@@ -43,3 +52,94 @@ func HubRSVPInterview(h wand.Wand) http.HandlerFunc {
 		w.WriteHeader(http.StatusOK)
 	}
 }
+
+// requestReschedule declines the interview on behalf of the candidate and
+// notifies the interviewers and the watchers of the alternative slots
+// proposed by the candidate
+func requestReschedule(
+	h wand.Wand,
+	w http.ResponseWriter,
+	r *http.Request,
+	rsvpReq hub.HubRSVPInterviewRequest,
+) {
+	if rsvpReq.RSVPStatus != common.NoRSVP {
+		h.Dbg("proposed slots without declining", "rsvpReq", rsvpReq)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(common.ValidationErrors{
+			Errors: []string{"proposed_slots"},
+		})
+		return
+	}
+
+	var slots []string
+	for _, slot := range rsvpReq.ProposedSlots {
+		if !slot.StartTime.After(time.Now()) ||
+			!slot.EndTime.After(slot.StartTime) {
+			h.Dbg("invalid proposed slot", "slot", slot)
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"proposed_slots"},
+			})
+			return
+		}
+		slots = append(slots, slot.StartTime.UTC().Format(time.RFC1123)+
+			" to "+slot.EndTime.UTC().Format(time.RFC1123))
+	}
+
+	participants, err := h.DB().
+		GetInterviewParticipants(r.Context(), rsvpReq.InterviewID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoInterview) {
+			h.Dbg("interview not found")
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		h.Dbg("failed to get participants", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	args := interviewChangeArgs(participants)
+	args["ProposedSlots"] = strings.Join(slots, "\n")
+	if rsvpReq.Reason != nil {
+		args["Reason"] = *rsvpReq.Reason
+	}
+
+	email, err := interviewEmployeesEmail(
+		h,
+		rsvpReq.InterviewID,
+		participants,
+		hedwig.InterviewRescheduleRequested,
+		participants.CandidateName+" has requested to reschedule the interview",
+		args,
+	)
+	if err != nil {
+		h.Err("failed to generate email", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	err = h.DB().RequestInterviewReschedule(
+		r.Context(),
+		db.RequestInterviewRescheduleReq{
+			HubRSVPInterviewRequest: rsvpReq,
+			Email:                   email,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoInterview) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, db.ErrInvalidInterviewState) {
+			http.Error(w, "", http.StatusUnprocessableEntity)
+			return
+		}
+		h.Dbg("failed to request reschedule", "err", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	h.Dbg("requested reschedule", "interview_id", rsvpReq.InterviewID)
+	w.WriteHeader(http.StatusOK)
+}
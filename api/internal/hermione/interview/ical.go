@@ -0,0 +1,73 @@
+package interview
+
+import (
+	"fmt"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+)
+
+// interviewICalEvent returns the calendar entry of the interview that is
+// common to the candidate and the employees. The UID is derived from the
+// interview ID so that all the invites and the feeds refer to the same entry.
+func interviewICalEvent(
+	interviewID string,
+	participants db.InterviewParticipants,
+) util.ICalEvent {
+	return util.ICalEvent{
+		UID:         interviewID + "@" + vetchi.ICalUIDDomain,
+		Sequence:    participants.ICalSequence,
+		StartTime:   participants.StartTime,
+		EndTime:     participants.EndTime,
+		Description: participants.Description,
+		Location:    participants.MeetingURL,
+		Organizer:   vetchi.EmailFrom,
+	}
+}
+
+// candidateICalEvent does not list the interviewers as attendees, so that
+// the candidate does not get to see their email addresses
+func candidateICalEvent(
+	h wand.Wand,
+	interviewID string,
+	participants db.InterviewParticipants,
+) util.ICalEvent {
+	event := interviewICalEvent(interviewID, participants)
+	event.Summary = fmt.Sprintf(
+		"%s interview with %s for %s",
+		participants.InterviewType,
+		participants.CompanyName,
+		participants.OpeningTitle,
+	)
+	event.URL = h.Config().Hub.WebURL + "/candidacy/" +
+		participants.CandidacyID
+	if participants.CandidateEmail != "" {
+		event.Attendees = []string{participants.CandidateEmail}
+	}
+	return event
+}
+
+func employeeICalEvent(
+	h wand.Wand,
+	interviewID string,
+	participants db.InterviewParticipants,
+) util.ICalEvent {
+	event := interviewICalEvent(interviewID, participants)
+	event.Summary = fmt.Sprintf(
+		"%s interview of %s for %s",
+		participants.InterviewType,
+		participants.CandidateName,
+		participants.OpeningTitle,
+	)
+	event.URL = h.Config().Employer.WebURL + "/interviews/" + interviewID
+	event.Attendees = participants.InterviewerEmails
+	return event
+}
+
+// icalInvite renders the event as an invite for the email attachment
+func icalInvite(method string, event util.ICalEvent) string {
+	event.Cancelled = method == util.ICalMethodCancel
+	return util.ICalendar(method, []util.ICalEvent{event})
+}
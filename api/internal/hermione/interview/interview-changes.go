@@ -0,0 +1,120 @@
+package interview
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+)
+
+// interviewChangeArgs returns the email arguments common to all the
+// notifications about the changes to an interview
+func interviewChangeArgs(
+	participants db.InterviewParticipants,
+) map[string]string {
+	return map[string]string{
+		"CandidateName": participants.CandidateName,
+		"CompanyName":   participants.CompanyName,
+		"OpeningTitle":  participants.OpeningTitle,
+		"InterviewType": string(participants.InterviewType),
+		"StartTime":     participants.StartTime.UTC().Format(time.RFC1123),
+		"EndTime":       participants.EndTime.UTC().Format(time.RFC1123),
+		"MeetingURL":    participants.MeetingURL,
+	}
+}
+
+// interviewChangeEmails generates separate emails for the candidate and for
+// the employees, so that the candidate does not get to see the email
+// addresses of the interviewers and the watchers. The participants should
+// reflect the interview after the change, as the emails carry the calendar
+// invites for the icalMethod.
+func interviewChangeEmails(
+	h wand.Wand,
+	interviewID string,
+	participants db.InterviewParticipants,
+	icalMethod string,
+	templateName string,
+	subject string,
+	args map[string]string,
+) ([]db.Email, error) {
+	candidateArgs := make(map[string]string)
+	for k, v := range args {
+		candidateArgs[k] = v
+	}
+	candidateArgs["InterviewURL"] = h.Config().Hub.WebURL + "/candidacy/" +
+		participants.CandidacyID
+
+	candidateEmail, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+		TemplateName: templateName,
+		Args:         candidateArgs,
+		EmailFrom:    vetchi.EmailFrom,
+		EmailTo:      []string{participants.CandidateEmail},
+		Subject:      subject,
+	})
+	if err != nil {
+		return nil, err
+	}
+	candidateEmail.EmailCalendarEvent = icalInvite(
+		icalMethod,
+		candidateICalEvent(h, interviewID, participants),
+	)
+
+	employeeEmail, err := interviewEmployeesEmail(
+		h,
+		interviewID,
+		participants,
+		templateName,
+		subject,
+		args,
+	)
+	if err != nil {
+		return nil, err
+	}
+	employeeEmail.EmailCalendarEvent = icalInvite(
+		icalMethod,
+		employeeICalEvent(h, interviewID, participants),
+	)
+
+	return []db.Email{candidateEmail, employeeEmail}, nil
+}
+
+// interviewEmployeesEmail generates an email for the interviewers and the
+// watchers of the interview
+func interviewEmployeesEmail(
+	h wand.Wand,
+	interviewID string,
+	participants db.InterviewParticipants,
+	templateName string,
+	subject string,
+	args map[string]string,
+) (db.Email, error) {
+	recipients := make(map[string]struct{})
+	for _, email := range participants.InterviewerEmails {
+		recipients[email] = struct{}{}
+	}
+	for _, email := range participants.WatcherEmails {
+		recipients[email] = struct{}{}
+	}
+
+	emailTo := make([]string, 0, len(recipients))
+	for email := range recipients {
+		emailTo = append(emailTo, email)
+	}
+
+	employeeArgs := make(map[string]string)
+	for k, v := range args {
+		employeeArgs[k] = v
+	}
+	employeeArgs["InterviewURL"] = h.Config().Employer.WebURL +
+		"/interviews/" + interviewID
+
+	return h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+		TemplateName: templateName,
+		Args:         employeeArgs,
+		EmailFrom:    vetchi.EmailFrom,
+		EmailTo:      emailTo,
+		Subject:      subject,
+	})
+}
@@ -0,0 +1,115 @@
+package interview
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/meeting"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+// errMeetingURLNotAllowed is returned when a meeting_url is pasted for an
+// interview that does not use the manual meeting provider
+var errMeetingURLNotAllowed = errors.New("meeting_url not allowed")
+
+// interviewMeetingProvider returns the meeting provider that the employer
+// has chosen for the VIDEO_CALL interviews. Other types of interviews have
+// no meeting room and so an empty provider is returned for them.
+func interviewMeetingProvider(
+	ctx context.Context,
+	h wand.Wand,
+	interviewType common.InterviewType,
+	manualURL string,
+) (employer.MeetingProvider, error) {
+	if interviewType != common.VideoCallInterviewType {
+		if manualURL != "" {
+			return "", errMeetingURLNotAllowed
+		}
+		return "", nil
+	}
+
+	provider, err := h.DB().GetMeetingProvider(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if manualURL != "" && provider != employer.ManualMeetingProvider {
+		return "", errMeetingURLNotAllowed
+	}
+
+	return provider, nil
+}
+
+// createMeetingRoom creates the room with the provider, if any, that was
+// chosen for the interview and returns its URL
+func createMeetingRoom(
+	ctx context.Context,
+	h wand.Wand,
+	providerName employer.MeetingProvider,
+	room meeting.Room,
+) (string, error) {
+	if providerName == "" {
+		return "", nil
+	}
+
+	provider, err := h.Meetings().Get(providerName)
+	if err != nil {
+		return "", err
+	}
+
+	return provider.CreateRoom(ctx, room)
+}
+
+// updateMeetingRoom moves the room of a rescheduled interview and returns
+// its URL, which may have changed. A new manualURL can only be pasted for
+// the interviews that use the manual meeting provider.
+func updateMeetingRoom(
+	ctx context.Context,
+	h wand.Wand,
+	participants db.InterviewParticipants,
+	room meeting.Room,
+) (string, error) {
+	if room.ManualURL != "" &&
+		participants.MeetingProvider != employer.ManualMeetingProvider {
+		return "", errMeetingURLNotAllowed
+	}
+
+	if participants.MeetingProvider == "" {
+		return "", nil
+	}
+
+	provider, err := h.Meetings().Get(participants.MeetingProvider)
+	if err != nil {
+		return "", err
+	}
+
+	return provider.UpdateRoom(ctx, participants.MeetingURL, room)
+}
+
+// deleteMeetingRoom deletes the room of an interview on a best effort
+// basis. A room that is left behind does no harm other than clutter with
+// the meeting provider and so the failures are only logged.
+func deleteMeetingRoom(
+	ctx context.Context,
+	h wand.Wand,
+	providerName employer.MeetingProvider,
+	meetingURL string,
+) {
+	if providerName == "" || meetingURL == "" {
+		return
+	}
+
+	provider, err := h.Meetings().Get(providerName)
+	if err != nil {
+		h.Err("failed to get meeting provider", "error", err)
+		return
+	}
+
+	err = provider.DeleteRoom(ctx, meetingURL)
+	if err != nil {
+		h.Err("failed to delete meeting room", "error", err)
+	}
+}
@@ -0,0 +1,72 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func PutScorecard(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered PutScorecard")
+		var scorecard employer.PutScorecardRequest
+		if err := json.NewDecoder(r.Body).Decode(&scorecard); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &scorecard) {
+			h.Dbg("validation failed", "scorecard", scorecard)
+			return
+		}
+		h.Dbg("validated", "interview_id", scorecard.InterviewID)
+
+		if err := h.DB().PutScorecard(r.Context(), scorecard); err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("no interview found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrNotAnInterviewer) {
+				h.Dbg("not an interviewer", "error", err)
+				http.Error(w, "", http.StatusForbidden)
+				return
+			}
+
+			if errors.Is(err, db.ErrStateMismatch) {
+				h.Dbg("interview state mismatch", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			if errors.Is(err, db.ErrScorecardSubmitted) {
+				h.Dbg("scorecard already submitted", "error", err)
+				http.Error(w, "", http.StatusConflict)
+				return
+			}
+
+			if errors.Is(err, db.ErrUnknownCompetency) {
+				h.Dbg("unknown competency", "error", err)
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"ratings"},
+				})
+				return
+			}
+
+			h.Dbg("error putting scorecard", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("scorecard put successfully", "id", scorecard.InterviewID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/hedwig"
 	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/internal/util"
 	"github.com/vetchium/vetchium/api/internal/wand"
 	"github.com/vetchium/vetchium/api/pkg/vetchi"
 	"github.com/vetchium/vetchium/typespec/employer"
@@ -33,11 +34,25 @@ func RemoveInterviewer(h wand.Wand) http.HandlerFunc {
 
 		ctx := r.Context()
 
+		participants, err := h.DB().
+			GetInterviewParticipants(ctx, removeInterviewerReq.InterviewID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get participants", "err", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
 		removedInterviewerNotify, err := h.Hedwig().
 			GenerateEmail(hedwig.GenerateEmailReq{
 				TemplateName: hedwig.RemovedInterviewerNotify,
 				Args: map[string]string{
-					"InterviewURL": "TODO",
+					"InterviewURL": h.Config().Employer.WebURL +
+						"/interviews/" + removeInterviewerReq.InterviewID,
 				},
 				EmailFrom: vetchi.EmailFrom,
 				EmailTo:   []string{removeInterviewerReq.OrgUserEmail},
@@ -51,6 +66,20 @@ func RemoveInterviewer(h wand.Wand) http.HandlerFunc {
 			return
 		}
 
+		// Cancels the calendar entry of only the removed interviewer
+		participants.ICalSequence++
+		participants.InterviewerEmails = []string{
+			removeInterviewerReq.OrgUserEmail,
+		}
+		removedInterviewerNotify.EmailCalendarEvent = icalInvite(
+			util.ICalMethodCancel,
+			employeeICalEvent(
+				h,
+				removeInterviewerReq.InterviewID,
+				participants,
+			),
+		)
+
 		orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
 		if !ok {
 			h.Err("failed to get orgUser from context")
@@ -0,0 +1,151 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/meeting"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func RescheduleInterview(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered RescheduleInterview")
+		var rescheduleReq employer.RescheduleInterviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&rescheduleReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &rescheduleReq) {
+			h.Dbg("validation failed", "rescheduleReq", rescheduleReq)
+			return
+		}
+		h.Dbg("validated", "rescheduleReq", rescheduleReq)
+
+		if !rescheduleReq.StartTime.After(time.Now()) {
+			h.Dbg("start_time is not in the future")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"start_time"},
+			})
+			return
+		}
+
+		if !rescheduleReq.EndTime.After(rescheduleReq.StartTime) {
+			h.Dbg("end_time is not after start_time")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"end_time"},
+			})
+			return
+		}
+
+		participants, err := h.DB().
+			GetInterviewParticipants(r.Context(), rescheduleReq.InterviewID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("interview not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get participants", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		var manualURL string
+		if rescheduleReq.MeetingURL != nil {
+			manualURL = *rescheduleReq.MeetingURL
+		}
+		meetingURL, err := updateMeetingRoom(
+			r.Context(),
+			h,
+			participants,
+			meeting.Room{
+				InterviewID: rescheduleReq.InterviewID,
+				StartTime:   rescheduleReq.StartTime,
+				EndTime:     rescheduleReq.EndTime,
+				ManualURL:   manualURL,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, errMeetingURLNotAllowed) {
+				h.Dbg("meeting_url not allowed", "error", err)
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"meeting_url"},
+				})
+				return
+			}
+
+			h.Err("failed to update meeting room", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		args := interviewChangeArgs(participants)
+		args["PreviousStartTime"] = args["StartTime"]
+		args["PreviousEndTime"] = args["EndTime"]
+		args["StartTime"] = rescheduleReq.StartTime.UTC().Format(time.RFC1123)
+		args["EndTime"] = rescheduleReq.EndTime.UTC().Format(time.RFC1123)
+		if rescheduleReq.Reason != nil {
+			args["Reason"] = *rescheduleReq.Reason
+		}
+
+		participants.StartTime = rescheduleReq.StartTime
+		participants.EndTime = rescheduleReq.EndTime
+		participants.ICalSequence++
+		participants.MeetingURL = meetingURL
+		args["MeetingURL"] = meetingURL
+
+		emails, err := interviewChangeEmails(
+			h,
+			rescheduleReq.InterviewID,
+			participants,
+			util.ICalMethodRequest,
+			hedwig.InterviewRescheduled,
+			"Interview rescheduled for "+participants.OpeningTitle,
+			args,
+		)
+		if err != nil {
+			h.Err("failed to generate emails", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().RescheduleInterview(r.Context(), db.RescheduleInterviewReq{
+			RescheduleInterviewRequest: rescheduleReq,
+			Emails:                     emails,
+			RoomURL:                    meetingURL,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("interview not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrInvalidInterviewState) {
+				h.Dbg("interview not scheduled", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to reschedule interview", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("rescheduled interview", "id", rescheduleReq.InterviewID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,54 @@
+package interview
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func SetMyAvailability(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SetMyAvailability")
+		var availability employer.OrgUserAvailability
+		err := json.NewDecoder(r.Body).Decode(&availability)
+		if err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &availability) {
+			h.Dbg("validation failed", "availability", availability)
+			return
+		}
+		h.Dbg("validated", "availability", availability)
+
+		// Windows crossing midnight should be split into two windows
+		for _, window := range availability.Windows {
+			start, _ := time.Parse("15:04", window.StartTime)
+			end, _ := time.Parse("15:04", window.EndTime)
+			if !end.After(start) {
+				h.Dbg("window ends before it starts", "window", window)
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"windows"},
+				})
+				return
+			}
+		}
+
+		err = h.DB().SetOrgUserAvailability(r.Context(), availability)
+		if err != nil {
+			h.Dbg("failed to set availability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("set availability")
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,45 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func SetOpeningCompetencies(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SetOpeningCompetencies")
+		var setReq employer.SetOpeningCompetenciesRequest
+		if err := json.NewDecoder(r.Body).Decode(&setReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &setReq) {
+			h.Dbg("validation failed", "setReq", setReq)
+			return
+		}
+		h.Dbg("validated", "setReq", setReq)
+
+		err := h.DB().SetOpeningCompetencies(r.Context(), setReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "opening_id", setReq.OpeningID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("error setting competencies", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("set competencies", "opening_id", setReq.OpeningID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,138 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func SetTakeHomeAssignment(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SetTakeHomeAssignment")
+		var setReq employer.SetTakeHomeAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&setReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &setReq) {
+			h.Dbg("validation failed", "interview_id", setReq.InterviewID)
+			return
+		}
+		h.Dbg("validated", "interview_id", setReq.InterviewID)
+
+		participants, err := h.DB().
+			GetInterviewParticipants(r.Context(), setReq.InterviewID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("interview not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get participants", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		// Check all the files before uploading any
+		var documents []takeHomeDocument
+		for _, file := range setReq.Files {
+			document, err := checkTakeHomeFile(r.Context(), h, file)
+			if err != nil {
+				if errors.Is(err, errBadTakeHomeFile) {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(common.ValidationErrors{
+						Errors: []string{"files"},
+					})
+					return
+				}
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			documents = append(documents, document)
+		}
+
+		s3Client := newS3Client(h)
+		var files []db.TakeHomeFile
+		for _, document := range documents {
+			file, err := uploadTakeHomeFile(r.Context(), h, s3Client, document)
+			if err != nil {
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			files = append(files, file)
+		}
+
+		args := map[string]string{
+			"CandidateName": participants.CandidateName,
+			"CompanyName":   participants.CompanyName,
+			"OpeningTitle":  participants.OpeningTitle,
+			"AvailableFrom": participants.StartTime.UTC().Format(time.RFC1123),
+			"Deadline":      participants.EndTime.UTC().Format(time.RFC1123),
+			"InterviewURL": h.Config().Hub.WebURL + "/candidacy/" +
+				participants.CandidacyID,
+		}
+		if setReq.TimeLimitMinutes != nil {
+			args["TimeLimit"] = strconv.Itoa(*setReq.TimeLimitMinutes)
+		}
+
+		email, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.TakeHomeAssignment,
+			Args:         args,
+			EmailFrom:    vetchi.EmailFrom,
+			EmailTo:      []string{participants.CandidateEmail},
+			Subject: "Take-home assignment for " +
+				participants.OpeningTitle,
+		})
+		if err != nil {
+			h.Err("failed to generate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().SetTakeHomeAssignment(
+			r.Context(),
+			db.SetTakeHomeAssignmentReq{
+				InterviewID:          setReq.InterviewID,
+				Brief:                setReq.Brief,
+				Files:                files,
+				TimeLimitMinutes:     setReq.TimeLimitMinutes,
+				LateSubmissionPolicy: setReq.LateSubmissionPolicy,
+				GracePeriodMinutes:   setReq.GracePeriodMinutes,
+				Email:                email,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("interview not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrNotTakeHome) ||
+				errors.Is(err, db.ErrInvalidInterviewState) ||
+				errors.Is(err, db.ErrStateMismatch) {
+				h.Dbg("cannot set take-home assignment", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to set take-home assignment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("set take-home assignment", "id", setReq.InterviewID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,67 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func StartTakeHomeAssignment(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered StartTakeHomeAssignment")
+		var startReq hub.StartTakeHomeAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&startReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &startReq) {
+			h.Dbg("validation failed", "startReq", startReq)
+			return
+		}
+		h.Dbg("validated", "startReq", startReq)
+
+		err := h.DB().StartTakeHomeAssignment(r.Context(), startReq.InterviewID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoTakeHomeAssignment) {
+				h.Dbg("take-home assignment not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrInvalidInterviewState) ||
+				errors.Is(err, db.ErrStateMismatch) ||
+				errors.Is(err, db.ErrTakeHomeNotOpen) {
+				h.Dbg("cannot start take-home assignment", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to start take-home assignment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		// The brief and the files are available only now
+		assignment, err := h.DB().
+			GetHubTakeHomeAssignment(r.Context(), startReq.InterviewID)
+		if err != nil {
+			h.Dbg("failed to get take-home assignment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("started take-home assignment", "id", startReq.InterviewID)
+		err = json.NewEncoder(w).Encode(assignment)
+		if err != nil {
+			h.Err("failed to encode take-home assignment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package interview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func SubmitTakeHomeAssignment(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SubmitTakeHomeAssignment")
+		var submitReq hub.SubmitTakeHomeAssignmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&submitReq); err != nil {
+			h.Dbg("decoding failed", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &submitReq) {
+			h.Dbg("validation failed", "interview_id", submitReq.InterviewID)
+			return
+		}
+		h.Dbg("validated", "interview_id", submitReq.InterviewID)
+
+		participants, err := h.DB().
+			GetInterviewParticipants(r.Context(), submitReq.InterviewID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoInterview) {
+				h.Dbg("interview not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+			h.Dbg("failed to get participants", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		document, err := checkTakeHomeFile(
+			r.Context(),
+			h,
+			submitReq.Submission,
+		)
+		if err != nil {
+			if errors.Is(err, errBadTakeHomeFile) {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(common.ValidationErrors{
+					Errors: []string{"submission"},
+				})
+				return
+			}
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s3Client := newS3Client(h)
+		file, err := uploadTakeHomeFile(r.Context(), h, s3Client, document)
+		if err != nil {
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		args := interviewChangeArgs(participants)
+		args["Filename"] = file.Filename
+
+		email, err := interviewEmployeesEmail(
+			h,
+			submitReq.InterviewID,
+			participants,
+			hedwig.TakeHomeSubmitted,
+			participants.CandidateName+" has submitted a take-home assignment",
+			args,
+		)
+		if err != nil {
+			h.Err("failed to generate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		submission, err := h.DB().SubmitTakeHomeAssignment(
+			r.Context(),
+			db.SubmitTakeHomeReq{
+				InterviewID: submitReq.InterviewID,
+				Submission:  file,
+				Email:       email,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoTakeHomeAssignment) {
+				h.Dbg("take-home assignment not found")
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrInvalidInterviewState) ||
+				errors.Is(err, db.ErrTakeHomeNotOpen) ||
+				errors.Is(err, db.ErrTooManySubmissions) {
+				h.Dbg("cannot submit take-home assignment", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Dbg("failed to submit take-home assignment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("submitted take-home assignment", "submission", submission)
+		err = json.NewEncoder(w).Encode(submission)
+		if err != nil {
+			h.Err("failed to encode take-home submission", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package interview
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/scanner"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// errBadTakeHomeFile is returned for the files that are not well-formed
+// PDFs or ZIP archives, are too large, or are found to be infected
+var errBadTakeHomeFile = errors.New("bad take-home file")
+
+func newS3Client(h wand.Wand) *s3.S3 {
+	cfg := h.Config()
+	s3Config := &aws.Config{
+		Credentials: credentials.NewStaticCredentials(
+			cfg.S3.AccessKey,
+			cfg.S3.SecretKey,
+			"",
+		),
+		Endpoint:         aws.String(cfg.S3.Endpoint),
+		Region:           aws.String(cfg.S3.Region),
+		S3ForcePathStyle: aws.Bool(true), // Required for MinIO
+	}
+	return s3.New(session.Must(session.NewSession(s3Config)))
+}
+
+// takeHomeDocument is a validated and scanned file that is yet to be stored
+type takeHomeDocument struct {
+	filename    string
+	contentType string
+	content     []byte
+}
+
+// checkTakeHomeFile decodes the file and scans it for malware
+func checkTakeHomeFile(
+	ctx context.Context,
+	h wand.Wand,
+	file common.TakeHomeFile,
+) (takeHomeDocument, error) {
+	content, contentType, err := util.ValidateDocument(
+		file.Document,
+		vetchi.MaxTakeHomeFileSize,
+	)
+	if err != nil {
+		h.Dbg("invalid take-home file", "error", err)
+		return takeHomeDocument{}, errBadTakeHomeFile
+	}
+
+	err = h.Scanner().Scan(ctx, content)
+	if err != nil {
+		if errors.Is(err, scanner.ErrInfected) {
+			h.Inf("infected take-home file", "filename", file.Filename)
+			return takeHomeDocument{}, errBadTakeHomeFile
+		}
+		h.Err("failed to scan take-home file", "error", err)
+		return takeHomeDocument{}, err
+	}
+
+	return takeHomeDocument{
+		filename:    file.Filename,
+		contentType: contentType,
+		content:     content,
+	}, nil
+}
+
+// uploadTakeHomeFile stores the document under its SHA-512 so that the same
+// file submitted again, or set for another interview, is stored only once
+func uploadTakeHomeFile(
+	ctx context.Context,
+	h wand.Wand,
+	s3Client *s3.S3,
+	document takeHomeDocument,
+) (db.TakeHomeFile, error) {
+	extension := "pdf"
+	if document.contentType == util.ZIPContentType {
+		extension = "zip"
+	}
+	hash := sha512.Sum512(document.content)
+	path := fmt.Sprintf("%s%x.%s", util.TakeHomesPath, hash, extension)
+
+	file := db.TakeHomeFile{
+		Filename:    document.filename,
+		FilePath:    path,
+		ContentType: document.contentType,
+		SizeBytes:   int64(len(document.content)),
+	}
+
+	bucket := h.Config().S3.Bucket
+	_, err := s3Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		h.Dbg("bucket does not exist, attempting to create", "bucket", bucket)
+		_, err = s3Client.CreateBucketWithContext(ctx, &s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			h.Err("failed to create bucket", "error", err)
+			return db.TakeHomeFile{}, fmt.Errorf("create bucket: %w", err)
+		}
+	}
+
+	_, err = s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(path),
+	})
+	if err == nil {
+		h.Dbg("take-home file already exists", "path", path)
+		return file, nil
+	}
+
+	_, err = s3Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(path),
+		Body:          bytes.NewReader(document.content),
+		ContentType:   aws.String(document.contentType),
+		ContentLength: aws.Int64(file.SizeBytes),
+	})
+	if err != nil {
+		h.Err("failed to upload take-home file", "error", err)
+		return db.TakeHomeFile{}, fmt.Errorf("upload take-home file: %w", err)
+	}
+
+	h.Dbg("uploaded take-home file", "path", path)
+	return file, nil
+}
+
+// serveTakeHomeFile streams the file as an attachment, as the archives
+// and the documents are not meant to be rendered by the browsers
+func serveTakeHomeFile(
+	w http.ResponseWriter,
+	r *http.Request,
+	h wand.Wand,
+	file db.TakeHomeFile,
+) {
+	result, err := newS3Client(h).GetObjectWithContext(
+		r.Context(),
+		&s3.GetObjectInput{
+			Bucket: aws.String(h.Config().S3.Bucket),
+			Key:    aws.String(file.FilePath),
+		},
+	)
+	if err != nil {
+		h.Err("failed to get take-home file from S3", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	defer result.Body.Close()
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", file.Filename),
+	)
+	if result.ContentLength != nil {
+		w.Header().
+			Set("Content-Length", fmt.Sprintf("%d", *result.ContentLength))
+	}
+
+	_, err = io.Copy(w, result.Body)
+	if err != nil {
+		// Headers might have been sent already
+		h.Err("failed to stream take-home file", "error", err)
+		return
+	}
+	h.Dbg("served take-home file", "path", file.FilePath)
+}
@@ -0,0 +1,120 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func ApproveOpening(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered ApproveOpening")
+		var approveReq employer.ApproveOpeningRequest
+		err := json.NewDecoder(r.Body).Decode(&approveReq)
+		if err != nil {
+			h.Dbg("failed to decode approve opening request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &approveReq) {
+			h.Dbg("validation failed", "approveReq", approveReq)
+			return
+		}
+		h.Dbg("validated", "approveReq", approveReq)
+
+		ctx := r.Context()
+		orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+		if !ok {
+			h.Err("failed to get orgUser from context")
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		opening, err := h.DB().GetOpening(
+			ctx,
+			employer.GetOpeningRequest{ID: approveReq.OpeningID},
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "id", approveReq.OpeningID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to get opening", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		approvals, err := h.DB().GetOpeningApprovals(
+			ctx,
+			employer.GetOpeningApprovalsRequest{OpeningID: approveReq.OpeningID},
+		)
+		if err != nil {
+			h.Err("failed to get opening approvals", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		current, ok := currentApprovalStep(approvals.Steps)
+		if !ok || approvals.Steps[current].Approver.Email != orgUser.Email {
+			h.Dbg("not waiting for the approval of the orgUser", "id", opening.ID)
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		var nextApprovalEmail *db.Email
+		if current+1 < len(approvals.Steps) {
+			nextApprover := approvals.Steps[current+1].Approver
+			email, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+				TemplateName: hedwig.OpeningApprovalRequest,
+				Args: map[string]string{
+					"ApproverName":   nextApprover.Name,
+					"RequestedBy":    approvals.RequestedBy.Name,
+					"OpeningTitle":   opening.Title,
+					"OpeningID":      opening.ID,
+					"CostCenterName": string(opening.CostCenterName),
+				},
+				EmailFrom: vetchi.EmailFrom,
+				EmailTo:   []string{nextApprover.Email},
+
+				// TODO: This should be dynamic and come from hedwig
+				Subject: "Approval requested for an Opening",
+			})
+			if err != nil {
+				h.Err("failed to generate approval email", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			nextApprovalEmail = &email
+		}
+
+		err = h.DB().ApproveOpening(ctx, db.ApproveOpeningReq{
+			OpeningID:         approveReq.OpeningID,
+			StepNumber:        approvals.Steps[current].StepNumber,
+			Comment:           approveReq.Comment,
+			NextApprovalEmail: nextApprovalEmail,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpeningApproval) {
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to approve opening", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("approved opening", "id", approveReq.OpeningID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -63,6 +63,8 @@ func ChangeOpeningState(h wand.Wand) http.HandlerFunc {
 				http.Error(w, "", http.StatusConflict)
 			} else if errors.Is(err, db.ErrNoOpening) {
 				http.Error(w, "", http.StatusNotFound)
+			} else if errors.Is(err, db.ErrOpeningApprovalPending) {
+				http.Error(w, "", http.StatusPreconditionRequired)
 			} else {
 				http.Error(w, "", http.StatusInternalServerError)
 			}
@@ -0,0 +1,77 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func CloneOpening(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered CloneOpening")
+		var cloneOpeningReq employer.CloneOpeningRequest
+		err := json.NewDecoder(r.Body).Decode(&cloneOpeningReq)
+		if err != nil {
+			h.Dbg("failed to decode clone opening request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &cloneOpeningReq) {
+			h.Dbg("validation failed", "cloneOpeningReq", cloneOpeningReq)
+			return
+		}
+		h.Dbg("validated", "cloneOpeningReq", cloneOpeningReq)
+
+		opening, err := h.DB().GetOpening(
+			r.Context(),
+			employer.GetOpeningRequest{ID: cloneOpeningReq.OpeningID},
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "id", cloneOpeningReq.OpeningID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to get opening", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		title := opening.Title
+		if cloneOpeningReq.Title != nil {
+			title = *cloneOpeningReq.Title
+		}
+
+		createOpeningReq := employer.CreateOpeningRequest{
+			Title:          title,
+			Positions:      opening.Positions,
+			JD:             opening.JD,
+			Recruiter:      common.EmailAddress(opening.Recruiter.Email),
+			HiringManager:  common.EmailAddress(opening.HiringManager.Email),
+			HiringTeam:     emailsOf(opening.HiringTeam),
+			CostCenterName: opening.CostCenterName,
+			LocationTitles: opening.LocationTitles,
+
+			RemoteCountryCodes: opening.RemoteCountryCodes,
+			RemoteTimezones:    opening.RemoteTimezones,
+
+			OpeningType: opening.OpeningType,
+			YoeMin:      opening.YoeMin,
+			YoeMax:      opening.YoeMax,
+
+			EmployerNotes:     opening.EmployerNotes,
+			MinEducationLevel: opening.MinEducationLevel,
+			Salary:            opening.Salary,
+			TagIDs:            tagIDsOf(opening.Tags),
+		}
+
+		createOpening(h, w, r, createOpeningReq)
+	}
+}
@@ -0,0 +1,100 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func CreateOpeningFromTemplate(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered CreateOpeningFromTemplate")
+		var fromTemplateReq employer.CreateOpeningFromTemplateRequest
+		err := json.NewDecoder(r.Body).Decode(&fromTemplateReq)
+		if err != nil {
+			h.Dbg("failed to decode from template request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &fromTemplateReq) {
+			h.Dbg("validation failed", "fromTemplateReq", fromTemplateReq)
+			return
+		}
+		h.Dbg("validated", "fromTemplateReq", fromTemplateReq)
+
+		template, err := h.DB().GetOpeningTemplate(
+			r.Context(),
+			employer.GetOpeningTemplateRequest{ID: fromTemplateReq.TemplateID},
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpeningTemplate) {
+				h.Dbg("template not found", "id", fromTemplateReq.TemplateID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to get opening template", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		vars := fromTemplateReq.Variables
+		title, titleOK := resolvePlaceholders(template.Title, vars)
+		jd, jdOK := resolvePlaceholders(template.JD, vars)
+		notesOK := true
+		var employerNotes *string
+		if template.EmployerNotes != nil {
+			var notes string
+			notes, notesOK = resolvePlaceholders(*template.EmployerNotes, vars)
+			employerNotes = &notes
+		}
+		if !titleOK || !jdOK || !notesOK {
+			h.Dbg("unresolved placeholders", "template", template)
+			w.WriteHeader(http.StatusBadRequest)
+			err = json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: []string{"variables"},
+			})
+			if err != nil {
+				h.Err("failed to encode validation errors", "error", err)
+			}
+			return
+		}
+
+		createOpeningReq := employer.CreateOpeningRequest{
+			Title:          title,
+			Positions:      template.Positions,
+			JD:             jd,
+			Recruiter:      common.EmailAddress(template.Recruiter.Email),
+			HiringManager:  common.EmailAddress(template.HiringManager.Email),
+			HiringTeam:     emailsOf(template.HiringTeam),
+			CostCenterName: template.CostCenterName,
+			LocationTitles: template.LocationTitles,
+
+			RemoteCountryCodes: template.RemoteCountryCodes,
+			RemoteTimezones:    template.RemoteTimezones,
+
+			OpeningType: template.OpeningType,
+			YoeMin:      template.YoeMin,
+			YoeMax:      template.YoeMax,
+
+			EmployerNotes:     employerNotes,
+			MinEducationLevel: template.MinEducationLevel,
+			Salary:            template.Salary,
+			TagIDs:            tagIDsOf(template.Tags),
+		}
+
+		// The resolved values could be out of the limits of an Opening
+		if !h.Vator().Struct(w, &createOpeningReq) {
+			h.Dbg("validation failed", "createOpeningReq", createOpeningReq)
+			return
+		}
+
+		createOpening(h, w, r, createOpeningReq)
+	}
+}
@@ -0,0 +1,48 @@
+package openings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func CreateOpeningTemplate(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered CreateOpeningTemplate")
+		var createTemplateReq employer.CreateOpeningTemplateRequest
+		err := json.NewDecoder(r.Body).Decode(&createTemplateReq)
+		if err != nil {
+			h.Dbg("failed to decode create template request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &createTemplateReq) {
+			h.Dbg("validation failed", "createTemplateReq", createTemplateReq)
+			return
+		}
+		h.Dbg("validated", "createTemplateReq", createTemplateReq)
+
+		if !validateOpening(h, w, createTemplateReq.OpeningRequest()) {
+			return
+		}
+
+		templateID, err := h.DB().
+			CreateOpeningTemplate(r.Context(), createTemplateReq)
+		if err != nil {
+			writeOpeningTemplateErr(h, w, err)
+			return
+		}
+
+		h.Dbg("created opening template", "templateID", templateID)
+		err = json.NewEncoder(w).Encode(employer.CreateOpeningTemplateResponse{
+			TemplateID: templateID,
+		})
+		if err != nil {
+			h.Err("failed to encode create template response", "error", err)
+			return
+		}
+	}
+}
@@ -28,125 +28,151 @@ func CreateOpening(h wand.Wand) http.HandlerFunc {
 		}
 		h.Dbg("validated", "createOpeningReq", createOpeningReq)
 
-		if createOpeningReq.YoeMax < createOpeningReq.YoeMin {
-			h.Dbg("yoe_max < yoe min", "createOpeningReq", createOpeningReq)
-			w.WriteHeader(http.StatusBadRequest)
-			err = json.NewEncoder(w).Encode(common.ValidationErrors{
-				Errors: []string{"yoe_min", "yoe_max"},
-			})
-			if err != nil {
-				h.Err("failed to encode validation errors", "error", err)
-			}
-			return
+		createOpening(h, w, r, createOpeningReq)
+	}
+}
+
+// validateOpening runs the checks on CreateOpeningRequest that cannot be
+// expressed via the validator tags. Writes the error response and returns
+// false if the validation fails.
+func validateOpening(
+	h wand.Wand,
+	w http.ResponseWriter,
+	createOpeningReq employer.CreateOpeningRequest,
+) bool {
+	var err error
+	if createOpeningReq.YoeMax < createOpeningReq.YoeMin {
+		h.Dbg("yoe_max < yoe min", "createOpeningReq", createOpeningReq)
+		w.WriteHeader(http.StatusBadRequest)
+		err = json.NewEncoder(w).Encode(common.ValidationErrors{
+			Errors: []string{"yoe_min", "yoe_max"},
+		})
+		if err != nil {
+			h.Err("failed to encode validation errors", "error", err)
 		}
+		return false
+	}
 
-		// Validate tags
-		totalTags := len(createOpeningReq.TagIDs)
-		if totalTags == 0 {
-			h.Dbg("no tags specified", "createOpeningReq", createOpeningReq)
+	// Validate tags
+	totalTags := len(createOpeningReq.TagIDs)
+	if totalTags == 0 {
+		h.Dbg("no tags specified", "createOpeningReq", createOpeningReq)
+		w.WriteHeader(http.StatusBadRequest)
+		err = json.NewEncoder(w).Encode(common.ValidationErrors{
+			Errors: []string{"tags"},
+		})
+		if err != nil {
+			h.Err("failed to encode validation errors", "error", err)
+		}
+		return false
+	}
+
+	if totalTags > 3 {
+		h.Dbg(
+			"too many tags specified",
+			"createOpeningReq",
+			createOpeningReq,
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		err = json.NewEncoder(w).Encode(common.ValidationErrors{
+			Errors: []string{"tags"},
+		})
+		if err != nil {
+			h.Err("failed to encode validation errors", "error", err)
+		}
+		return false
+	}
+
+	if createOpeningReq.Salary != nil {
+		if createOpeningReq.Salary.MinAmount > createOpeningReq.Salary.MaxAmount {
+			h.Dbg("salary min > max", "createOpeningReq", createOpeningReq)
 			w.WriteHeader(http.StatusBadRequest)
 			err = json.NewEncoder(w).Encode(common.ValidationErrors{
-				Errors: []string{"tags"},
+				Errors: []string{"salary"},
 			})
 			if err != nil {
 				h.Err("failed to encode validation errors", "error", err)
 			}
-			return
+			return false
 		}
 
-		if totalTags > 3 {
-			h.Dbg(
-				"too many tags specified",
-				"createOpeningReq",
-				createOpeningReq,
-			)
+		if createOpeningReq.Salary.Currency == "" {
+			h.Dbg("currency is empty", "createOpeningReq", createOpeningReq)
 			w.WriteHeader(http.StatusBadRequest)
 			err = json.NewEncoder(w).Encode(common.ValidationErrors{
-				Errors: []string{"tags"},
+				Errors: []string{"currency"},
 			})
 			if err != nil {
 				h.Err("failed to encode validation errors", "error", err)
 			}
-			return
+			return false
 		}
+	}
 
-		if createOpeningReq.Salary != nil {
-			if createOpeningReq.Salary.MinAmount > createOpeningReq.Salary.MaxAmount {
-				h.Dbg("salary min > max", "createOpeningReq", createOpeningReq)
-				w.WriteHeader(http.StatusBadRequest)
-				err = json.NewEncoder(w).Encode(common.ValidationErrors{
-					Errors: []string{"salary"},
-				})
-				if err != nil {
-					h.Err("failed to encode validation errors", "error", err)
-				}
-				return
-			}
-
-			if createOpeningReq.Salary.Currency == "" {
-				h.Dbg("currency is empty", "createOpeningReq", createOpeningReq)
-				w.WriteHeader(http.StatusBadRequest)
-				err = json.NewEncoder(w).Encode(common.ValidationErrors{
-					Errors: []string{"currency"},
-				})
-				if err != nil {
-					h.Err("failed to encode validation errors", "error", err)
-				}
-				return
-			}
+	if len(createOpeningReq.RemoteCountryCodes) == 0 &&
+		len(createOpeningReq.LocationTitles) == 0 {
+		h.Dbg(
+			"neither remote countries nor locations specified",
+			"createOpeningReq",
+			createOpeningReq,
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		err = json.NewEncoder(w).Encode(common.ValidationErrors{
+			Errors: []string{"remote_country_codes", "location_titles"},
+		})
+		if err != nil {
+			h.Err("failed to encode validation errors", "error", err)
 		}
+		return false
+	}
+
+	return true
+}
+
+// createOpening validates and creates the Opening and writes the response
+func createOpening(
+	h wand.Wand,
+	w http.ResponseWriter,
+	r *http.Request,
+	createOpeningReq employer.CreateOpeningRequest,
+) {
+	if !validateOpening(h, w, createOpeningReq) {
+		return
+	}
 
-		if len(createOpeningReq.RemoteCountryCodes) == 0 &&
-			len(createOpeningReq.LocationTitles) == 0 {
-			h.Dbg(
-				"neither remote countries nor locations specified",
-				"createOpeningReq",
-				createOpeningReq,
-			)
+	openingID, err := h.DB().CreateOpening(r.Context(), createOpeningReq)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidTagIDs) {
+			h.Dbg("invalid tag IDs provided", "error", err)
 			w.WriteHeader(http.StatusBadRequest)
 			err = json.NewEncoder(w).Encode(common.ValidationErrors{
-				Errors: []string{"remote_country_codes", "location_titles"},
+				Errors: []string{"tags"},
 			})
 			if err != nil {
 				h.Err("failed to encode validation errors", "error", err)
 			}
 			return
 		}
-
-		openingID, err := h.DB().CreateOpening(r.Context(), createOpeningReq)
-		if err != nil {
-			if errors.Is(err, db.ErrInvalidTagIDs) {
-				h.Dbg("invalid tag IDs provided", "error", err)
-				w.WriteHeader(http.StatusBadRequest)
-				err = json.NewEncoder(w).Encode(common.ValidationErrors{
-					Errors: []string{"tags"},
-				})
-				if err != nil {
-					h.Err("failed to encode validation errors", "error", err)
-				}
-				return
-			}
-			if errors.Is(err, db.ErrNoRecruiter) ||
-				errors.Is(err, db.ErrNoLocation) ||
-				errors.Is(err, db.ErrNoHiringManager) ||
-				errors.Is(err, db.ErrNoCostCenter) {
-				h.Dbg("location or team or recruiter not found", "error", err)
-				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-				return
-			}
-
-			h.Err("failed to create opening", "error", err)
-			http.Error(w, "", http.StatusInternalServerError)
+		if errors.Is(err, db.ErrNoRecruiter) ||
+			errors.Is(err, db.ErrNoLocation) ||
+			errors.Is(err, db.ErrNoHiringManager) ||
+			errors.Is(err, db.ErrNoCostCenter) {
+			h.Dbg("location or team or recruiter not found", "error", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 
-		h.Dbg("created opening", "openingID", openingID)
-		err = json.NewEncoder(w).Encode(employer.CreateOpeningResponse{
-			OpeningID: openingID,
-		})
-		if err != nil {
-			h.Err("failed to encode create opening response", "error", err)
-			return
-		}
+		h.Err("failed to create opening", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	h.Dbg("created opening", "openingID", openingID)
+	err = json.NewEncoder(w).Encode(employer.CreateOpeningResponse{
+		OpeningID: openingID,
+	})
+	if err != nil {
+		h.Err("failed to encode create opening response", "error", err)
+		return
 	}
 }
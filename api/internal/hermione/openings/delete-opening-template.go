@@ -0,0 +1,46 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func DeleteOpeningTemplate(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered DeleteOpeningTemplate")
+		var deleteTemplateReq employer.DeleteOpeningTemplateRequest
+		err := json.NewDecoder(r.Body).Decode(&deleteTemplateReq)
+		if err != nil {
+			h.Dbg("failed to decode delete template request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &deleteTemplateReq) {
+			h.Dbg("validation failed", "deleteTemplateReq", deleteTemplateReq)
+			return
+		}
+		h.Dbg("validated", "deleteTemplateReq", deleteTemplateReq)
+
+		err = h.DB().DeleteOpeningTemplate(r.Context(), deleteTemplateReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpeningTemplate) {
+				h.Dbg("opening template not found", "id", deleteTemplateReq.ID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to delete opening template", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("deleted opening template", "id", deleteTemplateReq.ID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
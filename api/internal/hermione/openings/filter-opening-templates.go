@@ -0,0 +1,53 @@
+package openings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func FilterOpeningTemplates(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered FilterOpeningTemplates")
+		var filterTemplatesReq employer.FilterOpeningTemplatesRequest
+		err := json.NewDecoder(r.Body).Decode(&filterTemplatesReq)
+		if err != nil {
+			h.Dbg("failed to decode filter templates request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &filterTemplatesReq) {
+			h.Dbg("validation failed", "filterTemplatesReq", filterTemplatesReq)
+			return
+		}
+		h.Dbg("validated", "filterTemplatesReq", filterTemplatesReq)
+
+		if filterTemplatesReq.Limit <= 0 {
+			filterTemplatesReq.Limit = 40
+			h.Dbg("set default limit", "limit", filterTemplatesReq.Limit)
+		}
+
+		templates, err := h.DB().
+			FilterOpeningTemplates(r.Context(), filterTemplatesReq)
+		if err != nil {
+			h.Dbg("failed to filter opening templates", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		for i := range templates {
+			templates[i].Placeholders = templatePlaceholders(templates[i])
+		}
+
+		h.Dbg("filtered opening templates", "templates", templates)
+		err = json.NewEncoder(w).Encode(templates)
+		if err != nil {
+			h.Err("failed to encode opening templates", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package openings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetMyOpeningApprovals(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetMyOpeningApprovals")
+		var myApprovalsReq employer.GetMyOpeningApprovalsRequest
+		err := json.NewDecoder(r.Body).Decode(&myApprovalsReq)
+		if err != nil {
+			h.Dbg("failed to decode my approvals request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &myApprovalsReq) {
+			h.Dbg("validation failed", "myApprovalsReq", myApprovalsReq)
+			return
+		}
+		h.Dbg("validated", "myApprovalsReq", myApprovalsReq)
+
+		if myApprovalsReq.Limit <= 0 {
+			myApprovalsReq.Limit = 40
+			h.Dbg("set default limit", "limit", myApprovalsReq.Limit)
+		}
+
+		approvals, err := h.DB().GetMyOpeningApprovals(r.Context(), myApprovalsReq)
+		if err != nil {
+			h.Err("failed to get my opening approvals", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(approvals)
+		if err != nil {
+			h.Err("failed to encode my opening approvals", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetOpeningApprovals(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetOpeningApprovals")
+		var getApprovalsReq employer.GetOpeningApprovalsRequest
+		err := json.NewDecoder(r.Body).Decode(&getApprovalsReq)
+		if err != nil {
+			h.Dbg("failed to decode get approvals request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getApprovalsReq) {
+			h.Dbg("validation failed", "getApprovalsReq", getApprovalsReq)
+			return
+		}
+		h.Dbg("validated", "getApprovalsReq", getApprovalsReq)
+
+		approvals, err := h.DB().GetOpeningApprovals(r.Context(), getApprovalsReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "id", getApprovalsReq.OpeningID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to get opening approvals", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(approvals.Steps)
+		if err != nil {
+			h.Err("failed to encode opening approvals", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
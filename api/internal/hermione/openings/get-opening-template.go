@@ -0,0 +1,52 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetOpeningTemplate(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetOpeningTemplate")
+		var getTemplateReq employer.GetOpeningTemplateRequest
+		err := json.NewDecoder(r.Body).Decode(&getTemplateReq)
+		if err != nil {
+			h.Dbg("failed to decode get template request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getTemplateReq) {
+			h.Dbg("validation failed", "getTemplateReq", getTemplateReq)
+			return
+		}
+		h.Dbg("validated", "getTemplateReq", getTemplateReq)
+
+		template, err := h.DB().GetOpeningTemplate(r.Context(), getTemplateReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpeningTemplate) {
+				h.Dbg("opening template not found", "id", getTemplateReq.ID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get opening template", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		template.Placeholders = templatePlaceholders(template)
+
+		h.Dbg("got opening template", "template", template)
+		err = json.NewEncoder(w).Encode(template)
+		if err != nil {
+			h.Err("failed to encode opening template", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
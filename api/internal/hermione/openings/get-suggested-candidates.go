@@ -0,0 +1,56 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func GetSuggestedCandidates(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered GetSuggestedCandidates")
+		var getSuggestedCandidatesReq employer.GetSuggestedCandidatesRequest
+		err := json.NewDecoder(r.Body).Decode(&getSuggestedCandidatesReq)
+		if err != nil {
+			h.Dbg("failed to decode get suggested candidates", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &getSuggestedCandidatesReq) {
+			h.Dbg("validation failed", "req", getSuggestedCandidatesReq)
+			return
+		}
+		h.Dbg("validated", "req", getSuggestedCandidatesReq)
+
+		candidates, err := h.DB().
+			GetSuggestedCandidates(r.Context(), getSuggestedCandidatesReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg(
+					"opening not found",
+					"id",
+					getSuggestedCandidatesReq.OpeningID,
+				)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get suggested candidates", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("got suggested candidates", "count", len(candidates))
+		err = json.NewEncoder(w).Encode(candidates)
+		if err != nil {
+			h.Err("failed to encode suggested candidates", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
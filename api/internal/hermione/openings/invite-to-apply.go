@@ -0,0 +1,123 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func InviteToApply(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered InviteToApply")
+		var inviteToApplyReq employer.InviteToApplyRequest
+		err := json.NewDecoder(r.Body).Decode(&inviteToApplyReq)
+		if err != nil {
+			h.Dbg("failed to decode invite to apply request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &inviteToApplyReq) {
+			h.Dbg("validation failed", "inviteToApplyReq", inviteToApplyReq)
+			return
+		}
+		h.Dbg("validated", "inviteToApplyReq", inviteToApplyReq)
+
+		orgUser, ok := r.Context().Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+		if !ok {
+			h.Err("failed to get orgUser from context")
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		mailInfo, err := h.DB().
+			GetOpeningInvitationMailInfo(r.Context(), inviteToApplyReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) ||
+				errors.Is(err, db.ErrNoHubUser) {
+				h.Dbg("not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to get opening invitation mail info", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		invitationID := util.RandomUniqueID(vetchi.OpeningInvitationIDLenBytes)
+
+		message := ""
+		if inviteToApplyReq.Message != nil {
+			message = *inviteToApplyReq.Message
+		}
+
+		email, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.InviteToApply,
+			Args: map[string]string{
+				"hub_user_full_name":      mailInfo.HubUser.FullName,
+				"inviter_name":            orgUser.Name,
+				"employer_company_name":   mailInfo.Employer.CompanyName,
+				"employer_primary_domain": mailInfo.Employer.PrimaryDomain,
+				"job_title":               mailInfo.Opening.Title,
+				"message":                 message,
+				"opening_link": h.Config().Hub.WebURL + "/org/" +
+					mailInfo.Employer.PrimaryDomain + "/opening/" +
+					mailInfo.Opening.OpeningID,
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{mailInfo.HubUser.Email},
+			Subject: fmt.Sprintf(
+				"Invitation to apply at %s",
+				mailInfo.Employer.CompanyName,
+			),
+		})
+		if err != nil {
+			h.Dbg("failed to generate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().InviteToApply(r.Context(), db.InviteToApplyReq{
+			InvitationID: invitationID,
+			OpeningID:    mailInfo.Opening.OpeningID,
+			HubUserID:    mailInfo.HubUser.HubUserID,
+			Message:      inviteToApplyReq.Message,
+			Email:        email,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, db.ErrNoOpening),
+				errors.Is(err, db.ErrNoHubUser):
+				h.Dbg("not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+			case errors.Is(err, db.ErrAlreadyInvited):
+				h.Dbg("already invited or applied", "error", err)
+				http.Error(w, "", http.StatusConflict)
+			case errors.Is(err, db.ErrOpeningNotActive):
+				h.Dbg("opening not active", "error", err)
+				http.Error(w, "", http.StatusUnprocessableEntity)
+			case errors.Is(err, db.ErrInvitationCapReached),
+				errors.Is(err, db.ErrInvitationQuotaExceeded):
+				h.Dbg("invitations capped", "error", err)
+				http.Error(w, "", http.StatusTooManyRequests)
+			default:
+				h.Dbg("failed to invite to apply", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		h.Dbg("invited to apply", "invitation_id", invitationID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
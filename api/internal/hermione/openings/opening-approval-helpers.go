@@ -0,0 +1,19 @@
+package openings
+
+import "github.com/vetchium/vetchium/typespec/employer"
+
+// currentApprovalStep returns the index of the step that is waiting for a
+// decision. Returns false if the chain is complete or has been rejected.
+func currentApprovalStep(steps []employer.OpeningApprovalStep) (int, bool) {
+	for i, step := range steps {
+		switch step.State {
+		case employer.OpeningApproved:
+			continue
+		case employer.OpeningPendingApproval:
+			return i, true
+		default:
+			return 0, false
+		}
+	}
+	return 0, false
+}
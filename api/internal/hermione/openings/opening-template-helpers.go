@@ -0,0 +1,105 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var placeholderRegex = regexp.MustCompile(
+	`\{\{\s*([A-Za-z][A-Za-z0-9_]*)\s*\}\}`,
+)
+
+// placeholders returns the sorted, unique names of the {{variables}} used in
+// the given texts
+func placeholders(texts ...string) []string {
+	var names []string
+	for _, text := range texts {
+		for _, match := range placeholderRegex.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(names, match[1]) {
+				names = append(names, match[1])
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func templatePlaceholders(template employer.OpeningTemplate) []string {
+	texts := []string{template.Title, template.JD}
+	if template.EmployerNotes != nil {
+		texts = append(texts, *template.EmployerNotes)
+	}
+	return placeholders(texts...)
+}
+
+// resolvePlaceholders replaces the {{variables}} in the text with the values
+// from vars. Returns false if any of the variables has no value.
+func resolvePlaceholders(text string, vars map[string]string) (string, bool) {
+	resolved := true
+	text = placeholderRegex.ReplaceAllStringFunc(text, func(m string) string {
+		name := placeholderRegex.FindStringSubmatch(m)[1]
+		value, ok := vars[name]
+		if !ok {
+			resolved = false
+			return m
+		}
+		return value
+	})
+	return text, resolved
+}
+
+func emailsOf(orgUsers []employer.OrgUserShort) []common.EmailAddress {
+	var emails []common.EmailAddress
+	for _, orgUser := range orgUsers {
+		emails = append(emails, common.EmailAddress(orgUser.Email))
+	}
+	return emails
+}
+
+func tagIDsOf(tags []common.VTag) []common.VTagID {
+	var tagIDs []common.VTagID
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	return tagIDs
+}
+
+// writeOpeningTemplateErr writes the response for the errors that can happen
+// when a template is created or updated
+func writeOpeningTemplateErr(h wand.Wand, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, db.ErrNoOpeningTemplate):
+		h.Dbg("opening template not found", "error", err)
+		http.Error(w, "", http.StatusNotFound)
+	case errors.Is(err, db.ErrDupOpeningTemplateName):
+		h.Dbg("opening template name already exists", "error", err)
+		http.Error(w, "", http.StatusConflict)
+	case errors.Is(err, db.ErrInvalidTagIDs):
+		h.Dbg("invalid tag IDs provided", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+		err = json.NewEncoder(w).Encode(common.ValidationErrors{
+			Errors: []string{"tags"},
+		})
+		if err != nil {
+			h.Err("failed to encode validation errors", "error", err)
+		}
+	case errors.Is(err, db.ErrNoRecruiter),
+		errors.Is(err, db.ErrNoLocation),
+		errors.Is(err, db.ErrNoHiringManager),
+		errors.Is(err, db.ErrInvalidHiringTeam),
+		errors.Is(err, db.ErrNoCostCenter):
+		h.Dbg("location or team or recruiter not found", "error", err)
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		h.Err("failed to save opening template", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,115 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func RejectOpening(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered RejectOpening")
+		var rejectReq employer.RejectOpeningRequest
+		err := json.NewDecoder(r.Body).Decode(&rejectReq)
+		if err != nil {
+			h.Dbg("failed to decode reject opening request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &rejectReq) {
+			h.Dbg("validation failed", "rejectReq", rejectReq)
+			return
+		}
+		h.Dbg("validated", "rejectReq", rejectReq)
+
+		ctx := r.Context()
+		orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+		if !ok {
+			h.Err("failed to get orgUser from context")
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		opening, err := h.DB().GetOpening(
+			ctx,
+			employer.GetOpeningRequest{ID: rejectReq.OpeningID},
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "id", rejectReq.OpeningID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to get opening", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		approvals, err := h.DB().GetOpeningApprovals(
+			ctx,
+			employer.GetOpeningApprovalsRequest{OpeningID: rejectReq.OpeningID},
+		)
+		if err != nil {
+			h.Err("failed to get opening approvals", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		current, ok := currentApprovalStep(approvals.Steps)
+		if !ok || approvals.Steps[current].Approver.Email != orgUser.Email {
+			h.Dbg("not waiting for the approval of the orgUser", "id", opening.ID)
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		rejectionEmail, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.OpeningApprovalRejected,
+			Args: map[string]string{
+				"RequesterName": approvals.RequestedBy.Name,
+				"RejectedBy":    orgUser.Name,
+				"OpeningTitle":  opening.Title,
+				"OpeningID":     opening.ID,
+				"Comment":       rejectReq.Comment,
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{approvals.RequestedBy.Email},
+
+			// TODO: This should be dynamic and come from hedwig
+			Subject: "Opening rejected",
+		})
+		if err != nil {
+			h.Err("failed to generate rejection email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().RejectOpening(ctx, db.RejectOpeningReq{
+			OpeningID:      rejectReq.OpeningID,
+			StepNumber:     approvals.Steps[current].StepNumber,
+			Comment:        rejectReq.Comment,
+			RejectionEmail: rejectionEmail,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpeningApproval) {
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to reject opening", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("rejected opening", "id", rejectReq.OpeningID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
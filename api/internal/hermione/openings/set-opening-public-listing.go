@@ -0,0 +1,51 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func SetOpeningPublicListing(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SetOpeningPublicListing")
+		var setPublicListingReq employer.SetOpeningPublicListingRequest
+		err := json.NewDecoder(r.Body).Decode(&setPublicListingReq)
+		if err != nil {
+			h.Dbg("failed to decode set public listing request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &setPublicListingReq) {
+			h.Dbg("invalid", "setPublicListingReq", setPublicListingReq)
+			return
+		}
+
+		err = h.DB().SetOpeningPublicListing(r.Context(), setPublicListingReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Dbg("failed to set opening public listing", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg(
+			"set public listing",
+			"openingID",
+			setPublicListingReq.OpeningID,
+			"publicListing",
+			setPublicListingReq.PublicListing,
+		)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,76 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func SetOpeningSchedule(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SetOpeningSchedule")
+		var setOpeningScheduleReq employer.SetOpeningScheduleRequest
+		err := json.NewDecoder(r.Body).Decode(&setOpeningScheduleReq)
+		if err != nil {
+			h.Dbg("failed to decode set opening schedule request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &setOpeningScheduleReq) {
+			h.Dbg("invalid", "setOpeningScheduleReq", setOpeningScheduleReq)
+			return
+		}
+
+		var invalidFields []string
+		closeAt := setOpeningScheduleReq.CloseAt
+		if closeAt != nil && !closeAt.After(time.Now()) {
+			invalidFields = append(invalidFields, "close_at")
+		}
+
+		publishAt := setOpeningScheduleReq.PublishAt
+		if closeAt != nil && publishAt != nil && !closeAt.After(*publishAt) {
+			invalidFields = append(invalidFields, "publish_at")
+		}
+
+		if len(invalidFields) > 0 {
+			h.Dbg("invalid schedule", "fields", invalidFields)
+			w.WriteHeader(http.StatusBadRequest)
+			err = json.NewEncoder(w).Encode(common.ValidationErrors{
+				Errors: invalidFields,
+			})
+			if err != nil {
+				h.Err("failed to encode validation errors", "error", err)
+			}
+			return
+		}
+
+		err = h.DB().SetOpeningSchedule(r.Context(), setOpeningScheduleReq)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "error", err)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrStateMismatch) {
+				h.Dbg("opening is closed", "error", err)
+				http.Error(w, "", http.StatusConflict)
+				return
+			}
+
+			h.Dbg("failed to set opening schedule", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("set schedule", "openingID", setOpeningScheduleReq.OpeningID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
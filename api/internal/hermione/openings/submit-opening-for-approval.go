@@ -0,0 +1,133 @@
+package openings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func SubmitOpeningForApproval(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered SubmitOpeningForApproval")
+		var submitReq employer.SubmitOpeningForApprovalRequest
+		err := json.NewDecoder(r.Body).Decode(&submitReq)
+		if err != nil {
+			h.Dbg("failed to decode submit for approval request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &submitReq) {
+			h.Dbg("validation failed", "submitReq", submitReq)
+			return
+		}
+		h.Dbg("validated", "submitReq", submitReq)
+
+		ctx := r.Context()
+		orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+		if !ok {
+			h.Err("failed to get orgUser from context")
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		opening, err := h.DB().GetOpening(
+			ctx,
+			employer.GetOpeningRequest{ID: submitReq.OpeningID},
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				h.Dbg("opening not found", "id", submitReq.OpeningID)
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			h.Err("failed to get opening", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if opening.State != common.DraftOpening {
+			h.Dbg("opening not in draft state", "state", opening.State)
+			http.Error(w, "", http.StatusConflict)
+			return
+		}
+
+		chain, err := h.DB().GetCostCenterApprovalChain(
+			ctx,
+			employer.GetCostCenterApprovalChainRequest{
+				CostCenterName: opening.CostCenterName,
+			},
+		)
+		if err != nil {
+			h.Err("failed to get approval chain", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if len(chain.Approvers) == 0 {
+			h.Dbg("no approval chain", "costCenter", opening.CostCenterName)
+			http.Error(w, "", http.StatusUnprocessableEntity)
+			return
+		}
+
+		approver := chain.Approvers[0]
+		approvalEmail, err := h.Hedwig().GenerateEmail(hedwig.GenerateEmailReq{
+			TemplateName: hedwig.OpeningApprovalRequest,
+			Args: map[string]string{
+				"ApproverName":   approver.Name,
+				"RequestedBy":    orgUser.Name,
+				"OpeningTitle":   opening.Title,
+				"OpeningID":      opening.ID,
+				"CostCenterName": string(opening.CostCenterName),
+			},
+			EmailFrom: vetchi.EmailFrom,
+			EmailTo:   []string{approver.Email},
+
+			// TODO: This should be dynamic and come from hedwig
+			Subject: "Approval requested for an Opening",
+		})
+		if err != nil {
+			h.Err("failed to generate approval email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = h.DB().SubmitOpeningForApproval(ctx, db.SubmitOpeningForApprovalReq{
+			OpeningID:     submitReq.OpeningID,
+			ApprovalEmail: approvalEmail,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNoOpening) {
+				http.Error(w, "", http.StatusNotFound)
+				return
+			}
+
+			if errors.Is(err, db.ErrStateMismatch) {
+				h.Dbg("already pending or approved", "id", submitReq.OpeningID)
+				http.Error(w, "", http.StatusConflict)
+				return
+			}
+
+			if errors.Is(err, db.ErrNoApprovalChain) {
+				http.Error(w, "", http.StatusUnprocessableEntity)
+				return
+			}
+
+			h.Err("failed to submit opening for approval", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		h.Dbg("submitted opening for approval", "id", submitReq.OpeningID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
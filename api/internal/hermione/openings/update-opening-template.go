@@ -0,0 +1,41 @@
+package openings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vetchium/vetchium/api/internal/wand"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func UpdateOpeningTemplate(h wand.Wand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.Dbg("Entered UpdateOpeningTemplate")
+		var updateTemplateReq employer.UpdateOpeningTemplateRequest
+		err := json.NewDecoder(r.Body).Decode(&updateTemplateReq)
+		if err != nil {
+			h.Dbg("failed to decode update template request", "error", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		if !h.Vator().Struct(w, &updateTemplateReq) {
+			h.Dbg("validation failed", "updateTemplateReq", updateTemplateReq)
+			return
+		}
+		h.Dbg("validated", "updateTemplateReq", updateTemplateReq)
+
+		if !validateOpening(h, w, updateTemplateReq.OpeningRequest()) {
+			return
+		}
+
+		err = h.DB().UpdateOpeningTemplate(r.Context(), updateTemplateReq)
+		if err != nil {
+			writeOpeningTemplateErr(h, w, err)
+			return
+		}
+
+		h.Dbg("updated opening template", "id", updateTemplateReq.ID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -1,6 +1,7 @@
 package hermione
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -8,6 +9,11 @@ import (
 func (h *Hermione) Run() error {
 	RegisterEmployerRoutes(h)
 	RegisterHubRoutes(h)
+	RegisterCareersRoutes(h)
+	RegisterESignRoutes(h)
+	RegisterCalendarRoutes(h)
+
+	go h.brk.Run(context.Background())
 
 	port := fmt.Sprintf(":%d", h.Config().Port)
 	return http.ListenAndServe(port, nil)
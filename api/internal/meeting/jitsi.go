@@ -0,0 +1,60 @@
+package meeting
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+// jitsi generates the room URLs on a self-hosted Jitsi Meet server. A Jitsi
+// room comes into existence when the first participant joins and goes away
+// when the last one leaves, so there is nothing to create or delete on the
+// server. The room name is derived from the interview ID, so the URL stays
+// the same across reschedules.
+type jitsi struct {
+	baseURL string
+	log     util.Logger
+}
+
+func newJitsi(baseURL string, log util.Logger) (*jitsi, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jitsi base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported jitsi URL scheme %q", u.Scheme)
+	}
+
+	return &jitsi{
+		baseURL: strings.TrimSuffix(u.String(), "/"),
+		log:     log,
+	}, nil
+}
+
+func (j *jitsi) Name() employer.MeetingProvider {
+	return employer.JitsiMeetingProvider
+}
+
+func (j *jitsi) CreateRoom(ctx context.Context, room Room) (string, error) {
+	// The interview IDs are random and hard to guess, which is all the
+	// protection a Jitsi room without a password has
+	meetingURL := j.baseURL + "/Vetchium-" + url.PathEscape(room.InterviewID)
+	j.log.Dbg("jitsi room", "interview_id", room.InterviewID, "url", meetingURL)
+	return meetingURL, nil
+}
+
+func (j *jitsi) UpdateRoom(
+	ctx context.Context,
+	meetingURL string,
+	room Room,
+) (string, error) {
+	return j.CreateRoom(ctx, room)
+}
+
+func (j *jitsi) DeleteRoom(ctx context.Context, meetingURL string) error {
+	return nil
+}
@@ -0,0 +1,35 @@
+package meeting
+
+import (
+	"context"
+
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+// manual keeps the link to the meeting that the org user created elsewhere
+// and pasted while scheduling the interview. The meeting itself is managed
+// by the org user and is left as it is when the interview is cancelled.
+type manual struct{}
+
+func (m *manual) Name() employer.MeetingProvider {
+	return employer.ManualMeetingProvider
+}
+
+func (m *manual) CreateRoom(ctx context.Context, room Room) (string, error) {
+	return room.ManualURL, nil
+}
+
+func (m *manual) UpdateRoom(
+	ctx context.Context,
+	meetingURL string,
+	room Room,
+) (string, error) {
+	if room.ManualURL != "" {
+		return room.ManualURL, nil
+	}
+	return meetingURL, nil
+}
+
+func (m *manual) DeleteRoom(ctx context.Context, meetingURL string) error {
+	return nil
+}
@@ -0,0 +1,73 @@
+// Package meeting creates the rooms of the VIDEO_CALL interviews with the
+// meeting provider chosen by the employer
+package meeting
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var ErrUnavailableProvider = errors.New("meeting provider not available")
+
+type Room struct {
+	InterviewID string
+	StartTime   time.Time
+	EndTime     time.Time
+
+	// The link pasted by the org user. Used only by the manual provider.
+	ManualURL string
+}
+
+// MeetingProvider is implemented by each of the video meeting services that
+// an interview can be held on
+type MeetingProvider interface {
+	Name() employer.MeetingProvider
+
+	// CreateRoom returns the URL of the room for the interview, which is
+	// empty if the interview has no room
+	CreateRoom(ctx context.Context, room Room) (string, error)
+
+	// UpdateRoom is called when the interview is rescheduled and returns the
+	// URL of the room, which may change
+	UpdateRoom(
+		ctx context.Context,
+		meetingURL string,
+		room Room,
+	) (string, error)
+
+	// DeleteRoom is called when the interview is cancelled
+	DeleteRoom(ctx context.Context, meetingURL string) error
+}
+
+// Providers are the meeting providers available on this deployment
+type Providers map[employer.MeetingProvider]MeetingProvider
+
+// NewProviders always has the manual provider. The Jitsi provider is
+// available only if the base URL of a Jitsi server is configured.
+func NewProviders(jitsiBaseURL string, log util.Logger) (Providers, error) {
+	providers := Providers{
+		employer.ManualMeetingProvider: &manual{},
+	}
+
+	if jitsiBaseURL != "" {
+		jitsiProvider, err := newJitsi(jitsiBaseURL, log)
+		if err != nil {
+			return nil, err
+		}
+		providers[employer.JitsiMeetingProvider] = jitsiProvider
+	}
+
+	return providers, nil
+}
+
+func (p Providers) Get(name employer.MeetingProvider) (MeetingProvider, error) {
+	provider, ok := p[name]
+	if !ok {
+		return nil, ErrUnavailableProvider
+	}
+	return provider, nil
+}
@@ -159,7 +159,9 @@ interview_insert AS (
 		description,
 		created_by,
 		employer_id,
-		candidate_rsvp
+		candidate_rsvp,
+		meeting_provider,
+		meeting_url
 	)
 	SELECT
 		$14,
@@ -171,7 +173,9 @@ interview_insert AS (
 		$7,                            -- description
 		$8,                            -- created_by
 		$2,                            -- employer_id
-		$13::rsvp_status               -- candidate_rsvp default
+		$13::rsvp_status,              -- candidate_rsvp default
+		NULLIF($15, '')::meeting_providers, -- meeting_provider
+		NULLIF($16, '')                -- meeting_url
 	WHERE (SELECT status FROM candidacy_check) = $12
 	RETURNING id
 )
@@ -199,6 +203,8 @@ SELECT
 		statusOK,                          // $12
 		common.NotSetRSVP,                 // $13
 		req.InterviewID,                   // $14
+		req.MeetingProvider,               // $15
+		req.RoomURL,                       // $16
 	).Scan(&result)
 	if err != nil {
 		p.log.Err("failed to add interview", "error", err)
@@ -269,11 +275,6 @@ AND ou.employer_id = $4`
 		}
 	}
 
-	// Insert email notifications
-	emailQuery := `
-INSERT INTO emails (email_from, email_to, email_subject, email_html_body, email_text_body, email_state)
-    VALUES ($1, $2, $3, $4, $5, $6)`
-
 	// Update applicant notification with actual interviewer names
 	if len(interviewerNames) > 0 {
 		req.ApplicantNotificationEmail.EmailHTMLBody = strings.Replace(
@@ -291,16 +292,8 @@ INSERT INTO emails (email_from, email_to, email_subject, email_html_body, email_
 	}
 
 	// Insert applicant notification
-	_, err = tx.Exec(
-		ctx,
-		emailQuery,
-		req.ApplicantNotificationEmail.EmailFrom,
-		[]string{applicantEmail},
-		req.ApplicantNotificationEmail.EmailSubject,
-		req.ApplicantNotificationEmail.EmailHTMLBody,
-		req.ApplicantNotificationEmail.EmailTextBody,
-		req.ApplicantNotificationEmail.EmailState,
-	)
+	req.ApplicantNotificationEmail.EmailTo = []string{applicantEmail}
+	err = p.insertEmail(ctx, tx, req.ApplicantNotificationEmail)
 	if err != nil {
 		p.log.Err("failed to insert applicant email", "error", err)
 		return db.ErrInternal
@@ -308,25 +301,13 @@ INSERT INTO emails (email_from, email_to, email_subject, email_html_body, email_
 
 	// Insert interviewer and watcher notifications if there are interviewers
 	if len(req.InterviewerEmails) > 0 {
-		_, err = tx.Exec(
-			ctx,
-			emailQuery+", ($7, $8, $9, $10, $11, $12)",
-			req.InterviewerNotificationEmail.EmailFrom,
-			req.InterviewerNotificationEmail.EmailTo,
-			req.InterviewerNotificationEmail.EmailSubject,
-			req.InterviewerNotificationEmail.EmailHTMLBody,
-			req.InterviewerNotificationEmail.EmailTextBody,
-			req.InterviewerNotificationEmail.EmailState,
-			// -- End of interviewer notification email
-			req.WatcherNotificationEmail.EmailFrom,
-			req.WatcherNotificationEmail.EmailTo,
-			req.WatcherNotificationEmail.EmailSubject,
-			req.WatcherNotificationEmail.EmailHTMLBody,
-			req.WatcherNotificationEmail.EmailTextBody,
-			req.WatcherNotificationEmail.EmailState,
-		)
+		err = p.insertEmail(ctx, tx, req.InterviewerNotificationEmail)
+		if err != nil {
+			return db.ErrInternal
+		}
+
+		err = p.insertEmail(ctx, tx, req.WatcherNotificationEmail)
 		if err != nil {
-			p.log.Err("failed to insert email", "error", err)
 			return db.ErrInternal
 		}
 	}
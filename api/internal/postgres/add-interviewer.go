@@ -144,31 +144,14 @@ SELECT validation_result FROM state_validation`
 		return db.ErrInternal
 	}
 
-	// Insert email
-	emailQuery := `
-INSERT INTO emails (email_from, email_to, email_subject, email_html_body, email_text_body, email_state)
-    VALUES ($1, $2, $3, $4, $5, $6),
-    ($7, $8, $9, $10, $11, $12)
-`
-	_, err = tx.Exec(
-		ctx,
-		emailQuery,
-		addInterviewerReq.InterviewerNotificationEmail.EmailFrom,
-		addInterviewerReq.InterviewerNotificationEmail.EmailTo,
-		addInterviewerReq.InterviewerNotificationEmail.EmailSubject,
-		addInterviewerReq.InterviewerNotificationEmail.EmailHTMLBody,
-		addInterviewerReq.InterviewerNotificationEmail.EmailTextBody,
-		addInterviewerReq.InterviewerNotificationEmail.EmailState,
-		// -- End of interviewer notification email
-		addInterviewerReq.WatcherNotificationEmail.EmailFrom,
-		addInterviewerReq.WatcherNotificationEmail.EmailTo,
-		addInterviewerReq.WatcherNotificationEmail.EmailSubject,
-		addInterviewerReq.WatcherNotificationEmail.EmailHTMLBody,
-		addInterviewerReq.WatcherNotificationEmail.EmailTextBody,
-		addInterviewerReq.WatcherNotificationEmail.EmailState,
-	)
+	// Insert emails
+	err = p.insertEmail(ctx, tx, addInterviewerReq.InterviewerNotificationEmail)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = p.insertEmail(ctx, tx, addInterviewerReq.WatcherNotificationEmail)
 	if err != nil {
-		p.log.Err("failed to insert email", "error", err)
 		return db.ErrInternal
 	}
 
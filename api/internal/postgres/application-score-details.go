@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func (p *PG) GetApplicationScoreDetails(
+	ctx context.Context,
+	req employer.GetApplicationScoreDetailsRequest,
+) ([]employer.ApplicationScoreDetails, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	// The application is LEFT JOINed, so that an application without any
+	// scores yet is told apart from an application that is not found
+	query := `
+SELECT s.model_name, s.score, s.explanation, s.created_at
+FROM applications a
+LEFT JOIN application_scores s ON s.application_id = a.id
+WHERE a.id = $1 AND a.employer_id = $2
+ORDER BY s.model_name
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		req.ApplicationID,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to query application scores", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	type scoreRow struct {
+		ModelName   *string
+		Score       *int
+		Explanation []byte
+		ScoredAt    *time.Time
+	}
+	scoreRows, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (scoreRow, error) {
+			var s scoreRow
+			err := row.Scan(&s.ModelName, &s.Score, &s.Explanation, &s.ScoredAt)
+			return s, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect application scores", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if len(scoreRows) == 0 {
+		p.log.Dbg("application not found", "id", req.ApplicationID)
+		return nil, db.ErrNoApplication
+	}
+
+	scores := []employer.ApplicationScoreDetails{}
+	for _, s := range scoreRows {
+		if s.ModelName == nil {
+			// The application is not scored by any model yet
+			continue
+		}
+
+		score := employer.ApplicationScoreDetails{
+			ModelName: *s.ModelName,
+			Score:     *s.Score,
+			ScoredAt:  *s.ScoredAt,
+		}
+		if s.Explanation != nil {
+			err = json.Unmarshal(s.Explanation, &score.Explanation)
+			if err != nil {
+				p.log.Err("failed to unmarshal explanation", "error", err)
+				return nil, db.ErrInternal
+			}
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
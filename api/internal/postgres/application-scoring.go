@@ -2,103 +2,288 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
 	"github.com/vetchium/vetchium/typespec/common"
 )
 
-// GetUnscoredApplication returns a random opening with unscored applications
-func (p *PG) GetUnscoredApplication(
-	ctx context.Context,
-	limit int,
-) (*db.UnscoredApplicationBatch, error) {
-	query := `
-WITH candidate_openings AS (
-	SELECT DISTINCT o.employer_id, o.id, o.jd
-	FROM openings o
-	JOIN applications a ON o.employer_id = a.employer_id AND o.id = a.opening_id
-	WHERE a.application_state = $1
-	AND (o.state = $2 OR o.state = $3)
-	-- Find applications that don't have scores from all active models
+// dueModelCondition holds for the models m that are due to score the
+// application a: the active models of the scorers that have not scored the
+// application, or whose scores are stale, and that are not leased, not
+// backing off after a failure and not dead lettered on the application. $1
+// is the models of the scorers.
+const dueModelCondition = `
+m.is_active = true
+AND m.model_name = ANY($1)
+AND NOT EXISTS (
+	SELECT 1
+	FROM application_scores s
+	WHERE s.application_id = a.id
+	AND s.model_name = m.model_name
+	AND NOT s.stale
+)
+AND NOT EXISTS (
+	SELECT 1
+	FROM application_scoring_state ss
+	WHERE ss.application_id = a.id
+	AND ss.model_name = m.model_name
 	AND (
-		SELECT COUNT(DISTINCT m.model_name)
-		FROM application_scoring_models m
-		WHERE m.is_active = true
-	) > (
-		SELECT COUNT(DISTINCT s.model_name)
-		FROM application_scores s
-		JOIN application_scoring_models m ON s.model_name = m.model_name
-		WHERE s.application_id = a.id AND m.is_active = true
+		ss.dead_lettered_at IS NOT NULL
+		OR ss.next_attempt_at > timezone('UTC', now())
+		OR ss.leased_until > timezone('UTC', now())
 	)
-	LIMIT 1
-)
-SELECT co.employer_id, co.id, co.jd, 
-	array_agg(a.id) AS app_ids,
-	array_agg(a.resume_sha) AS resume_shas
-FROM candidate_openings co
-JOIN applications a ON co.employer_id = a.employer_id AND co.id = a.opening_id
-WHERE a.application_state = $1
--- Find applications that don't have scores from all active models
-AND (
-	SELECT COUNT(DISTINCT m.model_name)
+)`
+
+// needsScoringCondition holds for the applications that any model is due
+// to score
+const needsScoringCondition = `
+EXISTS (
+	SELECT 1
 	FROM application_scoring_models m
-	WHERE m.is_active = true
-) > (
-	SELECT COUNT(DISTINCT s.model_name)
-	FROM application_scores s
-	JOIN application_scoring_models m ON s.model_name = m.model_name
-	WHERE s.application_id = a.id AND m.is_active = true
-)
-GROUP BY co.employer_id, co.id, co.jd
-LIMIT 1
-`
+	WHERE ` + dueModelCondition + `
+)`
 
-	var employerID, openingID, jd string
-	var appIDs, resumeSHAs []string
+// retryingCondition holds for the applications that some model failed to
+// score before
+const retryingCondition = `
+EXISTS (
+	SELECT 1
+	FROM application_scoring_state ss
+	WHERE ss.application_id = a.id
+	AND ss.model_name = ANY($1)
+	AND ss.attempts > 0
+	AND ss.dead_lettered_at IS NULL
+)`
 
-	err := p.pool.QueryRow(
-		ctx,
-		query,
+// ClaimUnscoredApplications leases the models that are due to score the
+// applications of an opening, out of the given models. The applications
+// that failed to be scored before are claimed one at a time, so that a
+// poisoned resume does not fail the others in its batch. Returns nil if
+// nothing is due.
+func (p *PG) ClaimUnscoredApplications(
+	ctx context.Context,
+	models []string,
+	limit int,
+) (*db.UnscoredApplicationBatch, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	// The leases that expired on the last attempt were of the replicas that
+	// crashed or hung on the applications
+	_, err = tx.Exec(ctx, `
+UPDATE application_scoring_state
+SET dead_lettered_at = timezone('UTC', now()),
+	leased_until = NULL,
+	last_error = COALESCE(last_error, 'lease expired'),
+	updated_at = timezone('UTC', now())
+WHERE dead_lettered_at IS NULL
+AND attempts >= $1
+AND leased_until <= timezone('UTC', now())
+`, vetchi.MaxApplicationScoringAttempts)
+	if err != nil {
+		p.log.Err("failed to dead letter expired leases", "error", err)
+		return nil, err
+	}
+
+	var employerID, openingID string
+	err = tx.QueryRow(ctx, `
+SELECT a.employer_id, a.opening_id
+FROM applications a
+JOIN openings o ON o.employer_id = a.employer_id AND o.id = a.opening_id
+WHERE a.application_state = $2
+AND (o.state = $3 OR o.state = $4)
+AND `+needsScoringCondition+`
+LIMIT 1
+FOR UPDATE OF a SKIP LOCKED
+`,
+		models,
 		common.AppliedAppState,
 		common.ActiveOpening,
 		common.SuspendedOpening,
-	).Scan(&employerID, &openingID, &jd, &appIDs, &resumeSHAs)
-
+	).Scan(&employerID, &openingID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
-		p.log.Err("Failed to query unscored application batch", "error", err)
+		p.log.Err("failed to query unscored applications", "error", err)
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+SELECT
+	a.id,
+	a.resume_sha,
+	ARRAY(
+		SELECT m.model_name
+		FROM application_scoring_models m
+		WHERE `+dueModelCondition+`
+		ORDER BY m.model_name
+	),
+	`+retryingCondition+`
+FROM applications a
+WHERE a.employer_id = $2
+AND a.opening_id = $3
+AND a.application_state = $4
+AND `+needsScoringCondition+`
+ORDER BY `+retryingCondition+`, a.created_at
+LIMIT $5
+FOR UPDATE OF a SKIP LOCKED
+`,
+		models,
+		employerID,
+		openingID,
+		common.AppliedAppState,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query unscored applications", "error", err)
+		return nil, err
+	}
+
+	type candidate struct {
+		ID        string
+		ResumeSHA string
+		Models    []string
+		Retrying  bool
+	}
+	candidates, err := pgx.CollectRows(
+		rows,
+		pgx.RowToStructByPos[candidate],
+	)
+	if err != nil {
+		p.log.Err("failed to collect unscored applications", "error", err)
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		// Claimed by another replica in the meanwhile
+		return nil, nil
+	}
+
+	resumeSHAs := make(map[string]string, len(candidates))
+	var appIDs, modelNames []string
+	for _, c := range candidates {
+		if c.Retrying && len(resumeSHAs) > 0 {
+			// The retries are ordered last and are claimed alone
+			break
+		}
+		resumeSHAs[c.ID] = c.ResumeSHA
+		for _, model := range c.Models {
+			appIDs = append(appIDs, c.ID)
+			modelNames = append(modelNames, model)
+		}
+		if c.Retrying {
+			break
+		}
+	}
+
+	// The lease is taken only if no other replica took it since, which the
+	// row locks above do not rule out entirely
+	rows, err = tx.Query(ctx, `
+INSERT INTO application_scoring_state
+	(application_id, model_name, attempts, leased_until)
+SELECT id, model_name, 1, timezone('UTC', now()) + make_interval(secs => $3)
+FROM unnest($1::TEXT[], $2::TEXT[]) AS due(id, model_name)
+ON CONFLICT (application_id, model_name) DO UPDATE
+SET attempts = application_scoring_state.attempts + 1,
+	leased_until = EXCLUDED.leased_until,
+	updated_at = timezone('UTC', now())
+WHERE application_scoring_state.dead_lettered_at IS NULL
+AND application_scoring_state.next_attempt_at <= timezone('UTC', now())
+AND (
+	application_scoring_state.leased_until IS NULL
+	OR application_scoring_state.leased_until <= timezone('UTC', now())
+)
+RETURNING application_id, model_name
+`, appIDs, modelNames, vetchi.ApplicationScoringLease.Seconds())
+	if err != nil {
+		p.log.Err("failed to lease applications", "error", err)
 		return nil, err
 	}
+	leased, err := pgx.CollectRows(
+		rows,
+		pgx.RowToStructByPos[db.ApplicationModel],
+	)
+	if err != nil {
+		p.log.Err("failed to collect leased applications", "error", err)
+		return nil, err
+	}
+	if len(leased) == 0 {
+		return nil, nil
+	}
+
+	var leasedIDs []string
+	leasedModels := make(map[string][]string)
+	for _, l := range leased {
+		if _, ok := leasedModels[l.ApplicationID]; !ok {
+			leasedIDs = append(leasedIDs, l.ApplicationID)
+		}
+		leasedModels[l.ApplicationID] = append(
+			leasedModels[l.ApplicationID],
+			l.ModelName,
+		)
+	}
 
-	// Create result struct
 	batch := &db.UnscoredApplicationBatch{
-		EmployerID:   employerID,
-		OpeningID:    openingID,
-		JD:           jd,
-		Applications: make([]db.ApplicationForScoring, 0, len(appIDs)),
+		EmployerID: employerID,
+		OpeningID:  openingID,
+	}
+	err = tx.QueryRow(ctx, `
+SELECT o.jd, o.yoe_min, o.yoe_max,
+	e.match_weight_resume,
+	e.match_weight_long_bio,
+	e.match_weight_certifications,
+	e.match_weight_education,
+	e.match_weight_verified_work_history
+FROM openings o
+JOIN employers e ON e.id = o.employer_id
+WHERE o.employer_id = $1 AND o.id = $2
+`, employerID, openingID).Scan(
+		&batch.JD,
+		&batch.YoeMin,
+		&batch.YoeMax,
+		&batch.MatchWeights.Resume,
+		&batch.MatchWeights.LongBio,
+		&batch.MatchWeights.Certifications,
+		&batch.MatchWeights.Education,
+		&batch.MatchWeights.VerifiedWorkHistory,
+	)
+	if err != nil {
+		p.log.Err("failed to get the opening to score", "error", err)
+		return nil, err
 	}
 
-	// Populate applications (up to limit)
-	maxApps := len(appIDs)
-	if maxApps > limit {
-		maxApps = limit
+	profiles, err := p.getCandidateProfiles(ctx, tx, leasedIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	for i := 0; i < maxApps; i++ {
+	for _, id := range leasedIDs {
 		batch.Applications = append(
 			batch.Applications,
 			db.ApplicationForScoring{
-				ApplicationID: appIDs[i],
-				ResumeSHA:     resumeSHAs[i],
+				ApplicationID: id,
+				ResumeSHA:     resumeSHAs[id],
+				Profile:       profiles[id],
+				Models:        leasedModels[id],
 			},
 		)
 	}
 
-	p.log.Dbg("Got unscored application batch",
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	p.log.Dbg("Claimed unscored application batch",
 		"employer_id", employerID,
 		"opening_id", openingID,
 		"app_count", len(batch.Applications))
@@ -106,53 +291,216 @@ LIMIT 1
 	return batch, nil
 }
 
-// SaveApplicationScores saves multiple scores for an application in a single transaction
-func (p *PG) SaveApplicationScores(
+// getCandidateProfiles returns the profiles of the applicants, keyed by
+// the application IDs
+func (p *PG) getCandidateProfiles(
 	ctx context.Context,
-	scores []db.ApplicationScore,
-) error {
-	p.log.Dbg("saving application scores", "count", len(scores))
-	if len(scores) == 0 {
-		p.log.Dbg("No scores to save, returning early")
-		return nil
+	tx pgx.Tx,
+	applicationIDs []string,
+) (map[string]db.CandidateProfile, error) {
+	query := `
+SELECT
+	a.id,
+	h.long_bio,
+	COALESCE(
+		(
+			SELECT array_agg(
+				concat_ws(' ', ac.title, ac.description)
+				ORDER BY ac.created_at
+			)
+			FROM achievements ac
+			WHERE ac.hub_user_id = h.id
+			AND ac.achievement_type = $2
+		),
+		'{}'
+	) AS certifications,
+	COALESCE(
+		(
+			SELECT array_agg(
+				concat_ws(' ', i.institute_name, ed.degree, ed.description)
+				ORDER BY ed.created_at
+			)
+			FROM education ed
+			JOIN institutes i ON i.id = ed.institute_id
+			WHERE ed.hub_user_id = h.id
+		),
+		'{}'
+	) AS education,
+	COALESCE(
+		(
+			SELECT array_agg(DISTINCT d.domain_name)
+			FROM hub_users_official_emails hue
+			JOIN domains d ON d.id = hue.domain_id
+			WHERE hue.hub_user_id = h.id
+			AND hue.last_verified_at IS NOT NULL
+		),
+		'{}'
+	) AS verified_employer_domains
+FROM applications a
+JOIN hub_users h ON h.id = a.hub_user_id
+WHERE a.id = ANY($1)
+`
+	rows, err := tx.Query(
+		ctx,
+		query,
+		applicationIDs,
+		common.Certification,
+	)
+	if err != nil {
+		p.log.Err("failed to query candidate profiles", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := make(map[string]db.CandidateProfile, len(applicationIDs))
+	for rows.Next() {
+		var applicationID string
+		var profile db.CandidateProfile
+		err := rows.Scan(
+			&applicationID,
+			&profile.LongBio,
+			&profile.Certifications,
+			&profile.Education,
+			&profile.VerifiedEmployerDomains,
+		)
+		if err != nil {
+			p.log.Err("failed to scan candidate profile", "error", err)
+			return nil, err
+		}
+		profiles[applicationID] = profile
 	}
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate candidate profiles", "error", err)
+		return nil, err
+	}
+
+	return profiles, nil
+}
 
+// FinishApplicationScoring saves the scores and releases the leases of a
+// batch. The models that failed on the applications are retried after a
+// backoff, or dead lettered if they have run out of their attempts.
+// Returns the models that are dead lettered on the applications.
+func (p *PG) FinishApplicationScoring(
+	ctx context.Context,
+	result db.ApplicationScoringResult,
+) ([]db.ApplicationModel, error) {
 	tx, err := p.pool.Begin(ctx)
 	if err != nil {
 		p.log.Err("Failed to begin transaction", "error", err)
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(context.Background())
 
-	// Prepare the query
 	query := `
-INSERT INTO application_scores (application_id, model_name, score)
-VALUES ($1, $2, $3)
+INSERT INTO application_scores
+	(application_id, model_name, score, explanation)
+VALUES ($1, $2, $3, $4)
 ON CONFLICT (application_id, model_name) DO UPDATE
-SET score = $3
+SET score = $3,
+	explanation = $4,
+	stale = FALSE,
+	created_at = timezone('UTC', now())
 `
-
-	// Execute individual SQL statements within the transaction
-	for _, score := range scores {
+	for _, score := range result.Scores {
 		_, err := tx.Exec(
 			ctx,
 			query,
 			score.ApplicationID,
 			score.ModelName,
 			score.Score,
+			score.Explanation,
 		)
 		if err != nil {
 			p.log.Err("INSERT to application_scores failed", "error", err)
-			return err
+			return nil, err
 		}
 	}
 
-	// Commit the transaction
+	var scoredIDs, scoredModels []string
+	for _, scored := range result.Scored {
+		scoredIDs = append(scoredIDs, scored.ApplicationID)
+		scoredModels = append(scoredModels, scored.ModelName)
+	}
+	_, err = tx.Exec(ctx, `
+UPDATE application_scoring_state ss
+SET attempts = 0,
+	leased_until = NULL,
+	last_error = NULL,
+	updated_at = timezone('UTC', now())
+FROM unnest($1::TEXT[], $2::TEXT[]) AS scored(id, model_name)
+WHERE ss.application_id = scored.id
+AND ss.model_name = scored.model_name
+`, scoredIDs, scoredModels)
+	if err != nil {
+		p.log.Err("failed to release scored applications", "error", err)
+		return nil, err
+	}
+
+	var failedIDs, failedModels, failedErrors []string
+	for _, failed := range result.Failed {
+		failedIDs = append(failedIDs, failed.ApplicationID)
+		failedModels = append(failedModels, failed.ModelName)
+		failedErrors = append(failedErrors, failed.Error)
+	}
+
+	// The attempts were counted when the applications were leased
+	rows, err := tx.Query(ctx, `
+UPDATE application_scoring_state ss
+SET leased_until = NULL,
+	last_error = failed.error,
+	next_attempt_at = timezone('UTC', now()) + LEAST(
+		make_interval(secs => $4 * power(2, ss.attempts - 1)),
+		make_interval(secs => $5)
+	),
+	dead_lettered_at = CASE
+		WHEN ss.attempts >= $6 THEN timezone('UTC', now())
+	END,
+	updated_at = timezone('UTC', now())
+FROM unnest($1::TEXT[], $2::TEXT[], $3::TEXT[])
+	AS failed(id, model_name, error)
+WHERE ss.application_id = failed.id
+AND ss.model_name = failed.model_name
+RETURNING ss.application_id, ss.model_name, ss.dead_lettered_at IS NOT NULL
+`,
+		failedIDs,
+		failedModels,
+		failedErrors,
+		vetchi.ApplicationScoringBaseDelay.Seconds(),
+		vetchi.ApplicationScoringMaxDelay.Seconds(),
+		vetchi.MaxApplicationScoringAttempts,
+	)
+	if err != nil {
+		p.log.Err("failed to release failed applications", "error", err)
+		return nil, err
+	}
+
+	var deadLettered []db.ApplicationModel
+	var failed db.ApplicationModel
+	var isDeadLettered bool
+	_, err = pgx.ForEachRow(
+		rows,
+		[]any{&failed.ApplicationID, &failed.ModelName, &isDeadLettered},
+		func() error {
+			if isDeadLettered {
+				deadLettered = append(deadLettered, failed)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect failed applications", "error", err)
+		return nil, err
+	}
+
 	if err = tx.Commit(context.Background()); err != nil {
 		p.log.Err("Failed to commit transaction", "error", err)
-		return err
+		return nil, err
 	}
 
-	p.log.Dbg("Successfully saved scores", "count", len(scores))
-	return nil
+	p.log.Dbg("finished application scoring",
+		"scores", len(result.Scores),
+		"scored", len(result.Scored),
+		"failed", len(result.Failed))
+	return deadLettered, nil
 }
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/vetchium/vetchium/api/internal/db"
@@ -11,6 +12,9 @@ import (
 	"github.com/vetchium/vetchium/typespec/employer"
 )
 
+// GetAssessment returns the scorecard of the calling org user as an
+// Assessment. The scorecards of the other interviewers are available only
+// via GetScorecards, which withholds them until the caller has submitted.
 func (p *PG) GetAssessment(
 	ctx context.Context,
 	req employer.GetAssessmentRequest,
@@ -25,35 +29,45 @@ func (p *PG) GetAssessment(
 
 	query := `
 SELECT
-	id,
-	interviewers_decision,
-	positives,
-	negatives,
-	overall_assessment,
-	feedback_to_candidate,
-	feedback_submitted_by,
-	feedback_submitted_at
-FROM interviews
-WHERE id = $1
-AND employer_id = $2
+    i.id,
+    s.interviewers_decision,
+    s.positives,
+    s.negatives,
+    s.overall_assessment,
+    s.feedback_to_candidate,
+    ou.email,
+    s.submitted_at
+FROM
+    interviews i
+    LEFT JOIN interview_scorecards s ON s.interview_id = i.id
+        AND s.interviewer_id = $3
+    LEFT JOIN org_users ou ON ou.id = s.interviewer_id
+WHERE
+    i.id = $1
+    AND i.employer_id = $2
 `
 
-	p.log.Dbg("query", "query", query)
-
 	var assessment employer.Assessment
 	var decision *common.InterviewersDecision
 	var positives, negatives, overallAssessment, feedbackToCandidate *string
-	err := p.pool.QueryRow(ctx, query, req.InterviewID, orgUser.EmployerID).
-		Scan(
-			&assessment.InterviewID,
-			&decision,
-			&positives,
-			&negatives,
-			&overallAssessment,
-			&feedbackToCandidate,
-			&assessment.FeedbackSubmittedBy,
-			&assessment.FeedbackSubmittedAt,
-		)
+	var submittedBy *string
+	var submittedAt *time.Time
+	err := p.pool.QueryRow(
+		ctx,
+		query,
+		req.InterviewID,
+		orgUser.EmployerID,
+		orgUser.ID,
+	).Scan(
+		&assessment.InterviewID,
+		&decision,
+		&positives,
+		&negatives,
+		&overallAssessment,
+		&feedbackToCandidate,
+		&submittedBy,
+		&submittedAt,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			p.log.Dbg("no interview found", "interview_id", req.InterviewID)
@@ -80,151 +94,41 @@ AND employer_id = $2
 	if feedbackToCandidate != nil {
 		assessment.FeedbackToCandidate = *feedbackToCandidate
 	}
+	if submittedBy != nil {
+		assessment.FeedbackSubmittedBy = *submittedBy
+	}
+	if submittedAt != nil {
+		assessment.FeedbackSubmittedAt = *submittedAt
+	}
 
 	return assessment, nil
 }
 
+// PutAssessment submits the scorecard of the calling interviewer, without
+// any ratings. As with PutScorecard, a submitted scorecard cannot be changed.
 func (p *PG) PutAssessment(
 	ctx context.Context,
 	req employer.PutAssessmentRequest,
 ) error {
 	p.log.Dbg("PutAssessment", "req", req)
 
-	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
-	if !ok {
-		p.log.Err("error getting org user")
-		return db.ErrInternal
-	}
-
-	p.log.Dbg(
-		"PutAssessment context",
-		"org_user_id",
-		orgUser.ID,
-		"email",
-		orgUser.Email,
-	)
-
-	const (
-		noInterview    = "NO_INTERVIEW"
-		notInterviewer = "NOT_INTERVIEWER"
-		wrongState     = "WRONG_STATE"
-		success        = "SUCCESS"
-	)
-
-	query := `
-WITH interviewer_debug AS (
-	SELECT ii.interviewer_id, ou.id as org_user_id, ou.email
-	FROM interview_interviewers ii
-	JOIN org_users ou ON ou.id = ii.interviewer_id
-	WHERE ii.interview_id = $1
-),
-validation AS (
-	SELECT
-		i.id,
-		i.interview_state,
-		i.employer_id,
-		EXISTS (
-			SELECT 1 FROM interview_interviewers ii
-			WHERE ii.interview_id = i.id
-			AND ii.interviewer_id = $7
-		) as is_interviewer,
-		(SELECT json_agg(row_to_json(d)) FROM interviewer_debug d) as debug_interviewers
-	FROM interviews i
-	WHERE i.id = $1
-	AND i.employer_id = $9
-),
-validation_result AS (
-	SELECT
-		id,
-		interview_state,
-		is_interviewer,
-		debug_interviewers,
-		CASE
-			WHEN id IS NULL THEN '` + noInterview + `'
-			WHEN is_interviewer = false THEN '` + notInterviewer + `'
-			WHEN interview_state != $8 THEN '` + wrongState + `'
-			ELSE '` + success + `'
-		END as validation_status
-	FROM validation
-),
-update_result AS (
-	UPDATE interviews i
-	SET
-		positives = $2,
-		negatives = $3,
-		overall_assessment = $4,
-		feedback_to_candidate = $5,
-		interviewers_decision = $6,
-		feedback_submitted_by = $7,
-		interview_state = CASE 
-			WHEN $11 = true THEN $10::interview_states 
-			ELSE i.interview_state 
-		END,
-		completed_at = CASE 
-			WHEN $11 = true THEN NOW() 
-			ELSE NULL 
-		END,
-		feedback_submitted_at = NOW()
-	FROM validation_result v
-	WHERE i.id = v.id
-	AND v.validation_status = '` + success + `'
-	RETURNING i.id
-)
-SELECT
-	v.validation_status as result,
-	v.interview_state as debug_state,
-	v.is_interviewer as debug_is_interviewer,
-	$8 as debug_expected_state,
-	v.debug_interviewers as debug_interviewers
-FROM validation_result v
-LIMIT 1`
-
-	p.log.Dbg("query", "query", query)
-
-	var (
-		result             string
-		debugState         string
-		debugIsInterviewer bool
-		debugExpectedState string
-		debugInterviewers  []byte
-	)
-	err := p.pool.QueryRow(
-		ctx,
-		query,
-		req.InterviewID,
-		req.Positives,
-		req.Negatives,
-		req.OverallAssessment,
-		req.FeedbackToCandidate,
-		req.Decision,
-		orgUser.ID,
-		string(common.ScheduledInterviewState),
-		orgUser.EmployerID,
-		string(common.CompletedInterviewState),
-		req.MarkInterviewCompleted,
-	).Scan(&result, &debugState, &debugIsInterviewer, &debugExpectedState, &debugInterviewers)
-	if err != nil {
-		p.log.Err("error putting assessment", "error", err)
-		return db.ErrInternal
+	scorecard := employer.PutScorecardRequest{
+		InterviewID:            req.InterviewID,
+		Decision:               req.Decision,
+		Ratings:                []employer.CompetencyRating{},
+		Positives:              nonEmpty(req.Positives),
+		Negatives:              nonEmpty(req.Negatives),
+		OverallAssessment:      nonEmpty(req.OverallAssessment),
+		FeedbackToCandidate:    nonEmpty(req.FeedbackToCandidate),
+		MarkInterviewCompleted: req.MarkInterviewCompleted,
 	}
 
-	p.log.Dbg("assessment validation result",
-		"result", result,
-		"state", debugState,
-		"is_interviewer", debugIsInterviewer,
-		"expected_state", debugExpectedState,
-		"debug_interviewers", string(debugInterviewers))
+	return p.PutScorecard(ctx, scorecard)
+}
 
-	switch result {
-	case success:
+func nonEmpty(s string) *string {
+	if s == "" {
 		return nil
-	case noInterview:
-		return db.ErrNoInterview
-	case notInterviewer:
-		return db.ErrNotAnInterviewer
-	case wrongState:
-		return db.ErrStateMismatch
-	default:
-		return db.ErrInternal
 	}
+	return &s
 }
@@ -0,0 +1,370 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// AddBookmark bookmarks the target for the HubUser. Bookmarking a target
+// again replaces the folder and the note of its bookmark.
+func (p *PG) AddBookmark(ctx context.Context, req db.AddBookmarkReq) error {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return err
+	}
+
+	var targetID string
+	var employerID, companyDomain, postID *string
+
+	target := req.Target
+	switch target.BookmarkType {
+	case hub.OpeningBookmark:
+		targetID = *target.OpeningIDWithinCompany
+		companyDomain = target.CompanyDomain
+		err = p.pool.QueryRow(
+			ctx,
+			`
+SELECT o.employer_id
+FROM openings o
+JOIN domains d ON d.employer_id = o.employer_id
+WHERE o.id = $1 AND d.domain_name = $2
+`,
+			targetID,
+			*target.CompanyDomain,
+		).Scan(&employerID)
+	case hub.PostBookmark:
+		targetID = *target.PostID
+		err = p.pool.QueryRow(
+			ctx,
+			`SELECT id FROM posts WHERE id = $1`,
+			targetID,
+		).Scan(&targetID)
+	case hub.EmployerPostBookmark:
+		targetID = *target.PostID
+		err = p.pool.QueryRow(
+			ctx,
+			`SELECT id FROM employer_posts WHERE id = $1`,
+			targetID,
+		).Scan(&targetID)
+	case hub.IncognitoPostBookmark:
+		targetID = *target.PostID
+		err = p.pool.QueryRow(
+			ctx,
+			`SELECT id FROM incognito_posts WHERE id = $1 AND NOT is_deleted`,
+			targetID,
+		).Scan(&targetID)
+	case hub.PostCommentBookmark:
+		targetID = *target.CommentID
+		err = p.pool.QueryRow(
+			ctx,
+			`SELECT post_id FROM post_comments WHERE id = $1`,
+			targetID,
+		).Scan(&postID)
+	case hub.IncognitoPostCommentBookmark:
+		targetID = *target.CommentID
+		err = p.pool.QueryRow(
+			ctx,
+			`
+SELECT c.incognito_post_id
+FROM incognito_post_comments c
+JOIN incognito_posts ip ON ip.id = c.incognito_post_id
+WHERE c.id = $1 AND NOT c.is_deleted AND NOT ip.is_deleted
+`,
+			targetID,
+		).Scan(&postID)
+	default:
+		p.log.Err("unknown bookmark type", "type", target.BookmarkType)
+		return db.ErrInternal
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("bookmark target not found", "target", target)
+			return db.ErrNoBookmarkTarget
+		}
+
+		p.log.Err("failed to get bookmark target", "error", err)
+		return db.ErrInternal
+	}
+
+	query := `
+INSERT INTO bookmarks (id, hub_user_id, bookmark_type, target_id, employer_id, company_domain, post_id, folder, note)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (
+	hub_user_id,
+	bookmark_type,
+	target_id,
+	COALESCE(employer_id, '00000000-0000-0000-0000-000000000000'::UUID)
+)
+DO UPDATE SET folder = EXCLUDED.folder, note = EXCLUDED.note
+`
+	_, err = p.pool.Exec(
+		ctx,
+		query,
+		req.BookmarkID,
+		hubUserID,
+		target.BookmarkType,
+		targetID,
+		employerID,
+		companyDomain,
+		postID,
+		req.Folder,
+		req.Note,
+	)
+	if err != nil {
+		p.log.Err("failed to insert bookmark", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// RemoveBookmark removes the bookmark of the target, if there is one
+func (p *PG) RemoveBookmark(
+	ctx context.Context,
+	target hub.BookmarkTarget,
+) error {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return err
+	}
+
+	query := `
+DELETE FROM bookmarks
+WHERE hub_user_id = $1 AND bookmark_type = $2 AND target_id = $3
+`
+	args := []interface{}{hubUserID, target.BookmarkType}
+
+	switch target.BookmarkType {
+	case hub.OpeningBookmark:
+		// The Opening is found by the domain it was bookmarked with, or by
+		// any other domain of the employer, as long as the employer exists
+		query += `
+	AND (
+		company_domain = $4
+		OR employer_id IN (SELECT employer_id FROM domains WHERE domain_name = $4)
+	)
+`
+		args = append(
+			args,
+			*target.OpeningIDWithinCompany,
+			*target.CompanyDomain,
+		)
+	case hub.PostBookmark, hub.EmployerPostBookmark, hub.IncognitoPostBookmark:
+		args = append(args, *target.PostID)
+	default:
+		args = append(args, *target.CommentID)
+	}
+
+	_, err = p.pool.Exec(ctx, query, args...)
+	if err != nil {
+		p.log.Err("failed to delete bookmark", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// ListBookmarks returns the bookmarks of the HubUser, latest first, with a
+// preview of each bookmarked item. The bookmarks whose items were deleted
+// are returned as tombstones, without a preview.
+func (p *PG) ListBookmarks(
+	ctx context.Context,
+	req hub.ListBookmarksRequest,
+) (hub.ListBookmarksResponse, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return hub.ListBookmarksResponse{}, err
+	}
+
+	query := `
+SELECT
+	b.id,
+	b.bookmark_type,
+	b.target_id,
+	b.company_domain,
+	b.post_id,
+	b.folder,
+	b.note,
+	b.created_at,
+	CASE b.bookmark_type
+		WHEN 'OPENING' THEN o.id IS NULL
+		WHEN 'POST' THEN p.id IS NULL
+		WHEN 'EMPLOYER_POST' THEN ep.id IS NULL
+		WHEN 'INCOGNITO_POST' THEN ip.id IS NULL OR ip.is_deleted
+		WHEN 'POST_COMMENT' THEN pc.id IS NULL
+		WHEN 'INCOGNITO_POST_COMMENT' THEN ipc.id IS NULL OR ipc.is_deleted
+	END AS is_deleted,
+	o.title,
+	COALESCE(oe.company_name, epe.company_name),
+	COALESCE(
+		p.content,
+		ep.content,
+		CASE WHEN NOT ip.is_deleted THEN ip.content END,
+		pc.content,
+		CASE WHEN NOT ipc.is_deleted THEN ipc.content END
+	),
+	COALESCE(pa.full_name, pca.full_name),
+	COALESCE(pa.handle, pca.handle)
+FROM bookmarks b
+LEFT JOIN openings o
+	ON b.bookmark_type = 'OPENING'
+	AND o.employer_id = b.employer_id AND o.id = b.target_id
+LEFT JOIN employers oe ON oe.id = o.employer_id
+LEFT JOIN posts p ON b.bookmark_type = 'POST' AND p.id = b.target_id
+LEFT JOIN hub_users pa ON pa.id = p.author_id
+LEFT JOIN employer_posts ep
+	ON b.bookmark_type = 'EMPLOYER_POST' AND ep.id = b.target_id
+LEFT JOIN employers epe ON epe.id = ep.employer_id
+LEFT JOIN incognito_posts ip
+	ON b.bookmark_type = 'INCOGNITO_POST' AND ip.id = b.target_id
+LEFT JOIN post_comments pc
+	ON b.bookmark_type = 'POST_COMMENT' AND pc.id = b.target_id
+LEFT JOIN hub_users pca ON pca.id = pc.author_id
+LEFT JOIN incognito_post_comments ipc
+	ON b.bookmark_type = 'INCOGNITO_POST_COMMENT' AND ipc.id = b.target_id
+WHERE b.hub_user_id = $1
+`
+	args := []interface{}{hubUserID}
+
+	if len(req.BookmarkTypes) > 0 {
+		args = append(args, req.BookmarkTypesAsStrings())
+		query += fmt.Sprintf(
+			"	AND b.bookmark_type = ANY($%d::bookmark_types[])\n",
+			len(args),
+		)
+	}
+
+	if req.Folder != nil {
+		args = append(args, *req.Folder)
+		query += fmt.Sprintf("	AND b.folder = $%d\n", len(args))
+	}
+
+	if req.PaginationKey != nil {
+		args = append(args, *req.PaginationKey)
+		query += fmt.Sprintf(`
+	AND (b.created_at, b.id) < (
+		SELECT created_at, id
+		FROM bookmarks
+		WHERE id = $%d AND hub_user_id = $1
+	)
+`, len(args))
+	}
+
+	args = append(args, req.Limit)
+	query += fmt.Sprintf(`
+ORDER BY b.created_at DESC, b.id DESC
+LIMIT $%d
+`, len(args))
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		p.log.Err("failed to query bookmarks", "error", err)
+		return hub.ListBookmarksResponse{}, db.ErrInternal
+	}
+
+	bookmarks, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (hub.Bookmark, error) {
+			var bookmark hub.Bookmark
+			var targetID string
+			var postID *string
+			var authorHandle *string
+			err := row.Scan(
+				&bookmark.BookmarkID,
+				&bookmark.Target.BookmarkType,
+				&targetID,
+				&bookmark.Target.CompanyDomain,
+				&postID,
+				&bookmark.Folder,
+				&bookmark.Note,
+				&bookmark.CreatedAt,
+				&bookmark.IsDeleted,
+				&bookmark.JobTitle,
+				&bookmark.CompanyName,
+				&bookmark.Content,
+				&bookmark.AuthorName,
+				&authorHandle,
+			)
+			if err != nil {
+				return hub.Bookmark{}, err
+			}
+
+			switch bookmark.Target.BookmarkType {
+			case hub.OpeningBookmark:
+				bookmark.Target.OpeningIDWithinCompany = &targetID
+			case hub.PostCommentBookmark, hub.IncognitoPostCommentBookmark:
+				bookmark.Target.PostID = postID
+				bookmark.Target.CommentID = &targetID
+			default:
+				bookmark.Target.PostID = &targetID
+			}
+
+			if authorHandle != nil {
+				handle := common.Handle(*authorHandle)
+				bookmark.AuthorHandle = &handle
+			}
+
+			return bookmark, nil
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect bookmarks", "error", err)
+		return hub.ListBookmarksResponse{}, db.ErrInternal
+	}
+
+	response := hub.ListBookmarksResponse{Bookmarks: bookmarks}
+	if len(bookmarks) > 0 {
+		response.PaginationKey = bookmarks[len(bookmarks)-1].BookmarkID
+	}
+
+	return response, nil
+}
+
+// GetBookmarkFolders returns the folders of the bookmarks of the HubUser,
+// with the number of bookmarks in each
+func (p *PG) GetBookmarkFolders(
+	ctx context.Context,
+) ([]hub.BookmarkFolder, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return nil, err
+	}
+
+	query := `
+SELECT folder, COUNT(*)
+FROM bookmarks
+WHERE hub_user_id = $1 AND folder IS NOT NULL
+GROUP BY folder
+ORDER BY folder
+`
+	rows, err := p.pool.Query(ctx, query, hubUserID)
+	if err != nil {
+		p.log.Err("failed to query bookmark folders", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	folders, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (hub.BookmarkFolder, error) {
+			var folder hub.BookmarkFolder
+			err := row.Scan(&folder.Name, &folder.BookmarksCount)
+			return folder, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect bookmark folders", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return folders, nil
+}
@@ -0,0 +1,435 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func (p *PG) ConnectCalDAV(
+	ctx context.Context,
+	req db.ConnectCalDAVReq,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	// The busy periods and the written events of an earlier connection may
+	// belong to some other calendar, so they are forgotten
+	_, err = tx.Exec(
+		ctx,
+		`DELETE FROM caldav_connections WHERE org_user_id = $1`,
+		orgUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to delete old caldav connection", "error", err)
+		return db.ErrInternal
+	}
+
+	query := `
+INSERT INTO caldav_connections (org_user_id, employer_id, calendar_url, username, encrypted_password)
+    VALUES ($1, $2, $3, $4, $5)
+`
+	_, err = tx.Exec(
+		ctx,
+		query,
+		orgUser.ID,
+		orgUser.EmployerID,
+		req.CalendarURL,
+		req.Username,
+		req.EncryptedPassword,
+	)
+	if err != nil {
+		p.log.Err("failed to insert caldav connection", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetCalDAVConnection(
+	ctx context.Context,
+) (employer.CalDAVConnection, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return employer.CalDAVConnection{}, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    calendar_url,
+    username,
+    last_synced_at,
+    COALESCE(last_sync_error, '')
+FROM
+    caldav_connections
+WHERE
+    org_user_id = $1
+`
+	var connection employer.CalDAVConnection
+	err := p.pool.QueryRow(ctx, query, orgUser.ID).Scan(
+		&connection.CalendarURL,
+		&connection.Username,
+		&connection.LastSyncedAt,
+		&connection.LastSyncError,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("caldav connection not found", "org_user_id", orgUser.ID)
+			return employer.CalDAVConnection{}, db.ErrNoCalDAVConnection
+		}
+		p.log.Err("failed to get caldav connection", "error", err)
+		return employer.CalDAVConnection{}, db.ErrInternal
+	}
+
+	return connection, nil
+}
+
+func (p *PG) DisconnectCalDAV(ctx context.Context) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	result, err := p.pool.Exec(
+		ctx,
+		`DELETE FROM caldav_connections WHERE org_user_id = $1`,
+		orgUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to delete caldav connection", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("caldav connection not found", "org_user_id", orgUser.ID)
+		return db.ErrNoCalDAVConnection
+	}
+
+	return nil
+}
+
+func (p *PG) GetDueCalDAVConnections(
+	ctx context.Context,
+	limit int,
+) ([]db.DueCalDAVConnection, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    cc.org_user_id,
+    cc.calendar_url,
+    cc.username,
+    cc.encrypted_password
+FROM
+    caldav_connections cc
+    JOIN org_users ou ON ou.id = cc.org_user_id
+WHERE
+    cc.next_sync_at <= timezone('UTC', now())
+    AND ou.org_user_state != $1
+ORDER BY
+    cc.next_sync_at
+LIMIT $2
+`,
+		employer.DisabledOrgUserState,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query due caldav connections", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	connections, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.DueCalDAVConnection, error) {
+			var connection db.DueCalDAVConnection
+			err := row.Scan(
+				&connection.OrgUserID,
+				&connection.CalendarURL,
+				&connection.Username,
+				&connection.EncryptedPassword,
+			)
+			return connection, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan due caldav connections", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return connections, nil
+}
+
+func (p *PG) GetCalDAVEventChanges(
+	ctx context.Context,
+	orgUserID uuid.UUID,
+) (db.CalDAVEventChanges, error) {
+	writesQuery := `
+SELECT
+    i.id,
+    i.interview_type,
+    i.interview_state,
+    i.start_time,
+    i.end_time,
+    COALESCE(i.description, ''),
+    COALESCE(i.meeting_url, ''),
+    i.ical_sequence,
+    c.id,
+    hu.full_name,
+    e.company_name,
+    o.title
+FROM
+    interviews i
+    JOIN interview_interviewers ii ON ii.interview_id = i.id
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+    JOIN hub_users hu ON hu.id = a.hub_user_id
+    JOIN employers e ON e.id = c.employer_id
+    JOIN openings o ON o.employer_id = c.employer_id
+        AND o.id = c.opening_id
+WHERE
+    ii.interviewer_id = $1
+    AND ii.rsvp_status = $2
+    AND i.interview_state = $3
+    AND i.end_time > timezone('UTC', now())
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            caldav_written_events cwe
+        WHERE
+            cwe.org_user_id = ii.interviewer_id
+            AND cwe.interview_id = i.id
+            AND cwe.ical_sequence = i.ical_sequence)
+ORDER BY
+    i.start_time ASC
+`
+	rows, err := p.pool.Query(
+		ctx,
+		writesQuery,
+		orgUserID,
+		common.YesRSVP,
+		common.ScheduledInterviewState,
+	)
+	if err != nil {
+		p.log.Err("failed to query caldav writes", "error", err)
+		return db.CalDAVEventChanges{}, db.ErrInternal
+	}
+	defer rows.Close()
+
+	var changes db.CalDAVEventChanges
+	for rows.Next() {
+		var interview db.CalendarFeedInterview
+		err := rows.Scan(
+			&interview.InterviewID,
+			&interview.InterviewType,
+			&interview.InterviewState,
+			&interview.StartTime,
+			&interview.EndTime,
+			&interview.Description,
+			&interview.MeetingURL,
+			&interview.ICalSequence,
+			&interview.CandidacyID,
+			&interview.CandidateName,
+			&interview.CompanyName,
+			&interview.OpeningTitle,
+		)
+		if err != nil {
+			p.log.Err("failed to scan caldav writes", "error", err)
+			return db.CalDAVEventChanges{}, db.ErrInternal
+		}
+		changes.WriteInterviews = append(changes.WriteInterviews, interview)
+	}
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate caldav writes", "error", err)
+		return db.CalDAVEventChanges{}, db.ErrInternal
+	}
+
+	// The interviews that are over are left as they are in the calendar
+	deletesQuery := `
+SELECT
+    cwe.interview_id
+FROM
+    caldav_written_events cwe
+    JOIN interviews i ON i.id = cwe.interview_id
+WHERE
+    cwe.org_user_id = $1
+    AND (i.interview_state = $2
+        OR NOT EXISTS (
+            SELECT
+                1
+            FROM
+                interview_interviewers ii
+            WHERE
+                ii.interview_id = i.id
+                AND ii.interviewer_id = cwe.org_user_id
+                AND ii.rsvp_status = $3))
+`
+	deleteRows, err := p.pool.Query(
+		ctx,
+		deletesQuery,
+		orgUserID,
+		common.CancelledInterviewState,
+		common.YesRSVP,
+	)
+	if err != nil {
+		p.log.Err("failed to query caldav deletes", "error", err)
+		return db.CalDAVEventChanges{}, db.ErrInternal
+	}
+
+	changes.DeleteInterviewIDs, err = pgx.CollectRows(
+		deleteRows,
+		pgx.RowTo[string],
+	)
+	if err != nil {
+		p.log.Err("failed to scan caldav deletes", "error", err)
+		return db.CalDAVEventChanges{}, db.ErrInternal
+	}
+
+	return changes, nil
+}
+
+func (p *PG) SaveCalDAVSync(
+	ctx context.Context,
+	req db.SaveCalDAVSyncReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var syncError *string
+	if req.SyncError != "" {
+		syncError = &req.SyncError
+	}
+
+	// The connection may have been removed during the sync, in
+	// which case the results of the sync are discarded
+	var exists bool
+	err = tx.QueryRow(
+		ctx,
+		`
+UPDATE
+    caldav_connections
+SET
+    last_synced_at = timezone('UTC', now()),
+    last_sync_error = $2,
+    next_sync_at = $3
+WHERE
+    org_user_id = $1
+RETURNING
+    TRUE
+`,
+		req.OrgUserID,
+		syncError,
+		req.NextSyncAt,
+	).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("caldav connection gone", "org_user_id", req.OrgUserID)
+			return db.ErrNoCalDAVConnection
+		}
+		p.log.Err("failed to update caldav connection", "error", err)
+		return db.ErrInternal
+	}
+
+	if req.FreeBusySynced {
+		_, err = tx.Exec(
+			ctx,
+			`DELETE FROM caldav_busy_periods WHERE org_user_id = $1`,
+			req.OrgUserID,
+		)
+		if err != nil {
+			p.log.Err("failed to delete busy periods", "error", err)
+			return db.ErrInternal
+		}
+
+		for _, period := range req.BusyPeriods {
+			_, err = tx.Exec(
+				ctx,
+				`
+INSERT INTO caldav_busy_periods (org_user_id, start_time, end_time)
+    VALUES ($1, $2, $3)
+`,
+				req.OrgUserID,
+				period.StartTime,
+				period.EndTime,
+			)
+			if err != nil {
+				p.log.Err("failed to insert busy period", "error", err)
+				return db.ErrInternal
+			}
+		}
+	}
+
+	for _, event := range req.WrittenEvents {
+		_, err = tx.Exec(
+			ctx,
+			`
+INSERT INTO caldav_written_events (org_user_id, interview_id, ical_sequence)
+    VALUES ($1, $2, $3)
+ON CONFLICT (org_user_id, interview_id)
+    DO UPDATE SET
+        ical_sequence = EXCLUDED.ical_sequence,
+        written_at = timezone('UTC', now())
+`,
+			req.OrgUserID,
+			event.InterviewID,
+			event.ICalSequence,
+		)
+		if err != nil {
+			p.log.Err("failed to upsert written event", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	if len(req.DeletedInterviewIDs) > 0 {
+		_, err = tx.Exec(
+			ctx,
+			`
+DELETE FROM caldav_written_events
+WHERE org_user_id = $1
+    AND interview_id = ANY ($2::text[])
+`,
+			req.OrgUserID,
+			req.DeletedInterviewIDs,
+		)
+		if err != nil {
+			p.log.Err("failed to delete written events", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
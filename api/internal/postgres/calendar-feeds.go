@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func (p *PG) GetOrgUserCalendarFeed(
+	ctx context.Context,
+	newToken string,
+) (string, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return "", db.ErrInternal
+	}
+
+	// The newToken is used only if the org user does not have a feed yet
+	query := `
+INSERT INTO calendar_feeds (token, owner_type, org_user_id)
+    VALUES ($1, $2, $3)
+ON CONFLICT (org_user_id)
+    DO UPDATE SET
+        token = calendar_feeds.token
+    RETURNING
+        token
+`
+	var token string
+	err := p.pool.QueryRow(
+		ctx,
+		query,
+		newToken,
+		db.OrgUserAuthorType,
+		orgUser.ID,
+	).Scan(&token)
+	if err != nil {
+		p.log.Err("failed to get calendar feed", "error", err)
+		return "", db.ErrInternal
+	}
+
+	return token, nil
+}
+
+func (p *PG) ResetOrgUserCalendarFeed(
+	ctx context.Context,
+	newToken string,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	query := `
+INSERT INTO calendar_feeds (token, owner_type, org_user_id)
+    VALUES ($1, $2, $3)
+ON CONFLICT (org_user_id)
+    DO UPDATE SET
+        token = EXCLUDED.token,
+        created_at = timezone('UTC', now())
+`
+	_, err := p.pool.Exec(
+		ctx,
+		query,
+		newToken,
+		db.OrgUserAuthorType,
+		orgUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to reset calendar feed", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetHubUserCalendarFeed(
+	ctx context.Context,
+	newToken string,
+) (string, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return "", db.ErrInternal
+	}
+
+	// The newToken is used only if the hub user does not have a feed yet
+	query := `
+INSERT INTO calendar_feeds (token, owner_type, hub_user_id)
+    VALUES ($1, $2, $3)
+ON CONFLICT (hub_user_id)
+    DO UPDATE SET
+        token = calendar_feeds.token
+    RETURNING
+        token
+`
+	var token string
+	err := p.pool.QueryRow(
+		ctx,
+		query,
+		newToken,
+		db.HubUserAuthorType,
+		hubUser.ID,
+	).Scan(&token)
+	if err != nil {
+		p.log.Err("failed to get calendar feed", "error", err)
+		return "", db.ErrInternal
+	}
+
+	return token, nil
+}
+
+func (p *PG) ResetHubUserCalendarFeed(
+	ctx context.Context,
+	newToken string,
+) error {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.ErrInternal
+	}
+
+	query := `
+INSERT INTO calendar_feeds (token, owner_type, hub_user_id)
+    VALUES ($1, $2, $3)
+ON CONFLICT (hub_user_id)
+    DO UPDATE SET
+        token = EXCLUDED.token,
+        created_at = timezone('UTC', now())
+`
+	_, err := p.pool.Exec(
+		ctx,
+		query,
+		newToken,
+		db.HubUserAuthorType,
+		hubUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to reset calendar feed", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// GetCalendarFeed returns the upcoming interviews of the owner of the feed.
+// The feeds of the disabled or deleted users are not served.
+func (p *PG) GetCalendarFeed(
+	ctx context.Context,
+	token string,
+) (db.CalendarFeed, error) {
+	ownerQuery := `
+SELECT
+    cf.owner_type,
+    cf.org_user_id,
+    cf.hub_user_id
+FROM
+    calendar_feeds cf
+    LEFT JOIN org_users ou ON ou.id = cf.org_user_id
+    LEFT JOIN hub_users hu ON hu.id = cf.hub_user_id
+WHERE
+    cf.token = $1
+    AND (ou.org_user_state IS NULL OR ou.org_user_state != $2)
+    AND (hu.state IS NULL OR hu.state = $3)
+`
+	var feed db.CalendarFeed
+	var orgUserID, hubUserID *uuid.UUID
+	err := p.pool.QueryRow(
+		ctx,
+		ownerQuery,
+		token,
+		employer.DisabledOrgUserState,
+		hub.ActiveHubUserState,
+	).Scan(&feed.OwnerType, &orgUserID, &hubUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("calendar feed not found")
+			return db.CalendarFeed{}, db.ErrNoCalendarFeed
+		}
+		p.log.Err("failed to get calendar feed owner", "error", err)
+		return db.CalendarFeed{}, db.ErrInternal
+	}
+
+	var filter string
+	var ownerID uuid.UUID
+	if feed.OwnerType == db.OrgUserAuthorType && orgUserID != nil {
+		filter = `i.id IN (
+        SELECT
+            ii.interview_id
+        FROM
+            interview_interviewers ii
+        WHERE
+            ii.interviewer_id = $1)`
+		ownerID = *orgUserID
+	} else if feed.OwnerType == db.HubUserAuthorType && hubUserID != nil {
+		filter = "a.hub_user_id = $1"
+		ownerID = *hubUserID
+	} else {
+		p.log.Err("invalid calendar feed owner", "owner_type", feed.OwnerType)
+		return db.CalendarFeed{}, db.ErrInternal
+	}
+
+	interviewsQuery := `
+SELECT
+    i.id,
+    i.interview_type,
+    i.interview_state,
+    i.start_time,
+    i.end_time,
+    COALESCE(i.description, ''),
+    COALESCE(i.meeting_url, ''),
+    i.ical_sequence,
+    c.id,
+    hu.full_name,
+    e.company_name,
+    o.title
+FROM
+    interviews i
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+    JOIN hub_users hu ON hu.id = a.hub_user_id
+    JOIN employers e ON e.id = c.employer_id
+    JOIN openings o ON o.employer_id = c.employer_id
+        AND o.id = c.opening_id
+WHERE
+    ` + filter + `
+    AND i.interview_state = $2
+    AND i.end_time > timezone('UTC', now())
+ORDER BY
+    i.start_time ASC
+`
+	rows, err := p.pool.Query(
+		ctx,
+		interviewsQuery,
+		ownerID,
+		common.ScheduledInterviewState,
+	)
+	if err != nil {
+		p.log.Err("failed to query calendar feed", "error", err)
+		return db.CalendarFeed{}, db.ErrInternal
+	}
+	defer rows.Close()
+
+	feed.Interviews = []db.CalendarFeedInterview{}
+	for rows.Next() {
+		var interview db.CalendarFeedInterview
+		err := rows.Scan(
+			&interview.InterviewID,
+			&interview.InterviewType,
+			&interview.InterviewState,
+			&interview.StartTime,
+			&interview.EndTime,
+			&interview.Description,
+			&interview.MeetingURL,
+			&interview.ICalSequence,
+			&interview.CandidacyID,
+			&interview.CandidateName,
+			&interview.CompanyName,
+			&interview.OpeningTitle,
+		)
+		if err != nil {
+			p.log.Err("failed to scan calendar feed", "error", err)
+			return db.CalendarFeed{}, db.ErrInternal
+		}
+		feed.Interviews = append(feed.Interviews, interview)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate calendar feed", "error", err)
+		return db.CalendarFeed{}, db.ErrInternal
+	}
+
+	return feed, nil
+}
@@ -10,13 +10,11 @@ import (
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/middleware"
 	"github.com/vetchium/vetchium/typespec/common"
-	"github.com/vetchium/vetchium/typespec/employer"
-	"github.com/vetchium/vetchium/typespec/hub"
 )
 
 func (p *PG) AddEmployerCandidacyComment(
 	ctx context.Context,
-	empCommentReq employer.AddEmployerCandidacyCommentRequest,
+	empCommentReq db.AddEmployerCandidacyCommentReq,
 ) (uuid.UUID, error) {
 	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
 	if !ok {
@@ -96,12 +94,19 @@ RETURNING
             valid_candidacy)
 `
 
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return uuid.UUID{}, db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
 	var (
 		commentID    uuid.UUID
 		isAuthorized sql.NullBool
 		isValidState sql.NullBool
 	)
-	err := p.pool.QueryRow(
+	err = tx.QueryRow(
 		ctx,
 		query,
 		orgUser.EmployerID,
@@ -130,12 +135,23 @@ RETURNING
 		return uuid.UUID{}, db.ErrInternal
 	}
 
+	err = p.insertCommentAttachments(ctx, tx, commentID, empCommentReq.Files)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return uuid.UUID{}, db.ErrInternal
+	}
+
 	return commentID, nil
 }
 
 func (p *PG) AddHubCandidacyComment(
 	ctx context.Context,
-	hubCommentReq hub.AddHubCandidacyCommentRequest,
+	hubCommentReq db.AddHubCandidacyCommentReq,
 ) (uuid.UUID, error) {
 	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
 	if !ok {
@@ -199,12 +215,19 @@ RETURNING
             valid_candidacy_id)
 `
 
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return uuid.UUID{}, db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
 	var (
 		commentID    uuid.UUID
 		isValidState sql.NullBool
 		isValidID    sql.NullBool
 	)
-	err := p.pool.QueryRow(
+	err = tx.QueryRow(
 		ctx,
 		query,
 		hubCommentReq.CandidacyID,
@@ -231,5 +254,47 @@ RETURNING
 		return uuid.UUID{}, db.ErrInternal
 	}
 
+	err = p.insertCommentAttachments(ctx, tx, commentID, hubCommentReq.Files)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return uuid.UUID{}, db.ErrInternal
+	}
+
 	return commentID, nil
 }
+
+// insertCommentAttachments records the files, already stored in S3, as the
+// attachments of the comment, numbered from 1 in their order
+func (p *PG) insertCommentAttachments(
+	ctx context.Context,
+	tx pgx.Tx,
+	commentID uuid.UUID,
+	files []db.CandidacyCommentFile,
+) error {
+	for i, file := range files {
+		_, err := tx.Exec(
+			ctx,
+			`
+INSERT INTO candidacy_comment_attachments (comment_id, attachment_number, filename, file_path, content_type, size_bytes)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`,
+			commentID,
+			i+1,
+			file.Filename,
+			file.FilePath,
+			file.ContentType,
+			file.SizeBytes,
+		)
+		if err != nil {
+			p.log.Err("failed to insert comment attachment", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/middleware"
 	"github.com/vetchium/vetchium/typespec/common"
@@ -20,61 +22,28 @@ func (p *PG) GetEmployerCandidacyComments(
 		return candidacyComments, db.ErrInternal
 	}
 
-	query := `
-WITH access_check AS (
-	SELECT EXISTS (
-		SELECT 1 FROM candidacies 
-		WHERE id = $1 AND employer_id = $2
-	) as has_access
+	var hasAccess bool
+	err := p.pool.QueryRow(
+		ctx,
+		`
+SELECT EXISTS (
+	SELECT 1 FROM candidacies
+	WHERE id = $1 AND employer_id = $2
 )
-SELECT 
-	cc.id,
-	COALESCE(ou.name, hu.full_name) as commenter_name,
-	cc.author_type,
-	cc.comment_text as content,
-	cc.created_at
-FROM candidacy_comments cc
-LEFT JOIN org_users ou ON cc.org_user_id = ou.id
-LEFT JOIN hub_users hu ON cc.hub_user_id = hu.id,
-access_check
-WHERE cc.candidacy_id = $1
-AND access_check.has_access
-ORDER BY cc.created_at DESC
-`
-
-	rows, err := p.pool.Query(ctx, query,
+`,
 		empGetCommentsReq.CandidacyID,
 		orgUser.EmployerID,
-	)
+	).Scan(&hasAccess)
 	if err != nil {
-		p.log.Err("failed to query candidacy comments", "error", err)
+		p.log.Err("failed to check candidacy access", "error", err)
 		return nil, db.ErrInternal
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var comment common.CandidacyComment
-		err := rows.Scan(
-			&comment.CommentID,
-			&comment.CommenterName,
-			&comment.CommenterType,
-			&comment.Content,
-			&comment.CreatedAt,
-		)
-		if err != nil {
-			p.log.Err("failed to scan candidacy comment", "error", err)
-			return nil, db.ErrInternal
-		}
-
-		candidacyComments = append(candidacyComments, comment)
-	}
 
-	if err = rows.Err(); err != nil {
-		p.log.Err("error iterating over rows", "error", err)
-		return nil, db.ErrInternal
+	if !hasAccess {
+		return candidacyComments, nil
 	}
 
-	return candidacyComments, nil
+	return p.getCandidacyComments(ctx, empGetCommentsReq.CandidacyID, nil)
 }
 
 func (p *PG) GetHubCandidacyComments(
@@ -89,61 +58,145 @@ func (p *PG) GetHubCandidacyComments(
 		return candidacyComments, db.ErrInternal
 	}
 
-	query := `
-WITH access_check AS (
-	SELECT EXISTS (
-		SELECT 1 FROM candidacies c
-		JOIN applications a ON c.application_id = a.id
-		WHERE c.id = $1 AND a.hub_user_id = $2
-	) as has_access
+	var hasAccess bool
+	err := p.pool.QueryRow(
+		ctx,
+		`
+SELECT EXISTS (
+	SELECT 1 FROM candidacies c
+	JOIN applications a ON c.application_id = a.id
+	WHERE c.id = $1 AND a.hub_user_id = $2
 )
-SELECT 
+`,
+		hubGetCommentsReq.CandidacyID,
+		hubUser.ID,
+	).Scan(&hasAccess)
+	if err != nil {
+		p.log.Err("failed to check candidacy access", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if !hasAccess {
+		return candidacyComments, nil
+	}
+
+	candidacyComments, err = p.getCandidacyComments(
+		ctx,
+		hubGetCommentsReq.CandidacyID,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.log.Dbg("got candidacy comments", "comments", candidacyComments)
+	return candidacyComments, nil
+}
+
+// getCandidacyComments returns the comments of the candidacy, the latest
+// first, or only those among the commentIDs if they are not nil. The
+// contents, the attachments and the edits of the retracted comments are
+// left out.
+func (p *PG) getCandidacyComments(
+	ctx context.Context,
+	candidacyID string,
+	commentIDs []uuid.UUID,
+) ([]common.CandidacyComment, error) {
+	query := `
+SELECT
 	cc.id,
 	COALESCE(ou.name, hu.full_name) as commenter_name,
 	cc.author_type,
-	cc.comment_text as content,
-	cc.created_at
+	CASE WHEN cc.retracted_at IS NULL THEN cc.comment_text ELSE '' END,
+	cc.created_at,
+	cc.edited_at,
+	cc.retracted_at IS NOT NULL,
+	COALESCE(
+		(
+			SELECT json_agg(
+				json_build_object(
+					'attachment_number', a.attachment_number,
+					'filename', a.filename,
+					'content_type', a.content_type,
+					'size_bytes', a.size_bytes
+				)
+				ORDER BY a.attachment_number
+			)
+			FROM candidacy_comment_attachments a
+			WHERE a.comment_id = cc.id AND cc.retracted_at IS NULL
+		),
+		'[]'::json
+	),
+	COALESCE(
+		(
+			SELECT json_agg(
+				json_build_object(
+					'previous_content', e.previous_text,
+					'edited_at', e.edited_at
+				)
+				ORDER BY e.edited_at
+			)
+			FROM candidacy_comment_edits e
+			WHERE e.comment_id = cc.id AND cc.retracted_at IS NULL
+		),
+		'[]'::json
+	),
+	COALESCE(
+		(
+			SELECT json_agg(
+				json_build_object(
+					'reader_name', COALESCE(rou.name, rhu.full_name),
+					'reader_type', r.reader_type
+				)
+				ORDER BY r.reader_type, COALESCE(rou.name, rhu.full_name)
+			)
+			FROM candidacy_comment_reads r
+			LEFT JOIN org_users rou ON rou.id = r.org_user_id
+			LEFT JOIN hub_users rhu ON rhu.id = r.hub_user_id
+			WHERE r.candidacy_id = cc.candidacy_id
+				AND r.last_read_at >= cc.created_at
+				AND r.org_user_id IS DISTINCT FROM cc.org_user_id
+				AND r.hub_user_id IS DISTINCT FROM cc.hub_user_id
+		),
+		'[]'::json
+	)
 FROM candidacy_comments cc
 LEFT JOIN org_users ou ON cc.org_user_id = ou.id
-LEFT JOIN hub_users hu ON cc.hub_user_id = hu.id,
-access_check
+LEFT JOIN hub_users hu ON cc.hub_user_id = hu.id
 WHERE cc.candidacy_id = $1
-AND access_check.has_access
+AND ($2::UUID[] IS NULL OR cc.id = ANY($2))
 ORDER BY cc.created_at DESC
 `
 
-	rows, err := p.pool.Query(ctx, query,
-		hubGetCommentsReq.CandidacyID,
-		hubUser.ID,
-	)
+	rows, err := p.pool.Query(ctx, query, candidacyID, commentIDs)
 	if err != nil {
 		p.log.Err("failed to query candidacy comments", "error", err)
 		return nil, db.ErrInternal
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var comment common.CandidacyComment
-		err := rows.Scan(
-			&comment.CommentID,
-			&comment.CommenterName,
-			&comment.CommenterType,
-			&comment.Content,
-			&comment.CreatedAt,
-		)
-		if err != nil {
-			p.log.Err("failed to scan candidacy comment", "error", err)
-			return nil, db.ErrInternal
-		}
-
-		candidacyComments = append(candidacyComments, comment)
-	}
 
-	if err = rows.Err(); err != nil {
-		p.log.Err("error iterating over rows", "error", err)
+	candidacyComments, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (common.CandidacyComment, error) {
+			var comment common.CandidacyComment
+			err := row.Scan(
+				&comment.CommentID,
+				&comment.CommenterName,
+				&comment.CommenterType,
+				&comment.Content,
+				&comment.CreatedAt,
+				&comment.EditedAt,
+				&comment.IsRetracted,
+				&comment.Attachments,
+				&comment.EditHistory,
+				&comment.ReadBy,
+			)
+			return comment, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan candidacy comments", "error", err)
 		return nil, db.ErrInternal
 	}
 
-	p.log.Dbg("got candidacy comments", "comments", candidacyComments)
 	return candidacyComments, nil
 }
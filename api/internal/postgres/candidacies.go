@@ -24,7 +24,22 @@ func (p *PG) FilterEmployerCandidacyInfos(
 	var args []interface{}
 
 	query := `
-SELECT c.id, o.id, o.title, o.jd, c.candidacy_state, hu.full_name, hu.handle
+SELECT c.id, o.id, o.title, o.jd, c.candidacy_state, hu.full_name, hu.handle,
+(
+	SELECT COUNT(*)
+	FROM candidacy_comments cc
+	WHERE cc.candidacy_id = c.id
+	AND cc.retracted_at IS NULL
+	AND cc.org_user_id IS DISTINCT FROM $2
+	AND cc.created_at > COALESCE(
+		(
+			SELECT r.last_read_at
+			FROM candidacy_comment_reads r
+			WHERE r.candidacy_id = c.id AND r.org_user_id = $2
+		),
+		'-infinity'
+	)
+) AS unread_comments_count
 FROM candidacies c
 JOIN openings o ON c.employer_id = o.employer_id AND c.opening_id = o.id 
 JOIN applications a ON c.application_id = a.id
@@ -32,7 +47,7 @@ JOIN hub_users hu ON a.hub_user_id = hu.id
 JOIN org_users ou ON o.recruiter = ou.id
 WHERE c.employer_id = $1
 	`
-	args = append(args, orgUser.EmployerID)
+	args = append(args, orgUser.EmployerID, orgUser.ID)
 
 	if request.OpeningID != nil {
 		query += fmt.Sprintf(` AND c.opening_id = $%d`, len(args)+1)
@@ -80,6 +95,7 @@ WHERE c.employer_id = $1
 			&candidacy.CandidacyState,
 			&candidacy.ApplicantName,
 			&candidacy.ApplicantHandle,
+			&candidacy.UnreadCommentsCount,
 		)
 		if err != nil {
 			p.log.Err("failed to scan candidacy", "error", err)
@@ -110,7 +126,22 @@ func (p *PG) GetMyCandidacies(
 	}
 
 	query := `
-SELECT c.id, e.company_name, d.domain_name, o.id, o.title, o.jd, c.candidacy_state
+SELECT c.id, e.company_name, d.domain_name, o.id, o.title, o.jd, c.candidacy_state,
+(
+	SELECT COUNT(*)
+	FROM candidacy_comments cc
+	WHERE cc.candidacy_id = c.id
+	AND cc.retracted_at IS NULL
+	AND cc.hub_user_id IS DISTINCT FROM $1
+	AND cc.created_at > COALESCE(
+		(
+			SELECT r.last_read_at
+			FROM candidacy_comment_reads r
+			WHERE r.candidacy_id = c.id AND r.hub_user_id = $1
+		),
+		'-infinity'
+	)
+) AS unread_comments_count
 FROM candidacies c
 JOIN openings o ON c.employer_id = o.employer_id AND c.opening_id = o.id
 JOIN employers e ON c.employer_id = e.id
@@ -173,6 +204,7 @@ WHERE a.hub_user_id = $1
 			&candidacy.OpeningTitle,
 			&candidacy.OpeningDescription,
 			&candidacy.CandidacyState,
+			&candidacy.UnreadCommentsCount,
 		)
 		if err != nil {
 			p.log.Err("failed to scan candidacy", "error", err)
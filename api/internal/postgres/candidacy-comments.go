@@ -0,0 +1,432 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+func (p *PG) EditEmployerCandidacyComment(
+	ctx context.Context,
+	req common.EditCandidacyCommentRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	return p.editCandidacyComment(ctx, req, db.OrgUserAuthorType, orgUser.ID)
+}
+
+func (p *PG) EditHubCandidacyComment(
+	ctx context.Context,
+	req common.EditCandidacyCommentRequest,
+) error {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.ErrInternal
+	}
+
+	return p.editCandidacyComment(ctx, req, db.HubUserAuthorType, hubUser.ID)
+}
+
+func (p *PG) RetractEmployerCandidacyComment(
+	ctx context.Context,
+	req common.RetractCandidacyCommentRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	return p.retractCandidacyComment(
+		ctx,
+		req.CommentID,
+		db.OrgUserAuthorType,
+		orgUser.ID,
+	)
+}
+
+func (p *PG) RetractHubCandidacyComment(
+	ctx context.Context,
+	req common.RetractCandidacyCommentRequest,
+) error {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.ErrInternal
+	}
+
+	return p.retractCandidacyComment(
+		ctx,
+		req.CommentID,
+		db.HubUserAuthorType,
+		hubUser.ID,
+	)
+}
+
+// editCandidacyComment replaces the content of the comment, preserving the
+// earlier content in the edit history
+func (p *PG) editCandidacyComment(
+	ctx context.Context,
+	req common.EditCandidacyCommentRequest,
+	authorType string,
+	authorID uuid.UUID,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	commentID, err := p.lockEditableComment(
+		ctx,
+		tx,
+		req.CommentID,
+		authorType,
+		authorID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO candidacy_comment_edits (comment_id, previous_text)
+SELECT id, comment_text
+FROM candidacy_comments
+WHERE id = $1
+`,
+		commentID,
+	)
+	if err != nil {
+		p.log.Err("failed to insert comment edit", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE candidacy_comments
+SET comment_text = $1, edited_at = timezone('UTC', now())
+WHERE id = $2
+`,
+		req.Comment,
+		commentID,
+	)
+	if err != nil {
+		p.log.Err("failed to edit comment", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) retractCandidacyComment(
+	ctx context.Context,
+	commentIDStr string,
+	authorType string,
+	authorID uuid.UUID,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	commentID, err := p.lockEditableComment(
+		ctx,
+		tx,
+		commentIDStr,
+		authorType,
+		authorID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE candidacy_comments
+SET retracted_at = timezone('UTC', now())
+WHERE id = $1
+`,
+		commentID,
+	)
+	if err != nil {
+		p.log.Err("failed to retract comment", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// lockEditableComment locks the comment of the author for an edit or a
+// retraction. Returns db.ErrNoCandidacyComment if the author has no such
+// comment and db.ErrCommentNotEditable if the comment is retracted or is
+// past its edit window.
+func (p *PG) lockEditableComment(
+	ctx context.Context,
+	tx pgx.Tx,
+	commentIDStr string,
+	authorType string,
+	authorID uuid.UUID,
+) (uuid.UUID, error) {
+	commentID, err := uuid.Parse(commentIDStr)
+	if err != nil {
+		p.log.Dbg("invalid comment id", "comment_id", commentIDStr)
+		return uuid.UUID{}, db.ErrNoCandidacyComment
+	}
+
+	var editable bool
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+	retracted_at IS NULL
+	AND created_at > timezone('UTC', now()) - make_interval(secs => $4)
+FROM candidacy_comments
+WHERE id = $1
+	AND author_type = $2
+	AND COALESCE(org_user_id, hub_user_id) = $3
+FOR UPDATE
+`,
+		commentID,
+		authorType,
+		authorID,
+		vetchi.CandidacyCommentEditWindow.Seconds(),
+	).Scan(&editable)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("comment not found", "comment_id", commentID)
+			return uuid.UUID{}, db.ErrNoCandidacyComment
+		}
+
+		p.log.Err("failed to get comment", "error", err)
+		return uuid.UUID{}, db.ErrInternal
+	}
+
+	if !editable {
+		p.log.Dbg("comment not editable", "comment_id", commentID)
+		return uuid.UUID{}, db.ErrCommentNotEditable
+	}
+
+	return commentID, nil
+}
+
+func (p *PG) MarkEmployerCandidacyCommentsRead(
+	ctx context.Context,
+	req common.MarkCandidacyCommentsReadRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	commentID, err := uuid.Parse(req.CommentID)
+	if err != nil {
+		p.log.Dbg("invalid comment id", "comment_id", req.CommentID)
+		return db.ErrNoCandidacyComment
+	}
+
+	// The read receipts only move forward, even if an older comment is
+	// marked as read later
+	result, err := p.pool.Exec(
+		ctx,
+		`
+INSERT INTO candidacy_comment_reads (candidacy_id, reader_type, org_user_id, last_read_at)
+SELECT cc.candidacy_id, $3::comment_author_types, $4::UUID, cc.created_at
+FROM candidacy_comments cc
+WHERE cc.id = $1
+	AND cc.candidacy_id = $2
+	AND cc.employer_id = $5
+ON CONFLICT (candidacy_id, org_user_id) WHERE org_user_id IS NOT NULL
+DO UPDATE SET last_read_at = GREATEST(candidacy_comment_reads.last_read_at, EXCLUDED.last_read_at)
+`,
+		commentID,
+		req.CandidacyID,
+		db.OrgUserAuthorType,
+		orgUser.ID,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to mark comments read", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("comment not found", "comment_id", commentID)
+		return db.ErrNoCandidacyComment
+	}
+
+	return nil
+}
+
+func (p *PG) MarkHubCandidacyCommentsRead(
+	ctx context.Context,
+	req common.MarkCandidacyCommentsReadRequest,
+) error {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.ErrInternal
+	}
+
+	commentID, err := uuid.Parse(req.CommentID)
+	if err != nil {
+		p.log.Dbg("invalid comment id", "comment_id", req.CommentID)
+		return db.ErrNoCandidacyComment
+	}
+
+	result, err := p.pool.Exec(
+		ctx,
+		`
+INSERT INTO candidacy_comment_reads (candidacy_id, reader_type, hub_user_id, last_read_at)
+SELECT cc.candidacy_id, $3::comment_author_types, $4::UUID, cc.created_at
+FROM candidacy_comments cc
+JOIN candidacies c ON c.id = cc.candidacy_id
+JOIN applications a ON a.id = c.application_id
+WHERE cc.id = $1
+	AND cc.candidacy_id = $2
+	AND a.hub_user_id = $4
+ON CONFLICT (candidacy_id, hub_user_id) WHERE hub_user_id IS NOT NULL
+DO UPDATE SET last_read_at = GREATEST(candidacy_comment_reads.last_read_at, EXCLUDED.last_read_at)
+`,
+		commentID,
+		req.CandidacyID,
+		db.HubUserAuthorType,
+		hubUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to mark comments read", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("comment not found", "comment_id", commentID)
+		return db.ErrNoCandidacyComment
+	}
+
+	return nil
+}
+
+func (p *PG) GetEmployerCommentAttachment(
+	ctx context.Context,
+	req common.GetCandidacyCommentAttachmentRequest,
+) (db.CandidacyCommentFile, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.CandidacyCommentFile{}, db.ErrInternal
+	}
+
+	return p.getCommentAttachment(
+		ctx,
+		`
+SELECT a.filename, a.file_path, a.content_type, a.size_bytes
+FROM candidacy_comment_attachments a
+JOIN candidacy_comments cc ON cc.id = a.comment_id
+WHERE a.comment_id = $1
+	AND a.attachment_number = $2
+	AND cc.retracted_at IS NULL
+	AND cc.employer_id = $3
+`,
+		req,
+		orgUser.EmployerID,
+	)
+}
+
+func (p *PG) GetHubCommentAttachment(
+	ctx context.Context,
+	req common.GetCandidacyCommentAttachmentRequest,
+) (db.CandidacyCommentFile, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.CandidacyCommentFile{}, db.ErrInternal
+	}
+
+	return p.getCommentAttachment(
+		ctx,
+		`
+SELECT a.filename, a.file_path, a.content_type, a.size_bytes
+FROM candidacy_comment_attachments a
+JOIN candidacy_comments cc ON cc.id = a.comment_id
+JOIN candidacies c ON c.id = cc.candidacy_id
+JOIN applications ap ON ap.id = c.application_id
+WHERE a.comment_id = $1
+	AND a.attachment_number = $2
+	AND cc.retracted_at IS NULL
+	AND ap.hub_user_id = $3
+`,
+		req,
+		hubUser.ID,
+	)
+}
+
+// getCommentAttachment runs the query, which checks the access of the user
+// given as $3, for the attachment. The attachments of the retracted comments
+// are not available anymore.
+func (p *PG) getCommentAttachment(
+	ctx context.Context,
+	query string,
+	req common.GetCandidacyCommentAttachmentRequest,
+	userScope uuid.UUID,
+) (db.CandidacyCommentFile, error) {
+	commentID, err := uuid.Parse(req.CommentID)
+	if err != nil {
+		p.log.Dbg("invalid comment id", "comment_id", req.CommentID)
+		return db.CandidacyCommentFile{}, db.ErrNoCommentAttachment
+	}
+
+	var file db.CandidacyCommentFile
+	err = p.pool.QueryRow(
+		ctx,
+		query,
+		commentID,
+		req.AttachmentNumber,
+		userScope,
+	).Scan(
+		&file.Filename,
+		&file.FilePath,
+		&file.ContentType,
+		&file.SizeBytes,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("comment attachment not found", "req", req)
+			return db.CandidacyCommentFile{}, db.ErrNoCommentAttachment
+		}
+
+		p.log.Err("failed to get comment attachment", "error", err)
+		return db.CandidacyCommentFile{}, db.ErrInternal
+	}
+
+	return file, nil
+}
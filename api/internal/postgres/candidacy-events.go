@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+)
+
+// GetUndispatchedCandidacyEvents returns the candidacy events whose hooks are
+// yet to be run, oldest first, so that the hooks see the changes of a
+// candidacy in the order in which they happened
+func (p *PG) GetUndispatchedCandidacyEvents(
+	ctx context.Context,
+	limit int,
+) ([]db.CandidacyEvent, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    ce.id,
+    ce.candidacy_id,
+    ce.employer_id,
+    ce.from_state,
+    ce.to_state,
+    ce.actor_type,
+    ce.reason,
+    ce.created_at,
+    hu.full_name,
+    e.company_name,
+    o.title,
+    ARRAY (
+        SELECT
+            ou.email
+        FROM
+            org_users ou
+        WHERE (ou.id IN (o.hiring_manager, o.recruiter)
+            OR ou.id IN (
+                SELECT
+                    ow.watcher_id
+                FROM
+                    opening_watchers ow
+                WHERE
+                    ow.employer_id = o.employer_id
+                    AND ow.opening_id = o.id))
+            AND ou.id IS DISTINCT FROM ce.org_user_id
+        ORDER BY
+            ou.email)
+FROM
+    candidacy_events ce
+    JOIN candidacies c ON c.id = ce.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+    JOIN hub_users hu ON hu.id = a.hub_user_id
+    JOIN employers e ON e.id = c.employer_id
+    JOIN openings o ON o.employer_id = c.employer_id AND o.id = c.opening_id
+WHERE
+    ce.dispatched_at IS NULL
+ORDER BY
+    ce.created_at,
+    ce.id
+LIMIT $1
+`,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query undispatched candidacy events", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	events, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.CandidacyEvent, error) {
+			var event db.CandidacyEvent
+			err := row.Scan(
+				&event.ID,
+				&event.CandidacyID,
+				&event.EmployerID,
+				&event.FromState,
+				&event.ToState,
+				&event.ActorType,
+				&event.Reason,
+				&event.CreatedAt,
+				&event.CandidateName,
+				&event.CompanyName,
+				&event.OpeningTitle,
+				&event.WatcherEmails,
+			)
+			return event, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan candidacy events", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return events, nil
+}
+
+// DispatchCandidacyEvent marks the hooks of the event as run and queues the
+// emails generated by them in a single transaction. Returns
+// db.ErrStateMismatch if the event was dispatched meanwhile.
+func (p *PG) DispatchCandidacyEvent(
+	ctx context.Context,
+	req db.DispatchCandidacyEventReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	result, err := tx.Exec(
+		ctx,
+		`
+UPDATE candidacy_events
+SET dispatched_at = timezone('UTC', now())
+WHERE id = $1
+    AND dispatched_at IS NULL
+`,
+		req.EventID,
+	)
+	if err != nil {
+		p.log.Err("failed to mark candidacy event dispatched", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() != 1 {
+		p.log.Dbg("candidacy event already dispatched", "id", req.EventID)
+		return db.ErrStateMismatch
+	}
+
+	for _, email := range req.Emails {
+		err = p.insertEmail(ctx, tx, email)
+		if err != nil {
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// candidacyStateChange is a change of the state of a candidacy
+type candidacyStateChange struct {
+	from common.CandidacyState
+	to   common.CandidacyState
+}
+
+// candidacyActors are who may make a state change. An org user needs any
+// one of the orgUserRoles.
+type candidacyActors struct {
+	orgUserRoles []common.OrgUserRole
+	hubUser      bool
+	system       bool
+}
+
+var candidacyManagers = []common.OrgUserRole{
+	common.Admin,
+	common.ApplicationsCRUD,
+}
+
+// candidacyStateChanges are the only legal state changes of a candidacy.
+// All the changes of the candidacy_state must go via transitionCandidacy,
+// which enforces this table and records the history. Each change is made by
+// some flow; a change is added here along with the flow that makes it.
+var candidacyStateChanges = map[candidacyStateChange]candidacyActors{
+	{
+		from: common.InterviewingCandidacyState,
+		to:   common.OfferedCandidacyState,
+	}: {orgUserRoles: candidacyManagers},
+
+	// Rescinded offer
+	{
+		from: common.OfferedCandidacyState,
+		to:   common.InterviewingCandidacyState,
+	}: {orgUserRoles: candidacyManagers},
+
+	// The employer can record an acceptance that happened outside of
+	// Vetchium. The e-signature providers accept or decline on behalf of
+	// the candidate.
+	{
+		from: common.OfferedCandidacyState,
+		to:   common.OfferAcceptedCandidacyState,
+	}: {orgUserRoles: candidacyManagers, hubUser: true, system: true},
+	{
+		from: common.OfferedCandidacyState,
+		to:   common.OfferDeclinedCandidacyState,
+	}: {hubUser: true, system: true},
+
+	// Expired offer
+	{
+		from: common.OfferedCandidacyState,
+		to:   common.CandidateNotRespondingCandidacyState,
+	}: {system: true},
+}
+
+// candidacyActor is who changes the state of a candidacy
+type candidacyActor struct {
+	actorType common.CandidacyActorType
+	orgUser   *db.OrgUserTO
+	hubUserID *uuid.UUID
+}
+
+func orgUserActor(orgUser db.OrgUserTO) candidacyActor {
+	return candidacyActor{
+		actorType: common.OrgUserCandidacyActor,
+		orgUser:   &orgUser,
+	}
+}
+
+func hubUserActor(hubUserID uuid.UUID) candidacyActor {
+	return candidacyActor{
+		actorType: common.HubUserCandidacyActor,
+		hubUserID: &hubUserID,
+	}
+}
+
+var systemActor = candidacyActor{actorType: common.SystemCandidacyActor}
+
+func (a candidacyActor) mayMake(change candidacyStateChange) bool {
+	actors, ok := candidacyStateChanges[change]
+	if !ok {
+		return false
+	}
+
+	switch a.actorType {
+	case common.OrgUserCandidacyActor:
+		for _, role := range a.orgUser.OrgUserRoles {
+			for _, allowed := range actors.orgUserRoles {
+				if role == allowed {
+					return true
+				}
+			}
+		}
+		return false
+	case common.HubUserCandidacyActor:
+		return actors.hubUser
+	case common.SystemCandidacyActor:
+		return actors.system
+	}
+
+	return false
+}
+
+// transitionCandidacy moves the candidacy of the employer to the state to,
+// if the change from its current state is legal for the actor, and records
+// the change in the candidacy_events. Returns db.ErrNoCandidacy if there is
+// no such candidacy and db.ErrIllegalTransition if the change is not legal.
+func (p *PG) transitionCandidacy(
+	ctx context.Context,
+	tx pgx.Tx,
+	employerID uuid.UUID,
+	candidacyID string,
+	to common.CandidacyState,
+	actor candidacyActor,
+	reason *string,
+) error {
+	var from common.CandidacyState
+	err := tx.QueryRow(
+		ctx,
+		`
+SELECT
+    candidacy_state
+FROM
+    candidacies
+WHERE
+    id = $1
+    AND employer_id = $2
+FOR UPDATE
+`,
+		candidacyID,
+		employerID,
+	).Scan(&from)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("candidacy not found", "candidacy_id", candidacyID)
+			return db.ErrNoCandidacy
+		}
+		p.log.Err("failed to get candidacy state", "error", err)
+		return db.ErrInternal
+	}
+
+	change := candidacyStateChange{from: from, to: to}
+	if !actor.mayMake(change) {
+		p.log.Dbg(
+			"illegal candidacy state change",
+			"candidacy_id", candidacyID,
+			"from", from,
+			"to", to,
+			"actor_type", actor.actorType,
+		)
+		return db.ErrIllegalTransition
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE candidacies
+SET candidacy_state = $1
+WHERE id = $2
+`,
+		to,
+		candidacyID,
+	)
+	if err != nil {
+		p.log.Err("failed to update candidacy state", "error", err)
+		return db.ErrInternal
+	}
+
+	return p.insertCandidacyEvent(
+		ctx,
+		tx,
+		employerID,
+		candidacyID,
+		&from,
+		to,
+		actor,
+		reason,
+	)
+}
+
+// insertCandidacyEvent appends a state change to the history of the
+// candidacy. The hooks are run for it later by granger.
+func (p *PG) insertCandidacyEvent(
+	ctx context.Context,
+	tx pgx.Tx,
+	employerID uuid.UUID,
+	candidacyID string,
+	from *common.CandidacyState,
+	to common.CandidacyState,
+	actor candidacyActor,
+	reason *string,
+) error {
+	var orgUserID *uuid.UUID
+	if actor.orgUser != nil {
+		orgUserID = &actor.orgUser.ID
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		`
+INSERT INTO candidacy_events (candidacy_id, employer_id, from_state, to_state, actor_type, org_user_id, hub_user_id, reason)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`,
+		candidacyID,
+		employerID,
+		from,
+		to,
+		actor.actorType,
+		orgUserID,
+		actor.hubUserID,
+		reason,
+	)
+	if err != nil {
+		p.log.Err("failed to insert candidacy event", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// getCandidacyTimeline returns the state changes of the candidacy, oldest
+// first. The names of the org users are left out if withOrgUserNames is
+// false, as the candidates deal with the employer and not its employees.
+func (p *PG) getCandidacyTimeline(
+	ctx context.Context,
+	candidacyID string,
+	withOrgUserNames bool,
+) ([]common.CandidacyEvent, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    ce.from_state,
+    ce.to_state,
+    ce.actor_type,
+    CASE ce.actor_type
+    WHEN 'ORG_USER' THEN
+        CASE WHEN $2 THEN
+            ou.name
+        END
+    WHEN 'HUB_USER' THEN
+        hu.full_name
+    END,
+    ce.reason,
+    ce.created_at
+FROM
+    candidacy_events ce
+    LEFT JOIN org_users ou ON ou.id = ce.org_user_id
+    LEFT JOIN hub_users hu ON hu.id = ce.hub_user_id
+WHERE
+    ce.candidacy_id = $1
+ORDER BY
+    ce.created_at,
+    ce.id
+`,
+		candidacyID,
+		withOrgUserNames,
+	)
+	if err != nil {
+		p.log.Err("failed to query candidacy events", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	timeline, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (common.CandidacyEvent, error) {
+			var event common.CandidacyEvent
+			err := row.Scan(
+				&event.FromState,
+				&event.ToState,
+				&event.ActorType,
+				&event.ActorName,
+				&event.Reason,
+				&event.CreatedAt,
+			)
+			return event, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan candidacy events", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return timeline, nil
+}
@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+func (p *PG) GetEmployerCandidacyUpdates(
+	ctx context.Context,
+	req db.GetCandidacyUpdatesReq,
+) ([]common.CandidacyUpdate, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	var hasAccess bool
+	err := p.pool.QueryRow(
+		ctx,
+		`
+SELECT EXISTS (
+    SELECT 1 FROM candidacies
+    WHERE id = $1 AND employer_id = $2
+)
+`,
+		req.CandidacyID,
+		orgUser.EmployerID,
+	).Scan(&hasAccess)
+	if err != nil {
+		p.log.Err("failed to check candidacy access", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if !hasAccess {
+		p.log.Dbg("candidacy not found", "candidacy_id", req.CandidacyID)
+		return nil, db.ErrNoCandidacy
+	}
+
+	return p.getCandidacyUpdates(ctx, req)
+}
+
+func (p *PG) GetHubCandidacyUpdates(
+	ctx context.Context,
+	req db.GetCandidacyUpdatesReq,
+) ([]common.CandidacyUpdate, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return nil, err
+	}
+
+	var hasAccess bool
+	err = p.pool.QueryRow(
+		ctx,
+		`
+SELECT EXISTS (
+    SELECT 1 FROM candidacies c
+    JOIN applications a ON c.application_id = a.id
+    WHERE c.id = $1 AND a.hub_user_id = $2
+)
+`,
+		req.CandidacyID,
+		hubUserID,
+	).Scan(&hasAccess)
+	if err != nil {
+		p.log.Err("failed to check candidacy access", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if !hasAccess {
+		p.log.Dbg("candidacy not found", "candidacy_id", req.CandidacyID)
+		return nil, db.ErrNoCandidacy
+	}
+
+	return p.getCandidacyUpdates(ctx, req)
+}
+
+// getCandidacyUpdates returns the updates of the candidacy after the cursor,
+// oldest first. The COMMENT_* and INTERVIEW_CHANGED updates carry the
+// comment or the interview as it is now, and not as it was when the update
+// was recorded.
+func (p *PG) getCandidacyUpdates(
+	ctx context.Context,
+	req db.GetCandidacyUpdatesReq,
+) ([]common.CandidacyUpdate, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    u.id,
+    u.candidacy_id,
+    u.update_type,
+    u.comment_id,
+    i.id,
+    i.interview_state,
+    i.start_time,
+    i.end_time,
+    i.candidate_rsvp,
+    u.candidacy_state,
+    u.created_at
+FROM
+    candidacy_updates u
+    LEFT JOIN interviews i ON i.id = u.interview_id
+WHERE
+    u.candidacy_id = $1
+    AND u.id > $2
+ORDER BY
+    u.id
+LIMIT $3
+`,
+		req.CandidacyID,
+		req.After,
+		req.Limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query candidacy updates", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	var commentIDs []uuid.UUID
+	updateCommentIDs := make(map[string]uuid.UUID)
+
+	updates, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (common.CandidacyUpdate, error) {
+			var update common.CandidacyUpdate
+			var updateID int64
+			var commentID *uuid.UUID
+
+			var interviewID *string
+			var interviewState *common.InterviewState
+			var startTime, endTime *time.Time
+			var candidateRSVP *common.RSVPStatus
+
+			err := row.Scan(
+				&updateID,
+				&update.CandidacyID,
+				&update.UpdateType,
+				&commentID,
+				&interviewID,
+				&interviewState,
+				&startTime,
+				&endTime,
+				&candidateRSVP,
+				&update.CandidacyState,
+				&update.CreatedAt,
+			)
+			if err != nil {
+				return update, err
+			}
+
+			update.UpdateID = strconv.FormatInt(updateID, 10)
+
+			if commentID != nil {
+				commentIDs = append(commentIDs, *commentID)
+				updateCommentIDs[update.UpdateID] = *commentID
+			}
+
+			if interviewID != nil {
+				update.Interview = &common.CandidacyInterviewUpdate{
+					InterviewID:    *interviewID,
+					InterviewState: *interviewState,
+					StartTime:      *startTime,
+					EndTime:        *endTime,
+					CandidateRSVP:  *candidateRSVP,
+				}
+			}
+
+			return update, nil
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan candidacy updates", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if len(commentIDs) == 0 {
+		return updates, nil
+	}
+
+	comments, err := p.getCandidacyComments(ctx, req.CandidacyID, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	commentsByID := make(map[string]common.CandidacyComment)
+	for _, comment := range comments {
+		commentsByID[comment.CommentID] = comment
+	}
+
+	for i := range updates {
+		commentID, ok := updateCommentIDs[updates[i].UpdateID]
+		if !ok {
+			continue
+		}
+
+		comment, ok := commentsByID[commentID.String()]
+		if ok {
+			updates[i].Comment = &comment
+		}
+	}
+
+	return updates, nil
+}
+
+// ListenCandidacyUpdates calls notify with the id of the candidacy for each
+// update to any candidacy, as notified by Postgres to all the listeners,
+// until ctx is done or the listening connection fails
+func (p *PG) ListenCandidacyUpdates(
+	ctx context.Context,
+	notify func(candidacyID string),
+) error {
+	poolConn, err := p.pool.Acquire(ctx)
+	if err != nil {
+		p.log.Err("failed to acquire connection", "error", err)
+		return db.ErrInternal
+	}
+	// The connection is taken out of the pool, as it keeps listening
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN candidacy_updates")
+	if err != nil {
+		p.log.Err("failed to listen to candidacy updates", "error", err)
+		return db.ErrInternal
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			p.log.Err("failed to wait for candidacy updates", "error", err)
+			return db.ErrInternal
+		}
+
+		notify(notification.Payload)
+	}
+}
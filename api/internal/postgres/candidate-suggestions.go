@@ -0,0 +1,381 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// GetStaleSuggestionOpenings returns the ACTIVE Openings whose suggested
+// HubUsers are never computed, are older than
+// vetchi.CandidateSuggestionsRefreshAge or are outdated by a change of the
+// JD, the oldest first
+func (p *PG) GetStaleSuggestionOpenings(
+	ctx context.Context,
+	limit int,
+) ([]db.SuggestionOpening, error) {
+	query := `
+SELECT
+	o.employer_id,
+	o.id,
+	o.title,
+	o.jd,
+	o.yoe_min,
+	o.yoe_max,
+	ARRAY(
+		SELECT t.display_name
+		FROM opening_tag_mappings otm
+		JOIN tags t ON t.id = otm.tag_id
+		WHERE otm.employer_id = o.employer_id AND otm.opening_id = o.id
+		ORDER BY t.display_name
+	)
+FROM openings o
+WHERE o.state = $1
+	AND (
+		o.candidate_suggestions_refreshed_at IS NULL
+		OR o.candidate_suggestions_refreshed_at <
+			timezone('UTC', now()) - make_interval(secs => $2)
+	)
+ORDER BY o.candidate_suggestions_refreshed_at NULLS FIRST
+LIMIT $3
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		common.ActiveOpening,
+		vetchi.CandidateSuggestionsRefreshAge.Seconds(),
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query suggestion openings", "error", err)
+		return nil, err
+	}
+
+	openings, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.SuggestionOpening, error) {
+			var opening db.SuggestionOpening
+			err := row.Scan(
+				&opening.EmployerID,
+				&opening.OpeningID,
+				&opening.Title,
+				&opening.JD,
+				&opening.YoeMin,
+				&opening.YoeMax,
+				&opening.Tags,
+			)
+			return opening, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect suggestion openings", "error", err)
+		return nil, err
+	}
+
+	return openings, nil
+}
+
+// GetSuggestionCandidates returns the ACTIVE and discoverable HubUsers, who
+// have not applied to the Opening, that share the country or a tag with the
+// Opening. Those that share the most tags come first, then those in the
+// city and then those in the country.
+func (p *PG) GetSuggestionCandidates(
+	ctx context.Context,
+	opening db.SuggestionOpening,
+	limit int,
+) ([]db.SuggestionCandidate, error) {
+	query := `
+WITH opening_places AS (
+	SELECT l.country_code, l.city_aka
+	FROM opening_locations ol
+	JOIN locations l ON l.id = ol.location_id
+	WHERE ol.employer_id = $1 AND ol.opening_id = $2
+),
+remote AS (
+	SELECT COALESCE(o.remote_country_codes, '{}') AS country_codes
+	FROM openings o
+	WHERE o.employer_id = $1 AND o.id = $2
+)
+SELECT *
+FROM (
+	SELECT` + recommendationProfileColumns + ` AS tags,
+		(
+			SELECT EXTRACT(YEAR FROM age(
+				MAX(COALESCE(wh.end_date, CURRENT_DATE)),
+				MIN(wh.start_date)
+			))::INTEGER
+			FROM work_history wh
+			WHERE wh.hub_user_id = hu.id
+		) AS yoe,
+		(
+			hu.resident_country_code IN (
+				SELECT op.country_code FROM opening_places op
+			)
+			OR hu.resident_country_code = ANY(r.country_codes)
+			OR $3 = ANY(r.country_codes)
+		) AS in_opening_country,
+		(
+			COALESCE(hu.resident_city, '') <> '' AND EXISTS (
+				SELECT 1
+				FROM opening_places op
+				CROSS JOIN unnest(op.city_aka) AS city
+				WHERE op.country_code = hu.resident_country_code
+					AND lower(city) = lower(hu.resident_city)
+			)
+		) AS in_opening_city,
+		hu.created_at AS joined_at
+	FROM hub_users hu
+	CROSS JOIN remote r
+	WHERE hu.state = $4
+		AND hu.discoverable
+		AND NOT EXISTS (
+			SELECT 1
+			FROM applications a
+			WHERE a.hub_user_id = hu.id
+				AND a.employer_id = $1
+				AND a.opening_id = $2
+		)
+) c
+WHERE c.in_opening_country OR c.tags && $5::TEXT[]
+ORDER BY
+	cardinality(ARRAY(
+		SELECT unnest(c.tags) INTERSECT SELECT unnest($5::TEXT[])
+	)) DESC,
+	c.in_opening_city DESC,
+	c.in_opening_country DESC,
+	c.joined_at
+LIMIT $6
+`
+	tags := opening.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		opening.EmployerID,
+		opening.OpeningID,
+		common.GlobalCountryCode,
+		hub.ActiveHubUserState,
+		tags,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query suggestion candidates", "error", err)
+		return nil, err
+	}
+
+	candidates, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.SuggestionCandidate, error) {
+			var candidate db.SuggestionCandidate
+			var joinedAt any
+			dest := append(
+				recommendationProfileDest(&candidate.Profile),
+				&candidate.Yoe,
+				&candidate.InOpeningCountry,
+				&candidate.InOpeningCity,
+				&joinedAt,
+			)
+			err := row.Scan(dest...)
+			return candidate, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect suggestion candidates", "error", err)
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+func (p *PG) SaveCandidateSuggestions(
+	ctx context.Context,
+	req db.SaveCandidateSuggestionsReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("Failed to begin transaction", "error", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(
+		ctx,
+		`
+DELETE FROM opening_candidate_suggestions
+WHERE employer_id = $1 AND opening_id = $2
+`,
+		req.EmployerID,
+		req.OpeningID,
+	)
+	if err != nil {
+		p.log.Err("failed to delete old suggestions", "error", err)
+		return err
+	}
+
+	query := `
+INSERT INTO opening_candidate_suggestions
+	(employer_id, opening_id, hub_user_id, score, reasons, yoe)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+	for _, suggestion := range req.Suggestions {
+		_, err = tx.Exec(
+			ctx,
+			query,
+			req.EmployerID,
+			req.OpeningID,
+			suggestion.HubUserID,
+			suggestion.Score,
+			suggestion.Reasons,
+			suggestion.Yoe,
+		)
+		if err != nil {
+			p.log.Err(
+				"INSERT to opening_candidate_suggestions failed",
+				"error", err,
+			)
+			return err
+		}
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE openings
+SET candidate_suggestions_refreshed_at = timezone('UTC', now())
+WHERE employer_id = $1 AND id = $2
+`,
+		req.EmployerID,
+		req.OpeningID,
+	)
+	if err != nil {
+		p.log.Err("failed to update suggestions refreshed_at", "error", err)
+		return err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetSuggestedCandidates returns the HubUsers suggested for an Opening of
+// the employer, that are still discoverable and have not applied, the best
+// first
+func (p *PG) GetSuggestedCandidates(
+	ctx context.Context,
+	req employer.GetSuggestedCandidatesRequest,
+) ([]employer.SuggestedCandidate, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	var openingExists bool
+	err := p.pool.QueryRow(
+		ctx,
+		`
+SELECT EXISTS (
+	SELECT 1 FROM openings WHERE employer_id = $1 AND id = $2
+)
+`,
+		orgUser.EmployerID,
+		req.OpeningID,
+	).Scan(&openingExists)
+	if err != nil {
+		p.log.Err("failed to check opening", "error", err)
+		return nil, db.ErrInternal
+	}
+	if !openingExists {
+		p.log.Dbg("opening not found", "id", req.OpeningID)
+		return nil, db.ErrNoOpening
+	}
+
+	limit := int64(vetchi.MaxSuggestedCandidates)
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	query := `
+SELECT
+	hu.handle,
+	hu.full_name,
+	hu.short_bio,
+	hu.resident_country_code,
+	hu.resident_city,
+	s.yoe,
+	s.score,
+	s.reasons,
+	s.created_at,
+	i.created_at
+FROM opening_candidate_suggestions s
+JOIN hub_users hu ON hu.id = s.hub_user_id
+LEFT JOIN opening_invitations i
+	ON i.employer_id = s.employer_id
+	AND i.opening_id = s.opening_id
+	AND i.hub_user_id = s.hub_user_id
+WHERE s.employer_id = $1 AND s.opening_id = $2
+	AND hu.state = $3
+	AND hu.discoverable
+	AND NOT EXISTS (
+		SELECT 1
+		FROM applications a
+		WHERE a.hub_user_id = s.hub_user_id
+			AND a.employer_id = s.employer_id
+			AND a.opening_id = s.opening_id
+	)
+ORDER BY s.score DESC, hu.handle
+LIMIT $4
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		orgUser.EmployerID,
+		req.OpeningID,
+		hub.ActiveHubUserState,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query suggested candidates", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	candidates, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (employer.SuggestedCandidate, error) {
+			var candidate employer.SuggestedCandidate
+			err := row.Scan(
+				&candidate.Handle,
+				&candidate.FullName,
+				&candidate.ShortBio,
+				&candidate.ResidentCountryCode,
+				&candidate.ResidentCity,
+				&candidate.Yoe,
+				&candidate.Score,
+				&candidate.Reasons,
+				&candidate.SuggestedAt,
+				&candidate.InvitedAt,
+			)
+			return candidate, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect suggested candidates", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return candidates, nil
+}
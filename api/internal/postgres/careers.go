@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/careers"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+// The locations are aggregated with the field names of db.CareersLocation as
+// the keys, so that they could be scanned without any json tags
+const careersOpeningsQuery = `
+SELECT
+    o.id,
+    o.title,
+    o.jd,
+    o.opening_type,
+    o.yoe_min,
+    o.yoe_max,
+    o.min_education_level,
+    o.remote_country_codes,
+    o.salary_min,
+    o.salary_max,
+    o.salary_currency,
+    o.close_at,
+    o.created_at,
+    COALESCE(
+        (
+            SELECT json_agg(
+                json_build_object(
+                    'Title', l.title,
+                    'CountryCode', l.country_code,
+                    'PostalAddress', l.postal_address,
+                    'PostalCode', l.postal_code
+                )
+                ORDER BY l.title
+            )
+            FROM opening_locations ol
+                JOIN locations l ON l.id = ol.location_id
+            WHERE ol.employer_id = o.employer_id
+                AND ol.opening_id = o.id
+        ),
+        '[]'
+    ) AS locations
+FROM openings o
+WHERE o.employer_id = $1
+    AND o.state = $2
+    AND o.public_listing
+    AND ($3::TEXT IS NULL OR o.id = $3::TEXT)
+ORDER BY o.created_at DESC, o.id
+LIMIT $4
+`
+
+func (p *PG) SetOpeningPublicListing(
+	ctx context.Context,
+	req employer.SetOpeningPublicListingRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	result, err := p.pool.Exec(
+		ctx,
+		`
+UPDATE openings
+SET public_listing = $1,
+    last_updated_at = timezone('UTC', now())
+WHERE id = $2
+    AND employer_id = $3
+`,
+		req.PublicListing,
+		req.OpeningID,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to set opening public listing", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		return db.ErrNoOpening
+	}
+
+	return nil
+}
+
+func (p *PG) GetCareersPage(
+	ctx context.Context,
+	req careers.GetCareersOpeningsRequest,
+) (db.CareersPage, error) {
+	employerID, companyName, err := p.getCareersEmployer(ctx, req.Domain)
+	if err != nil {
+		return db.CareersPage{}, err
+	}
+
+	openings, err := p.getCareersOpenings(
+		ctx,
+		employerID,
+		companyName,
+		nil,
+		vetchi.MaxCareersOpenings,
+	)
+	if err != nil {
+		return db.CareersPage{}, err
+	}
+
+	return db.CareersPage{
+		CompanyName: companyName,
+		Openings:    openings,
+	}, nil
+}
+
+func (p *PG) GetCareersOpening(
+	ctx context.Context,
+	req careers.GetCareersOpeningRequest,
+) (db.CareersOpening, error) {
+	employerID, companyName, err := p.getCareersEmployer(ctx, req.Domain)
+	if err != nil {
+		return db.CareersOpening{}, err
+	}
+
+	openings, err := p.getCareersOpenings(
+		ctx,
+		employerID,
+		companyName,
+		&req.OpeningID,
+		1,
+	)
+	if err != nil {
+		return db.CareersOpening{}, err
+	}
+
+	if len(openings) == 0 {
+		return db.CareersOpening{}, db.ErrNoOpening
+	}
+
+	return openings[0], nil
+}
+
+// getCareersEmployer returns db.ErrNoEmployer unless the domain is a verified
+// domain of an onboarded employer
+func (p *PG) getCareersEmployer(
+	ctx context.Context,
+	domain string,
+) (uuid.UUID, string, error) {
+	var employerID uuid.UUID
+	var companyName string
+	err := p.pool.QueryRow(
+		ctx,
+		`
+SELECT e.id, e.company_name
+FROM domains d
+    JOIN employers e ON e.id = d.employer_id
+WHERE d.domain_name = $1
+    AND d.domain_state = $2
+    AND e.employer_state = $3
+`,
+		domain,
+		db.VerifiedDomainState,
+		db.OnboardedEmployerState,
+	).Scan(&employerID, &companyName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("no careers page for domain", "domain", domain)
+			return uuid.UUID{}, "", db.ErrNoEmployer
+		}
+		p.log.Err("failed to get careers employer", "error", err)
+		return uuid.UUID{}, "", db.ErrInternal
+	}
+
+	return employerID, companyName, nil
+}
+
+func (p *PG) getCareersOpenings(
+	ctx context.Context,
+	employerID uuid.UUID,
+	companyName string,
+	openingID *string,
+	limit int,
+) ([]db.CareersOpening, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		careersOpeningsQuery,
+		employerID,
+		common.ActiveOpening,
+		openingID,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query careers openings", "error", err)
+		return nil, db.ErrInternal
+	}
+	defer rows.Close()
+
+	openings := []db.CareersOpening{}
+	for rows.Next() {
+		var opening db.CareersOpening
+		var minAmount, maxAmount *float64
+		var currency *string
+		err := rows.Scan(
+			&opening.ID,
+			&opening.Title,
+			&opening.JD,
+			&opening.OpeningType,
+			&opening.YoeMin,
+			&opening.YoeMax,
+			&opening.MinEducationLevel,
+			&opening.RemoteCountryCodes,
+			&minAmount,
+			&maxAmount,
+			&currency,
+			&opening.CloseAt,
+			&opening.CreatedAt,
+			&opening.Locations,
+		)
+		if err != nil {
+			p.log.Err("failed to scan careers opening", "error", err)
+			return nil, db.ErrInternal
+		}
+
+		// The salary is disclosed only if the opening has a complete salary
+		if minAmount != nil && maxAmount != nil && currency != nil {
+			opening.Salary = &common.Salary{
+				MinAmount: *minAmount,
+				MaxAmount: *maxAmount,
+				Currency:  common.Currency(*currency),
+			}
+		}
+
+		opening.CompanyName = companyName
+		openings = append(openings, opening)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate careers openings", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return openings, nil
+}
@@ -16,9 +16,15 @@ func (pg *PG) GetPostComments(
 	ctx context.Context,
 	req hub.GetPostCommentsRequest,
 ) ([]hub.PostComment, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		pg.log.Err("failed to get hub user ID", "error", err)
+		return nil, err
+	}
+
 	// Check if post exists
 	var postExists bool
-	err := pg.pool.QueryRow(ctx, `
+	err = pg.pool.QueryRow(ctx, `
 		SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)
 	`, req.PostID).Scan(&postExists)
 	if err != nil {
@@ -39,13 +45,19 @@ func (pg *PG) GetPostComments(
 			pc.content,
 			hu.full_name,
 			hu.handle,
-			pc.created_at
+			pc.created_at,
+			EXISTS(
+				SELECT 1 FROM bookmarks b
+				WHERE b.hub_user_id = $2
+				AND b.bookmark_type = 'POST_COMMENT'
+				AND b.target_id = pc.id
+			) AS is_bookmarked
 		FROM post_comments pc
 		JOIN hub_users hu ON pc.author_id = hu.id
 		WHERE pc.post_id = $1
 	`
-	args := []interface{}{req.PostID}
-	argCount := 1
+	args := []interface{}{req.PostID, hubUserID}
+	argCount := 2
 
 	// Add pagination condition if provided
 	if req.PaginationKey != "" {
@@ -67,10 +79,10 @@ func (pg *PG) GetPostComments(
 		} else {
 			// Valid pagination key - add pagination condition
 			query += ` AND (
-				pc.created_at < $2
+				pc.created_at < $3
 				OR (
-					pc.created_at = $2
-					AND pc.id < $3
+					pc.created_at = $3
+					AND pc.id < $4
 				)
 			)`
 			argCount += 2
@@ -106,6 +118,7 @@ func (pg *PG) GetPostComments(
 			&authorName,
 			&authorHandle,
 			&comment.CreatedAt,
+			&comment.IsBookmarked,
 		)
 		if err != nil {
 			pg.log.Err("failed to scan comment row", "error", err)
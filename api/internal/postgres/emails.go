@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/vetchium/vetchium/api/internal/db"
 )
 
@@ -15,7 +16,8 @@ SELECT
     email_subject,
     email_html_body,
     email_text_body,
-    email_state
+    email_state,
+    COALESCE(email_calendar_event, '')
 FROM
     emails
 WHERE
@@ -41,6 +43,7 @@ LIMIT 10
 			&email.EmailHTMLBody,
 			&email.EmailTextBody,
 			&email.EmailState,
+			&email.EmailCalendarEvent,
 		)
 		if err != nil {
 			return nil, err
@@ -78,3 +81,30 @@ WHERE
 
 	return nil
 }
+
+// insertEmail queues the email for sending as part of the transaction tx
+func (p *PG) insertEmail(ctx context.Context, tx pgx.Tx, email db.Email) error {
+	query := `
+INSERT INTO emails (email_from, email_to, email_cc, email_bcc, email_subject, email_html_body, email_text_body, email_state, email_calendar_event)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))
+`
+	_, err := tx.Exec(
+		ctx,
+		query,
+		email.EmailFrom,
+		email.EmailTo,
+		email.EmailCC,
+		email.EmailBCC,
+		email.EmailSubject,
+		email.EmailHTMLBody,
+		email.EmailTextBody,
+		email.EmailState,
+		email.EmailCalendarEvent,
+	)
+	if err != nil {
+		p.log.Err("failed to insert email", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/employer"
 )
 
 func (pg *PG) ChangeCoolOffPeriod(
@@ -47,3 +48,191 @@ func (pg *PG) GetCoolOffPeriod(ctx context.Context) (int32, error) {
 	`, orgUser.EmployerID).Scan(&period)
 	return period, err
 }
+
+func (pg *PG) ChangeApplicationExpiryPeriod(
+	ctx context.Context,
+	days int32,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		pg.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	// TODO: Audit logs
+	_, err := pg.pool.Exec(ctx, `
+		UPDATE employers
+		SET application_expiry_days = $1
+		WHERE id = $2
+	`, days, orgUser.EmployerID)
+	if err != nil {
+		pg.log.Err("failed to change application expiry period", "error", err)
+		return err
+	}
+
+	pg.log.Dbg("application expiry period changed", "days", days)
+
+	return nil
+}
+
+func (pg *PG) GetApplicationExpiryPeriod(ctx context.Context) (int32, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		pg.log.Err("failed to get orgUser from context")
+		return -1, db.ErrInternal
+	}
+
+	var days int32
+	err := pg.pool.QueryRow(ctx, `
+		SELECT application_expiry_days FROM employers
+		WHERE id = $1
+	`, orgUser.EmployerID).Scan(&days)
+	return days, err
+}
+
+func (pg *PG) ChangeAutoCloseFilledOpenings(
+	ctx context.Context,
+	autoClose bool,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		pg.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	// TODO: Audit logs
+	_, err := pg.pool.Exec(ctx, `
+		UPDATE employers
+		SET auto_close_filled_openings = $1
+		WHERE id = $2
+	`, autoClose, orgUser.EmployerID)
+	if err != nil {
+		pg.log.Err("failed to change auto close filled openings", "error", err)
+		return err
+	}
+
+	pg.log.Dbg("auto close filled openings changed", "autoClose", autoClose)
+
+	return nil
+}
+
+func (pg *PG) GetAutoCloseFilledOpenings(ctx context.Context) (bool, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		pg.log.Err("failed to get orgUser from context")
+		return false, db.ErrInternal
+	}
+
+	var autoClose bool
+	err := pg.pool.QueryRow(ctx, `
+		SELECT auto_close_filled_openings FROM employers
+		WHERE id = $1
+	`, orgUser.EmployerID).Scan(&autoClose)
+	return autoClose, err
+}
+
+func (pg *PG) ChangeMeetingProvider(
+	ctx context.Context,
+	provider employer.MeetingProvider,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		pg.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	// TODO: Audit logs
+	_, err := pg.pool.Exec(ctx, `
+		UPDATE employers
+		SET meeting_provider = $1
+		WHERE id = $2
+	`, provider, orgUser.EmployerID)
+	if err != nil {
+		pg.log.Err("failed to change meeting provider", "error", err)
+		return err
+	}
+
+	pg.log.Dbg("meeting provider changed", "provider", provider)
+
+	return nil
+}
+
+func (pg *PG) GetMeetingProvider(
+	ctx context.Context,
+) (employer.MeetingProvider, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		pg.log.Err("failed to get orgUser from context")
+		return "", db.ErrInternal
+	}
+
+	var provider employer.MeetingProvider
+	err := pg.pool.QueryRow(ctx, `
+		SELECT meeting_provider FROM employers
+		WHERE id = $1
+	`, orgUser.EmployerID).Scan(&provider)
+	return provider, err
+}
+
+func (pg *PG) ChangeMatchScoreWeights(
+	ctx context.Context,
+	weights employer.MatchScoreWeights,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		pg.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	// TODO: Audit logs
+	_, err := pg.pool.Exec(ctx, `
+		UPDATE employers
+		SET match_weight_resume = $1,
+			match_weight_long_bio = $2,
+			match_weight_certifications = $3,
+			match_weight_education = $4,
+			match_weight_verified_work_history = $5
+		WHERE id = $6
+	`,
+		weights.Resume,
+		weights.LongBio,
+		weights.Certifications,
+		weights.Education,
+		weights.VerifiedWorkHistory,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		pg.log.Err("failed to change match score weights", "error", err)
+		return err
+	}
+
+	pg.log.Dbg("match score weights changed", "weights", weights)
+
+	return nil
+}
+
+func (pg *PG) GetMatchScoreWeights(
+	ctx context.Context,
+) (employer.MatchScoreWeights, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		pg.log.Err("failed to get orgUser from context")
+		return employer.MatchScoreWeights{}, db.ErrInternal
+	}
+
+	var weights employer.MatchScoreWeights
+	err := pg.pool.QueryRow(ctx, `
+		SELECT match_weight_resume, match_weight_long_bio,
+			match_weight_certifications, match_weight_education,
+			match_weight_verified_work_history
+		FROM employers
+		WHERE id = $1
+	`, orgUser.EmployerID).Scan(
+		&weights.Resume,
+		&weights.LongBio,
+		&weights.Certifications,
+		&weights.Education,
+		&weights.VerifiedWorkHistory,
+	)
+	return weights, err
+}
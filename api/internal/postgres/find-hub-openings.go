@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/vetchium/vetchium/api/internal/db"
 	"github.com/vetchium/vetchium/api/internal/middleware"
 	"github.com/vetchium/vetchium/typespec/common"
@@ -15,6 +16,89 @@ func (p *PG) FindHubOpenings(
 	ctx context.Context,
 	req *hub.FindHubOpeningsRequest,
 ) ([]hub.HubOpening, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return nil, db.ErrInternal
+	}
+
+	query, args := p.hubOpeningsQuery(`
+	o.id as opening_id_within_company,
+	d.domain_name as company_domain,
+	e.company_name as company_name,
+	o.title as job_title,
+	o.jd as jd,
+	o.pagination_key`,
+		hubUser.ID,
+		req,
+	)
+	argPos := len(args) + 1
+
+	// Add pagination and ordering
+	query += fmt.Sprintf(" AND o.pagination_key > $%d", argPos)
+	args = append(args, req.PaginationKey)
+	argPos++
+
+	// Add GROUP BY
+	query += `
+		GROUP BY
+			o.employer_id,
+			o.id,
+			o.title,
+			o.jd,
+			d.domain_name,
+			e.company_name,
+			o.pagination_key
+		ORDER BY o.pagination_key
+`
+
+	// Add LIMIT
+	query += fmt.Sprintf(" LIMIT $%d", argPos)
+	args = append(args, req.Limit)
+
+	p.log.Dbg("Final hub openings query", "query", query, "args", args)
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		p.log.Err("error querying openings", "err", err)
+		return nil, db.ErrInternal
+	}
+	defer rows.Close()
+
+	openings := []hub.HubOpening{}
+	for rows.Next() {
+		var opening hub.HubOpening
+		err := rows.Scan(
+			&opening.OpeningIDWithinCompany,
+			&opening.CompanyDomain,
+			&opening.CompanyName,
+			&opening.JobTitle,
+			&opening.JD,
+			&opening.PaginationKey,
+		)
+		if err != nil {
+			p.log.Err("error scanning opening row", "err", err)
+			return nil, db.ErrInternal
+		}
+		openings = append(openings, opening)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.log.Err("error iterating opening rows", "err", err)
+		return nil, db.ErrInternal
+	}
+
+	return openings, nil
+}
+
+// hubOpeningsQuery returns the query for the columns of the ACTIVE Openings
+// that the HubUser can apply to and that match the search, with its args.
+// More conditions can be appended to the query, from $len(args)+1.
+func (p *PG) hubOpeningsQuery(
+	columns string,
+	hubUserID uuid.UUID,
+	req *hub.FindHubOpeningsRequest,
+) (string, []interface{}) {
 	query := `
 WITH applicable_openings AS (
 	SELECT
@@ -24,13 +108,7 @@ WITH applicable_openings AS (
 	FROM openings o
 	WHERE o.state = $2
 )
-SELECT
-	o.id as opening_id_within_company,
-	d.domain_name as company_domain,
-	e.company_name as company_name,
-	o.title as job_title,
-	o.jd as jd,
-	o.pagination_key
+SELECT` + columns + `
 FROM openings o
 	JOIN employers e ON o.employer_id = e.id
 	JOIN employer_primary_domains epd ON e.id = epd.employer_id
@@ -50,13 +128,8 @@ FROM openings o
 
 	args := []interface{}{}
 
-	// $1 is for hub_user_id from context
-	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
-	if !ok {
-		p.log.Err("failed to get hubUser from context")
-		return nil, db.ErrInternal
-	}
-	args = append(args, hubUser.ID)
+	// $1 is for hub_user_id
+	args = append(args, hubUserID)
 
 	// $2 is for opening state
 	args = append(args, common.ActiveOpening)
@@ -219,59 +292,5 @@ FROM openings o
 	}
 	p.log.Dbg("with WHERE", "query", query, "args", args, "argPos", argPos)
 
-	// Add pagination and ordering
-	query += fmt.Sprintf(" AND o.pagination_key > $%d", argPos)
-	args = append(args, req.PaginationKey)
-	argPos++
-
-	// Add GROUP BY
-	query += `
-		GROUP BY
-			o.employer_id,
-			o.id,
-			o.title,
-			o.jd,
-			d.domain_name,
-			e.company_name,
-			o.pagination_key
-		ORDER BY o.pagination_key
-`
-
-	// Add LIMIT
-	query += fmt.Sprintf(" LIMIT $%d", argPos)
-	args = append(args, req.Limit)
-
-	p.log.Dbg("Final hub openings query", "query", query, "args", args)
-
-	rows, err := p.pool.Query(ctx, query, args...)
-	if err != nil {
-		p.log.Err("error querying openings", "err", err)
-		return nil, db.ErrInternal
-	}
-	defer rows.Close()
-
-	openings := []hub.HubOpening{}
-	for rows.Next() {
-		var opening hub.HubOpening
-		err := rows.Scan(
-			&opening.OpeningIDWithinCompany,
-			&opening.CompanyDomain,
-			&opening.CompanyName,
-			&opening.JobTitle,
-			&opening.JD,
-			&opening.PaginationKey,
-		)
-		if err != nil {
-			p.log.Err("error scanning opening row", "err", err)
-			return nil, db.ErrInternal
-		}
-		openings = append(openings, opening)
-	}
-
-	if err = rows.Err(); err != nil {
-		p.log.Err("error iterating opening rows", "err", err)
-		return nil, db.ErrInternal
-	}
-
-	return openings, nil
+	return query, args
 }
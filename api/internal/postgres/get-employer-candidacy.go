@@ -21,7 +21,22 @@ func (p *PG) GetEmployerCandidacyInfo(
 	}
 
 	query := `
-SELECT c.id, o.id, o.title, o.jd, c.candidacy_state, hu.full_name, hu.handle
+SELECT c.id, o.id, o.title, o.jd, c.candidacy_state, hu.full_name, hu.handle,
+(
+	SELECT COUNT(*)
+	FROM candidacy_comments cc
+	WHERE cc.candidacy_id = c.id
+	AND cc.retracted_at IS NULL
+	AND cc.org_user_id IS DISTINCT FROM $3
+	AND cc.created_at > COALESCE(
+		(
+			SELECT r.last_read_at
+			FROM candidacy_comment_reads r
+			WHERE r.candidacy_id = c.id AND r.org_user_id = $3
+		),
+		'-infinity'
+	)
+) AS unread_comments_count
 FROM candidacies c
 JOIN openings o ON c.opening_id = o.id
 JOIN applications a ON c.application_id = a.id
@@ -36,6 +51,7 @@ AND o.employer_id = $2
 		query,
 		getCandidacyInfoReq.CandidacyID,
 		orgUser.EmployerID,
+		orgUser.ID,
 	).Scan(
 		&candidacy.CandidacyID,
 		&candidacy.OpeningID,
@@ -44,6 +60,7 @@ AND o.employer_id = $2
 		&candidacy.CandidacyState,
 		&candidacy.ApplicantName,
 		&candidacy.ApplicantHandle,
+		&candidacy.UnreadCommentsCount,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -60,5 +77,14 @@ AND o.employer_id = $2
 		return employer.Candidacy{}, db.ErrInternal
 	}
 
+	candidacy.Timeline, err = p.getCandidacyTimeline(
+		ctx,
+		candidacy.CandidacyID,
+		true,
+	)
+	if err != nil {
+		return employer.Candidacy{}, err
+	}
+
 	return candidacy, nil
 }
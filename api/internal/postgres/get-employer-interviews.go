@@ -55,6 +55,7 @@ func (p *PG) GetEmployerInterviewsByOpening(
 			i.feedback_to_candidate,
 			i.created_at,
 			i.feedback_submitted_at,
+			i.meeting_url,
 			CASE 
 				WHEN fb.id IS NOT NULL THEN
 					json_build_object(
@@ -97,7 +98,8 @@ func (p *PG) GetEmployerInterviewsByOpening(
 			fb.id,
 			fb.name,
 			fb.email,
-			i.feedback_submitted_at
+			i.feedback_submitted_at,
+			i.meeting_url
 		ORDER BY i.start_time ASC, i.id ASC
 		LIMIT $4
 	)
@@ -116,7 +118,8 @@ func (p *PG) GetEmployerInterviewsByOpening(
 		feedback_to_candidate,
 		created_at,
 		feedback_submitted_by,
-		feedback_submitted_at
+		feedback_submitted_at,
+		meeting_url
 	FROM interview_data
 	`
 
@@ -161,6 +164,7 @@ func (p *PG) GetEmployerInterviewsByOpening(
 			&interview.CreatedAt,
 			&feedbackSubmittedBy,
 			&interview.FeedbackSubmittedAt,
+			&interview.MeetingURL,
 		)
 		if err != nil {
 			p.log.Err("failed to scan interview", "error", err)
@@ -211,6 +215,7 @@ func (p *PG) GetEmployerInterviewsByCandidacy(
 			i.feedback_to_candidate,
 			i.created_at,
 			i.feedback_submitted_at,
+			i.meeting_url,
 			CASE 
 				WHEN fb.id IS NOT NULL THEN
 					json_build_object(
@@ -251,7 +256,8 @@ func (p *PG) GetEmployerInterviewsByCandidacy(
 			fb.id,
 			fb.name,
 			fb.email,
-			i.feedback_submitted_at
+			i.feedback_submitted_at,
+			i.meeting_url
 		ORDER BY i.start_time ASC, i.id ASC
 	)
 	SELECT 
@@ -269,7 +275,8 @@ func (p *PG) GetEmployerInterviewsByCandidacy(
 		feedback_to_candidate,
 		created_at,
 		feedback_submitted_by,
-		feedback_submitted_at
+		feedback_submitted_at,
+		meeting_url
 	FROM interview_data
 	`
 
@@ -312,6 +319,7 @@ func (p *PG) GetEmployerInterviewsByCandidacy(
 			&interview.CreatedAt,
 			&feedbackSubmittedBy,
 			&interview.FeedbackSubmittedAt,
+			&interview.MeetingURL,
 		)
 		if err != nil {
 			p.log.Err("failed to scan interview", "error", err)
@@ -28,7 +28,22 @@ SELECT
 	o.id as opening_id,
 	o.title as opening_title,
 	o.jd as opening_description,
-	c.candidacy_state
+	c.candidacy_state,
+	(
+		SELECT COUNT(*)
+		FROM candidacy_comments cc
+		WHERE cc.candidacy_id = c.id
+		AND cc.retracted_at IS NULL
+		AND cc.hub_user_id IS DISTINCT FROM $2
+		AND cc.created_at > COALESCE(
+			(
+				SELECT r.last_read_at
+				FROM candidacy_comment_reads r
+				WHERE r.candidacy_id = c.id AND r.hub_user_id = $2
+			),
+			'-infinity'
+		)
+	) AS unread_comments_count
 FROM candidacies c
 JOIN applications a ON a.id = c.application_id
 JOIN openings o ON o.employer_id = c.employer_id AND o.id = c.opening_id
@@ -52,6 +67,7 @@ WHERE c.id = $1 AND a.hub_user_id = $2
 		&candidacy.OpeningTitle,
 		&candidacy.OpeningDescription,
 		&candidacy.CandidacyState,
+		&candidacy.UnreadCommentsCount,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -60,5 +76,15 @@ WHERE c.id = $1 AND a.hub_user_id = $2
 		p.log.Err("failed to scan candidacy", "error", err)
 		return hub.MyCandidacy{}, db.ErrInternal
 	}
+
+	candidacy.Timeline, err = p.getCandidacyTimeline(
+		ctx,
+		candidacy.CandidacyID,
+		false,
+	)
+	if err != nil {
+		return hub.MyCandidacy{}, err
+	}
+
 	return candidacy, nil
 }
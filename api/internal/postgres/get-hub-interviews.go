@@ -52,6 +52,7 @@ func (p *PG) GetHubInterviewsByCandidacy(
 			i.interview_type,
 			i.description,
 			i.candidate_rsvp,
+			i.meeting_url,
 			COALESCE(
 				jsonb_agg(
 					jsonb_build_object(
@@ -73,7 +74,8 @@ func (p *PG) GetHubInterviewsByCandidacy(
 			i.end_time,
 			i.interview_type,
 			i.description,
-			i.candidate_rsvp
+			i.candidate_rsvp,
+			i.meeting_url
 		ORDER BY i.start_time ASC`
 
 	rows, err := p.pool.Query(ctx, query, req.CandidacyID)
@@ -95,6 +97,7 @@ func (p *PG) GetHubInterviewsByCandidacy(
 			&interview.InterviewType,
 			&interview.Description,
 			&interview.CandidateRSVP,
+			&interview.MeetingURL,
 			&interviewerData,
 		); err != nil {
 			p.log.Err("failed to scan hub interview", "error", err)
@@ -128,5 +131,22 @@ func (p *PG) GetHubInterviewsByCandidacy(
 		return nil, db.ErrInternal
 	}
 
+	interviewIDs := make([]string, 0, len(interviews))
+	for _, interview := range interviews {
+		interviewIDs = append(interviewIDs, interview.InterviewID)
+	}
+
+	changes, err := p.getInterviewChanges(ctx, interviewIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range interviews {
+		interviews[i].Changes = changes[interviews[i].InterviewID]
+		if interviews[i].Changes == nil {
+			interviews[i].Changes = []common.InterviewChange{}
+		}
+	}
+
 	return interviews, nil
 }
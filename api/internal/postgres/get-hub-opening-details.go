@@ -64,7 +64,14 @@ func (p *PG) GetHubOpeningDetails(
 		od.hiring_manager_name,
 		od.hiring_manager_vetchi_handle,
 		od.recruiter_name,
-		can_apply($3::uuid, od.employer_id, od.opening_id_within_company) as is_appliable
+		can_apply($3::uuid, od.employer_id, od.opening_id_within_company) as is_appliable,
+		EXISTS (
+			SELECT 1 FROM bookmarks b
+			WHERE b.hub_user_id = $3
+				AND b.bookmark_type = 'OPENING'
+				AND b.employer_id = od.employer_id
+				AND b.target_id = od.opening_id_within_company
+		) as is_bookmarked
 	FROM opening_details od
 `
 
@@ -106,6 +113,7 @@ func (p *PG) GetHubOpeningDetails(
 		&hiringManagerHandle,
 		&details.RecruiterName,
 		&details.IsAppliable,
+		&details.IsBookmarked,
 	)
 
 	if err != nil {
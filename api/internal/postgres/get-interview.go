@@ -50,7 +50,8 @@ func (p *PG) GetInterview(
 			i.created_at at time zone 'UTC' as created_at,
 			hu.full_name as candidate_name,
 			hu.handle as candidate_handle,
-			i.candidate_rsvp as candidate_rsvp_status
+			i.candidate_rsvp as candidate_rsvp_status,
+			i.meeting_url
 		FROM interviews i
 		LEFT JOIN org_users ou ON i.feedback_submitted_by = ou.id
 		LEFT JOIN candidacies c ON i.candidacy_id = c.id
@@ -83,6 +84,7 @@ func (p *PG) GetInterview(
 		&interview.CandidateName,
 		&interview.CandidateHandle,
 		&interview.CandidateRSVPStatus,
+		&interview.MeetingURL,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -147,5 +149,11 @@ func (p *PG) GetInterview(
 	}
 	interview.Interviewers = interviewers
 
+	changes, err := p.getInterviewChanges(ctx, []string{interviewID})
+	if err != nil {
+		return employer.EmployerInterview{}, err
+	}
+	interview.Changes = changes[interviewID]
+
 	return interview, nil
 }
@@ -0,0 +1,452 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func (p *PG) RecordOfferAcceptance(
+	ctx context.Context,
+	req employer.RecordOfferAcceptanceRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	err = p.fillPosition(
+		ctx,
+		tx,
+		orgUser.EmployerID,
+		req.CandidacyID,
+		orgUserActor(orgUser),
+		req.StartDate,
+		req.BackfillReason,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO candidacy_comments (author_type, org_user_id, comment_text, candidacy_id, employer_id, created_at)
+    VALUES ($1, $2, $3, $4, $5, timezone('UTC', now()))
+`,
+		db.OrgUserAuthorType,
+		orgUser.ID,
+		"Offer accepted by the candidate",
+		req.CandidacyID,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to add comment", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// fillPosition moves an OFFERED candidacy to OFFER_ACCEPTED, accepts its
+// pending offer if any and fills a position of its Opening. The Opening is
+// closed once all its positions are filled, if the employer has opted for it.
+func (p *PG) fillPosition(
+	ctx context.Context,
+	tx pgx.Tx,
+	employerID uuid.UUID,
+	candidacyID string,
+	actor candidacyActor,
+	startDate *string,
+	backfillReason *employer.BackfillReason,
+) error {
+	// Locking the opening serializes the concurrent acceptances of the
+	// candidacies of the same opening, so that positions are not overfilled
+	var openingID string
+	var positions int
+	var openingState common.OpeningState
+	var autoClose bool
+	err := tx.QueryRow(
+		ctx,
+		`
+SELECT o.id, o.positions, o.state, e.auto_close_filled_openings
+FROM candidacies c
+    JOIN openings o ON o.employer_id = c.employer_id AND o.id = c.opening_id
+    JOIN employers e ON e.id = c.employer_id
+WHERE c.id = $1
+    AND c.employer_id = $2
+    AND c.candidacy_state = $3
+FOR UPDATE OF c, o
+`,
+		candidacyID,
+		employerID,
+		common.OfferedCandidacyState,
+	).Scan(&openingID, &positions, &openingState, &autoClose)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("no offered candidacy", "candidacy_id", candidacyID)
+			return db.ErrNoCandidacy
+		}
+		p.log.Err("failed to get candidacy", "error", err)
+		return db.ErrInternal
+	}
+
+	// The hires are the record of the filled positions
+	var filled int
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT COUNT(*)
+FROM opening_hires
+WHERE employer_id = $1
+    AND opening_id = $2
+`,
+		employerID,
+		openingID,
+	).Scan(&filled)
+	if err != nil {
+		p.log.Err("failed to count filled positions", "error", err)
+		return db.ErrInternal
+	}
+
+	if filled >= positions {
+		p.log.Dbg("all positions filled", "opening_id", openingID)
+		return db.ErrAllPositionsFilled
+	}
+
+	err = p.transitionCandidacy(
+		ctx,
+		tx,
+		employerID,
+		candidacyID,
+		common.OfferAcceptedCandidacyState,
+		actor,
+		nil,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrIllegalTransition) {
+			return db.ErrNoCandidacy
+		}
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE candidacy_offers
+SET offer_state = $1,
+    responded_at = timezone('UTC', now())
+WHERE candidacy_id = $2
+    AND offer_state = $3
+`,
+		common.AcceptedOffer,
+		candidacyID,
+		common.PendingOffer,
+	)
+	if err != nil {
+		p.log.Err("failed to accept pending offer", "error", err)
+		return db.ErrInternal
+	}
+
+	err = p.voidOfferSignatures(ctx, tx, candidacyID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO opening_hires (candidacy_id, employer_id, opening_id, start_date, backfill_reason)
+    VALUES ($1, $2, $3, $4::DATE, $5)
+`,
+		candidacyID,
+		employerID,
+		openingID,
+		startDate,
+		backfillReason,
+	)
+	if err != nil {
+		p.log.Err("failed to insert opening hire", "error", err)
+		return db.ErrInternal
+	}
+
+	if autoClose && filled+1 >= positions &&
+		(openingState == common.ActiveOpening ||
+			openingState == common.SuspendedOpening) {
+		_, err = tx.Exec(
+			ctx,
+			`
+UPDATE openings
+SET state = $1,
+    last_updated_at = timezone('UTC', now())
+WHERE employer_id = $2
+    AND id = $3
+`,
+			common.ClosedOpening,
+			employerID,
+			openingID,
+		)
+		if err != nil {
+			p.log.Err("failed to close filled opening", "error", err)
+			return db.ErrInternal
+		}
+		p.log.Dbg("closed filled opening", "opening_id", openingID)
+	}
+
+	return nil
+}
+
+func (p *PG) UpdateOpeningHire(
+	ctx context.Context,
+	req employer.UpdateOpeningHireRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	result, err := p.pool.Exec(
+		ctx,
+		`
+UPDATE opening_hires
+SET start_date = $1::DATE,
+    backfill_reason = $2
+WHERE candidacy_id = $3
+    AND employer_id = $4
+`,
+		req.StartDate,
+		req.BackfillReason,
+		req.CandidacyID,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to update opening hire", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		return db.ErrNoOpeningHire
+	}
+
+	return nil
+}
+
+func (p *PG) GetOpeningHires(
+	ctx context.Context,
+	req employer.GetOpeningHiresRequest,
+) ([]employer.OpeningHire, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	var exists bool
+	err := p.pool.QueryRow(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM openings WHERE id = $1 AND employer_id = $2)`,
+		req.OpeningID,
+		orgUser.EmployerID,
+	).Scan(&exists)
+	if err != nil {
+		p.log.Err("failed to check opening", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if !exists {
+		return nil, db.ErrNoOpening
+	}
+
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    oh.candidacy_id,
+    h.full_name,
+    h.handle,
+    oh.start_date::TEXT,
+    oh.backfill_reason,
+    oh.created_at
+FROM opening_hires oh
+    JOIN candidacies c ON c.id = oh.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+    JOIN hub_users h ON h.id = a.hub_user_id
+WHERE oh.employer_id = $1
+    AND oh.opening_id = $2
+ORDER BY oh.created_at, oh.candidacy_id
+`,
+		orgUser.EmployerID,
+		req.OpeningID,
+	)
+	if err != nil {
+		p.log.Err("failed to query opening hires", "error", err)
+		return nil, db.ErrInternal
+	}
+	defer rows.Close()
+
+	hires := []employer.OpeningHire{}
+	for rows.Next() {
+		var hire employer.OpeningHire
+		err := rows.Scan(
+			&hire.CandidacyID,
+			&hire.CandidateName,
+			&hire.CandidateHandle,
+			&hire.StartDate,
+			&hire.BackfillReason,
+			&hire.HiredAt,
+		)
+		if err != nil {
+			p.log.Err("failed to scan opening hire", "error", err)
+			return nil, db.ErrInternal
+		}
+		hires = append(hires, hire)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate opening hires", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return hires, nil
+}
+
+func (p *PG) GetHeadcountReport(
+	ctx context.Context,
+	req employer.GetHeadcountReportRequest,
+) ([]employer.HeadcountReportRow, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	query := `
+WITH opening_counts AS (
+    SELECT
+        o.employer_id,
+        o.id,
+        o.cost_center_id,
+        o.state,
+        o.positions,
+        (
+            SELECT COUNT(*)
+            FROM opening_hires oh
+            WHERE oh.employer_id = o.employer_id
+                AND oh.opening_id = o.id
+        ) AS filled,
+        (
+            SELECT COUNT(*)
+            FROM candidacies c
+            WHERE c.employer_id = o.employer_id
+                AND c.opening_id = o.id
+                AND c.candidacy_state = $3
+        ) AS in_offer
+    FROM openings o
+    WHERE o.employer_id = $1
+        AND o.state != $4
+),
+located AS (
+    SELECT
+        oc.*,
+        l.title AS location_title,
+        GREATEST(COUNT(ol.location_id) OVER (PARTITION BY oc.id), 1) AS location_count,
+        ROW_NUMBER() OVER (PARTITION BY oc.id ORDER BY l.title, l.id) - 1 AS location_index
+    FROM opening_counts oc
+        LEFT JOIN opening_locations ol ON ol.employer_id = oc.employer_id AND ol.opening_id = oc.id
+        LEFT JOIN locations l ON l.id = ol.location_id
+),
+-- The counts of an opening are split evenly across its locations, the
+-- remainders going to the first locations by title, so that an opening is
+-- counted only once across the locations of its cost center
+split AS (
+    SELECT
+        located.cost_center_id,
+        located.state,
+        located.location_title,
+        located.positions / located.location_count + (located.location_index < located.positions % located.location_count)::INTEGER AS positions,
+        located.filled / located.location_count + (located.location_index < located.filled % located.location_count)::INTEGER AS filled,
+        located.in_offer / located.location_count + (located.location_index < located.in_offer % located.location_count)::INTEGER AS in_offer
+    FROM located
+)
+SELECT
+    cc.cost_center_name,
+    split.location_title,
+    COALESCE(SUM(GREATEST(split.positions - split.filled, 0)) FILTER (WHERE split.state = ANY($5::opening_states[])), 0)::INTEGER AS open_positions,
+    COALESCE(SUM(split.filled), 0)::INTEGER AS filled_positions,
+    COALESCE(SUM(split.in_offer) FILTER (WHERE split.state = ANY($5::opening_states[])), 0)::INTEGER AS in_offer_positions
+FROM split
+    JOIN org_cost_centers cc ON cc.id = split.cost_center_id
+WHERE $2::TEXT IS NULL OR cc.cost_center_name = $2::TEXT
+GROUP BY cc.cost_center_name, split.location_title
+ORDER BY cc.cost_center_name, split.location_title NULLS LAST
+`
+
+	var costCenterName *string
+	if req.CostCenterName != nil {
+		name := string(*req.CostCenterName)
+		costCenterName = &name
+	}
+
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		orgUser.EmployerID,
+		costCenterName,
+		common.OfferedCandidacyState,
+		common.DraftOpening,
+		[]string{
+			string(common.ActiveOpening),
+			string(common.SuspendedOpening),
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to query headcount report", "error", err)
+		return nil, db.ErrInternal
+	}
+	defer rows.Close()
+
+	report := []employer.HeadcountReportRow{}
+	for rows.Next() {
+		var row employer.HeadcountReportRow
+		err := rows.Scan(
+			&row.CostCenterName,
+			&row.LocationTitle,
+			&row.OpenPositions,
+			&row.FilledPositions,
+			&row.InOfferPositions,
+		)
+		if err != nil {
+			p.log.Err("failed to scan headcount report row", "error", err)
+			return nil, db.ErrInternal
+		}
+		report = append(report, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate headcount report", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return report, nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vetchium/vetchium/api/internal/db"
+)
+
+// SetDiscoverability sets whether the HubUser may be suggested to the
+// employers for their Openings. Those who opt out are not suggested any more.
+func (p *PG) SetDiscoverability(ctx context.Context, discoverable bool) error {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return err
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("Failed to begin transaction", "error", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE hub_users SET discoverable = $1 WHERE id = $2`,
+		discoverable,
+		hubUserID,
+	)
+	if err != nil {
+		p.log.Err("failed to update discoverability", "error", err)
+		return err
+	}
+
+	if !discoverable {
+		_, err = tx.Exec(
+			ctx,
+			`DELETE FROM opening_candidate_suggestions WHERE hub_user_id = $1`,
+			hubUserID,
+		)
+		if err != nil {
+			p.log.Err("failed to delete candidate suggestions", "error", err)
+			return err
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *PG) GetDiscoverability(ctx context.Context) (bool, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return false, err
+	}
+
+	var discoverable bool
+	err = p.pool.QueryRow(
+		ctx,
+		`SELECT discoverable FROM hub_users WHERE id = $1`,
+		hubUserID,
+	).Scan(&discoverable)
+	if err != nil {
+		p.log.Err("failed to get discoverability", "error", err)
+		return false, db.ErrInternal
+	}
+
+	return discoverable, nil
+}
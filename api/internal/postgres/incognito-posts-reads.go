@@ -72,6 +72,12 @@ func (pg *PG) GetIncognitoPost(
 				ELSE TRUE
 			END as can_downvote,
 			ip.is_deleted,
+			EXISTS(
+				SELECT 1 FROM bookmarks b
+				WHERE b.hub_user_id = $2
+				AND b.bookmark_type = 'INCOGNITO_POST'
+				AND b.target_id = ip.id
+			) as is_bookmarked,
 			COALESCE(
 				ARRAY_AGG(t.id ORDER BY t.display_name) FILTER (WHERE t.id IS NOT NULL),
 				'{}'::text[]
@@ -105,6 +111,7 @@ func (pg *PG) GetIncognitoPost(
 		&post.CanUpvote,
 		&post.CanDownvote,
 		&post.IsDeleted,
+		&post.IsBookmarked,
 		&tagIDs,
 		&tagNames,
 	)
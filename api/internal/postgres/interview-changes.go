@@ -0,0 +1,519 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+func (p *PG) GetInterviewParticipants(
+	ctx context.Context,
+	interviewID string,
+) (db.InterviewParticipants, error) {
+	query := `
+SELECT
+    i.interview_type,
+    i.interview_state,
+    i.start_time,
+    i.end_time,
+    COALESCE(i.description, ''),
+    i.ical_sequence,
+    COALESCE(i.meeting_provider::text, ''),
+    COALESCE(i.meeting_url, ''),
+    c.id,
+    hu.full_name,
+    hu.email,
+    e.company_name,
+    o.title,
+    ARRAY (
+        SELECT
+            ou.email
+        FROM
+            interview_interviewers ii
+            JOIN org_users ou ON ou.id = ii.interviewer_id
+        WHERE
+            ii.interview_id = i.id
+        ORDER BY
+            ou.email),
+    ARRAY (
+        SELECT
+            ou.email
+        FROM
+            org_users ou
+        WHERE
+            ou.id = o.hiring_manager
+            OR ou.id = o.recruiter
+            OR ou.id IN (
+                SELECT
+                    ow.watcher_id
+                FROM
+                    opening_watchers ow
+                WHERE
+                    ow.employer_id = o.employer_id
+                    AND ow.opening_id = o.id)
+        ORDER BY
+            ou.email)
+FROM
+    interviews i
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+    JOIN hub_users hu ON hu.id = a.hub_user_id
+    JOIN employers e ON e.id = c.employer_id
+    JOIN openings o ON o.employer_id = c.employer_id
+        AND o.id = c.opening_id
+WHERE
+    i.id = $1
+`
+
+	var participants db.InterviewParticipants
+	err := p.pool.QueryRow(ctx, query, interviewID).Scan(
+		&participants.InterviewType,
+		&participants.InterviewState,
+		&participants.StartTime,
+		&participants.EndTime,
+		&participants.Description,
+		&participants.ICalSequence,
+		&participants.MeetingProvider,
+		&participants.MeetingURL,
+		&participants.CandidacyID,
+		&participants.CandidateName,
+		&participants.CandidateEmail,
+		&participants.CompanyName,
+		&participants.OpeningTitle,
+		&participants.InterviewerEmails,
+		&participants.WatcherEmails,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("interview not found", "interview_id", interviewID)
+			return db.InterviewParticipants{}, db.ErrNoInterview
+		}
+		p.log.Err("failed to get interview participants", "error", err)
+		return db.InterviewParticipants{}, db.ErrInternal
+	}
+
+	return participants, nil
+}
+
+func (p *PG) RescheduleInterview(
+	ctx context.Context,
+	req db.RescheduleInterviewReq,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	startTime, endTime, err := p.lockScheduledInterview(
+		ctx,
+		tx,
+		req.InterviewID,
+		orgUser,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE interviews
+SET start_time = $1,
+    end_time = $2,
+    candidate_rsvp = $3,
+    ical_sequence = ical_sequence + 1,
+    meeting_url = NULLIF($5, '')
+WHERE id = $4
+`,
+		req.StartTime,
+		req.EndTime,
+		common.NotSetRSVP,
+		req.InterviewID,
+		req.RoomURL,
+	)
+	if err != nil {
+		p.log.Err("failed to reschedule interview", "error", err)
+		return db.ErrInternal
+	}
+
+	// The interviewers have to confirm their availability for the new slot
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE interview_interviewers
+SET rsvp_status = $1
+WHERE interview_id = $2
+`,
+		common.NotSetRSVP,
+		req.InterviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to reset interviewer rsvps", "error", err)
+		return db.ErrInternal
+	}
+
+	// The deadline of a take-home moves with the interview and so the
+	// candidate has to be reminded of the new deadline
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE take_home_assignments
+SET reminder_sent_at = NULL
+WHERE interview_id = $1
+`,
+		req.InterviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to reset take-home reminder", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO interview_changes (interview_id, employer_id, change_type, previous_start_time, previous_end_time, new_start_time, new_end_time, reason, author_type, org_user_id)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`,
+		req.InterviewID,
+		orgUser.EmployerID,
+		common.RescheduledInterviewChange,
+		startTime,
+		endTime,
+		req.StartTime,
+		req.EndTime,
+		req.Reason,
+		db.OrgUserAuthorType,
+		orgUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to insert interview change", "error", err)
+		return db.ErrInternal
+	}
+
+	for _, email := range req.Emails {
+		err = p.insertEmail(ctx, tx, email)
+		if err != nil {
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) CancelInterview(
+	ctx context.Context,
+	req db.CancelInterviewReq,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	startTime, endTime, err := p.lockScheduledInterview(
+		ctx,
+		tx,
+		req.InterviewID,
+		orgUser,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE interviews
+SET interview_state = $1,
+    ical_sequence = ical_sequence + 1
+WHERE id = $2
+`,
+		common.CancelledInterviewState,
+		req.InterviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to cancel interview", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO interview_changes (interview_id, employer_id, change_type, previous_start_time, previous_end_time, reason, author_type, org_user_id)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`,
+		req.InterviewID,
+		orgUser.EmployerID,
+		common.CancelledInterviewChange,
+		startTime,
+		endTime,
+		req.Reason,
+		db.OrgUserAuthorType,
+		orgUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to insert interview change", "error", err)
+		return db.ErrInternal
+	}
+
+	for _, email := range req.Emails {
+		err = p.insertEmail(ctx, tx, email)
+		if err != nil {
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// lockScheduledInterview locks the interview of the employer of the orgUser
+// and returns its current slot, if the interview is still SCHEDULED
+func (p *PG) lockScheduledInterview(
+	ctx context.Context,
+	tx pgx.Tx,
+	interviewID string,
+	orgUser db.OrgUserTO,
+) (time.Time, time.Time, error) {
+	var startTime, endTime time.Time
+	var state common.InterviewState
+	err := tx.QueryRow(
+		ctx,
+		`
+SELECT
+    start_time,
+    end_time,
+    interview_state
+FROM
+    interviews
+WHERE
+    id = $1
+    AND employer_id = $2
+FOR UPDATE
+`,
+		interviewID,
+		orgUser.EmployerID,
+	).Scan(&startTime, &endTime, &state)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("interview not found", "interview_id", interviewID)
+			return time.Time{}, time.Time{}, db.ErrNoInterview
+		}
+		p.log.Err("failed to get interview", "error", err)
+		return time.Time{}, time.Time{}, db.ErrInternal
+	}
+
+	if state != common.ScheduledInterviewState {
+		p.log.Dbg("interview not scheduled", "state", state)
+		return time.Time{}, time.Time{}, db.ErrInvalidInterviewState
+	}
+
+	return startTime, endTime, nil
+}
+
+func (p *PG) RequestInterviewReschedule(
+	ctx context.Context,
+	req db.RequestInterviewRescheduleReq,
+) error {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var startTime, endTime time.Time
+	var state common.InterviewState
+	var employerID uuid.UUID
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    i.start_time,
+    i.end_time,
+    i.interview_state,
+    i.employer_id
+FROM
+    interviews i
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+WHERE
+    i.id = $1
+    AND a.hub_user_id = $2
+FOR UPDATE OF i
+`,
+		req.InterviewID,
+		hubUser.ID,
+	).Scan(&startTime, &endTime, &state, &employerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("interview not found", "interview_id", req.InterviewID)
+			return db.ErrNoInterview
+		}
+		p.log.Err("failed to get interview", "error", err)
+		return db.ErrInternal
+	}
+
+	if state != common.ScheduledInterviewState {
+		p.log.Dbg("interview not scheduled", "state", state)
+		return db.ErrInvalidInterviewState
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE interviews SET candidate_rsvp = $1 WHERE id = $2`,
+		common.NoRSVP,
+		req.InterviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to update candidate rsvp", "error", err)
+		return db.ErrInternal
+	}
+
+	proposedSlots, err := json.Marshal(req.ProposedSlots)
+	if err != nil {
+		p.log.Err("failed to marshal proposed slots", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO interview_changes (interview_id, employer_id, change_type, previous_start_time, previous_end_time, proposed_slots, reason, author_type, hub_user_id)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`,
+		req.InterviewID,
+		employerID,
+		common.RescheduleRequestedInterviewChange,
+		startTime,
+		endTime,
+		proposedSlots,
+		req.Reason,
+		db.HubUserAuthorType,
+		hubUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to insert interview change", "error", err)
+		return db.ErrInternal
+	}
+
+	err = p.insertEmail(ctx, tx, req.Email)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// getInterviewChanges returns the changes made to each of the interviews,
+// oldest first
+func (p *PG) getInterviewChanges(
+	ctx context.Context,
+	interviewIDs []string,
+) (map[string][]common.InterviewChange, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    ic.interview_id,
+    ic.change_type,
+    ic.previous_start_time,
+    ic.previous_end_time,
+    ic.new_start_time,
+    ic.new_end_time,
+    ic.proposed_slots,
+    ic.reason,
+    COALESCE(ou.name, hu.full_name),
+    ic.created_at
+FROM
+    interview_changes ic
+    LEFT JOIN org_users ou ON ou.id = ic.org_user_id
+    LEFT JOIN hub_users hu ON hu.id = ic.hub_user_id
+WHERE
+    ic.interview_id = ANY ($1)
+ORDER BY
+    ic.created_at
+`,
+		interviewIDs,
+	)
+	if err != nil {
+		p.log.Err("failed to query interview changes", "error", err)
+		return nil, db.ErrInternal
+	}
+	defer rows.Close()
+
+	changes := make(map[string][]common.InterviewChange)
+	for rows.Next() {
+		var interviewID string
+		var change common.InterviewChange
+		err = rows.Scan(
+			&interviewID,
+			&change.ChangeType,
+			&change.PreviousStartTime,
+			&change.PreviousEndTime,
+			&change.NewStartTime,
+			&change.NewEndTime,
+			&change.ProposedSlots,
+			&change.Reason,
+			&change.ChangedBy,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			p.log.Err("failed to scan interview change", "error", err)
+			return nil, db.ErrInternal
+		}
+		changes[interviewID] = append(changes[interviewID], change)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate interview changes", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,747 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// Values of the scheduling_request_states enum
+const (
+	openSchedulingRequestState      = "OPEN"
+	bookedSchedulingRequestState    = "BOOKED"
+	cancelledSchedulingRequestState = "CANCELLED"
+)
+
+func (p *PG) SetOrgUserAvailability(
+	ctx context.Context,
+	availability employer.OrgUserAvailability,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	if availability.Windows == nil {
+		availability.Windows = []employer.AvailabilityWindow{}
+	}
+	windows, err := json.Marshal(availability.Windows)
+	if err != nil {
+		p.log.Err("failed to marshal windows", "error", err)
+		return db.ErrInternal
+	}
+
+	query := `
+INSERT INTO org_user_availability (org_user_id, employer_id, time_zone, windows)
+    VALUES ($1, $2, $3, $4)
+ON CONFLICT (org_user_id)
+    DO UPDATE SET
+        time_zone = EXCLUDED.time_zone,
+        windows = EXCLUDED.windows,
+        updated_at = timezone('UTC', now())
+`
+	_, err = p.pool.Exec(
+		ctx,
+		query,
+		orgUser.ID,
+		orgUser.EmployerID,
+		availability.TimeZone,
+		windows,
+	)
+	if err != nil {
+		p.log.Err("failed to set availability", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetOrgUserAvailability(
+	ctx context.Context,
+) (employer.OrgUserAvailability, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return employer.OrgUserAvailability{}, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    time_zone,
+    windows
+FROM
+    org_user_availability
+WHERE
+    org_user_id = $1
+`
+	var availability employer.OrgUserAvailability
+	var windows []byte
+	err := p.pool.QueryRow(ctx, query, orgUser.ID).
+		Scan(&availability.TimeZone, &windows)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("availability not found", "org_user_id", orgUser.ID)
+			return employer.OrgUserAvailability{}, db.ErrNoAvailability
+		}
+		p.log.Err("failed to get availability", "error", err)
+		return employer.OrgUserAvailability{}, db.ErrInternal
+	}
+
+	err = json.Unmarshal(windows, &availability.Windows)
+	if err != nil {
+		p.log.Err("failed to unmarshal windows", "error", err)
+		return employer.OrgUserAvailability{}, db.ErrInternal
+	}
+
+	return availability, nil
+}
+
+func (p *PG) CreateSchedulingRequest(
+	ctx context.Context,
+	req db.CreateSchedulingRequestReq,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var candidacyState common.CandidacyState
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    candidacy_state
+FROM
+    candidacies
+WHERE
+    id = $1
+    AND employer_id = $2
+`,
+		req.CandidacyID,
+		orgUser.EmployerID,
+	).Scan(&candidacyState)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("candidacy not found", "candidacy_id", req.CandidacyID)
+			return db.ErrNoCandidacy
+		}
+		p.log.Err("failed to get candidacy", "error", err)
+		return db.ErrInternal
+	}
+
+	if candidacyState != common.InterviewingCandidacyState {
+		p.log.Dbg("candidacy not interviewing", "state", candidacyState)
+		return db.ErrInvalidCandidacyState
+	}
+
+	interviewersQuery := `
+SELECT
+    ou.id,
+    oua.org_user_id IS NOT NULL
+FROM
+    org_users ou
+    LEFT JOIN org_user_availability oua ON oua.org_user_id = ou.id
+WHERE
+    ou.email = ANY ($1::text[])
+    AND ou.employer_id = $2
+    AND ou.org_user_state = ANY ($3::org_user_states[])
+`
+	rows, err := tx.Query(
+		ctx,
+		interviewersQuery,
+		req.InterviewerEmails,
+		orgUser.EmployerID,
+		[]string{
+			string(employer.ActiveOrgUserState),
+			string(employer.AddedOrgUserState),
+			string(employer.ReplicatedOrgUserState),
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to query interviewers", "error", err)
+		return db.ErrInternal
+	}
+
+	var interviewerIDs []uuid.UUID
+	allAvailable := true
+	for rows.Next() {
+		var interviewerID uuid.UUID
+		var hasAvailability bool
+		err := rows.Scan(&interviewerID, &hasAvailability)
+		if err != nil {
+			rows.Close()
+			p.log.Err("failed to scan interviewer", "error", err)
+			return db.ErrInternal
+		}
+		interviewerIDs = append(interviewerIDs, interviewerID)
+		allAvailable = allAvailable && hasAvailability
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate interviewers", "error", err)
+		return db.ErrInternal
+	}
+
+	if len(interviewerIDs) != len(req.InterviewerEmails) {
+		p.log.Dbg("some interviewers are not active org users")
+		return db.ErrNoOrgUser
+	}
+
+	if !allAvailable {
+		p.log.Dbg("some interviewers have not set their availability")
+		return db.ErrNoAvailability
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO interview_scheduling_requests (id, candidacy_id, employer_id, interview_type, duration_minutes, description, window_start, window_end, created_by, meeting_provider, meeting_url)
+    VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, NULLIF($10, '')::meeting_providers, NULLIF($11, ''))
+`,
+		req.SchedulingRequestID,
+		req.CandidacyID,
+		orgUser.EmployerID,
+		req.InterviewType,
+		req.DurationMinutes,
+		req.Description,
+		req.WindowStart,
+		req.WindowEnd,
+		orgUser.ID,
+		req.MeetingProvider,
+		req.MeetingURL,
+	)
+	if err != nil {
+		p.log.Err("failed to insert scheduling request", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO scheduling_request_interviewers (scheduling_request_id, interviewer_id, employer_id)
+SELECT
+    $1,
+    interviewer_id,
+    $3
+FROM
+    unnest($2::uuid[]) AS interviewer_id
+`,
+		req.SchedulingRequestID,
+		interviewerIDs,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to insert scheduling interviewers", "error", err)
+		return db.ErrInternal
+	}
+
+	err = p.insertEmail(ctx, tx, req.CandidateEmail)
+	if err != nil {
+		p.log.Err("failed to insert candidate email", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) CancelSchedulingRequest(
+	ctx context.Context,
+	req employer.CancelSchedulingRequestRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var state string
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    scheduling_request_state
+FROM
+    interview_scheduling_requests
+WHERE
+    id = $1
+    AND employer_id = $2
+FOR UPDATE
+`,
+		req.SchedulingRequestID,
+		orgUser.EmployerID,
+	).Scan(&state)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("scheduling request not found", "req", req)
+			return db.ErrNoSchedulingRequest
+		}
+		p.log.Err("failed to get scheduling request", "error", err)
+		return db.ErrInternal
+	}
+
+	if state != openSchedulingRequestState {
+		p.log.Dbg("scheduling request not open", "state", state)
+		return db.ErrInvalidSchedulingRequestState
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE
+    interview_scheduling_requests
+SET
+    scheduling_request_state = $2
+WHERE
+    id = $1
+`,
+		req.SchedulingRequestID,
+		cancelledSchedulingRequestState,
+	)
+	if err != nil {
+		p.log.Err("failed to cancel scheduling request", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM interview_slot_holds WHERE scheduling_request_id = $1",
+		req.SchedulingRequestID,
+	)
+	if err != nil {
+		p.log.Err("failed to delete slot hold", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetInterviewSlots(
+	ctx context.Context,
+	req hub.GetInterviewSlotsRequest,
+) (hub.InterviewSlots, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return hub.InterviewSlots{}, db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return hub.InterviewSlots{}, db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	sr, err := p.getSchedulingRequest(
+		ctx,
+		tx,
+		req.SchedulingRequestID,
+		hubUser.ID,
+		false,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("scheduling request not found", "req", req)
+			return hub.InterviewSlots{}, db.ErrNoSchedulingRequest
+		}
+		p.log.Err("failed to get scheduling request", "error", err)
+		return hub.InterviewSlots{}, db.ErrInternal
+	}
+
+	slots := hub.InterviewSlots{
+		SchedulingRequestID: sr.id,
+		CandidacyID:         sr.candidacyID,
+		CompanyName:         sr.companyName,
+		OpeningTitle:        sr.openingTitle,
+		InterviewType:       sr.interviewType,
+		DurationMinutes:     sr.durationMinutes,
+		Description:         sr.description,
+		Slots:               []common.InterviewSlot{},
+	}
+
+	if sr.state != openSchedulingRequestState ||
+		sr.candidacyState != common.InterviewingCandidacyState {
+		return slots, nil
+	}
+
+	slots.Slots, err = p.getFreeSlots(ctx, tx, sr)
+	if err != nil {
+		return hub.InterviewSlots{}, db.ErrInternal
+	}
+
+	var heldSlot common.InterviewSlot
+	var holdExpiresAt time.Time
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    start_time,
+    end_time,
+    expires_at
+FROM
+    interview_slot_holds
+WHERE
+    scheduling_request_id = $1
+    AND expires_at > timezone('UTC', now())
+`,
+		sr.id,
+	).Scan(&heldSlot.StartTime, &heldSlot.EndTime, &holdExpiresAt)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			p.log.Err("failed to get slot hold", "error", err)
+			return hub.InterviewSlots{}, db.ErrInternal
+		}
+	} else {
+		slots.HeldSlot = &heldSlot
+		slots.HoldExpiresAt = &holdExpiresAt
+	}
+
+	return slots, nil
+}
+
+// lockFreeSlot locks the scheduling request and all of its participants and
+// verifies that the slot starting at startTime is still free. The locks on
+// the participants serialize the holds and the bookings of all the
+// scheduling requests that share an interviewer or the candidate, so that
+// the same time is never given out twice.
+func (p *PG) lockFreeSlot(
+	ctx context.Context,
+	tx pgx.Tx,
+	schedulingRequestID string,
+	startTime time.Time,
+) (schedulingRequest, common.InterviewSlot, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return schedulingRequest{}, common.InterviewSlot{}, db.ErrInternal
+	}
+
+	sr, err := p.getSchedulingRequest(
+		ctx,
+		tx,
+		schedulingRequestID,
+		hubUser.ID,
+		true,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("scheduling request not found", "id", schedulingRequestID)
+			return schedulingRequest{}, common.InterviewSlot{},
+				db.ErrNoSchedulingRequest
+		}
+		p.log.Err("failed to get scheduling request", "error", err)
+		return schedulingRequest{}, common.InterviewSlot{}, db.ErrInternal
+	}
+
+	if sr.state != openSchedulingRequestState ||
+		sr.candidacyState != common.InterviewingCandidacyState {
+		p.log.Dbg("scheduling request not open", "state", sr.state,
+			"candidacy_state", sr.candidacyState)
+		return schedulingRequest{}, common.InterviewSlot{},
+			db.ErrInvalidSchedulingRequestState
+	}
+
+	// Locked in a fixed order to avoid deadlocks
+	_, err = tx.Exec(
+		ctx,
+		`
+SELECT
+    id
+FROM
+    org_users
+WHERE
+    id = ANY ($1::uuid[])
+ORDER BY
+    id
+FOR NO KEY UPDATE
+`,
+		sr.interviewerIDs,
+	)
+	if err != nil {
+		p.log.Err("failed to lock interviewers", "error", err)
+		return schedulingRequest{}, common.InterviewSlot{}, db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"SELECT id FROM hub_users WHERE id = $1 FOR NO KEY UPDATE",
+		sr.hubUserID,
+	)
+	if err != nil {
+		p.log.Err("failed to lock candidate", "error", err)
+		return schedulingRequest{}, common.InterviewSlot{}, db.ErrInternal
+	}
+
+	slots, err := p.getFreeSlots(ctx, tx, sr)
+	if err != nil {
+		return schedulingRequest{}, common.InterviewSlot{}, db.ErrInternal
+	}
+
+	for _, slot := range slots {
+		if slot.StartTime.Equal(startTime) {
+			return sr, slot, nil
+		}
+	}
+
+	p.log.Dbg("slot not free", "start_time", startTime)
+	return schedulingRequest{}, common.InterviewSlot{}, db.ErrSlotUnavailable
+}
+
+func (p *PG) HoldInterviewSlot(
+	ctx context.Context,
+	req hub.HoldInterviewSlotRequest,
+) (time.Time, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return time.Time{}, db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	sr, slot, err := p.lockFreeSlot(
+		ctx,
+		tx,
+		req.SchedulingRequestID,
+		req.StartTime,
+	)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var expiresAt time.Time
+	err = tx.QueryRow(
+		ctx,
+		`
+INSERT INTO interview_slot_holds (scheduling_request_id, start_time, end_time, expires_at)
+    VALUES ($1, $2, $3, timezone('UTC', now()) + $4 * interval '1 second')
+ON CONFLICT (scheduling_request_id)
+    DO UPDATE SET
+        start_time = EXCLUDED.start_time,
+        end_time = EXCLUDED.end_time,
+        expires_at = EXCLUDED.expires_at
+    RETURNING
+        expires_at
+`,
+		sr.id,
+		slot.StartTime,
+		slot.EndTime,
+		int(vetchi.InterviewSlotHoldTTL.Seconds()),
+	).Scan(&expiresAt)
+	if err != nil {
+		p.log.Err("failed to hold slot", "error", err)
+		return time.Time{}, db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return time.Time{}, db.ErrInternal
+	}
+
+	return expiresAt, nil
+}
+
+func (p *PG) GetSchedulingRequestParticipants(
+	ctx context.Context,
+	schedulingRequestID string,
+) (db.SchedulingRequestParticipants, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.SchedulingRequestParticipants{}, db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.SchedulingRequestParticipants{}, db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	sr, err := p.getSchedulingRequest(
+		ctx,
+		tx,
+		schedulingRequestID,
+		hubUser.ID,
+		false,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("scheduling request not found", "id", schedulingRequestID)
+			return db.SchedulingRequestParticipants{}, db.ErrNoSchedulingRequest
+		}
+		p.log.Err("failed to get scheduling request", "error", err)
+		return db.SchedulingRequestParticipants{}, db.ErrInternal
+	}
+
+	return db.SchedulingRequestParticipants{
+		InterviewParticipants: db.InterviewParticipants{
+			InterviewType:     sr.interviewType,
+			InterviewState:    common.ScheduledInterviewState,
+			Description:       sr.description,
+			CandidacyID:       sr.candidacyID,
+			CandidateName:     sr.candidateName,
+			CandidateEmail:    sr.candidateEmail,
+			CompanyName:       sr.companyName,
+			OpeningTitle:      sr.openingTitle,
+			InterviewerEmails: sr.interviewerEmails,
+			MeetingProvider:   sr.meetingProvider,
+			MeetingURL:        sr.meetingURL,
+		},
+		DurationMinutes: sr.durationMinutes,
+	}, nil
+}
+
+func (p *PG) BookInterviewSlot(
+	ctx context.Context,
+	req db.BookInterviewSlotReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	sr, slot, err := p.lockFreeSlot(
+		ctx,
+		tx,
+		req.SchedulingRequestID,
+		req.StartTime,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The candidate picked the slot and so has already accepted it
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO interviews (id, candidacy_id, interview_type, interview_state, start_time, end_time, description, created_by, employer_id, candidate_rsvp, meeting_provider, meeting_url)
+    VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, NULLIF($11, '')::meeting_providers, NULLIF($12, ''))
+`,
+		req.InterviewID,
+		sr.candidacyID,
+		sr.interviewType,
+		common.ScheduledInterviewState,
+		slot.StartTime,
+		slot.EndTime,
+		sr.description,
+		sr.createdBy,
+		sr.employerID,
+		common.YesRSVP,
+		sr.meetingProvider,
+		req.RoomURL,
+	)
+	if err != nil {
+		p.log.Err("failed to insert interview", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO interview_interviewers (interview_id, interviewer_id, employer_id, rsvp_status)
+SELECT
+    $1,
+    sri.interviewer_id,
+    sri.employer_id,
+    $3
+FROM
+    scheduling_request_interviewers sri
+WHERE
+    sri.scheduling_request_id = $2
+`,
+		req.InterviewID,
+		sr.id,
+		common.NotSetRSVP,
+	)
+	if err != nil {
+		p.log.Err("failed to insert interviewers", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE
+    interview_scheduling_requests
+SET
+    scheduling_request_state = $2,
+    interview_id = $3
+WHERE
+    id = $1
+`,
+		sr.id,
+		bookedSchedulingRequestState,
+		req.InterviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to book scheduling request", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM interview_slot_holds WHERE scheduling_request_id = $1",
+		sr.id,
+	)
+	if err != nil {
+		p.log.Err("failed to delete slot hold", "error", err)
+		return db.ErrInternal
+	}
+
+	for _, email := range req.Emails {
+		err = p.insertEmail(ctx, tx, email)
+		if err != nil {
+			p.log.Err("failed to insert email", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
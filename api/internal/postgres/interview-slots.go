@@ -0,0 +1,522 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+// timeRange is the half open interval [start, end)
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// schedulingRequest is the part of an interview scheduling request that is
+// needed to compute the free slots
+type schedulingRequest struct {
+	id              string
+	candidacyID     string
+	employerID      uuid.UUID
+	hubUserID       uuid.UUID
+	interviewType   common.InterviewType
+	description     string
+	durationMinutes int
+	windowStart     time.Time
+	windowEnd       time.Time
+	state           string
+	candidacyState  common.CandidacyState
+	createdBy       uuid.UUID
+	companyName     string
+	openingTitle    string
+	candidateName   string
+	candidateEmail  string
+	meetingProvider employer.MeetingProvider
+	meetingURL      string
+
+	// The interviewerIDs and the interviewerEmails are in the same order
+	interviewerIDs    []uuid.UUID
+	interviewerEmails []string
+}
+
+// timeZoneLocation returns the fixed offset location of a TimeZone like
+// "IST India Standard Time GMT+0530". The daylight saving variants are
+// separate TimeZones and so the offset never changes.
+func timeZoneLocation(tz string) (*time.Location, error) {
+	i := strings.LastIndex(tz, "GMT")
+	if i < 0 {
+		return nil, fmt.Errorf("no GMT offset in timezone %q", tz)
+	}
+
+	offset := tz[i+len("GMT"):]
+	if len(offset) != 5 || (offset[0] != '+' && offset[0] != '-') {
+		return nil, fmt.Errorf("invalid GMT offset in timezone %q", tz)
+	}
+	hours, err := strconv.Atoi(offset[1:3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid GMT offset in timezone %q", tz)
+	}
+	minutes, err := strconv.Atoi(offset[3:5])
+	if err != nil {
+		return nil, fmt.Errorf("invalid GMT offset in timezone %q", tz)
+	}
+
+	seconds := hours*3600 + minutes*60
+	if offset[0] == '-' {
+		seconds = -seconds
+	}
+	return time.FixedZone(tz, seconds), nil
+}
+
+// clockDuration converts a "15:04" clock time to the duration since midnight
+func clockDuration(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute, nil
+}
+
+// availableRanges expands the weekly windows, which are in the local time of
+// loc, to the absolute time ranges that fall within [from, to)
+func availableRanges(
+	loc *time.Location,
+	windows []employer.AvailabilityWindow,
+	from time.Time,
+	to time.Time,
+) ([]timeRange, error) {
+	var ranges []timeRange
+
+	// Begin a day early, as the local date could be behind the UTC date
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).
+		AddDate(0, 0, -1)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, window := range windows {
+			if time.Weekday(window.DayOfWeek) != day.Weekday() {
+				continue
+			}
+
+			start, err := clockDuration(window.StartTime)
+			if err != nil {
+				return nil, err
+			}
+			end, err := clockDuration(window.EndTime)
+			if err != nil {
+				return nil, err
+			}
+
+			r := timeRange{start: day.Add(start), end: day.Add(end)}
+			if r.start.Before(from) {
+				r.start = from
+			}
+			if r.end.After(to) {
+				r.end = to
+			}
+			if r.end.After(r.start) {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+
+	return mergeRanges(ranges), nil
+}
+
+// mergeRanges sorts the ranges and merges the overlapping ones
+func mergeRanges(ranges []timeRange) []timeRange {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Before(ranges[j].start)
+	})
+
+	var merged []timeRange
+	for _, r := range ranges {
+		last := len(merged) - 1
+		if last >= 0 && !r.start.After(merged[last].end) {
+			if r.end.After(merged[last].end) {
+				merged[last].end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// intersectRanges returns the ranges common to both a and b, which should be
+// sorted and merged
+func intersectRanges(a, b []timeRange) []timeRange {
+	var overlap []timeRange
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start := a[i].start
+		if b[j].start.After(start) {
+			start = b[j].start
+		}
+		end := a[i].end
+		if b[j].end.Before(end) {
+			end = b[j].end
+		}
+		if end.After(start) {
+			overlap = append(overlap, timeRange{start: start, end: end})
+		}
+
+		if a[i].end.Before(b[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return overlap
+}
+
+// subtractRanges removes the busy ranges from the free ranges, which should
+// be sorted and merged
+func subtractRanges(free, busy []timeRange) []timeRange {
+	busy = mergeRanges(busy)
+
+	var remaining []timeRange
+	for _, r := range free {
+		for _, b := range busy {
+			if !b.end.After(r.start) {
+				continue
+			}
+			if !b.start.Before(r.end) {
+				break
+			}
+			if b.start.After(r.start) {
+				remaining = append(
+					remaining,
+					timeRange{start: r.start, end: b.start},
+				)
+			}
+			r.start = b.end
+		}
+		if r.end.After(r.start) {
+			remaining = append(remaining, r)
+		}
+	}
+	return remaining
+}
+
+// splitSlots splits the free ranges into the slots of the given duration,
+// starting at every vetchi.InterviewSlotStep, not earlier than notBefore
+func splitSlots(
+	free []timeRange,
+	duration time.Duration,
+	notBefore time.Time,
+) []common.InterviewSlot {
+	step := vetchi.InterviewSlotStep
+	slots := []common.InterviewSlot{}
+	for _, r := range free {
+		start := r.start
+		if notBefore.After(start) {
+			start = notBefore
+		}
+		// Round up to the next step
+		if rounded := start.Truncate(step); rounded.Before(start) {
+			start = rounded.Add(step)
+		}
+
+		for ; !start.Add(duration).After(r.end); start = start.Add(step) {
+			slots = append(slots, common.InterviewSlot{
+				StartTime: start.UTC(),
+				EndTime:   start.Add(duration).UTC(),
+			})
+			if len(slots) >= vetchi.MaxInterviewSlots {
+				return slots
+			}
+		}
+	}
+	return slots
+}
+
+// getSchedulingRequest fetches the scheduling request of a candidacy of the
+// hub user. When forUpdate is set, the request row is locked till the end of
+// the transaction, so that the holds and the bookings of the same request
+// are serialized.
+func (p *PG) getSchedulingRequest(
+	ctx context.Context,
+	tx pgx.Tx,
+	schedulingRequestID string,
+	hubUserID uuid.UUID,
+	forUpdate bool,
+) (schedulingRequest, error) {
+	query := `
+SELECT
+    isr.id,
+    isr.candidacy_id,
+    isr.employer_id,
+    a.hub_user_id,
+    isr.interview_type,
+    COALESCE(isr.description, ''),
+    isr.duration_minutes,
+    isr.window_start,
+    isr.window_end,
+    isr.scheduling_request_state,
+    c.candidacy_state,
+    isr.created_by,
+    e.company_name,
+    o.title,
+    hu.full_name,
+    hu.email,
+    COALESCE(isr.meeting_provider::text, ''),
+    COALESCE(isr.meeting_url, ''),
+    ARRAY (
+        SELECT
+            sri.interviewer_id
+        FROM
+            scheduling_request_interviewers sri
+        WHERE
+            sri.scheduling_request_id = isr.id
+        ORDER BY
+            sri.interviewer_id),
+    ARRAY (
+        SELECT
+            ou.email
+        FROM
+            scheduling_request_interviewers sri
+            JOIN org_users ou ON ou.id = sri.interviewer_id
+        WHERE
+            sri.scheduling_request_id = isr.id
+        ORDER BY
+            sri.interviewer_id)
+FROM
+    interview_scheduling_requests isr
+    JOIN candidacies c ON c.id = isr.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+    JOIN hub_users hu ON hu.id = a.hub_user_id
+    JOIN employers e ON e.id = isr.employer_id
+    JOIN openings o ON o.employer_id = c.employer_id
+        AND o.id = c.opening_id
+WHERE
+    isr.id = $1
+    AND a.hub_user_id = $2
+`
+	if forUpdate {
+		query += "FOR UPDATE OF isr\n"
+	}
+
+	var sr schedulingRequest
+	err := tx.QueryRow(ctx, query, schedulingRequestID, hubUserID).Scan(
+		&sr.id,
+		&sr.candidacyID,
+		&sr.employerID,
+		&sr.hubUserID,
+		&sr.interviewType,
+		&sr.description,
+		&sr.durationMinutes,
+		&sr.windowStart,
+		&sr.windowEnd,
+		&sr.state,
+		&sr.candidacyState,
+		&sr.createdBy,
+		&sr.companyName,
+		&sr.openingTitle,
+		&sr.candidateName,
+		&sr.candidateEmail,
+		&sr.meetingProvider,
+		&sr.meetingURL,
+		&sr.interviewerIDs,
+		&sr.interviewerEmails,
+	)
+	if err != nil {
+		return schedulingRequest{}, err
+	}
+
+	return sr, nil
+}
+
+// getFreeSlots computes the slots of the scheduling request in which all the
+// interviewers of the pool are available and in which neither the
+// interviewers nor the candidate have an interview or a slot held for some
+// other scheduling request. The busy periods synced from the CalDAV
+// calendars of the interviewers are excluded too.
+func (p *PG) getFreeSlots(
+	ctx context.Context,
+	tx pgx.Tx,
+	sr schedulingRequest,
+) ([]common.InterviewSlot, error) {
+	now := time.Now().UTC()
+	from := sr.windowStart
+	if now.After(from) {
+		from = now
+	}
+	if !sr.windowEnd.After(from) {
+		return []common.InterviewSlot{}, nil
+	}
+
+	availabilityQuery := `
+SELECT
+    sri.interviewer_id,
+    oua.time_zone,
+    oua.windows
+FROM
+    scheduling_request_interviewers sri
+    LEFT JOIN org_user_availability oua ON oua.org_user_id = sri.interviewer_id
+WHERE
+    sri.scheduling_request_id = $1
+`
+	rows, err := tx.Query(ctx, availabilityQuery, sr.id)
+	if err != nil {
+		p.log.Err("failed to query availability", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var free []timeRange
+	first := true
+	for rows.Next() {
+		var interviewerID uuid.UUID
+		var timeZone *string
+		var windowsJSON []byte
+		err := rows.Scan(&interviewerID, &timeZone, &windowsJSON)
+		if err != nil {
+			p.log.Err("failed to scan availability", "error", err)
+			return nil, err
+		}
+
+		// An interviewer who has not set the availability is never free
+		if timeZone == nil {
+			p.log.Dbg("no availability", "interviewer_id", interviewerID)
+			return []common.InterviewSlot{}, nil
+		}
+
+		loc, err := timeZoneLocation(*timeZone)
+		if err != nil {
+			p.log.Err("invalid time zone", "error", err)
+			return nil, err
+		}
+
+		var windows []employer.AvailabilityWindow
+		err = json.Unmarshal(windowsJSON, &windows)
+		if err != nil {
+			p.log.Err("failed to unmarshal windows", "error", err)
+			return nil, err
+		}
+
+		available, err := availableRanges(loc, windows, from, sr.windowEnd)
+		if err != nil {
+			p.log.Err("invalid availability window", "error", err)
+			return nil, err
+		}
+
+		if first {
+			free = available
+			first = false
+		} else {
+			free = intersectRanges(free, available)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate availability", "error", err)
+		return nil, err
+	}
+
+	if len(free) == 0 {
+		return []common.InterviewSlot{}, nil
+	}
+
+	busyQuery := `
+SELECT
+    i.start_time,
+    i.end_time
+FROM
+    interviews i
+WHERE
+    i.interview_state = $1
+    AND i.end_time > $2
+    AND i.start_time < $3
+    AND (i.id IN (
+            SELECT
+                ii.interview_id
+            FROM
+                interview_interviewers ii
+            WHERE
+                ii.interviewer_id = ANY ($4::uuid[]))
+        OR i.candidacy_id IN (
+            SELECT
+                c.id
+            FROM
+                candidacies c
+                JOIN applications a ON a.id = c.application_id
+            WHERE
+                a.hub_user_id = $5))
+UNION ALL
+SELECT
+    h.start_time,
+    h.end_time
+FROM
+    interview_slot_holds h
+    JOIN interview_scheduling_requests isr ON isr.id = h.scheduling_request_id
+    JOIN candidacies c ON c.id = isr.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+WHERE
+    h.scheduling_request_id != $6
+    AND h.expires_at > timezone('UTC', now())
+    AND isr.scheduling_request_state = $7
+    AND h.end_time > $2
+    AND h.start_time < $3
+    AND (a.hub_user_id = $5
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                scheduling_request_interviewers sri
+            WHERE
+                sri.scheduling_request_id = h.scheduling_request_id
+                AND sri.interviewer_id = ANY ($4::uuid[])))
+UNION ALL
+SELECT
+    cbp.start_time,
+    cbp.end_time
+FROM
+    caldav_busy_periods cbp
+WHERE
+    cbp.org_user_id = ANY ($4::uuid[])
+    AND cbp.end_time > $2
+    AND cbp.start_time < $3
+`
+	busyRows, err := tx.Query(
+		ctx,
+		busyQuery,
+		common.ScheduledInterviewState,
+		from,
+		sr.windowEnd,
+		sr.interviewerIDs,
+		sr.hubUserID,
+		sr.id,
+		openSchedulingRequestState,
+	)
+	if err != nil {
+		p.log.Err("failed to query busy times", "error", err)
+		return nil, err
+	}
+	defer busyRows.Close()
+
+	var busy []timeRange
+	for busyRows.Next() {
+		var r timeRange
+		if err := busyRows.Scan(&r.start, &r.end); err != nil {
+			p.log.Err("failed to scan busy time", "error", err)
+			return nil, err
+		}
+		busy = append(busy, r)
+	}
+	if err := busyRows.Err(); err != nil {
+		p.log.Err("failed to iterate busy times", "error", err)
+		return nil, err
+	}
+
+	duration := time.Duration(sr.durationMinutes) * time.Minute
+	return splitSlots(subtractRanges(free, busy), duration, from), nil
+}
@@ -125,7 +125,13 @@ SELECT
     tags, upvotes_count, downvotes_count, score,
     me_upvoted, me_downvoted, can_upvote, can_downvote, am_i_author,
     can_comment, comments_count,
-    employer_name, employer_id_internal, employer_domain_name
+    employer_name, employer_id_internal, employer_domain_name,
+    EXISTS (
+        SELECT 1 FROM bookmarks b
+        WHERE b.hub_user_id = $1
+        AND b.bookmark_type = 'POST'
+        AND b.target_id = item_id
+    ) AS is_bookmarked
 FROM hu_timeline_extended
 WHERE hub_user_id = $1
 `
@@ -169,6 +175,7 @@ WHERE hub_user_id = $1
 		var canComment bool
 		var commentsCount int32
 		var employerName, employerIDInternal, employerDomainName sql.NullString
+		var isBookmarked bool
 
 		err := rows.Scan(
 			&itemID, &itemTypeStr, &content, &createdAt, &updatedAt,
@@ -177,6 +184,7 @@ WHERE hub_user_id = $1
 			&meUpvoted, &meDownvoted, &canUpvote, &canDownvote, &amIAuthor,
 			&canComment, &commentsCount,
 			&employerName, &employerIDInternal, &employerDomainName,
+			&isBookmarked,
 		)
 		if err != nil {
 			pg.log.Err("Failed to scan timeline item row", "error", err)
@@ -203,6 +211,7 @@ WHERE hub_user_id = $1
 				AmIAuthor:      amIAuthor.Bool,
 				CanComment:     canComment,
 				CommentsCount:  commentsCount,
+				IsBookmarked:   isBookmarked,
 			}
 			userPosts = append(userPosts, userPost)
 		} else if itemType == common.TimelineItemEmployerPost {
@@ -0,0 +1,560 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// voidOfferSignatures voids the signature of the offers of the candidacy
+// that are still waiting to be signed. Should be called whenever the
+// PENDING offer of the candidacy leaves the PENDING state by any means
+// other than the signature.
+//
+// TODO: The envelope should be voided with the provider too, so that the
+// candidate cannot sign it anymore. Until then, a signature that arrives
+// after this is ignored.
+func (p *PG) voidOfferSignatures(
+	ctx context.Context,
+	tx pgx.Tx,
+	candidacyID string,
+) error {
+	_, err := tx.Exec(
+		ctx,
+		`
+UPDATE candidacy_offer_signatures
+SET signature_state = $1,
+    completed_at = timezone('UTC', now())
+WHERE candidacy_id = $2
+    AND signature_state = $3
+`,
+		common.SignatureVoided,
+		candidacyID,
+		common.SentForSignature,
+	)
+	if err != nil {
+		p.log.Err("failed to void offer signatures", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetOfferForSignature(
+	ctx context.Context,
+	candidacyID string,
+) (db.OfferForSignature, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.OfferForSignature{}, db.ErrInternal
+	}
+
+	var offer db.OfferForSignature
+	var signatureState *common.OfferSignatureState
+	err := p.pool.QueryRow(
+		ctx,
+		`
+SELECT
+    co.version,
+    co.offer_letter_path,
+    cos.signature_state
+FROM
+    candidacy_offers co
+    LEFT JOIN candidacy_offer_signatures cos ON cos.candidacy_id = co.candidacy_id
+        AND cos.version = co.version
+WHERE
+    co.candidacy_id = $1
+    AND co.employer_id = $2
+    AND co.offer_state = $3
+`,
+		candidacyID,
+		orgUser.EmployerID,
+		common.PendingOffer,
+	).Scan(&offer.Version, &offer.OfferLetterPath, &signatureState)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("no pending offer", "candidacy_id", candidacyID)
+			return db.OfferForSignature{}, db.ErrNoOffer
+		}
+		p.log.Err("failed to get pending offer", "error", err)
+		return db.OfferForSignature{}, db.ErrInternal
+	}
+
+	if signatureState != nil {
+		p.log.Dbg("offer already sent for signature", "state", signatureState)
+		return db.OfferForSignature{}, db.ErrOfferSentForSignature
+	}
+
+	return offer, nil
+}
+
+func (p *PG) SaveOfferSignature(
+	ctx context.Context,
+	req db.SaveOfferSignatureReq,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	// The offer could have been revised or responded to while the document
+	// was being sent to the provider
+	version, err := p.lockPendingOffer(
+		ctx,
+		tx,
+		orgUser.EmployerID,
+		req.CandidacyID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if version != req.Version {
+		p.log.Dbg("offer revised meanwhile", "version", version, "req", req)
+		return db.ErrNoOffer
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO candidacy_offer_signatures (candidacy_id, version, provider, envelope_id, signature_state, sent_by)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`,
+		req.CandidacyID,
+		req.Version,
+		req.Provider,
+		req.EnvelopeID,
+		common.SentForSignature,
+		orgUser.ID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+			pgErr.ConstraintName == "candidacy_offer_signatures_pkey" {
+			p.log.Dbg("offer already sent for signature", "req", req)
+			return db.ErrOfferSentForSignature
+		}
+		p.log.Err("failed to insert offer signature", "error", err)
+		return db.ErrInternal
+	}
+
+	err = p.addOrgUserComment(
+		ctx,
+		tx,
+		orgUser,
+		req.CandidacyID,
+		"Offer sent to the candidate for signature",
+	)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) PollOfferSignatureNow(
+	ctx context.Context,
+	provider string,
+	envelopeID string,
+) error {
+	// The envelopes that are already completed or voided are acknowledged
+	// too, so that the provider does not keep retrying the webhook
+	result, err := p.pool.Exec(
+		ctx,
+		`
+UPDATE candidacy_offer_signatures
+SET next_poll_at = CASE WHEN signature_state = $3 THEN
+        timezone('UTC', now())
+    ELSE
+        next_poll_at
+    END
+WHERE provider = $1
+    AND envelope_id = $2
+`,
+		provider,
+		envelopeID,
+		common.SentForSignature,
+	)
+	if err != nil {
+		p.log.Err("failed to update next_poll_at", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("no offer signature", "envelope_id", envelopeID)
+		return db.ErrNoOfferSignature
+	}
+
+	return nil
+}
+
+func (p *PG) GetDueOfferSignatures(
+	ctx context.Context,
+	provider string,
+	limit int,
+) ([]db.DueOfferSignature, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    cos.candidacy_id,
+    cos.version,
+    cos.envelope_id,
+    h.full_name,
+    o.title,
+    r.email
+FROM
+    candidacy_offer_signatures cos
+    JOIN candidacies c ON c.id = cos.candidacy_id
+    JOIN applications a ON c.application_id = a.id
+    JOIN hub_users h ON a.hub_user_id = h.id
+    JOIN openings o ON c.employer_id = o.employer_id AND c.opening_id = o.id
+    JOIN org_users r ON o.recruiter = r.id
+WHERE
+    cos.provider = $1
+    AND cos.signature_state = $2
+    AND cos.next_poll_at <= timezone('UTC', now())
+ORDER BY
+    cos.next_poll_at
+LIMIT $3
+`,
+		provider,
+		common.SentForSignature,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query due offer signatures", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	signatures, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.DueOfferSignature, error) {
+			var signature db.DueOfferSignature
+			err := row.Scan(
+				&signature.CandidacyID,
+				&signature.Version,
+				&signature.EnvelopeID,
+				&signature.CandidateName,
+				&signature.OpeningTitle,
+				&signature.RecruiterEmail,
+			)
+			return signature, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan due offer signatures", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return signatures, nil
+}
+
+func (p *PG) DeferOfferSignaturePoll(
+	ctx context.Context,
+	req db.DeferOfferSignaturePollReq,
+) error {
+	_, err := p.pool.Exec(
+		ctx,
+		`
+UPDATE candidacy_offer_signatures
+SET next_poll_at = $1
+WHERE candidacy_id = $2
+    AND version = $3
+`,
+		req.NextPollAt,
+		req.CandidacyID,
+		req.Version,
+	)
+	if err != nil {
+		p.log.Err("failed to defer offer signature poll", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// completeSignature moves the signature from SENT_FOR_SIGNATURE to the
+// given state and returns the hub user and the employer of the candidacy.
+// Returns db.ErrStateMismatch if the signature is not SENT_FOR_SIGNATURE
+// anymore, for example because the offer was revised meanwhile.
+func (p *PG) completeSignature(
+	ctx context.Context,
+	tx pgx.Tx,
+	candidacyID string,
+	version int,
+	state common.OfferSignatureState,
+	signedDocumentPath *string,
+) (hubUserID uuid.UUID, employerID uuid.UUID, err error) {
+	err = tx.QueryRow(
+		ctx,
+		`
+UPDATE candidacy_offer_signatures cos
+SET signature_state = $1,
+    signed_document_path = $2,
+    completed_at = timezone('UTC', now())
+FROM
+    candidacies c
+    JOIN applications a ON c.application_id = a.id
+WHERE
+    cos.candidacy_id = $3
+    AND cos.version = $4
+    AND cos.signature_state = $5
+    AND c.id = cos.candidacy_id
+RETURNING
+    a.hub_user_id,
+    c.employer_id
+`,
+		state,
+		signedDocumentPath,
+		candidacyID,
+		version,
+		common.SentForSignature,
+	).Scan(&hubUserID, &employerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("signature state changed", "candidacy_id", candidacyID)
+			return uuid.Nil, uuid.Nil, db.ErrStateMismatch
+		}
+		p.log.Err("failed to complete offer signature", "error", err)
+		return uuid.Nil, uuid.Nil, db.ErrInternal
+	}
+
+	return hubUserID, employerID, nil
+}
+
+func (p *PG) addHubUserComment(
+	ctx context.Context,
+	tx pgx.Tx,
+	hubUserID uuid.UUID,
+	employerID uuid.UUID,
+	candidacyID string,
+	comment string,
+) error {
+	_, err := tx.Exec(
+		ctx,
+		`
+INSERT INTO candidacy_comments (author_type, hub_user_id, comment_text, candidacy_id, employer_id, created_at)
+    VALUES ($1, $2, $3, $4, $5, timezone('UTC', now()))
+`,
+		db.HubUserAuthorType,
+		hubUserID,
+		comment,
+		candidacyID,
+		employerID,
+	)
+	if err != nil {
+		p.log.Err("failed to add comment", "error", err)
+		return db.ErrInternal
+	}
+	return nil
+}
+
+// CompleteOfferSignature saves the signed document and accepts the offer.
+// If all the positions of the opening got filled meanwhile, the signature
+// is still saved but the candidacy is left in the OFFERED state for the
+// employer to resolve.
+func (p *PG) CompleteOfferSignature(
+	ctx context.Context,
+	req db.CompleteOfferSignatureReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	hubUserID, employerID, err := p.completeSignature(
+		ctx,
+		tx,
+		req.CandidacyID,
+		req.Version,
+		common.OfferSigned,
+		&req.SignedDocumentPath,
+	)
+	if err != nil {
+		return err
+	}
+
+	var startDate string
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT start_date::TEXT
+FROM candidacy_offers
+WHERE candidacy_id = $1
+    AND version = $2
+    AND offer_state = $3
+FOR UPDATE
+`,
+		req.CandidacyID,
+		req.Version,
+		common.PendingOffer,
+	).Scan(&startDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("offer not pending", "candidacy_id", req.CandidacyID)
+			return db.ErrStateMismatch
+		}
+		p.log.Err("failed to get pending offer", "error", err)
+		return db.ErrInternal
+	}
+
+	comment := "Offer signed by the candidate"
+	err = p.fillPosition(
+		ctx,
+		tx,
+		employerID,
+		req.CandidacyID,
+		systemActor,
+		&startDate,
+		nil,
+	)
+	if err != nil {
+		if !errors.Is(err, db.ErrAllPositionsFilled) {
+			return err
+		}
+		comment = "Offer signed by the candidate, but all the positions " +
+			"of the opening are already filled"
+	}
+
+	err = p.addHubUserComment(
+		ctx,
+		tx,
+		hubUserID,
+		employerID,
+		req.CandidacyID,
+		comment,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = p.insertEmail(ctx, tx, req.Email)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// DeclineOfferSignature declines the offer and moves the candidacy to
+// OFFER_DECLINED as the candidate declined to sign the offer letter
+func (p *PG) DeclineOfferSignature(
+	ctx context.Context,
+	req db.DeclineOfferSignatureReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	hubUserID, employerID, err := p.completeSignature(
+		ctx,
+		tx,
+		req.CandidacyID,
+		req.Version,
+		common.SignatureDeclined,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(
+		ctx,
+		`
+UPDATE candidacy_offers
+SET offer_state = $1,
+    responded_at = timezone('UTC', now())
+WHERE candidacy_id = $2
+    AND version = $3
+    AND offer_state = $4
+`,
+		common.DeclinedOffer,
+		req.CandidacyID,
+		req.Version,
+		common.PendingOffer,
+	)
+	if err != nil {
+		p.log.Err("failed to decline offer", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() != 1 {
+		p.log.Dbg("offer not pending", "candidacy_id", req.CandidacyID)
+		return db.ErrStateMismatch
+	}
+
+	err = p.transitionCandidacy(
+		ctx,
+		tx,
+		employerID,
+		req.CandidacyID,
+		common.OfferDeclinedCandidacyState,
+		systemActor,
+		nil,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrIllegalTransition) {
+			p.log.Dbg("candidacy not offered", "candidacy_id", req.CandidacyID)
+			return db.ErrStateMismatch
+		}
+		return err
+	}
+
+	err = p.addHubUserComment(
+		ctx,
+		tx,
+		hubUserID,
+		employerID,
+		req.CandidacyID,
+		"Offer declined by the candidate while signing",
+	)
+	if err != nil {
+		return err
+	}
+
+	err = p.insertEmail(ctx, tx, req.Email)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
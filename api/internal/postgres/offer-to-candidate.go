@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 
 	"github.com/jackc/pgx/v5"
@@ -28,30 +27,25 @@ func (p *PG) OfferToCandidate(
 	}
 	defer tx.Rollback(context.Background())
 
-	query := `
-UPDATE candidacies
-SET candidacy_state = $1
-WHERE id = $2
-AND employer_id = $3
-AND candidacy_state = $4
-RETURNING id
-`
-
-	err = tx.QueryRow(
+	err = p.transitionCandidacy(
 		ctx,
-		query,
-		common.OfferedCandidacyState,
-		request.CandidacyID,
+		tx,
 		orgUser.EmployerID,
-		common.InterviewingCandidacyState,
-	).Scan(&request.CandidacyID)
+		request.CandidacyID,
+		common.OfferedCandidacyState,
+		orgUserActor(orgUser),
+		nil,
+	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			p.log.Dbg("not found", "candidacy_id", request.CandidacyID)
+		if errors.Is(err, db.ErrIllegalTransition) {
 			return db.ErrNoCandidacy
 		}
-		p.log.Err("failed to update candidacy status", "error", err)
-		return db.ErrInternal
+		return err
+	}
+
+	err = p.insertOffer(ctx, tx, orgUser, request.CandidacyID, request.Offer)
+	if err != nil {
+		return err
 	}
 
 	interviewUpdateQuery := `
@@ -158,13 +152,15 @@ SELECT
 	h.full_name,
 	h.email,
 	e.company_name,
-	o.title
+	o.title,
+	r.email
 FROM
 	candidacies c
 	JOIN applications a ON c.application_id = a.id
 	JOIN hub_users h ON a.hub_user_id = h.id
 	JOIN employers e ON c.employer_id = e.id
 	JOIN openings o ON c.employer_id = o.employer_id AND c.opening_id = o.id
+	JOIN org_users r ON o.recruiter = r.id
 WHERE
 	c.id = $1
 `
@@ -174,6 +170,7 @@ WHERE
 		&info.CandidateEmail,
 		&info.CompanyName,
 		&info.OpeningTitle,
+		&info.RecruiterEmail,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
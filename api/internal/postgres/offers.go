@@ -0,0 +1,838 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// insertOffer saves the offer as the next version of the offers of the
+// candidacy. Any older PENDING offer should be superseded before.
+func (p *PG) insertOffer(
+	ctx context.Context,
+	tx pgx.Tx,
+	orgUser db.OrgUserTO,
+	candidacyID string,
+	offer db.OfferDetails,
+) error {
+	var version int
+	err := tx.QueryRow(
+		ctx,
+		`
+INSERT INTO candidacy_offers (candidacy_id, version, employer_id, compensation_amount, compensation_currency, compensation_notes, start_date, expires_at, offer_letter_path, offer_state, created_by)
+SELECT
+    $1,
+    COALESCE(MAX(version), 0) + 1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6::DATE,
+    $7,
+    $8,
+    $9,
+    $10
+FROM
+    candidacy_offers
+WHERE
+    candidacy_id = $1
+RETURNING
+    version
+`,
+		candidacyID,
+		orgUser.EmployerID,
+		offer.Compensation.Amount,
+		offer.Compensation.Currency,
+		offer.Compensation.Notes,
+		offer.StartDate,
+		offer.ExpiresAt,
+		offer.OfferLetterPath,
+		common.PendingOffer,
+		orgUser.ID,
+	).Scan(&version)
+	if err != nil {
+		p.log.Err("failed to insert offer", "error", err)
+		return db.ErrInternal
+	}
+
+	for i, attachment := range offer.Attachments {
+		_, err = tx.Exec(
+			ctx,
+			`
+INSERT INTO candidacy_offer_attachments (candidacy_id, version, attachment_number, filename, file_path)
+    VALUES ($1, $2, $3, $4, $5)
+`,
+			candidacyID,
+			version,
+			i+1,
+			attachment.Filename,
+			attachment.FilePath,
+		)
+		if err != nil {
+			p.log.Err("failed to insert offer attachment", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	p.log.Dbg("inserted offer", "candidacy_id", candidacyID, "version", version)
+	return nil
+}
+
+func (p *PG) addOrgUserComment(
+	ctx context.Context,
+	tx pgx.Tx,
+	orgUser db.OrgUserTO,
+	candidacyID string,
+	comment string,
+) error {
+	_, err := tx.Exec(
+		ctx,
+		`
+INSERT INTO candidacy_comments (author_type, org_user_id, comment_text, candidacy_id, employer_id, created_at)
+    VALUES ($1, $2, $3, $4, $5, timezone('UTC', now()))
+`,
+		db.OrgUserAuthorType,
+		orgUser.ID,
+		comment,
+		candidacyID,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to add comment", "error", err)
+		return db.ErrInternal
+	}
+	return nil
+}
+
+// lockPendingOffer returns the version of the PENDING offer of the candidacy
+func (p *PG) lockPendingOffer(
+	ctx context.Context,
+	tx pgx.Tx,
+	employerID uuid.UUID,
+	candidacyID string,
+) (int, error) {
+	var version int
+	err := tx.QueryRow(
+		ctx,
+		`
+SELECT
+    co.version
+FROM
+    candidacy_offers co
+    JOIN candidacies c ON c.id = co.candidacy_id
+WHERE
+    co.candidacy_id = $1
+    AND co.employer_id = $2
+    AND co.offer_state = $3
+    AND c.candidacy_state = $4
+FOR UPDATE OF co, c
+`,
+		candidacyID,
+		employerID,
+		common.PendingOffer,
+		common.OfferedCandidacyState,
+	).Scan(&version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("no pending offer", "candidacy_id", candidacyID)
+			return 0, db.ErrNoOffer
+		}
+		p.log.Err("failed to get pending offer", "error", err)
+		return 0, db.ErrInternal
+	}
+
+	return version, nil
+}
+
+func (p *PG) ReviseOffer(ctx context.Context, req db.ReviseOfferReq) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	version, err := p.lockPendingOffer(
+		ctx,
+		tx,
+		orgUser.EmployerID,
+		req.CandidacyID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE candidacy_offers
+SET offer_state = $1
+WHERE candidacy_id = $2
+    AND version = $3
+`,
+		common.SupersededOffer,
+		req.CandidacyID,
+		version,
+	)
+	if err != nil {
+		p.log.Err("failed to supersede offer", "error", err)
+		return db.ErrInternal
+	}
+
+	err = p.voidOfferSignatures(ctx, tx, req.CandidacyID)
+	if err != nil {
+		return err
+	}
+
+	err = p.insertOffer(ctx, tx, orgUser, req.CandidacyID, req.Offer)
+	if err != nil {
+		return err
+	}
+
+	err = p.addOrgUserComment(ctx, tx, orgUser, req.CandidacyID, req.Comment)
+	if err != nil {
+		return err
+	}
+
+	err = p.insertEmail(ctx, tx, req.Email)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) RescindOffer(ctx context.Context, req db.RescindOfferReq) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	version, err := p.lockPendingOffer(
+		ctx,
+		tx,
+		orgUser.EmployerID,
+		req.CandidacyID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE candidacy_offers
+SET offer_state = $1,
+    response_reason = $2,
+    responded_at = timezone('UTC', now())
+WHERE candidacy_id = $3
+    AND version = $4
+`,
+		common.RescindedOffer,
+		req.Reason,
+		req.CandidacyID,
+		version,
+	)
+	if err != nil {
+		p.log.Err("failed to rescind offer", "error", err)
+		return db.ErrInternal
+	}
+
+	err = p.voidOfferSignatures(ctx, tx, req.CandidacyID)
+	if err != nil {
+		return err
+	}
+
+	err = p.transitionCandidacy(
+		ctx,
+		tx,
+		orgUser.EmployerID,
+		req.CandidacyID,
+		common.InterviewingCandidacyState,
+		orgUserActor(orgUser),
+		&req.Reason,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrIllegalTransition) {
+			return db.ErrNoOffer
+		}
+		return err
+	}
+
+	err = p.addOrgUserComment(
+		ctx,
+		tx,
+		orgUser,
+		req.CandidacyID,
+		"Offer rescinded: "+req.Reason,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = p.insertEmail(ctx, tx, req.Email)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetEmployerOffers(
+	ctx context.Context,
+	req common.GetOffersRequest,
+) ([]common.Offer, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	var exists bool
+	err := p.pool.QueryRow(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM candidacies WHERE id = $1 AND employer_id = $2)`,
+		req.CandidacyID,
+		orgUser.EmployerID,
+	).Scan(&exists)
+	if err != nil {
+		p.log.Err("failed to check candidacy", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if !exists {
+		return nil, db.ErrNoCandidacy
+	}
+
+	return p.getOffers(ctx, req.CandidacyID)
+}
+
+func (p *PG) GetHubOffers(
+	ctx context.Context,
+	req common.GetOffersRequest,
+) ([]common.Offer, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return nil, db.ErrInternal
+	}
+
+	var exists bool
+	err := p.pool.QueryRow(
+		ctx,
+		`
+SELECT EXISTS (
+    SELECT
+        1
+    FROM
+        candidacies c
+        JOIN applications a ON c.application_id = a.id
+    WHERE
+        c.id = $1
+        AND a.hub_user_id = $2)
+`,
+		req.CandidacyID,
+		hubUser.ID,
+	).Scan(&exists)
+	if err != nil {
+		p.log.Err("failed to check candidacy", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if !exists {
+		return nil, db.ErrNoCandidacy
+	}
+
+	return p.getOffers(ctx, req.CandidacyID)
+}
+
+// getOffers returns all the versions of the offers of the candidacy, latest
+// first. The caller should have checked the access to the candidacy.
+func (p *PG) getOffers(
+	ctx context.Context,
+	candidacyID string,
+) ([]common.Offer, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    co.candidacy_id,
+    co.version,
+    co.compensation_amount,
+    co.compensation_currency,
+    co.compensation_notes,
+    co.start_date::TEXT,
+    co.expires_at,
+    co.offer_state,
+    co.response_reason,
+    co.responded_at,
+    co.created_at,
+    cos.signature_state,
+    CASE WHEN cos.signature_state = $2 THEN
+        cos.completed_at
+    END,
+    COALESCE((
+        SELECT
+            json_agg(json_build_object('attachment_number', coa.attachment_number, 'filename', coa.filename) ORDER BY coa.attachment_number)
+        FROM candidacy_offer_attachments coa
+        WHERE
+            coa.candidacy_id = co.candidacy_id
+            AND coa.version = co.version), '[]'::json)
+FROM
+    candidacy_offers co
+    LEFT JOIN candidacy_offer_signatures cos ON cos.candidacy_id = co.candidacy_id
+        AND cos.version = co.version
+WHERE
+    co.candidacy_id = $1
+ORDER BY
+    co.version DESC
+`,
+		candidacyID,
+		common.OfferSigned,
+	)
+	if err != nil {
+		p.log.Err("failed to query offers", "error", err)
+		return nil, db.ErrInternal
+	}
+	defer rows.Close()
+
+	offers := []common.Offer{}
+	for rows.Next() {
+		var offer common.Offer
+		err = rows.Scan(
+			&offer.CandidacyID,
+			&offer.Version,
+			&offer.Compensation.Amount,
+			&offer.Compensation.Currency,
+			&offer.Compensation.Notes,
+			&offer.StartDate,
+			&offer.ExpiresAt,
+			&offer.OfferState,
+			&offer.ResponseReason,
+			&offer.RespondedAt,
+			&offer.CreatedAt,
+			&offer.SignatureState,
+			&offer.SignedAt,
+			&offer.Attachments,
+		)
+		if err != nil {
+			p.log.Err("failed to scan offer", "error", err)
+			return nil, db.ErrInternal
+		}
+		offers = append(offers, offer)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate offers", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return offers, nil
+}
+
+func (p *PG) GetEmployerOfferDocumentPath(
+	ctx context.Context,
+	req common.GetOfferDocumentRequest,
+) (string, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return "", db.ErrInternal
+	}
+
+	return p.getOfferDocumentPath(
+		ctx,
+		req,
+		`co.employer_id = $4`,
+		orgUser.EmployerID,
+	)
+}
+
+func (p *PG) GetHubOfferDocumentPath(
+	ctx context.Context,
+	req common.GetOfferDocumentRequest,
+) (string, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return "", db.ErrInternal
+	}
+
+	return p.getOfferDocumentPath(
+		ctx,
+		req,
+		`
+EXISTS (
+    SELECT
+        1
+    FROM
+        candidacies c
+        JOIN applications a ON c.application_id = a.id
+    WHERE
+        c.id = co.candidacy_id
+        AND a.hub_user_id = $4)`,
+		hubUser.ID,
+	)
+}
+
+// getOfferDocumentPath returns the object storage path of the offer letter,
+// or of the attachment if an attachment_number is requested, or of the
+// signed offer letter if the signed document is requested. The access
+// clause should use $4 for the accessor.
+func (p *PG) getOfferDocumentPath(
+	ctx context.Context,
+	req common.GetOfferDocumentRequest,
+	accessClause string,
+	accessor uuid.UUID,
+) (string, error) {
+	query := `
+SELECT
+    CASE WHEN $5::BOOLEAN THEN
+        cos.signed_document_path
+    WHEN $3::INTEGER IS NULL THEN
+        co.offer_letter_path
+    ELSE
+        coa.file_path
+    END
+FROM
+    candidacy_offers co
+    LEFT JOIN candidacy_offer_attachments coa ON coa.candidacy_id = co.candidacy_id
+        AND coa.version = co.version
+        AND coa.attachment_number = $3
+    LEFT JOIN candidacy_offer_signatures cos ON cos.candidacy_id = co.candidacy_id
+        AND cos.version = co.version
+WHERE
+    co.candidacy_id = $1
+    AND co.version = $2
+    AND ` + accessClause
+
+	var path *string
+	err := p.pool.QueryRow(
+		ctx,
+		query,
+		req.CandidacyID,
+		req.Version,
+		req.AttachmentNumber,
+		accessor,
+		req.Signed != nil && *req.Signed,
+	).Scan(&path)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", db.ErrNoOffer
+		}
+		p.log.Err("failed to get offer document path", "error", err)
+		return "", db.ErrInternal
+	}
+
+	if path == nil {
+		p.log.Dbg("no such offer document", "request", req)
+		return "", db.ErrNoOffer
+	}
+
+	return *path, nil
+}
+
+func (p *PG) RespondToOffer(ctx context.Context, req db.RespondToOfferReq) error {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var employerID uuid.UUID
+	var startDate string
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    co.employer_id,
+    co.start_date::TEXT
+FROM
+    candidacy_offers co
+    JOIN candidacies c ON c.id = co.candidacy_id
+    JOIN applications a ON c.application_id = a.id
+WHERE
+    co.candidacy_id = $1
+    AND co.version = $2
+    AND a.hub_user_id = $3
+    AND co.offer_state = $4
+    AND co.expires_at > timezone('UTC', now())
+FOR UPDATE OF co
+`,
+		req.Request.CandidacyID,
+		req.Request.Version,
+		hubUser.ID,
+		common.PendingOffer,
+	).Scan(&employerID, &startDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("no pending offer", "request", req.Request)
+			return db.ErrNoOffer
+		}
+		p.log.Err("failed to get pending offer", "error", err)
+		return db.ErrInternal
+	}
+
+	comment := "Offer accepted by the candidate"
+	if req.Request.Response == hub.AcceptOffer {
+		err = p.fillPosition(
+			ctx,
+			tx,
+			employerID,
+			req.Request.CandidacyID,
+			hubUserActor(hubUser.ID),
+			&startDate,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	} else {
+		_, err = tx.Exec(
+			ctx,
+			`
+UPDATE candidacy_offers
+SET offer_state = $1,
+    response_reason = $2,
+    responded_at = timezone('UTC', now())
+WHERE candidacy_id = $3
+    AND version = $4
+`,
+			common.DeclinedOffer,
+			req.Request.Reason,
+			req.Request.CandidacyID,
+			req.Request.Version,
+		)
+		if err != nil {
+			p.log.Err("failed to decline offer", "error", err)
+			return db.ErrInternal
+		}
+
+		err = p.voidOfferSignatures(ctx, tx, req.Request.CandidacyID)
+		if err != nil {
+			return err
+		}
+
+		err = p.transitionCandidacy(
+			ctx,
+			tx,
+			employerID,
+			req.Request.CandidacyID,
+			common.OfferDeclinedCandidacyState,
+			hubUserActor(hubUser.ID),
+			req.Request.Reason,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrIllegalTransition) {
+				p.log.Dbg("candidacy not offered", "request", req.Request)
+				return db.ErrNoCandidacy
+			}
+			return err
+		}
+
+		comment = "Offer declined by the candidate"
+		if req.Request.Reason != nil {
+			comment += ": " + *req.Request.Reason
+		}
+	}
+
+	err = p.addHubUserComment(
+		ctx,
+		tx,
+		hubUser.ID,
+		employerID,
+		req.Request.CandidacyID,
+		comment,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = p.insertEmail(ctx, tx, req.Email)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetExpiredOffers(
+	ctx context.Context,
+	limit int,
+) ([]db.ExpiredOffer, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    co.candidacy_id,
+    co.version,
+    h.full_name,
+    h.email,
+    e.company_name,
+    o.title
+FROM
+    candidacy_offers co
+    JOIN candidacies c ON c.id = co.candidacy_id
+    JOIN applications a ON c.application_id = a.id
+    JOIN hub_users h ON a.hub_user_id = h.id
+    JOIN employers e ON co.employer_id = e.id
+    JOIN openings o ON c.employer_id = o.employer_id AND c.opening_id = o.id
+WHERE
+    co.offer_state = $1
+    AND co.expires_at <= timezone('UTC', now())
+ORDER BY
+    co.expires_at
+LIMIT $2
+`,
+		common.PendingOffer,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query expired offers", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	offers, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.ExpiredOffer, error) {
+			var offer db.ExpiredOffer
+			err := row.Scan(
+				&offer.CandidacyID,
+				&offer.Version,
+				&offer.CandidateName,
+				&offer.CandidateEmail,
+				&offer.CompanyName,
+				&offer.OpeningTitle,
+			)
+			return offer, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan expired offers", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return offers, nil
+}
+
+// ExpireOffers expires the offers, moves their candidacies to
+// CANDIDATE_NOT_RESPONDING and queues the emails in a single transaction. If
+// any of the offers is no longer PENDING, nothing is applied and
+// db.ErrStateMismatch is returned.
+func (p *PG) ExpireOffers(ctx context.Context, req db.ExpireOffersReq) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	for _, offer := range req.Offers {
+		var employerID uuid.UUID
+		err := tx.QueryRow(
+			ctx,
+			`
+UPDATE candidacy_offers
+SET offer_state = $1
+WHERE candidacy_id = $2
+    AND version = $3
+    AND offer_state = $4
+RETURNING employer_id
+`,
+			common.ExpiredOffer,
+			offer.CandidacyID,
+			offer.Version,
+			common.PendingOffer,
+		).Scan(&employerID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				p.log.Dbg("offer state changed meanwhile", "offer", offer)
+				return db.ErrStateMismatch
+			}
+			p.log.Err("failed to expire offer", "error", err)
+			return db.ErrInternal
+		}
+
+		err = p.voidOfferSignatures(ctx, tx, offer.CandidacyID)
+		if err != nil {
+			return err
+		}
+
+		err = p.transitionCandidacy(
+			ctx,
+			tx,
+			employerID,
+			offer.CandidacyID,
+			common.CandidateNotRespondingCandidacyState,
+			systemActor,
+			nil,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrIllegalTransition) {
+				p.log.Dbg("candidacy state changed meanwhile", "offer", offer)
+				return db.ErrStateMismatch
+			}
+			return err
+		}
+	}
+
+	for _, email := range req.Emails {
+		err = p.insertEmail(ctx, tx, email)
+		if err != nil {
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
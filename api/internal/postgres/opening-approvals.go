@@ -0,0 +1,543 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func (p *PG) SetCostCenterApprovalChain(
+	ctx context.Context,
+	req employer.SetCostCenterApprovalChainRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var costCenterID uuid.UUID
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT id
+FROM org_cost_centers
+WHERE cost_center_name = $1
+    AND employer_id = $2
+FOR UPDATE
+`,
+		req.CostCenterName,
+		orgUser.EmployerID,
+	).Scan(&costCenterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ErrNoCostCenter
+		}
+		p.log.Err("failed to get cost center", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`DELETE FROM cost_center_approvers WHERE cost_center_id = $1`,
+		costCenterID,
+	)
+	if err != nil {
+		p.log.Err("failed to clear approval chain", "error", err)
+		return db.ErrInternal
+	}
+
+	if len(req.Approvers) > 0 {
+		query := `
+INSERT INTO cost_center_approvers (cost_center_id, step_number, approver_id)
+SELECT $1, a.step_number, ou.id
+FROM unnest($2::text[]) WITH ORDINALITY AS a(email, step_number)
+    JOIN org_users ou ON ou.email = a.email
+WHERE ou.employer_id = $3
+    AND ou.org_user_state = ANY($4::org_user_states[])
+`
+		result, err := tx.Exec(
+			ctx,
+			query,
+			costCenterID,
+			req.Approvers,
+			orgUser.EmployerID,
+			[]string{
+				string(employer.ActiveOrgUserState),
+				string(employer.AddedOrgUserState),
+				string(employer.ReplicatedOrgUserState),
+			},
+		)
+		if err != nil {
+			p.log.Err("failed to insert approval chain", "error", err)
+			return db.ErrInternal
+		}
+		if int(result.RowsAffected()) != len(req.Approvers) {
+			p.log.Dbg("invalid approvers", "approvers", req.Approvers)
+			return db.ErrInvalidApprovers
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetCostCenterApprovalChain(
+	ctx context.Context,
+	req employer.GetCostCenterApprovalChainRequest,
+) (employer.CostCenterApprovalChain, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return employer.CostCenterApprovalChain{}, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    cc.cost_center_name,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('email', ou.email, 'name', ou.name, 'vetchi_handle', hu.handle) ORDER BY cca.step_number)
+        FROM cost_center_approvers cca
+            JOIN org_users ou ON cca.approver_id = ou.id
+            LEFT JOIN hub_users_official_emails hue ON ou.email = hue.official_email
+            LEFT JOIN hub_users hu ON hue.hub_user_id = hu.id
+        WHERE cca.cost_center_id = cc.id
+    ), '[]'::jsonb) AS approvers
+FROM
+    org_cost_centers cc
+WHERE
+    cc.cost_center_name = $1
+    AND cc.employer_id = $2
+`
+	var chain employer.CostCenterApprovalChain
+	err := p.pool.QueryRow(
+		ctx,
+		query,
+		req.CostCenterName,
+		orgUser.EmployerID,
+	).Scan(&chain.CostCenterName, &chain.Approvers)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return employer.CostCenterApprovalChain{}, db.ErrNoCostCenter
+		}
+		p.log.Err("failed to get approval chain", "error", err)
+		return employer.CostCenterApprovalChain{}, db.ErrInternal
+	}
+
+	return chain, nil
+}
+
+func (p *PG) SubmitOpeningForApproval(
+	ctx context.Context,
+	req db.SubmitOpeningForApprovalReq,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	// A rejected opening can be submitted again but not one that is already
+	// pending approval or is approved
+	query := `
+SELECT
+    o.state,
+    o.cost_center_id,
+    EXISTS (
+        SELECT 1
+        FROM opening_approvals oa
+        WHERE oa.employer_id = o.employer_id
+            AND oa.opening_id = o.id
+    ) AS submitted,
+    EXISTS (
+        SELECT 1
+        FROM opening_approvals oa
+        WHERE oa.employer_id = o.employer_id
+            AND oa.opening_id = o.id
+            AND oa.approval_state = $3
+    ) AS rejected
+FROM
+    openings o
+WHERE
+    o.id = $1
+    AND o.employer_id = $2
+FOR UPDATE
+`
+	var state common.OpeningState
+	var costCenterID *uuid.UUID
+	var submitted, rejected bool
+	err = tx.QueryRow(
+		ctx,
+		query,
+		req.OpeningID,
+		orgUser.EmployerID,
+		employer.OpeningRejected,
+	).Scan(&state, &costCenterID, &submitted, &rejected)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ErrNoOpening
+		}
+		p.log.Err("failed to get opening", "error", err)
+		return db.ErrInternal
+	}
+
+	if state != common.DraftOpening || (submitted && !rejected) {
+		p.log.Dbg("opening cannot be submitted", "state", state)
+		return db.ErrStateMismatch
+	}
+
+	if costCenterID == nil {
+		return db.ErrNoApprovalChain
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+DELETE FROM opening_approvals
+WHERE employer_id = $1
+    AND opening_id = $2
+`,
+		orgUser.EmployerID,
+		req.OpeningID,
+	)
+	if err != nil {
+		p.log.Err("failed to clear old approvals", "error", err)
+		return db.ErrInternal
+	}
+
+	result, err := tx.Exec(
+		ctx,
+		`
+INSERT INTO opening_approvals (employer_id, opening_id, step_number, approver_id, approval_state, requested_by)
+SELECT $1, $2, step_number, approver_id, $3, $4
+FROM cost_center_approvers
+WHERE cost_center_id = $5
+`,
+		orgUser.EmployerID,
+		req.OpeningID,
+		employer.OpeningPendingApproval,
+		orgUser.ID,
+		*costCenterID,
+	)
+	if err != nil {
+		p.log.Err("failed to insert opening approvals", "error", err)
+		return db.ErrInternal
+	}
+	if result.RowsAffected() == 0 {
+		return db.ErrNoApprovalChain
+	}
+
+	err = p.insertEmail(ctx, tx, req.ApprovalEmail)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetOpeningApprovals(
+	ctx context.Context,
+	req employer.GetOpeningApprovalsRequest,
+) (db.OpeningApprovals, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.OpeningApprovals{}, db.ErrInternal
+	}
+
+	var exists bool
+	err := p.pool.QueryRow(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM openings WHERE id = $1 AND employer_id = $2)`,
+		req.OpeningID,
+		orgUser.EmployerID,
+	).Scan(&exists)
+	if err != nil {
+		p.log.Err("failed to check opening", "error", err)
+		return db.OpeningApprovals{}, db.ErrInternal
+	}
+	if !exists {
+		return db.OpeningApprovals{}, db.ErrNoOpening
+	}
+
+	query := `
+SELECT
+    oa.step_number,
+    jsonb_build_object('email', a.email, 'name', a.name, 'vetchi_handle', hu_a.handle) AS approver,
+    oa.approval_state,
+    oa.comment,
+    oa.decided_at,
+    jsonb_build_object('email', rb.email, 'name', rb.name, 'vetchi_handle', hu_rb.handle) AS requested_by
+FROM
+    opening_approvals oa
+    JOIN org_users a ON oa.approver_id = a.id
+    LEFT JOIN hub_users_official_emails hue_a ON a.email = hue_a.official_email
+    LEFT JOIN hub_users hu_a ON hue_a.hub_user_id = hu_a.id
+    JOIN org_users rb ON oa.requested_by = rb.id
+    LEFT JOIN hub_users_official_emails hue_rb ON rb.email = hue_rb.official_email
+    LEFT JOIN hub_users hu_rb ON hue_rb.hub_user_id = hu_rb.id
+WHERE
+    oa.employer_id = $1
+    AND oa.opening_id = $2
+ORDER BY
+    oa.step_number
+`
+	rows, err := p.pool.Query(ctx, query, orgUser.EmployerID, req.OpeningID)
+	if err != nil {
+		p.log.Err("failed to query opening approvals", "error", err)
+		return db.OpeningApprovals{}, db.ErrInternal
+	}
+	defer rows.Close()
+
+	approvals := db.OpeningApprovals{
+		Steps: []employer.OpeningApprovalStep{},
+	}
+	for rows.Next() {
+		var step employer.OpeningApprovalStep
+		err = rows.Scan(
+			&step.StepNumber,
+			&step.Approver,
+			&step.State,
+			&step.Comment,
+			&step.DecidedAt,
+			&approvals.RequestedBy,
+		)
+		if err != nil {
+			p.log.Err("failed to scan opening approval", "error", err)
+			return db.OpeningApprovals{}, db.ErrInternal
+		}
+		approvals.Steps = append(approvals.Steps, step)
+	}
+	if err = rows.Err(); err != nil {
+		p.log.Err("failed to iterate opening approvals", "error", err)
+		return db.OpeningApprovals{}, db.ErrInternal
+	}
+
+	return approvals, nil
+}
+
+func (p *PG) ApproveOpening(
+	ctx context.Context,
+	req db.ApproveOpeningReq,
+) error {
+	return p.decideOpeningApproval(
+		ctx,
+		req.OpeningID,
+		req.StepNumber,
+		employer.OpeningApproved,
+		req.Comment,
+		req.NextApprovalEmail,
+	)
+}
+
+func (p *PG) RejectOpening(ctx context.Context, req db.RejectOpeningReq) error {
+	return p.decideOpeningApproval(
+		ctx,
+		req.OpeningID,
+		req.StepNumber,
+		employer.OpeningRejected,
+		&req.Comment,
+		&req.RejectionEmail,
+	)
+}
+
+// decideOpeningApproval records the decision of the current OrgUser for the
+// given step, provided that the step is the current step of the chain and is
+// assigned to the OrgUser
+func (p *PG) decideOpeningApproval(
+	ctx context.Context,
+	openingID string,
+	stepNumber int,
+	decision employer.OpeningApprovalState,
+	comment *string,
+	email *db.Email,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	query := `
+UPDATE opening_approvals oa
+SET
+    approval_state = $4,
+    comment = $5,
+    decided_at = timezone('UTC', now())
+WHERE
+    oa.employer_id = $1
+    AND oa.opening_id = $2
+    AND oa.step_number = $3
+    AND oa.approver_id = $6
+    AND oa.approval_state = $7
+    AND NOT EXISTS (
+        SELECT 1
+        FROM opening_approvals prev
+        WHERE prev.employer_id = oa.employer_id
+            AND prev.opening_id = oa.opening_id
+            AND prev.step_number < oa.step_number
+            AND prev.approval_state != $8
+    )
+`
+	result, err := tx.Exec(
+		ctx,
+		query,
+		orgUser.EmployerID,
+		openingID,
+		stepNumber,
+		decision,
+		comment,
+		orgUser.ID,
+		employer.OpeningPendingApproval,
+		employer.OpeningApproved,
+	)
+	if err != nil {
+		p.log.Err("failed to update opening approval", "error", err)
+		return db.ErrInternal
+	}
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("no approval waiting", "opening", openingID, "step", stepNumber)
+		return db.ErrNoOpeningApproval
+	}
+
+	if email != nil {
+		err = p.insertEmail(ctx, tx, *email)
+		if err != nil {
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetMyOpeningApprovals(
+	ctx context.Context,
+	req employer.GetMyOpeningApprovalsRequest,
+) ([]employer.MyOpeningApproval, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    o.id,
+    o.title,
+    cc.cost_center_name,
+    jsonb_build_object('email', rb.email, 'name', rb.name, 'vetchi_handle', hu_rb.handle) AS requested_by,
+    oa.requested_at,
+    oa.step_number
+FROM
+    opening_approvals oa
+    JOIN openings o ON oa.employer_id = o.employer_id AND oa.opening_id = o.id
+    JOIN org_cost_centers cc ON o.cost_center_id = cc.id
+    JOIN org_users rb ON oa.requested_by = rb.id
+    LEFT JOIN hub_users_official_emails hue_rb ON rb.email = hue_rb.official_email
+    LEFT JOIN hub_users hu_rb ON hue_rb.hub_user_id = hu_rb.id
+WHERE
+    oa.employer_id = $1
+    AND oa.approver_id = $2
+    AND oa.approval_state = $3
+    AND NOT EXISTS (
+        SELECT 1
+        FROM opening_approvals prev
+        WHERE prev.employer_id = oa.employer_id
+            AND prev.opening_id = oa.opening_id
+            AND prev.step_number < oa.step_number
+            AND prev.approval_state != $4
+    )
+    AND o.pagination_key > COALESCE((
+        SELECT pagination_key
+        FROM openings
+        WHERE employer_id = $1
+            AND id = $5
+    ), 0)
+ORDER BY
+    o.pagination_key
+LIMIT $6
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		orgUser.EmployerID,
+		orgUser.ID,
+		employer.OpeningPendingApproval,
+		employer.OpeningApproved,
+		req.PaginationKey,
+		req.Limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query my opening approvals", "error", err)
+		return nil, db.ErrInternal
+	}
+	defer rows.Close()
+
+	approvals := []employer.MyOpeningApproval{}
+	for rows.Next() {
+		var approval employer.MyOpeningApproval
+		err = rows.Scan(
+			&approval.OpeningID,
+			&approval.OpeningTitle,
+			&approval.CostCenterName,
+			&approval.RequestedBy,
+			&approval.RequestedAt,
+			&approval.StepNumber,
+		)
+		if err != nil {
+			p.log.Err("failed to scan my opening approval", "error", err)
+			return nil, db.ErrInternal
+		}
+		approvals = append(approvals, approval)
+	}
+	if err = rows.Err(); err != nil {
+		p.log.Err("failed to iterate my opening approvals", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return approvals, nil
+}
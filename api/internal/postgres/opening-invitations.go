@@ -0,0 +1,359 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func (p *PG) GetOpeningInvitationMailInfo(
+	ctx context.Context,
+	req employer.InviteToApplyRequest,
+) (db.OpeningInvitationMailInfo, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.OpeningInvitationMailInfo{}, db.ErrInternal
+	}
+
+	var mailInfo db.OpeningInvitationMailInfo
+
+	openingQuery := `
+SELECT
+	e.id as employer_id,
+	e.company_name,
+	d.domain_name as primary_domain,
+	o.id as opening_id,
+	o.title as opening_title
+FROM openings o
+JOIN employers e ON e.id = o.employer_id
+JOIN employer_primary_domains epd ON epd.employer_id = e.id
+JOIN domains d ON d.id = epd.domain_id
+WHERE o.id = $1
+AND o.employer_id = $2
+`
+	err := p.pool.QueryRow(
+		ctx,
+		openingQuery,
+		req.OpeningID,
+		orgUser.EmployerID,
+	).Scan(
+		&mailInfo.Employer.EmployerID,
+		&mailInfo.Employer.CompanyName,
+		&mailInfo.Employer.PrimaryDomain,
+		&mailInfo.Opening.OpeningID,
+		&mailInfo.Opening.Title,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("opening not found", "id", req.OpeningID)
+			return db.OpeningInvitationMailInfo{}, db.ErrNoOpening
+		}
+
+		p.log.Err("failed to get opening mail info", "error", err)
+		return db.OpeningInvitationMailInfo{}, db.ErrInternal
+	}
+
+	// Only the HubUsers who chose to be discoverable can be invited
+	hubUserQuery := `
+SELECT
+	h.id as hub_user_id,
+	h.state as hub_user_state,
+	h.full_name,
+	h.handle,
+	h.email,
+	h.preferred_language
+FROM hub_users h
+WHERE h.handle = $1
+AND h.state = $2
+AND h.discoverable
+`
+	err = p.pool.QueryRow(
+		ctx,
+		hubUserQuery,
+		req.Handle,
+		hub.ActiveHubUserState,
+	).Scan(
+		&mailInfo.HubUser.HubUserID,
+		&mailInfo.HubUser.State,
+		&mailInfo.HubUser.FullName,
+		&mailInfo.HubUser.Handle,
+		&mailInfo.HubUser.Email,
+		&mailInfo.HubUser.PreferredLanguage,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("discoverable hub user not found", "handle", req.Handle)
+			return db.OpeningInvitationMailInfo{}, db.ErrNoHubUser
+		}
+
+		p.log.Err("failed to get hub user mail info", "error", err)
+		return db.OpeningInvitationMailInfo{}, db.ErrInternal
+	}
+
+	return mailInfo, nil
+}
+
+func (p *PG) InviteToApply(ctx context.Context, req db.InviteToApplyReq) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	// The employer and the HubUser are locked, so that the concurrent
+	// invitations are counted against the caps one after the other
+	_, err = tx.Exec(
+		ctx,
+		`SELECT id FROM employers WHERE id = $1 FOR UPDATE`,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to lock employer", "error", err)
+		return db.ErrInternal
+	}
+
+	var openingState common.OpeningState
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT state
+FROM openings
+WHERE employer_id = $1 AND id = $2
+FOR UPDATE
+`,
+		orgUser.EmployerID,
+		req.OpeningID,
+	).Scan(&openingState)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("opening not found", "id", req.OpeningID)
+			return db.ErrNoOpening
+		}
+
+		p.log.Err("failed to get opening", "error", err)
+		return db.ErrInternal
+	}
+	if openingState != common.ActiveOpening {
+		p.log.Dbg("opening not active", "state", openingState)
+		return db.ErrOpeningNotActive
+	}
+
+	var discoverable bool
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT discoverable
+FROM hub_users
+WHERE id = $1 AND state = $2
+FOR UPDATE
+`,
+		req.HubUserID,
+		hub.ActiveHubUserState,
+	).Scan(&discoverable)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("hub user not found", "id", req.HubUserID)
+			return db.ErrNoHubUser
+		}
+
+		p.log.Err("failed to get hub user", "error", err)
+		return db.ErrInternal
+	}
+	if !discoverable {
+		p.log.Dbg("hub user not discoverable", "id", req.HubUserID)
+		return db.ErrNoHubUser
+	}
+
+	var alreadyInvited, invitationsToHubUser, invitationsByEmployer int
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+	(
+		SELECT COUNT(*)
+		FROM opening_invitations
+		WHERE employer_id = $1 AND opening_id = $2 AND hub_user_id = $3
+	) + (
+		SELECT COUNT(*)
+		FROM applications
+		WHERE employer_id = $1 AND opening_id = $2 AND hub_user_id = $3
+	),
+	(
+		SELECT COUNT(*)
+		FROM opening_invitations
+		WHERE hub_user_id = $3
+			AND created_at > timezone('UTC', now()) - make_interval(secs => $4)
+	),
+	(
+		SELECT COUNT(*)
+		FROM opening_invitations
+		WHERE employer_id = $1
+			AND created_at > timezone('UTC', now()) - make_interval(secs => $5)
+	)
+`,
+		orgUser.EmployerID,
+		req.OpeningID,
+		req.HubUserID,
+		vetchi.OpeningInvitationsPerHubUserWindow.Seconds(),
+		vetchi.OpeningInvitationsPerEmployerWindow.Seconds(),
+	).Scan(&alreadyInvited, &invitationsToHubUser, &invitationsByEmployer)
+	if err != nil {
+		p.log.Err("failed to count invitations", "error", err)
+		return db.ErrInternal
+	}
+
+	switch {
+	case alreadyInvited > 0:
+		p.log.Dbg("already invited or applied", "id", req.HubUserID)
+		return db.ErrAlreadyInvited
+	case invitationsToHubUser >= vetchi.MaxOpeningInvitationsPerHubUser:
+		p.log.Dbg("hub user invitation cap reached", "id", req.HubUserID)
+		return db.ErrInvitationCapReached
+	case invitationsByEmployer >= vetchi.MaxOpeningInvitationsPerEmployer:
+		p.log.Dbg("employer invitation quota exceeded")
+		return db.ErrInvitationQuotaExceeded
+	}
+
+	emailQuery := `
+INSERT INTO emails (email_from, email_to, email_subject, email_html_body, email_text_body, email_state)
+    VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING
+    email_key
+`
+	var emailKey string
+	err = tx.QueryRow(
+		ctx,
+		emailQuery,
+		req.Email.EmailFrom,
+		req.Email.EmailTo,
+		req.Email.EmailSubject,
+		req.Email.EmailHTMLBody,
+		req.Email.EmailTextBody,
+		req.Email.EmailState,
+	).Scan(&emailKey)
+	if err != nil {
+		p.log.Err("failed to insert email", "error", err)
+		return db.ErrInternal
+	}
+
+	invitationQuery := `
+INSERT INTO opening_invitations (id, employer_id, opening_id, hub_user_id, invited_by, message, email_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+	_, err = tx.Exec(
+		ctx,
+		invitationQuery,
+		req.InvitationID,
+		orgUser.EmployerID,
+		req.OpeningID,
+		req.HubUserID,
+		orgUser.ID,
+		req.Message,
+		emailKey,
+	)
+	if err != nil {
+		p.log.Err("failed to insert invitation", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	p.log.Dbg("invited to apply", "invitation_id", req.InvitationID)
+	return nil
+}
+
+// GetOpeningInvitations returns the invitations to apply that the HubUser
+// received, the latest first
+func (p *PG) GetOpeningInvitations(
+	ctx context.Context,
+	req hub.GetOpeningInvitationsRequest,
+) ([]hub.OpeningInvitation, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return nil, err
+	}
+
+	query := `
+SELECT
+	i.id,
+	o.id,
+	d.domain_name,
+	e.company_name,
+	o.title,
+	i.message,
+	i.created_at
+FROM opening_invitations i
+JOIN openings o ON o.employer_id = i.employer_id AND o.id = i.opening_id
+JOIN employers e ON e.id = i.employer_id
+JOIN employer_primary_domains epd ON epd.employer_id = e.id
+JOIN domains d ON d.id = epd.domain_id
+WHERE i.hub_user_id = $1
+`
+	args := []interface{}{hubUserID}
+
+	if req.PaginationKey != nil {
+		query += `
+	AND (i.created_at, i.id) < (
+		SELECT created_at, id
+		FROM opening_invitations
+		WHERE id = $2 AND hub_user_id = $1
+	)
+`
+		args = append(args, *req.PaginationKey)
+	}
+
+	query += `
+ORDER BY i.created_at DESC, i.id DESC
+LIMIT $` + fmt.Sprint(len(args)+1)
+	args = append(args, req.Limit)
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		p.log.Err("failed to query opening invitations", "error", err)
+		return nil, err
+	}
+
+	invitations, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (hub.OpeningInvitation, error) {
+			var invitation hub.OpeningInvitation
+			err := row.Scan(
+				&invitation.InvitationID,
+				&invitation.OpeningIDWithinCompany,
+				&invitation.CompanyDomain,
+				&invitation.CompanyName,
+				&invitation.JobTitle,
+				&invitation.Message,
+				&invitation.InvitedAt,
+			)
+			return invitation, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect opening invitations", "error", err)
+		return nil, err
+	}
+
+	return invitations, nil
+}
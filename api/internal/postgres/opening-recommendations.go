@@ -0,0 +1,490 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// recommendationProfileColumns are the columns of the profile of the
+// hub_users hu, in the order of recommendationProfileDest. The tags of the
+// HubUser are those of their posts and of the Openings that they applied to,
+// except those of the Openings that they are not interested in.
+const recommendationProfileColumns = `
+	hu.id,
+	hu.resident_country_code,
+	COALESCE(hu.resident_city, ''),
+	hu.short_bio,
+	hu.long_bio,
+	ARRAY(
+		SELECT wh.title
+		FROM work_history wh
+		WHERE wh.hub_user_id = hu.id
+		ORDER BY wh.start_date DESC
+	),
+	ARRAY(
+		SELECT wh.description
+		FROM work_history wh
+		WHERE wh.hub_user_id = hu.id AND wh.description IS NOT NULL
+		ORDER BY wh.start_date DESC
+	),
+	ARRAY(
+		SELECT o.title
+		FROM applications a
+		JOIN openings o
+			ON o.employer_id = a.employer_id AND o.id = a.opening_id
+		WHERE a.hub_user_id = hu.id
+		ORDER BY a.created_at DESC
+	),
+	ARRAY(
+		SELECT t.display_name
+		FROM tags t
+		WHERE t.id IN (
+			SELECT pt.tag_id
+			FROM post_tags pt
+			JOIN posts po ON po.id = pt.post_id
+			WHERE po.author_id = hu.id
+			UNION
+			SELECT otm.tag_id
+			FROM applications a
+			JOIN opening_tag_mappings otm
+				ON otm.employer_id = a.employer_id
+				AND otm.opening_id = a.opening_id
+			WHERE a.hub_user_id = hu.id
+		)
+		AND t.id NOT IN (
+			SELECT otm.tag_id
+			FROM opening_recommendation_feedback f
+			JOIN opening_tag_mappings otm
+				ON otm.employer_id = f.employer_id
+				AND otm.opening_id = f.opening_id
+			WHERE f.hub_user_id = hu.id AND f.feedback = 'NOT_INTERESTED'
+		)
+		ORDER BY t.display_name
+	)`
+
+func recommendationProfileDest(profile *db.RecommendationProfile) []any {
+	return []any{
+		&profile.HubUserID,
+		&profile.ResidentCountryCode,
+		&profile.ResidentCity,
+		&profile.ShortBio,
+		&profile.LongBio,
+		&profile.WorkHistoryTitles,
+		&profile.WorkHistoryDescriptions,
+		&profile.AppliedOpeningTitles,
+		&profile.Tags,
+	}
+}
+
+// GetStaleRecommendationProfiles returns the profiles of the ACTIVE
+// HubUsers whose recommendations are never computed or are older than
+// vetchi.RecommendationsRefreshAge, the oldest first
+func (p *PG) GetStaleRecommendationProfiles(
+	ctx context.Context,
+	limit int,
+) ([]db.RecommendationProfile, error) {
+	query := `
+SELECT` + recommendationProfileColumns + `
+FROM hub_users hu
+WHERE hu.state = $1
+	AND (
+		hu.recommendations_refreshed_at IS NULL
+		OR hu.recommendations_refreshed_at <
+			timezone('UTC', now()) - make_interval(secs => $2)
+	)
+ORDER BY hu.recommendations_refreshed_at NULLS FIRST
+LIMIT $3
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		hub.ActiveHubUserState,
+		vetchi.RecommendationsRefreshAge.Seconds(),
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query recommendation profiles", "error", err)
+		return nil, err
+	}
+
+	profiles, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.RecommendationProfile, error) {
+			var profile db.RecommendationProfile
+			err := row.Scan(recommendationProfileDest(&profile)...)
+			return profile, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect recommendation profiles", "error", err)
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// GetRecommendationCandidates returns the ACTIVE Openings that the HubUser
+// can apply to, and has not given a feedback on, that share the country,
+// the city or a tag with the HubUser. Those that share the most tags come
+// first, then those in the city and then those in the country.
+func (p *PG) GetRecommendationCandidates(
+	ctx context.Context,
+	profile db.RecommendationProfile,
+	limit int,
+) ([]db.RecommendationCandidate, error) {
+	query := `
+WITH candidates AS (
+	SELECT
+		o.employer_id,
+		o.id,
+		o.title,
+		o.jd,
+		o.created_at,
+		ARRAY(
+			SELECT t.display_name
+			FROM opening_tag_mappings otm
+			JOIN tags t ON t.id = otm.tag_id
+			WHERE otm.employer_id = o.employer_id AND otm.opening_id = o.id
+			ORDER BY t.display_name
+		) AS tags,
+		(
+			$3 = ANY(COALESCE(o.remote_country_codes, '{}'))
+			OR $4 = ANY(COALESCE(o.remote_country_codes, '{}'))
+			OR EXISTS (
+				SELECT 1
+				FROM opening_locations ol
+				JOIN locations l ON l.id = ol.location_id
+				WHERE ol.employer_id = o.employer_id
+					AND ol.opening_id = o.id
+					AND l.country_code = $3
+			)
+		) AS in_resident_country,
+		(
+			$5 <> '' AND EXISTS (
+				SELECT 1
+				FROM opening_locations ol
+				JOIN locations l ON l.id = ol.location_id
+				CROSS JOIN unnest(l.city_aka) AS city
+				WHERE ol.employer_id = o.employer_id
+					AND ol.opening_id = o.id
+					AND l.country_code = $3
+					AND lower(city) = lower($5)
+			)
+		) AS in_resident_city
+	FROM openings o
+	WHERE o.state = $2
+		AND NOT EXISTS (
+			SELECT 1
+			FROM opening_recommendation_feedback f
+			WHERE f.hub_user_id = $1
+				AND f.employer_id = o.employer_id
+				AND f.opening_id = o.id
+		)
+		AND can_apply($1, o.employer_id, o.id)
+)
+SELECT
+	employer_id,
+	id,
+	title,
+	jd,
+	tags,
+	in_resident_country,
+	in_resident_city
+FROM candidates
+WHERE in_resident_country OR tags && $6::TEXT[]
+ORDER BY
+	cardinality(ARRAY(
+		SELECT unnest(tags) INTERSECT SELECT unnest($6::TEXT[])
+	)) DESC,
+	in_resident_city DESC,
+	in_resident_country DESC,
+	created_at DESC
+LIMIT $7
+`
+	tags := profile.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		profile.HubUserID,
+		common.ActiveOpening,
+		profile.ResidentCountryCode,
+		common.GlobalCountryCode,
+		profile.ResidentCity,
+		tags,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query recommendation candidates", "error", err)
+		return nil, err
+	}
+
+	candidates, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.RecommendationCandidate, error) {
+			var candidate db.RecommendationCandidate
+			err := row.Scan(
+				&candidate.EmployerID,
+				&candidate.OpeningID,
+				&candidate.Title,
+				&candidate.JD,
+				&candidate.Tags,
+				&candidate.InResidentCountry,
+				&candidate.InResidentCity,
+			)
+			return candidate, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect recommendation candidates", "error", err)
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+func (p *PG) SaveRecommendations(
+	ctx context.Context,
+	req db.SaveRecommendationsReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("Failed to begin transaction", "error", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(
+		ctx,
+		`DELETE FROM opening_recommendations WHERE hub_user_id = $1`,
+		req.HubUserID,
+	)
+	if err != nil {
+		p.log.Err("failed to delete old recommendations", "error", err)
+		return err
+	}
+
+	query := `
+INSERT INTO opening_recommendations
+	(hub_user_id, employer_id, opening_id, score, reasons)
+VALUES ($1, $2, $3, $4, $5)
+`
+	for _, recommendation := range req.Recommendations {
+		_, err = tx.Exec(
+			ctx,
+			query,
+			req.HubUserID,
+			recommendation.EmployerID,
+			recommendation.OpeningID,
+			recommendation.Score,
+			recommendation.Reasons,
+		)
+		if err != nil {
+			p.log.Err("INSERT to opening_recommendations failed", "error", err)
+			return err
+		}
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE hub_users
+SET recommendations_refreshed_at = timezone('UTC', now())
+WHERE id = $1
+`,
+		req.HubUserID,
+	)
+	if err != nil {
+		p.log.Err("failed to update recommendations_refreshed_at", "error", err)
+		return err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetRecommendedOpenings returns the recommendations of the HubUser that
+// are still ACTIVE and not applied to, the best first
+func (p *PG) GetRecommendedOpenings(
+	ctx context.Context,
+	req hub.GetRecommendedOpeningsRequest,
+) ([]hub.RecommendedOpening, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hubUserID", "error", err)
+		return nil, err
+	}
+
+	limit := int64(vetchi.MaxRecommendedOpenings)
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	query := `
+SELECT
+	o.id,
+	d.domain_name,
+	e.company_name,
+	o.title,
+	r.score,
+	r.reasons,
+	r.created_at
+FROM opening_recommendations r
+JOIN openings o ON o.employer_id = r.employer_id AND o.id = r.opening_id
+JOIN employers e ON e.id = o.employer_id
+JOIN employer_primary_domains epd ON epd.employer_id = e.id
+JOIN domains d ON d.id = epd.domain_id
+WHERE r.hub_user_id = $1
+	AND o.state = $2
+	AND NOT EXISTS (
+		SELECT 1
+		FROM applications a
+		WHERE a.hub_user_id = r.hub_user_id
+			AND a.employer_id = r.employer_id
+			AND a.opening_id = r.opening_id
+	)
+ORDER BY r.score DESC, o.pagination_key DESC
+LIMIT $3
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		hubUserID,
+		common.ActiveOpening,
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query recommended openings", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	openings, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (hub.RecommendedOpening, error) {
+			var opening hub.RecommendedOpening
+			err := row.Scan(
+				&opening.OpeningIDWithinCompany,
+				&opening.CompanyDomain,
+				&opening.CompanyName,
+				&opening.JobTitle,
+				&opening.Score,
+				&opening.Reasons,
+				&opening.RecommendedAt,
+			)
+			return opening, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect recommended openings", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return openings, nil
+}
+
+// DismissRecommendedOpening records the feedback of the HubUser on an
+// Opening, so that it is not recommended again. The recommendations are
+// refreshed at the earliest if the HubUser is not interested, as the tags
+// of the HubUser change.
+func (p *PG) DismissRecommendedOpening(
+	ctx context.Context,
+	req hub.DismissRecommendedOpeningRequest,
+) error {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hubUserID", "error", err)
+		return err
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("Failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	query := `
+INSERT INTO opening_recommendation_feedback
+	(hub_user_id, employer_id, opening_id, feedback)
+SELECT $1, o.employer_id, o.id, $4
+FROM openings o
+JOIN employer_primary_domains epd ON epd.employer_id = o.employer_id
+JOIN domains d ON d.id = epd.domain_id
+WHERE o.id = $2 AND d.domain_name = $3
+ON CONFLICT (hub_user_id, employer_id, opening_id) DO UPDATE
+SET feedback = EXCLUDED.feedback,
+	created_at = timezone('UTC', now())
+RETURNING employer_id
+`
+	var employerID uuid.UUID
+	err = tx.QueryRow(
+		ctx,
+		query,
+		hubUserID,
+		req.OpeningIDWithinCompany,
+		req.CompanyDomain,
+		req.Feedback,
+	).Scan(&employerID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			p.log.Dbg("opening not found", "id", req.OpeningIDWithinCompany)
+			return db.ErrNoOpening
+		}
+		p.log.Err("failed to save recommendation feedback", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+DELETE FROM opening_recommendations
+WHERE hub_user_id = $1 AND employer_id = $2 AND opening_id = $3
+`,
+		hubUserID,
+		employerID,
+		req.OpeningIDWithinCompany,
+	)
+	if err != nil {
+		p.log.Err("failed to delete the recommendation", "error", err)
+		return db.ErrInternal
+	}
+
+	if req.Feedback == hub.NotInterestedRecommendation {
+		_, err = tx.Exec(
+			ctx,
+			`
+UPDATE hub_users
+SET recommendations_refreshed_at = NULL
+WHERE id = $1
+`,
+			hubUserID,
+		)
+		if err != nil {
+			p.log.Err("failed to reset recommendations", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,365 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func (p *PG) SetOpeningSchedule(
+	ctx context.Context,
+	req employer.SetOpeningScheduleRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var state common.OpeningState
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT state
+FROM openings
+WHERE id = $1
+    AND employer_id = $2
+FOR UPDATE
+`,
+		req.OpeningID,
+		orgUser.EmployerID,
+	).Scan(&state)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ErrNoOpening
+		}
+		p.log.Err("failed to get opening", "error", err)
+		return db.ErrInternal
+	}
+
+	if state == common.ClosedOpening {
+		p.log.Dbg("opening is closed", "opening_id", req.OpeningID)
+		return db.ErrStateMismatch
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE openings
+SET publish_at = $1,
+    close_at = $2,
+    max_applications = $3,
+    max_applications_reached_at = CASE
+        WHEN max_applications IS DISTINCT FROM $3 THEN NULL
+        ELSE max_applications_reached_at
+    END,
+    last_updated_at = timezone('UTC', now())
+WHERE id = $4
+    AND employer_id = $5
+`,
+		req.PublishAt,
+		req.CloseAt,
+		req.MaxApplications,
+		req.OpeningID,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to set opening schedule", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// GetDueOpeningScheduleChanges returns up to limit opening state transitions
+// and up to limit application expiries that are due as of now. An opening
+// whose close_at has passed is closed rather than published or suspended. A
+// DRAFT opening is published only if it passes the approval checks that
+// ChangeOpeningState applies for a manual publish. An ACTIVE opening is
+// suspended for reaching its max_applications only once, so that the
+// employer could reactivate it to take more applications. Only the APPLIED
+// and SHORTLISTED applications count towards max_applications, as the
+// withdrawn, rejected and expired ones no longer take up a slot.
+func (p *PG) GetDueOpeningScheduleChanges(
+	ctx context.Context,
+	limit int,
+) (db.OpeningScheduleChanges, error) {
+	transitionsQuery := `
+WITH due AS (
+    SELECT o.employer_id, o.id, o.title, o.state AS from_state, $2::opening_states AS to_state, o.close_at AS due_at
+    FROM openings o
+    WHERE o.state = ANY($3::opening_states[])
+        AND o.close_at <= timezone('UTC', now())
+    UNION ALL
+    SELECT o.employer_id, o.id, o.title, o.state, $4::opening_states, o.publish_at
+    FROM openings o
+    WHERE o.state = $5::opening_states
+        AND o.publish_at <= timezone('UTC', now())
+        AND (o.close_at IS NULL OR o.close_at > timezone('UTC', now()))
+        AND
+        CASE
+            WHEN EXISTS (
+                SELECT 1
+                FROM opening_approvals oa
+                WHERE oa.employer_id = o.employer_id AND oa.opening_id = o.id
+            ) THEN NOT EXISTS (
+                SELECT 1
+                FROM opening_approvals oa
+                WHERE oa.employer_id = o.employer_id
+                    AND oa.opening_id = o.id
+                    AND oa.approval_state != $6
+            )
+            ELSE NOT EXISTS (
+                SELECT 1
+                FROM cost_center_approvers cca
+                WHERE cca.cost_center_id = o.cost_center_id
+            )
+        END
+    UNION ALL
+    SELECT o.employer_id, o.id, o.title, o.state, $7::opening_states, o.last_updated_at
+    FROM openings o
+    WHERE o.state = $4::opening_states
+        AND o.max_applications IS NOT NULL
+        AND o.max_applications_reached_at IS NULL
+        AND (o.close_at IS NULL OR o.close_at > timezone('UTC', now()))
+        AND (
+            SELECT COUNT(*)
+            FROM applications a
+            WHERE a.employer_id = o.employer_id
+                AND a.opening_id = o.id
+                AND a.application_state = ANY($8::application_states[])
+        ) >= o.max_applications
+)
+SELECT
+    due.employer_id,
+    due.id,
+    due.title,
+    due.from_state,
+    due.to_state,
+    ARRAY(
+        SELECT ou.email
+        FROM opening_watchers ow
+            JOIN org_users ou ON ou.id = ow.watcher_id
+        WHERE ow.employer_id = due.employer_id AND ow.opening_id = due.id
+        ORDER BY ou.email
+    ) AS watchers
+FROM due
+ORDER BY due.due_at
+LIMIT $1
+`
+	rows, err := p.pool.Query(
+		ctx,
+		transitionsQuery,
+		limit,
+		common.ClosedOpening,
+		[]string{
+			string(common.ActiveOpening),
+			string(common.SuspendedOpening),
+		},
+		common.ActiveOpening,
+		common.DraftOpening,
+		employer.OpeningApproved,
+		common.SuspendedOpening,
+		[]string{
+			string(common.AppliedAppState),
+			string(common.ShortlistedAppState),
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to query due opening transitions", "error", err)
+		return db.OpeningScheduleChanges{}, db.ErrInternal
+	}
+
+	var changes db.OpeningScheduleChanges
+	changes.Transitions, err = pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.OpeningScheduleTransition, error) {
+			var t db.OpeningScheduleTransition
+			err := row.Scan(
+				&t.EmployerID,
+				&t.OpeningID,
+				&t.OpeningTitle,
+				&t.FromState,
+				&t.ToState,
+				&t.Watchers,
+			)
+			return t, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan due opening transitions", "error", err)
+		return db.OpeningScheduleChanges{}, db.ErrInternal
+	}
+
+	expiriesQuery := `
+SELECT
+    a.employer_id,
+    a.id,
+    o.id,
+    o.title,
+    e.company_name,
+    d.domain_name,
+    h.full_name,
+    h.email,
+    ARRAY(
+        SELECT ou.email
+        FROM opening_watchers ow
+            JOIN org_users ou ON ou.id = ow.watcher_id
+        WHERE ow.employer_id = o.employer_id AND ow.opening_id = o.id
+        ORDER BY ou.email
+    ) AS watchers
+FROM applications a
+    JOIN openings o ON o.employer_id = a.employer_id AND o.id = a.opening_id
+    JOIN employers e ON e.id = a.employer_id
+    JOIN employer_primary_domains epd ON epd.employer_id = e.id
+    JOIN domains d ON d.id = epd.domain_id
+    JOIN hub_users h ON h.id = a.hub_user_id
+WHERE a.application_state = $2
+    AND e.application_expiry_days > 0
+    AND a.created_at <= timezone('UTC', now()) - make_interval(days => e.application_expiry_days)
+ORDER BY a.created_at
+LIMIT $1
+`
+	rows, err = p.pool.Query(
+		ctx,
+		expiriesQuery,
+		limit,
+		common.AppliedAppState,
+	)
+	if err != nil {
+		p.log.Err("failed to query expired applications", "error", err)
+		return db.OpeningScheduleChanges{}, db.ErrInternal
+	}
+
+	changes.ExpiredApplications, err = pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.ExpiredApplication, error) {
+			var a db.ExpiredApplication
+			err := row.Scan(
+				&a.EmployerID,
+				&a.ApplicationID,
+				&a.OpeningID,
+				&a.OpeningTitle,
+				&a.CompanyName,
+				&a.PrimaryDomain,
+				&a.HubUserFullName,
+				&a.HubUserEmail,
+				&a.Watchers,
+			)
+			return a, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan expired applications", "error", err)
+		return db.OpeningScheduleChanges{}, db.ErrInternal
+	}
+
+	return changes, nil
+}
+
+// ApplyOpeningScheduleChanges applies the changes and queues the emails in a
+// single transaction. If any of the openings or applications is no longer in
+// the expected state, nothing is applied and db.ErrStateMismatch is returned,
+// so that the changes could be recomputed and retried.
+func (p *PG) ApplyOpeningScheduleChanges(
+	ctx context.Context,
+	req db.ApplyOpeningScheduleChangesReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	for _, t := range req.Changes.Transitions {
+		result, err := tx.Exec(
+			ctx,
+			`
+UPDATE openings
+SET state = $1,
+    max_applications_reached_at = CASE
+        WHEN $1 = $5 THEN timezone('UTC', now())
+        ELSE max_applications_reached_at
+    END,
+    last_updated_at = timezone('UTC', now())
+WHERE employer_id = $2
+    AND id = $3
+    AND state = $4
+`,
+			t.ToState,
+			t.EmployerID,
+			t.OpeningID,
+			t.FromState,
+			common.SuspendedOpening,
+		)
+		if err != nil {
+			p.log.Err("failed to change opening state", "error", err)
+			return db.ErrInternal
+		}
+
+		if result.RowsAffected() != 1 {
+			p.log.Dbg("opening state changed meanwhile", "transition", t)
+			return db.ErrStateMismatch
+		}
+	}
+
+	for _, a := range req.Changes.ExpiredApplications {
+		result, err := tx.Exec(
+			ctx,
+			`
+UPDATE applications
+SET application_state = $1
+WHERE id = $2
+    AND employer_id = $3
+    AND application_state = $4
+`,
+			common.ExpiredAppState,
+			a.ApplicationID,
+			a.EmployerID,
+			common.AppliedAppState,
+		)
+		if err != nil {
+			p.log.Err("failed to expire application", "error", err)
+			return db.ErrInternal
+		}
+
+		if result.RowsAffected() != 1 {
+			p.log.Dbg("application state changed meanwhile", "application", a)
+			return db.ErrStateMismatch
+		}
+	}
+
+	for _, email := range req.Emails {
+		err = p.insertEmail(ctx, tx, email)
+		if err != nil {
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,575 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+const openingTemplateSelect = `
+SELECT
+    t.id,
+    t.name,
+    t.description,
+    t.title,
+    t.positions,
+    t.jd,
+    jsonb_build_object('email', r.email, 'name', r.name, 'vetchi_handle', hu_r.handle) AS recruiter,
+    jsonb_build_object('email', hm.email, 'name', hm.name, 'vetchi_handle', hu_hm.handle) AS hiring_manager,
+    cc.cost_center_name,
+    t.employer_notes,
+    t.remote_country_codes,
+    t.remote_timezones,
+    t.opening_type,
+    t.yoe_min,
+    t.yoe_max,
+    t.min_education_level,
+    t.salary_min,
+    t.salary_max,
+    t.salary_currency,
+    t.created_at,
+    t.last_updated_at,
+    ARRAY(
+        SELECT l.title
+        FROM opening_template_locations otl
+        JOIN locations l ON otl.location_id = l.id
+        WHERE otl.template_id = t.id
+        ORDER BY l.title
+    ) AS locations,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('email', ht.email, 'name', ht.name, 'vetchi_handle', hu_ht.handle) ORDER BY ht.email)
+        FROM opening_template_hiring_team otht
+        JOIN org_users ht ON otht.hiring_team_mate_id = ht.id
+        LEFT JOIN hub_users_official_emails hue_ht ON ht.email = hue_ht.official_email
+        LEFT JOIN hub_users hu_ht ON hue_ht.hub_user_id = hu_ht.id
+        WHERE otht.template_id = t.id
+    ), '[]'::jsonb) AS hiring_team,
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('id', tg.id, 'name', tg.display_name) ORDER BY tg.id)
+        FROM opening_template_tag_mappings ottm
+        JOIN tags tg ON ottm.tag_id = tg.id
+        WHERE ottm.template_id = t.id
+    ), '[]'::jsonb) AS tags
+FROM
+    opening_templates t
+    JOIN org_cost_centers cc ON t.cost_center_id = cc.id
+    JOIN org_users r ON t.recruiter = r.id
+    LEFT JOIN hub_users_official_emails hue_r ON r.email = hue_r.official_email
+    LEFT JOIN hub_users hu_r ON hue_r.hub_user_id = hu_r.id
+    JOIN org_users hm ON t.hiring_manager = hm.id
+    LEFT JOIN hub_users_official_emails hue_hm ON hm.email = hue_hm.official_email
+    LEFT JOIN hub_users hu_hm ON hue_hm.hub_user_id = hu_hm.id
+`
+
+func (p *PG) CreateOpeningTemplate(
+	ctx context.Context,
+	req employer.CreateOpeningTemplateRequest,
+) (employer.OpeningTemplateID, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return "", db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return "", db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	salaryMin, salaryMax, currency := salaryColumns(req.Salary)
+
+	query := `
+INSERT INTO opening_templates (employer_id, name, description, title, positions, jd, recruiter, hiring_manager, cost_center_id, employer_notes, remote_country_codes, remote_timezones, opening_type, yoe_min, yoe_max, min_education_level, salary_min, salary_max, salary_currency, created_by)
+    VALUES ($1, $2, $3, $4, $5, $6,
+        (SELECT id FROM org_users WHERE email = $7 AND employer_id = $1),
+        (SELECT id FROM org_users WHERE email = $8 AND employer_id = $1),
+        (SELECT id FROM org_cost_centers WHERE cost_center_name = $9 AND employer_id = $1),
+        $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+RETURNING
+    id
+`
+	var templateID uuid.UUID
+	err = tx.QueryRow(
+		ctx,
+		query,
+		orgUser.EmployerID,
+		req.Name,
+		req.Description,
+		req.Title,
+		req.Positions,
+		req.JD,
+		req.Recruiter,
+		req.HiringManager,
+		req.CostCenterName,
+		req.EmployerNotes,
+		req.RemoteCountryCodes,
+		req.RemoteTimezones,
+		req.OpeningType,
+		req.YoeMin,
+		req.YoeMax,
+		req.MinEducationLevel,
+		salaryMin,
+		salaryMax,
+		currency,
+		orgUser.ID,
+	).Scan(&templateID)
+	if err != nil {
+		return "", p.openingTemplateWriteErr(err)
+	}
+
+	err = p.setOpeningTemplateRelations(ctx, tx, orgUser, templateID, req)
+	if err != nil {
+		return "", err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return "", db.ErrInternal
+	}
+
+	return employer.OpeningTemplateID(templateID.String()), nil
+}
+
+func (p *PG) UpdateOpeningTemplate(
+	ctx context.Context,
+	req employer.UpdateOpeningTemplateRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	templateID, err := uuid.Parse(string(req.ID))
+	if err != nil {
+		p.log.Dbg("invalid template ID", "id", req.ID)
+		return db.ErrNoOpeningTemplate
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	salaryMin, salaryMax, currency := salaryColumns(req.Salary)
+
+	query := `
+UPDATE opening_templates
+SET
+    name = $3,
+    description = $4,
+    title = $5,
+    positions = $6,
+    jd = $7,
+    recruiter = (SELECT id FROM org_users WHERE email = $8 AND employer_id = $2),
+    hiring_manager = (SELECT id FROM org_users WHERE email = $9 AND employer_id = $2),
+    cost_center_id = (SELECT id FROM org_cost_centers WHERE cost_center_name = $10 AND employer_id = $2),
+    employer_notes = $11,
+    remote_country_codes = $12,
+    remote_timezones = $13,
+    opening_type = $14,
+    yoe_min = $15,
+    yoe_max = $16,
+    min_education_level = $17,
+    salary_min = $18,
+    salary_max = $19,
+    salary_currency = $20,
+    last_updated_at = timezone('UTC', now())
+WHERE
+    id = $1
+    AND employer_id = $2
+RETURNING
+    id
+`
+	err = tx.QueryRow(
+		ctx,
+		query,
+		templateID,
+		orgUser.EmployerID,
+		req.Name,
+		req.Description,
+		req.Title,
+		req.Positions,
+		req.JD,
+		req.Recruiter,
+		req.HiringManager,
+		req.CostCenterName,
+		req.EmployerNotes,
+		req.RemoteCountryCodes,
+		req.RemoteTimezones,
+		req.OpeningType,
+		req.YoeMin,
+		req.YoeMax,
+		req.MinEducationLevel,
+		salaryMin,
+		salaryMax,
+		currency,
+	).Scan(&templateID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("template not found", "id", templateID)
+			return db.ErrNoOpeningTemplate
+		}
+		return p.openingTemplateWriteErr(err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+DELETE FROM opening_template_hiring_team WHERE template_id = $1;
+`,
+		templateID,
+	)
+	if err != nil {
+		p.log.Err("failed to clear template hiring team", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+DELETE FROM opening_template_locations WHERE template_id = $1;
+`,
+		templateID,
+	)
+	if err != nil {
+		p.log.Err("failed to clear template locations", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+DELETE FROM opening_template_tag_mappings WHERE template_id = $1;
+`,
+		templateID,
+	)
+	if err != nil {
+		p.log.Err("failed to clear template tags", "error", err)
+		return db.ErrInternal
+	}
+
+	err = p.setOpeningTemplateRelations(
+		ctx,
+		tx,
+		orgUser,
+		templateID,
+		req.CreateOpeningTemplateRequest,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetOpeningTemplate(
+	ctx context.Context,
+	req employer.GetOpeningTemplateRequest,
+) (employer.OpeningTemplate, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return employer.OpeningTemplate{}, db.ErrInternal
+	}
+
+	templateID, err := uuid.Parse(string(req.ID))
+	if err != nil {
+		p.log.Dbg("invalid template ID", "id", req.ID)
+		return employer.OpeningTemplate{}, db.ErrNoOpeningTemplate
+	}
+
+	query := openingTemplateSelect + `
+WHERE
+    t.id = $1
+    AND t.employer_id = $2
+`
+	rows, err := p.pool.Query(ctx, query, templateID, orgUser.EmployerID)
+	if err != nil {
+		p.log.Err("failed to query opening template", "error", err)
+		return employer.OpeningTemplate{}, db.ErrInternal
+	}
+
+	templates, err := pgx.CollectRows(rows, scanOpeningTemplate)
+	if err != nil {
+		p.log.Err("failed to scan opening template", "error", err)
+		return employer.OpeningTemplate{}, db.ErrInternal
+	}
+
+	if len(templates) == 0 {
+		return employer.OpeningTemplate{}, db.ErrNoOpeningTemplate
+	}
+
+	return templates[0], nil
+}
+
+func (p *PG) FilterOpeningTemplates(
+	ctx context.Context,
+	req employer.FilterOpeningTemplatesRequest,
+) ([]employer.OpeningTemplate, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	query := openingTemplateSelect + `
+WHERE
+    t.employer_id = $1
+    AND t.name > $2
+    AND t.name ILIKE $3 || '%'
+ORDER BY
+    t.name
+LIMIT $4
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		orgUser.EmployerID,
+		req.PaginationKey,
+		req.NamePrefix,
+		req.Limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query opening templates", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	templates, err := pgx.CollectRows(rows, scanOpeningTemplate)
+	if err != nil {
+		p.log.Err("failed to scan opening templates", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return templates, nil
+}
+
+func (p *PG) DeleteOpeningTemplate(
+	ctx context.Context,
+	req employer.DeleteOpeningTemplateRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	templateID, err := uuid.Parse(string(req.ID))
+	if err != nil {
+		p.log.Dbg("invalid template ID", "id", req.ID)
+		return db.ErrNoOpeningTemplate
+	}
+
+	query := `
+DELETE FROM opening_templates
+WHERE id = $1
+    AND employer_id = $2
+`
+	result, err := p.pool.Exec(ctx, query, templateID, orgUser.EmployerID)
+	if err != nil {
+		p.log.Err("failed to delete opening template", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		return db.ErrNoOpeningTemplate
+	}
+
+	return nil
+}
+
+// setOpeningTemplateRelations validates and inserts the hiring team,
+// locations and tags of a template. Any existing relations should have been
+// removed by the caller.
+func (p *PG) setOpeningTemplateRelations(
+	ctx context.Context,
+	tx pgx.Tx,
+	orgUser db.OrgUserTO,
+	templateID uuid.UUID,
+	req employer.CreateOpeningTemplateRequest,
+) error {
+	if len(req.HiringTeam) > 0 {
+		query := `
+INSERT INTO opening_template_hiring_team (template_id, hiring_team_mate_id)
+SELECT $1, id
+FROM org_users
+WHERE email = ANY($2)
+AND employer_id = $3
+AND org_user_state IN ('ACTIVE_ORG_USER', 'REPLICATED_ORG_USER')
+`
+		result, err := tx.Exec(
+			ctx,
+			query,
+			templateID,
+			uniqueEmails(req.HiringTeam),
+			orgUser.EmployerID,
+		)
+		if err != nil {
+			p.log.Err("failed to insert template hiring team", "error", err)
+			return db.ErrInternal
+		}
+		if int(result.RowsAffected()) != len(uniqueEmails(req.HiringTeam)) {
+			p.log.Dbg("invalid hiring team", "team", req.HiringTeam)
+			return db.ErrInvalidHiringTeam
+		}
+	}
+
+	if len(req.LocationTitles) > 0 {
+		query := `
+INSERT INTO opening_template_locations (template_id, location_id)
+SELECT $1, l.id
+FROM locations l
+WHERE l.title = ANY($2)
+AND l.employer_id = $3
+`
+		result, err := tx.Exec(
+			ctx,
+			query,
+			templateID,
+			req.LocationTitles,
+			orgUser.EmployerID,
+		)
+		if err != nil {
+			p.log.Err("failed to insert template locations", "error", err)
+			return db.ErrInternal
+		}
+		if int(result.RowsAffected()) != len(req.LocationTitles) {
+			p.log.Dbg("invalid locations", "locations", req.LocationTitles)
+			return db.ErrNoLocation
+		}
+	}
+
+	if len(req.TagIDs) > 0 {
+		query := `
+INSERT INTO opening_template_tag_mappings (template_id, tag_id)
+SELECT $1, id
+FROM tags
+WHERE id = ANY($2::text[])
+`
+		result, err := tx.Exec(ctx, query, templateID, req.TagIDs)
+		if err != nil {
+			p.log.Err("failed to insert template tags", "error", err)
+			return db.ErrInternal
+		}
+		if int(result.RowsAffected()) != len(req.TagIDs) {
+			p.log.Dbg("invalid tag IDs", "tags", req.TagIDs)
+			return db.ErrInvalidTagIDs
+		}
+	}
+
+	return nil
+}
+
+func (p *PG) openingTemplateWriteErr(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 23502 is not null error code
+		if pgErr.Code == "23502" {
+			switch pgErr.ColumnName {
+			case "recruiter":
+				return db.ErrNoRecruiter
+			case "hiring_manager":
+				return db.ErrNoHiringManager
+			case "cost_center_id":
+				return db.ErrNoCostCenter
+			}
+		}
+
+		if pgErr.Code == "23505" &&
+			pgErr.ConstraintName == "uniq_opening_template_name" {
+			return db.ErrDupOpeningTemplateName
+		}
+	}
+
+	p.log.Err("failed to write opening template", "error", err)
+	return db.ErrInternal
+}
+
+func scanOpeningTemplate(
+	row pgx.CollectableRow,
+) (employer.OpeningTemplate, error) {
+	var template employer.OpeningTemplate
+	var templateID uuid.UUID
+	var minAmount, maxAmount *float64
+	var currencyStr *string
+
+	err := row.Scan(
+		&templateID,
+		&template.Name,
+		&template.Description,
+		&template.Title,
+		&template.Positions,
+		&template.JD,
+		&template.Recruiter,
+		&template.HiringManager,
+		&template.CostCenterName,
+		&template.EmployerNotes,
+		&template.RemoteCountryCodes,
+		&template.RemoteTimezones,
+		&template.OpeningType,
+		&template.YoeMin,
+		&template.YoeMax,
+		&template.MinEducationLevel,
+		&minAmount,
+		&maxAmount,
+		&currencyStr,
+		&template.CreatedAt,
+		&template.LastUpdatedAt,
+		&template.LocationTitles,
+		&template.HiringTeam,
+		&template.Tags,
+	)
+	if err != nil {
+		return employer.OpeningTemplate{}, err
+	}
+
+	template.ID = employer.OpeningTemplateID(templateID.String())
+	if minAmount != nil && maxAmount != nil && currencyStr != nil {
+		template.Salary = &common.Salary{
+			MinAmount: *minAmount,
+			MaxAmount: *maxAmount,
+			Currency:  common.Currency(*currencyStr),
+		}
+	}
+
+	return template, nil
+}
+
+func salaryColumns(salary *common.Salary) (*float64, *float64, *string) {
+	if salary == nil {
+		return nil, nil, nil
+	}
+	currency := string(salary.Currency)
+	return &salary.MinAmount, &salary.MaxAmount, &currency
+}
+
+func uniqueEmails(emails []common.EmailAddress) []string {
+	seen := make(map[common.EmailAddress]struct{}, len(emails))
+	unique := make([]string, 0, len(emails))
+	for _, email := range emails {
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+		unique = append(unique, string(email))
+	}
+	return unique
+}
@@ -40,12 +40,34 @@ SELECT
     o.salary_max,
     o.salary_currency,
     o.state,
+    o.publish_at,
+    o.close_at,
+    o.max_applications,
+    o.public_listing,
     o.created_at,
     o.last_updated_at,
     jsonb_build_object('email', r.email, 'name', r.name, 'vetchi_handle', hu_r.handle) AS recruiter,
     ARRAY_AGG(DISTINCT l.title) FILTER (WHERE l.title IS NOT NULL) AS locations,
     ARRAY_AGG(DISTINCT jsonb_build_object('email', ht.email, 'name', ht.name, 'vetchi_handle', hu_ht.handle)) FILTER (WHERE ht.email IS NOT NULL) AS hiring_team,
-    ARRAY_AGG(DISTINCT jsonb_build_object('id', ot.id, 'name', ot.display_name)) FILTER (WHERE ot.id IS NOT NULL) AS tags
+    ARRAY_AGG(DISTINCT jsonb_build_object('id', ot.id, 'name', ot.display_name)) FILTER (WHERE ot.id IS NOT NULL) AS tags,
+    (
+        SELECT
+            CASE
+                WHEN COUNT(*) = 0 THEN NULL
+                WHEN bool_or(oa.approval_state = $3) THEN $3
+                WHEN bool_and(oa.approval_state = $4) THEN $4
+                ELSE $5
+            END
+        FROM opening_approvals oa
+        WHERE oa.employer_id = o.employer_id
+            AND oa.opening_id = o.id
+    ) AS approval_state,
+    (
+        SELECT COUNT(*)
+        FROM opening_hires oh
+        WHERE oh.employer_id = o.employer_id
+            AND oh.opening_id = o.id
+    ) AS filled_positions
 FROM
     openings o
     LEFT JOIN org_cost_centers cc ON o.cost_center_id = cc.id
@@ -67,6 +89,7 @@ WHERE
     o.id = $1
     AND o.employer_id = $2
 GROUP BY
+    o.employer_id,
     o.id,
     o.title,
     o.positions,
@@ -86,6 +109,10 @@ GROUP BY
     o.salary_max,
     o.salary_currency,
     o.state,
+    o.publish_at,
+    o.close_at,
+    o.max_applications,
+    o.public_listing,
     o.created_at,
     o.last_updated_at,
     r.email,
@@ -102,7 +129,15 @@ GROUP BY
 	var currencyStr *string
 	var tags []common.VTag
 
-	err := p.pool.QueryRow(ctx, query, getOpeningReq.ID, orgUser.EmployerID).
+	err := p.pool.QueryRow(
+		ctx,
+		query,
+		getOpeningReq.ID,
+		orgUser.EmployerID,
+		employer.OpeningRejected,
+		employer.OpeningApproved,
+		employer.OpeningPendingApproval,
+	).
 		Scan(
 			&opening.ID,
 			&opening.Title,
@@ -121,12 +156,18 @@ GROUP BY
 			&maxAmount,
 			&currencyStr,
 			&opening.State,
+			&opening.PublishAt,
+			&opening.CloseAt,
+			&opening.MaxApplications,
+			&opening.PublicListing,
 			&opening.CreatedAt,
 			&opening.LastUpdatedAt,
 			&recruiter,
 			&locations,
 			&hiringTeam,
 			&tags,
+			&opening.ApprovalState,
+			&opening.FilledPositions,
 		)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -189,7 +230,12 @@ SELECT
     o.id,
     o.title,
     o.positions,
-    0 as filled_positions, -- TODO Calculate filled positions
+    (
+        SELECT COUNT(*)
+        FROM opening_hires oh
+        WHERE oh.employer_id = o.employer_id
+            AND oh.opening_id = o.id
+    ) as filled_positions,
     o.opening_type,
     o.state,
     o.created_at,
@@ -489,9 +535,10 @@ func (p *PG) ChangeOpeningState(
 	changeOpeningStateReq employer.ChangeOpeningStateRequest,
 ) error {
 	const (
-		resultNoOpening     = "no_opening"
-		resultStateMismatch = "state_mismatch"
-		resultUpdated       = "updated"
+		resultNoOpening       = "no_opening"
+		resultStateMismatch   = "state_mismatch"
+		resultApprovalPending = "approval_pending"
+		resultUpdated         = "updated"
 	)
 
 	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
@@ -500,23 +547,54 @@ func (p *PG) ChangeOpeningState(
 		return db.ErrInternal
 	}
 
+	// An opening whose cost center has an approval chain can be published
+	// only after all the steps of its latest approval request are approved
 	query := `
 WITH opening_check AS (
-    SELECT state
+    SELECT state, cost_center_id
     FROM openings
     WHERE id = $1 AND employer_id = $2
 ),
+approval_check AS (
+    SELECT
+        $4::opening_states = $8::opening_states
+        AND $3::opening_states = $9::opening_states
+        AND
+        CASE
+            WHEN EXISTS (
+                SELECT 1
+                FROM opening_approvals
+                WHERE opening_id = $1 AND employer_id = $2
+            ) THEN EXISTS (
+                SELECT 1
+                FROM opening_approvals
+                WHERE opening_id = $1
+                    AND employer_id = $2
+                    AND approval_state != $10
+            )
+            ELSE EXISTS (
+                SELECT 1
+                FROM cost_center_approvers cca, opening_check oc
+                WHERE cca.cost_center_id = oc.cost_center_id
+            )
+        END AS blocked
+),
 state_update AS (
     UPDATE openings
     SET state = $3
     WHERE id = $1
         AND employer_id = $2
         AND state = $4
+        AND NOT (SELECT blocked FROM approval_check)
     RETURNING true as updated
 )
 SELECT 
     CASE
         WHEN NOT EXISTS (SELECT 1 FROM opening_check) THEN $5
+        WHEN NOT EXISTS (
+            SELECT 1 FROM opening_check WHERE state = $4
+        ) THEN $6
+        WHEN (SELECT blocked FROM approval_check) THEN $11
         WHEN NOT EXISTS (SELECT 1 FROM state_update) THEN $6
         ELSE $7
     END as result;
@@ -533,6 +611,10 @@ SELECT
 		resultNoOpening,
 		resultStateMismatch,
 		resultUpdated,
+		common.DraftOpening,
+		common.ActiveOpening,
+		employer.OpeningApproved,
+		resultApprovalPending,
 	).Scan(&result)
 	if err != nil {
 		p.log.Err("failed to change opening state", "error", err)
@@ -546,6 +628,8 @@ SELECT
 		return db.ErrNoOpening
 	case resultStateMismatch:
 		return db.ErrStateMismatch
+	case resultApprovalPending:
+		return db.ErrOpeningApprovalPending
 	case resultUpdated:
 		return nil
 	default:
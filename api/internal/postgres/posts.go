@@ -278,7 +278,13 @@ func (pg *PG) GetPost(req db.GetPostRequest) (hub.Post, error) {
 			p.downvotes_count,
 			p.score,
 			p.comments_enabled AS can_comment,
-			(SELECT COUNT(*) FROM post_comments WHERE post_id = p.id)::int AS comments_count
+			(SELECT COUNT(*) FROM post_comments WHERE post_id = p.id)::int AS comments_count,
+			EXISTS (
+				SELECT 1 FROM bookmarks
+				WHERE hub_user_id = $1
+				AND bookmark_type = 'POST'
+				AND target_id = p.id
+			) AS is_bookmarked
 		FROM
 			posts p
 		JOIN
@@ -310,6 +316,7 @@ func (pg *PG) GetPost(req db.GetPostRequest) (hub.Post, error) {
 			&post.Score,
 			&post.CanComment,
 			&post.CommentsCount,
+			&post.IsBookmarked,
 		)
 
 	if err != nil {
@@ -408,7 +415,13 @@ func (pg *PG) GetUserPosts(
 			p.downvotes_count,
 			p.score,
 			p.comments_enabled AS can_comment,
-			(SELECT COUNT(*) FROM post_comments WHERE post_id = p.id)::int AS comments_count
+			(SELECT COUNT(*) FROM post_comments WHERE post_id = p.id)::int AS comments_count,
+			EXISTS (
+				SELECT 1 FROM bookmarks
+				WHERE hub_user_id = $1
+				AND bookmark_type = 'POST'
+				AND target_id = p.id
+			) AS is_bookmarked
 		FROM
 			posts p
 		JOIN
@@ -494,6 +507,7 @@ func (pg *PG) GetUserPosts(
 			&post.Score,
 			&post.CanComment,
 			&post.CommentsCount,
+			&post.IsBookmarked,
 		)
 		if err != nil {
 			pg.log.Err("failed to scan post row", "error", err)
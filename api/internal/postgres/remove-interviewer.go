@@ -124,30 +124,30 @@ RETURNING id
 		return db.ErrInternal
 	}
 
-	emailQuery := `
-INSERT INTO emails (
-	email_from,
-	email_to,
-	email_subject,
-	email_html_body,
-	email_text_body,
-	email_state
-) VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING email_key
-`
-	var emailKey string
-	err = tx.QueryRow(
+	// The calendar entry of the removed interviewer is cancelled with the
+	// next SEQUENCE of the interview
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE interviews
+SET ical_sequence = ical_sequence + 1
+WHERE id = $1
+    AND employer_id = $2
+`,
+		removeInterviewerReq.InterviewID,
+		orgUser.EmployerID,
+	)
+	if err != nil {
+		p.log.Err("failed to update ical sequence", "error", err)
+		return db.ErrInternal
+	}
+
+	err = p.insertEmail(
 		ctx,
-		emailQuery,
-		removeInterviewerReq.RemovedInterviewerEmailNotification.EmailFrom,
-		removeInterviewerReq.RemovedInterviewerEmailNotification.EmailTo,
-		removeInterviewerReq.RemovedInterviewerEmailNotification.EmailSubject,
-		removeInterviewerReq.RemovedInterviewerEmailNotification.EmailHTMLBody,
-		removeInterviewerReq.RemovedInterviewerEmailNotification.EmailTextBody,
-		removeInterviewerReq.RemovedInterviewerEmailNotification.EmailState,
-	).Scan(&emailKey)
+		tx,
+		removeInterviewerReq.RemovedInterviewerEmailNotification,
+	)
 	if err != nil {
-		p.log.Err("failed to insert email", "error", err)
 		return db.ErrInternal
 	}
 
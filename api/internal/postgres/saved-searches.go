@@ -0,0 +1,704 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+func (p *PG) SaveSearch(ctx context.Context, req db.SaveSearchReq) error {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return err
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	// The HubUser is locked, so that the concurrent saves are counted
+	// against the cap one after the other
+	var savedSearches int
+	err = tx.QueryRow(
+		ctx,
+		`
+WITH locked AS (
+    SELECT id FROM hub_users WHERE id = $1 FOR UPDATE
+)
+SELECT COUNT(*) FROM saved_searches WHERE hub_user_id = (SELECT id FROM locked)
+`,
+		hubUserID,
+	).Scan(&savedSearches)
+	if err != nil {
+		p.log.Err("failed to count saved searches", "error", err)
+		return db.ErrInternal
+	}
+	if savedSearches >= vetchi.MaxSavedSearchesPerHubUser {
+		p.log.Dbg("too many saved searches", "count", savedSearches)
+		return db.ErrTooManySavedSearches
+	}
+
+	query := `
+INSERT INTO saved_searches (id, hub_user_id, name, search, frequency, unsubscribe_token)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+	_, err = tx.Exec(
+		ctx,
+		query,
+		req.SavedSearchID,
+		hubUserID,
+		req.Name,
+		req.Search,
+		req.Frequency,
+		req.UnsubscribeToken,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+			pgErr.ConstraintName == "uniq_saved_search_name" {
+			p.log.Dbg("saved search name in use", "name", req.Name)
+			return db.ErrDupSavedSearchName
+		}
+
+		p.log.Err("failed to insert saved search", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) ListSavedSearches(ctx context.Context) ([]hub.SavedSearch, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return nil, err
+	}
+
+	query := `
+SELECT
+    s.id,
+    s.name,
+    s.search,
+    s.frequency,
+    s.paused,
+    (
+        SELECT COUNT(*)
+        FROM saved_search_matches m
+        WHERE m.saved_search_id = s.id AND m.matched_at > s.last_viewed_at
+    ),
+    s.last_viewed_at,
+    s.created_at
+FROM saved_searches s
+WHERE s.hub_user_id = $1
+ORDER BY s.created_at DESC
+`
+	rows, err := p.pool.Query(ctx, query, hubUserID)
+	if err != nil {
+		p.log.Err("failed to query saved searches", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	savedSearches, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (hub.SavedSearch, error) {
+			var savedSearch hub.SavedSearch
+			err := row.Scan(
+				&savedSearch.SavedSearchID,
+				&savedSearch.Name,
+				&savedSearch.Search,
+				&savedSearch.Frequency,
+				&savedSearch.Paused,
+				&savedSearch.NewOpeningsCount,
+				&savedSearch.LastViewedAt,
+				&savedSearch.CreatedAt,
+			)
+			return savedSearch, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect saved searches", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return savedSearches, nil
+}
+
+func (p *PG) UpdateSavedSearch(
+	ctx context.Context,
+	req hub.UpdateSavedSearchRequest,
+) error {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return err
+	}
+
+	// A changed search is matched only against the Openings that become
+	// ACTIVE from now on, as the earlier ones were matched against the old
+	query := `
+UPDATE saved_searches
+SET
+    name = COALESCE($1, name),
+    search = COALESCE($2, search),
+    frequency = COALESCE($3, frequency),
+    evaluated_at = CASE
+        WHEN $2::JSONB IS NULL THEN evaluated_at
+        ELSE timezone('UTC', now())
+    END
+WHERE id = $4 AND hub_user_id = $5
+`
+	result, err := p.pool.Exec(
+		ctx,
+		query,
+		req.Name,
+		req.Search,
+		req.Frequency,
+		req.SavedSearchID,
+		hubUserID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+			pgErr.ConstraintName == "uniq_saved_search_name" {
+			p.log.Dbg("saved search name in use", "name", req.Name)
+			return db.ErrDupSavedSearchName
+		}
+
+		p.log.Err("failed to update saved search", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("saved search not found", "id", req.SavedSearchID)
+		return db.ErrNoSavedSearch
+	}
+
+	return nil
+}
+
+func (p *PG) SetSavedSearchPaused(
+	ctx context.Context,
+	savedSearchID string,
+	paused bool,
+) error {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return err
+	}
+
+	result, err := p.pool.Exec(
+		ctx,
+		`
+UPDATE saved_searches
+SET paused = $1
+WHERE id = $2 AND hub_user_id = $3
+`,
+		paused,
+		savedSearchID,
+		hubUserID,
+	)
+	if err != nil {
+		p.log.Err("failed to set saved search paused", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("saved search not found", "id", savedSearchID)
+		return db.ErrNoSavedSearch
+	}
+
+	return nil
+}
+
+func (p *PG) DeleteSavedSearch(
+	ctx context.Context,
+	savedSearchID string,
+) error {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return err
+	}
+
+	result, err := p.pool.Exec(
+		ctx,
+		`DELETE FROM saved_searches WHERE id = $1 AND hub_user_id = $2`,
+		savedSearchID,
+		hubUserID,
+	)
+	if err != nil {
+		p.log.Err("failed to delete saved search", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("saved search not found", "id", savedSearchID)
+		return db.ErrNoSavedSearch
+	}
+
+	return nil
+}
+
+// ViewSavedSearch returns the Openings that matched the saved search, the
+// latest first, and marks them as viewed
+func (p *PG) ViewSavedSearch(
+	ctx context.Context,
+	savedSearchID string,
+) ([]hub.SavedSearchOpening, error) {
+	hubUserID, err := getHubUserID(ctx)
+	if err != nil {
+		p.log.Err("failed to get hub user ID", "error", err)
+		return nil, err
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return nil, db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var id string
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT id
+FROM saved_searches
+WHERE id = $1 AND hub_user_id = $2
+FOR UPDATE
+`,
+		savedSearchID,
+		hubUserID,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("saved search not found", "id", savedSearchID)
+			return nil, db.ErrNoSavedSearch
+		}
+
+		p.log.Err("failed to get saved search", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    o.id,
+    d.domain_name,
+    e.company_name,
+    o.title,
+    m.matched_at,
+    m.matched_at > s.last_viewed_at
+FROM saved_search_matches m
+JOIN saved_searches s ON s.id = m.saved_search_id
+JOIN openings o ON o.employer_id = m.employer_id AND o.id = m.opening_id
+JOIN employers e ON e.id = m.employer_id
+JOIN employer_primary_domains epd ON epd.employer_id = e.id
+JOIN domains d ON d.id = epd.domain_id
+WHERE m.saved_search_id = $1
+ORDER BY m.matched_at DESC, o.id
+LIMIT $2
+`
+	rows, err := tx.Query(
+		ctx,
+		query,
+		savedSearchID,
+		vetchi.MaxSavedSearchOpeningsShown,
+	)
+	if err != nil {
+		p.log.Err("failed to query saved search openings", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	openings, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (hub.SavedSearchOpening, error) {
+			var opening hub.SavedSearchOpening
+			err := row.Scan(
+				&opening.OpeningIDWithinCompany,
+				&opening.CompanyDomain,
+				&opening.CompanyName,
+				&opening.JobTitle,
+				&opening.MatchedAt,
+				&opening.IsNew,
+			)
+			return opening, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect saved search openings", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE saved_searches
+SET last_viewed_at = timezone('UTC', now())
+WHERE id = $1
+`,
+		savedSearchID,
+	)
+	if err != nil {
+		p.log.Err("failed to update last_viewed_at", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return openings, nil
+}
+
+// UnsubscribeSavedSearch pauses the alerts of the saved search with the
+// unsubscribe token
+func (p *PG) UnsubscribeSavedSearch(ctx context.Context, token string) error {
+	result, err := p.pool.Exec(
+		ctx,
+		`UPDATE saved_searches SET paused = TRUE WHERE unsubscribe_token = $1`,
+		token,
+	)
+	if err != nil {
+		p.log.Err("failed to unsubscribe saved search", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("no saved search for the unsubscribe token")
+		return db.ErrNoSavedSearch
+	}
+
+	return nil
+}
+
+// GetSavedSearchesToEvaluate returns the saved searches that are not paused
+// and were not matched in the last vetchi.AlertSavedSearchesInterval, the
+// least recently matched first
+func (p *PG) GetSavedSearchesToEvaluate(
+	ctx context.Context,
+	limit int,
+) ([]db.SavedSearchEvaluation, error) {
+	query := `
+SELECT s.id, s.hub_user_id, s.search, s.evaluated_at
+FROM saved_searches s
+JOIN hub_users hu ON hu.id = s.hub_user_id
+WHERE NOT s.paused
+    AND hu.state = $1
+    AND s.evaluated_at < timezone('UTC', now()) - make_interval(secs => $2)
+ORDER BY s.evaluated_at
+LIMIT $3
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		hub.ActiveHubUserState,
+		vetchi.AlertSavedSearchesInterval.Seconds(),
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query saved searches to evaluate", "error", err)
+		return nil, err
+	}
+
+	searches, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.SavedSearchEvaluation, error) {
+			var search db.SavedSearchEvaluation
+			err := row.Scan(
+				&search.SavedSearchID,
+				&search.HubUserID,
+				&search.Search,
+				&search.EvaluatedAt,
+			)
+			return search, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect saved searches", "error", err)
+		return nil, err
+	}
+
+	return searches, nil
+}
+
+// EvaluateSavedSearch records the Openings that became ACTIVE since the
+// saved search was last evaluated and that match it, as its matches
+func (p *PG) EvaluateSavedSearch(
+	ctx context.Context,
+	search db.SavedSearchEvaluation,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("Failed to begin transaction", "error", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(context.Background())
+
+	query, args := p.hubOpeningsQuery(
+		" o.employer_id, o.id",
+		search.HubUserID,
+		&search.Search,
+	)
+	query += fmt.Sprintf(" AND o.activated_at > $%d", len(args)+1)
+	args = append(args, search.EvaluatedAt)
+	query += `
+        GROUP BY o.employer_id, o.id
+`
+
+	matchQuery := fmt.Sprintf(
+		`
+INSERT INTO saved_search_matches (saved_search_id, employer_id, opening_id)
+SELECT $%d, m.employer_id, m.id
+FROM (%s) m
+ON CONFLICT DO NOTHING
+`,
+		len(args)+1,
+		query,
+	)
+	args = append(args, search.SavedSearchID)
+
+	result, err := tx.Exec(ctx, matchQuery, args...)
+	if err != nil {
+		p.log.Err("failed to insert saved search matches", "error", err)
+		return err
+	}
+	p.log.Dbg("saved search matches",
+		"id", search.SavedSearchID,
+		"count", result.RowsAffected())
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE saved_searches
+SET evaluated_at = timezone('UTC', now())
+WHERE id = $1
+`,
+		search.SavedSearchID,
+	)
+	if err != nil {
+		p.log.Err("failed to update evaluated_at", "error", err)
+		return err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetDueSavedSearchAlerts returns the saved searches that have matches that
+// are not alerted yet and whose frequency allows an alert now
+func (p *PG) GetDueSavedSearchAlerts(
+	ctx context.Context,
+	limit int,
+) ([]db.SavedSearchAlert, error) {
+	query := `
+SELECT
+    s.id,
+    s.name,
+    s.frequency,
+    s.unsubscribe_token,
+    hu.full_name,
+    hu.email,
+    (
+        SELECT MAX(m.matched_at)
+        FROM saved_search_matches m
+        WHERE m.saved_search_id = s.id AND m.alerted_at IS NULL
+    )
+FROM saved_searches s
+JOIN hub_users hu ON hu.id = s.hub_user_id
+WHERE NOT s.paused
+    AND hu.state = $1
+    AND EXISTS (
+        SELECT 1
+        FROM saved_search_matches m
+        WHERE m.saved_search_id = s.id AND m.alerted_at IS NULL
+    )
+    AND (
+        s.frequency = $2
+        OR (
+            s.frequency = $3
+            AND s.alerted_at <= timezone('UTC', now()) - make_interval(secs => $4)
+        )
+        OR (
+            s.frequency = $5
+            AND s.alerted_at <= timezone('UTC', now()) - make_interval(secs => $6)
+        )
+    )
+ORDER BY s.alerted_at
+LIMIT $7
+`
+	rows, err := p.pool.Query(
+		ctx,
+		query,
+		hub.ActiveHubUserState,
+		hub.InstantSavedSearch,
+		hub.DailySavedSearch,
+		vetchi.DailySavedSearchAlertGap.Seconds(),
+		hub.WeeklySavedSearch,
+		vetchi.WeeklySavedSearchAlertGap.Seconds(),
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query due saved search alerts", "error", err)
+		return nil, err
+	}
+
+	alerts, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.SavedSearchAlert, error) {
+			var alert db.SavedSearchAlert
+			err := row.Scan(
+				&alert.SavedSearchID,
+				&alert.Name,
+				&alert.Frequency,
+				&alert.UnsubscribeToken,
+				&alert.HubUserFullName,
+				&alert.HubUserEmail,
+				&alert.LastMatchedAt,
+			)
+			return alert, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to collect due saved search alerts", "error", err)
+		return nil, err
+	}
+
+	// The Openings that are not ACTIVE anymore are not alerted
+	openingsQuery := `
+SELECT
+    o.id,
+    d.domain_name,
+    e.company_name,
+    o.title,
+    COUNT(*) OVER ()
+FROM saved_search_matches m
+JOIN openings o ON o.employer_id = m.employer_id AND o.id = m.opening_id
+JOIN employers e ON e.id = m.employer_id
+JOIN employer_primary_domains epd ON epd.employer_id = e.id
+JOIN domains d ON d.id = epd.domain_id
+WHERE m.saved_search_id = $1
+    AND m.alerted_at IS NULL
+    AND m.matched_at <= $2
+    AND o.state = $3
+ORDER BY m.matched_at DESC, o.id
+LIMIT $4
+`
+	for i := range alerts {
+		rows, err := p.pool.Query(
+			ctx,
+			openingsQuery,
+			alerts[i].SavedSearchID,
+			alerts[i].LastMatchedAt,
+			common.ActiveOpening,
+			vetchi.MaxOpeningsPerSavedSearchAlert,
+		)
+		if err != nil {
+			p.log.Err("failed to query alert openings", "error", err)
+			return nil, err
+		}
+
+		alerts[i].Openings, err = pgx.CollectRows(
+			rows,
+			func(row pgx.CollectableRow) (db.SavedSearchAlertOpening, error) {
+				var opening db.SavedSearchAlertOpening
+				err := row.Scan(
+					&opening.OpeningID,
+					&opening.CompanyDomain,
+					&opening.CompanyName,
+					&opening.JobTitle,
+					&alerts[i].MatchCount,
+				)
+				return opening, err
+			},
+		)
+		if err != nil {
+			p.log.Err("failed to collect alert openings", "error", err)
+			return nil, err
+		}
+	}
+
+	return alerts, nil
+}
+
+// SaveSavedSearchAlert marks the matches of the saved search, up to the
+// LastMatchedAt, as alerted and queues the email of the alert, if any
+func (p *PG) SaveSavedSearchAlert(
+	ctx context.Context,
+	req db.SaveSavedSearchAlertReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("Failed to begin transaction", "error", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE saved_search_matches
+SET alerted_at = timezone('UTC', now())
+WHERE saved_search_id = $1 AND alerted_at IS NULL AND matched_at <= $2
+`,
+		req.SavedSearchID,
+		req.LastMatchedAt,
+	)
+	if err != nil {
+		p.log.Err("failed to mark saved search matches alerted", "error", err)
+		return err
+	}
+
+	if req.Email != nil {
+		err = p.insertEmail(ctx, tx, *req.Email)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			`
+UPDATE saved_searches
+SET alerted_at = timezone('UTC', now())
+WHERE id = $1
+`,
+			req.SavedSearchID,
+		)
+		if err != nil {
+			p.log.Err("failed to update alerted_at", "error", err)
+			return err
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,640 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+func (p *PG) SetOpeningCompetencies(
+	ctx context.Context,
+	req employer.SetOpeningCompetenciesRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var exists bool
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT EXISTS (
+    SELECT 1 FROM openings WHERE employer_id = $1 AND id = $2 FOR UPDATE)
+`,
+		orgUser.EmployerID,
+		req.OpeningID,
+	).Scan(&exists)
+	if err != nil {
+		p.log.Err("failed to check opening", "error", err)
+		return db.ErrInternal
+	}
+
+	if !exists {
+		p.log.Dbg("opening not found", "opening_id", req.OpeningID)
+		return db.ErrNoOpening
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+DELETE FROM opening_competencies
+WHERE employer_id = $1
+    AND opening_id = $2
+`,
+		orgUser.EmployerID,
+		req.OpeningID,
+	)
+	if err != nil {
+		p.log.Err("failed to delete competencies", "error", err)
+		return db.ErrInternal
+	}
+
+	for i, competency := range req.Competencies {
+		_, err = tx.Exec(
+			ctx,
+			`
+INSERT INTO opening_competencies (employer_id, opening_id, name, description, display_order)
+    VALUES ($1, $2, $3, $4, $5)
+`,
+			orgUser.EmployerID,
+			req.OpeningID,
+			competency.Name,
+			competency.Description,
+			i+1,
+		)
+		if err != nil {
+			p.log.Err("failed to insert competency", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetOpeningCompetencies(
+	ctx context.Context,
+	req employer.GetOpeningCompetenciesRequest,
+) ([]employer.Competency, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return nil, db.ErrInternal
+	}
+
+	var exists bool
+	err := p.pool.QueryRow(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM openings WHERE employer_id = $1 AND id = $2)`,
+		orgUser.EmployerID,
+		req.OpeningID,
+	).Scan(&exists)
+	if err != nil {
+		p.log.Err("failed to check opening", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if !exists {
+		p.log.Dbg("opening not found", "opening_id", req.OpeningID)
+		return nil, db.ErrNoOpening
+	}
+
+	return p.getCompetencies(ctx, orgUser, req.OpeningID)
+}
+
+func (p *PG) getCompetencies(
+	ctx context.Context,
+	orgUser db.OrgUserTO,
+	openingID string,
+) ([]employer.Competency, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    name,
+    description
+FROM
+    opening_competencies
+WHERE
+    employer_id = $1
+    AND opening_id = $2
+ORDER BY
+    display_order
+`,
+		orgUser.EmployerID,
+		openingID,
+	)
+	if err != nil {
+		p.log.Err("failed to query competencies", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	competencies, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (employer.Competency, error) {
+			var competency employer.Competency
+			err := row.Scan(&competency.Name, &competency.Description)
+			return competency, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan competencies", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return competencies, nil
+}
+
+func (p *PG) PutScorecard(
+	ctx context.Context,
+	req employer.PutScorecardRequest,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var interviewState common.InterviewState
+	var openingID string
+	var isInterviewer bool
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    i.interview_state,
+    c.opening_id,
+    EXISTS (
+        SELECT
+            1
+        FROM
+            interview_interviewers ii
+        WHERE
+            ii.interview_id = i.id
+            AND ii.interviewer_id = $3)
+FROM
+    interviews i
+    JOIN candidacies c ON c.id = i.candidacy_id
+WHERE
+    i.id = $1
+    AND i.employer_id = $2
+FOR UPDATE OF i
+`,
+		req.InterviewID,
+		orgUser.EmployerID,
+		orgUser.ID,
+	).Scan(&interviewState, &openingID, &isInterviewer)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("interview not found", "interview_id", req.InterviewID)
+			return db.ErrNoInterview
+		}
+		p.log.Err("failed to get interview", "error", err)
+		return db.ErrInternal
+	}
+
+	if !isInterviewer {
+		p.log.Dbg("not an interviewer", "interview_id", req.InterviewID)
+		return db.ErrNotAnInterviewer
+	}
+
+	// Scorecards can be submitted after the interview is completed too, as
+	// the interviewers of a panel may submit them at different times
+	if interviewState == common.CancelledInterviewState {
+		p.log.Dbg("interview cancelled", "interview_id", req.InterviewID)
+		return db.ErrStateMismatch
+	}
+
+	if len(req.Ratings) > 0 {
+		competencies, err := p.getCompetencies(ctx, orgUser, openingID)
+		if err != nil {
+			return err
+		}
+
+		rubric := make(map[string]bool)
+		for _, competency := range competencies {
+			rubric[competency.Name] = true
+		}
+
+		for _, rating := range req.Ratings {
+			if !rubric[rating.Competency] {
+				p.log.Dbg("unknown competency", "competency", rating.Competency)
+				return db.ErrUnknownCompetency
+			}
+		}
+	}
+
+	// A submitted scorecard cannot be changed, so that an interviewer cannot
+	// revise their feedback after reading the scorecards of the others
+	result, err := tx.Exec(
+		ctx,
+		`
+INSERT INTO interview_scorecards (interview_id, interviewer_id, employer_id, interviewers_decision, positives, negatives, overall_assessment, feedback_to_candidate)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (interview_id, interviewer_id)
+    DO NOTHING
+`,
+		req.InterviewID,
+		orgUser.ID,
+		orgUser.EmployerID,
+		req.Decision,
+		req.Positives,
+		req.Negatives,
+		req.OverallAssessment,
+		req.FeedbackToCandidate,
+	)
+	if err != nil {
+		p.log.Err("failed to insert scorecard", "error", err)
+		return db.ErrInternal
+	}
+
+	if result.RowsAffected() == 0 {
+		p.log.Dbg("scorecard already submitted", "id", req.InterviewID)
+		return db.ErrScorecardSubmitted
+	}
+
+	for _, rating := range req.Ratings {
+		_, err = tx.Exec(
+			ctx,
+			`
+INSERT INTO scorecard_ratings (interview_id, interviewer_id, competency, rating, notes)
+    VALUES ($1, $2, $3, $4, $5)
+`,
+			req.InterviewID,
+			orgUser.ID,
+			rating.Competency,
+			rating.Rating,
+			rating.Notes,
+		)
+		if err != nil {
+			p.log.Err("failed to insert rating", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	if req.MarkInterviewCompleted &&
+		interviewState == common.ScheduledInterviewState {
+		_, err = tx.Exec(
+			ctx,
+			`
+UPDATE interviews
+SET interview_state = $1,
+    completed_at = timezone('UTC', now())
+WHERE id = $2
+`,
+			common.CompletedInterviewState,
+			req.InterviewID,
+		)
+		if err != nil {
+			p.log.Err("failed to complete interview", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetScorecards(
+	ctx context.Context,
+	req employer.GetScorecardsRequest,
+) (employer.InterviewScorecards, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return employer.InterviewScorecards{}, db.ErrInternal
+	}
+
+	interviews, err := p.getInterviewScorecards(
+		ctx,
+		orgUser,
+		`i.id = $3`,
+		req.InterviewID,
+	)
+	if err != nil {
+		return employer.InterviewScorecards{}, err
+	}
+
+	if len(interviews) == 0 {
+		p.log.Dbg("interview not found", "interview_id", req.InterviewID)
+		return employer.InterviewScorecards{}, db.ErrNoInterview
+	}
+
+	return interviews[0], nil
+}
+
+func (p *PG) GetCandidacyDebrief(
+	ctx context.Context,
+	req employer.GetCandidacyDebriefRequest,
+) (employer.CandidacyDebrief, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return employer.CandidacyDebrief{}, db.ErrInternal
+	}
+
+	var openingID string
+	err := p.pool.QueryRow(
+		ctx,
+		`SELECT opening_id FROM candidacies WHERE id = $1 AND employer_id = $2`,
+		req.CandidacyID,
+		orgUser.EmployerID,
+	).Scan(&openingID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("candidacy not found", "candidacy_id", req.CandidacyID)
+			return employer.CandidacyDebrief{}, db.ErrNoCandidacy
+		}
+		p.log.Err("failed to get candidacy", "error", err)
+		return employer.CandidacyDebrief{}, db.ErrInternal
+	}
+
+	interviews, err := p.getInterviewScorecards(
+		ctx,
+		orgUser,
+		`i.candidacy_id = $3 AND i.interview_state != '`+
+			string(common.CancelledInterviewState)+`'`,
+		req.CandidacyID,
+	)
+	if err != nil {
+		return employer.CandidacyDebrief{}, err
+	}
+
+	competencies, err := p.getCompetencies(ctx, orgUser, openingID)
+	if err != nil {
+		return employer.CandidacyDebrief{}, err
+	}
+
+	return summarizeDebrief(req.CandidacyID, interviews, competencies), nil
+}
+
+// summarizeDebrief aggregates the ratings and the decisions of the given
+// scorecards. The competencies are ordered as in the rubric of the opening,
+// followed by the competencies that were removed from the rubric after
+// they were rated.
+func summarizeDebrief(
+	candidacyID string,
+	interviews []employer.InterviewScorecards,
+	rubric []employer.Competency,
+) employer.CandidacyDebrief {
+	decisions := []employer.DecisionCount{
+		{Decision: common.StrongYesInterviewersDecision},
+		{Decision: common.YesInterviewersDecision},
+		{Decision: common.NeutralInterviewersDecision},
+		{Decision: common.NoInterviewersDecision},
+		{Decision: common.StrongNoInterviewersDecision},
+	}
+
+	totals := make(map[string]int)
+	counts := make(map[string]int)
+	for _, interview := range interviews {
+		for _, scorecard := range interview.Scorecards {
+			for i := range decisions {
+				if decisions[i].Decision == scorecard.Decision {
+					decisions[i].Count++
+				}
+			}
+			for _, rating := range scorecard.Ratings {
+				totals[rating.Competency] += rating.Rating
+				counts[rating.Competency]++
+			}
+		}
+	}
+
+	order := make(map[string]int)
+	for i, competency := range rubric {
+		order[competency.Name] = i
+	}
+
+	competencies := []employer.CompetencySummary{}
+	for competency, count := range counts {
+		competencies = append(competencies, employer.CompetencySummary{
+			Competency:    competency,
+			AverageRating: float64(totals[competency]) / float64(count),
+			RatingsCount:  count,
+		})
+	}
+	sort.Slice(competencies, func(i, j int) bool {
+		oi, iok := order[competencies[i].Competency]
+		oj, jok := order[competencies[j].Competency]
+		if iok != jok {
+			return iok
+		}
+		if iok && oi != oj {
+			return oi < oj
+		}
+		return competencies[i].Competency < competencies[j].Competency
+	})
+
+	return employer.CandidacyDebrief{
+		CandidacyID:  candidacyID,
+		Interviews:   interviews,
+		Competencies: competencies,
+		Decisions:    decisions,
+	}
+}
+
+// getInterviewScorecards returns the scorecards of the interviews matching
+// the filter, ordered by their start_time. The filter should use $3 for its
+// argument. If the org user is an interviewer of an interview and has not
+// submitted their scorecard yet, the scorecards of the others are withheld.
+func (p *PG) getInterviewScorecards(
+	ctx context.Context,
+	orgUser db.OrgUserTO,
+	filter string,
+	arg string,
+) ([]employer.InterviewScorecards, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    i.id,
+    i.interview_type,
+    i.interview_state,
+    i.start_time,
+    EXISTS (
+        SELECT
+            1
+        FROM
+            interview_interviewers ii
+        WHERE
+            ii.interview_id = i.id
+            AND ii.interviewer_id = $2)
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            interview_scorecards s
+        WHERE
+            s.interview_id = i.id
+            AND s.interviewer_id = $2),
+    COALESCE((
+        SELECT
+            json_agg(json_build_object('name', ou.name, 'email', ou.email) ORDER BY ou.email)
+        FROM interview_interviewers ii
+        JOIN org_users ou ON ou.id = ii.interviewer_id
+        WHERE
+            ii.interview_id = i.id
+            AND NOT EXISTS (
+                SELECT
+                    1
+                FROM
+                    interview_scorecards s
+                WHERE
+                    s.interview_id = i.id
+                    AND s.interviewer_id = ii.interviewer_id)), '[]'::json)
+FROM
+    interviews i
+WHERE
+    i.employer_id = $1
+    AND `+filter+`
+ORDER BY
+    i.start_time,
+    i.id
+`,
+		orgUser.EmployerID,
+		orgUser.ID,
+		arg,
+	)
+	if err != nil {
+		p.log.Err("failed to query interviews", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	interviews, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (employer.InterviewScorecards, error) {
+			var interview employer.InterviewScorecards
+			err := row.Scan(
+				&interview.InterviewID,
+				&interview.InterviewType,
+				&interview.InterviewState,
+				&interview.StartTime,
+				&interview.OthersHidden,
+				&interview.PendingInterviewers,
+			)
+			interview.Scorecards = []employer.Scorecard{}
+			return interview, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan interviews", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	if len(interviews) == 0 {
+		return interviews, nil
+	}
+
+	index := make(map[string]int)
+	var interviewIDs []string
+	for i, interview := range interviews {
+		index[interview.InterviewID] = i
+		interviewIDs = append(interviewIDs, interview.InterviewID)
+	}
+
+	rows, err = p.pool.Query(
+		ctx,
+		`
+SELECT
+    s.interview_id,
+    s.interviewer_id = $2,
+    ou.name,
+    ou.email,
+    s.interviewers_decision,
+    s.positives,
+    s.negatives,
+    s.overall_assessment,
+    s.feedback_to_candidate,
+    s.submitted_at,
+    COALESCE((
+        SELECT
+            json_agg(json_build_object('competency', r.competency, 'rating', r.rating, 'notes', r.notes) ORDER BY r.competency)
+        FROM scorecard_ratings r
+        WHERE
+            r.interview_id = s.interview_id
+            AND r.interviewer_id = s.interviewer_id), '[]'::json)
+FROM
+    interview_scorecards s
+    JOIN org_users ou ON ou.id = s.interviewer_id
+WHERE
+    s.interview_id = ANY ($1)
+ORDER BY
+    s.submitted_at
+`,
+		interviewIDs,
+		orgUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to query scorecards", "error", err)
+		return nil, db.ErrInternal
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var scorecard employer.Scorecard
+		var own bool
+		err = rows.Scan(
+			&scorecard.InterviewID,
+			&own,
+			&scorecard.Interviewer.Name,
+			&scorecard.Interviewer.Email,
+			&scorecard.Decision,
+			&scorecard.Positives,
+			&scorecard.Negatives,
+			&scorecard.OverallAssessment,
+			&scorecard.FeedbackToCandidate,
+			&scorecard.SubmittedAt,
+			&scorecard.Ratings,
+		)
+		if err != nil {
+			p.log.Err("failed to scan scorecard", "error", err)
+			return nil, db.ErrInternal
+		}
+
+		interview := &interviews[index[scorecard.InterviewID]]
+		if interview.OthersHidden && !own {
+			continue
+		}
+		interview.Scorecards = append(interview.Scorecards, scorecard)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.log.Err("failed to iterate scorecards", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return interviews, nil
+}
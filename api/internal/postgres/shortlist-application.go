@@ -53,6 +53,20 @@ RETURNING id
 		return db.ErrInternal
 	}
 
+	err = p.insertCandidacyEvent(
+		ctx,
+		tx,
+		orgUser.EmployerID,
+		candidacyID,
+		nil,
+		common.InterviewingCandidacyState,
+		orgUserActor(orgUser),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
 	applicationQuery := `
 WITH application_check AS (
     SELECT CASE
@@ -0,0 +1,898 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/vetchium/vetchium/api/internal/db"
+	"github.com/vetchium/vetchium/api/internal/middleware"
+	"github.com/vetchium/vetchium/api/pkg/vetchi"
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// takeHomeDeadline is the deadline of the take-home assignment t of the
+// interview i. A started assignment is due at the end of its time limit, if
+// that is earlier than the end of the interview.
+const takeHomeDeadline = `
+    CASE WHEN t.started_at IS NULL THEN
+        i.end_time
+    ELSE
+        LEAST(i.end_time, t.started_at + make_interval(mins => t.time_limit_minutes))
+    END`
+
+func (p *PG) SetTakeHomeAssignment(
+	ctx context.Context,
+	req db.SetTakeHomeAssignmentReq,
+) error {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var interviewType common.InterviewType
+	var state common.InterviewState
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    interview_type,
+    interview_state
+FROM
+    interviews
+WHERE
+    id = $1
+    AND employer_id = $2
+FOR UPDATE
+`,
+		req.InterviewID,
+		orgUser.EmployerID,
+	).Scan(&interviewType, &state)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("interview not found", "interview_id", req.InterviewID)
+			return db.ErrNoInterview
+		}
+		p.log.Err("failed to get interview", "error", err)
+		return db.ErrInternal
+	}
+
+	if interviewType != common.TakeHomeInterviewType {
+		p.log.Dbg("not a take-home interview", "type", interviewType)
+		return db.ErrNotTakeHome
+	}
+
+	if state != common.ScheduledInterviewState {
+		p.log.Dbg("interview not scheduled", "state", state)
+		return db.ErrInvalidInterviewState
+	}
+
+	// The assignment cannot be changed under the feet of a candidate who
+	// has already started working on it
+	var started bool
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    t.started_at IS NOT NULL
+    OR EXISTS (
+        SELECT
+            1
+        FROM
+            take_home_submissions s
+        WHERE
+            s.interview_id = t.interview_id)
+FROM
+    take_home_assignments t
+WHERE
+    t.interview_id = $1
+`,
+		req.InterviewID,
+	).Scan(&started)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		p.log.Err("failed to get take-home assignment", "error", err)
+		return db.ErrInternal
+	}
+
+	if started {
+		p.log.Dbg("take-home already started", "id", req.InterviewID)
+		return db.ErrStateMismatch
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO take_home_assignments (interview_id, employer_id, brief, time_limit_minutes, late_submission_policy, grace_period_minutes, created_by)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (interview_id)
+    DO UPDATE SET
+        brief = EXCLUDED.brief,
+        time_limit_minutes = EXCLUDED.time_limit_minutes,
+        late_submission_policy = EXCLUDED.late_submission_policy,
+        grace_period_minutes = EXCLUDED.grace_period_minutes,
+        reminder_sent_at = NULL,
+        updated_at = timezone('UTC', now())
+`,
+		req.InterviewID,
+		orgUser.EmployerID,
+		req.Brief,
+		req.TimeLimitMinutes,
+		req.LateSubmissionPolicy,
+		req.GracePeriodMinutes,
+		orgUser.ID,
+	)
+	if err != nil {
+		p.log.Err("failed to upsert take-home assignment", "error", err)
+		return db.ErrInternal
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+DELETE FROM take_home_assignment_files
+WHERE interview_id = $1
+`,
+		req.InterviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to delete take-home files", "error", err)
+		return db.ErrInternal
+	}
+
+	for i, file := range req.Files {
+		_, err = tx.Exec(
+			ctx,
+			`
+INSERT INTO take_home_assignment_files (interview_id, file_number, filename, file_path, content_type, size_bytes)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`,
+			req.InterviewID,
+			i+1,
+			file.Filename,
+			file.FilePath,
+			file.ContentType,
+			file.SizeBytes,
+		)
+		if err != nil {
+			p.log.Err("failed to insert take-home file", "error", err)
+			return db.ErrInternal
+		}
+	}
+
+	err = p.insertEmail(ctx, tx, req.Email)
+	if err != nil {
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+func (p *PG) GetEmployerTakeHomeAssignment(
+	ctx context.Context,
+	interviewID string,
+) (common.TakeHomeAssignment, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return common.TakeHomeAssignment{}, db.ErrInternal
+	}
+
+	return p.getTakeHomeAssignment(
+		ctx,
+		interviewID,
+		"t.employer_id = $2",
+		orgUser.EmployerID,
+	)
+}
+
+// GetHubTakeHomeAssignment returns the assignment of an interview of the
+// hub user. The brief and the files are left out until the assignment is
+// available and, for a time-boxed assignment, until it is started.
+func (p *PG) GetHubTakeHomeAssignment(
+	ctx context.Context,
+	interviewID string,
+) (common.TakeHomeAssignment, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return common.TakeHomeAssignment{}, db.ErrInternal
+	}
+
+	assignment, err := p.getTakeHomeAssignment(
+		ctx,
+		interviewID,
+		"a.hub_user_id = $2",
+		hubUser.ID,
+	)
+	if err != nil {
+		return common.TakeHomeAssignment{}, err
+	}
+
+	available := !time.Now().Before(assignment.AvailableFrom) &&
+		(assignment.TimeLimitMinutes == nil || assignment.StartedAt != nil)
+	if !available {
+		assignment.Brief = nil
+		assignment.Files = []common.TakeHomeFileInfo{}
+	}
+
+	return assignment, nil
+}
+
+// getTakeHomeAssignment returns the assignment of the interview, if the
+// ownerFilter on $2 matches. The ownerFilter can refer to the assignment t
+// and to the application a of the candidacy of the interview.
+func (p *PG) getTakeHomeAssignment(
+	ctx context.Context,
+	interviewID string,
+	ownerFilter string,
+	ownerID uuid.UUID,
+) (common.TakeHomeAssignment, error) {
+	query := `
+SELECT
+    t.interview_id,
+    t.brief,
+    i.start_time,` + takeHomeDeadline + `,
+    t.time_limit_minutes,
+    t.started_at,
+    t.late_submission_policy,
+    t.grace_period_minutes
+FROM
+    take_home_assignments t
+    JOIN interviews i ON i.id = t.interview_id
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+WHERE
+    t.interview_id = $1
+    AND ` + ownerFilter + `
+`
+
+	var assignment common.TakeHomeAssignment
+	var brief string
+	err := p.pool.QueryRow(ctx, query, interviewID, ownerID).Scan(
+		&assignment.InterviewID,
+		&brief,
+		&assignment.AvailableFrom,
+		&assignment.Deadline,
+		&assignment.TimeLimitMinutes,
+		&assignment.StartedAt,
+		&assignment.LateSubmissionPolicy,
+		&assignment.GracePeriodMinutes,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("take-home not found", "interview_id", interviewID)
+			return common.TakeHomeAssignment{}, db.ErrNoTakeHomeAssignment
+		}
+		p.log.Err("failed to get take-home assignment", "error", err)
+		return common.TakeHomeAssignment{}, db.ErrInternal
+	}
+	assignment.Brief = &brief
+
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    file_number,
+    filename,
+    content_type,
+    size_bytes
+FROM
+    take_home_assignment_files
+WHERE
+    interview_id = $1
+ORDER BY
+    file_number
+`,
+		interviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to query take-home files", "error", err)
+		return common.TakeHomeAssignment{}, db.ErrInternal
+	}
+
+	assignment.Files, err = pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (common.TakeHomeFileInfo, error) {
+			var file common.TakeHomeFileInfo
+			err := row.Scan(
+				&file.FileNumber,
+				&file.Filename,
+				&file.ContentType,
+				&file.SizeBytes,
+			)
+			return file, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan take-home files", "error", err)
+		return common.TakeHomeAssignment{}, db.ErrInternal
+	}
+
+	rows, err = p.pool.Query(
+		ctx,
+		`
+SELECT
+    submission_number,
+    filename,
+    content_type,
+    size_bytes,
+    is_late,
+    submitted_at
+FROM
+    take_home_submissions
+WHERE
+    interview_id = $1
+ORDER BY
+    submission_number
+`,
+		interviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to query take-home submissions", "error", err)
+		return common.TakeHomeAssignment{}, db.ErrInternal
+	}
+
+	assignment.Submissions, err = pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (common.TakeHomeSubmission, error) {
+			var submission common.TakeHomeSubmission
+			err := row.Scan(
+				&submission.SubmissionNumber,
+				&submission.Filename,
+				&submission.ContentType,
+				&submission.SizeBytes,
+				&submission.IsLate,
+				&submission.SubmittedAt,
+			)
+			return submission, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan take-home submissions", "error", err)
+		return common.TakeHomeAssignment{}, db.ErrInternal
+	}
+
+	return assignment, nil
+}
+
+func (p *PG) GetEmployerTakeHomeFile(
+	ctx context.Context,
+	req common.GetTakeHomeFileRequest,
+) (db.TakeHomeFile, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.TakeHomeFile{}, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    f.filename,
+    f.file_path,
+    f.content_type,
+    f.size_bytes
+FROM
+    take_home_assignment_files f
+    JOIN take_home_assignments t ON t.interview_id = f.interview_id
+WHERE
+    f.interview_id = $1
+    AND f.file_number = $2
+    AND t.employer_id = $3
+`
+
+	return p.getTakeHomeFile(
+		ctx,
+		query,
+		req.InterviewID,
+		req.FileNumber,
+		orgUser.EmployerID,
+	)
+}
+
+// GetHubTakeHomeFile returns a file of the brief of the assignment, only
+// if the assignment is available to the candidate
+func (p *PG) GetHubTakeHomeFile(
+	ctx context.Context,
+	req common.GetTakeHomeFileRequest,
+) (db.TakeHomeFile, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.TakeHomeFile{}, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    f.filename,
+    f.file_path,
+    f.content_type,
+    f.size_bytes
+FROM
+    take_home_assignment_files f
+    JOIN take_home_assignments t ON t.interview_id = f.interview_id
+    JOIN interviews i ON i.id = t.interview_id
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+WHERE
+    f.interview_id = $1
+    AND f.file_number = $2
+    AND a.hub_user_id = $3
+    AND i.start_time <= timezone('UTC', now())
+    AND (t.time_limit_minutes IS NULL
+        OR t.started_at IS NOT NULL)
+`
+
+	return p.getTakeHomeFile(
+		ctx,
+		query,
+		req.InterviewID,
+		req.FileNumber,
+		hubUser.ID,
+	)
+}
+
+func (p *PG) GetEmployerTakeHomeSubmission(
+	ctx context.Context,
+	req common.GetTakeHomeSubmissionRequest,
+) (db.TakeHomeFile, error) {
+	orgUser, ok := ctx.Value(middleware.OrgUserCtxKey).(db.OrgUserTO)
+	if !ok {
+		p.log.Err("failed to get orgUser from context")
+		return db.TakeHomeFile{}, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    s.filename,
+    s.file_path,
+    s.content_type,
+    s.size_bytes
+FROM
+    take_home_submissions s
+    JOIN take_home_assignments t ON t.interview_id = s.interview_id
+WHERE
+    s.interview_id = $1
+    AND s.submission_number = $2
+    AND t.employer_id = $3
+`
+
+	return p.getTakeHomeFile(
+		ctx,
+		query,
+		req.InterviewID,
+		req.SubmissionNumber,
+		orgUser.EmployerID,
+	)
+}
+
+func (p *PG) GetHubTakeHomeSubmission(
+	ctx context.Context,
+	req common.GetTakeHomeSubmissionRequest,
+) (db.TakeHomeFile, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.TakeHomeFile{}, db.ErrInternal
+	}
+
+	query := `
+SELECT
+    s.filename,
+    s.file_path,
+    s.content_type,
+    s.size_bytes
+FROM
+    take_home_submissions s
+    JOIN interviews i ON i.id = s.interview_id
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+WHERE
+    s.interview_id = $1
+    AND s.submission_number = $2
+    AND a.hub_user_id = $3
+`
+
+	return p.getTakeHomeFile(
+		ctx,
+		query,
+		req.InterviewID,
+		req.SubmissionNumber,
+		hubUser.ID,
+	)
+}
+
+func (p *PG) getTakeHomeFile(
+	ctx context.Context,
+	query string,
+	args ...any,
+) (db.TakeHomeFile, error) {
+	var file db.TakeHomeFile
+	err := p.pool.QueryRow(ctx, query, args...).Scan(
+		&file.Filename,
+		&file.FilePath,
+		&file.ContentType,
+		&file.SizeBytes,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("take-home file not found", "args", args)
+			return db.TakeHomeFile{}, db.ErrNoTakeHomeFile
+		}
+		p.log.Err("failed to get take-home file", "error", err)
+		return db.TakeHomeFile{}, db.ErrInternal
+	}
+
+	return file, nil
+}
+
+// StartTakeHomeAssignment starts the clock of a time-boxed assignment
+func (p *PG) StartTakeHomeAssignment(
+	ctx context.Context,
+	interviewID string,
+) error {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var state common.InterviewState
+	var startTime, endTime, now time.Time
+	var timeBoxed, started bool
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    i.interview_state,
+    i.start_time,
+    i.end_time,
+    t.time_limit_minutes IS NOT NULL,
+    t.started_at IS NOT NULL,
+    timezone('UTC', now())
+FROM
+    take_home_assignments t
+    JOIN interviews i ON i.id = t.interview_id
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+WHERE
+    t.interview_id = $1
+    AND a.hub_user_id = $2
+FOR UPDATE OF t
+`,
+		interviewID,
+		hubUser.ID,
+	).Scan(&state, &startTime, &endTime, &timeBoxed, &started, &now)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("take-home not found", "interview_id", interviewID)
+			return db.ErrNoTakeHomeAssignment
+		}
+		p.log.Err("failed to get take-home assignment", "error", err)
+		return db.ErrInternal
+	}
+
+	if state != common.ScheduledInterviewState {
+		p.log.Dbg("interview not scheduled", "state", state)
+		return db.ErrInvalidInterviewState
+	}
+
+	if !timeBoxed || started {
+		p.log.Dbg("cannot start take-home", "time_boxed", timeBoxed)
+		return db.ErrStateMismatch
+	}
+
+	if now.Before(startTime) || !now.Before(endTime) {
+		p.log.Dbg("take-home not open", "now", now)
+		return db.ErrTakeHomeNotOpen
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE take_home_assignments
+SET started_at = $1
+WHERE interview_id = $2
+`,
+		now,
+		interviewID,
+	)
+	if err != nil {
+		p.log.Err("failed to start take-home", "error", err)
+		return db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
+
+// SubmitTakeHomeAssignment saves a submission of the candidate. A submission
+// after the deadline is marked late and, under the REJECT_LATE policy, is
+// not accepted at all once the grace period is over too.
+func (p *PG) SubmitTakeHomeAssignment(
+	ctx context.Context,
+	req db.SubmitTakeHomeReq,
+) (common.TakeHomeSubmission, error) {
+	hubUser, ok := ctx.Value(middleware.HubUserCtxKey).(db.HubUserTO)
+	if !ok {
+		p.log.Err("failed to get hubUser from context")
+		return common.TakeHomeSubmission{}, db.ErrInternal
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return common.TakeHomeSubmission{}, db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	var state common.InterviewState
+	var startTime, deadline, now time.Time
+	var timeBoxed, started bool
+	var policy common.LateSubmissionPolicy
+	var gracePeriodMinutes, submissions int
+	err = tx.QueryRow(
+		ctx,
+		`
+SELECT
+    i.interview_state,
+    i.start_time,`+takeHomeDeadline+`,
+    t.time_limit_minutes IS NOT NULL,
+    t.started_at IS NOT NULL,
+    t.late_submission_policy,
+    t.grace_period_minutes,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            take_home_submissions s
+        WHERE
+            s.interview_id = t.interview_id),
+    timezone('UTC', now())
+FROM
+    take_home_assignments t
+    JOIN interviews i ON i.id = t.interview_id
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+WHERE
+    t.interview_id = $1
+    AND a.hub_user_id = $2
+FOR UPDATE OF t
+`,
+		req.InterviewID,
+		hubUser.ID,
+	).Scan(
+		&state,
+		&startTime,
+		&deadline,
+		&timeBoxed,
+		&started,
+		&policy,
+		&gracePeriodMinutes,
+		&submissions,
+		&now,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Dbg("take-home not found", "id", req.InterviewID)
+			return common.TakeHomeSubmission{}, db.ErrNoTakeHomeAssignment
+		}
+		p.log.Err("failed to get take-home assignment", "error", err)
+		return common.TakeHomeSubmission{}, db.ErrInternal
+	}
+
+	if state != common.ScheduledInterviewState {
+		p.log.Dbg("interview not scheduled", "state", state)
+		return common.TakeHomeSubmission{}, db.ErrInvalidInterviewState
+	}
+
+	if now.Before(startTime) || (timeBoxed && !started) {
+		p.log.Dbg("take-home not open", "now", now, "started", started)
+		return common.TakeHomeSubmission{}, db.ErrTakeHomeNotOpen
+	}
+
+	if submissions >= vetchi.MaxTakeHomeSubmissions {
+		p.log.Dbg("too many submissions", "submissions", submissions)
+		return common.TakeHomeSubmission{}, db.ErrTooManySubmissions
+	}
+
+	isLate := now.After(deadline)
+	gracePeriod := time.Duration(gracePeriodMinutes) * time.Minute
+	if isLate && policy == common.RejectLateSubmissions &&
+		now.After(deadline.Add(gracePeriod)) {
+		p.log.Dbg("take-home deadline passed", "deadline", deadline)
+		return common.TakeHomeSubmission{}, db.ErrTakeHomeNotOpen
+	}
+
+	submission := common.TakeHomeSubmission{
+		SubmissionNumber: submissions + 1,
+		Filename:         req.Submission.Filename,
+		ContentType:      req.Submission.ContentType,
+		SizeBytes:        req.Submission.SizeBytes,
+		IsLate:           isLate,
+		SubmittedAt:      now,
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`
+INSERT INTO take_home_submissions (interview_id, submission_number, filename, file_path, content_type, size_bytes, is_late, submitted_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`,
+		req.InterviewID,
+		submission.SubmissionNumber,
+		submission.Filename,
+		req.Submission.FilePath,
+		submission.ContentType,
+		submission.SizeBytes,
+		submission.IsLate,
+		submission.SubmittedAt,
+	)
+	if err != nil {
+		p.log.Err("failed to insert take-home submission", "error", err)
+		return common.TakeHomeSubmission{}, db.ErrInternal
+	}
+
+	err = p.insertEmail(ctx, tx, req.Email)
+	if err != nil {
+		return common.TakeHomeSubmission{}, db.ErrInternal
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return common.TakeHomeSubmission{}, db.ErrInternal
+	}
+
+	return submission, nil
+}
+
+// GetDueTakeHomeReminders returns the open assignments without any
+// submission, whose deadline is within the leadTime and whose candidates
+// are not reminded yet
+func (p *PG) GetDueTakeHomeReminders(
+	ctx context.Context,
+	leadTime time.Duration,
+	limit int,
+) ([]db.DueTakeHomeReminder, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`
+SELECT
+    t.interview_id,
+    c.id,
+    hu.full_name,
+    hu.email,
+    e.company_name,
+    o.title,
+    d.deadline
+FROM
+    take_home_assignments t
+    JOIN interviews i ON i.id = t.interview_id
+    CROSS JOIN LATERAL (
+        SELECT`+takeHomeDeadline+` AS deadline) d
+    JOIN candidacies c ON c.id = i.candidacy_id
+    JOIN applications a ON a.id = c.application_id
+    JOIN hub_users hu ON hu.id = a.hub_user_id
+    JOIN employers e ON e.id = t.employer_id
+    JOIN openings o ON o.employer_id = c.employer_id
+        AND o.id = c.opening_id
+WHERE
+    i.interview_state = $1
+    AND t.reminder_sent_at IS NULL
+    AND i.start_time <= timezone('UTC', now())
+    AND d.deadline > timezone('UTC', now())
+    AND d.deadline <= timezone('UTC', now()) + make_interval(secs => $2)
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            take_home_submissions s
+        WHERE
+            s.interview_id = t.interview_id)
+ORDER BY
+    d.deadline
+LIMIT $3
+`,
+		common.ScheduledInterviewState,
+		leadTime.Seconds(),
+		limit,
+	)
+	if err != nil {
+		p.log.Err("failed to query due take-home reminders", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	reminders, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (db.DueTakeHomeReminder, error) {
+			var reminder db.DueTakeHomeReminder
+			err := row.Scan(
+				&reminder.InterviewID,
+				&reminder.CandidacyID,
+				&reminder.CandidateName,
+				&reminder.CandidateEmail,
+				&reminder.CompanyName,
+				&reminder.OpeningTitle,
+				&reminder.Deadline,
+			)
+			return reminder, err
+		},
+	)
+	if err != nil {
+		p.log.Err("failed to scan due take-home reminders", "error", err)
+		return nil, db.ErrInternal
+	}
+
+	return reminders, nil
+}
+
+// SendTakeHomeReminders marks the assignments as reminded and queues the
+// reminder emails in a single transaction
+func (p *PG) SendTakeHomeReminders(
+	ctx context.Context,
+	req db.TakeHomeRemindersReq,
+) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		p.log.Err("failed to begin transaction", "error", err)
+		return db.ErrInternal
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(
+		ctx,
+		`
+UPDATE take_home_assignments
+SET reminder_sent_at = timezone('UTC', now())
+WHERE interview_id = ANY ($1)
+`,
+		req.InterviewIDs,
+	)
+	if err != nil {
+		p.log.Err("failed to mark take-home reminders", "error", err)
+		return db.ErrInternal
+	}
+
+	for _, email := range req.Emails {
+		err = p.insertEmail(ctx, tx, email)
+		if err != nil {
+			return db.ErrInternal
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		p.log.Err("failed to commit transaction", "error", err)
+		return db.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+)
+
+const (
+	// clamd rejects the chunks larger than its StreamMaxLength and so the
+	// file is streamed in chunks well below the default of 25MB
+	clamdChunkSize = 1024 * 1024
+
+	// Timeout of the whole scan, including the connection to clamd
+	clamdTimeout = 2 * time.Minute
+)
+
+// clamd scans the files with the INSTREAM command of the ClamAV daemon
+type clamd struct {
+	address string
+	log     util.Logger
+}
+
+func (c *clamd) Scan(ctx context.Context, file []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, clamdTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return fmt.Errorf("failed to set clamd deadline: %w", err)
+	}
+
+	_, err = conn.Write([]byte("zINSTREAM\x00"))
+	if err != nil {
+		return fmt.Errorf("failed to send clamd command: %w", err)
+	}
+
+	// Each chunk is prefixed with its length and a zero length chunk marks
+	// the end of the stream
+	for start := 0; start < len(file); start += clamdChunkSize {
+		end := min(start+clamdChunkSize, len(file))
+		err = writeChunk(conn, file[start:end])
+		if err != nil {
+			return fmt.Errorf("failed to stream to clamd: %w", err)
+		}
+	}
+	err = writeChunk(conn, nil)
+	if err != nil {
+		return fmt.Errorf("failed to end clamd stream: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil {
+		return fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	reply = strings.TrimSuffix(reply, "\x00")
+
+	// The reply is "stream: OK" for a clean file and
+	// "stream: <signature> FOUND" for an infected one
+	switch {
+	case strings.HasSuffix(reply, " OK"):
+		return nil
+	case strings.HasSuffix(reply, " FOUND"):
+		c.log.Inf("clamd found malware", "reply", reply)
+		return ErrInfected
+	}
+
+	return fmt.Errorf("unexpected clamd reply: %q", reply)
+}
+
+func writeChunk(conn net.Conn, chunk []byte) error {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(chunk)))
+	_, err := conn.Write(size[:])
+	if err != nil {
+		return err
+	}
+
+	if len(chunk) > 0 {
+		_, err = conn.Write(chunk)
+	}
+	return err
+}
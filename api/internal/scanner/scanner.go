@@ -0,0 +1,37 @@
+// Package scanner scans the files uploaded by the users for malware
+package scanner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vetchium/vetchium/api/internal/util"
+)
+
+var ErrInfected = errors.New("file is infected")
+
+type Scanner interface {
+	// Scan returns ErrInfected if the file is found to be malicious
+	Scan(ctx context.Context, file []byte) error
+}
+
+// New returns a Scanner backed by the clamd daemon at the address. If the
+// address is empty, the files are not scanned for malware and only the
+// structural checks of the file types are applied by the callers.
+func New(clamdAddress string, log util.Logger) Scanner {
+	if clamdAddress == "" {
+		log.Inf("clamd address not configured, files will not be scanned")
+		return &noop{}
+	}
+
+	return &clamd{
+		address: clamdAddress,
+		log:     log,
+	}
+}
+
+type noop struct{}
+
+func (n *noop) Scan(ctx context.Context, file []byte) error {
+	return nil
+}
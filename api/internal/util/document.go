@@ -0,0 +1,152 @@
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"path"
+	"strings"
+)
+
+var (
+	ErrUnsupportedDocument = errors.New("document is neither a PDF nor a ZIP")
+	ErrDocumentTooLarge    = errors.New("document too large")
+	ErrMalformedArchive    = errors.New("malformed ZIP archive")
+)
+
+const (
+	PDFContentType  = "application/pdf"
+	ZIPContentType  = "application/zip"
+	JPEGContentType = "image/jpeg"
+	PNGContentType  = "image/png"
+
+	// Limits on the contents of the ZIP archives, to reject the archives
+	// that expand to far more than their size
+	maxArchiveEntries          = 1000
+	maxArchiveUncompressedSize = 200 * 1024 * 1024 // 200MB
+)
+
+// ZIP local file header magic number
+var zipHeader = []byte("PK\x03\x04")
+
+// ValidateDocument decodes the base64 string and checks that it is a
+// well-formed PDF or ZIP archive of at most maxSize bytes. Returns the
+// decoded bytes along with their content type.
+//
+// The ZIP archives are rejected if they are encrypted, as their contents
+// cannot be scanned, if they have entries that would be extracted outside
+// of the target directory, or if they expand to too many or too large files.
+func ValidateDocument(
+	base64Document string,
+	maxSize int,
+) ([]byte, string, error) {
+	document, err := decodeDocument(base64Document, maxSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType, err := validateDocumentBytes(document)
+	if err != nil {
+		return nil, "", err
+	}
+	return document, contentType, nil
+}
+
+// ValidateAttachment is ValidateDocument that also accepts the JPEG and the
+// PNG images
+func ValidateAttachment(
+	base64Attachment string,
+	maxSize int,
+) ([]byte, string, error) {
+	attachment, err := decodeDocument(base64Attachment, maxSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(attachment))
+	if err == nil && config.Width > 0 && config.Height > 0 {
+		switch format {
+		case "jpeg":
+			return attachment, JPEGContentType, nil
+		case "png":
+			return attachment, PNGContentType, nil
+		}
+	}
+
+	contentType, err := validateDocumentBytes(attachment)
+	if err != nil {
+		return nil, "", err
+	}
+	return attachment, contentType, nil
+}
+
+func decodeDocument(base64Document string, maxSize int) ([]byte, error) {
+	// Reject without decoding the documents that are obviously too large
+	if base64.StdEncoding.DecodedLen(len(base64Document)) > maxSize+2 {
+		return nil, ErrDocumentTooLarge
+	}
+
+	document, err := base64.StdEncoding.DecodeString(base64Document)
+	if err != nil {
+		return nil, ErrInvalidBase64
+	}
+
+	if len(document) > maxSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	return document, nil
+}
+
+func validateDocumentBytes(document []byte) (string, error) {
+	switch {
+	case bytes.HasPrefix(document, []byte(pdfHeader)):
+		err := validatePDFStructure(document)
+		if err != nil {
+			return "", err
+		}
+		return PDFContentType, nil
+
+	case bytes.HasPrefix(document, zipHeader):
+		err := validateZIPStructure(document)
+		if err != nil {
+			return "", err
+		}
+		return ZIPContentType, nil
+	}
+
+	return "", ErrUnsupportedDocument
+}
+
+func validateZIPStructure(document []byte) error {
+	r, err := zip.NewReader(bytes.NewReader(document), int64(len(document)))
+	if err != nil {
+		return ErrMalformedArchive
+	}
+
+	if len(r.File) > maxArchiveEntries {
+		return ErrMalformedArchive
+	}
+
+	var uncompressedSize uint64
+	for _, f := range r.File {
+		// Bit 0 of the general purpose flags marks an encrypted entry
+		if f.Flags&0x1 != 0 {
+			return ErrMalformedArchive
+		}
+
+		name := strings.ReplaceAll(f.Name, "\\", "/")
+		if path.IsAbs(name) || strings.HasPrefix(path.Clean(name), "..") {
+			return ErrMalformedArchive
+		}
+
+		uncompressedSize += f.UncompressedSize64
+		if uncompressedSize > maxArchiveUncompressedSize {
+			return ErrMalformedArchive
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iTIP methods, https://www.rfc-editor.org/rfc/rfc5546#section-1.4
+const (
+	ICalMethodPublish = "PUBLISH"
+	ICalMethodRequest = "REQUEST"
+	ICalMethodCancel  = "CANCEL"
+)
+
+const (
+	icalProdID       = "-//Vetchium//Interviews//EN"
+	icalTimeFormat   = "20060102T150405Z"
+	icalMaxLineBytes = 75
+)
+
+// ICalEvent is a VEVENT of an iCalendar object. The UID should remain the
+// same for the lifetime of the event and the Sequence should be incremented
+// every time the event is rescheduled or cancelled, so that the calendar
+// clients update the existing entry instead of creating a new one.
+type ICalEvent struct {
+	UID         string
+	Sequence    int
+	StartTime   time.Time
+	EndTime     time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Organizer   string
+	Attendees   []string
+	Cancelled   bool
+}
+
+// ICalendar renders the events as an RFC 5545 iCalendar object with the
+// given iTIP method. The method should be empty for the objects that are
+// stored in a CalDAV calendar.
+func ICalendar(method string, events []ICalEvent) string {
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:"+icalProdID,
+		"CALSCALE:GREGORIAN",
+	)
+	if method != "" {
+		lines = append(lines, "METHOD:"+method)
+	}
+
+	stamp := time.Now().UTC().Format(icalTimeFormat)
+	for _, event := range events {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+event.UID,
+			fmt.Sprintf("SEQUENCE:%d", event.Sequence),
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+event.StartTime.UTC().Format(icalTimeFormat),
+			"DTEND:"+event.EndTime.UTC().Format(icalTimeFormat),
+			"SUMMARY:"+icalEscape(event.Summary),
+		)
+		if event.Description != "" {
+			lines = append(lines,
+				"DESCRIPTION:"+icalEscape(event.Description))
+		}
+		if event.Location != "" {
+			lines = append(lines, "LOCATION:"+icalEscape(event.Location))
+		}
+		if event.URL != "" {
+			lines = append(lines, "URL:"+event.URL)
+		}
+		if event.Organizer != "" {
+			lines = append(lines, "ORGANIZER:mailto:"+event.Organizer)
+		}
+		for _, attendee := range event.Attendees {
+			lines = append(lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;"+
+				"PARTSTAT=NEEDS-ACTION;RSVP=FALSE:mailto:"+attendee)
+		}
+		if event.Cancelled {
+			lines = append(lines, "STATUS:CANCELLED")
+		} else {
+			lines = append(lines, "STATUS:CONFIRMED")
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(icalFold(line))
+		sb.WriteString("\r\n")
+	}
+	return sb.String()
+}
+
+// ICalContentType returns the MIME type of the iCalendar object, including
+// the method parameter that the mail clients need to show the invitation
+func ICalContentType(calendar string) string {
+	contentType := "text/calendar; charset=utf-8"
+	for _, line := range strings.Split(calendar, "\r\n") {
+		if method, ok := strings.CutPrefix(line, "METHOD:"); ok {
+			return contentType + "; method=" + method
+		}
+	}
+	return contentType
+}
+
+func icalEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// icalFold splits the content lines longer than 75 octets, without breaking
+// a multi-byte character, as per RFC 5545 section 3.1
+func icalFold(line string) string {
+	var sb strings.Builder
+	limit := icalMaxLineBytes
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of the continuation line counts towards the limit
+		limit = icalMaxLineBytes - 1
+	}
+	sb.WriteString(line)
+	return sb.String()
+}
@@ -23,8 +23,11 @@ const (
 	ProfilePictureIDLenBytes = 16              // Length of the unique ID for profile pictures
 
 	// S3 storage paths
-	ProfilePicturesPath = "hub-users/profile-pictures/" // Scoped under hub-users since it's user specific
-	ResumesPath         = "resumes/"                    // Top-level since resumes can come from multiple sources
+	ProfilePicturesPath   = "hub-users/profile-pictures/" // Scoped under hub-users since it's user specific
+	ResumesPath           = "resumes/"                    // Top-level since resumes can come from multiple sources
+	OffersPath            = "offers/"                     // Offer letters and attachments of the candidacies
+	TakeHomesPath         = "take-homes/"                 // Briefs and submissions of the take-home assignments
+	CandidacyCommentsPath = "candidacy-comments/"         // Files attached to the comments on the candidacies
 )
 
 // ValidateImage checks if the given image file meets the size, format, and dimension requirements
@@ -0,0 +1,764 @@
+package util
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	// Limits on the work done for a single document, as the resumes are
+	// uploaded by the applicants
+	maxPDFDecodedSize = 32 * 1024 * 1024
+	maxPDFTextSize    = 1024 * 1024
+	maxPDFPageDepth   = 32
+)
+
+// PDFText extracts the text of the pages of a PDF document, a line of the
+// output per line of the text as positioned on the pages. Only the
+// uncompressed and the FlateDecode streams are read, and the text of the
+// fonts without a ToUnicode map is read as Latin-1. This is meant for the
+// search and the scoring of the documents and not for their rendering.
+func PDFText(pdf []byte) (string, error) {
+	if !bytes.HasPrefix(pdf, []byte(pdfHeader)) {
+		return "", ErrNotPDF
+	}
+
+	r := &pdfReader{
+		data:    pdf,
+		objects: make(map[int]any),
+		cmaps:   make(map[int]*pdfCMap),
+	}
+	r.indexObjects()
+
+	var catalog pdfDict
+	for _, object := range r.objects {
+		dict, ok := object.(pdfDict)
+		if ok && dict.name("Type") == "Catalog" {
+			catalog = dict
+			break
+		}
+	}
+	if catalog == nil {
+		return "", ErrMalformedPDF
+	}
+
+	root, ok := r.resolve(catalog["Pages"]).(pdfDict)
+	if !ok {
+		return "", ErrMalformedPDF
+	}
+
+	var text strings.Builder
+	r.walkPages(root, nil, 0, &text)
+	return text.String(), nil
+}
+
+type (
+	pdfDict    map[string]any
+	pdfName    string
+	pdfKeyword string
+	pdfRef     int
+)
+
+type pdfStream struct {
+	dict pdfDict
+	raw  []byte
+}
+
+func (d pdfDict) name(key string) string {
+	name, _ := d[key].(pdfName)
+	return string(name)
+}
+
+type pdfReader struct {
+	data    []byte
+	objects map[int]any
+	cmaps   map[int]*pdfCMap
+	decoded int
+}
+
+var pdfObjectStart = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+
+// indexObjects parses all the indirect objects of the document, including
+// the ones inside the object streams. The cross-reference tables are not
+// read, so that the documents with broken tables can still be read. The
+// later definitions of an object win, as with the incremental updates.
+func (r *pdfReader) indexObjects() {
+	for _, match := range pdfObjectStart.FindAllSubmatchIndex(r.data, -1) {
+		num, err := strconv.Atoi(string(r.data[match[2]:match[3]]))
+		if err != nil {
+			continue
+		}
+
+		l := &pdfLexer{data: r.data, pos: match[1]}
+		value := l.value()
+		dict, ok := value.(pdfDict)
+		if ok && l.keyword("stream") {
+			value = pdfStream{dict: dict, raw: l.streamData()}
+		}
+		r.objects[num] = value
+	}
+
+	for _, object := range r.objects {
+		stream, ok := object.(pdfStream)
+		if !ok || stream.dict.name("Type") != "ObjStm" {
+			continue
+		}
+		r.indexObjectStream(stream)
+	}
+}
+
+func (r *pdfReader) indexObjectStream(stream pdfStream) {
+	data := r.decode(stream)
+	first, _ := stream.dict["First"].(float64)
+	count, _ := stream.dict["N"].(float64)
+	// The offsets are read from the document and cannot be trusted. The
+	// checks are written to also reject a NaN.
+	if data == nil || !(first >= 0 && first <= float64(len(data))) {
+		return
+	}
+
+	header := &pdfLexer{data: data[:int(first)]}
+	for i := 0; i < int(count); i++ {
+		num, ok1 := header.value().(float64)
+		offset, ok2 := header.value().(float64)
+		if !ok1 || !ok2 ||
+			!(offset >= 0 && first+offset < float64(len(data))) {
+			return
+		}
+		if _, exists := r.objects[int(num)]; exists {
+			continue
+		}
+		l := &pdfLexer{data: data, pos: int(first + offset)}
+		r.objects[int(num)] = l.value()
+	}
+}
+
+// resolve follows the indirect references, for a bounded number of hops
+func (r *pdfReader) resolve(value any) any {
+	for i := 0; i < 8; i++ {
+		ref, ok := value.(pdfRef)
+		if !ok {
+			return value
+		}
+		value = r.objects[int(ref)]
+	}
+	return nil
+}
+
+// decode returns the decoded data of the stream, or nil if its filters
+// are not supported
+func (r *pdfReader) decode(stream pdfStream) []byte {
+	var filters []any
+	switch filter := r.resolve(stream.dict["Filter"]).(type) {
+	case nil:
+	case pdfName:
+		filters = []any{filter}
+	case []any:
+		filters = filter
+	}
+
+	data := stream.raw
+	for _, filter := range filters {
+		if r.resolve(filter) != pdfName("FlateDecode") {
+			return nil
+		}
+
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+		// The truncated streams are common and still mostly readable
+		data, _ = io.ReadAll(
+			io.LimitReader(zr, int64(maxPDFDecodedSize-r.decoded)),
+		)
+		zr.Close()
+		r.decoded += len(data)
+		if r.decoded >= maxPDFDecodedSize {
+			return nil
+		}
+	}
+
+	return data
+}
+
+// walkPages writes the text of the pages under the node of the page tree,
+// in order. The resources are inherited from the ancestors of the pages.
+func (r *pdfReader) walkPages(
+	node pdfDict,
+	resources pdfDict,
+	depth int,
+	text *strings.Builder,
+) {
+	// The depth also guards against the cycles in the broken page trees
+	if depth > maxPDFPageDepth {
+		return
+	}
+
+	if own, ok := r.resolve(node["Resources"]).(pdfDict); ok {
+		resources = own
+	}
+
+	if node.name("Type") != "Pages" {
+		r.pageText(node, resources, text)
+		return
+	}
+
+	kids, _ := r.resolve(node["Kids"]).([]any)
+	for _, kid := range kids {
+		if text.Len() >= maxPDFTextSize {
+			return
+		}
+		if page, ok := r.resolve(kid).(pdfDict); ok {
+			r.walkPages(page, resources, depth+1, text)
+		}
+	}
+}
+
+func (r *pdfReader) pageText(
+	page pdfDict,
+	resources pdfDict,
+	text *strings.Builder,
+) {
+	fonts := make(map[string]*pdfFont)
+	fontDicts, _ := r.resolve(resources["Font"]).(pdfDict)
+	for name, ref := range fontDicts {
+		if font, ok := r.resolve(ref).(pdfDict); ok {
+			fonts[name] = r.font(font)
+		}
+	}
+
+	var contents []any
+	switch content := r.resolve(page["Contents"]).(type) {
+	case pdfStream:
+		contents = []any{content}
+	case []any:
+		contents = content
+	}
+
+	// The content streams of a page are a single stream split at arbitrary
+	// token boundaries
+	var data []byte
+	for _, content := range contents {
+		if stream, ok := r.resolve(content).(pdfStream); ok {
+			data = append(data, r.decode(stream)...)
+			data = append(data, '\n')
+		}
+	}
+
+	interpretText(data, fonts, text)
+	text.WriteByte('\n')
+}
+
+type pdfFont struct {
+	cmap *pdfCMap
+	// Composite fonts use the multi-byte codes, which are meaningless
+	// without a ToUnicode map
+	composite bool
+}
+
+func (r *pdfReader) font(dict pdfDict) *pdfFont {
+	font := &pdfFont{composite: dict.name("Subtype") == "Type0"}
+
+	ref, ok := dict["ToUnicode"].(pdfRef)
+	if !ok {
+		return font
+	}
+
+	cmap, seen := r.cmaps[int(ref)]
+	if !seen {
+		if stream, ok := r.resolve(ref).(pdfStream); ok {
+			cmap = parseCMap(r.decode(stream))
+		}
+		r.cmaps[int(ref)] = cmap
+	}
+	font.cmap = cmap
+	return font
+}
+
+func (f *pdfFont) text(s []byte) string {
+	if f == nil || f.cmap == nil {
+		if f != nil && f.composite {
+			return ""
+		}
+		return latin1(s)
+	}
+
+	var out strings.Builder
+	width := f.cmap.codeWidth
+	for i := 0; i+width <= len(s); i += width {
+		code := 0
+		for _, b := range s[i : i+width] {
+			code = code<<8 | int(b)
+		}
+		if mapped, ok := f.cmap.codes[code]; ok {
+			out.WriteString(mapped)
+		} else if width == 1 {
+			out.WriteString(latin1(s[i : i+1]))
+		}
+	}
+	return out.String()
+}
+
+func latin1(s []byte) string {
+	runes := make([]rune, 0, len(s))
+	for _, b := range s {
+		runes = append(runes, rune(b))
+	}
+	return string(runes)
+}
+
+// pdfCMap maps the character codes of a font to their Unicode text
+type pdfCMap struct {
+	codeWidth int
+	codes     map[int]string
+}
+
+func parseCMap(data []byte) *pdfCMap {
+	cmap := &pdfCMap{codeWidth: 0, codes: make(map[int]string)}
+	l := &pdfLexer{data: data}
+
+	var operands []any
+	for {
+		value := l.value()
+		if value == nil && l.pos >= len(data) {
+			break
+		}
+
+		keyword, ok := value.(pdfKeyword)
+		if !ok {
+			operands = append(operands, value)
+			continue
+		}
+
+		switch keyword {
+		case "endcodespacerange":
+			if len(operands) > 0 {
+				if lo, ok := operands[0].([]byte); ok {
+					cmap.codeWidth = len(lo)
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].([]byte)
+				dst, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 {
+					cmap.set(src, utf16BE(dst))
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				cmap.setRange(operands[i], operands[i+1], operands[i+2])
+			}
+		}
+		operands = operands[:0]
+	}
+
+	if cmap.codeWidth == 0 {
+		cmap.codeWidth = 2
+	}
+	return cmap
+}
+
+func (c *pdfCMap) set(src []byte, text string) {
+	code := 0
+	for _, b := range src {
+		code = code<<8 | int(b)
+	}
+	c.codes[code] = text
+	if c.codeWidth == 0 {
+		c.codeWidth = len(src)
+	}
+}
+
+func (c *pdfCMap) setRange(loValue, hiValue, dstValue any) {
+	lo, ok1 := loValue.([]byte)
+	hi, ok2 := hiValue.([]byte)
+	if !ok1 || !ok2 || len(lo) != len(hi) {
+		return
+	}
+
+	start, end := 0, 0
+	for i := range lo {
+		start = start<<8 | int(lo[i])
+		end = end<<8 | int(hi[i])
+	}
+	// Guards against the absurd ranges in the broken or hostile documents
+	if end < start || end-start > 0xFFFF {
+		return
+	}
+
+	switch dst := dstValue.(type) {
+	case []byte:
+		runes := utf16.Decode(utf16Units(dst))
+		if len(runes) == 0 {
+			return
+		}
+		for code := start; code <= end; code++ {
+			// The last character is incremented along the range
+			runes[len(runes)-1] = runes[len(runes)-1] + rune(code-start)
+			c.codes[code] = string(runes)
+			runes[len(runes)-1] = runes[len(runes)-1] - rune(code-start)
+		}
+	case []any:
+		for i, item := range dst {
+			if text, ok := item.([]byte); ok && start+i <= end {
+				c.codes[start+i] = utf16BE(text)
+			}
+		}
+	}
+
+	if c.codeWidth == 0 {
+		c.codeWidth = len(lo)
+	}
+}
+
+func utf16Units(b []byte) []uint16 {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return units
+}
+
+func utf16BE(b []byte) string {
+	return string(utf16.Decode(utf16Units(b)))
+}
+
+// interpretText runs the text operators of a content stream. The text
+// positioning operators that move to another line end the line, which
+// keeps the headings and the bullets of the resumes on their own lines.
+func interpretText(
+	data []byte,
+	fonts map[string]*pdfFont,
+	text *strings.Builder,
+) {
+	l := &pdfLexer{data: data}
+	var font *pdfFont
+	var operands []any
+
+	for text.Len() < maxPDFTextSize {
+		value := l.value()
+		if value == nil && l.pos >= len(data) {
+			return
+		}
+
+		op, ok := value.(pdfKeyword)
+		if !ok {
+			operands = append(operands, value)
+			continue
+		}
+
+		switch op {
+		case "Tf":
+			if len(operands) > 0 {
+				name, _ := operands[0].(pdfName)
+				font = fonts[string(name)]
+			}
+		case "Tj":
+			if s, ok := lastOperand(operands).([]byte); ok {
+				text.WriteString(font.text(s))
+			}
+		case "'", "\"":
+			text.WriteByte('\n')
+			if s, ok := lastOperand(operands).([]byte); ok {
+				text.WriteString(font.text(s))
+			}
+		case "TJ":
+			items, _ := lastOperand(operands).([]any)
+			for _, item := range items {
+				switch item := item.(type) {
+				case []byte:
+					text.WriteString(font.text(item))
+				case float64:
+					// A large negative adjustment is a gap between words
+					if item < -200 {
+						text.WriteByte(' ')
+					}
+				}
+			}
+		case "Td", "TD":
+			if len(operands) == 2 {
+				if ty, _ := operands[1].(float64); ty != 0 {
+					text.WriteByte('\n')
+				} else {
+					text.WriteByte(' ')
+				}
+			}
+		case "T*", "Tm", "ET":
+			text.WriteByte('\n')
+		case "ID":
+			l.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+}
+
+func lastOperand(operands []any) any {
+	if len(operands) == 0 {
+		return nil
+	}
+	return operands[len(operands)-1]
+}
+
+// pdfLexer reads the values of the PDF syntax. The keywords, like the
+// operators of the content streams, are returned as pdfKeyword. A nil
+// value is returned at the end of the data and for the unreadable bytes.
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' ||
+		c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func (l *pdfLexer) skipWhitespace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if c == '%' {
+			for l.pos < len(l.data) &&
+				l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		if !isPDFWhitespace(c) {
+			return
+		}
+		l.pos++
+	}
+}
+
+func (l *pdfLexer) regular() string {
+	start := l.pos
+	for l.pos < len(l.data) &&
+		!isPDFWhitespace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+// keyword consumes the keyword if it is the next token
+func (l *pdfLexer) keyword(keyword string) bool {
+	pos := l.pos
+	l.skipWhitespace()
+	if l.regular() == keyword {
+		return true
+	}
+	l.pos = pos
+	return false
+}
+
+func (l *pdfLexer) value() any {
+	l.skipWhitespace()
+	if l.pos >= len(l.data) {
+		return nil
+	}
+
+	switch c := l.data[l.pos]; {
+	case c == '(':
+		return l.literalString()
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return l.dict()
+	case c == '<':
+		return l.hexString()
+	case c == '[':
+		l.pos++
+		return l.array()
+	case c == '/':
+		l.pos++
+		return pdfName(l.regular())
+	case c == ']' || c == '>' || c == ')' || c == '{' || c == '}':
+		l.pos++
+		return pdfKeyword([]byte{c})
+	}
+
+	token := l.regular()
+	if token == "" {
+		l.pos++
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		switch token {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return pdfKeyword(token)
+	}
+
+	// An indirect reference is two integers followed by R
+	pos := l.pos
+	l.skipWhitespace()
+	if l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '9' {
+		_, err := strconv.Atoi(l.regular())
+		if err == nil && l.keyword("R") {
+			return pdfRef(int(number))
+		}
+	}
+	l.pos = pos
+	return number
+}
+
+func (l *pdfLexer) dict() pdfDict {
+	dict := make(pdfDict)
+	for {
+		l.skipWhitespace()
+		if l.pos+1 >= len(l.data) {
+			return dict
+		}
+		if l.data[l.pos] == '>' && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			return dict
+		}
+
+		key, ok := l.value().(pdfName)
+		if !ok {
+			continue
+		}
+		dict[string(key)] = l.value()
+	}
+}
+
+func (l *pdfLexer) array() []any {
+	var array []any
+	for {
+		l.skipWhitespace()
+		if l.pos >= len(l.data) {
+			return array
+		}
+		if l.data[l.pos] == ']' {
+			l.pos++
+			return array
+		}
+		array = append(array, l.value())
+	}
+}
+
+func (l *pdfLexer) literalString() []byte {
+	var s []byte
+	depth := 0
+	l.pos++ // (
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return s
+			}
+			depth--
+		case '\\':
+			if l.pos >= len(l.data) {
+				return s
+			}
+			c = l.data[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				// A line continuation
+				if c == '\r' && l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					octal := int(c - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) &&
+						l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						octal = octal*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(octal)
+				}
+			}
+		}
+		s = append(s, c)
+	}
+	return s
+}
+
+func (l *pdfLexer) hexString() []byte {
+	var s []byte
+	var digits []byte
+	l.pos++ // <
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		c := l.data[l.pos]
+		l.pos++
+		if strings.IndexByte("0123456789abcdefABCDEF", c) >= 0 {
+			digits = append(digits, c)
+		}
+	}
+	l.pos++ // >
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	for i := 0; i < len(digits); i += 2 {
+		b, _ := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		s = append(s, byte(b))
+	}
+	return s
+}
+
+// streamData returns the raw data of the stream whose keyword was just
+// read. The Length of the dictionary is not trusted, as it is often an
+// indirect reference or plain wrong.
+func (l *pdfLexer) streamData() []byte {
+	if l.pos < len(l.data) && l.data[l.pos] == '\r' {
+		l.pos++
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+		l.pos++
+	}
+
+	end := bytes.Index(l.data[l.pos:], []byte("endstream"))
+	if end < 0 {
+		return l.data[l.pos:]
+	}
+	data := bytes.TrimRight(l.data[l.pos:l.pos+end], "\r\n")
+	l.pos += end + len("endstream")
+	return data
+}
+
+// skipInlineImage skips the binary data of an inline image, which ends at
+// an EI surrounded by the whitespace
+func (l *pdfLexer) skipInlineImage() {
+	for l.pos+2 < len(l.data) {
+		if isPDFWhitespace(l.data[l.pos]) &&
+			l.data[l.pos+1] == 'E' && l.data[l.pos+2] == 'I' &&
+			(l.pos+3 == len(l.data) || isPDFWhitespace(l.data[l.pos+3])) {
+			l.pos += 3
+			return
+		}
+		l.pos++
+	}
+	l.pos = len(l.data)
+}
@@ -0,0 +1,136 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// objectStreamPDF is a document whose catalog is inside an object stream
+// with the given /First and the given offset of the catalog
+func objectStreamPDF(first, offset string) []byte {
+	catalog := "<< /Type /Catalog /Pages 3 0 R >>"
+	header := "2 " + offset + " "
+	data := header + catalog
+	content := "BT /F1 11 Tf 56 790 Td (Hello from the stream) Tj ET"
+
+	return []byte(fmt.Sprintf(`%%PDF-1.5
+1 0 obj
+<< /Type /ObjStm /N 1 /First %s /Length %d >>
+stream
+%s
+endstream
+endobj
+3 0 obj
+<< /Type /Pages /Kids [4 0 R] /Count 1 >>
+endobj
+4 0 obj
+<< /Type /Page /Parent 3 0 R /Contents 5 0 R >>
+endobj
+5 0 obj
+<< /Length %d >>
+stream
+%s
+endstream
+endobj
+%%%%EOF
+`, first, len(data), data, len(content), content))
+}
+
+func TestPDFText(t *testing.T) {
+	validStream := objectStreamPDF(
+		fmt.Sprint(len("2 0 ")),
+		"0",
+	)
+
+	tests := []struct {
+		name     string
+		pdf      []byte
+		wantText string
+		wantErr  error
+	}{
+		{
+			name:     "rendered by TextPDF",
+			pdf:      TextPDF([]string{"Senior Go Engineer", "Kubernetes"}),
+			wantText: "Senior Go Engineer\nKubernetes",
+		},
+		{
+			name:     "catalog in an object stream",
+			pdf:      validStream,
+			wantText: "Hello from the stream",
+		},
+		{
+			name:    "not a PDF",
+			pdf:     []byte("PK\x03\x04"),
+			wantErr: ErrNotPDF,
+		},
+		{
+			name:    "no catalog",
+			pdf:     []byte("%PDF-1.4\n1 0 obj\n<< /Type /Pages >>\nendobj\n"),
+			wantErr: ErrMalformedPDF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := PDFText(tt.pdf)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PDFText() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("PDFText() = %q, want it to contain %q",
+					text, tt.wantText)
+			}
+		})
+	}
+}
+
+// The offsets of the object streams are read from the uploaded documents
+// and must not be trusted
+func TestPDFTextHostileObjectStreams(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  string
+		offset string
+	}{
+		{name: "negative first", first: "-3", offset: "0"},
+		{name: "negative offset", first: "4", offset: "-44"},
+		{name: "first past the data", first: "4096", offset: "0"},
+		{name: "offset past the data", first: "4", offset: "4096"},
+		{name: "NaN first", first: "NaN", offset: "0"},
+		{name: "NaN offset", first: "6", offset: "NaN"},
+		{name: "infinite first", first: "Inf", offset: "0"},
+		{name: "infinite offset", first: "6", offset: "-Inf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The catalog cannot be found, as it is in the broken stream
+			_, err := PDFText(objectStreamPDF(tt.first, tt.offset))
+			if !errors.Is(err, ErrMalformedPDF) {
+				t.Errorf("PDFText() error = %v, want %v",
+					err, ErrMalformedPDF)
+			}
+		})
+	}
+}
+
+func FuzzPDFText(f *testing.F) {
+	f.Add(TextPDF([]string{"Senior Go Engineer", "Kubernetes"}))
+	f.Add(objectStreamPDF("4", "0"))
+	f.Add(objectStreamPDF("-3", "0"))
+	f.Add(objectStreamPDF("4", "-44"))
+	f.Add(objectStreamPDF("NaN", "NaN"))
+	f.Add([]byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n"))
+
+	f.Fuzz(func(t *testing.T, pdf []byte) {
+		text, err := PDFText(pdf)
+		if err != nil && text != "" {
+			t.Errorf("PDFText() = %q along with error %v", text, err)
+		}
+		if len(text) > 2*maxPDFTextSize {
+			t.Errorf("PDFText() returned %d bytes", len(text))
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	textPDFLinesPerPage = 50
+	textPDFFontSize     = 11
+	textPDFLeading      = 14
+	textPDFMaxLineLen   = 90
+)
+
+// TextPDF renders the given lines as a plain A4 PDF document in Helvetica.
+// Long lines are wrapped and the lines are paginated. Characters outside
+// the printable ASCII range are replaced with '?' as the standard fonts
+// cannot render them without embedding a font.
+func TextPDF(lines []string) []byte {
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapLine(toPDFASCII(line))...)
+	}
+
+	var pages [][]string
+	for len(wrapped) > textPDFLinesPerPage {
+		pages = append(pages, wrapped[:textPDFLinesPerPage])
+		wrapped = wrapped[textPDFLinesPerPage:]
+	}
+	pages = append(pages, wrapped)
+
+	// Objects 1 and 2 are the catalog and the page tree, 3 is the font and
+	// every page takes two objects, the page and its content stream.
+	var objects []string
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*i))
+	}
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+	objects = append(objects, fmt.Sprintf(
+		"<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "),
+		len(pages),
+	))
+	objects = append(
+		objects,
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	)
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(
+			&content,
+			"BT /F1 %d Tf %d TL 56 790 Td\n",
+			textPDFFontSize,
+			textPDFLeading,
+		)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escapePDFText(line))
+		}
+		content.WriteString("ET")
+
+		objects = append(objects, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] "+
+				"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			5+2*i,
+		))
+		objects = append(objects, fmt.Sprintf(
+			"<< /Length %d >>\nstream\n%s\nendstream",
+			content.Len(),
+			content.String(),
+		))
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, 0, len(objects))
+	for i, object := range objects {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(
+		&out,
+		"trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1,
+		xref,
+	)
+	return out.Bytes()
+}
+
+func toPDFASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return ' '
+		}
+		if r < 0x20 || r > 0x7e {
+			return '?'
+		}
+		return r
+	}, s)
+}
+
+func wrapLine(line string) []string {
+	if len(line) <= textPDFMaxLineLen {
+		return []string{line}
+	}
+
+	var lines []string
+	for len(line) > textPDFMaxLineLen {
+		cut := strings.LastIndex(line[:textPDFMaxLineLen], " ")
+		if cut <= 0 {
+			cut = textPDFMaxLineLen
+		}
+		lines = append(lines, line[:cut])
+		line = strings.TrimLeft(line[cut:], " ")
+	}
+	return append(lines, line)
+}
+
+func escapePDFText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(s)
+}
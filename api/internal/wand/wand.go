@@ -1,9 +1,13 @@
 package wand
 
 import (
+	"github.com/vetchium/vetchium/api/internal/broker"
 	"github.com/vetchium/vetchium/api/internal/config"
+	"github.com/vetchium/vetchium/api/internal/esign"
 	"github.com/vetchium/vetchium/api/internal/hedwig"
+	"github.com/vetchium/vetchium/api/internal/meeting"
 	"github.com/vetchium/vetchium/api/internal/postgres"
+	"github.com/vetchium/vetchium/api/internal/scanner"
 	"github.com/vetchium/vetchium/api/pkg/vetchi"
 )
 
@@ -16,6 +20,10 @@ type Wand interface {
 	DB() *postgres.PG
 	Vator() *vetchi.Vator
 	Hedwig() hedwig.Hedwig
+	ESign() esign.ESignProvider
+	Meetings() meeting.Providers
+	Scanner() scanner.Scanner
+	Broker() *broker.Broker
 
 	Config() *config.Hermione
 
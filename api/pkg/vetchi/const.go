@@ -21,6 +21,8 @@ const (
 	// Sent as a response to the signin request
 	// Used for the /employer/tfa request body
 	TGTokenLenBytes = 32
+	// Part of the URL of the private iCalendar feed of the interviews
+	CalendarFeedTokenLenBytes = 24
 
 	// Used for the email code that is sent to the user's email for tfa
 	EmailTokenLenBytes = 2
@@ -34,10 +36,15 @@ const (
 	InterviewIDLenBytes   = 16
 	PostIDLenBytes        = 24
 	ResumeIDLenBytes      = 12
+
+	SchedulingRequestIDLenBytes = 16
 )
 
 const (
 	EmailFrom = "no-reply@vetchi.org"
+
+	// Suffix of the globally unique UIDs of the iCalendar events
+	ICalUIDDomain = "vetchi.org"
 )
 
 const (
@@ -59,6 +66,14 @@ const (
 
 const (
 	MaxApplicationsToScorePerBatch = 10
+	// Maximum number of opening transitions and of application expiries
+	// applied by a single run of the opening schedules job
+	MaxOpeningScheduleChangesPerBatch = 100
+	MaxExpiredOffersPerBatch          = 100
+	MaxOfferSignaturesPerPoll         = 20
+	MaxCalDAVSyncsPerBatch            = 20
+	MaxTakeHomeRemindersPerBatch      = 100
+	MaxCandidacyEventsPerBatch        = 100
 )
 
 // Timer intervals for granger background jobs
@@ -68,8 +83,146 @@ const (
 	PruneOfficialEmailCodesInterval = 5 * time.Minute
 	MailSenderInterval              = 5 * time.Second
 	ScoreApplicationsInterval       = 1 * time.Minute
+	ApplyOpeningSchedulesInterval   = 1 * time.Minute
+	ExpireOffersInterval            = 1 * time.Minute
+	PollOfferSignaturesInterval     = 1 * time.Minute
+	SyncCalDAVInterval              = 1 * time.Minute
+	RemindTakeHomesInterval         = 1 * time.Minute
+	DispatchCandidacyEventsInterval = 10 * time.Second
+	RefreshRecommendationsInterval  = 5 * time.Minute
+	SuggestCandidatesInterval       = 5 * time.Minute
+	AlertSavedSearchesInterval      = 1 * time.Minute
+
+	// Time after which the status of an unsigned offer is checked again
+	// with the e-signature provider, if no webhook arrives before
+	OfferSignatureRepollDelay = 15 * time.Minute
+
+	// Time after which the free/busy of a CalDAV calendar is synced again
+	CalDAVResyncDelay = 15 * time.Minute
+	// Timeout of each of the requests to the CalDAV servers
+	CalDAVRequestTimeout = 10 * time.Second
+)
+
+const (
+	// Duration for which the applications claimed by a granger replica for
+	// scoring are not claimed by the others. Should be longer than the
+	// scoring of a batch takes.
+	ApplicationScoringLease = 10 * time.Minute
+	// An application that fails to be scored is retried after a delay that
+	// doubles with each attempt, from the base to the max
+	ApplicationScoringBaseDelay = 1 * time.Minute
+	ApplicationScoringMaxDelay  = 6 * time.Hour
+	// The application is dead lettered after these many failed attempts
+	MaxApplicationScoringAttempts = 6
+)
+
+const (
+	// The recommended openings of a hub user are computed again after this
+	RecommendationsRefreshAge = 24 * time.Hour
+	// Hub users whose recommendations are refreshed by a single run
+	MaxRecommendationRefreshesPerBatch = 50
+	// Openings that are scored for a hub user, out of those that share the
+	// country, the city or a tag with the hub user
+	MaxRecommendationCandidates = 500
+	// Openings that are recommended to a hub user
+	MaxRecommendedOpenings = 20
+)
+
+const (
+	// The hub users suggested for an ACTIVE opening are computed again after
+	// this, or as soon as the JD changes
+	CandidateSuggestionsRefreshAge = 24 * time.Hour
+	// Openings whose suggestions are refreshed by a single run
+	MaxCandidateSuggestionRefreshesPerBatch = 20
+	// Discoverable hub users that are scored for an opening, out of those
+	// that share the country or a tag with the opening
+	MaxCandidateSuggestionCandidates = 500
+	// Hub users that are suggested for an opening
+	MaxSuggestedCandidates = 50
+
+	OpeningInvitationIDLenBytes = 16
+	// A hub user is not invited to apply more than these many times in the
+	// window, by all the employers together
+	MaxOpeningInvitationsPerHubUser    = 3
+	OpeningInvitationsPerHubUserWindow = 7 * 24 * time.Hour
+	// An employer does not invite more than these many hub users in the
+	// window, across all its openings
+	MaxOpeningInvitationsPerEmployer    = 100
+	OpeningInvitationsPerEmployerWindow = 24 * time.Hour
+)
+
+const (
+	SavedSearchIDLenBytes               = 16
+	SavedSearchUnsubscribeTokenLenBytes = 32
+	// Saved searches that a hub user may have
+	MaxSavedSearchesPerHubUser = 20
+	// Saved searches that are matched against the newly ACTIVE openings by
+	// a single run
+	MaxSavedSearchEvaluationsPerBatch = 100
+	// Alerts that are sent by a single run
+	MaxSavedSearchAlertsPerBatch = 50
+	// Openings that are listed in an alert, out of those that matched
+	MaxOpeningsPerSavedSearchAlert = 20
+	// Openings that matched a saved search, that are shown to the hub user
+	MaxSavedSearchOpeningsShown = 100
+	// The least time between two digests of a saved search
+	DailySavedSearchAlertGap  = 24 * time.Hour
+	WeeklySavedSearchAlertGap = 7 * 24 * time.Hour
+)
+
+const (
+	BookmarkIDLenBytes = 16
 )
 
 const (
 	MaxCommentDepth = 4
 )
+
+const (
+	// Duration for which a slot held by the candidate is not offered to
+	// anyone else, while the candidate confirms the booking
+	InterviewSlotHoldTTL = 10 * time.Minute
+	// The interview slots offered to the candidates start at every step
+	InterviewSlotStep = 30 * time.Minute
+	// Maximum span of the window of a scheduling request
+	MaxSchedulingWindow = 30 * 24 * time.Hour // 30 days
+	// Maximum number of free slots offered to the candidate at once
+	MaxInterviewSlots = 200
+)
+
+const (
+	// Maximum size of each of the files of a take-home assignment and of
+	// each of its submissions
+	MaxTakeHomeFileSize = 20 * 1024 * 1024 // 20MB
+	// Maximum number of times a take-home assignment can be submitted
+	MaxTakeHomeSubmissions = 10
+	// The candidate is reminded of a take-home assignment that is not
+	// submitted yet, this long before its deadline
+	TakeHomeReminderLeadTime = 24 * time.Hour
+)
+
+const (
+	// Maximum number of openings served on a careers page or job feed
+	MaxCareersOpenings = 500
+	// max-age of the Cache-Control header of the careers page responses
+	CareersCacheMaxAge = 5 * time.Minute
+)
+
+const (
+	// Interval at which the streams of the candidacy updates are kept alive
+	// and are checked for any updates whose notifications were missed
+	CandidacyUpdatesKeepAliveInterval = 25 * time.Second
+	// Maximum number of updates fetched at once for a stream
+	MaxCandidacyUpdatesPerBatch = 100
+	// Delay before the candidacy updates are listened to again, after the
+	// listening connection fails
+	CandidacyUpdatesRelistenDelay = 5 * time.Second
+)
+
+const (
+	// Duration after its creation for which a candidacy comment can be
+	// edited or retracted by its author
+	CandidacyCommentEditWindow = 15 * time.Minute
+	// Maximum size of each of the files attached to a candidacy comment
+	MaxCandidacyCommentAttachmentSize = 10 * 1024 * 1024 // 10MB
+)
@@ -0,0 +1,573 @@
+package dolores
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Opening Templates", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, viewerToken, nonOpeningsToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0038-opening-templates-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@opening-templates.example":        &adminToken,
+			"crud@opening-templates.example":         &crudToken,
+			"viewer@opening-templates.example":       &viewerToken,
+			"non-openings@opening-templates.example": &nonOpeningsToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"opening-templates.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0038-opening-templates-down.pgsql")
+		db.Close()
+	})
+
+	validTemplate := func(name string) employer.CreateOpeningTemplateRequest {
+		return employer.CreateOpeningTemplateRequest{
+			Name:          employer.OpeningTemplateName(name),
+			Description:   strptr("Quarterly backend hiring"),
+			Title:         "Backend Engineer {{location}}",
+			Positions:     2,
+			JD:            "Looking for backend engineers in {{location}}",
+			Recruiter:     "recruiter@opening-templates.example",
+			HiringManager: "hiring-manager@opening-templates.example",
+			HiringTeam: []common.EmailAddress{
+				"crud@opening-templates.example",
+			},
+			CostCenterName:     "Engineering",
+			LocationTitles:     []string{"Bangalore Office"},
+			RemoteCountryCodes: []common.CountryCode{"IND"},
+			OpeningType:        common.FullTimeOpening,
+			YoeMin:             2,
+			YoeMax:             5,
+			MinEducationLevel:  common.BachelorEducation,
+			Salary: &common.Salary{
+				MinAmount: 50000,
+				MaxAmount: 100000,
+				Currency:  "USD",
+			},
+			TagIDs: []common.VTagID{"devops"},
+		}
+	}
+
+	createTemplate := func(
+		token string,
+		req employer.CreateOpeningTemplateRequest,
+	) employer.OpeningTemplateID {
+		resp := testPOSTGetResp(
+			token,
+			req,
+			"/employer/create-opening-template",
+			http.StatusOK,
+		).([]byte)
+		var createResp employer.CreateOpeningTemplateResponse
+		err := json.Unmarshal(resp, &createResp)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(createResp.TemplateID).ShouldNot(BeEmpty())
+		return createResp.TemplateID
+	}
+
+	getTemplate := func(id employer.OpeningTemplateID) employer.OpeningTemplate {
+		resp := testPOSTGetResp(
+			viewerToken,
+			employer.GetOpeningTemplateRequest{ID: id},
+			"/employer/get-opening-template",
+			http.StatusOK,
+		).([]byte)
+		var template employer.OpeningTemplate
+		err := json.Unmarshal(resp, &template)
+		Expect(err).ShouldNot(HaveOccurred())
+		return template
+	}
+
+	getOpening := func(openingID string) employer.Opening {
+		resp := testPOSTGetResp(
+			adminToken,
+			employer.GetOpeningRequest{ID: openingID},
+			"/employer/get-opening",
+			http.StatusOK,
+		).([]byte)
+		var opening employer.Opening
+		err := json.Unmarshal(resp, &opening)
+		Expect(err).ShouldNot(HaveOccurred())
+		return opening
+	}
+
+	Describe("Create Opening Template", func() {
+		It("should create templates with proper validation", func() {
+			type testCase struct {
+				description   string
+				token         string
+				request       employer.CreateOpeningTemplateRequest
+				wantStatus    int
+				wantErrFields []string
+			}
+
+			testCases := []testCase{
+				{
+					description: "with Admin token",
+					token:       adminToken,
+					request:     validTemplate("Backend Admin"),
+					wantStatus:  http.StatusOK,
+				},
+				{
+					description: "with CRUD token",
+					token:       crudToken,
+					request:     validTemplate("Backend CRUD"),
+					wantStatus:  http.StatusOK,
+				},
+				{
+					description: "with duplicate name",
+					token:       crudToken,
+					request:     validTemplate("Backend CRUD"),
+					wantStatus:  http.StatusConflict,
+				},
+				{
+					description: "with Viewer token",
+					token:       viewerToken,
+					request:     validTemplate("Backend Viewer"),
+					wantStatus:  common.ErrEmployerRBAC,
+				},
+				{
+					description: "with non-openings token",
+					token:       nonOpeningsToken,
+					request:     validTemplate("Backend Non Openings"),
+					wantStatus:  common.ErrEmployerRBAC,
+				},
+				{
+					description: "with missing name",
+					token:       adminToken,
+					request:     validTemplate(""),
+					wantStatus:  http.StatusBadRequest,
+					wantErrFields: []string{
+						"name",
+					},
+				},
+				{
+					description: "with invalid YOE range",
+					token:       adminToken,
+					request: func() employer.CreateOpeningTemplateRequest {
+						r := validTemplate("Backend YOE")
+						r.YoeMin = 6
+						r.YoeMax = 5
+						return r
+					}(),
+					wantStatus:    http.StatusBadRequest,
+					wantErrFields: []string{"yoe_min", "yoe_max"},
+				},
+				{
+					description: "with no tags",
+					token:       adminToken,
+					request: func() employer.CreateOpeningTemplateRequest {
+						r := validTemplate("Backend No Tags")
+						r.TagIDs = nil
+						return r
+					}(),
+					wantStatus:    http.StatusBadRequest,
+					wantErrFields: []string{"tags"},
+				},
+				{
+					description: "with non-existent recruiter",
+					token:       adminToken,
+					request: func() employer.CreateOpeningTemplateRequest {
+						r := validTemplate("Backend Bad Recruiter")
+						r.Recruiter = "nobody@opening-templates.example"
+						return r
+					}(),
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "with non-existent hiring team member",
+					token:       adminToken,
+					request: func() employer.CreateOpeningTemplateRequest {
+						r := validTemplate("Backend Bad Team")
+						r.HiringTeam = []common.EmailAddress{
+							"nobody@opening-templates.example",
+						}
+						return r
+					}(),
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "with non-existent cost center",
+					token:       adminToken,
+					request: func() employer.CreateOpeningTemplateRequest {
+						r := validTemplate("Backend Bad CC")
+						r.CostCenterName = "NonExistent"
+						return r
+					}(),
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "with non-existent location",
+					token:       adminToken,
+					request: func() employer.CreateOpeningTemplateRequest {
+						r := validTemplate("Backend Bad Location")
+						r.LocationTitles = []string{"NonExistent"}
+						return r
+					}(),
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "#### %s\n", tc.description)
+				if len(tc.wantErrFields) > 0 {
+					resp := testPOSTGetResp(
+						tc.token,
+						tc.request,
+						"/employer/create-opening-template",
+						tc.wantStatus,
+					).([]byte)
+					var validationErrors common.ValidationErrors
+					err := json.Unmarshal(resp, &validationErrors)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(validationErrors.Errors).Should(
+						ContainElements(tc.wantErrFields),
+					)
+				} else {
+					testPOST(
+						tc.token,
+						tc.request,
+						"/employer/create-opening-template",
+						tc.wantStatus,
+					)
+				}
+			}
+		})
+	})
+
+	Describe("Get, Update, Filter and Delete Opening Templates", func() {
+		It("should manage the lifecycle of a template", func() {
+			templateID := createTemplate(
+				adminToken,
+				validTemplate("Lifecycle Template"),
+			)
+
+			template := getTemplate(templateID)
+			Expect(template.Name).Should(
+				Equal(employer.OpeningTemplateName("Lifecycle Template")),
+			)
+			Expect(template.Recruiter.Email).Should(
+				Equal("recruiter@opening-templates.example"),
+			)
+			Expect(template.HiringTeam).Should(HaveLen(1))
+			Expect(template.LocationTitles).Should(
+				ConsistOf("Bangalore Office"),
+			)
+			Expect(template.Tags).Should(HaveLen(1))
+			Expect(template.Placeholders).Should(ConsistOf("location"))
+
+			updateReq := employer.UpdateOpeningTemplateRequest{
+				ID: templateID,
+				CreateOpeningTemplateRequest: func() employer.CreateOpeningTemplateRequest {
+					r := validTemplate("Lifecycle Template Renamed")
+					r.Title = "{{level}} Backend Engineer"
+					r.LocationTitles = []string{"Chennai Office"}
+					r.HiringTeam = nil
+					return r
+				}(),
+			}
+			testPOST(
+				crudToken,
+				updateReq,
+				"/employer/update-opening-template",
+				http.StatusOK,
+			)
+
+			template = getTemplate(templateID)
+			Expect(template.Name).Should(
+				Equal(employer.OpeningTemplateName("Lifecycle Template Renamed")),
+			)
+			Expect(template.HiringTeam).Should(BeEmpty())
+			Expect(template.LocationTitles).Should(ConsistOf("Chennai Office"))
+			Expect(template.Placeholders).Should(
+				ConsistOf("level", "location"),
+			)
+
+			testPOST(
+				viewerToken,
+				updateReq,
+				"/employer/update-opening-template",
+				common.ErrEmployerRBAC,
+			)
+
+			testPOST(
+				crudToken,
+				employer.UpdateOpeningTemplateRequest{
+					ID:                           "nonexistent",
+					CreateOpeningTemplateRequest: validTemplate("Unknown"),
+				},
+				"/employer/update-opening-template",
+				http.StatusNotFound,
+			)
+
+			resp := testPOSTGetResp(
+				viewerToken,
+				employer.FilterOpeningTemplatesRequest{
+					NamePrefix: "Lifecycle",
+				},
+				"/employer/filter-opening-templates",
+				http.StatusOK,
+			).([]byte)
+			var templates []employer.OpeningTemplate
+			err := json.Unmarshal(resp, &templates)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(templates).Should(HaveLen(1))
+			Expect(templates[0].ID).Should(Equal(templateID))
+
+			testPOST(
+				nonOpeningsToken,
+				employer.FilterOpeningTemplatesRequest{},
+				"/employer/filter-opening-templates",
+				common.ErrEmployerRBAC,
+			)
+
+			testPOST(
+				viewerToken,
+				employer.DeleteOpeningTemplateRequest{ID: templateID},
+				"/employer/delete-opening-template",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				crudToken,
+				employer.DeleteOpeningTemplateRequest{ID: templateID},
+				"/employer/delete-opening-template",
+				http.StatusOK,
+			)
+			testPOST(
+				viewerToken,
+				employer.GetOpeningTemplateRequest{ID: templateID},
+				"/employer/get-opening-template",
+				http.StatusNotFound,
+			)
+			testPOST(
+				crudToken,
+				employer.DeleteOpeningTemplateRequest{ID: templateID},
+				"/employer/delete-opening-template",
+				http.StatusNotFound,
+			)
+		})
+
+		It("should paginate templates by name", func() {
+			for _, name := range []string{"Page A", "Page B", "Page C"} {
+				createTemplate(crudToken, validTemplate(name))
+			}
+
+			resp := testPOSTGetResp(
+				adminToken,
+				employer.FilterOpeningTemplatesRequest{
+					NamePrefix: "Page",
+					Limit:      2,
+				},
+				"/employer/filter-opening-templates",
+				http.StatusOK,
+			).([]byte)
+			var templates []employer.OpeningTemplate
+			err := json.Unmarshal(resp, &templates)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(templates).Should(HaveLen(2))
+			Expect(templates[0].Name).Should(
+				Equal(employer.OpeningTemplateName("Page A")),
+			)
+
+			resp = testPOSTGetResp(
+				adminToken,
+				employer.FilterOpeningTemplatesRequest{
+					NamePrefix:    "Page",
+					PaginationKey: templates[1].Name,
+				},
+				"/employer/filter-opening-templates",
+				http.StatusOK,
+			).([]byte)
+			err = json.Unmarshal(resp, &templates)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(templates).Should(HaveLen(1))
+			Expect(templates[0].Name).Should(
+				Equal(employer.OpeningTemplateName("Page C")),
+			)
+		})
+	})
+
+	Describe("Create Opening From Template", func() {
+		It("should resolve placeholders into a DRAFT opening", func() {
+			templateID := createTemplate(
+				adminToken,
+				validTemplate("From Template"),
+			)
+
+			testPOST(
+				crudToken,
+				employer.CreateOpeningFromTemplateRequest{
+					TemplateID: templateID,
+				},
+				"/employer/create-opening-from-template",
+				http.StatusBadRequest,
+			)
+
+			testPOST(
+				viewerToken,
+				employer.CreateOpeningFromTemplateRequest{
+					TemplateID: templateID,
+					Variables:  map[string]string{"location": "Bangalore"},
+				},
+				"/employer/create-opening-from-template",
+				common.ErrEmployerRBAC,
+			)
+
+			testPOST(
+				crudToken,
+				employer.CreateOpeningFromTemplateRequest{
+					TemplateID: "nonexistent",
+					Variables:  map[string]string{"location": "Bangalore"},
+				},
+				"/employer/create-opening-from-template",
+				http.StatusNotFound,
+			)
+
+			// The resolved title exceeds the 32 chars limit of Openings
+			resp := testPOSTGetResp(
+				crudToken,
+				employer.CreateOpeningFromTemplateRequest{
+					TemplateID: templateID,
+					Variables: map[string]string{
+						"location": "Bangalore, Chennai and Hyderabad",
+					},
+				},
+				"/employer/create-opening-from-template",
+				http.StatusBadRequest,
+			).([]byte)
+			var validationErrors common.ValidationErrors
+			err := json.Unmarshal(resp, &validationErrors)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(validationErrors.Errors).Should(ContainElement("title"))
+
+			resp = testPOSTGetResp(
+				crudToken,
+				employer.CreateOpeningFromTemplateRequest{
+					TemplateID: templateID,
+					Variables:  map[string]string{"location": "Bangalore"},
+				},
+				"/employer/create-opening-from-template",
+				http.StatusOK,
+			).([]byte)
+			var createResp employer.CreateOpeningResponse
+			err = json.Unmarshal(resp, &createResp)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			opening := getOpening(createResp.OpeningID)
+			Expect(opening.Title).Should(Equal("Backend Engineer Bangalore"))
+			Expect(opening.JD).Should(
+				Equal("Looking for backend engineers in Bangalore"),
+			)
+			Expect(opening.State).Should(Equal(common.DraftOpening))
+			Expect(opening.CostCenterName).Should(
+				Equal(employer.CostCenterName("Engineering")),
+			)
+			Expect(opening.HiringTeam).Should(HaveLen(1))
+			Expect(opening.Tags).Should(HaveLen(1))
+		})
+	})
+
+	Describe("Clone Opening", func() {
+		It("should copy an existing opening into a new DRAFT", func() {
+			templateID := createTemplate(adminToken, validTemplate("To Clone"))
+			resp := testPOSTGetResp(
+				adminToken,
+				employer.CreateOpeningFromTemplateRequest{
+					TemplateID: templateID,
+					Variables:  map[string]string{"location": "Chennai"},
+				},
+				"/employer/create-opening-from-template",
+				http.StatusOK,
+			).([]byte)
+			var createResp employer.CreateOpeningResponse
+			err := json.Unmarshal(resp, &createResp)
+			Expect(err).ShouldNot(HaveOccurred())
+			source := getOpening(createResp.OpeningID)
+
+			testPOST(
+				adminToken,
+				employer.ChangeOpeningStateRequest{
+					OpeningID: source.ID,
+					FromState: common.DraftOpening,
+					ToState:   common.ActiveOpening,
+				},
+				"/employer/change-opening-state",
+				http.StatusOK,
+			)
+
+			testPOST(
+				viewerToken,
+				employer.CloneOpeningRequest{OpeningID: source.ID},
+				"/employer/clone-opening",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				crudToken,
+				employer.CloneOpeningRequest{OpeningID: "nonexistent"},
+				"/employer/clone-opening",
+				http.StatusNotFound,
+			)
+			testPOST(
+				crudToken,
+				employer.CloneOpeningRequest{
+					OpeningID: source.ID,
+					Title:     strptr("ab"),
+				},
+				"/employer/clone-opening",
+				http.StatusBadRequest,
+			)
+
+			resp = testPOSTGetResp(
+				crudToken,
+				employer.CloneOpeningRequest{
+					OpeningID: source.ID,
+					Title:     strptr("Backend Engineer Q3"),
+				},
+				"/employer/clone-opening",
+				http.StatusOK,
+			).([]byte)
+			err = json.Unmarshal(resp, &createResp)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(createResp.OpeningID).ShouldNot(Equal(source.ID))
+
+			clone := getOpening(createResp.OpeningID)
+			Expect(clone.Title).Should(Equal("Backend Engineer Q3"))
+			Expect(clone.State).Should(Equal(common.DraftOpening))
+			Expect(clone.JD).Should(Equal(source.JD))
+			Expect(clone.Positions).Should(Equal(source.Positions))
+			Expect(clone.Recruiter.Email).Should(Equal(source.Recruiter.Email))
+			Expect(clone.HiringTeam).Should(HaveLen(len(source.HiringTeam)))
+			Expect(clone.LocationTitles).Should(
+				ConsistOf(source.LocationTitles),
+			)
+			Expect(clone.Salary).Should(Equal(source.Salary))
+			Expect(clone.Tags).Should(ConsistOf(source.Tags))
+		})
+	})
+})
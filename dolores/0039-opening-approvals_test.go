@@ -0,0 +1,436 @@
+package dolores
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Opening Approvals", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, viewerToken string
+	var ccOwnerToken, hrAdminToken, ccCrudToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0039-opening-approvals-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@opening-approvals.example":    &adminToken,
+			"crud@opening-approvals.example":     &crudToken,
+			"viewer@opening-approvals.example":   &viewerToken,
+			"cc-owner@opening-approvals.example": &ccOwnerToken,
+			"hr-admin@opening-approvals.example": &hrAdminToken,
+			"cc-crud@opening-approvals.example":  &ccCrudToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"opening-approvals.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0039-opening-approvals-down.pgsql")
+		db.Close()
+	})
+
+	createOpening := func(costCenter employer.CostCenterName) string {
+		resp := testPOSTGetResp(
+			crudToken,
+			employer.CreateOpeningRequest{
+				Title:             "Approved Opening",
+				Positions:         1,
+				JD:                "An opening that needs approvals",
+				Recruiter:         "crud@opening-approvals.example",
+				HiringManager:     "admin@opening-approvals.example",
+				CostCenterName:    costCenter,
+				OpeningType:       common.FullTimeOpening,
+				YoeMin:            0,
+				YoeMax:            5,
+				MinEducationLevel: common.BachelorEducation,
+				RemoteCountryCodes: []common.CountryCode{
+					"IND",
+				},
+				TagIDs: []common.VTagID{"devops"},
+			},
+			"/employer/create-opening",
+			http.StatusOK,
+		).([]byte)
+		var createResp employer.CreateOpeningResponse
+		err := json.Unmarshal(resp, &createResp)
+		Expect(err).ShouldNot(HaveOccurred())
+		return createResp.OpeningID
+	}
+
+	publish := func(openingID string, wantStatus int) {
+		testPOST(
+			crudToken,
+			employer.ChangeOpeningStateRequest{
+				OpeningID: openingID,
+				FromState: common.DraftOpening,
+				ToState:   common.ActiveOpening,
+			},
+			"/employer/change-opening-state",
+			wantStatus,
+		)
+	}
+
+	getApprovals := func(openingID string) []employer.OpeningApprovalStep {
+		resp := testPOSTGetResp(
+			viewerToken,
+			employer.GetOpeningApprovalsRequest{OpeningID: openingID},
+			"/employer/get-opening-approvals",
+			http.StatusOK,
+		).([]byte)
+		var steps []employer.OpeningApprovalStep
+		err := json.Unmarshal(resp, &steps)
+		Expect(err).ShouldNot(HaveOccurred())
+		return steps
+	}
+
+	getMyApprovals := func(token string) []employer.MyOpeningApproval {
+		resp := testPOSTGetResp(
+			token,
+			employer.GetMyOpeningApprovalsRequest{},
+			"/employer/get-my-opening-approvals",
+			http.StatusOK,
+		).([]byte)
+		var approvals []employer.MyOpeningApproval
+		err := json.Unmarshal(resp, &approvals)
+		Expect(err).ShouldNot(HaveOccurred())
+		return approvals
+	}
+
+	getApprovalState := func(openingID string) *employer.OpeningApprovalState {
+		resp := testPOSTGetResp(
+			adminToken,
+			employer.GetOpeningRequest{ID: openingID},
+			"/employer/get-opening",
+			http.StatusOK,
+		).([]byte)
+		var opening employer.Opening
+		err := json.Unmarshal(resp, &opening)
+		Expect(err).ShouldNot(HaveOccurred())
+		return opening.ApprovalState
+	}
+
+	Describe("Cost Center Approval Chains", func() {
+		type setChainTestCase struct {
+			description string
+			token       string
+			request     employer.SetCostCenterApprovalChainRequest
+			wantStatus  int
+		}
+
+		It("should validate the approval chains", func() {
+			testCases := []setChainTestCase{
+				{
+					description: "without any roles",
+					token:       crudToken,
+					request: employer.SetCostCenterApprovalChainRequest{
+						CostCenterName: "Engineering",
+						Approvers: []common.EmailAddress{
+							"cc-owner@opening-approvals.example",
+						},
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "with an unknown cost center",
+					token:       adminToken,
+					request: employer.SetCostCenterApprovalChainRequest{
+						CostCenterName: "Unknown",
+						Approvers: []common.EmailAddress{
+							"cc-owner@opening-approvals.example",
+						},
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with an unknown approver",
+					token:       adminToken,
+					request: employer.SetCostCenterApprovalChainRequest{
+						CostCenterName: "Engineering",
+						Approvers: []common.EmailAddress{
+							"unknown@opening-approvals.example",
+						},
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "with a disabled approver",
+					token:       adminToken,
+					request: employer.SetCostCenterApprovalChainRequest{
+						CostCenterName: "Engineering",
+						Approvers: []common.EmailAddress{
+							"disabled@opening-approvals.example",
+						},
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "with duplicate approvers",
+					token:       adminToken,
+					request: employer.SetCostCenterApprovalChainRequest{
+						CostCenterName: "Engineering",
+						Approvers: []common.EmailAddress{
+							"cc-owner@opening-approvals.example",
+							"cc-owner@opening-approvals.example",
+						},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an approver repeated later in the chain",
+					token:       adminToken,
+					request: employer.SetCostCenterApprovalChainRequest{
+						CostCenterName: "Engineering",
+						Approvers: []common.EmailAddress{
+							"cc-owner@opening-approvals.example",
+							"hr-admin@opening-approvals.example",
+							"cc-owner@opening-approvals.example",
+						},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with a valid chain by a cost centers crud user",
+					token:       ccCrudToken,
+					request: employer.SetCostCenterApprovalChainRequest{
+						CostCenterName: "Engineering",
+						Approvers: []common.EmailAddress{
+							"cc-owner@opening-approvals.example",
+							"hr-admin@opening-approvals.example",
+						},
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/set-cost-center-approval-chain",
+					tc.wantStatus,
+				)
+			}
+		})
+
+		It("should return the approvers in order", func() {
+			resp := testPOSTGetResp(
+				adminToken,
+				employer.GetCostCenterApprovalChainRequest{
+					CostCenterName: "Engineering",
+				},
+				"/employer/get-cost-center-approval-chain",
+				http.StatusOK,
+			).([]byte)
+			var chain employer.CostCenterApprovalChain
+			err := json.Unmarshal(resp, &chain)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(chain.Approvers).Should(HaveLen(2))
+			Expect(chain.Approvers[0].Email).Should(
+				Equal("cc-owner@opening-approvals.example"),
+			)
+			Expect(chain.Approvers[1].Email).Should(
+				Equal("hr-admin@opening-approvals.example"),
+			)
+
+			testPOST(
+				adminToken,
+				employer.GetCostCenterApprovalChainRequest{
+					CostCenterName: "Unknown",
+				},
+				"/employer/get-cost-center-approval-chain",
+				http.StatusNotFound,
+			)
+		})
+	})
+
+	Describe("Opening Approval Workflow", func() {
+		It("should gate publishing on the approval chain", func() {
+			openingID := createOpening("Engineering")
+			Expect(getApprovalState(openingID)).Should(BeNil())
+
+			// Cannot publish without submitting for approval
+			publish(openingID, http.StatusPreconditionRequired)
+
+			submitReq := employer.SubmitOpeningForApprovalRequest{
+				OpeningID: openingID,
+			}
+			testPOST(
+				viewerToken,
+				submitReq,
+				"/employer/submit-opening-for-approval",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				crudToken,
+				submitReq,
+				"/employer/submit-opening-for-approval",
+				http.StatusOK,
+			)
+			// Already pending approval
+			testPOST(
+				crudToken,
+				submitReq,
+				"/employer/submit-opening-for-approval",
+				http.StatusConflict,
+			)
+			Expect(*getApprovalState(openingID)).Should(
+				Equal(employer.OpeningPendingApproval),
+			)
+
+			// The second approver cannot act before the first
+			testPOST(
+				hrAdminToken,
+				employer.ApproveOpeningRequest{OpeningID: openingID},
+				"/employer/approve-opening",
+				http.StatusNotFound,
+			)
+			Expect(getMyApprovals(hrAdminToken)).Should(BeEmpty())
+
+			ccOwnerApprovals := getMyApprovals(ccOwnerToken)
+			Expect(ccOwnerApprovals).Should(HaveLen(1))
+			Expect(ccOwnerApprovals[0].OpeningID).Should(Equal(openingID))
+			Expect(ccOwnerApprovals[0].StepNumber).Should(Equal(1))
+			Expect(ccOwnerApprovals[0].RequestedBy.Email).Should(
+				Equal("crud@opening-approvals.example"),
+			)
+
+			testPOST(
+				ccOwnerToken,
+				employer.ApproveOpeningRequest{
+					OpeningID: openingID,
+					Comment:   strptr("Budget is available"),
+				},
+				"/employer/approve-opening",
+				http.StatusOK,
+			)
+			Expect(getMyApprovals(ccOwnerToken)).Should(BeEmpty())
+			Expect(getMyApprovals(hrAdminToken)).Should(HaveLen(1))
+
+			// Still pending with the second approver
+			publish(openingID, http.StatusPreconditionRequired)
+
+			// A rejection needs a comment
+			testPOST(
+				hrAdminToken,
+				employer.RejectOpeningRequest{OpeningID: openingID},
+				"/employer/reject-opening",
+				http.StatusBadRequest,
+			)
+			testPOST(
+				hrAdminToken,
+				employer.RejectOpeningRequest{
+					OpeningID: openingID,
+					Comment:   "Headcount frozen for this quarter",
+				},
+				"/employer/reject-opening",
+				http.StatusOK,
+			)
+
+			steps := getApprovals(openingID)
+			Expect(steps).Should(HaveLen(2))
+			Expect(steps[0].State).Should(Equal(employer.OpeningApproved))
+			Expect(steps[0].DecidedAt).ShouldNot(BeNil())
+			Expect(steps[1].State).Should(Equal(employer.OpeningRejected))
+			Expect(*steps[1].Comment).Should(
+				Equal("Headcount frozen for this quarter"),
+			)
+			Expect(*getApprovalState(openingID)).Should(
+				Equal(employer.OpeningRejected),
+			)
+			publish(openingID, http.StatusPreconditionRequired)
+
+			// Resubmitting after a rejection restarts the chain
+			testPOST(
+				crudToken,
+				submitReq,
+				"/employer/submit-opening-for-approval",
+				http.StatusOK,
+			)
+			steps = getApprovals(openingID)
+			Expect(steps).Should(HaveLen(2))
+			Expect(steps[0].State).Should(
+				Equal(employer.OpeningPendingApproval),
+			)
+			Expect(steps[1].State).Should(
+				Equal(employer.OpeningPendingApproval),
+			)
+
+			testPOST(
+				ccOwnerToken,
+				employer.ApproveOpeningRequest{OpeningID: openingID},
+				"/employer/approve-opening",
+				http.StatusOK,
+			)
+			testPOST(
+				hrAdminToken,
+				employer.ApproveOpeningRequest{OpeningID: openingID},
+				"/employer/approve-opening",
+				http.StatusOK,
+			)
+			Expect(*getApprovalState(openingID)).Should(
+				Equal(employer.OpeningApproved),
+			)
+
+			// Nothing more to approve
+			testPOST(
+				hrAdminToken,
+				employer.ApproveOpeningRequest{OpeningID: openingID},
+				"/employer/approve-opening",
+				http.StatusNotFound,
+			)
+
+			publish(openingID, http.StatusOK)
+		})
+
+		It("should not gate openings of cost centers without a chain", func() {
+			openingID := createOpening("Sales")
+			testPOST(
+				crudToken,
+				employer.SubmitOpeningForApprovalRequest{OpeningID: openingID},
+				"/employer/submit-opening-for-approval",
+				http.StatusUnprocessableEntity,
+			)
+			publish(openingID, http.StatusOK)
+		})
+
+		It("should return 404 for unknown openings", func() {
+			testPOST(
+				crudToken,
+				employer.SubmitOpeningForApprovalRequest{
+					OpeningID: "2024-Jan-01-999",
+				},
+				"/employer/submit-opening-for-approval",
+				http.StatusNotFound,
+			)
+			testPOST(
+				viewerToken,
+				employer.GetOpeningApprovalsRequest{
+					OpeningID: "2024-Jan-01-999",
+				},
+				"/employer/get-opening-approvals",
+				http.StatusNotFound,
+			)
+		})
+	})
+})
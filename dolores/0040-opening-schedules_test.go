@@ -0,0 +1,348 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Opening Schedules", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, viewerToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0040-opening-schedules-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@opening-schedules.example":  &adminToken,
+			"crud@opening-schedules.example":   &crudToken,
+			"viewer@opening-schedules.example": &viewerToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"opening-schedules.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0040-opening-schedules-down.pgsql")
+		db.Close()
+	})
+
+	getOpening := func(openingID string) employer.Opening {
+		resp := testPOSTGetResp(
+			viewerToken,
+			employer.GetOpeningRequest{ID: openingID},
+			"/employer/get-opening",
+			http.StatusOK,
+		).([]byte)
+		var opening employer.Opening
+		err := json.Unmarshal(resp, &opening)
+		Expect(err).ShouldNot(HaveOccurred())
+		return opening
+	}
+
+	Describe("Set Opening Schedule", func() {
+		type setScheduleTestCase struct {
+			description string
+			token       string
+			request     employer.SetOpeningScheduleRequest
+			wantStatus  int
+		}
+
+		It("should validate the schedule", func() {
+			future := time.Now().Add(24 * time.Hour)
+			past := time.Now().Add(-24 * time.Hour)
+			beyond := future.Add(24 * time.Hour)
+
+			testCases := []setScheduleTestCase{
+				{
+					description: "without any roles",
+					token:       viewerToken,
+					request: employer.SetOpeningScheduleRequest{
+						OpeningID: "2024-Jan-01-5",
+						PublishAt: &future,
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "with close_at in the past",
+					token:       crudToken,
+					request: employer.SetOpeningScheduleRequest{
+						OpeningID: "2024-Jan-01-5",
+						CloseAt:   &past,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with close_at before publish_at",
+					token:       crudToken,
+					request: employer.SetOpeningScheduleRequest{
+						OpeningID: "2024-Jan-01-5",
+						PublishAt: &beyond,
+						CloseAt:   &future,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with zero max_applications",
+					token:       crudToken,
+					request: employer.SetOpeningScheduleRequest{
+						OpeningID:       "2024-Jan-01-5",
+						MaxApplications: intptr(0),
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an unknown opening",
+					token:       crudToken,
+					request: employer.SetOpeningScheduleRequest{
+						OpeningID: "2024-Jan-01-999",
+						PublishAt: &future,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with a closed opening",
+					token:       crudToken,
+					request: employer.SetOpeningScheduleRequest{
+						OpeningID: "2024-Jan-01-6",
+						CloseAt:   &future,
+					},
+					wantStatus: http.StatusConflict,
+				},
+				{
+					description: "with a valid schedule",
+					token:       crudToken,
+					request: employer.SetOpeningScheduleRequest{
+						OpeningID:       "2024-Jan-01-5",
+						PublishAt:       &future,
+						CloseAt:         &beyond,
+						MaxApplications: intptr(50),
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/set-opening-schedule",
+					tc.wantStatus,
+				)
+			}
+
+			opening := getOpening("2024-Jan-01-5")
+			Expect(opening.PublishAt).ShouldNot(BeNil())
+			Expect(opening.PublishAt.Unix()).Should(Equal(future.Unix()))
+			Expect(opening.CloseAt).ShouldNot(BeNil())
+			Expect(opening.CloseAt.Unix()).Should(Equal(beyond.Unix()))
+			Expect(opening.MaxApplications).ShouldNot(BeNil())
+			Expect(*opening.MaxApplications).Should(Equal(50))
+			Expect(opening.State).Should(Equal(common.DraftOpening))
+
+			// Absent fields clear the schedule
+			testPOST(
+				adminToken,
+				employer.SetOpeningScheduleRequest{OpeningID: "2024-Jan-01-5"},
+				"/employer/set-opening-schedule",
+				http.StatusOK,
+			)
+			opening = getOpening("2024-Jan-01-5")
+			Expect(opening.PublishAt).Should(BeNil())
+			Expect(opening.CloseAt).Should(BeNil())
+			Expect(opening.MaxApplications).Should(BeNil())
+		})
+	})
+
+	Describe("Application Expiry Period", func() {
+		It("should change the application expiry period", func() {
+			testPOST(
+				crudToken,
+				employer.ChangeApplicationExpiryPeriodRequest{
+					ApplicationExpiryDays: 45,
+				},
+				"/employer/change-application-expiry-period",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				adminToken,
+				employer.ChangeApplicationExpiryPeriodRequest{
+					ApplicationExpiryDays: 400,
+				},
+				"/employer/change-application-expiry-period",
+				http.StatusBadRequest,
+			)
+
+			// The seeded period of 30 days is retained for the granger test
+			testPOST(
+				adminToken,
+				employer.ChangeApplicationExpiryPeriodRequest{
+					ApplicationExpiryDays: 30,
+				},
+				"/employer/change-application-expiry-period",
+				http.StatusOK,
+			)
+
+			req, err := http.NewRequest(
+				http.MethodGet,
+				serverURL+"/employer/get-application-expiry-period",
+				nil,
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).ShouldNot(HaveOccurred())
+			days, err := strconv.Atoi(string(body))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(days).Should(Equal(30))
+		})
+	})
+
+	Describe("Granger", func() {
+		It("should apply the due schedules", func() {
+			// granger runs the job every minute
+			Eventually(func(g Gomega) {
+				g.Expect(getOpening("2024-Jan-01-1").State).
+					Should(Equal(common.ActiveOpening))
+				g.Expect(getOpening("2024-Jan-01-2").State).
+					Should(Equal(common.ClosedOpening))
+				g.Expect(getOpening("2024-Jan-01-3").State).
+					Should(Equal(common.SuspendedOpening))
+			}).WithTimeout(3 * time.Minute).
+				WithPolling(5 * time.Second).
+				Should(Succeed())
+
+			Expect(getOpening("2024-Jan-01-4").State).
+				Should(Equal(common.ActiveOpening))
+			Expect(getOpening("2024-Jan-01-5").State).
+				Should(Equal(common.DraftOpening))
+			// The withdrawn application does not count towards
+			// max_applications
+			Expect(getOpening("2024-Jan-01-7").State).
+				Should(Equal(common.ActiveOpening))
+
+			getState := func(applicationID string) string {
+				var state string
+				err := db.QueryRow(
+					context.Background(),
+					"SELECT application_state FROM applications WHERE id = $1",
+					applicationID,
+				).Scan(&state)
+				Expect(err).ShouldNot(HaveOccurred())
+				return state
+			}
+			Expect(getState("APP-0040-1")).Should(Equal("APPLIED"))
+			Expect(getState("APP-0040-2")).Should(Equal("EXPIRED"))
+			Expect(getState("APP-0040-3")).Should(Equal("APPLIED"))
+
+			var digests int
+			err := db.QueryRow(
+				context.Background(),
+				`SELECT COUNT(*) FROM emails WHERE $1 = ANY(email_to)`,
+				"admin@opening-schedules.example",
+			).Scan(&digests)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(digests).Should(BeNumerically(">=", 2))
+
+			var expiryEmails int
+			err = db.QueryRow(
+				context.Background(),
+				`SELECT COUNT(*) FROM emails WHERE $1 = ANY(email_to)`,
+				"hub1@opening-schedules-hub.example",
+			).Scan(&expiryEmails)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(expiryEmails).Should(Equal(1))
+		})
+
+		It("should not suspend a reactivated opening again", func() {
+			getReachedAt := func() *time.Time {
+				var reachedAt *time.Time
+				err := db.QueryRow(
+					context.Background(),
+					`
+SELECT max_applications_reached_at
+FROM openings
+WHERE employer_id = $1
+    AND id = $2
+`,
+					"12345678-0040-0040-0040-000000000201",
+					"2024-Jan-01-3",
+				).Scan(&reachedAt)
+				Expect(err).ShouldNot(HaveOccurred())
+				return reachedAt
+			}
+			Expect(getReachedAt()).ShouldNot(BeNil())
+
+			testPOST(
+				adminToken,
+				employer.ChangeOpeningStateRequest{
+					OpeningID: "2024-Jan-01-3",
+					FromState: common.SuspendedOpening,
+					ToState:   common.ActiveOpening,
+				},
+				"/employer/change-opening-state",
+				http.StatusOK,
+			)
+
+			// The opening still has max_applications applications, but
+			// should outlive a couple of runs of granger
+			Consistently(func() common.OpeningState {
+				return getOpening("2024-Jan-01-3").State
+			}).WithTimeout(150 * time.Second).
+				WithPolling(10 * time.Second).
+				Should(Equal(common.ActiveOpening))
+
+			// Changing max_applications lets the opening be suspended again
+			setMaxApplications := func(maxApplications int) {
+				testPOST(
+					crudToken,
+					employer.SetOpeningScheduleRequest{
+						OpeningID:       "2024-Jan-01-3",
+						MaxApplications: intptr(maxApplications),
+					},
+					"/employer/set-opening-schedule",
+					http.StatusOK,
+				)
+			}
+			setMaxApplications(2)
+			Expect(getReachedAt()).Should(BeNil())
+
+			setMaxApplications(1)
+			Eventually(func(g Gomega) {
+				g.Expect(getOpening("2024-Jan-01-3").State).
+					Should(Equal(common.SuspendedOpening))
+			}).WithTimeout(3 * time.Minute).
+				WithPolling(5 * time.Second).
+				Should(Succeed())
+			Expect(getReachedAt()).ShouldNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,470 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Headcount", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, appsCRUDToken, openingsViewerToken string
+	var ccViewerToken, locationsViewerToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0041-headcount-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@headcount.example":            &adminToken,
+			"apps-crud@headcount.example":        &appsCRUDToken,
+			"openings-viewer@headcount.example":  &openingsViewerToken,
+			"cc-viewer@headcount.example":        &ccViewerToken,
+			"locations-viewer@headcount.example": &locationsViewerToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"headcount.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0041-headcount-down.pgsql")
+		db.Close()
+	})
+
+	getOpening := func(openingID string) employer.Opening {
+		resp := testPOSTGetResp(
+			openingsViewerToken,
+			employer.GetOpeningRequest{ID: openingID},
+			"/employer/get-opening",
+			http.StatusOK,
+		).([]byte)
+		var opening employer.Opening
+		err := json.Unmarshal(resp, &opening)
+		Expect(err).ShouldNot(HaveOccurred())
+		return opening
+	}
+
+	getCandidacyState := func(candidacyID string) string {
+		var state string
+		err := db.QueryRow(
+			context.Background(),
+			"SELECT candidacy_state FROM candidacies WHERE id = $1",
+			candidacyID,
+		).Scan(&state)
+		Expect(err).ShouldNot(HaveOccurred())
+		return state
+	}
+
+	Describe("Record Offer Acceptance", func() {
+		type recordTestCase struct {
+			description string
+			token       string
+			request     employer.RecordOfferAcceptanceRequest
+			wantStatus  int
+		}
+
+		It("should fill the positions of the opening", func() {
+			backfill := employer.BackfillReason("Replacing a resignation")
+			testCases := []recordTestCase{
+				{
+					description: "without any roles",
+					token:       openingsViewerToken,
+					request: employer.RecordOfferAcceptanceRequest{
+						CandidacyID: "CAND-0041-1",
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "without candidacy id",
+					token:       appsCRUDToken,
+					request:     employer.RecordOfferAcceptanceRequest{},
+					wantStatus:  http.StatusBadRequest,
+				},
+				{
+					description: "with an invalid start date",
+					token:       appsCRUDToken,
+					request: employer.RecordOfferAcceptanceRequest{
+						CandidacyID: "CAND-0041-1",
+						StartDate:   strptr("01-04-2024"),
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an unknown candidacy",
+					token:       appsCRUDToken,
+					request: employer.RecordOfferAcceptanceRequest{
+						CandidacyID: "CAND-0041-999",
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with a candidacy that is not offered",
+					token:       appsCRUDToken,
+					request: employer.RecordOfferAcceptanceRequest{
+						CandidacyID: "CAND-0041-4",
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with a new headcount",
+					token:       appsCRUDToken,
+					request: employer.RecordOfferAcceptanceRequest{
+						CandidacyID: "CAND-0041-1",
+						StartDate:   strptr("2024-04-01"),
+					},
+					wantStatus: http.StatusOK,
+				},
+				{
+					description: "with an accepted candidacy",
+					token:       appsCRUDToken,
+					request: employer.RecordOfferAcceptanceRequest{
+						CandidacyID: "CAND-0041-1",
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with a backfill",
+					token:       adminToken,
+					request: employer.RecordOfferAcceptanceRequest{
+						CandidacyID:    "CAND-0041-2",
+						BackfillReason: &backfill,
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/record-offer-acceptance",
+					tc.wantStatus,
+				)
+			}
+
+			Expect(getCandidacyState("CAND-0041-1")).
+				Should(Equal(string(common.OfferAcceptedCandidacyState)))
+			Expect(getCandidacyState("CAND-0041-2")).
+				Should(Equal(string(common.OfferAcceptedCandidacyState)))
+
+			// Both the positions are filled and so the opening is auto closed
+			opening := getOpening("2024-Mar-01-1")
+			Expect(opening.FilledPositions).Should(Equal(2))
+			Expect(opening.State).Should(Equal(common.ClosedOpening))
+		})
+
+		It("should not overfill an opening", func() {
+			// Reopening a filled opening does not make room for more hires
+			_, err := db.Exec(
+				context.Background(),
+				"UPDATE openings SET state = $1 WHERE id = $2",
+				common.ActiveOpening,
+				"2024-Mar-01-1",
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			testPOST(
+				appsCRUDToken,
+				employer.RecordOfferAcceptanceRequest{
+					CandidacyID: "CAND-0041-3",
+				},
+				"/employer/record-offer-acceptance",
+				http.StatusUnprocessableEntity,
+			)
+			Expect(getCandidacyState("CAND-0041-3")).
+				Should(Equal(string(common.OfferedCandidacyState)))
+
+			resp := testPOSTGetResp(
+				openingsViewerToken,
+				employer.FilterOpeningsRequest{
+					State: []common.OpeningState{common.ActiveOpening},
+					Limit: 40,
+				},
+				"/employer/filter-openings",
+				http.StatusOK,
+			).([]byte)
+			var openings []employer.OpeningInfo
+			err = json.Unmarshal(resp, &openings)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			filled := make(map[string]int)
+			for _, opening := range openings {
+				filled[opening.ID] = opening.FilledPositions
+			}
+			Expect(filled).Should(HaveKeyWithValue("2024-Mar-01-1", 2))
+			Expect(filled).Should(HaveKeyWithValue("2024-Mar-01-3", 0))
+		})
+	})
+
+	Describe("Opening Hires", func() {
+		It("should list and update the hires of an opening", func() {
+			testPOST(
+				locationsViewerToken,
+				employer.GetOpeningHiresRequest{OpeningID: "2024-Mar-01-1"},
+				"/employer/get-opening-hires",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				openingsViewerToken,
+				employer.GetOpeningHiresRequest{OpeningID: "2024-Mar-01-999"},
+				"/employer/get-opening-hires",
+				http.StatusNotFound,
+			)
+
+			getHires := func() []employer.OpeningHire {
+				resp := testPOSTGetResp(
+					openingsViewerToken,
+					employer.GetOpeningHiresRequest{
+						OpeningID: "2024-Mar-01-1",
+					},
+					"/employer/get-opening-hires",
+					http.StatusOK,
+				).([]byte)
+				var hires []employer.OpeningHire
+				err := json.Unmarshal(resp, &hires)
+				Expect(err).ShouldNot(HaveOccurred())
+				return hires
+			}
+
+			hires := getHires()
+			Expect(hires).Should(HaveLen(2))
+			Expect(hires[0].CandidacyID).Should(Equal("CAND-0041-1"))
+			Expect(hires[0].CandidateHandle).
+				Should(Equal("headcount_hub_user_1"))
+			Expect(hires[0].StartDate).ShouldNot(BeNil())
+			Expect(*hires[0].StartDate).Should(Equal("2024-04-01"))
+			Expect(hires[0].BackfillReason).Should(BeNil())
+			Expect(hires[1].CandidacyID).Should(Equal("CAND-0041-2"))
+			Expect(hires[1].StartDate).Should(BeNil())
+			Expect(hires[1].BackfillReason).ShouldNot(BeNil())
+
+			testPOST(
+				openingsViewerToken,
+				employer.UpdateOpeningHireRequest{CandidacyID: "CAND-0041-2"},
+				"/employer/update-opening-hire",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				appsCRUDToken,
+				employer.UpdateOpeningHireRequest{CandidacyID: "CAND-0041-3"},
+				"/employer/update-opening-hire",
+				http.StatusNotFound,
+			)
+			testPOST(
+				appsCRUDToken,
+				employer.UpdateOpeningHireRequest{
+					CandidacyID: "CAND-0041-2",
+					StartDate:   strptr("2024-05-15"),
+				},
+				"/employer/update-opening-hire",
+				http.StatusOK,
+			)
+
+			hires = getHires()
+			Expect(hires).Should(HaveLen(2))
+			Expect(hires[1].StartDate).ShouldNot(BeNil())
+			Expect(*hires[1].StartDate).Should(Equal("2024-05-15"))
+			// Absent backfill reason marks the hire as a new headcount
+			Expect(hires[1].BackfillReason).Should(BeNil())
+		})
+	})
+
+	Describe("Auto Close Filled Openings", func() {
+		It("should honour the employer setting", func() {
+			testPOST(
+				appsCRUDToken,
+				employer.AutoCloseFilledOpenings{
+					AutoCloseFilledOpenings: false,
+				},
+				"/employer/change-auto-close-filled-openings",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				adminToken,
+				employer.AutoCloseFilledOpenings{
+					AutoCloseFilledOpenings: false,
+				},
+				"/employer/change-auto-close-filled-openings",
+				http.StatusOK,
+			)
+
+			req, err := http.NewRequest(
+				http.MethodGet,
+				serverURL+"/employer/get-auto-close-filled-openings",
+				nil,
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			var autoClose employer.AutoCloseFilledOpenings
+			err = json.NewDecoder(resp.Body).Decode(&autoClose)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(autoClose.AutoCloseFilledOpenings).Should(BeFalse())
+
+			_, err = db.Exec(
+				context.Background(),
+				"UPDATE candidacies SET candidacy_state = $1 WHERE id = $2",
+				common.OfferedCandidacyState,
+				"CAND-0041-4",
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			testPOST(
+				appsCRUDToken,
+				employer.RecordOfferAcceptanceRequest{
+					CandidacyID: "CAND-0041-4",
+				},
+				"/employer/record-offer-acceptance",
+				http.StatusOK,
+			)
+
+			opening := getOpening("2024-Mar-01-2")
+			Expect(opening.FilledPositions).Should(Equal(1))
+			Expect(opening.State).Should(Equal(common.ActiveOpening))
+		})
+	})
+
+	Describe("Headcount Report", func() {
+		It("should report the positions per cost center and location", func() {
+			testPOST(
+				locationsViewerToken,
+				employer.GetHeadcountReportRequest{},
+				"/employer/get-headcount-report",
+				common.ErrEmployerRBAC,
+			)
+
+			getReport := func(
+				token string,
+				request employer.GetHeadcountReportRequest,
+			) []employer.HeadcountReportRow {
+				resp := testPOSTGetResp(
+					token,
+					request,
+					"/employer/get-headcount-report",
+					http.StatusOK,
+				).([]byte)
+				var report []employer.HeadcountReportRow
+				err := json.Unmarshal(resp, &report)
+				Expect(err).ShouldNot(HaveOccurred())
+				return report
+			}
+
+			// An opening with multiple locations has its positions split
+			// across them, so that it is not counted more than once
+			//
+			// Engineering / Bangalore Office: 2024-Mar-01-1, 2 filled and
+			//     1 still in offer, and 2 of the 3 of 2024-Mar-01-4 open
+			// Engineering / Chennai Office: 1 of the 3 of 2024-Mar-01-4 open
+			// Engineering / no location: 2024-Mar-01-3, 3 open
+			// Sales / Bangalore Office: 2024-Mar-01-2, 1 filled
+			// Sales / Chennai Office: none of 2024-Mar-01-2
+			report := getReport(
+				ccViewerToken,
+				employer.GetHeadcountReportRequest{},
+			)
+			Expect(report).Should(HaveLen(5))
+
+			type counts struct {
+				costCenter string
+				location   *string
+				open       int
+				filled     int
+				inOffer    int
+			}
+			bangalore, chennai := "Bangalore Office", "Chennai Office"
+			want := []counts{
+				{"Engineering", &bangalore, 2, 2, 1},
+				{"Engineering", &chennai, 1, 0, 0},
+				{"Engineering", nil, 3, 0, 0},
+				{"Sales", &bangalore, 0, 1, 0},
+				{"Sales", &chennai, 0, 0, 0},
+			}
+			for i, row := range report {
+				Expect(row.CostCenterName).Should(Equal(
+					employer.CostCenterName(want[i].costCenter),
+				))
+				if want[i].location == nil {
+					Expect(row.LocationTitle).Should(BeNil())
+				} else {
+					Expect(row.LocationTitle).ShouldNot(BeNil())
+					Expect(*row.LocationTitle).
+						Should(Equal(*want[i].location))
+				}
+				Expect(row.OpenPositions).Should(Equal(want[i].open))
+				Expect(row.FilledPositions).Should(Equal(want[i].filled))
+				Expect(row.InOfferPositions).Should(Equal(want[i].inOffer))
+			}
+
+			sales := employer.CostCenterName("Sales")
+			report = getReport(
+				openingsViewerToken,
+				employer.GetHeadcountReportRequest{CostCenterName: &sales},
+			)
+			Expect(report).Should(HaveLen(2))
+			Expect(*report[0].LocationTitle).Should(Equal("Bangalore Office"))
+			Expect(*report[1].LocationTitle).Should(Equal("Chennai Office"))
+		})
+
+		It("should count the hires as the filled positions", func() {
+			// The hires are the record of the filled positions, whatever
+			// becomes of their candidacies later
+			_, err := db.Exec(
+				context.Background(),
+				"UPDATE candidacies SET candidacy_state = $1 WHERE id = $2",
+				common.CandidateUnsuitableCandidacyState,
+				"CAND-0041-1",
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(getOpening("2024-Mar-01-1").FilledPositions).Should(Equal(2))
+
+			engineering := employer.CostCenterName("Engineering")
+			resp := testPOSTGetResp(
+				ccViewerToken,
+				employer.GetHeadcountReportRequest{
+					CostCenterName: &engineering,
+				},
+				"/employer/get-headcount-report",
+				http.StatusOK,
+			).([]byte)
+			var report []employer.HeadcountReportRow
+			err = json.Unmarshal(resp, &report)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report).ShouldNot(BeEmpty())
+			Expect(*report[0].LocationTitle).Should(Equal("Bangalore Office"))
+			Expect(report[0].FilledPositions).Should(Equal(2))
+
+			testPOST(
+				appsCRUDToken,
+				employer.RecordOfferAcceptanceRequest{
+					CandidacyID: "CAND-0041-3",
+				},
+				"/employer/record-offer-acceptance",
+				http.StatusUnprocessableEntity,
+			)
+		})
+	})
+})
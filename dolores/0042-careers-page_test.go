@@ -0,0 +1,300 @@
+package dolores
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/careers"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Careers Page", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, viewerToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0042-careers-page-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@careers-page.example":  &adminToken,
+			"crud@careers-page.example":   &crudToken,
+			"viewer@careers-page.example": &viewerToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"careers-page.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0042-careers-page-down.pgsql")
+		db.Close()
+	})
+
+	// No Authorization header is sent to the careers endpoints
+	publicGET := func(endpoint string, wantStatus int) (*http.Response, []byte) {
+		resp, err := http.Get(serverURL + endpoint)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(wantStatus))
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		return resp, body
+	}
+
+	Describe("Set Opening Public Listing", func() {
+		type setPublicListingTestCase struct {
+			description string
+			token       string
+			request     employer.SetOpeningPublicListingRequest
+			wantStatus  int
+		}
+
+		It("should list and unlist the openings", func() {
+			testCases := []setPublicListingTestCase{
+				{
+					description: "without any roles",
+					token:       viewerToken,
+					request: employer.SetOpeningPublicListingRequest{
+						OpeningID:     "2024-Apr-01-2",
+						PublicListing: true,
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "without opening id",
+					token:       crudToken,
+					request: employer.SetOpeningPublicListingRequest{
+						PublicListing: true,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an unknown opening",
+					token:       crudToken,
+					request: employer.SetOpeningPublicListingRequest{
+						OpeningID:     "2024-Apr-01-999",
+						PublicListing: true,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with openings crud role",
+					token:       crudToken,
+					request: employer.SetOpeningPublicListingRequest{
+						OpeningID:     "2024-Apr-01-2",
+						PublicListing: true,
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/set-opening-public-listing",
+					tc.wantStatus,
+				)
+			}
+
+			resp := testPOSTGetResp(
+				viewerToken,
+				employer.GetOpeningRequest{ID: "2024-Apr-01-2"},
+				"/employer/get-opening",
+				http.StatusOK,
+			).([]byte)
+			var opening employer.Opening
+			err := json.Unmarshal(resp, &opening)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opening.PublicListing).Should(BeTrue())
+
+			_, body := publicGET(
+				"/careers/careers-page.example/openings",
+				http.StatusOK,
+			)
+			var careersPage careers.CareersPage
+			err = json.Unmarshal(body, &careersPage)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(careersPage.Openings).Should(HaveLen(3))
+
+			testPOST(
+				adminToken,
+				employer.SetOpeningPublicListingRequest{
+					OpeningID:     "2024-Apr-01-2",
+					PublicListing: false,
+				},
+				"/employer/set-opening-public-listing",
+				http.StatusOK,
+			)
+		})
+	})
+
+	Describe("Get Careers Openings", func() {
+		It("should list the publicly listed active openings", func() {
+			publicGET(
+				"/careers/careers-page-unknown.example/openings",
+				http.StatusNotFound,
+			)
+			publicGET(
+				"/careers/careers-page-unverified.example/openings",
+				http.StatusNotFound,
+			)
+			publicGET("/careers/x/openings", http.StatusBadRequest)
+
+			resp, body := publicGET(
+				"/careers/careers-page.example/openings",
+				http.StatusOK,
+			)
+			Expect(resp.Header.Get("Cache-Control")).
+				Should(ContainSubstring("public"))
+			Expect(resp.Header.Get("Access-Control-Allow-Origin")).
+				Should(Equal("*"))
+
+			var careersPage careers.CareersPage
+			err := json.Unmarshal(body, &careersPage)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(careersPage.CompanyName).Should(Equal("Careers Page Inc"))
+			Expect(careersPage.CompanyDomain).
+				Should(Equal("careers-page.example"))
+
+			// Newest first
+			Expect(careersPage.Openings).Should(HaveLen(2))
+			Expect(careersPage.Openings[0].OpeningID).
+				Should(Equal("2024-Apr-01-1"))
+			Expect(careersPage.Openings[0].LocationTitles).
+				Should(Equal([]string{"Bangalore Office"}))
+			Expect(careersPage.Openings[0].Salary).ShouldNot(BeNil())
+			Expect(careersPage.Openings[0].Salary.Currency).
+				Should(Equal(common.Currency("INR")))
+			Expect(careersPage.Openings[0].URL).
+				Should(HaveSuffix("/org/careers-page.example/opening/2024-Apr-01-1"))
+
+			Expect(careersPage.Openings[1].OpeningID).
+				Should(Equal("2024-Apr-01-4"))
+			Expect(careersPage.Openings[1].Salary).Should(BeNil())
+
+			// Nothing private to the employer is served
+			Expect(string(body)).ShouldNot(ContainSubstring("Internal notes"))
+		})
+	})
+
+	Describe("Get Careers Opening", func() {
+		It("should serve the opening as a JobPosting", func() {
+			publicGET(
+				"/careers/careers-page.example/openings/2024-Apr-01-2",
+				http.StatusNotFound,
+			)
+			publicGET(
+				"/careers/careers-page.example/openings/2024-Apr-01-3",
+				http.StatusNotFound,
+			)
+			publicGET(
+				"/careers/careers-page.example/openings/2024-Apr-01-999",
+				http.StatusNotFound,
+			)
+
+			resp, body := publicGET(
+				"/careers/careers-page.example/openings/2024-Apr-01-1",
+				http.StatusOK,
+			)
+			Expect(resp.Header.Get("Content-Type")).
+				Should(Equal("application/ld+json"))
+			Expect(resp.Header.Get("Cache-Control")).
+				Should(ContainSubstring("public"))
+
+			var posting careers.JobPosting
+			err := json.Unmarshal(body, &posting)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(posting.Context).Should(Equal("https://schema.org"))
+			Expect(posting.Type).Should(Equal("JobPosting"))
+			Expect(posting.Title).Should(Equal("Backend Engineer"))
+			Expect(posting.EmploymentType).Should(Equal("FULL_TIME"))
+			Expect(posting.HiringOrganization.Name).
+				Should(Equal("Careers Page Inc"))
+			Expect(posting.HiringOrganization.SameAs).
+				Should(Equal("https://careers-page.example"))
+			Expect(posting.Identifier.Value).Should(Equal("2024-Apr-01-1"))
+			Expect(posting.JobLocation).Should(HaveLen(1))
+			Expect(posting.JobLocation[0].Address.PostalCode).
+				Should(Equal("560001"))
+			Expect(posting.JobLocationType).Should(Equal("TELECOMMUTE"))
+			Expect(posting.ApplicantLocationRequirements).Should(HaveLen(1))
+			Expect(posting.ApplicantLocationRequirements[0].Name).
+				Should(Equal("IND"))
+			Expect(posting.BaseSalary).ShouldNot(BeNil())
+			Expect(posting.BaseSalary.Currency).Should(Equal("INR"))
+			Expect(posting.BaseSalary.Value.MinValue).Should(Equal(1000000.0))
+			Expect(posting.BaseSalary.Value.MaxValue).Should(Equal(2000000.0))
+
+			_, body = publicGET(
+				"/careers/careers-page.example/openings/2024-Apr-01-4",
+				http.StatusOK,
+			)
+			posting = careers.JobPosting{}
+			err = json.Unmarshal(body, &posting)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(posting.EmploymentType).Should(Equal("PART_TIME"))
+			Expect(posting.JobLocation).Should(BeEmpty())
+			Expect(posting.JobLocationType).Should(Equal("TELECOMMUTE"))
+			Expect(posting.ApplicantLocationRequirements).Should(BeEmpty())
+			Expect(posting.BaseSalary).Should(BeNil())
+		})
+	})
+
+	Describe("Get Careers Feed", func() {
+		It("should serve the openings as an RSS feed", func() {
+			publicGET(
+				"/careers/careers-page-unknown.example/feed.xml",
+				http.StatusNotFound,
+			)
+
+			resp, body := publicGET(
+				"/careers/careers-page.example/feed.xml",
+				http.StatusOK,
+			)
+			Expect(resp.Header.Get("Content-Type")).
+				Should(ContainSubstring("application/rss+xml"))
+
+			var feed struct {
+				Version string `xml:"version,attr"`
+				Channel struct {
+					Title string `xml:"title"`
+					Items []struct {
+						Title string `xml:"title"`
+						Link  string `xml:"link"`
+						GUID  string `xml:"guid"`
+					} `xml:"item"`
+				} `xml:"channel"`
+			}
+			err := xml.Unmarshal(body, &feed)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(feed.Version).Should(Equal("2.0"))
+			Expect(feed.Channel.Title).Should(ContainSubstring("Careers Page Inc"))
+			Expect(feed.Channel.Items).Should(HaveLen(2))
+			Expect(feed.Channel.Items[0].Title).Should(Equal("Backend Engineer"))
+			Expect(feed.Channel.Items[0].GUID).
+				Should(Equal(feed.Channel.Items[0].Link))
+			Expect(feed.Channel.Items[1].Title).Should(Equal("Technical Writer"))
+		})
+	})
+})
@@ -0,0 +1,677 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// A minimal valid PDF, used as an offer attachment
+const offerAttachmentPDF = "" +
+	"JVBERi0xLjQKMSAwIG9iago8PCAvVHlwZSAvQ2F0YWxvZyAvUGFnZXMgMiAwIFIg" +
+	"Pj4KZW5kb2JqCjIgMCBvYmoKPDwgL1R5cGUgL1BhZ2VzIC9LaWRzIFs0IDAgUl0g" +
+	"L0NvdW50IDEgPj4KZW5kb2JqCjMgMCBvYmoKPDwgL1R5cGUgL0ZvbnQgL1N1YnR5" +
+	"cGUgL1R5cGUxIC9CYXNlRm9udCAvSGVsdmV0aWNhID4+CmVuZG9iago0IDAgb2Jq" +
+	"Cjw8IC9UeXBlIC9QYWdlIC9QYXJlbnQgMiAwIFIgL01lZGlhQm94IFswIDAgNTk1" +
+	"IDg0Ml0gL1Jlc291cmNlcyA8PCAvRm9udCA8PCAvRjEgMyAwIFIgPj4gPj4gL0Nv" +
+	"bnRlbnRzIDUgMCBSID4+CmVuZG9iago1IDAgb2JqCjw8IC9MZW5ndGggNDggPj4K" +
+	"c3RyZWFtCkJUIC9GMSAxMSBUZiAxNCBUTCA1NiA3OTAgVGQKKEJlbmVmaXRzKSBU" +
+	"aiBUKgpFVAplbmRzdHJlYW0KZW5kb2JqCnhyZWYKMCA2CjAwMDAwMDAwMDAgNjU1" +
+	"MzUgZiAKMDAwMDAwMDAwOSAwMDAwMCBuIAowMDAwMDAwMDU4IDAwMDAwIG4gCjAw" +
+	"MDAwMDAxMTUgMDAwMDAgbiAKMDAwMDAwMDE4NSAwMDAwMCBuIAowMDAwMDAwMzEx" +
+	"IDAwMDAwIG4gCnRyYWlsZXIKPDwgL1NpemUgNiAvUm9vdCAxIDAgUiA+PgpzdGFy" +
+	"dHhyZWYKNDA5CiUlRU9GCg=="
+
+var _ = Describe("Candidacy Offers", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, viewerToken, openingsViewerToken string
+	var hub1Token, hub2Token, hub3Token, hub4Token string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0043-candidacy-offers-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@candidacy-offers.example":           &adminToken,
+			"crud@candidacy-offers.example":            &crudToken,
+			"viewer@candidacy-offers.example":          &viewerToken,
+			"openings-viewer@candidacy-offers.example": &openingsViewerToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"candidacy-offers.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+
+		hubTokens := map[string]*string{
+			"hub1@candidacy-offers-hub.example": &hub1Token,
+			"hub2@candidacy-offers-hub.example": &hub2Token,
+			"hub3@candidacy-offers-hub.example": &hub3Token,
+			"hub4@candidacy-offers-hub.example": &hub4Token,
+		}
+		for email, token := range hubTokens {
+			wg.Add(1)
+			hubSigninAsync(email, "NewPassword123$", token, &wg)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0043-candidacy-offers-down.pgsql")
+		db.Close()
+	})
+
+	getOffers := func(token, endpoint, candidacyID string) []common.Offer {
+		resp := testPOSTGetResp(
+			token,
+			common.GetOffersRequest{CandidacyID: candidacyID},
+			endpoint,
+			http.StatusOK,
+		).([]byte)
+		var offers []common.Offer
+		err := json.Unmarshal(resp, &offers)
+		Expect(err).ShouldNot(HaveOccurred())
+		return offers
+	}
+
+	getCandidacyState := func(candidacyID string) string {
+		var state string
+		err := db.QueryRow(
+			context.Background(),
+			"SELECT candidacy_state FROM candidacies WHERE id = $1",
+			candidacyID,
+		).Scan(&state)
+		Expect(err).ShouldNot(HaveOccurred())
+		return state
+	}
+
+	compensation := common.OfferCompensation{
+		Amount:   2000000,
+		Currency: "INR",
+		Notes:    strptr("Includes a joining bonus of 100000 INR"),
+	}
+
+	Describe("Offer To Candidate", func() {
+		type offerTestCase struct {
+			description string
+			token       string
+			request     employer.OfferToCandidateRequest
+			wantStatus  int
+		}
+
+		It("should make the offers", func() {
+			expiresAt := time.Now().Add(7 * 24 * time.Hour)
+			past := time.Now().Add(-time.Hour)
+
+			testCases := []offerTestCase{
+				{
+					description: "without any roles",
+					token:       viewerToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID:  "CAND-0043-1",
+						Compensation: compensation,
+						StartDate:    "2025-01-01",
+						ExpiresAt:    expiresAt,
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "without compensation",
+					token:       crudToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID: "CAND-0043-1",
+						StartDate:   "2025-01-01",
+						ExpiresAt:   expiresAt,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an invalid currency",
+					token:       crudToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID: "CAND-0043-1",
+						Compensation: common.OfferCompensation{
+							Amount:   2000000,
+							Currency: "XYZW",
+						},
+						StartDate: "2025-01-01",
+						ExpiresAt: expiresAt,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an invalid start date",
+					token:       crudToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID:  "CAND-0043-1",
+						Compensation: compensation,
+						StartDate:    "01-01-2025",
+						ExpiresAt:    expiresAt,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with expires_at in the past",
+					token:       crudToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID:  "CAND-0043-1",
+						Compensation: compensation,
+						StartDate:    "2025-01-01",
+						ExpiresAt:    past,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an attachment that is not a PDF",
+					token:       crudToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID:  "CAND-0043-1",
+						Compensation: compensation,
+						StartDate:    "2025-01-01",
+						ExpiresAt:    expiresAt,
+						Attachments: []common.OfferAttachment{
+							{Filename: "benefits.pdf", Document: "aGVsbG8="},
+						},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an unknown candidacy",
+					token:       crudToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID:  "CAND-0043-999",
+						Compensation: compensation,
+						StartDate:    "2025-01-01",
+						ExpiresAt:    expiresAt,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with a candidacy that is already offered",
+					token:       crudToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID:  "CAND-0043-5",
+						Compensation: compensation,
+						StartDate:    "2025-01-01",
+						ExpiresAt:    expiresAt,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with an attachment",
+					token:       crudToken,
+					request: employer.OfferToCandidateRequest{
+						CandidacyID:  "CAND-0043-1",
+						Compensation: compensation,
+						StartDate:    "2025-01-01",
+						ExpiresAt:    expiresAt,
+						Attachments: []common.OfferAttachment{
+							{
+								Filename: "benefits.pdf",
+								Document: offerAttachmentPDF,
+							},
+						},
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/offer-to-candidate",
+					tc.wantStatus,
+				)
+			}
+
+			for _, candidacyID := range []string{
+				"CAND-0043-2",
+				"CAND-0043-3",
+				"CAND-0043-4",
+			} {
+				testPOST(
+					adminToken,
+					employer.OfferToCandidateRequest{
+						CandidacyID:  candidacyID,
+						Compensation: compensation,
+						StartDate:    "2025-02-01",
+						ExpiresAt:    expiresAt,
+					},
+					"/employer/offer-to-candidate",
+					http.StatusOK,
+				)
+				Expect(getCandidacyState(candidacyID)).Should(Equal("OFFERED"))
+			}
+
+			offers := getOffers(
+				viewerToken,
+				"/employer/get-offers",
+				"CAND-0043-1",
+			)
+			Expect(offers).Should(HaveLen(1))
+			Expect(offers[0].Version).Should(Equal(1))
+			Expect(offers[0].OfferState).Should(Equal(common.PendingOffer))
+			Expect(offers[0].StartDate).Should(Equal("2025-01-01"))
+			Expect(offers[0].Compensation.Amount).Should(Equal(2000000.0))
+			Expect(offers[0].Compensation.Currency).
+				Should(Equal(common.Currency("INR")))
+			Expect(offers[0].Attachments).Should(HaveLen(1))
+			Expect(offers[0].Attachments[0].Filename).
+				Should(Equal("benefits.pdf"))
+		})
+	})
+
+	Describe("Get Offers", func() {
+		It("should scope the offers to the employer and the candidate", func() {
+			testPOST(
+				openingsViewerToken,
+				common.GetOffersRequest{CandidacyID: "CAND-0043-1"},
+				"/employer/get-offers",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				viewerToken,
+				common.GetOffersRequest{CandidacyID: "CAND-0043-999"},
+				"/employer/get-offers",
+				http.StatusNotFound,
+			)
+
+			// Candidates can see only their own offers
+			testPOST(
+				hub2Token,
+				common.GetOffersRequest{CandidacyID: "CAND-0043-1"},
+				"/hub/get-offers",
+				http.StatusNotFound,
+			)
+			offers := getOffers(hub1Token, "/hub/get-offers", "CAND-0043-1")
+			Expect(offers).Should(HaveLen(1))
+			Expect(offers[0].OfferState).Should(Equal(common.PendingOffer))
+		})
+	})
+
+	Describe("Get Offer Document", func() {
+		It("should serve the offer letter and the attachments", func() {
+			type documentTestCase struct {
+				description string
+				token       string
+				endpoint    string
+				request     common.GetOfferDocumentRequest
+				wantStatus  int
+			}
+
+			testCases := []documentTestCase{
+				{
+					description: "offer letter to the employer",
+					token:       viewerToken,
+					endpoint:    "/employer/get-offer-document",
+					request: common.GetOfferDocumentRequest{
+						CandidacyID: "CAND-0043-1",
+						Version:     1,
+					},
+					wantStatus: http.StatusOK,
+				},
+				{
+					description: "attachment to the employer",
+					token:       viewerToken,
+					endpoint:    "/employer/get-offer-document",
+					request: common.GetOfferDocumentRequest{
+						CandidacyID:      "CAND-0043-1",
+						Version:          1,
+						AttachmentNumber: intptr(1),
+					},
+					wantStatus: http.StatusOK,
+				},
+				{
+					description: "unknown attachment",
+					token:       viewerToken,
+					endpoint:    "/employer/get-offer-document",
+					request: common.GetOfferDocumentRequest{
+						CandidacyID:      "CAND-0043-1",
+						Version:          1,
+						AttachmentNumber: intptr(2),
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "unknown version",
+					token:       viewerToken,
+					endpoint:    "/employer/get-offer-document",
+					request: common.GetOfferDocumentRequest{
+						CandidacyID: "CAND-0043-1",
+						Version:     2,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "offer letter to the candidate",
+					token:       hub1Token,
+					endpoint:    "/hub/get-offer-document",
+					request: common.GetOfferDocumentRequest{
+						CandidacyID: "CAND-0043-1",
+						Version:     1,
+					},
+					wantStatus: http.StatusOK,
+				},
+				{
+					description: "offer letter to another candidate",
+					token:       hub2Token,
+					endpoint:    "/hub/get-offer-document",
+					request: common.GetOfferDocumentRequest{
+						CandidacyID: "CAND-0043-1",
+						Version:     1,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				resp := testPOSTGetResp(
+					tc.token,
+					tc.request,
+					tc.endpoint,
+					tc.wantStatus,
+				).([]byte)
+				if tc.wantStatus == http.StatusOK {
+					Expect(string(resp)).Should(HavePrefix("%PDF-"))
+				}
+			}
+		})
+	})
+
+	Describe("Revise Offer", func() {
+		It("should supersede the pending offer", func() {
+			expiresAt := time.Now().Add(14 * 24 * time.Hour)
+			revised := common.OfferCompensation{
+				Amount:   2200000,
+				Currency: "INR",
+			}
+
+			testPOST(
+				viewerToken,
+				employer.ReviseOfferRequest{
+					CandidacyID:  "CAND-0043-1",
+					Compensation: revised,
+					StartDate:    "2025-01-15",
+					ExpiresAt:    expiresAt,
+				},
+				"/employer/revise-offer",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				crudToken,
+				employer.ReviseOfferRequest{
+					CandidacyID:  "CAND-0043-999",
+					Compensation: revised,
+					StartDate:    "2025-01-15",
+					ExpiresAt:    expiresAt,
+				},
+				"/employer/revise-offer",
+				http.StatusNotFound,
+			)
+			testPOST(
+				crudToken,
+				employer.ReviseOfferRequest{
+					CandidacyID:  "CAND-0043-1",
+					Compensation: revised,
+					StartDate:    "2025-01-15",
+					ExpiresAt:    expiresAt,
+				},
+				"/employer/revise-offer",
+				http.StatusOK,
+			)
+
+			offers := getOffers(
+				crudToken,
+				"/employer/get-offers",
+				"CAND-0043-1",
+			)
+			Expect(offers).Should(HaveLen(2))
+			Expect(offers[0].Version).Should(Equal(2))
+			Expect(offers[0].OfferState).Should(Equal(common.PendingOffer))
+			Expect(offers[0].Compensation.Amount).Should(Equal(2200000.0))
+			Expect(offers[0].StartDate).Should(Equal("2025-01-15"))
+			Expect(offers[0].Attachments).Should(BeEmpty())
+			Expect(offers[1].Version).Should(Equal(1))
+			Expect(offers[1].OfferState).Should(Equal(common.SupersededOffer))
+			Expect(getCandidacyState("CAND-0043-1")).Should(Equal("OFFERED"))
+		})
+	})
+
+	Describe("Rescind Offer", func() {
+		It("should rescind the pending offer", func() {
+			testPOST(
+				crudToken,
+				employer.RescindOfferRequest{CandidacyID: "CAND-0043-4"},
+				"/employer/rescind-offer",
+				http.StatusBadRequest,
+			)
+			testPOST(
+				viewerToken,
+				employer.RescindOfferRequest{
+					CandidacyID: "CAND-0043-4",
+					Reason:      "Budget freeze",
+				},
+				"/employer/rescind-offer",
+				common.ErrEmployerRBAC,
+			)
+			testPOST(
+				crudToken,
+				employer.RescindOfferRequest{
+					CandidacyID: "CAND-0043-4",
+					Reason:      "Budget freeze",
+				},
+				"/employer/rescind-offer",
+				http.StatusOK,
+			)
+			testPOST(
+				crudToken,
+				employer.RescindOfferRequest{
+					CandidacyID: "CAND-0043-4",
+					Reason:      "Budget freeze",
+				},
+				"/employer/rescind-offer",
+				http.StatusNotFound,
+			)
+
+			Expect(getCandidacyState("CAND-0043-4")).
+				Should(Equal("INTERVIEWING"))
+			offers := getOffers(hub4Token, "/hub/get-offers", "CAND-0043-4")
+			Expect(offers).Should(HaveLen(1))
+			Expect(offers[0].OfferState).Should(Equal(common.RescindedOffer))
+			Expect(offers[0].ResponseReason).ShouldNot(BeNil())
+			Expect(*offers[0].ResponseReason).Should(Equal("Budget freeze"))
+
+			// A candidate can not respond to a rescinded offer
+			testPOST(
+				hub4Token,
+				hub.RespondToOfferRequest{
+					CandidacyID: "CAND-0043-4",
+					Version:     1,
+					Response:    hub.AcceptOffer,
+				},
+				"/hub/respond-to-offer",
+				http.StatusNotFound,
+			)
+		})
+	})
+
+	Describe("Respond To Offer", func() {
+		type respondTestCase struct {
+			description string
+			token       string
+			request     hub.RespondToOfferRequest
+			wantStatus  int
+		}
+
+		It("should accept and decline the offers", func() {
+			testCases := []respondTestCase{
+				{
+					description: "with an invalid response",
+					token:       hub1Token,
+					request: hub.RespondToOfferRequest{
+						CandidacyID: "CAND-0043-1",
+						Version:     2,
+						Response:    "MAYBE",
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with the offer of another candidate",
+					token:       hub2Token,
+					request: hub.RespondToOfferRequest{
+						CandidacyID: "CAND-0043-1",
+						Version:     2,
+						Response:    hub.AcceptOffer,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with a superseded version",
+					token:       hub1Token,
+					request: hub.RespondToOfferRequest{
+						CandidacyID: "CAND-0043-1",
+						Version:     1,
+						Response:    hub.AcceptOffer,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with an expired offer",
+					token:       hub4Token,
+					request: hub.RespondToOfferRequest{
+						CandidacyID: "CAND-0043-5",
+						Version:     1,
+						Response:    hub.AcceptOffer,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "accepting the pending offer",
+					token:       hub1Token,
+					request: hub.RespondToOfferRequest{
+						CandidacyID: "CAND-0043-1",
+						Version:     2,
+						Response:    hub.AcceptOffer,
+					},
+					wantStatus: http.StatusOK,
+				},
+				{
+					description: "accepting when all positions are filled",
+					token:       hub2Token,
+					request: hub.RespondToOfferRequest{
+						CandidacyID: "CAND-0043-2",
+						Version:     1,
+						Response:    hub.AcceptOffer,
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "declining with a reason",
+					token:       hub3Token,
+					request: hub.RespondToOfferRequest{
+						CandidacyID: "CAND-0043-3",
+						Version:     1,
+						Response:    hub.DeclineOffer,
+						Reason:      strptr("Accepted another offer"),
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/hub/respond-to-offer",
+					tc.wantStatus,
+				)
+			}
+
+			Expect(getCandidacyState("CAND-0043-1")).
+				Should(Equal("OFFER_ACCEPTED"))
+			offers := getOffers(
+				adminToken,
+				"/employer/get-offers",
+				"CAND-0043-1",
+			)
+			Expect(offers[0].OfferState).Should(Equal(common.AcceptedOffer))
+			Expect(offers[0].RespondedAt).ShouldNot(BeNil())
+
+			// The start date of the hire is taken from the accepted offer
+			var startDate string
+			err := db.QueryRow(
+				context.Background(),
+				`
+SELECT start_date::TEXT
+FROM opening_hires
+WHERE candidacy_id = $1
+`,
+				"CAND-0043-1",
+			).Scan(&startDate)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(startDate).Should(Equal("2025-01-15"))
+
+			Expect(getCandidacyState("CAND-0043-2")).Should(Equal("OFFERED"))
+
+			Expect(getCandidacyState("CAND-0043-3")).
+				Should(Equal("OFFER_DECLINED"))
+			offers = getOffers(hub3Token, "/hub/get-offers", "CAND-0043-3")
+			Expect(offers[0].OfferState).Should(Equal(common.DeclinedOffer))
+			Expect(*offers[0].ResponseReason).
+				Should(Equal("Accepted another offer"))
+
+			var recruiterEmails int
+			err = db.QueryRow(
+				context.Background(),
+				`
+SELECT COUNT(*)
+FROM emails
+WHERE $1 = ANY(email_to)
+    AND email_subject LIKE '%the offer for%'
+`,
+				"crud@candidacy-offers.example",
+			).Scan(&recruiterEmails)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(recruiterEmails).Should(Equal(2))
+		})
+	})
+
+	Describe("Granger", func() {
+		It("should expire the unanswered offers", func() {
+			// granger runs the job every minute
+			Eventually(func(g Gomega) {
+				g.Expect(getCandidacyState("CAND-0043-5")).
+					Should(Equal("CANDIDATE_NOT_RESPONDING"))
+			}).WithTimeout(3 * time.Minute).
+				WithPolling(5 * time.Second).
+				Should(Succeed())
+
+			offers := getOffers(hub4Token, "/hub/get-offers", "CAND-0043-5")
+			Expect(offers).Should(HaveLen(1))
+			Expect(offers[0].OfferState).Should(Equal(common.ExpiredOffer))
+
+			// The pending offers that are not yet due are left alone
+			Expect(getCandidacyState("CAND-0043-2")).Should(Equal("OFFERED"))
+		})
+	})
+})
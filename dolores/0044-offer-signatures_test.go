@@ -0,0 +1,380 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Offer Signatures", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, viewerToken string
+	var signerToken, declinerToken, revisedToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0044-offer-signatures-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@offer-signatures.example":  &adminToken,
+			"crud@offer-signatures.example":   &crudToken,
+			"viewer@offer-signatures.example": &viewerToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"offer-signatures.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+
+		hubTokens := map[string]*string{
+			"signer@offer-signatures-hub.example":         &signerToken,
+			"decline-signer@offer-signatures-hub.example": &declinerToken,
+			"revised@offer-signatures-hub.example":        &revisedToken,
+		}
+		for email, token := range hubTokens {
+			wg.Add(1)
+			hubSigninAsync(email, "NewPassword123$", token, &wg)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0044-offer-signatures-down.pgsql")
+		db.Close()
+	})
+
+	getOffers := func(token, endpoint, candidacyID string) []common.Offer {
+		resp := testPOSTGetResp(
+			token,
+			common.GetOffersRequest{CandidacyID: candidacyID},
+			endpoint,
+			http.StatusOK,
+		).([]byte)
+		var offers []common.Offer
+		err := json.Unmarshal(resp, &offers)
+		Expect(err).ShouldNot(HaveOccurred())
+		return offers
+	}
+
+	getCandidacyState := func(candidacyID string) string {
+		var state string
+		err := db.QueryRow(
+			context.Background(),
+			"SELECT candidacy_state FROM candidacies WHERE id = $1",
+			candidacyID,
+		).Scan(&state)
+		Expect(err).ShouldNot(HaveOccurred())
+		return state
+	}
+
+	getEnvelopeID := func(candidacyID string, version int) string {
+		var envelopeID string
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT envelope_id
+FROM candidacy_offer_signatures
+WHERE candidacy_id = $1
+    AND version = $2
+`,
+			candidacyID,
+			version,
+		).Scan(&envelopeID)
+		Expect(err).ShouldNot(HaveOccurred())
+		return envelopeID
+	}
+
+	compensation := common.OfferCompensation{
+		Amount:   3000000,
+		Currency: "INR",
+	}
+
+	Describe("Send Offer For Signature", func() {
+		type sendTestCase struct {
+			description string
+			token       string
+			request     employer.SendOfferForSignatureRequest
+			wantStatus  int
+		}
+
+		It("should send the pending offers for signature", func() {
+			expiresAt := time.Now().Add(7 * 24 * time.Hour)
+			for _, candidacyID := range []string{
+				"CAND-0044-1",
+				"CAND-0044-2",
+				"CAND-0044-3",
+			} {
+				testPOST(
+					crudToken,
+					employer.OfferToCandidateRequest{
+						CandidacyID:  candidacyID,
+						Compensation: compensation,
+						StartDate:    "2025-03-01",
+						ExpiresAt:    expiresAt,
+					},
+					"/employer/offer-to-candidate",
+					http.StatusOK,
+				)
+			}
+
+			testCases := []sendTestCase{
+				{
+					description: "without any roles",
+					token:       viewerToken,
+					request: employer.SendOfferForSignatureRequest{
+						CandidacyID: "CAND-0044-1",
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "without a candidacy_id",
+					token:       crudToken,
+					request:     employer.SendOfferForSignatureRequest{},
+					wantStatus:  http.StatusBadRequest,
+				},
+				{
+					description: "with an unknown candidacy",
+					token:       crudToken,
+					request: employer.SendOfferForSignatureRequest{
+						CandidacyID: "CAND-0044-999",
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "to a candidate who signs",
+					token:       crudToken,
+					request: employer.SendOfferForSignatureRequest{
+						CandidacyID: "CAND-0044-1",
+					},
+					wantStatus: http.StatusOK,
+				},
+				{
+					description: "an offer that is already sent",
+					token:       adminToken,
+					request: employer.SendOfferForSignatureRequest{
+						CandidacyID: "CAND-0044-1",
+					},
+					wantStatus: http.StatusConflict,
+				},
+				{
+					description: "to a candidate who declines",
+					token:       adminToken,
+					request: employer.SendOfferForSignatureRequest{
+						CandidacyID: "CAND-0044-2",
+					},
+					wantStatus: http.StatusOK,
+				},
+				{
+					description: "an offer that will be revised",
+					token:       crudToken,
+					request: employer.SendOfferForSignatureRequest{
+						CandidacyID: "CAND-0044-3",
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/send-offer-for-signature",
+					tc.wantStatus,
+				)
+			}
+
+			offers := getOffers(
+				viewerToken,
+				"/employer/get-offers",
+				"CAND-0044-1",
+			)
+			Expect(offers).Should(HaveLen(1))
+			Expect(offers[0].SignatureState).ShouldNot(BeNil())
+			Expect(*offers[0].SignatureState).
+				Should(Equal(common.SentForSignature))
+			Expect(offers[0].SignedAt).Should(BeNil())
+		})
+
+		It("should void the signature when the offer is revised", func() {
+			testPOST(
+				crudToken,
+				employer.ReviseOfferRequest{
+					CandidacyID:  "CAND-0044-3",
+					Compensation: compensation,
+					StartDate:    "2025-04-01",
+					ExpiresAt:    time.Now().Add(7 * 24 * time.Hour),
+				},
+				"/employer/revise-offer",
+				http.StatusOK,
+			)
+
+			offers := getOffers(revisedToken, "/hub/get-offers", "CAND-0044-3")
+			Expect(offers).Should(HaveLen(2))
+			Expect(offers[0].Version).Should(Equal(2))
+			Expect(offers[0].SignatureState).Should(BeNil())
+			Expect(offers[1].OfferState).Should(Equal(common.SupersededOffer))
+			Expect(*offers[1].SignatureState).
+				Should(Equal(common.SignatureVoided))
+		})
+	})
+
+	Describe("ESign Webhook", func() {
+		It("should accept the webhooks only for the known envelopes", func() {
+			testPOST(
+				"",
+				map[string]string{"envelope_id": "not-a-stub-envelope"},
+				"/esign/webhook",
+				http.StatusBadRequest,
+			)
+			testPOST(
+				"",
+				map[string]string{"envelope_id": "stub-unknown-envelope"},
+				"/esign/webhook",
+				http.StatusNotFound,
+			)
+
+			// The voided signatures are acknowledged but not polled anymore
+			testPOST(
+				"",
+				map[string]string{
+					"envelope_id": getEnvelopeID("CAND-0044-3", 1),
+				},
+				"/esign/webhook",
+				http.StatusOK,
+			)
+
+			testPOST(
+				"",
+				map[string]string{
+					"envelope_id": getEnvelopeID("CAND-0044-1", 1),
+				},
+				"/esign/webhook",
+				http.StatusOK,
+			)
+		})
+	})
+
+	Describe("Granger", func() {
+		It("should complete the signatures with the provider", func() {
+			// granger polls the provider every minute
+			Eventually(func(g Gomega) {
+				g.Expect(getCandidacyState("CAND-0044-1")).
+					Should(Equal("OFFER_ACCEPTED"))
+				g.Expect(getCandidacyState("CAND-0044-2")).
+					Should(Equal("OFFER_DECLINED"))
+			}).WithTimeout(3 * time.Minute).
+				WithPolling(5 * time.Second).
+				Should(Succeed())
+
+			offers := getOffers(signerToken, "/hub/get-offers", "CAND-0044-1")
+			Expect(offers[0].OfferState).Should(Equal(common.AcceptedOffer))
+			Expect(*offers[0].SignatureState).Should(Equal(common.OfferSigned))
+			Expect(offers[0].SignedAt).ShouldNot(BeNil())
+
+			offers = getOffers(
+				adminToken,
+				"/employer/get-offers",
+				"CAND-0044-2",
+			)
+			Expect(offers[0].OfferState).Should(Equal(common.DeclinedOffer))
+			Expect(*offers[0].SignatureState).
+				Should(Equal(common.SignatureDeclined))
+			Expect(offers[0].SignedAt).Should(BeNil())
+
+			// The revised offer was never sent for signature
+			Expect(getCandidacyState("CAND-0044-3")).Should(Equal("OFFERED"))
+
+			var startDate string
+			err := db.QueryRow(
+				context.Background(),
+				`
+SELECT start_date::TEXT
+FROM opening_hires
+WHERE candidacy_id = $1
+`,
+				"CAND-0044-1",
+			).Scan(&startDate)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(startDate).Should(Equal("2025-03-01"))
+
+			var recruiterEmails int
+			err = db.QueryRow(
+				context.Background(),
+				`
+SELECT COUNT(*)
+FROM emails
+WHERE $1 = ANY(email_to)
+    AND email_subject LIKE '%the offer for%'
+`,
+				"crud@offer-signatures.example",
+			).Scan(&recruiterEmails)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(recruiterEmails).Should(Equal(2))
+		})
+
+		It("should serve the signed offer letter", func() {
+			signed := true
+			testPOST(
+				signerToken,
+				common.GetOfferDocumentRequest{
+					CandidacyID: "CAND-0044-1",
+					Version:     1,
+					Signed:      &signed,
+				},
+				"/hub/get-offer-document",
+				http.StatusOK,
+			)
+			testPOST(
+				viewerToken,
+				common.GetOfferDocumentRequest{
+					CandidacyID: "CAND-0044-1",
+					Version:     1,
+					Signed:      &signed,
+				},
+				"/employer/get-offer-document",
+				http.StatusOK,
+			)
+
+			// Declined offers have no signed document
+			testPOST(
+				declinerToken,
+				common.GetOfferDocumentRequest{
+					CandidacyID: "CAND-0044-2",
+					Version:     1,
+					Signed:      &signed,
+				},
+				"/hub/get-offer-document",
+				http.StatusNotFound,
+			)
+
+			// Signed document cannot be requested along with an attachment
+			testPOST(
+				signerToken,
+				common.GetOfferDocumentRequest{
+					CandidacyID:      "CAND-0044-1",
+					Version:          1,
+					AttachmentNumber: intptr(1),
+					Signed:           &signed,
+				},
+				"/hub/get-offer-document",
+				http.StatusBadRequest,
+			)
+		})
+	})
+})
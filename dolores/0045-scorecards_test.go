@@ -0,0 +1,440 @@
+package dolores
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Scorecards", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, openingsToken string
+	var interviewer1Token, interviewer2Token string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0045-scorecards-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@scorecards.example":        &adminToken,
+			"openings@scorecards.example":     &openingsToken,
+			"interviewer1@scorecards.example": &interviewer1Token,
+			"interviewer2@scorecards.example": &interviewer2Token,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"scorecards.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0045-scorecards-down.pgsql")
+		db.Close()
+	})
+
+	getScorecards := func(
+		token, interviewID string,
+	) employer.InterviewScorecards {
+		resp := testPOSTGetResp(
+			token,
+			employer.GetScorecardsRequest{InterviewID: interviewID},
+			"/employer/get-scorecards",
+			http.StatusOK,
+		).([]byte)
+		var scorecards employer.InterviewScorecards
+		err := json.Unmarshal(resp, &scorecards)
+		Expect(err).ShouldNot(HaveOccurred())
+		return scorecards
+	}
+
+	Describe("Opening Competencies", func() {
+		It("should set and get the competencies of an opening", func() {
+			type setTestCase struct {
+				description string
+				token       string
+				request     employer.SetOpeningCompetenciesRequest
+				wantStatus  int
+			}
+
+			testCases := []setTestCase{
+				{
+					description: "without any roles",
+					token:       interviewer1Token,
+					request: employer.SetOpeningCompetenciesRequest{
+						OpeningID: "2024-Jun-01-1",
+						Competencies: []employer.Competency{
+							{Name: "Coding"},
+						},
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "with duplicate competencies",
+					token:       openingsToken,
+					request: employer.SetOpeningCompetenciesRequest{
+						OpeningID: "2024-Jun-01-1",
+						Competencies: []employer.Competency{
+							{Name: "Coding"},
+							{Name: "Coding"},
+						},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an unknown opening",
+					token:       openingsToken,
+					request: employer.SetOpeningCompetenciesRequest{
+						OpeningID: "2024-Jun-01-999",
+						Competencies: []employer.Competency{
+							{Name: "Coding"},
+						},
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "with valid competencies",
+					token:       openingsToken,
+					request: employer.SetOpeningCompetenciesRequest{
+						OpeningID: "2024-Jun-01-1",
+						Competencies: []employer.Competency{
+							{
+								Name:        "System Design",
+								Description: strptr("Designs scalable systems"),
+							},
+							{Name: "Coding"},
+							{Name: "Communication"},
+						},
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/set-opening-competencies",
+					tc.wantStatus,
+				)
+			}
+
+			resp := testPOSTGetResp(
+				interviewer1Token,
+				employer.GetOpeningCompetenciesRequest{
+					OpeningID: "2024-Jun-01-1",
+				},
+				"/employer/get-opening-competencies",
+				http.StatusOK,
+			).([]byte)
+			var competencies []employer.Competency
+			err := json.Unmarshal(resp, &competencies)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(competencies).Should(HaveLen(3))
+			Expect(competencies[0].Name).Should(Equal("System Design"))
+			Expect(*competencies[0].Description).
+				Should(Equal("Designs scalable systems"))
+			Expect(competencies[1].Name).Should(Equal("Coding"))
+			Expect(competencies[2].Name).Should(Equal("Communication"))
+
+			testPOST(
+				adminToken,
+				employer.GetOpeningCompetenciesRequest{
+					OpeningID: "2024-Jun-01-999",
+				},
+				"/employer/get-opening-competencies",
+				http.StatusNotFound,
+			)
+		})
+	})
+
+	Describe("Put Scorecard", func() {
+		It("should validate the scorecards", func() {
+			type putTestCase struct {
+				description string
+				token       string
+				request     employer.PutScorecardRequest
+				wantStatus  int
+			}
+
+			testCases := []putTestCase{
+				{
+					description: "without a decision",
+					token:       interviewer1Token,
+					request: employer.PutScorecardRequest{
+						InterviewID: "INT-0045-1",
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an invalid rating",
+					token:       interviewer1Token,
+					request: employer.PutScorecardRequest{
+						InterviewID: "INT-0045-1",
+						Decision:    common.YesInterviewersDecision,
+						Ratings: []employer.CompetencyRating{
+							{Competency: "Coding", Rating: 6},
+						},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an unknown competency",
+					token:       interviewer1Token,
+					request: employer.PutScorecardRequest{
+						InterviewID: "INT-0045-1",
+						Decision:    common.YesInterviewersDecision,
+						Ratings: []employer.CompetencyRating{
+							{Competency: "Juggling", Rating: 4},
+						},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an unknown interview",
+					token:       interviewer1Token,
+					request: employer.PutScorecardRequest{
+						InterviewID: "INT-0045-999",
+						Decision:    common.YesInterviewersDecision,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "by someone who is not an interviewer",
+					token:       adminToken,
+					request: employer.PutScorecardRequest{
+						InterviewID: "INT-0045-1",
+						Decision:    common.YesInterviewersDecision,
+					},
+					wantStatus: http.StatusForbidden,
+				},
+				{
+					description: "for a cancelled interview",
+					token:       interviewer1Token,
+					request: employer.PutScorecardRequest{
+						InterviewID: "INT-0045-2",
+						Decision:    common.YesInterviewersDecision,
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/put-scorecard",
+					tc.wantStatus,
+				)
+			}
+		})
+
+		It("should hide the others' scorecards until submitted", func() {
+			testPOST(
+				interviewer1Token,
+				employer.PutScorecardRequest{
+					InterviewID: "INT-0045-1",
+					Decision:    common.StrongYesInterviewersDecision,
+					Ratings: []employer.CompetencyRating{
+						{Competency: "Coding", Rating: 5},
+						{
+							Competency: "System Design",
+							Rating:     4,
+							Notes:      strptr("Good trade-offs"),
+						},
+					},
+					Positives: strptr("Strong coder"),
+				},
+				"/employer/put-scorecard",
+				http.StatusOK,
+			)
+
+			scorecards := getScorecards(interviewer2Token, "INT-0045-1")
+			Expect(scorecards.OthersHidden).Should(BeTrue())
+			Expect(scorecards.Scorecards).Should(BeEmpty())
+			Expect(scorecards.PendingInterviewers).Should(HaveLen(1))
+			Expect(scorecards.PendingInterviewers[0].Email).
+				Should(Equal("interviewer2@scorecards.example"))
+
+			// Non-interviewers see all the submitted scorecards
+			scorecards = getScorecards(adminToken, "INT-0045-1")
+			Expect(scorecards.OthersHidden).Should(BeFalse())
+			Expect(scorecards.Scorecards).Should(HaveLen(1))
+			Expect(scorecards.Scorecards[0].Ratings).Should(HaveLen(2))
+
+			testPOST(
+				interviewer2Token,
+				employer.PutScorecardRequest{
+					InterviewID: "INT-0045-1",
+					Decision:    common.NoInterviewersDecision,
+					Ratings: []employer.CompetencyRating{
+						{Competency: "Coding", Rating: 2},
+					},
+					MarkInterviewCompleted: true,
+				},
+				"/employer/put-scorecard",
+				http.StatusOK,
+			)
+
+			scorecards = getScorecards(interviewer2Token, "INT-0045-1")
+			Expect(scorecards.OthersHidden).Should(BeFalse())
+			Expect(scorecards.Scorecards).Should(HaveLen(2))
+			Expect(scorecards.PendingInterviewers).Should(BeEmpty())
+			Expect(scorecards.InterviewState).
+				Should(Equal(common.CompletedInterviewState))
+
+		})
+
+		It("should not allow a submitted scorecard to be changed", func() {
+			// Having read the scorecard of interviewer1, interviewer2 tries
+			// to revise their own
+			testPOST(
+				interviewer2Token,
+				employer.PutScorecardRequest{
+					InterviewID: "INT-0045-1",
+					Decision:    common.StrongYesInterviewersDecision,
+					Ratings: []employer.CompetencyRating{
+						{Competency: "Coding", Rating: 5},
+					},
+				},
+				"/employer/put-scorecard",
+				http.StatusConflict,
+			)
+
+			testPOST(
+				interviewer2Token,
+				employer.PutAssessmentRequest{
+					InterviewID: "INT-0045-1",
+					Decision:    common.StrongYesInterviewersDecision,
+				},
+				"/employer/put-assessment",
+				http.StatusConflict,
+			)
+
+			scorecards := getScorecards(adminToken, "INT-0045-1")
+			Expect(scorecards.Scorecards).Should(HaveLen(2))
+			for _, scorecard := range scorecards.Scorecards {
+				if scorecard.Interviewer.Email ==
+					"interviewer2@scorecards.example" {
+					Expect(scorecard.Decision).
+						Should(Equal(common.NoInterviewersDecision))
+					Expect(scorecard.Ratings).Should(ConsistOf(
+						employer.CompetencyRating{
+							Competency: "Coding",
+							Rating:     2,
+						},
+					))
+				}
+			}
+		})
+	})
+
+	Describe("Legacy Assessments", func() {
+		getAssessment := func(
+			token, interviewID string,
+		) employer.Assessment {
+			resp := testPOSTGetResp(
+				token,
+				employer.GetAssessmentRequest{InterviewID: interviewID},
+				"/employer/get-assessment",
+				http.StatusOK,
+			).([]byte)
+			var assessment employer.Assessment
+			err := json.Unmarshal(resp, &assessment)
+			Expect(err).ShouldNot(HaveOccurred())
+			return assessment
+		}
+
+		It("should return only the scorecard of the caller", func() {
+			testPOST(
+				adminToken,
+				employer.GetAssessmentRequest{InterviewID: "INT-0045-999"},
+				"/employer/get-assessment",
+				http.StatusNotFound,
+			)
+
+			// The admin has not submitted a scorecard, and does not get to
+			// see the scorecards of the interviewers via this endpoint
+			assessment := getAssessment(adminToken, "INT-0045-1")
+			Expect(assessment.InterviewID).Should(Equal("INT-0045-1"))
+			Expect(assessment.Decision).Should(BeEmpty())
+			Expect(assessment.Positives).Should(BeEmpty())
+			Expect(assessment.FeedbackSubmittedBy).Should(BeEmpty())
+
+			assessment = getAssessment(interviewer1Token, "INT-0045-1")
+			Expect(assessment.Decision).
+				Should(Equal(common.StrongYesInterviewersDecision))
+			Expect(assessment.Positives).Should(Equal("Strong coder"))
+			Expect(assessment.FeedbackSubmittedBy).
+				Should(Equal("interviewer1@scorecards.example"))
+
+			testPOST(
+				interviewer1Token,
+				employer.PutAssessmentRequest{InterviewID: "INT-0045-1"},
+				"/employer/put-assessment",
+				http.StatusBadRequest,
+			)
+		})
+	})
+
+	Describe("Candidacy Debrief", func() {
+		It("should aggregate the scorecards of a candidacy", func() {
+			testPOST(
+				adminToken,
+				employer.GetCandidacyDebriefRequest{
+					CandidacyID: "CAND-0045-999",
+				},
+				"/employer/get-candidacy-debrief",
+				http.StatusNotFound,
+			)
+
+			resp := testPOSTGetResp(
+				adminToken,
+				employer.GetCandidacyDebriefRequest{CandidacyID: "CAND-0045-1"},
+				"/employer/get-candidacy-debrief",
+				http.StatusOK,
+			).([]byte)
+			var debrief employer.CandidacyDebrief
+			err := json.Unmarshal(resp, &debrief)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// The cancelled interview is not part of the debrief
+			Expect(debrief.Interviews).Should(HaveLen(1))
+			Expect(debrief.Interviews[0].Scorecards).Should(HaveLen(2))
+
+			Expect(debrief.Competencies).Should(HaveLen(2))
+			Expect(debrief.Competencies[0].Competency).
+				Should(Equal("System Design"))
+			Expect(debrief.Competencies[0].AverageRating).Should(Equal(4.0))
+			Expect(debrief.Competencies[1].Competency).Should(Equal("Coding"))
+			Expect(debrief.Competencies[1].AverageRating).Should(Equal(3.5))
+			Expect(debrief.Competencies[1].RatingsCount).Should(Equal(2))
+
+			decisions := make(map[common.InterviewersDecision]int)
+			for _, decision := range debrief.Decisions {
+				decisions[decision.Decision] = decision.Count
+			}
+			Expect(decisions[common.StrongYesInterviewersDecision]).
+				Should(Equal(1))
+			Expect(decisions[common.NeutralInterviewersDecision]).
+				Should(Equal(0))
+			Expect(decisions[common.NoInterviewersDecision]).Should(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,426 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Interview Changes", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, interviewerToken, candidateToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0046-interview-changes-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@interview-changes.example":        &adminToken,
+			"crud@interview-changes.example":         &crudToken,
+			"interviewer1@interview-changes.example": &interviewerToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"interview-changes.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+
+		wg.Add(1)
+		hubSigninAsync(
+			"candidate@interview-changes-hub.example",
+			"NewPassword123$",
+			&candidateToken,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0046-interview-changes-down.pgsql")
+		db.Close()
+	})
+
+	getInterview := func(interviewID string) employer.EmployerInterview {
+		resp := testPOSTGetResp(
+			adminToken,
+			employer.GetInterviewDetailsRequest{InterviewID: interviewID},
+			"/employer/get-interview-details",
+			http.StatusOK,
+		).([]byte)
+		var interview employer.EmployerInterview
+		err := json.Unmarshal(resp, &interview)
+		Expect(err).ShouldNot(HaveOccurred())
+		return interview
+	}
+
+	countEmails := func(recipient, subject string) int {
+		var count int
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT COUNT(*)
+FROM emails
+WHERE $1 = ANY(email_to)
+    AND email_subject = $2
+`,
+			recipient,
+			subject,
+		).Scan(&count)
+		Expect(err).ShouldNot(HaveOccurred())
+		return count
+	}
+
+	Describe("Reschedule Interview", func() {
+		It("should reschedule the scheduled interviews", func() {
+			startTime := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+			endTime := startTime.Add(time.Hour)
+
+			type rescheduleTestCase struct {
+				description string
+				token       string
+				request     employer.RescheduleInterviewRequest
+				wantStatus  int
+			}
+
+			testCases := []rescheduleTestCase{
+				{
+					description: "without the required roles",
+					token:       interviewerToken,
+					request: employer.RescheduleInterviewRequest{
+						InterviewID: "INT-0046-1",
+						StartTime:   startTime,
+						EndTime:     endTime,
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "to a slot in the past",
+					token:       crudToken,
+					request: employer.RescheduleInterviewRequest{
+						InterviewID: "INT-0046-1",
+						StartTime:   time.Now().Add(-time.Hour),
+						EndTime:     time.Now(),
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with end_time before start_time",
+					token:       crudToken,
+					request: employer.RescheduleInterviewRequest{
+						InterviewID: "INT-0046-1",
+						StartTime:   endTime,
+						EndTime:     startTime,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "an unknown interview",
+					token:       crudToken,
+					request: employer.RescheduleInterviewRequest{
+						InterviewID: "INT-0046-999",
+						StartTime:   startTime,
+						EndTime:     endTime,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+				{
+					description: "a completed interview",
+					token:       crudToken,
+					request: employer.RescheduleInterviewRequest{
+						InterviewID: "INT-0046-3",
+						StartTime:   startTime,
+						EndTime:     endTime,
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "a scheduled interview",
+					token:       crudToken,
+					request: employer.RescheduleInterviewRequest{
+						InterviewID: "INT-0046-1",
+						StartTime:   startTime,
+						EndTime:     endTime,
+						Reason:      strptr("Interviewer is travelling"),
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/reschedule-interview",
+					tc.wantStatus,
+				)
+			}
+
+			interview := getInterview("INT-0046-1")
+			Expect(interview.StartTime.Equal(startTime)).Should(BeTrue())
+			Expect(interview.EndTime.Equal(endTime)).Should(BeTrue())
+			Expect(interview.CandidateRSVPStatus).
+				Should(Equal(common.NotSetRSVP))
+			Expect(interview.Interviewers).Should(HaveLen(1))
+			Expect(interview.Interviewers[0].RSVPStatus).
+				Should(Equal(common.NotSetRSVP))
+
+			Expect(interview.Changes).Should(HaveLen(1))
+			change := interview.Changes[0]
+			Expect(change.ChangeType).
+				Should(Equal(common.RescheduledInterviewChange))
+			Expect(change.NewStartTime.Equal(startTime)).Should(BeTrue())
+			Expect(*change.Reason).Should(Equal("Interviewer is travelling"))
+			Expect(change.ChangedBy).Should(Equal("Applications CRUD User"))
+
+			subject := "Interview rescheduled for Interview Changes Opening"
+			Expect(countEmails(
+				"candidate@interview-changes-hub.example",
+				subject,
+			)).Should(Equal(1))
+			Expect(countEmails(
+				"interviewer1@interview-changes.example",
+				subject,
+			)).Should(Equal(1))
+			Expect(countEmails(
+				"watcher@interview-changes.example",
+				subject,
+			)).Should(Equal(1))
+		})
+	})
+
+	Describe("Request Reschedule", func() {
+		It("should let the candidate propose alternative slots", func() {
+			slot := common.InterviewSlot{
+				StartTime: time.Now().Add(96 * time.Hour),
+				EndTime:   time.Now().Add(97 * time.Hour),
+			}
+
+			type requestTestCase struct {
+				description string
+				request     hub.HubRSVPInterviewRequest
+				wantStatus  int
+			}
+
+			testCases := []requestTestCase{
+				{
+					description: "with a YES rsvp_status",
+					request: hub.HubRSVPInterviewRequest{
+						InterviewID:   "INT-0046-2",
+						RSVPStatus:    common.YesRSVP,
+						ProposedSlots: []common.InterviewSlot{slot},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with a slot in the past",
+					request: hub.HubRSVPInterviewRequest{
+						InterviewID: "INT-0046-2",
+						RSVPStatus:  common.NoRSVP,
+						ProposedSlots: []common.InterviewSlot{
+							{
+								StartTime: time.Now().Add(-2 * time.Hour),
+								EndTime:   time.Now().Add(-time.Hour),
+							},
+						},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with too many slots",
+					request: hub.HubRSVPInterviewRequest{
+						InterviewID: "INT-0046-2",
+						RSVPStatus:  common.NoRSVP,
+						ProposedSlots: []common.InterviewSlot{
+							slot, slot, slot, slot,
+						},
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "for a completed interview",
+					request: hub.HubRSVPInterviewRequest{
+						InterviewID:   "INT-0046-3",
+						RSVPStatus:    common.NoRSVP,
+						ProposedSlots: []common.InterviewSlot{slot},
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "for a scheduled interview",
+					request: hub.HubRSVPInterviewRequest{
+						InterviewID:   "INT-0046-2",
+						RSVPStatus:    common.NoRSVP,
+						ProposedSlots: []common.InterviewSlot{slot},
+						Reason:        strptr("I have an exam that day"),
+					},
+					wantStatus: http.StatusOK,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					candidateToken,
+					tc.request,
+					"/hub/rsvp-interview",
+					tc.wantStatus,
+				)
+			}
+
+			resp := testPOSTGetResp(
+				candidateToken,
+				hub.GetHubInterviewsByCandidacyRequest{
+					CandidacyID: "CAND-0046-1",
+				},
+				"/hub/get-interviews-by-candidacy",
+				http.StatusOK,
+			).([]byte)
+			var interviews []hub.HubInterview
+			err := json.Unmarshal(resp, &interviews)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var requested *hub.HubInterview
+			for i := range interviews {
+				if interviews[i].InterviewID == "INT-0046-2" {
+					requested = &interviews[i]
+				}
+			}
+			Expect(requested).ShouldNot(BeNil())
+			Expect(requested.CandidateRSVP).Should(Equal(common.NoRSVP))
+			Expect(requested.Changes).Should(HaveLen(1))
+			Expect(requested.Changes[0].ChangeType).
+				Should(Equal(common.RescheduleRequestedInterviewChange))
+			Expect(requested.Changes[0].ProposedSlots).Should(HaveLen(1))
+			Expect(requested.Changes[0].ChangedBy).
+				Should(Equal("Interview Changes Hub User"))
+
+			subject := "Interview Changes Hub User has requested to " +
+				"reschedule the interview"
+			Expect(countEmails(
+				"interviewer1@interview-changes.example",
+				subject,
+			)).Should(Equal(1))
+			Expect(countEmails(
+				"candidate@interview-changes-hub.example",
+				subject,
+			)).Should(Equal(0))
+		})
+	})
+
+	Describe("Cancel Interview", func() {
+		It("should cancel the scheduled interviews", func() {
+			type cancelTestCase struct {
+				description string
+				token       string
+				request     employer.CancelInterviewRequest
+				wantStatus  int
+			}
+
+			testCases := []cancelTestCase{
+				{
+					description: "without the required roles",
+					token:       interviewerToken,
+					request: employer.CancelInterviewRequest{
+						InterviewID: "INT-0046-1",
+						Reason:      "Position on hold",
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "without a reason",
+					token:       adminToken,
+					request: employer.CancelInterviewRequest{
+						InterviewID: "INT-0046-1",
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "a completed interview",
+					token:       adminToken,
+					request: employer.CancelInterviewRequest{
+						InterviewID: "INT-0046-3",
+						Reason:      "Position on hold",
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "a scheduled interview",
+					token:       adminToken,
+					request: employer.CancelInterviewRequest{
+						InterviewID: "INT-0046-1",
+						Reason:      "Position on hold",
+					},
+					wantStatus: http.StatusOK,
+				},
+				{
+					description: "an already cancelled interview",
+					token:       adminToken,
+					request: employer.CancelInterviewRequest{
+						InterviewID: "INT-0046-1",
+						Reason:      "Position on hold",
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/cancel-interview",
+					tc.wantStatus,
+				)
+			}
+
+			interview := getInterview("INT-0046-1")
+			Expect(interview.InterviewState).
+				Should(Equal(common.CancelledInterviewState))
+			Expect(interview.Changes).Should(HaveLen(2))
+			Expect(interview.Changes[1].ChangeType).
+				Should(Equal(common.CancelledInterviewChange))
+			Expect(*interview.Changes[1].Reason).
+				Should(Equal("Position on hold"))
+			Expect(interview.Changes[1].ChangedBy).Should(Equal("Admin User"))
+
+			// A cancelled interview cannot be rescheduled
+			testPOST(
+				adminToken,
+				employer.RescheduleInterviewRequest{
+					InterviewID: "INT-0046-1",
+					StartTime:   time.Now().Add(24 * time.Hour),
+					EndTime:     time.Now().Add(25 * time.Hour),
+				},
+				"/employer/reschedule-interview",
+				http.StatusUnprocessableEntity,
+			)
+
+			subject := "Interview cancelled for Interview Changes Opening"
+			Expect(countEmails(
+				"candidate@interview-changes-hub.example",
+				subject,
+			)).Should(Equal(1))
+			Expect(countEmails(
+				"watcher@interview-changes.example",
+				subject,
+			)).Should(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,238 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Calendar Feeds", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, interviewerToken, candidateToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0047-calendar-feeds-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@calendar-feeds.example":        &adminToken,
+			"interviewer1@calendar-feeds.example": &interviewerToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"calendar-feeds.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+
+		wg.Add(1)
+		hubSigninAsync(
+			"candidate@calendar-feeds-hub.example",
+			"NewPassword123$",
+			&candidateToken,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0047-calendar-feeds-down.pgsql")
+		db.Close()
+	})
+
+	getFeedPath := func(token, endpoint string) string {
+		resp := testPOSTGetResp(token, nil, endpoint, http.StatusOK).([]byte)
+		var feed common.CalendarFeed
+		err := json.Unmarshal(resp, &feed)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(feed.FeedPath).Should(HavePrefix("/calendar-feeds/"))
+		Expect(feed.FeedPath).Should(HaveSuffix("/interviews.ics"))
+		return feed.FeedPath
+	}
+
+	getFeed := func(feedPath string, wantStatus int) string {
+		resp, err := http.Get(serverURL + feedPath)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(wantStatus))
+		if wantStatus != http.StatusOK {
+			return ""
+		}
+
+		Expect(resp.Header.Get("Content-Type")).
+			Should(HavePrefix("text/calendar"))
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		return string(body)
+	}
+
+	getCalendarEvent := func(recipient, subject string) string {
+		var calendarEvent string
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT COALESCE(email_calendar_event, '')
+FROM emails
+WHERE $1 = ANY(email_to)
+    AND email_subject = $2
+ORDER BY created_at DESC
+LIMIT 1
+`,
+			recipient,
+			subject,
+		).Scan(&calendarEvent)
+		Expect(err).ShouldNot(HaveOccurred())
+		return calendarEvent
+	}
+
+	Describe("Get Calendar Feed", func() {
+		It("should need a session", func() {
+			for _, endpoint := range []string{
+				"/employer/get-calendar-feed",
+				"/hub/get-calendar-feed",
+			} {
+				testPOST("", nil, endpoint, http.StatusUnauthorized)
+			}
+		})
+
+		It("should return the same feed on every call", func() {
+			endpoint := "/employer/get-calendar-feed"
+			first := getFeedPath(interviewerToken, endpoint)
+			second := getFeedPath(interviewerToken, endpoint)
+			Expect(second).Should(Equal(first))
+
+			hubFeed := getFeedPath(candidateToken, "/hub/get-calendar-feed")
+			Expect(hubFeed).ShouldNot(Equal(first))
+		})
+
+		It("should list the upcoming interviews of the interviewer", func() {
+			feedPath := getFeedPath(
+				interviewerToken,
+				"/employer/get-calendar-feed",
+			)
+			feed := getFeed(feedPath, http.StatusOK)
+			Expect(feed).Should(ContainSubstring("METHOD:PUBLISH"))
+			Expect(feed).Should(ContainSubstring("UID:INT-0047-1@vetchi.org"))
+			Expect(feed).ShouldNot(ContainSubstring("INT-0047-2"))
+			Expect(feed).ShouldNot(ContainSubstring("INT-0047-3"))
+		})
+
+		It("should list the upcoming interviews of the candidate", func() {
+			feedPath := getFeedPath(candidateToken, "/hub/get-calendar-feed")
+			feed := getFeed(feedPath, http.StatusOK)
+			Expect(feed).Should(ContainSubstring("UID:INT-0047-1@vetchi.org"))
+			Expect(feed).Should(ContainSubstring("UID:INT-0047-3@vetchi.org"))
+			Expect(feed).ShouldNot(ContainSubstring("INT-0047-2"))
+		})
+
+		It("should not serve unknown feeds", func() {
+			getFeed("/calendar-feeds/unknown-token/interviews.ics", 404)
+		})
+	})
+
+	Describe("Interview Invites", func() {
+		It("should update the calendar entries on reschedule", func() {
+			startTime := time.Now().Add(72 * time.Hour).UTC().
+				Truncate(time.Second)
+			testPOST(
+				adminToken,
+				employer.RescheduleInterviewRequest{
+					InterviewID: "INT-0047-1",
+					StartTime:   startTime,
+					EndTime:     startTime.Add(time.Hour),
+				},
+				"/employer/reschedule-interview",
+				http.StatusOK,
+			)
+
+			invite := getCalendarEvent(
+				"candidate@calendar-feeds-hub.example",
+				"Interview rescheduled for Calendar Feeds Opening",
+			)
+			Expect(invite).Should(ContainSubstring("METHOD:REQUEST"))
+			Expect(invite).Should(ContainSubstring("UID:INT-0047-1@vetchi.org"))
+			Expect(invite).Should(ContainSubstring("SEQUENCE:1"))
+			Expect(invite).Should(ContainSubstring(
+				"DTSTART:" + startTime.Format("20060102T150405Z"),
+			))
+			// The candidate should not see the interviewers
+			Expect(invite).ShouldNot(ContainSubstring(
+				"interviewer1@calendar-feeds.example",
+			))
+
+			feedPath := getFeedPath(
+				interviewerToken,
+				"/employer/get-calendar-feed",
+			)
+			feed := getFeed(feedPath, http.StatusOK)
+			Expect(feed).Should(ContainSubstring("SEQUENCE:1"))
+		})
+
+		It("should cancel the calendar entries on cancellation", func() {
+			testPOST(
+				adminToken,
+				employer.CancelInterviewRequest{
+					InterviewID: "INT-0047-3",
+					Reason:      "Position is on hold",
+				},
+				"/employer/cancel-interview",
+				http.StatusOK,
+			)
+
+			invite := getCalendarEvent(
+				"candidate@calendar-feeds-hub.example",
+				"Interview cancelled for Calendar Feeds Opening",
+			)
+			Expect(invite).Should(ContainSubstring("METHOD:CANCEL"))
+			Expect(invite).Should(ContainSubstring("UID:INT-0047-3@vetchi.org"))
+			Expect(invite).Should(ContainSubstring("STATUS:CANCELLED"))
+
+			feedPath := getFeedPath(candidateToken, "/hub/get-calendar-feed")
+			feed := getFeed(feedPath, http.StatusOK)
+			Expect(feed).Should(ContainSubstring("INT-0047-1"))
+			Expect(feed).ShouldNot(ContainSubstring("INT-0047-3"))
+		})
+	})
+
+	Describe("Reset Calendar Feed", func() {
+		It("should stop serving the previous feed", func() {
+			for _, tc := range []struct {
+				token  string
+				prefix string
+			}{
+				{interviewerToken, "/employer"},
+				{candidateToken, "/hub"},
+			} {
+				getEndpoint := tc.prefix + "/get-calendar-feed"
+				oldPath := getFeedPath(tc.token, getEndpoint)
+				newPath := getFeedPath(
+					tc.token,
+					tc.prefix+"/reset-calendar-feed",
+				)
+				Expect(newPath).ShouldNot(Equal(oldPath))
+
+				getFeed(oldPath, http.StatusNotFound)
+				feed := getFeed(newPath, http.StatusOK)
+				Expect(strings.Count(feed, "BEGIN:VEVENT")).Should(Equal(1))
+
+				Expect(getFeedPath(tc.token, getEndpoint)).
+					Should(Equal(newPath))
+			}
+		})
+	})
+})
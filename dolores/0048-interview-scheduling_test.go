@@ -0,0 +1,489 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Interview Scheduling", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, interviewer1Token, interviewer2Token string
+	var candidateToken, otherToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0048-interview-scheduling-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@interview-scheduling.example":        &adminToken,
+			"interviewer1@interview-scheduling.example": &interviewer1Token,
+			"interviewer2@interview-scheduling.example": &interviewer2Token,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"interview-scheduling.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+
+		hubTokens := map[string]*string{
+			"candidate@interview-scheduling-hub.example": &candidateToken,
+			"other@interview-scheduling-hub.example":     &otherToken,
+		}
+		for email, token := range hubTokens {
+			wg.Add(1)
+			hubSigninAsync(email, "NewPassword123$", token, &wg)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0048-interview-scheduling-down.pgsql")
+		db.Close()
+	})
+
+	everyDay := func(startTime, endTime string) []employer.AvailabilityWindow {
+		var windows []employer.AvailabilityWindow
+		for day := 0; day < 7; day++ {
+			windows = append(windows, employer.AvailabilityWindow{
+				DayOfWeek: day,
+				StartTime: startTime,
+				EndTime:   endTime,
+			})
+		}
+		return windows
+	}
+
+	createRequest := func(interviewerEmails []string) string {
+		resp := testPOSTGetResp(
+			adminToken,
+			employer.CreateSchedulingRequestRequest{
+				CandidacyID:       "CAND-0048-1",
+				InterviewType:     common.VideoCallInterviewType,
+				DurationMinutes:   60,
+				Description:       "Self scheduled interview",
+				WindowStart:       time.Now().UTC(),
+				WindowEnd:         time.Now().UTC().Add(72 * time.Hour),
+				InterviewerEmails: interviewerEmails,
+			},
+			"/employer/create-scheduling-request",
+			http.StatusOK,
+		).([]byte)
+
+		var createResp employer.CreateSchedulingRequestResponse
+		err := json.Unmarshal(resp, &createResp)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(createResp.SchedulingRequestID).ShouldNot(BeEmpty())
+		return createResp.SchedulingRequestID
+	}
+
+	getSlots := func(schedulingRequestID string) hub.InterviewSlots {
+		resp := testPOSTGetResp(
+			candidateToken,
+			hub.GetInterviewSlotsRequest{
+				SchedulingRequestID: schedulingRequestID,
+			},
+			"/hub/get-interview-slots",
+			http.StatusOK,
+		).([]byte)
+
+		var slots hub.InterviewSlots
+		err := json.Unmarshal(resp, &slots)
+		Expect(err).ShouldNot(HaveOccurred())
+		return slots
+	}
+
+	containsSlot := func(slots []common.InterviewSlot, start time.Time) bool {
+		for _, slot := range slots {
+			if slot.StartTime.Equal(start) {
+				return true
+			}
+		}
+		return false
+	}
+
+	interviewers := []string{
+		"interviewer1@interview-scheduling.example",
+		"interviewer2@interview-scheduling.example",
+	}
+	var firstRequestID, secondRequestID string
+	var heldStart, bookedStart time.Time
+
+	Describe("Availability", func() {
+		It("should not have any availability to begin with", func() {
+			testPOST(
+				interviewer1Token,
+				nil,
+				"/employer/get-my-availability",
+				http.StatusNotFound,
+			)
+		})
+
+		It("should reject invalid availability", func() {
+			for _, availability := range []employer.OrgUserAvailability{
+				{
+					TimeZone: "Mars Standard Time",
+					Windows:  everyDay("09:00", "17:00"),
+				},
+				{
+					TimeZone: "UTC Coordinated Universal Time GMT+0000",
+					Windows:  everyDay("17:00", "09:00"),
+				},
+				{
+					TimeZone: "UTC Coordinated Universal Time GMT+0000",
+					Windows: []employer.AvailabilityWindow{
+						{DayOfWeek: 7, StartTime: "09:00", EndTime: "17:00"},
+					},
+				},
+			} {
+				testPOST(
+					interviewer1Token,
+					availability,
+					"/employer/set-my-availability",
+					http.StatusBadRequest,
+				)
+			}
+		})
+
+		It("should set the availability in the time zone of the user", func() {
+			// Both are 09:00 to 17:00 UTC
+			testPOST(
+				interviewer1Token,
+				employer.OrgUserAvailability{
+					TimeZone: "UTC Coordinated Universal Time GMT+0000",
+					Windows:  everyDay("09:00", "17:00"),
+				},
+				"/employer/set-my-availability",
+				http.StatusOK,
+			)
+			testPOST(
+				interviewer2Token,
+				employer.OrgUserAvailability{
+					TimeZone: "IST Indian Standard Time GMT+0530",
+					Windows:  everyDay("14:30", "22:30"),
+				},
+				"/employer/set-my-availability",
+				http.StatusOK,
+			)
+
+			resp := testPOSTGetResp(
+				interviewer2Token,
+				nil,
+				"/employer/get-my-availability",
+				http.StatusOK,
+			).([]byte)
+			var availability employer.OrgUserAvailability
+			err := json.Unmarshal(resp, &availability)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(availability.TimeZone).Should(Equal(
+				common.TimeZone("IST Indian Standard Time GMT+0530"),
+			))
+			Expect(availability.Windows).Should(HaveLen(7))
+		})
+	})
+
+	Describe("Create Scheduling Request", func() {
+		It("should validate the request", func() {
+			valid := employer.CreateSchedulingRequestRequest{
+				CandidacyID:       "CAND-0048-1",
+				InterviewType:     common.VideoCallInterviewType,
+				DurationMinutes:   60,
+				WindowStart:       time.Now().UTC(),
+				WindowEnd:         time.Now().UTC().Add(72 * time.Hour),
+				InterviewerEmails: interviewers,
+			}
+
+			testPOST(
+				interviewer1Token,
+				valid,
+				"/employer/create-scheduling-request",
+				http.StatusForbidden,
+			)
+
+			for _, tc := range []struct {
+				mutate     func(*employer.CreateSchedulingRequestRequest)
+				wantStatus int
+			}{
+				{
+					func(req *employer.CreateSchedulingRequestRequest) {
+						req.WindowEnd = req.WindowStart.Add(60 * 24 * time.Hour)
+					},
+					http.StatusBadRequest,
+				},
+				{
+					func(req *employer.CreateSchedulingRequestRequest) {
+						req.DurationMinutes = 5
+					},
+					http.StatusBadRequest,
+				},
+				{
+					func(req *employer.CreateSchedulingRequestRequest) {
+						// Has not set the availability
+						req.InterviewerEmails = []string{
+							"interviewer3@interview-scheduling.example",
+						}
+					},
+					http.StatusBadRequest,
+				},
+				{
+					func(req *employer.CreateSchedulingRequestRequest) {
+						req.CandidacyID = "CAND-0048-unknown"
+					},
+					http.StatusNotFound,
+				},
+				{
+					func(req *employer.CreateSchedulingRequestRequest) {
+						req.CandidacyID = "CAND-0048-2"
+					},
+					http.StatusUnprocessableEntity,
+				},
+			} {
+				req := valid
+				tc.mutate(&req)
+				testPOST(
+					adminToken,
+					req,
+					"/employer/create-scheduling-request",
+					tc.wantStatus,
+				)
+			}
+		})
+
+		It("should notify the candidate", func() {
+			firstRequestID = createRequest(interviewers)
+
+			var count int
+			err := db.QueryRow(
+				context.Background(),
+				`
+SELECT COUNT(*)
+FROM emails
+WHERE 'candidate@interview-scheduling-hub.example' = ANY(email_to)
+    AND email_subject LIKE 'Pick a slot for your interview%'
+    AND email_text_body LIKE '%' || $1 || '%'
+`,
+				firstRequestID,
+			).Scan(&count)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(count).Should(Equal(1))
+		})
+	})
+
+	Describe("Self Scheduling", func() {
+		It("should offer the free slots of all the interviewers", func() {
+			slots := getSlots(firstRequestID)
+			Expect(slots.CompanyName).Should(Equal("Interview Scheduling Inc"))
+			Expect(slots.DurationMinutes).Should(Equal(60))
+			Expect(slots.Slots).ShouldNot(BeEmpty())
+			for _, slot := range slots.Slots {
+				start := slot.StartTime.UTC()
+				Expect(start.After(time.Now())).Should(BeTrue())
+				Expect(start.Minute() % 30).Should(Equal(0))
+				Expect(slot.EndTime.Sub(slot.StartTime)).
+					Should(Equal(time.Hour))
+				Expect(start.Hour()).Should(BeNumerically(">=", 9))
+				Expect(slot.EndTime.UTC().Hour()*60 +
+					slot.EndTime.UTC().Minute()).
+					Should(BeNumerically("<=", 17*60))
+			}
+		})
+
+		It("should not show the request to other hub users", func() {
+			testPOST(
+				otherToken,
+				hub.GetInterviewSlotsRequest{
+					SchedulingRequestID: firstRequestID,
+				},
+				"/hub/get-interview-slots",
+				http.StatusNotFound,
+			)
+		})
+
+		It("should hold a slot", func() {
+			slots := getSlots(firstRequestID)
+			heldStart = slots.Slots[0].StartTime
+
+			resp := testPOSTGetResp(
+				candidateToken,
+				hub.HoldInterviewSlotRequest{
+					SchedulingRequestID: firstRequestID,
+					StartTime:           heldStart,
+				},
+				"/hub/hold-interview-slot",
+				http.StatusOK,
+			).([]byte)
+			var holdResp hub.HoldInterviewSlotResponse
+			err := json.Unmarshal(resp, &holdResp)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(holdResp.HoldExpiresAt.After(time.Now())).Should(BeTrue())
+
+			slots = getSlots(firstRequestID)
+			Expect(slots.HeldSlot).ShouldNot(BeNil())
+			Expect(slots.HeldSlot.StartTime.Equal(heldStart)).Should(BeTrue())
+
+			// The held slot is not offered for the other requests
+			secondRequestID = createRequest(interviewers[:1])
+			slots = getSlots(secondRequestID)
+			Expect(containsSlot(slots.Slots, heldStart)).Should(BeFalse())
+		})
+
+		It("should not hold a slot that is not free", func() {
+			testPOST(
+				candidateToken,
+				hub.HoldInterviewSlotRequest{
+					SchedulingRequestID: secondRequestID,
+					StartTime:           heldStart,
+				},
+				"/hub/hold-interview-slot",
+				http.StatusConflict,
+			)
+
+			testPOST(
+				candidateToken,
+				hub.HoldInterviewSlotRequest{
+					SchedulingRequestID: firstRequestID,
+					StartTime:           heldStart.Add(7 * time.Minute),
+				},
+				"/hub/hold-interview-slot",
+				http.StatusConflict,
+			)
+		})
+
+		It("should book the held slot", func() {
+			bookedStart = heldStart
+			resp := testPOSTGetResp(
+				candidateToken,
+				hub.BookInterviewSlotRequest{
+					SchedulingRequestID: firstRequestID,
+					StartTime:           bookedStart,
+				},
+				"/hub/book-interview-slot",
+				http.StatusOK,
+			).([]byte)
+			var bookResp hub.BookInterviewSlotResponse
+			err := json.Unmarshal(resp, &bookResp)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(bookResp.InterviewID).ShouldNot(BeEmpty())
+
+			var interviewerCount int
+			var candidateRSVP string
+			var startTime time.Time
+			err = db.QueryRow(
+				context.Background(),
+				`
+SELECT
+    i.candidate_rsvp,
+    i.start_time,
+    (SELECT COUNT(*) FROM interview_interviewers ii
+        WHERE ii.interview_id = i.id)
+FROM interviews i
+WHERE i.id = $1
+`,
+				bookResp.InterviewID,
+			).Scan(&candidateRSVP, &startTime, &interviewerCount)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(candidateRSVP).Should(Equal(string(common.YesRSVP)))
+			Expect(startTime.Equal(bookedStart)).Should(BeTrue())
+			Expect(interviewerCount).Should(Equal(2))
+
+			var invite string
+			err = db.QueryRow(
+				context.Background(),
+				`
+SELECT COALESCE(email_calendar_event, '')
+FROM emails
+WHERE 'interviewer1@interview-scheduling.example' = ANY(email_to)
+ORDER BY created_at DESC
+LIMIT 1
+`,
+			).Scan(&invite)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(invite).Should(ContainSubstring("METHOD:REQUEST"))
+			Expect(invite).Should(ContainSubstring(
+				"UID:" + bookResp.InterviewID + "@vetchi.org",
+			))
+		})
+
+		It("should not double book", func() {
+			for _, tc := range []struct {
+				schedulingRequestID string
+				wantStatus          int
+			}{
+				// Already booked
+				{firstRequestID, http.StatusUnprocessableEntity},
+				// The interviewer and the candidate are busy then
+				{secondRequestID, http.StatusConflict},
+			} {
+				testPOST(
+					candidateToken,
+					hub.BookInterviewSlotRequest{
+						SchedulingRequestID: tc.schedulingRequestID,
+						StartTime:           bookedStart,
+					},
+					"/hub/book-interview-slot",
+					tc.wantStatus,
+				)
+			}
+
+			slots := getSlots(secondRequestID)
+			Expect(slots.Slots).ShouldNot(BeEmpty())
+			for _, slot := range slots.Slots {
+				overlaps := slot.StartTime.Before(bookedStart.Add(time.Hour)) &&
+					slot.EndTime.After(bookedStart)
+				Expect(overlaps).Should(BeFalse())
+			}
+		})
+	})
+
+	Describe("Cancel Scheduling Request", func() {
+		It("should stop offering the slots", func() {
+			req := employer.CancelSchedulingRequestRequest{
+				SchedulingRequestID: secondRequestID,
+			}
+			testPOST(
+				interviewer1Token,
+				req,
+				"/employer/cancel-scheduling-request",
+				http.StatusForbidden,
+			)
+			testPOST(
+				adminToken,
+				req,
+				"/employer/cancel-scheduling-request",
+				http.StatusOK,
+			)
+			testPOST(
+				adminToken,
+				req,
+				"/employer/cancel-scheduling-request",
+				http.StatusUnprocessableEntity,
+			)
+			testPOST(
+				adminToken,
+				employer.CancelSchedulingRequestRequest{
+					SchedulingRequestID: "unknown",
+				},
+				"/employer/cancel-scheduling-request",
+				http.StatusNotFound,
+			)
+
+			slots := getSlots(secondRequestID)
+			Expect(slots.Slots).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,260 @@
+package dolores
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("CalDAV", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, interviewerToken, candidateToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0049-caldav-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		employerSigninAsync(
+			"caldav.example",
+			"admin@caldav.example",
+			"NewPassword123$",
+			&adminToken,
+			&wg,
+		)
+		employerSigninAsync(
+			"caldav.example",
+			"interviewer@caldav.example",
+			"NewPassword123$",
+			&interviewerToken,
+			&wg,
+		)
+		hubSigninAsync(
+			"candidate@caldav-hub.example",
+			"NewPassword123$",
+			&candidateToken,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0049-caldav-down.pgsql")
+		db.Close()
+	})
+
+	// The seeded busy period is from 10:00 to 12:00 UTC on this day
+	busyDay := time.Now().UTC().Truncate(24 * time.Hour).Add(48 * time.Hour)
+
+	getSlotStarts := func() []time.Time {
+		var windows []employer.AvailabilityWindow
+		for day := 0; day < 7; day++ {
+			windows = append(windows, employer.AvailabilityWindow{
+				DayOfWeek: day,
+				StartTime: "09:00",
+				EndTime:   "17:00",
+			})
+		}
+		testPOST(
+			interviewerToken,
+			employer.OrgUserAvailability{
+				TimeZone: "UTC Coordinated Universal Time GMT+0000",
+				Windows:  windows,
+			},
+			"/employer/set-my-availability",
+			http.StatusOK,
+		)
+
+		resp := testPOSTGetResp(
+			adminToken,
+			employer.CreateSchedulingRequestRequest{
+				CandidacyID:     "CAND-0049-1",
+				InterviewType:   common.VideoCallInterviewType,
+				DurationMinutes: 60,
+				WindowStart:     time.Now().UTC(),
+				WindowEnd:       time.Now().UTC().Add(72 * time.Hour),
+				InterviewerEmails: []string{
+					"interviewer@caldav.example",
+				},
+			},
+			"/employer/create-scheduling-request",
+			http.StatusOK,
+		).([]byte)
+		var createResp employer.CreateSchedulingRequestResponse
+		err := json.Unmarshal(resp, &createResp)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp = testPOSTGetResp(
+			candidateToken,
+			hub.GetInterviewSlotsRequest{
+				SchedulingRequestID: createResp.SchedulingRequestID,
+			},
+			"/hub/get-interview-slots",
+			http.StatusOK,
+		).([]byte)
+		var slots hub.InterviewSlots
+		err = json.Unmarshal(resp, &slots)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		testPOST(
+			adminToken,
+			employer.CancelSchedulingRequestRequest{
+				SchedulingRequestID: createResp.SchedulingRequestID,
+			},
+			"/employer/cancel-scheduling-request",
+			http.StatusOK,
+		)
+
+		var starts []time.Time
+		for _, slot := range slots.Slots {
+			starts = append(starts, slot.StartTime.UTC())
+		}
+		return starts
+	}
+
+	Describe("Get CalDAV Connection", func() {
+		It("should return 404 when not connected", func() {
+			testPOST(
+				adminToken,
+				nil,
+				"/employer/get-caldav-connection",
+				http.StatusNotFound,
+			)
+		})
+
+		It("should return the connection without the password", func() {
+			resp := testPOSTGetResp(
+				interviewerToken,
+				nil,
+				"/employer/get-caldav-connection",
+				http.StatusOK,
+			).([]byte)
+			Expect(string(resp)).ShouldNot(ContainSubstring("password"))
+
+			var connection employer.CalDAVConnection
+			err := json.Unmarshal(resp, &connection)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(connection.CalendarURL).Should(Equal(
+				"https://calendar.caldav.example/calendars/interviewer/work/",
+			))
+			Expect(connection.Username).Should(Equal("interviewer"))
+			Expect(connection.LastSyncedAt).ShouldNot(BeNil())
+			Expect(connection.LastSyncError).Should(Equal(
+				"caldav put returned status 507",
+			))
+		})
+	})
+
+	Describe("Connect CalDAV", func() {
+		It("should validate the request", func() {
+			valid := employer.ConnectCalDAVRequest{
+				CalendarURL: "https://calendar.caldav.example/calendars/admin/",
+				Username:    "admin",
+				Password:    "secret",
+			}
+
+			for _, mutate := range []func(*employer.ConnectCalDAVRequest){
+				func(req *employer.ConnectCalDAVRequest) {
+					req.CalendarURL = ""
+				},
+				func(req *employer.ConnectCalDAVRequest) {
+					req.CalendarURL = "not a url"
+				},
+				func(req *employer.ConnectCalDAVRequest) {
+					req.CalendarURL = "ftp://calendar.caldav.example/admin/"
+				},
+				func(req *employer.ConnectCalDAVRequest) {
+					req.Username = ""
+				},
+				func(req *employer.ConnectCalDAVRequest) {
+					req.Password = ""
+				},
+			} {
+				req := valid
+				mutate(&req)
+				testPOST(
+					adminToken,
+					req,
+					"/employer/connect-caldav",
+					http.StatusBadRequest,
+				)
+			}
+		})
+
+		It("should not connect an unreachable calendar", func() {
+			testPOST(
+				adminToken,
+				employer.ConnectCalDAVRequest{
+					CalendarURL: "http://radicale:1/calendars/admin/",
+					Username:    "admin",
+					Password:    "secret",
+				},
+				"/employer/connect-caldav",
+				http.StatusUnprocessableEntity,
+			)
+
+			testPOST(
+				adminToken,
+				nil,
+				"/employer/get-caldav-connection",
+				http.StatusNotFound,
+			)
+		})
+	})
+
+	Describe("Free/Busy", func() {
+		It("should not offer the slots that are busy in the calendar", func() {
+			starts := getSlotStarts()
+			for _, hour := range []time.Duration{9, 12, 16} {
+				Expect(starts).Should(ContainElement(BeTemporally(
+					"==",
+					busyDay.Add(hour*time.Hour),
+				)))
+			}
+			for _, minutes := range []time.Duration{570, 600, 630, 660, 690} {
+				Expect(starts).ShouldNot(ContainElement(BeTemporally(
+					"==",
+					busyDay.Add(minutes*time.Minute),
+				)))
+			}
+		})
+	})
+
+	Describe("Disconnect CalDAV", func() {
+		It("should forget the busy periods of the calendar", func() {
+			testPOST(
+				interviewerToken,
+				nil,
+				"/employer/disconnect-caldav",
+				http.StatusOK,
+			)
+			testPOST(
+				interviewerToken,
+				nil,
+				"/employer/get-caldav-connection",
+				http.StatusNotFound,
+			)
+			testPOST(
+				interviewerToken,
+				nil,
+				"/employer/disconnect-caldav",
+				http.StatusNotFound,
+			)
+
+			starts := getSlotStarts()
+			Expect(starts).Should(ContainElement(BeTemporally(
+				"==",
+				busyDay.Add(10*time.Hour),
+			)))
+		})
+	})
+})
@@ -0,0 +1,250 @@
+package dolores
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Meeting Providers", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, viewerToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0050-meeting-providers-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		employerSigninAsync(
+			"meeting.example",
+			"admin@meeting.example",
+			"NewPassword123$",
+			&adminToken,
+			&wg,
+		)
+		employerSigninAsync(
+			"meeting.example",
+			"interviewer@meeting.example",
+			"NewPassword123$",
+			&viewerToken,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0050-meeting-providers-down.pgsql")
+		db.Close()
+	})
+
+	getMeetingProvider := func() employer.MeetingProvider {
+		resp := testPOSTGetResp(
+			viewerToken,
+			nil,
+			"/employer/get-meeting-provider",
+			http.StatusOK,
+		).([]byte)
+		var setting employer.MeetingProviderSetting
+		err := json.Unmarshal(resp, &setting)
+		Expect(err).ShouldNot(HaveOccurred())
+		return setting.MeetingProvider
+	}
+
+	addInterview := func(req employer.AddInterviewRequest) string {
+		resp := testPOSTGetResp(
+			adminToken,
+			req,
+			"/employer/add-interview",
+			http.StatusOK,
+		).([]byte)
+		var addResp employer.AddInterviewResponse
+		err := json.Unmarshal(resp, &addResp)
+		Expect(err).ShouldNot(HaveOccurred())
+		return addResp.InterviewID
+	}
+
+	getInterview := func(interviewID string) employer.EmployerInterview {
+		resp := testPOSTGetResp(
+			adminToken,
+			employer.GetInterviewDetailsRequest{InterviewID: interviewID},
+			"/employer/get-interview-details",
+			http.StatusOK,
+		).([]byte)
+		var interview employer.EmployerInterview
+		err := json.Unmarshal(resp, &interview)
+		Expect(err).ShouldNot(HaveOccurred())
+		return interview
+	}
+
+	startTime := time.Now().UTC().Add(72 * time.Hour).Truncate(time.Hour)
+	endTime := startTime.Add(time.Hour)
+
+	Describe("Meeting provider setting", func() {
+		It("defaults to the manual provider", func() {
+			Expect(getMeetingProvider()).
+				Should(Equal(employer.ManualMeetingProvider))
+		})
+
+		It("validates the change", func() {
+			testPOST(
+				viewerToken,
+				employer.MeetingProviderSetting{
+					MeetingProvider: employer.JitsiMeetingProvider,
+				},
+				"/employer/change-meeting-provider",
+				common.ErrEmployerRBAC,
+			)
+
+			testPOST(
+				adminToken,
+				employer.MeetingProviderSetting{
+					MeetingProvider: "ZOOM",
+				},
+				"/employer/change-meeting-provider",
+				http.StatusBadRequest,
+			)
+
+			Expect(getMeetingProvider()).
+				Should(Equal(employer.ManualMeetingProvider))
+		})
+	})
+
+	Describe("Manual meeting provider", func() {
+		It("keeps the pasted meeting link", func() {
+			meetingURL := "https://video.meeting.example/room-1"
+			interviewID := addInterview(employer.AddInterviewRequest{
+				CandidacyID:   "CAND-0050-1",
+				StartTime:     startTime,
+				EndTime:       endTime,
+				InterviewType: common.VideoCallInterviewType,
+				MeetingURL:    meetingURL,
+			})
+
+			interview := getInterview(interviewID)
+			Expect(interview.MeetingURL).ShouldNot(BeNil())
+			Expect(*interview.MeetingURL).Should(Equal(meetingURL))
+
+			newMeetingURL := "https://video.meeting.example/room-2"
+			testPOST(
+				adminToken,
+				employer.RescheduleInterviewRequest{
+					InterviewID: interviewID,
+					StartTime:   startTime.Add(24 * time.Hour),
+					EndTime:     endTime.Add(24 * time.Hour),
+					MeetingURL:  &newMeetingURL,
+				},
+				"/employer/reschedule-interview",
+				http.StatusOK,
+			)
+
+			interview = getInterview(interviewID)
+			Expect(interview.MeetingURL).ShouldNot(BeNil())
+			Expect(*interview.MeetingURL).Should(Equal(newMeetingURL))
+		})
+
+		It("rejects a meeting link for other interview types", func() {
+			testPOST(
+				adminToken,
+				employer.AddInterviewRequest{
+					CandidacyID:   "CAND-0050-1",
+					StartTime:     startTime,
+					EndTime:       endTime,
+					InterviewType: common.InPersonInterviewType,
+					MeetingURL:    "https://video.meeting.example/room-3",
+				},
+				"/employer/add-interview",
+				http.StatusBadRequest,
+			)
+
+			interviewID := addInterview(employer.AddInterviewRequest{
+				CandidacyID:   "CAND-0050-1",
+				StartTime:     startTime,
+				EndTime:       endTime,
+				InterviewType: common.InPersonInterviewType,
+			})
+			Expect(getInterview(interviewID).MeetingURL).Should(BeNil())
+		})
+	})
+
+	Describe("Jitsi meeting provider", func() {
+		BeforeAll(func() {
+			testPOST(
+				adminToken,
+				employer.MeetingProviderSetting{
+					MeetingProvider: employer.JitsiMeetingProvider,
+				},
+				"/employer/change-meeting-provider",
+				http.StatusOK,
+			)
+			Expect(getMeetingProvider()).
+				Should(Equal(employer.JitsiMeetingProvider))
+		})
+
+		AfterAll(func() {
+			testPOST(
+				adminToken,
+				employer.MeetingProviderSetting{
+					MeetingProvider: employer.ManualMeetingProvider,
+				},
+				"/employer/change-meeting-provider",
+				http.StatusOK,
+			)
+			Expect(getMeetingProvider()).
+				Should(Equal(employer.ManualMeetingProvider))
+		})
+
+		It("generates a room that survives a reschedule", func() {
+			interviewID := addInterview(employer.AddInterviewRequest{
+				CandidacyID:   "CAND-0050-1",
+				StartTime:     startTime,
+				EndTime:       endTime,
+				InterviewType: common.VideoCallInterviewType,
+			})
+
+			interview := getInterview(interviewID)
+			Expect(interview.MeetingURL).ShouldNot(BeNil())
+			meetingURL := *interview.MeetingURL
+			Expect(meetingURL).Should(
+				HavePrefix("https://meet.jit.si/Vetchium-"),
+			)
+
+			testPOST(
+				adminToken,
+				employer.RescheduleInterviewRequest{
+					InterviewID: interviewID,
+					StartTime:   startTime.Add(24 * time.Hour),
+					EndTime:     endTime.Add(24 * time.Hour),
+				},
+				"/employer/reschedule-interview",
+				http.StatusOK,
+			)
+
+			interview = getInterview(interviewID)
+			Expect(interview.MeetingURL).ShouldNot(BeNil())
+			Expect(*interview.MeetingURL).Should(Equal(meetingURL))
+		})
+
+		It("rejects a pasted meeting link", func() {
+			testPOST(
+				adminToken,
+				employer.AddInterviewRequest{
+					CandidacyID:   "CAND-0050-1",
+					StartTime:     startTime,
+					EndTime:       endTime,
+					InterviewType: common.VideoCallInterviewType,
+					MeetingURL:    "https://video.meeting.example/room-4",
+				},
+				"/employer/add-interview",
+				http.StatusBadRequest,
+			)
+		})
+	})
+})
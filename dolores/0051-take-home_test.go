@@ -0,0 +1,508 @@
+package dolores
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// takeHomeZIP returns a base64 encoded ZIP archive with the named entries
+func takeHomeZIP(names ...string) string {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := zw.Create(name)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = f.Write([]byte("package main\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+	Expect(zw.Close()).Should(Succeed())
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+var _ = Describe("Take-home assignments", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, interviewerToken, hubToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0051-take-home-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		employerSigninAsync(
+			"takehome.example",
+			"admin@takehome.example",
+			"NewPassword123$",
+			&adminToken,
+			&wg,
+		)
+		employerSigninAsync(
+			"takehome.example",
+			"interviewer@takehome.example",
+			"NewPassword123$",
+			&interviewerToken,
+			&wg,
+		)
+		hubSigninAsync(
+			"candidate@takehome-hub.example",
+			"NewPassword123$",
+			&hubToken,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0051-take-home-down.pgsql")
+		db.Close()
+	})
+
+	getHubAssignment := func(interviewID string) common.TakeHomeAssignment {
+		resp := testPOSTGetResp(
+			hubToken,
+			common.GetTakeHomeAssignmentRequest{InterviewID: interviewID},
+			"/hub/get-take-home-assignment",
+			http.StatusOK,
+		).([]byte)
+		var assignment common.TakeHomeAssignment
+		err := json.Unmarshal(resp, &assignment)
+		Expect(err).ShouldNot(HaveOccurred())
+		return assignment
+	}
+
+	getEmployerAssignment := func(
+		interviewID string,
+	) common.TakeHomeAssignment {
+		resp := testPOSTGetResp(
+			interviewerToken,
+			common.GetTakeHomeAssignmentRequest{InterviewID: interviewID},
+			"/employer/get-take-home-assignment",
+			http.StatusOK,
+		).([]byte)
+		var assignment common.TakeHomeAssignment
+		err := json.Unmarshal(resp, &assignment)
+		Expect(err).ShouldNot(HaveOccurred())
+		return assignment
+	}
+
+	submit := func(
+		interviewID string,
+		document string,
+	) common.TakeHomeSubmission {
+		resp := testPOSTGetResp(
+			hubToken,
+			hub.SubmitTakeHomeAssignmentRequest{
+				InterviewID: interviewID,
+				Submission: common.TakeHomeFile{
+					Filename: "solution.zip",
+					Document: document,
+				},
+			},
+			"/hub/submit-take-home-assignment",
+			http.StatusOK,
+		).([]byte)
+		var submission common.TakeHomeSubmission
+		err := json.Unmarshal(resp, &submission)
+		Expect(err).ShouldNot(HaveOccurred())
+		return submission
+	}
+
+	Describe("Set take-home assignment", func() {
+		type setTestCase struct {
+			description string
+			token       string
+			request     employer.SetTakeHomeAssignmentRequest
+			wantStatus  int
+		}
+
+		It("validates the assignment", func() {
+			testCases := []setTestCase{
+				{
+					description: "without the required role",
+					token:       interviewerToken,
+					request: employer.SetTakeHomeAssignmentRequest{
+						InterviewID:          "INT-0051-1",
+						Brief:                "Build a URL shortener",
+						LateSubmissionPolicy: common.RejectLateSubmissions,
+					},
+					wantStatus: common.ErrEmployerRBAC,
+				},
+				{
+					description: "with an invalid late submission policy",
+					token:       adminToken,
+					request: employer.SetTakeHomeAssignmentRequest{
+						InterviewID:          "INT-0051-1",
+						Brief:                "Build a URL shortener",
+						LateSubmissionPolicy: "IGNORE_LATE",
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with a file that is neither PDF nor ZIP",
+					token:       adminToken,
+					request: employer.SetTakeHomeAssignmentRequest{
+						InterviewID: "INT-0051-1",
+						Brief:       "Build a URL shortener",
+						Files: []common.TakeHomeFile{
+							{Filename: "brief.txt", Document: "aGVsbG8="},
+						},
+						LateSubmissionPolicy: common.RejectLateSubmissions,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "with an archive that escapes its directory",
+					token:       adminToken,
+					request: employer.SetTakeHomeAssignmentRequest{
+						InterviewID: "INT-0051-1",
+						Brief:       "Build a URL shortener",
+						Files: []common.TakeHomeFile{
+							{
+								Filename: "starter.zip",
+								Document: takeHomeZIP("../evil.go"),
+							},
+						},
+						LateSubmissionPolicy: common.RejectLateSubmissions,
+					},
+					wantStatus: http.StatusBadRequest,
+				},
+				{
+					description: "for an interview that is not a take-home",
+					token:       adminToken,
+					request: employer.SetTakeHomeAssignmentRequest{
+						InterviewID:          "INT-0051-3",
+						Brief:                "Build a URL shortener",
+						LateSubmissionPolicy: common.RejectLateSubmissions,
+					},
+					wantStatus: http.StatusUnprocessableEntity,
+				},
+				{
+					description: "for a non-existent interview",
+					token:       adminToken,
+					request: employer.SetTakeHomeAssignmentRequest{
+						InterviewID:          "INT-0051-404",
+						Brief:                "Build a URL shortener",
+						LateSubmissionPolicy: common.RejectLateSubmissions,
+					},
+					wantStatus: http.StatusNotFound,
+				},
+			}
+
+			for _, tc := range testCases {
+				fmt.Fprintf(GinkgoWriter, "### %s\n", tc.description)
+				testPOST(
+					tc.token,
+					tc.request,
+					"/employer/set-take-home-assignment",
+					tc.wantStatus,
+				)
+			}
+
+			testPOST(
+				interviewerToken,
+				common.GetTakeHomeAssignmentRequest{InterviewID: "INT-0051-1"},
+				"/employer/get-take-home-assignment",
+				http.StatusNotFound,
+			)
+		})
+
+		It("sets the assignment with its files", func() {
+			testPOST(
+				adminToken,
+				employer.SetTakeHomeAssignmentRequest{
+					InterviewID: "INT-0051-1",
+					Brief:       "Build a URL shortener",
+					Files: []common.TakeHomeFile{
+						{Filename: "brief.pdf", Document: offerAttachmentPDF},
+						{
+							Filename: "starter.zip",
+							Document: takeHomeZIP("main.go", "go.mod"),
+						},
+					},
+					LateSubmissionPolicy: common.RejectLateSubmissions,
+				},
+				"/employer/set-take-home-assignment",
+				http.StatusOK,
+			)
+
+			assignment := getEmployerAssignment("INT-0051-1")
+			Expect(assignment.Brief).ShouldNot(BeNil())
+			Expect(*assignment.Brief).Should(Equal("Build a URL shortener"))
+			Expect(assignment.Files).Should(HaveLen(2))
+			Expect(assignment.Files[0].Filename).Should(Equal("brief.pdf"))
+			Expect(assignment.Files[0].ContentType).
+				Should(Equal("application/pdf"))
+			Expect(assignment.Files[1].ContentType).
+				Should(Equal("application/zip"))
+			Expect(assignment.Submissions).Should(BeEmpty())
+
+			resp := testPOSTGetResp(
+				interviewerToken,
+				common.GetTakeHomeFileRequest{
+					InterviewID: "INT-0051-1",
+					FileNumber:  1,
+				},
+				"/employer/get-take-home-file",
+				http.StatusOK,
+			).([]byte)
+			Expect(string(resp)).Should(HavePrefix("%PDF-"))
+		})
+	})
+
+	Describe("Candidate view of the assignment", func() {
+		It("shows an open assignment", func() {
+			assignment := getHubAssignment("INT-0051-1")
+			Expect(assignment.Brief).ShouldNot(BeNil())
+			Expect(assignment.Files).Should(HaveLen(2))
+
+			resp := testPOSTGetResp(
+				hubToken,
+				common.GetTakeHomeFileRequest{
+					InterviewID: "INT-0051-1",
+					FileNumber:  2,
+				},
+				"/hub/get-take-home-file",
+				http.StatusOK,
+			).([]byte)
+			Expect(string(resp)).Should(HavePrefix("PK"))
+		})
+
+		It("hides an assignment that is not available yet", func() {
+			testPOST(
+				adminToken,
+				employer.SetTakeHomeAssignmentRequest{
+					InterviewID: "INT-0051-2",
+					Brief:       "Build a rate limiter",
+					Files: []common.TakeHomeFile{
+						{Filename: "brief.pdf", Document: offerAttachmentPDF},
+					},
+					LateSubmissionPolicy: common.AcceptLateSubmissions,
+				},
+				"/employer/set-take-home-assignment",
+				http.StatusOK,
+			)
+
+			assignment := getHubAssignment("INT-0051-2")
+			Expect(assignment.Brief).Should(BeNil())
+			Expect(assignment.Files).Should(BeEmpty())
+
+			testPOST(
+				hubToken,
+				common.GetTakeHomeFileRequest{
+					InterviewID: "INT-0051-2",
+					FileNumber:  1,
+				},
+				"/hub/get-take-home-file",
+				http.StatusNotFound,
+			)
+
+			testPOST(
+				hubToken,
+				hub.SubmitTakeHomeAssignmentRequest{
+					InterviewID: "INT-0051-2",
+					Submission: common.TakeHomeFile{
+						Filename: "solution.zip",
+						Document: takeHomeZIP("main.go"),
+					},
+				},
+				"/hub/submit-take-home-assignment",
+				http.StatusUnprocessableEntity,
+			)
+		})
+	})
+
+	Describe("Submissions", func() {
+		It("accepts a submission before the deadline", func() {
+			testPOST(
+				hubToken,
+				hub.SubmitTakeHomeAssignmentRequest{
+					InterviewID: "INT-0051-1",
+					Submission: common.TakeHomeFile{
+						Filename: "solution.txt",
+						Document: "aGVsbG8=",
+					},
+				},
+				"/hub/submit-take-home-assignment",
+				http.StatusBadRequest,
+			)
+
+			submission := submit("INT-0051-1", takeHomeZIP("main.go"))
+			Expect(submission.SubmissionNumber).Should(Equal(1))
+			Expect(submission.IsLate).Should(BeFalse())
+			Expect(submission.ContentType).Should(Equal("application/zip"))
+
+			assignment := getEmployerAssignment("INT-0051-1")
+			Expect(assignment.Submissions).Should(HaveLen(1))
+
+			resp := testPOSTGetResp(
+				interviewerToken,
+				common.GetTakeHomeSubmissionRequest{
+					InterviewID:      "INT-0051-1",
+					SubmissionNumber: 1,
+				},
+				"/employer/get-take-home-submission",
+				http.StatusOK,
+			).([]byte)
+			Expect(string(resp)).Should(HavePrefix("PK"))
+
+			testPOST(
+				hubToken,
+				common.GetTakeHomeSubmissionRequest{
+					InterviewID:      "INT-0051-1",
+					SubmissionNumber: 2,
+				},
+				"/hub/get-take-home-submission",
+				http.StatusNotFound,
+			)
+
+			// The assignment cannot be changed once submitted
+			testPOST(
+				adminToken,
+				employer.SetTakeHomeAssignmentRequest{
+					InterviewID:          "INT-0051-1",
+					Brief:                "Build a URL shortener in Rust",
+					LateSubmissionPolicy: common.RejectLateSubmissions,
+				},
+				"/employer/set-take-home-assignment",
+				http.StatusUnprocessableEntity,
+			)
+		})
+
+		It("applies the late submission policy", func() {
+			testPOST(
+				adminToken,
+				employer.SetTakeHomeAssignmentRequest{
+					InterviewID:          "INT-0051-4",
+					Brief:                "Build a cache",
+					LateSubmissionPolicy: common.RejectLateSubmissions,
+					GracePeriodMinutes:   30,
+				},
+				"/employer/set-take-home-assignment",
+				http.StatusOK,
+			)
+
+			testPOST(
+				hubToken,
+				hub.SubmitTakeHomeAssignmentRequest{
+					InterviewID: "INT-0051-4",
+					Submission: common.TakeHomeFile{
+						Filename: "solution.zip",
+						Document: takeHomeZIP("main.go"),
+					},
+				},
+				"/hub/submit-take-home-assignment",
+				http.StatusUnprocessableEntity,
+			)
+
+			testPOST(
+				adminToken,
+				employer.SetTakeHomeAssignmentRequest{
+					InterviewID:          "INT-0051-4",
+					Brief:                "Build a cache",
+					LateSubmissionPolicy: common.AcceptLateSubmissions,
+				},
+				"/employer/set-take-home-assignment",
+				http.StatusOK,
+			)
+
+			submission := submit("INT-0051-4", takeHomeZIP("cache.go"))
+			Expect(submission.IsLate).Should(BeTrue())
+		})
+	})
+
+	Describe("Time-boxed assignment", func() {
+		It("starts the clock on request", func() {
+			timeLimit := 60
+			testPOST(
+				adminToken,
+				employer.SetTakeHomeAssignmentRequest{
+					InterviewID: "INT-0051-5",
+					Brief:       "Build a queue",
+					Files: []common.TakeHomeFile{
+						{Filename: "brief.pdf", Document: offerAttachmentPDF},
+					},
+					TimeLimitMinutes:     &timeLimit,
+					LateSubmissionPolicy: common.RejectLateSubmissions,
+				},
+				"/employer/set-take-home-assignment",
+				http.StatusOK,
+			)
+
+			assignment := getHubAssignment("INT-0051-5")
+			Expect(assignment.Brief).Should(BeNil())
+			Expect(assignment.StartedAt).Should(BeNil())
+
+			testPOST(
+				hubToken,
+				hub.SubmitTakeHomeAssignmentRequest{
+					InterviewID: "INT-0051-5",
+					Submission: common.TakeHomeFile{
+						Filename: "solution.zip",
+						Document: takeHomeZIP("queue.go"),
+					},
+				},
+				"/hub/submit-take-home-assignment",
+				http.StatusUnprocessableEntity,
+			)
+
+			// Only the time-boxed assignments can be started
+			testPOST(
+				hubToken,
+				hub.StartTakeHomeAssignmentRequest{InterviewID: "INT-0051-1"},
+				"/hub/start-take-home-assignment",
+				http.StatusUnprocessableEntity,
+			)
+
+			resp := testPOSTGetResp(
+				hubToken,
+				hub.StartTakeHomeAssignmentRequest{InterviewID: "INT-0051-5"},
+				"/hub/start-take-home-assignment",
+				http.StatusOK,
+			).([]byte)
+			err := json.Unmarshal(resp, &assignment)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(assignment.Brief).ShouldNot(BeNil())
+			Expect(assignment.Files).Should(HaveLen(1))
+			Expect(assignment.StartedAt).ShouldNot(BeNil())
+			Expect(assignment.Deadline).Should(BeTemporally(
+				"~",
+				assignment.StartedAt.Add(time.Hour),
+				time.Second,
+			))
+
+			testPOST(
+				hubToken,
+				hub.StartTakeHomeAssignmentRequest{InterviewID: "INT-0051-5"},
+				"/hub/start-take-home-assignment",
+				http.StatusUnprocessableEntity,
+			)
+
+			testPOST(
+				adminToken,
+				employer.SetTakeHomeAssignmentRequest{
+					InterviewID:          "INT-0051-5",
+					Brief:                "Build a stack",
+					LateSubmissionPolicy: common.RejectLateSubmissions,
+				},
+				"/employer/set-take-home-assignment",
+				http.StatusUnprocessableEntity,
+			)
+
+			submission := submit("INT-0051-5", takeHomeZIP("queue.go"))
+			Expect(submission.IsLate).Should(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,362 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Candidacy Events", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, viewerToken string
+	var hub1Token, hub3Token string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0052-candidacy-events-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@candidacy-events.example":  &adminToken,
+			"crud@candidacy-events.example":   &crudToken,
+			"viewer@candidacy-events.example": &viewerToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"candidacy-events.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+
+		hubTokens := map[string]*string{
+			"hub1@candidacy-events-hub.example": &hub1Token,
+			"hub3@candidacy-events-hub.example": &hub3Token,
+		}
+		for email, token := range hubTokens {
+			wg.Add(1)
+			hubSigninAsync(email, "NewPassword123$", token, &wg)
+		}
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0052-candidacy-events-down.pgsql")
+		db.Close()
+	})
+
+	getEmployerTimeline := func(candidacyID string) []common.CandidacyEvent {
+		resp := testPOSTGetResp(
+			viewerToken,
+			common.GetCandidacyInfoRequest{CandidacyID: candidacyID},
+			"/employer/get-candidacy-info",
+			http.StatusOK,
+		).([]byte)
+		var candidacy employer.Candidacy
+		err := json.Unmarshal(resp, &candidacy)
+		Expect(err).ShouldNot(HaveOccurred())
+		return candidacy.Timeline
+	}
+
+	getHubTimeline := func(
+		token, candidacyID string,
+	) []common.CandidacyEvent {
+		resp := testPOSTGetResp(
+			token,
+			common.GetCandidacyInfoRequest{CandidacyID: candidacyID},
+			"/hub/get-candidacy-info",
+			http.StatusOK,
+		).([]byte)
+		var candidacy hub.MyCandidacy
+		err := json.Unmarshal(resp, &candidacy)
+		Expect(err).ShouldNot(HaveOccurred())
+		return candidacy.Timeline
+	}
+
+	offer := func(token, candidacyID string, wantStatus int) {
+		testPOST(
+			token,
+			employer.OfferToCandidateRequest{
+				CandidacyID: candidacyID,
+				Compensation: common.OfferCompensation{
+					Amount:   2000000,
+					Currency: "INR",
+				},
+				StartDate: "2025-01-01",
+				ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+			},
+			"/employer/offer-to-candidate",
+			wantStatus,
+		)
+	}
+
+	expectEvent := func(
+		event common.CandidacyEvent,
+		from *common.CandidacyState,
+		to common.CandidacyState,
+		actorType common.CandidacyActorType,
+	) {
+		if from == nil {
+			Expect(event.FromState).Should(BeNil())
+		} else {
+			Expect(event.FromState).ShouldNot(BeNil())
+			Expect(*event.FromState).Should(Equal(*from))
+		}
+		Expect(event.ToState).Should(Equal(to))
+		Expect(event.ActorType).Should(Equal(actorType))
+	}
+
+	interviewing := common.InterviewingCandidacyState
+	offered := common.OfferedCandidacyState
+
+	Describe("State Changes", func() {
+		It("should record the creation of a candidacy", func() {
+			testPOST(
+				adminToken,
+				employer.ShortlistApplicationRequest{
+					ApplicationID: "APP-0052-3",
+				},
+				"/employer/shortlist-application",
+				http.StatusOK,
+			)
+
+			var candidacyID string
+			err := db.QueryRow(
+				context.Background(),
+				"SELECT id FROM candidacies WHERE application_id = $1",
+				"APP-0052-3",
+			).Scan(&candidacyID)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			timeline := getEmployerTimeline(candidacyID)
+			Expect(timeline).Should(HaveLen(1))
+			expectEvent(
+				timeline[0],
+				nil,
+				interviewing,
+				common.OrgUserCandidacyActor,
+			)
+			Expect(timeline[0].ActorName).ShouldNot(BeNil())
+			Expect(*timeline[0].ActorName).Should(Equal("Admin User"))
+
+			timeline = getHubTimeline(hub3Token, candidacyID)
+			Expect(timeline).Should(HaveLen(1))
+			Expect(timeline[0].ActorName).Should(BeNil())
+		})
+
+		It("should allow only the legal state changes", func() {
+			// No events for the seeded candidacy yet
+			Expect(getEmployerTimeline("CAND-0052-1")).Should(BeEmpty())
+
+			offer(viewerToken, "CAND-0052-1", common.ErrEmployerRBAC)
+			offer(crudToken, "CAND-0052-1", http.StatusOK)
+
+			// OFFERED to OFFERED is not a legal change
+			offer(crudToken, "CAND-0052-1", http.StatusNotFound)
+
+			testPOST(
+				crudToken,
+				employer.RescindOfferRequest{
+					CandidacyID: "CAND-0052-1",
+					Reason:      "Budget freeze",
+				},
+				"/employer/rescind-offer",
+				http.StatusOK,
+			)
+
+			offer(crudToken, "CAND-0052-1", http.StatusOK)
+
+			testPOST(
+				hub1Token,
+				hub.RespondToOfferRequest{
+					CandidacyID: "CAND-0052-1",
+					Version:     2,
+					Response:    hub.DeclineOffer,
+					Reason:      strptr("Accepted another offer"),
+				},
+				"/hub/respond-to-offer",
+				http.StatusOK,
+			)
+
+			// The declined offer can not be rescinded anymore
+			testPOST(
+				crudToken,
+				employer.RescindOfferRequest{
+					CandidacyID: "CAND-0052-1",
+					Reason:      "Budget freeze",
+				},
+				"/employer/rescind-offer",
+				http.StatusNotFound,
+			)
+		})
+
+		It("should expose the timeline to the employer", func() {
+			timeline := getEmployerTimeline("CAND-0052-1")
+			Expect(timeline).Should(HaveLen(4))
+
+			expectEvent(
+				timeline[0],
+				&interviewing,
+				offered,
+				common.OrgUserCandidacyActor,
+			)
+			Expect(timeline[0].ActorName).ShouldNot(BeNil())
+			Expect(*timeline[0].ActorName).
+				Should(Equal("Applications CRUD User"))
+			Expect(timeline[0].Reason).Should(BeNil())
+
+			expectEvent(
+				timeline[1],
+				&offered,
+				interviewing,
+				common.OrgUserCandidacyActor,
+			)
+			Expect(timeline[1].Reason).ShouldNot(BeNil())
+			Expect(*timeline[1].Reason).Should(Equal("Budget freeze"))
+
+			expectEvent(
+				timeline[2],
+				&interviewing,
+				offered,
+				common.OrgUserCandidacyActor,
+			)
+
+			expectEvent(
+				timeline[3],
+				&offered,
+				common.OfferDeclinedCandidacyState,
+				common.HubUserCandidacyActor,
+			)
+			Expect(timeline[3].ActorName).ShouldNot(BeNil())
+			Expect(*timeline[3].ActorName).Should(Equal("Events Hub User 1"))
+			Expect(timeline[3].Reason).ShouldNot(BeNil())
+			Expect(*timeline[3].Reason).
+				Should(Equal("Accepted another offer"))
+
+			for i := 1; i < len(timeline); i++ {
+				Expect(timeline[i].CreatedAt).
+					Should(BeTemporally(">=", timeline[i-1].CreatedAt))
+			}
+		})
+
+		It("should hide the org users from the candidate", func() {
+			timeline := getHubTimeline(hub1Token, "CAND-0052-1")
+			Expect(timeline).Should(HaveLen(4))
+
+			for _, event := range timeline[:3] {
+				Expect(event.ActorType).
+					Should(Equal(common.OrgUserCandidacyActor))
+				Expect(event.ActorName).Should(BeNil())
+			}
+			Expect(timeline[1].Reason).ShouldNot(BeNil())
+
+			Expect(timeline[3].ActorName).ShouldNot(BeNil())
+			Expect(*timeline[3].ActorName).Should(Equal("Events Hub User 1"))
+		})
+
+		It("should not expose the timeline of others", func() {
+			testPOST(
+				hub3Token,
+				common.GetCandidacyInfoRequest{CandidacyID: "CAND-0052-1"},
+				"/hub/get-candidacy-info",
+				http.StatusNotFound,
+			)
+		})
+	})
+
+	Describe("Hooks", func() {
+		countNotifications := func(subject string, emailTo string) int {
+			var notifications int
+			err := db.QueryRow(
+				context.Background(),
+				`
+SELECT COUNT(*)
+FROM emails
+WHERE $1 = ANY(email_to)
+    AND email_subject LIKE $2
+`,
+				emailTo,
+				subject,
+			).Scan(&notifications)
+			Expect(err).ShouldNot(HaveOccurred())
+			return notifications
+		}
+
+		admin := "admin@candidacy-events.example"
+		crud := "crud@candidacy-events.example"
+		viewer := "viewer@candidacy-events.example"
+
+		It("should record and dispatch the system changes", func() {
+			// granger expires the offers every minute
+			Eventually(func(g Gomega) {
+				timeline := getEmployerTimeline("CAND-0052-2")
+				g.Expect(timeline).Should(HaveLen(1))
+				g.Expect(timeline[0].ToState).
+					Should(Equal(common.CandidateNotRespondingCandidacyState))
+				g.Expect(timeline[0].ActorType).
+					Should(Equal(common.SystemCandidacyActor))
+				g.Expect(timeline[0].ActorName).Should(BeNil())
+			}).WithTimeout(3 * time.Minute).
+				WithPolling(5 * time.Second).
+				Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				var undispatched int
+				err := db.QueryRow(
+					context.Background(),
+					`
+SELECT COUNT(*)
+FROM candidacy_events
+WHERE employer_id = $1
+    AND dispatched_at IS NULL
+`,
+					"12345678-0052-0052-0052-000000000201",
+				).Scan(&undispatched)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(undispatched).Should(BeZero())
+			}).WithTimeout(time.Minute).
+				WithPolling(5 * time.Second).
+				Should(Succeed())
+
+			// The expiry of the offer by the system
+			expired := "Candidacy of Events Hub User 2 for % is now " +
+				string(common.CandidateNotRespondingCandidacyState)
+			Expect(countNotifications(expired, admin)).Should(Equal(1))
+			Expect(countNotifications(expired, crud)).Should(Equal(1))
+		})
+
+		It("should dispatch the changes made by the users", func() {
+			// The two offers and the rescind by the recruiter, and the
+			// decline by the candidate
+			candidate := "Candidacy of Events Hub User 1 for %"
+			Expect(countNotifications(candidate, admin)).Should(Equal(4))
+
+			// The recruiter is not notified of their own changes
+			Expect(countNotifications(candidate, crud)).Should(Equal(1))
+			declined := "Candidacy of Events Hub User 1 for % is now " +
+				string(common.OfferDeclinedCandidacyState)
+			Expect(countNotifications(declined, crud)).Should(Equal(1))
+
+			// Neither watching the opening nor hiring for it
+			Expect(countNotifications(candidate, viewer)).Should(BeZero())
+
+			// The creation of a candidacy is not notified
+			Expect(countNotifications(
+				"Candidacy of Events Hub User 3 for %",
+				admin,
+			)).Should(BeZero())
+		})
+	})
+})
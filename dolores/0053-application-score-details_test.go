@@ -0,0 +1,151 @@
+package dolores
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Application Score Details", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken, viewerToken, openingsToken string
+	var otherAdminToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0053-application-score-details-up.pgsql")
+
+		var wg sync.WaitGroup
+		tokens := map[string]*string{
+			"admin@score-details.example":    &adminToken,
+			"crud@score-details.example":     &crudToken,
+			"viewer@score-details.example":   &viewerToken,
+			"openings@score-details.example": &openingsToken,
+		}
+		for email, token := range tokens {
+			wg.Add(1)
+			employerSigninAsync(
+				"score-details.example",
+				email,
+				"NewPassword123$",
+				token,
+				&wg,
+			)
+		}
+
+		wg.Add(1)
+		employerSigninAsync(
+			"other-score-details.example",
+			"admin@other-score-details.example",
+			"NewPassword123$",
+			&otherAdminToken,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0053-application-score-details-down.pgsql")
+		db.Close()
+	})
+
+	getScoreDetails := func(
+		token, applicationID string,
+	) []employer.ApplicationScoreDetails {
+		resp := testPOSTGetResp(
+			token,
+			employer.GetApplicationScoreDetailsRequest{
+				ApplicationID: applicationID,
+			},
+			"/employer/get-application-score-details",
+			http.StatusOK,
+		).([]byte)
+		var scores []employer.ApplicationScoreDetails
+		err := json.Unmarshal(resp, &scores)
+		Expect(err).ShouldNot(HaveOccurred())
+		return scores
+	}
+
+	It("should explain the scores to the hiring team", func() {
+		for _, token := range []string{adminToken, crudToken, viewerToken} {
+			scores := getScoreDetails(token, "APP-0053-1")
+			Expect(scores).Should(HaveLen(2))
+
+			// Ordered by the model names
+			Expect(scores[0].ModelName).Should(Equal("Microsoft-E5-Research"))
+			Expect(scores[0].Score).Should(Equal(48))
+			Expect(scores[0].Explanation).Should(BeNil())
+			Expect(scores[0].ScoredAt).ShouldNot(BeZero())
+
+			Expect(scores[1].ModelName).Should(Equal("Vetchium-Lexical-BM25"))
+			Expect(scores[1].Score).Should(Equal(62))
+			explanation := scores[1].Explanation
+			Expect(explanation).ShouldNot(BeNil())
+			Expect(explanation.MatchedTerms).
+				Should(Equal([]string{"go", "kubernetes"}))
+			Expect(explanation.MissingTerms).
+				Should(Equal([]string{"terraform"}))
+			Expect(explanation.SectionScores).Should(Equal(
+				[]common.SectionScore{
+					{Section: "experience", Score: 70},
+					{Section: "skills", Score: 55},
+				},
+			))
+			Expect(explanation.YoeMatch).ShouldNot(BeNil())
+			Expect(explanation.YoeMatch.ResumeYoe).ShouldNot(BeNil())
+			Expect(*explanation.YoeMatch.ResumeYoe).Should(Equal(3))
+			Expect(explanation.YoeMatch.YoeMin).Should(Equal(2))
+			Expect(explanation.YoeMatch.YoeMax).Should(Equal(5))
+			Expect(explanation.YoeMatch.Fit).
+				Should(Equal(common.WithinYoeFit))
+		}
+	})
+
+	It("should return no scores for the unscored applications", func() {
+		Expect(getScoreDetails(viewerToken, "APP-0053-2")).Should(BeEmpty())
+	})
+
+	It("should not return the scores of the other employers", func() {
+		testPOST(
+			otherAdminToken,
+			employer.GetApplicationScoreDetailsRequest{
+				ApplicationID: "APP-0053-1",
+			},
+			"/employer/get-application-score-details",
+			http.StatusNotFound,
+		)
+
+		testPOST(
+			adminToken,
+			employer.GetApplicationScoreDetailsRequest{
+				ApplicationID: "APP-0053-NONEXISTENT",
+			},
+			"/employer/get-application-score-details",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should allow only the applications roles", func() {
+		testPOST(
+			openingsToken,
+			employer.GetApplicationScoreDetailsRequest{
+				ApplicationID: "APP-0053-1",
+			},
+			"/employer/get-application-score-details",
+			common.ErrEmployerRBAC,
+		)
+
+		testPOST(
+			adminToken,
+			employer.GetApplicationScoreDetailsRequest{},
+			"/employer/get-application-score-details",
+			http.StatusBadRequest,
+		)
+	})
+})
@@ -0,0 +1,131 @@
+package dolores
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+)
+
+var _ = Describe("Match Score Weights", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, crudToken string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0054-match-score-weights-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		employerSigninAsync(
+			"match-weights.example",
+			"admin@match-weights.example",
+			"NewPassword123$",
+			&adminToken,
+			&wg,
+		)
+		employerSigninAsync(
+			"match-weights.example",
+			"crud@match-weights.example",
+			"NewPassword123$",
+			&crudToken,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0054-match-score-weights-down.pgsql")
+		db.Close()
+	})
+
+	getWeights := func() employer.MatchScoreWeights {
+		resp := testPOSTGetResp(
+			adminToken,
+			nil,
+			"/employer/get-match-score-weights",
+			http.StatusOK,
+		).([]byte)
+		var weights employer.MatchScoreWeights
+		err := json.Unmarshal(resp, &weights)
+		Expect(err).ShouldNot(HaveOccurred())
+		return weights
+	}
+
+	defaults := employer.MatchScoreWeights{
+		Resume:              50,
+		LongBio:             20,
+		Certifications:      15,
+		Education:           5,
+		VerifiedWorkHistory: 10,
+	}
+
+	It("should have the default weights", func() {
+		Expect(getWeights()).Should(Equal(defaults))
+	})
+
+	It("should allow only the admins", func() {
+		testPOST(
+			crudToken,
+			nil,
+			"/employer/get-match-score-weights",
+			common.ErrEmployerRBAC,
+		)
+
+		testPOST(
+			crudToken,
+			employer.MatchScoreWeights{Resume: 100},
+			"/employer/change-match-score-weights",
+			common.ErrEmployerRBAC,
+		)
+
+		Expect(getWeights()).Should(Equal(defaults))
+	})
+
+	It("should validate the weights", func() {
+		testPOST(
+			adminToken,
+			employer.MatchScoreWeights{},
+			"/employer/change-match-score-weights",
+			http.StatusBadRequest,
+		)
+
+		testPOST(
+			adminToken,
+			employer.MatchScoreWeights{Resume: 101},
+			"/employer/change-match-score-weights",
+			http.StatusBadRequest,
+		)
+
+		testPOST(
+			adminToken,
+			employer.MatchScoreWeights{Resume: 50, LongBio: -1},
+			"/employer/change-match-score-weights",
+			http.StatusBadRequest,
+		)
+
+		Expect(getWeights()).Should(Equal(defaults))
+	})
+
+	It("should change the weights", func() {
+		weights := employer.MatchScoreWeights{
+			Resume:              30,
+			LongBio:             30,
+			Certifications:      20,
+			Education:           0,
+			VerifiedWorkHistory: 20,
+		}
+		testPOST(
+			adminToken,
+			weights,
+			"/employer/change-match-score-weights",
+			http.StatusOK,
+		)
+		Expect(getWeights()).Should(Equal(weights))
+	})
+})
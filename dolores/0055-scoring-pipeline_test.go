@@ -0,0 +1,179 @@
+package dolores
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scoring Pipeline", Ordered, func() {
+	var db *pgxpool.Pool
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0055-scoring-pipeline-up.pgsql")
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0055-scoring-pipeline-down.pgsql")
+		db.Close()
+	})
+
+	exec := func(query string, args ...any) {
+		_, err := db.Exec(context.Background(), query, args...)
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	isStale := func(applicationID, modelName string) bool {
+		var stale bool
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT stale
+FROM application_scores
+WHERE application_id = $1 AND model_name = $2
+`,
+			applicationID,
+			modelName,
+		).Scan(&stale)
+		Expect(err).ShouldNot(HaveOccurred())
+		return stale
+	}
+
+	isDeadLettered := func(applicationID, modelName string) bool {
+		var count int
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT COUNT(*)
+FROM dead_lettered_application_scorings
+WHERE application_id = $1 AND model_name = $2
+`,
+			applicationID,
+			modelName,
+		).Scan(&count)
+		Expect(err).ShouldNot(HaveOccurred())
+		return count > 0
+	}
+
+	deadLetter := func(applicationID, modelName string) {
+		exec(`
+INSERT INTO application_scoring_state
+	(application_id, model_name, attempts, dead_lettered_at)
+VALUES ($1, $2, 6, timezone('UTC', now()))
+ON CONFLICT (application_id, model_name) DO UPDATE
+SET attempts = 6, dead_lettered_at = timezone('UTC', now())
+`, applicationID, modelName)
+	}
+
+	lexical := "Vetchium-Lexical-BM25"
+	sortinghat := "Microsoft-E5-Research"
+	activated := "Dolores-0055-Model"
+
+	It("should show the dead letters to the operators", func() {
+		var employerID, openingID, lastError string
+		var attempts int
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT employer_id, opening_id, attempts, last_error
+FROM dead_lettered_application_scorings
+WHERE application_id = $1 AND model_name = $2
+`,
+			"APP-0055-1",
+			sortinghat,
+		).Scan(&employerID, &openingID, &attempts, &lastError)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(employerID).
+			Should(Equal("12345678-0055-0055-0055-000000000201"))
+		Expect(openingID).Should(Equal("2024-Jul-01-1"))
+		Expect(attempts).Should(Equal(6))
+		Expect(lastError).Should(Equal("sortinghat returned status 500"))
+	})
+
+	It("should not rescore when the JD is not changed", func() {
+		exec(`
+UPDATE openings
+SET title = 'Senior Backend Engineer'
+WHERE id = '2024-Jul-01-1'
+`)
+		Expect(isStale("APP-0055-1", lexical)).Should(BeFalse())
+		Expect(isDeadLettered("APP-0055-1", sortinghat)).Should(BeTrue())
+	})
+
+	It("should rescore the applied applications on a JD change", func() {
+		exec(`
+UPDATE openings
+SET jd = 'Backend Engineer with Go and Kubernetes'
+WHERE id = '2024-Jul-01-1'
+`)
+		Expect(isStale("APP-0055-1", lexical)).Should(BeTrue())
+		Expect(isDeadLettered("APP-0055-1", sortinghat)).Should(BeFalse())
+
+		var attempts int
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT attempts
+FROM application_scoring_state
+WHERE application_id = $1
+    AND model_name = $2
+    AND next_attempt_at <= timezone('UTC', now())
+`,
+			"APP-0055-1",
+			sortinghat,
+		).Scan(&attempts)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(attempts).Should(BeZero())
+
+		// The shortlisted applications are not scored anymore
+		Expect(isStale("APP-0055-2", lexical)).Should(BeFalse())
+	})
+
+	It("should rescore on a change of the years of experience", func() {
+		exec(`
+UPDATE application_scores
+SET stale = FALSE
+WHERE application_id = 'APP-0055-1'
+`)
+		exec(`UPDATE openings SET yoe_max = 8 WHERE id = '2024-Jul-01-1'`)
+		Expect(isStale("APP-0055-1", lexical)).Should(BeTrue())
+	})
+
+	It("should rescore with an activated model", func() {
+		exec(`
+UPDATE application_scores
+SET stale = FALSE
+WHERE application_id = 'APP-0055-1'
+`)
+		deadLetter("APP-0055-1", sortinghat)
+		deadLetter("APP-0055-1", activated)
+
+		exec(`
+UPDATE application_scoring_models
+SET is_active = TRUE
+WHERE model_name = 'Dolores-0055-Model'
+`)
+		Expect(isStale("APP-0055-1", activated)).Should(BeTrue())
+		Expect(isStale("APP-0055-1", lexical)).Should(BeFalse())
+
+		// Only the activated model gets another chance
+		Expect(isDeadLettered("APP-0055-1", activated)).Should(BeFalse())
+		Expect(isDeadLettered("APP-0055-1", sortinghat)).Should(BeTrue())
+
+		// Only the activation rescores
+		exec(`
+UPDATE application_scores
+SET stale = FALSE
+WHERE application_id = 'APP-0055-1'
+`)
+		exec(`
+UPDATE application_scoring_models
+SET description = 'Changed'
+WHERE model_name = 'Dolores-0055-Model'
+`)
+		Expect(isStale("APP-0055-1", activated)).Should(BeFalse())
+	})
+})
@@ -0,0 +1,222 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Recommended Openings", Ordered, func() {
+	var db *pgxpool.Pool
+	var hubToken1, hubToken2 string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0056-recommended-openings-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		hubSigninAsync(
+			"hub1@recommended-openings-hub.example",
+			"NewPassword123$",
+			&hubToken1,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub2@recommended-openings-hub.example",
+			"NewPassword123$",
+			&hubToken2,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0056-recommended-openings-down.pgsql")
+		db.Close()
+	})
+
+	domain := "recommended-openings.example"
+
+	getRecommendedOpenings := func(
+		token string,
+		limit int64,
+	) []hub.RecommendedOpening {
+		resp := testPOSTGetResp(
+			token,
+			hub.GetRecommendedOpeningsRequest{Limit: limit},
+			"/hub/get-recommended-openings",
+			http.StatusOK,
+		).([]byte)
+		var openings []hub.RecommendedOpening
+		err := json.Unmarshal(resp, &openings)
+		Expect(err).ShouldNot(HaveOccurred())
+		return openings
+	}
+
+	openingIDs := func(openings []hub.RecommendedOpening) []string {
+		ids := []string{}
+		for _, opening := range openings {
+			ids = append(ids, opening.OpeningIDWithinCompany)
+		}
+		return ids
+	}
+
+	refreshedAt := func() *time.Time {
+		var refreshedAt *time.Time
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT recommendations_refreshed_at
+FROM hub_users
+WHERE id = '12345678-0056-0056-0056-000000080001'
+`,
+		).Scan(&refreshedAt)
+		Expect(err).ShouldNot(HaveOccurred())
+		return refreshedAt
+	}
+
+	It("should recommend the best openings first, with reasons", func() {
+		openings := getRecommendedOpenings(hubToken1, 0)
+
+		// The closed and the applied openings are not recommended
+		Expect(openingIDs(openings)).Should(Equal([]string{
+			"2024-Jul-01-1",
+			"2024-Jul-01-2",
+			"2024-Jul-01-3",
+		}))
+
+		Expect(openings[0].CompanyDomain).Should(Equal(domain))
+		Expect(openings[0].CompanyName).
+			Should(Equal("Recommended Openings Inc"))
+		Expect(openings[0].JobTitle).Should(Equal("Backend Engineer"))
+		Expect(openings[0].Score).Should(Equal(80))
+		Expect(openings[0].RecommendedAt).ShouldNot(BeZero())
+
+		golang, country := "Golang", "IND"
+		Expect(openings[0].Reasons).Should(Equal([]hub.RecommendationReason{
+			{
+				ReasonType: hub.MatchesTagReason,
+				Detail:     &golang,
+				Text:       "Matches your tag Golang",
+			},
+			{
+				ReasonType: hub.HiringInYourCountryReason,
+				Detail:     &country,
+				Text:       "Hiring in your country",
+			},
+		}))
+
+		Expect(openings[1].Reasons).Should(Equal([]hub.RecommendationReason{
+			{
+				ReasonType: hub.MatchesProfileReason,
+				Text:       "Matches your profile",
+			},
+		}))
+	})
+
+	It("should limit the recommendations", func() {
+		Expect(openingIDs(getRecommendedOpenings(hubToken1, 2))).
+			Should(Equal([]string{"2024-Jul-01-1", "2024-Jul-01-2"}))
+
+		testPOST(
+			hubToken1,
+			hub.GetRecommendedOpeningsRequest{Limit: 21},
+			"/hub/get-recommended-openings",
+			http.StatusBadRequest,
+		)
+	})
+
+	It("should not return the recommendations of the others", func() {
+		Expect(getRecommendedOpenings(hubToken2, 0)).Should(BeEmpty())
+	})
+
+	It("should validate the feedback", func() {
+		testPOST(
+			hubToken1,
+			hub.DismissRecommendedOpeningRequest{
+				OpeningIDWithinCompany: "2024-Jul-01-1",
+				CompanyDomain:          domain,
+				Feedback:               "LIKED",
+			},
+			"/hub/dismiss-recommended-opening",
+			http.StatusBadRequest,
+		)
+
+		testPOST(
+			hubToken1,
+			hub.DismissRecommendedOpeningRequest{
+				OpeningIDWithinCompany: "2024-Jul-01-99",
+				CompanyDomain:          domain,
+				Feedback:               hub.DismissedRecommendation,
+			},
+			"/hub/dismiss-recommended-opening",
+			http.StatusNotFound,
+		)
+
+		testPOST(
+			hubToken1,
+			hub.DismissRecommendedOpeningRequest{
+				OpeningIDWithinCompany: "2024-Jul-01-1",
+				CompanyDomain:          "nonexistent-recommended.example",
+				Feedback:               hub.DismissedRecommendation,
+			},
+			"/hub/dismiss-recommended-opening",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should not recommend the dismissed openings", func() {
+		testPOST(
+			hubToken1,
+			hub.DismissRecommendedOpeningRequest{
+				OpeningIDWithinCompany: "2024-Jul-01-1",
+				CompanyDomain:          domain,
+				Feedback:               hub.DismissedRecommendation,
+			},
+			"/hub/dismiss-recommended-opening",
+			http.StatusOK,
+		)
+
+		Expect(openingIDs(getRecommendedOpenings(hubToken1, 0))).
+			Should(Equal([]string{"2024-Jul-01-2", "2024-Jul-01-3"}))
+
+		// Dismissing does not change what the HubUser is interested in
+		Expect(refreshedAt()).ShouldNot(BeNil())
+	})
+
+	It("should refresh the recommendations when not interested", func() {
+		testPOST(
+			hubToken1,
+			hub.DismissRecommendedOpeningRequest{
+				OpeningIDWithinCompany: "2024-Jul-01-2",
+				CompanyDomain:          domain,
+				Feedback:               hub.NotInterestedRecommendation,
+			},
+			"/hub/dismiss-recommended-opening",
+			http.StatusOK,
+		)
+
+		var feedback string
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT feedback
+FROM opening_recommendation_feedback
+WHERE hub_user_id = '12345678-0056-0056-0056-000000080001'
+	AND opening_id = '2024-Jul-01-2'
+`,
+		).Scan(&feedback)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(feedback).Should(Equal(string(hub.NotInterestedRecommendation)))
+
+		Expect(refreshedAt()).Should(BeNil())
+	})
+})
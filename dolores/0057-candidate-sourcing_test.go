@@ -0,0 +1,377 @@
+package dolores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Candidate Sourcing", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, viewerToken, ccToken string
+	var hubToken1, hubToken2 string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0057-candidate-sourcing-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(5)
+		employerSigninAsync(
+			"candidate-sourcing.example",
+			"admin@candidate-sourcing.example",
+			"NewPassword123$",
+			&adminToken,
+			&wg,
+		)
+		employerSigninAsync(
+			"candidate-sourcing.example",
+			"viewer@candidate-sourcing.example",
+			"NewPassword123$",
+			&viewerToken,
+			&wg,
+		)
+		employerSigninAsync(
+			"candidate-sourcing.example",
+			"cc@candidate-sourcing.example",
+			"NewPassword123$",
+			&ccToken,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub1@candidate-sourcing-hub.example",
+			"NewPassword123$",
+			&hubToken1,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub2@candidate-sourcing-hub.example",
+			"NewPassword123$",
+			&hubToken2,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0057-candidate-sourcing-down.pgsql")
+		db.Close()
+	})
+
+	getSuggestedCandidates := func(
+		token string,
+		req employer.GetSuggestedCandidatesRequest,
+	) []employer.SuggestedCandidate {
+		resp := testPOSTGetResp(
+			token,
+			req,
+			"/employer/get-suggested-candidates",
+			http.StatusOK,
+		).([]byte)
+		var candidates []employer.SuggestedCandidate
+		err := json.Unmarshal(resp, &candidates)
+		Expect(err).ShouldNot(HaveOccurred())
+		return candidates
+	}
+
+	handles := func(candidates []employer.SuggestedCandidate) []string {
+		result := []string{}
+		for _, candidate := range candidates {
+			result = append(result, string(candidate.Handle))
+		}
+		return result
+	}
+
+	getOpeningInvitations := func(token string) []hub.OpeningInvitation {
+		resp := testPOSTGetResp(
+			token,
+			hub.GetOpeningInvitationsRequest{},
+			"/hub/get-opening-invitations",
+			http.StatusOK,
+		).([]byte)
+		var invitations []hub.OpeningInvitation
+		err := json.Unmarshal(resp, &invitations)
+		Expect(err).ShouldNot(HaveOccurred())
+		return invitations
+	}
+
+	getDiscoverability := func(token string) bool {
+		resp := testPOSTGetResp(
+			token,
+			struct{}{},
+			"/hub/get-discoverability",
+			http.StatusOK,
+		).([]byte)
+		var discoverability hub.Discoverability
+		err := json.Unmarshal(resp, &discoverability)
+		Expect(err).ShouldNot(HaveOccurred())
+		return discoverability.Discoverable
+	}
+
+	It("should suggest the discoverable candidates, best first", func() {
+		candidates := getSuggestedCandidates(
+			adminToken,
+			employer.GetSuggestedCandidatesRequest{OpeningID: "2024-Jul-01-1"},
+		)
+
+		// Not those who are not discoverable or who applied already
+		Expect(handles(candidates)).Should(Equal([]string{
+			"sourcing_hub_user_1",
+			"sourcing_hub_user_2",
+		}))
+
+		Expect(candidates[0].FullName).Should(Equal("Sourcing Hub User 1"))
+		Expect(candidates[0].ResidentCountryCode).
+			Should(Equal(common.CountryCode("IND")))
+		Expect(candidates[0].Score).Should(Equal(90))
+		Expect(*candidates[0].Yoe).Should(Equal(3))
+		Expect(candidates[0].SuggestedAt).ShouldNot(BeZero())
+		Expect(candidates[0].InvitedAt).Should(BeNil())
+
+		golang, yoe := "Golang", "3"
+		Expect(candidates[0].Reasons).Should(Equal(
+			[]employer.CandidateSuggestionReason{
+				{
+					ReasonType: employer.MatchesTagReason,
+					Detail:     &golang,
+					Text:       "Has the tag Golang",
+				},
+				{
+					ReasonType: employer.YoeWithinRangeReason,
+					Detail:     &yoe,
+					Text:       "3 years of experience",
+				},
+			},
+		))
+		Expect(candidates[1].Yoe).Should(BeNil())
+
+		Expect(handles(getSuggestedCandidates(
+			adminToken,
+			employer.GetSuggestedCandidatesRequest{
+				OpeningID: "2024-Jul-01-1",
+				Limit:     1,
+			},
+		))).Should(Equal([]string{"sourcing_hub_user_1"}))
+
+		Expect(getSuggestedCandidates(
+			adminToken,
+			employer.GetSuggestedCandidatesRequest{OpeningID: "2024-Jul-01-2"},
+		)).Should(BeEmpty())
+	})
+
+	It("should validate the suggested candidates request", func() {
+		testPOST(
+			adminToken,
+			employer.GetSuggestedCandidatesRequest{
+				OpeningID: "2024-Jul-01-1",
+				Limit:     51,
+			},
+			"/employer/get-suggested-candidates",
+			http.StatusBadRequest,
+		)
+
+		testPOST(
+			adminToken,
+			employer.GetSuggestedCandidatesRequest{OpeningID: "2024-Jul-01-99"},
+			"/employer/get-suggested-candidates",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should check the roles for the suggested candidates", func() {
+		Expect(getSuggestedCandidates(
+			viewerToken,
+			employer.GetSuggestedCandidatesRequest{OpeningID: "2024-Jul-01-1"},
+		)).Should(HaveLen(2))
+
+		testPOST(
+			ccToken,
+			employer.GetSuggestedCandidatesRequest{OpeningID: "2024-Jul-01-1"},
+			"/employer/get-suggested-candidates",
+			common.ErrEmployerRBAC,
+		)
+
+		testPOST(
+			viewerToken,
+			employer.InviteToApplyRequest{
+				OpeningID: "2024-Jul-01-1",
+				Handle:    "sourcing_hub_user_1",
+			},
+			"/employer/invite-to-apply",
+			common.ErrEmployerRBAC,
+		)
+	})
+
+	It("should invite a suggested candidate to apply once", func() {
+		message := "We would love to have you"
+		testPOST(
+			adminToken,
+			employer.InviteToApplyRequest{
+				OpeningID: "2024-Jul-01-1",
+				Handle:    "sourcing_hub_user_1",
+				Message:   &message,
+			},
+			"/employer/invite-to-apply",
+			http.StatusOK,
+		)
+
+		var emailCount int
+		err := db.QueryRow(
+			context.Background(),
+			`
+SELECT COUNT(*)
+FROM opening_invitations i
+JOIN emails e ON e.email_key = i.email_key
+WHERE i.hub_user_id = '12345678-0057-0057-0057-000000080001'
+	AND 'hub1@candidate-sourcing-hub.example' = ANY(e.email_to)
+`,
+		).Scan(&emailCount)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(emailCount).Should(Equal(1))
+
+		testPOST(
+			adminToken,
+			employer.InviteToApplyRequest{
+				OpeningID: "2024-Jul-01-1",
+				Handle:    "sourcing_hub_user_1",
+			},
+			"/employer/invite-to-apply",
+			http.StatusConflict,
+		)
+
+		candidates := getSuggestedCandidates(
+			adminToken,
+			employer.GetSuggestedCandidatesRequest{OpeningID: "2024-Jul-01-1"},
+		)
+		Expect(candidates[0].InvitedAt).ShouldNot(BeNil())
+		Expect(candidates[1].InvitedAt).Should(BeNil())
+	})
+
+	It("should not invite those who cannot be invited", func() {
+		type inviteTestCase struct {
+			description string
+			request     employer.InviteToApplyRequest
+			wantStatus  int
+		}
+
+		for _, tc := range []inviteTestCase{
+			{
+				description: "a HubUser who applied already",
+				request: employer.InviteToApplyRequest{
+					OpeningID: "2024-Jul-01-1",
+					Handle:    "sourcing_hub_user_5",
+				},
+				wantStatus: http.StatusConflict,
+			},
+			{
+				description: "a HubUser who is not discoverable",
+				request: employer.InviteToApplyRequest{
+					OpeningID: "2024-Jul-01-1",
+					Handle:    "sourcing_hub_user_4",
+				},
+				wantStatus: http.StatusNotFound,
+			},
+			{
+				description: "a HubUser who does not exist",
+				request: employer.InviteToApplyRequest{
+					OpeningID: "2024-Jul-01-1",
+					Handle:    "sourcing_hub_user_99",
+				},
+				wantStatus: http.StatusNotFound,
+			},
+			{
+				description: "an Opening that does not exist",
+				request: employer.InviteToApplyRequest{
+					OpeningID: "2024-Jul-01-99",
+					Handle:    "sourcing_hub_user_2",
+				},
+				wantStatus: http.StatusNotFound,
+			},
+			{
+				description: "an Opening that is not active",
+				request: employer.InviteToApplyRequest{
+					OpeningID: "2024-Jul-01-4",
+					Handle:    "sourcing_hub_user_2",
+				},
+				wantStatus: http.StatusUnprocessableEntity,
+			},
+			{
+				description: "a HubUser invited too often in the week",
+				request: employer.InviteToApplyRequest{
+					OpeningID: "2024-Jul-01-1",
+					Handle:    "sourcing_hub_user_3",
+				},
+				wantStatus: http.StatusTooManyRequests,
+			},
+		} {
+			fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+			testPOST(
+				adminToken,
+				tc.request,
+				"/employer/invite-to-apply",
+				tc.wantStatus,
+			)
+		}
+	})
+
+	It("should show the invitations to the HubUser", func() {
+		invitations := getOpeningInvitations(hubToken1)
+		Expect(invitations).Should(HaveLen(1))
+		Expect(invitations[0].InvitationID).ShouldNot(BeEmpty())
+		Expect(invitations[0].OpeningIDWithinCompany).
+			Should(Equal("2024-Jul-01-1"))
+		Expect(invitations[0].CompanyDomain).
+			Should(Equal("candidate-sourcing.example"))
+		Expect(invitations[0].CompanyName).
+			Should(Equal("Candidate Sourcing Inc"))
+		Expect(invitations[0].JobTitle).Should(Equal("Backend Engineer"))
+		Expect(*invitations[0].Message).
+			Should(Equal("We would love to have you"))
+
+		Expect(getOpeningInvitations(hubToken2)).Should(BeEmpty())
+	})
+
+	It("should not suggest those who opt out", func() {
+		Expect(getDiscoverability(hubToken2)).Should(BeTrue())
+
+		testPOST(
+			hubToken2,
+			hub.Discoverability{Discoverable: false},
+			"/hub/set-discoverability",
+			http.StatusOK,
+		)
+		Expect(getDiscoverability(hubToken2)).Should(BeFalse())
+
+		Expect(handles(getSuggestedCandidates(
+			adminToken,
+			employer.GetSuggestedCandidatesRequest{OpeningID: "2024-Jul-01-1"},
+		))).Should(Equal([]string{"sourcing_hub_user_1"}))
+
+		testPOST(
+			adminToken,
+			employer.InviteToApplyRequest{
+				OpeningID: "2024-Jul-01-2",
+				Handle:    "sourcing_hub_user_2",
+			},
+			"/employer/invite-to-apply",
+			http.StatusNotFound,
+		)
+
+		testPOST(
+			hubToken2,
+			hub.Discoverability{Discoverable: true},
+			"/hub/set-discoverability",
+			http.StatusOK,
+		)
+		Expect(getDiscoverability(hubToken2)).Should(BeTrue())
+	})
+})
@@ -0,0 +1,359 @@
+package dolores
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Saved Searches", Ordered, func() {
+	var db *pgxpool.Pool
+	var hubToken1, hubToken2, hubToken3 string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0058-saved-searches-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		hubSigninAsync(
+			"hub1@saved-searches-hub.example",
+			"NewPassword123$",
+			&hubToken1,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub2@saved-searches-hub.example",
+			"NewPassword123$",
+			&hubToken2,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub3@saved-searches-hub.example",
+			"NewPassword123$",
+			&hubToken3,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0058-saved-searches-down.pgsql")
+		db.Close()
+	})
+
+	listSavedSearches := func(token string) []hub.SavedSearch {
+		resp := testPOSTGetResp(
+			token,
+			struct{}{},
+			"/hub/list-saved-searches",
+			http.StatusOK,
+		).([]byte)
+		var savedSearches []hub.SavedSearch
+		err := json.Unmarshal(resp, &savedSearches)
+		Expect(err).ShouldNot(HaveOccurred())
+		return savedSearches
+	}
+
+	findSavedSearch := func(token, savedSearchID string) hub.SavedSearch {
+		for _, savedSearch := range listSavedSearches(token) {
+			if savedSearch.SavedSearchID == savedSearchID {
+				return savedSearch
+			}
+		}
+		Fail("saved search not found: " + savedSearchID)
+		return hub.SavedSearch{}
+	}
+
+	viewSavedSearch := func(
+		token, savedSearchID string,
+	) []hub.SavedSearchOpening {
+		resp := testPOSTGetResp(
+			token,
+			hub.SavedSearchRequest{SavedSearchID: savedSearchID},
+			"/hub/view-saved-search",
+			http.StatusOK,
+		).([]byte)
+		var openings []hub.SavedSearchOpening
+		err := json.Unmarshal(resp, &openings)
+		Expect(err).ShouldNot(HaveOccurred())
+		return openings
+	}
+
+	It("should list the saved searches with the new openings", func() {
+		savedSearches := listSavedSearches(hubToken1)
+		Expect(savedSearches).Should(HaveLen(1))
+		Expect(savedSearches[0].SavedSearchID).Should(Equal("SS-0058-1"))
+		Expect(savedSearches[0].Name).Should(Equal("Go in India"))
+		Expect(savedSearches[0].Search.CountryCode).
+			Should(Equal(common.CountryCode("IND")))
+		Expect(savedSearches[0].Search.Terms).Should(Equal([]string{"Go"}))
+		Expect(savedSearches[0].Frequency).Should(Equal(hub.DailySavedSearch))
+		Expect(savedSearches[0].Paused).Should(BeFalse())
+		Expect(savedSearches[0].NewOpeningsCount).Should(Equal(1))
+
+		Expect(listSavedSearches(hubToken2)).Should(BeEmpty())
+	})
+
+	It("should show the matched openings and mark them viewed", func() {
+		openings := viewSavedSearch(hubToken1, "SS-0058-1")
+		Expect(openings).Should(HaveLen(2))
+		Expect(openings[0].OpeningIDWithinCompany).
+			Should(Equal("2024-Aug-01-2"))
+		Expect(openings[0].CompanyDomain).
+			Should(Equal("saved-searches.example"))
+		Expect(openings[0].CompanyName).Should(Equal("Saved Searches Inc"))
+		Expect(openings[0].JobTitle).Should(Equal("Platform Engineer"))
+		Expect(openings[0].IsNew).Should(BeTrue())
+		Expect(openings[1].OpeningIDWithinCompany).
+			Should(Equal("2024-Aug-01-1"))
+		Expect(openings[1].IsNew).Should(BeFalse())
+
+		Expect(findSavedSearch(hubToken1, "SS-0058-1").NewOpeningsCount).
+			Should(Equal(0))
+
+		for _, opening := range viewSavedSearch(hubToken1, "SS-0058-1") {
+			Expect(opening.IsNew).Should(BeFalse())
+		}
+
+		// Another HubUser's saved search is not found
+		testPOST(
+			hubToken2,
+			hub.SavedSearchRequest{SavedSearchID: "SS-0058-1"},
+			"/hub/view-saved-search",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should save a search", func() {
+		resp := testPOSTGetResp(
+			hubToken2,
+			hub.SaveSearchRequest{
+				Name: "Remote Go",
+				Search: hub.FindHubOpeningsRequest{
+					Terms:         []string{"Go"},
+					PaginationKey: 10,
+					Limit:         5,
+				},
+				Frequency: hub.InstantSavedSearch,
+			},
+			"/hub/save-search",
+			http.StatusOK,
+		).([]byte)
+		var saveSearchResp hub.SaveSearchResponse
+		err := json.Unmarshal(resp, &saveSearchResp)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(saveSearchResp.SavedSearchID).ShouldNot(BeEmpty())
+
+		savedSearch := findSavedSearch(
+			hubToken2,
+			saveSearchResp.SavedSearchID,
+		)
+		Expect(savedSearch.Name).Should(Equal("Remote Go"))
+		Expect(savedSearch.Frequency).Should(Equal(hub.InstantSavedSearch))
+		Expect(savedSearch.Search.Terms).Should(Equal([]string{"Go"}))
+		Expect(savedSearch.Search.PaginationKey).Should(BeZero())
+		Expect(savedSearch.Search.Limit).Should(BeZero())
+		Expect(savedSearch.NewOpeningsCount).Should(Equal(0))
+
+		// The Openings that were ACTIVE already are not matched
+		Expect(viewSavedSearch(hubToken2, saveSearchResp.SavedSearchID)).
+			Should(BeEmpty())
+
+		// The same name is allowed for another HubUser
+		testPOST(
+			hubToken1,
+			hub.SaveSearchRequest{
+				Name:      "Remote Go",
+				Search:    hub.FindHubOpeningsRequest{},
+				Frequency: hub.WeeklySavedSearch,
+			},
+			"/hub/save-search",
+			http.StatusOK,
+		)
+	})
+
+	It("should not save an invalid search", func() {
+		type saveSearchTestCase struct {
+			description string
+			token       string
+			request     hub.SaveSearchRequest
+			wantStatus  int
+		}
+
+		for _, tc := range []saveSearchTestCase{
+			{
+				description: "a duplicate name",
+				token:       hubToken1,
+				request: hub.SaveSearchRequest{
+					Name:      "Go in India",
+					Frequency: hub.DailySavedSearch,
+				},
+				wantStatus: http.StatusConflict,
+			},
+			{
+				description: "an empty name",
+				token:       hubToken1,
+				request: hub.SaveSearchRequest{
+					Frequency: hub.DailySavedSearch,
+				},
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				description: "an invalid frequency",
+				token:       hubToken1,
+				request: hub.SaveSearchRequest{
+					Name:      "Hourly",
+					Frequency: "HOURLY",
+				},
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				description: "too many saved searches",
+				token:       hubToken3,
+				request: hub.SaveSearchRequest{
+					Name:      "One too many",
+					Frequency: hub.DailySavedSearch,
+				},
+				wantStatus: http.StatusUnprocessableEntity,
+			},
+		} {
+			fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+			testPOST(tc.token, tc.request, "/hub/save-search", tc.wantStatus)
+		}
+	})
+
+	It("should update a saved search", func() {
+		name := "Go anywhere"
+		frequency := hub.WeeklySavedSearch
+		testPOST(
+			hubToken1,
+			hub.UpdateSavedSearchRequest{
+				SavedSearchID: "SS-0058-1",
+				Name:          &name,
+				Search: &hub.FindHubOpeningsRequest{
+					Terms: []string{"Go", "Backend"},
+				},
+				Frequency: &frequency,
+			},
+			"/hub/update-saved-search",
+			http.StatusOK,
+		)
+
+		savedSearch := findSavedSearch(hubToken1, "SS-0058-1")
+		Expect(savedSearch.Name).Should(Equal("Go anywhere"))
+		Expect(savedSearch.Frequency).Should(Equal(hub.WeeklySavedSearch))
+		Expect(savedSearch.Search.CountryCode).Should(BeEmpty())
+		Expect(savedSearch.Search.Terms).
+			Should(Equal([]string{"Go", "Backend"}))
+
+		duplicate := "Remote Go"
+		testPOST(
+			hubToken1,
+			hub.UpdateSavedSearchRequest{
+				SavedSearchID: "SS-0058-1",
+				Name:          &duplicate,
+			},
+			"/hub/update-saved-search",
+			http.StatusConflict,
+		)
+
+		testPOST(
+			hubToken2,
+			hub.UpdateSavedSearchRequest{
+				SavedSearchID: "SS-0058-1",
+				Name:          &name,
+			},
+			"/hub/update-saved-search",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should pause and resume a saved search", func() {
+		testPOST(
+			hubToken1,
+			hub.SavedSearchRequest{SavedSearchID: "SS-0058-1"},
+			"/hub/pause-saved-search",
+			http.StatusOK,
+		)
+		Expect(findSavedSearch(hubToken1, "SS-0058-1").Paused).
+			Should(BeTrue())
+
+		testPOST(
+			hubToken1,
+			hub.SavedSearchRequest{SavedSearchID: "SS-0058-1"},
+			"/hub/resume-saved-search",
+			http.StatusOK,
+		)
+		Expect(findSavedSearch(hubToken1, "SS-0058-1").Paused).
+			Should(BeFalse())
+
+		testPOST(
+			hubToken2,
+			hub.SavedSearchRequest{SavedSearchID: "SS-0058-1"},
+			"/hub/pause-saved-search",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should unsubscribe a saved search without signing in", func() {
+		testPOST(
+			"",
+			hub.UnsubscribeSavedSearchRequest{
+				Token: "unsubscribe-token-0058-1",
+			},
+			"/hub/unsubscribe-saved-search",
+			http.StatusOK,
+		)
+		Expect(findSavedSearch(hubToken1, "SS-0058-1").Paused).
+			Should(BeTrue())
+
+		testPOST(
+			"",
+			hub.UnsubscribeSavedSearchRequest{Token: "no-such-token"},
+			"/hub/unsubscribe-saved-search",
+			http.StatusNotFound,
+		)
+
+		testPOST(
+			"",
+			hub.UnsubscribeSavedSearchRequest{},
+			"/hub/unsubscribe-saved-search",
+			http.StatusBadRequest,
+		)
+	})
+
+	It("should delete a saved search", func() {
+		testPOST(
+			hubToken2,
+			hub.SavedSearchRequest{SavedSearchID: "SS-0058-1"},
+			"/hub/delete-saved-search",
+			http.StatusNotFound,
+		)
+
+		testPOST(
+			hubToken1,
+			hub.SavedSearchRequest{SavedSearchID: "SS-0058-1"},
+			"/hub/delete-saved-search",
+			http.StatusOK,
+		)
+
+		for _, savedSearch := range listSavedSearches(hubToken1) {
+			Expect(savedSearch.SavedSearchID).ShouldNot(Equal("SS-0058-1"))
+		}
+
+		testPOST(
+			hubToken1,
+			hub.SavedSearchRequest{SavedSearchID: "SS-0058-1"},
+			"/hub/delete-saved-search",
+			http.StatusNotFound,
+		)
+	})
+})
@@ -0,0 +1,511 @@
+package dolores
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Bookmarks", Ordered, func() {
+	var db *pgxpool.Pool
+	var hubToken1, hubToken2 string
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0059-bookmarks-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		hubSigninAsync(
+			"hub1@bookmarks-hub.example",
+			"NewPassword123$",
+			&hubToken1,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub2@bookmarks-hub.example",
+			"NewPassword123$",
+			&hubToken2,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0059-bookmarks-down.pgsql")
+		db.Close()
+	})
+
+	ptr := func(s string) *string {
+		return &s
+	}
+
+	openingTarget := hub.BookmarkTarget{
+		BookmarkType:           hub.OpeningBookmark,
+		CompanyDomain:          ptr("bookmarks.example"),
+		OpeningIDWithinCompany: ptr("2024-Sep-01-1"),
+	}
+	postTarget := hub.BookmarkTarget{
+		BookmarkType: hub.PostBookmark,
+		PostID:       ptr("post-0059-000000000001"),
+	}
+	employerPostTarget := hub.BookmarkTarget{
+		BookmarkType: hub.EmployerPostBookmark,
+		PostID:       ptr("emppost-0059-000000000001"),
+	}
+	incognitoPostTarget := hub.BookmarkTarget{
+		BookmarkType: hub.IncognitoPostBookmark,
+		PostID:       ptr("incog-0059-000000000001"),
+	}
+	postCommentTarget := hub.BookmarkTarget{
+		BookmarkType: hub.PostCommentBookmark,
+		CommentID:    ptr("comment-0059-000000000001"),
+	}
+	incognitoPostCommentTarget := hub.BookmarkTarget{
+		BookmarkType: hub.IncognitoPostCommentBookmark,
+		CommentID:    ptr("incogcomment-0059-000000000001"),
+	}
+
+	listBookmarks := func(
+		token string,
+		req hub.ListBookmarksRequest,
+	) hub.ListBookmarksResponse {
+		resp := testPOSTGetResp(
+			token,
+			req,
+			"/hub/list-bookmarks",
+			http.StatusOK,
+		).([]byte)
+		var bookmarks hub.ListBookmarksResponse
+		err := json.Unmarshal(resp, &bookmarks)
+		Expect(err).ShouldNot(HaveOccurred())
+		return bookmarks
+	}
+
+	bookmarkTypes := func(bookmarks []hub.Bookmark) []hub.BookmarkType {
+		result := []hub.BookmarkType{}
+		for _, bookmark := range bookmarks {
+			result = append(result, bookmark.Target.BookmarkType)
+		}
+		return result
+	}
+
+	It("should add bookmarks of each type", func() {
+		testPOST(
+			hubToken1,
+			hub.AddBookmarkRequest{
+				Target: openingTarget,
+				Folder: ptr("Jobs"),
+				Note:   ptr("Apply after the vacation"),
+			},
+			"/hub/add-bookmark",
+			http.StatusOK,
+		)
+
+		for _, target := range []hub.BookmarkTarget{
+			postTarget,
+			employerPostTarget,
+			incognitoPostTarget,
+			postCommentTarget,
+			incognitoPostCommentTarget,
+		} {
+			fmt.Fprintf(
+				GinkgoWriter,
+				"### Bookmarking: %s\n",
+				target.BookmarkType,
+			)
+			testPOST(
+				hubToken1,
+				hub.AddBookmarkRequest{Target: target},
+				"/hub/add-bookmark",
+				http.StatusOK,
+			)
+		}
+
+		bookmarks := listBookmarks(hubToken1, hub.ListBookmarksRequest{})
+		Expect(bookmarkTypes(bookmarks.Bookmarks)).Should(Equal(
+			[]hub.BookmarkType{
+				hub.IncognitoPostCommentBookmark,
+				hub.PostCommentBookmark,
+				hub.IncognitoPostBookmark,
+				hub.EmployerPostBookmark,
+				hub.PostBookmark,
+				hub.OpeningBookmark,
+				hub.PostBookmark,
+			},
+		))
+		Expect(bookmarks.PaginationKey).Should(Equal("BM-0059-1"))
+
+		byType := map[hub.BookmarkType]hub.Bookmark{}
+		for _, bookmark := range bookmarks.Bookmarks[:6] {
+			Expect(bookmark.IsDeleted).Should(BeFalse())
+			byType[bookmark.Target.BookmarkType] = bookmark
+		}
+
+		opening := byType[hub.OpeningBookmark]
+		Expect(*opening.Target.CompanyDomain).Should(Equal("bookmarks.example"))
+		Expect(*opening.Target.OpeningIDWithinCompany).
+			Should(Equal("2024-Sep-01-1"))
+		Expect(*opening.JobTitle).Should(Equal("Backend Engineer"))
+		Expect(*opening.CompanyName).Should(Equal("Bookmarks Inc"))
+		Expect(*opening.Folder).Should(Equal("Jobs"))
+		Expect(*opening.Note).Should(Equal("Apply after the vacation"))
+
+		post := byType[hub.PostBookmark]
+		Expect(*post.Target.PostID).Should(Equal("post-0059-000000000001"))
+		Expect(*post.Content).Should(Equal("Go generics are here"))
+		Expect(*post.AuthorName).Should(Equal("Bookmarks Hub User 2"))
+		Expect(*post.AuthorHandle).
+			Should(Equal(common.Handle("bookmarks_hub_user_2")))
+		Expect(post.Folder).Should(BeNil())
+		Expect(post.Note).Should(BeNil())
+
+		employerPost := byType[hub.EmployerPostBookmark]
+		Expect(*employerPost.Content).
+			Should(Equal("We are hiring Go engineers"))
+		Expect(*employerPost.CompanyName).Should(Equal("Bookmarks Inc"))
+
+		incognitoPost := byType[hub.IncognitoPostBookmark]
+		Expect(*incognitoPost.Content).
+			Should(Equal("Is my manager reading this"))
+		Expect(incognitoPost.AuthorName).Should(BeNil())
+
+		postComment := byType[hub.PostCommentBookmark]
+		Expect(*postComment.Target.PostID).
+			Should(Equal("post-0059-000000000001"))
+		Expect(*postComment.Target.CommentID).
+			Should(Equal("comment-0059-000000000001"))
+		Expect(*postComment.Content).Should(Equal("And they are useful"))
+
+		incognitoComment := byType[hub.IncognitoPostCommentBookmark]
+		Expect(*incognitoComment.Target.PostID).
+			Should(Equal("incog-0059-000000000001"))
+		Expect(*incognitoComment.Content).Should(Equal("Probably not"))
+
+		// The post that was deleted since it was bookmarked
+		tombstone := bookmarks.Bookmarks[6]
+		Expect(tombstone.BookmarkID).Should(Equal("BM-0059-1"))
+		Expect(tombstone.IsDeleted).Should(BeTrue())
+		Expect(*tombstone.Target.PostID).Should(Equal("post-0059-deleted"))
+		Expect(tombstone.Content).Should(BeNil())
+		Expect(tombstone.AuthorName).Should(BeNil())
+
+		Expect(listBookmarks(hubToken2, hub.ListBookmarksRequest{}).Bookmarks).
+			Should(BeEmpty())
+	})
+
+	It("should not add invalid bookmarks", func() {
+		type addBookmarkTestCase struct {
+			description string
+			request     hub.AddBookmarkRequest
+			wantStatus  int
+		}
+
+		for _, tc := range []addBookmarkTestCase{
+			{
+				description: "an unknown bookmark type",
+				request: hub.AddBookmarkRequest{
+					Target: hub.BookmarkTarget{
+						BookmarkType: "ARTICLE",
+						PostID:       ptr("post-0059-000000000001"),
+					},
+				},
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				description: "an opening without the company domain",
+				request: hub.AddBookmarkRequest{
+					Target: hub.BookmarkTarget{
+						BookmarkType:           hub.OpeningBookmark,
+						OpeningIDWithinCompany: ptr("2024-Sep-01-1"),
+					},
+				},
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				description: "a comment without the comment ID",
+				request: hub.AddBookmarkRequest{
+					Target: hub.BookmarkTarget{
+						BookmarkType: hub.PostCommentBookmark,
+						PostID:       ptr("post-0059-000000000001"),
+					},
+				},
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				description: "a folder name that is too long",
+				request: hub.AddBookmarkRequest{
+					Target: postTarget,
+					Folder: ptr(strings.Repeat("a", 65)),
+				},
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				description: "an opening that does not exist",
+				request: hub.AddBookmarkRequest{
+					Target: hub.BookmarkTarget{
+						BookmarkType:           hub.OpeningBookmark,
+						CompanyDomain:          ptr("bookmarks.example"),
+						OpeningIDWithinCompany: ptr("2024-Sep-01-99"),
+					},
+				},
+				wantStatus: http.StatusNotFound,
+			},
+			{
+				description: "a post that does not exist",
+				request: hub.AddBookmarkRequest{
+					Target: hub.BookmarkTarget{
+						BookmarkType: hub.PostBookmark,
+						PostID:       ptr("post-0059-nonexistent"),
+					},
+				},
+				wantStatus: http.StatusNotFound,
+			},
+			{
+				description: "a post of the wrong type",
+				request: hub.AddBookmarkRequest{
+					Target: hub.BookmarkTarget{
+						BookmarkType: hub.IncognitoPostBookmark,
+						PostID:       ptr("post-0059-000000000001"),
+					},
+				},
+				wantStatus: http.StatusNotFound,
+			},
+		} {
+			fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", tc.description)
+			testPOST(hubToken1, tc.request, "/hub/add-bookmark", tc.wantStatus)
+		}
+	})
+
+	It("should replace the folder and the note on bookmarking again", func() {
+		testPOST(
+			hubToken1,
+			hub.AddBookmarkRequest{
+				Target: openingTarget,
+				Folder: ptr("Applied"),
+			},
+			"/hub/add-bookmark",
+			http.StatusOK,
+		)
+
+		bookmarks := listBookmarks(
+			hubToken1,
+			hub.ListBookmarksRequest{Folder: ptr("Applied")},
+		).Bookmarks
+		Expect(bookmarks).Should(HaveLen(1))
+		Expect(bookmarks[0].Target.BookmarkType).
+			Should(Equal(hub.OpeningBookmark))
+		Expect(bookmarks[0].Note).Should(BeNil())
+
+		Expect(listBookmarks(
+			hubToken1,
+			hub.ListBookmarksRequest{Folder: ptr("Jobs")},
+		).Bookmarks).Should(BeEmpty())
+
+		resp := testPOSTGetResp(
+			hubToken1,
+			struct{}{},
+			"/hub/get-bookmark-folders",
+			http.StatusOK,
+		).([]byte)
+		var folders []hub.BookmarkFolder
+		err := json.Unmarshal(resp, &folders)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(folders).Should(Equal([]hub.BookmarkFolder{
+			{Name: "Applied", BookmarksCount: 1},
+			{Name: "Old", BookmarksCount: 1},
+		}))
+	})
+
+	It("should filter and paginate the bookmarks", func() {
+		bookmarks := listBookmarks(hubToken1, hub.ListBookmarksRequest{
+			BookmarkTypes: []hub.BookmarkType{
+				hub.PostBookmark,
+				hub.EmployerPostBookmark,
+			},
+		}).Bookmarks
+		Expect(bookmarkTypes(bookmarks)).Should(Equal([]hub.BookmarkType{
+			hub.EmployerPostBookmark,
+			hub.PostBookmark,
+			hub.PostBookmark,
+		}))
+
+		firstPage := listBookmarks(
+			hubToken1,
+			hub.ListBookmarksRequest{Limit: 4},
+		)
+		Expect(firstPage.Bookmarks).Should(HaveLen(4))
+
+		secondPage := listBookmarks(hubToken1, hub.ListBookmarksRequest{
+			PaginationKey: &firstPage.PaginationKey,
+			Limit:         4,
+		})
+		Expect(bookmarkTypes(secondPage.Bookmarks)).Should(Equal(
+			[]hub.BookmarkType{
+				hub.PostBookmark,
+				hub.OpeningBookmark,
+				hub.PostBookmark,
+			},
+		))
+
+		testPOST(
+			hubToken1,
+			hub.ListBookmarksRequest{Limit: 41},
+			"/hub/list-bookmarks",
+			http.StatusBadRequest,
+		)
+		testPOST(
+			hubToken1,
+			hub.ListBookmarksRequest{
+				BookmarkTypes: []hub.BookmarkType{"ARTICLE"},
+			},
+			"/hub/list-bookmarks",
+			http.StatusBadRequest,
+		)
+	})
+
+	It("should tell whether the items are bookmarked", func() {
+		getPost := func(token string) hub.Post {
+			resp := testPOSTGetResp(
+				token,
+				hub.GetPostDetailsRequest{PostID: "post-0059-000000000001"},
+				"/hub/get-post-details",
+				http.StatusOK,
+			).([]byte)
+			var post hub.Post
+			err := json.Unmarshal(resp, &post)
+			Expect(err).ShouldNot(HaveOccurred())
+			return post
+		}
+		Expect(getPost(hubToken1).IsBookmarked).Should(BeTrue())
+		Expect(getPost(hubToken2).IsBookmarked).Should(BeFalse())
+
+		getOpening := func(token string) hub.HubOpeningDetails {
+			resp := testPOSTGetResp(
+				token,
+				hub.GetHubOpeningDetailsRequest{
+					CompanyDomain:          "bookmarks.example",
+					OpeningIDWithinCompany: "2024-Sep-01-1",
+				},
+				"/hub/get-opening-details",
+				http.StatusOK,
+			).([]byte)
+			var opening hub.HubOpeningDetails
+			err := json.Unmarshal(resp, &opening)
+			Expect(err).ShouldNot(HaveOccurred())
+			return opening
+		}
+		Expect(getOpening(hubToken1).IsBookmarked).Should(BeTrue())
+		Expect(getOpening(hubToken2).IsBookmarked).Should(BeFalse())
+
+		getIncognitoPost := func(token string) hub.IncognitoPost {
+			resp := testPOSTGetResp(
+				token,
+				hub.GetIncognitoPostRequest{
+					IncognitoPostID: "incog-0059-000000000001",
+				},
+				"/hub/get-incognito-post",
+				http.StatusOK,
+			).([]byte)
+			var post hub.IncognitoPost
+			err := json.Unmarshal(resp, &post)
+			Expect(err).ShouldNot(HaveOccurred())
+			return post
+		}
+		Expect(getIncognitoPost(hubToken1).IsBookmarked).Should(BeTrue())
+		Expect(getIncognitoPost(hubToken2).IsBookmarked).Should(BeFalse())
+
+		getComments := func(token string) []hub.PostComment {
+			resp := testPOSTGetResp(
+				token,
+				hub.GetPostCommentsRequest{PostID: "post-0059-000000000001"},
+				"/hub/get-post-comments",
+				http.StatusOK,
+			).([]byte)
+			var comments []hub.PostComment
+			err := json.Unmarshal(resp, &comments)
+			Expect(err).ShouldNot(HaveOccurred())
+			return comments
+		}
+		Expect(getComments(hubToken1)[0].IsBookmarked).Should(BeTrue())
+		Expect(getComments(hubToken2)[0].IsBookmarked).Should(BeFalse())
+	})
+
+	It("should show a tombstone once the item is deleted", func() {
+		testPOST(
+			hubToken2,
+			hub.DeleteIncognitoPostRequest{
+				IncognitoPostID: "incog-0059-000000000001",
+			},
+			"/hub/delete-incognito-post",
+			http.StatusOK,
+		)
+
+		bookmarks := listBookmarks(hubToken1, hub.ListBookmarksRequest{
+			BookmarkTypes: []hub.BookmarkType{hub.IncognitoPostBookmark},
+		}).Bookmarks
+		Expect(bookmarks).Should(HaveLen(1))
+		Expect(bookmarks[0].IsDeleted).Should(BeTrue())
+		Expect(bookmarks[0].Content).Should(BeNil())
+		Expect(*bookmarks[0].Target.PostID).
+			Should(Equal("incog-0059-000000000001"))
+
+		// A deleted item cannot be bookmarked anew
+		testPOST(
+			hubToken2,
+			hub.AddBookmarkRequest{Target: incognitoPostTarget},
+			"/hub/add-bookmark",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should remove bookmarks", func() {
+		for _, target := range []hub.BookmarkTarget{
+			openingTarget,
+			postTarget,
+			incognitoPostTarget,
+		} {
+			testPOST(
+				hubToken1,
+				hub.RemoveBookmarkRequest{Target: target},
+				"/hub/remove-bookmark",
+				http.StatusOK,
+			)
+		}
+
+		Expect(bookmarkTypes(listBookmarks(
+			hubToken1,
+			hub.ListBookmarksRequest{},
+		).Bookmarks)).Should(Equal([]hub.BookmarkType{
+			hub.IncognitoPostCommentBookmark,
+			hub.PostCommentBookmark,
+			hub.EmployerPostBookmark,
+			hub.PostBookmark,
+		}))
+
+		// Removing a bookmark that does not exist is not an error
+		testPOST(
+			hubToken1,
+			hub.RemoveBookmarkRequest{Target: postTarget},
+			"/hub/remove-bookmark",
+			http.StatusOK,
+		)
+
+		testPOST(
+			hubToken1,
+			hub.RemoveBookmarkRequest{
+				Target: hub.BookmarkTarget{BookmarkType: hub.PostBookmark},
+			},
+			"/hub/remove-bookmark",
+			http.StatusBadRequest,
+		)
+	})
+})
@@ -0,0 +1,273 @@
+package dolores
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Candidacy Updates", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, hubToken1, hubToken2 string
+
+	const candidacyID = "12345678-0060-0060-0060-000000070001"
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0060-candidacy-updates-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		employerSigninAsync(
+			"candidacy-updates.example",
+			"admin@candidacy-updates.example",
+			"NewPassword123$",
+			&adminToken,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub1@candidacy-updates-hub.example",
+			"NewPassword123$",
+			&hubToken1,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub2@candidacy-updates-hub.example",
+			"NewPassword123$",
+			&hubToken2,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0060-candidacy-updates-down.pgsql")
+		db.Close()
+	})
+
+	newStreamRequest := func(
+		ctx context.Context,
+		token string,
+		endpoint string,
+		candidacyID string,
+		lastEventID string,
+	) *http.Request {
+		query := url.Values{}
+		query.Set("candidacy_id", candidacyID)
+
+		req, err := http.NewRequestWithContext(
+			ctx,
+			http.MethodGet,
+			serverURL+endpoint+"?"+query.Encode(),
+			nil,
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		return req
+	}
+
+	// openStream returns the updates received on the stream, which is
+	// closed when the spec ends
+	openStream := func(
+		token string,
+		endpoint string,
+		lastEventID string,
+	) <-chan common.CandidacyUpdate {
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		resp, err := http.DefaultClient.Do(
+			newStreamRequest(ctx, token, endpoint, candidacyID, lastEventID),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).
+			Should(Equal("text/event-stream"))
+
+		updates := make(chan common.CandidacyUpdate, 10)
+		go func() {
+			defer GinkgoRecover()
+			defer resp.Body.Close()
+			defer close(updates)
+
+			var data string
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				line := scanner.Text()
+				switch {
+				case strings.HasPrefix(line, "data: "):
+					data = strings.TrimPrefix(line, "data: ")
+				case line == "" && data != "":
+					var update common.CandidacyUpdate
+					err := json.Unmarshal([]byte(data), &update)
+					Expect(err).ShouldNot(HaveOccurred())
+					updates <- update
+					data = ""
+				}
+			}
+		}()
+
+		return updates
+	}
+
+	receive := func(
+		updates <-chan common.CandidacyUpdate,
+	) common.CandidacyUpdate {
+		var update common.CandidacyUpdate
+		Eventually(updates).
+			WithTimeout(10 * time.Second).
+			Should(Receive(&update))
+		return update
+	}
+
+	streamStatus := func(
+		token string,
+		endpoint string,
+		candidacyID string,
+		lastEventID string,
+	) int {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		resp, err := http.DefaultClient.Do(
+			newStreamRequest(ctx, token, endpoint, candidacyID, lastEventID),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	var firstUpdateID string
+
+	It("should replay the past updates and push the new ones", func() {
+		hubUpdates := openStream(hubToken1, "/hub/stream-candidacy-updates", "")
+
+		update := receive(hubUpdates)
+		Expect(update.CandidacyID).Should(Equal(candidacyID))
+		Expect(update.UpdateType).Should(Equal(common.CommentAddedUpdate))
+		Expect(update.Comment).ShouldNot(BeNil())
+		Expect(update.Comment.Content).Should(Equal("Welcome aboard"))
+		Expect(update.Comment.CommenterName).Should(Equal("Admin User"))
+		Expect(update.Comment.CommenterType).
+			Should(Equal(common.CommenterTypeOrgUser))
+		firstUpdateID = update.UpdateID
+
+		employerUpdates := openStream(
+			adminToken,
+			"/employer/stream-candidacy-updates",
+			"",
+		)
+		Expect(receive(employerUpdates).UpdateID).Should(Equal(firstUpdateID))
+
+		testPOST(
+			hubToken1,
+			hub.AddHubCandidacyCommentRequest{
+				CandidacyID: candidacyID,
+				Comment:     "Thank you",
+			},
+			"/hub/add-candidacy-comment",
+			http.StatusOK,
+		)
+
+		for _, updates := range []<-chan common.CandidacyUpdate{
+			hubUpdates,
+			employerUpdates,
+		} {
+			update := receive(updates)
+			Expect(update.UpdateType).Should(Equal(common.CommentAddedUpdate))
+			Expect(update.Comment.Content).Should(Equal("Thank you"))
+			Expect(update.Comment.CommenterType).
+				Should(Equal(common.CommenterTypeHubUser))
+		}
+
+		_, err := db.Exec(
+			context.Background(),
+			`
+UPDATE candidacies
+SET candidacy_state = 'CANDIDATE_UNSUITABLE'
+WHERE id = $1
+`,
+			candidacyID,
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		update = receive(hubUpdates)
+		Expect(update.UpdateType).Should(Equal(common.StateChangedUpdate))
+		Expect(*update.CandidacyState).
+			Should(Equal(common.CandidacyState("CANDIDATE_UNSUITABLE")))
+		Expect(update.Comment).Should(BeNil())
+	})
+
+	It("should resume after the last event seen", func() {
+		updates := openStream(
+			hubToken1,
+			"/hub/stream-candidacy-updates",
+			firstUpdateID,
+		)
+
+		update := receive(updates)
+		Expect(update.UpdateType).Should(Equal(common.CommentAddedUpdate))
+		Expect(update.Comment.Content).Should(Equal("Thank you"))
+
+		update = receive(updates)
+		Expect(update.UpdateType).Should(Equal(common.StateChangedUpdate))
+
+		Consistently(updates).
+			WithTimeout(2 * time.Second).
+			ShouldNot(Receive())
+	})
+
+	It("should stream only to the participants", func() {
+		Expect(streamStatus(
+			hubToken2,
+			"/hub/stream-candidacy-updates",
+			candidacyID,
+			"",
+		)).Should(Equal(http.StatusNotFound))
+
+		Expect(streamStatus(
+			adminToken,
+			"/employer/stream-candidacy-updates",
+			"12345678-0060-0060-0060-000000079999",
+			"",
+		)).Should(Equal(http.StatusNotFound))
+
+		Expect(streamStatus(
+			"",
+			"/hub/stream-candidacy-updates",
+			candidacyID,
+			"",
+		)).Should(Equal(http.StatusUnauthorized))
+
+		Expect(streamStatus(
+			hubToken1,
+			"/hub/stream-candidacy-updates",
+			candidacyID,
+			"not-a-cursor",
+		)).Should(Equal(http.StatusBadRequest))
+
+		Expect(streamStatus(
+			hubToken1,
+			"/hub/stream-candidacy-updates",
+			"",
+			"",
+		)).Should(Equal(http.StatusBadRequest))
+	})
+})
@@ -0,0 +1,317 @@
+package dolores
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+var _ = Describe("Candidacy Comment Extras", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, hubToken1, hubToken2 string
+
+	const candidacyID = "12345678-0061-0061-0061-000000070001"
+	const oldCommentID = "12345678-0061-0061-0061-000000080001"
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0061-candidacy-comment-extras-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		employerSigninAsync(
+			"comment-extras.example",
+			"admin@comment-extras.example",
+			"NewPassword123$",
+			&adminToken,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub1@comment-extras-hub.example",
+			"NewPassword123$",
+			&hubToken1,
+			&wg,
+		)
+		hubSigninAsync(
+			"hub2@comment-extras-hub.example",
+			"NewPassword123$",
+			&hubToken2,
+			&wg,
+		)
+		wg.Wait()
+	})
+
+	AfterAll(func() {
+		seedDatabase(db, "0061-candidacy-comment-extras-down.pgsql")
+		db.Close()
+	})
+
+	getComments := func(
+		token string,
+		endpoint string,
+	) map[string]common.CandidacyComment {
+		resp := testPOSTGetResp(
+			token,
+			common.GetCandidacyCommentsRequest{CandidacyID: candidacyID},
+			endpoint,
+			http.StatusOK,
+		).([]byte)
+
+		var comments []common.CandidacyComment
+		err := json.Unmarshal(resp, &comments)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		commentsByID := make(map[string]common.CandidacyComment)
+		for _, comment := range comments {
+			commentsByID[comment.CommentID] = comment
+		}
+		return commentsByID
+	}
+
+	employerUnreadCount := func() int {
+		resp := testPOSTGetResp(
+			adminToken,
+			common.GetCandidacyInfoRequest{CandidacyID: candidacyID},
+			"/employer/get-candidacy-info",
+			http.StatusOK,
+		).([]byte)
+		var candidacy employer.Candidacy
+		err := json.Unmarshal(resp, &candidacy)
+		Expect(err).ShouldNot(HaveOccurred())
+		return candidacy.UnreadCommentsCount
+	}
+
+	hubUnreadCount := func() int {
+		resp := testPOSTGetResp(
+			hubToken1,
+			common.GetCandidacyInfoRequest{CandidacyID: candidacyID},
+			"/hub/get-candidacy-info",
+			http.StatusOK,
+		).([]byte)
+		var candidacy hub.MyCandidacy
+		err := json.Unmarshal(resp, &candidacy)
+		Expect(err).ShouldNot(HaveOccurred())
+		return candidacy.UnreadCommentsCount
+	}
+
+	var hubCommentID string
+
+	It("should accept only the valid attachments", func() {
+		testPOST(
+			hubToken1,
+			hub.AddHubCandidacyCommentRequest{
+				CandidacyID: candidacyID,
+				Comment:     "Not a document",
+				Attachments: []common.CandidacyCommentAttachment{
+					{Filename: "notes.txt", Document: "aGVsbG8="},
+				},
+			},
+			"/hub/add-candidacy-comment",
+			http.StatusBadRequest,
+		)
+
+		testPOST(
+			hubToken1,
+			hub.AddHubCandidacyCommentRequest{
+				CandidacyID: candidacyID,
+				Comment:     "Please find my portfolio",
+				Attachments: []common.CandidacyCommentAttachment{
+					{Filename: "portfolio.pdf", Document: offerAttachmentPDF},
+				},
+			},
+			"/hub/add-candidacy-comment",
+			http.StatusOK,
+		)
+
+		for _, comment := range getComments(
+			adminToken,
+			"/employer/get-candidacy-comments",
+		) {
+			if comment.Content == "Please find my portfolio" {
+				hubCommentID = comment.CommentID
+				Expect(comment.Attachments).Should(HaveLen(1))
+				Expect(comment.Attachments[0].AttachmentNumber).Should(Equal(1))
+				Expect(comment.Attachments[0].Filename).
+					Should(Equal("portfolio.pdf"))
+				Expect(comment.Attachments[0].ContentType).
+					Should(Equal("application/pdf"))
+			}
+			Expect(comment.Content).ShouldNot(Equal("Not a document"))
+		}
+		Expect(hubCommentID).ShouldNot(BeEmpty())
+
+		resp := testPOSTGetResp(
+			adminToken,
+			common.GetCandidacyCommentAttachmentRequest{
+				CommentID:        hubCommentID,
+				AttachmentNumber: 1,
+			},
+			"/employer/get-candidacy-comment-attachment",
+			http.StatusOK,
+		).([]byte)
+		Expect(string(resp)).Should(HavePrefix("%PDF-"))
+
+		testPOST(
+			adminToken,
+			common.GetCandidacyCommentAttachmentRequest{
+				CommentID:        hubCommentID,
+				AttachmentNumber: 2,
+			},
+			"/employer/get-candidacy-comment-attachment",
+			http.StatusNotFound,
+		)
+
+		testPOST(
+			hubToken2,
+			common.GetCandidacyCommentAttachmentRequest{
+				CommentID:        hubCommentID,
+				AttachmentNumber: 1,
+			},
+			"/hub/get-candidacy-comment-attachment",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should track the unread comments of each participant", func() {
+		Expect(employerUnreadCount()).Should(Equal(1))
+		Expect(hubUnreadCount()).Should(Equal(1))
+
+		testPOST(
+			adminToken,
+			common.MarkCandidacyCommentsReadRequest{
+				CandidacyID: candidacyID,
+				CommentID:   hubCommentID,
+			},
+			"/employer/mark-candidacy-comments-read",
+			http.StatusOK,
+		)
+		Expect(employerUnreadCount()).Should(Equal(0))
+		Expect(hubUnreadCount()).Should(Equal(1))
+
+		// Marking an older comment as read does not move the receipt back
+		testPOST(
+			adminToken,
+			common.MarkCandidacyCommentsReadRequest{
+				CandidacyID: candidacyID,
+				CommentID:   oldCommentID,
+			},
+			"/employer/mark-candidacy-comments-read",
+			http.StatusOK,
+		)
+		Expect(employerUnreadCount()).Should(Equal(0))
+
+		comments := getComments(hubToken1, "/hub/get-candidacy-comments")
+		Expect(comments[hubCommentID].ReadBy).Should(ConsistOf(
+			common.CandidacyCommentReader{
+				ReaderName: "Admin User",
+				ReaderType: common.CommenterTypeOrgUser,
+			},
+		))
+		Expect(comments[oldCommentID].ReadBy).Should(BeEmpty())
+
+		testPOST(
+			hubToken2,
+			common.MarkCandidacyCommentsReadRequest{
+				CandidacyID: candidacyID,
+				CommentID:   oldCommentID,
+			},
+			"/hub/mark-candidacy-comments-read",
+			http.StatusNotFound,
+		)
+	})
+
+	It("should let only the commenter edit within the window", func() {
+		testPOST(
+			hubToken1,
+			common.EditCandidacyCommentRequest{
+				CommentID: hubCommentID,
+				Comment:   "Please find my updated portfolio",
+			},
+			"/hub/edit-candidacy-comment",
+			http.StatusOK,
+		)
+
+		comment := getComments(
+			adminToken,
+			"/employer/get-candidacy-comments",
+		)[hubCommentID]
+		Expect(comment.Content).
+			Should(Equal("Please find my updated portfolio"))
+		Expect(comment.EditedAt).ShouldNot(BeNil())
+		Expect(comment.EditHistory).Should(HaveLen(1))
+		Expect(comment.EditHistory[0].PreviousContent).
+			Should(Equal("Please find my portfolio"))
+
+		testPOST(
+			adminToken,
+			common.EditCandidacyCommentRequest{
+				CommentID: hubCommentID,
+				Comment:   "Not my comment",
+			},
+			"/employer/edit-candidacy-comment",
+			http.StatusNotFound,
+		)
+
+		testPOST(
+			adminToken,
+			common.EditCandidacyCommentRequest{
+				CommentID: oldCommentID,
+				Comment:   "Too late",
+			},
+			"/employer/edit-candidacy-comment",
+			http.StatusUnprocessableEntity,
+		)
+
+		testPOST(
+			adminToken,
+			common.RetractCandidacyCommentRequest{CommentID: oldCommentID},
+			"/employer/retract-candidacy-comment",
+			http.StatusUnprocessableEntity,
+		)
+	})
+
+	It("should hide the retracted comments", func() {
+		testPOST(
+			hubToken1,
+			common.RetractCandidacyCommentRequest{CommentID: hubCommentID},
+			"/hub/retract-candidacy-comment",
+			http.StatusOK,
+		)
+
+		comment := getComments(
+			adminToken,
+			"/employer/get-candidacy-comments",
+		)[hubCommentID]
+		Expect(comment.IsRetracted).Should(BeTrue())
+		Expect(comment.Content).Should(BeEmpty())
+		Expect(comment.Attachments).Should(BeEmpty())
+		Expect(comment.EditHistory).Should(BeEmpty())
+
+		testPOST(
+			adminToken,
+			common.GetCandidacyCommentAttachmentRequest{
+				CommentID:        hubCommentID,
+				AttachmentNumber: 1,
+			},
+			"/employer/get-candidacy-comment-attachment",
+			http.StatusNotFound,
+		)
+
+		testPOST(
+			hubToken1,
+			common.EditCandidacyCommentRequest{
+				CommentID: hubCommentID,
+				Comment:   "Back again",
+			},
+			"/hub/edit-candidacy-comment",
+			http.StatusUnprocessableEntity,
+		)
+	})
+})
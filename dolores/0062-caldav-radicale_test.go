@@ -0,0 +1,375 @@
+package dolores
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vetchium/vetchium/typespec/common"
+	"github.com/vetchium/vetchium/typespec/employer"
+	"github.com/vetchium/vetchium/typespec/hub"
+)
+
+// The Radicale CalDAV server of the dev and CI clusters, as reached by
+// dolores and by hermione and granger respectively
+const (
+	radicaleURL          = "http://localhost:5232"
+	radicaleClusterURL   = "http://radicale:5232"
+	radicaleUsername     = "dolores"
+	radicalePassword     = "dolores-caldav-password"
+	radicaleInterviewer  = "12345678-0062-0062-0062-000000040002"
+	radicaleInterviewID  = "INT-0062-1"
+	radicaleSyncTimeout  = 3 * time.Minute
+	radicalePollInterval = 5 * time.Second
+
+	icalUTC = "20060102T150405Z"
+)
+
+var _ = Describe("CalDAV with Radicale", Ordered, func() {
+	var db *pgxpool.Pool
+	var adminToken, interviewerToken, candidateToken string
+
+	// Each run uses a calendar of its own, as the server outlives the tests
+	calendarPath := fmt.Sprintf(
+		"/%s/vetchium-%d/",
+		radicaleUsername,
+		time.Now().UnixNano(),
+	)
+
+	// Busy from 10:00 to 12:00 UTC on this day, as per the calendar
+	busyDay := time.Now().UTC().Truncate(24 * time.Hour).Add(48 * time.Hour)
+
+	radicale := func(method, path, body string) (int, string) {
+		req, err := http.NewRequest(
+			method,
+			radicaleURL+path,
+			bytes.NewBufferString(body),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		req.SetBasicAuth(radicaleUsername, radicalePassword)
+		if body != "" {
+			req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		return resp.StatusCode, string(data)
+	}
+
+	BeforeAll(func() {
+		db = setupTestDB()
+		seedDatabase(db, "0062-caldav-radicale-up.pgsql")
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		employerSigninAsync(
+			"caldav-radicale.example",
+			"admin@caldav-radicale.example",
+			"NewPassword123$",
+			&adminToken,
+			&wg,
+		)
+		employerSigninAsync(
+			"caldav-radicale.example",
+			"interviewer@caldav-radicale.example",
+			"NewPassword123$",
+			&interviewerToken,
+			&wg,
+		)
+		hubSigninAsync(
+			"candidate@caldav-radicale-hub.example",
+			"NewPassword123$",
+			&candidateToken,
+			&wg,
+		)
+		wg.Wait()
+
+		status, _ := radicale("MKCALENDAR", calendarPath, "")
+		Expect(status).Should(Equal(http.StatusCreated))
+
+		status, _ = radicale(
+			http.MethodPut,
+			calendarPath+"busy.ics",
+			"BEGIN:VCALENDAR\r\n"+
+				"VERSION:2.0\r\n"+
+				"PRODID:-//Vetchium//Dolores//EN\r\n"+
+				"BEGIN:VEVENT\r\n"+
+				"UID:busy@dolores.example\r\n"+
+				"DTSTAMP:20240101T000000Z\r\n"+
+				"DTSTART:"+busyDay.Add(10*time.Hour).Format(icalUTC)+"\r\n"+
+				"DTEND:"+busyDay.Add(12*time.Hour).Format(icalUTC)+"\r\n"+
+				"SUMMARY:Busy\r\n"+
+				"END:VEVENT\r\n"+
+				"END:VCALENDAR\r\n",
+		)
+		Expect(status).Should(Equal(http.StatusCreated))
+
+		// An event that ends when it starts, which the sync should ignore
+		status, _ = radicale(
+			http.MethodPut,
+			calendarPath+"instant.ics",
+			"BEGIN:VCALENDAR\r\n"+
+				"VERSION:2.0\r\n"+
+				"PRODID:-//Vetchium//Dolores//EN\r\n"+
+				"BEGIN:VEVENT\r\n"+
+				"UID:instant@dolores.example\r\n"+
+				"DTSTAMP:20240101T000000Z\r\n"+
+				"DTSTART:"+busyDay.Add(15*time.Hour).Format(icalUTC)+"\r\n"+
+				"DTEND:"+busyDay.Add(15*time.Hour).Format(icalUTC)+"\r\n"+
+				"SUMMARY:Reminder\r\n"+
+				"END:VEVENT\r\n"+
+				"END:VCALENDAR\r\n",
+		)
+		Expect(status).Should(Equal(http.StatusCreated))
+	})
+
+	AfterAll(func() {
+		radicale(http.MethodDelete, calendarPath, "")
+		seedDatabase(db, "0062-caldav-radicale-down.pgsql")
+		db.Close()
+	})
+
+	getConnection := func(g Gomega) employer.CalDAVConnection {
+		resp := testPOSTGetResp(
+			interviewerToken,
+			nil,
+			"/employer/get-caldav-connection",
+			http.StatusOK,
+		).([]byte)
+		var connection employer.CalDAVConnection
+		err := json.Unmarshal(resp, &connection)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		return connection
+	}
+
+	// Makes the connection due for a sync, which granger runs every minute
+	syncNow := func() time.Time {
+		_, err := db.Exec(
+			context.Background(),
+			`
+UPDATE caldav_connections
+SET next_sync_at = timezone('UTC', now())
+WHERE org_user_id = $1
+`,
+			radicaleInterviewer,
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		return time.Now()
+	}
+
+	waitForSync := func(after time.Time) {
+		Eventually(func(g Gomega) {
+			connection := getConnection(g)
+			g.Expect(connection.LastSyncedAt).ShouldNot(BeNil())
+			g.Expect(*connection.LastSyncedAt).
+				Should(BeTemporally(">", after))
+			g.Expect(connection.LastSyncError).Should(BeEmpty())
+		}).WithTimeout(radicaleSyncTimeout).
+			WithPolling(radicalePollInterval).
+			Should(Succeed())
+	}
+
+	Describe("Connect CalDAV", func() {
+		It("should not connect to the non-public addresses", func() {
+			for _, calendarURL := range []string{
+				"http://127.0.0.1:5232" + calendarPath,
+				"http://localhost:5232" + calendarPath,
+				"http://169.254.169.254/latest/meta-data/",
+				"http://10.0.0.1/calendars/interviewer/",
+				"http://[::1]:5232" + calendarPath,
+			} {
+				fmt.Fprintf(GinkgoWriter, "### Testing: %s\n", calendarURL)
+				testPOST(
+					interviewerToken,
+					employer.ConnectCalDAVRequest{
+						CalendarURL: calendarURL,
+						Username:    radicaleUsername,
+						Password:    radicalePassword,
+					},
+					"/employer/connect-caldav",
+					http.StatusBadRequest,
+				)
+			}
+
+			testPOST(
+				interviewerToken,
+				nil,
+				"/employer/get-caldav-connection",
+				http.StatusNotFound,
+			)
+		})
+
+		It("should not connect with the wrong credentials", func() {
+			testPOST(
+				interviewerToken,
+				employer.ConnectCalDAVRequest{
+					CalendarURL: radicaleClusterURL + calendarPath,
+					Username:    radicaleUsername,
+					Password:    "wrong-password",
+				},
+				"/employer/connect-caldav",
+				http.StatusUnprocessableEntity,
+			)
+
+			// An allowed host that does not serve CalDAV
+			testPOST(
+				interviewerToken,
+				employer.ConnectCalDAVRequest{
+					CalendarURL: "http://radicale:1" + calendarPath,
+					Username:    radicaleUsername,
+					Password:    radicalePassword,
+				},
+				"/employer/connect-caldav",
+				http.StatusUnprocessableEntity,
+			)
+		})
+
+		It("should connect the calendar", func() {
+			testPOST(
+				interviewerToken,
+				employer.ConnectCalDAVRequest{
+					CalendarURL: radicaleClusterURL + calendarPath,
+					Username:    radicaleUsername,
+					Password:    radicalePassword,
+				},
+				"/employer/connect-caldav",
+				http.StatusOK,
+			)
+
+			connection := getConnection(Default)
+			Expect(connection.CalendarURL).
+				Should(Equal(radicaleClusterURL + calendarPath))
+			Expect(connection.Username).Should(Equal(radicaleUsername))
+		})
+	})
+
+	Describe("Sync", func() {
+		It("should not offer the slots that are busy in the calendar", func() {
+			waitForSync(time.Time{})
+
+			var windows []employer.AvailabilityWindow
+			for day := 0; day < 7; day++ {
+				windows = append(windows, employer.AvailabilityWindow{
+					DayOfWeek: day,
+					StartTime: "09:00",
+					EndTime:   "17:00",
+				})
+			}
+			testPOST(
+				interviewerToken,
+				employer.OrgUserAvailability{
+					TimeZone: "UTC Coordinated Universal Time GMT+0000",
+					Windows:  windows,
+				},
+				"/employer/set-my-availability",
+				http.StatusOK,
+			)
+
+			resp := testPOSTGetResp(
+				adminToken,
+				employer.CreateSchedulingRequestRequest{
+					CandidacyID:     "CAND-0062-1",
+					InterviewType:   common.VideoCallInterviewType,
+					DurationMinutes: 60,
+					WindowStart:     time.Now().UTC(),
+					WindowEnd:       time.Now().UTC().Add(72 * time.Hour),
+					InterviewerEmails: []string{
+						"interviewer@caldav-radicale.example",
+					},
+				},
+				"/employer/create-scheduling-request",
+				http.StatusOK,
+			).([]byte)
+			var createResp employer.CreateSchedulingRequestResponse
+			err := json.Unmarshal(resp, &createResp)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			resp = testPOSTGetResp(
+				candidateToken,
+				hub.GetInterviewSlotsRequest{
+					SchedulingRequestID: createResp.SchedulingRequestID,
+				},
+				"/hub/get-interview-slots",
+				http.StatusOK,
+			).([]byte)
+			var slots hub.InterviewSlots
+			err = json.Unmarshal(resp, &slots)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			testPOST(
+				adminToken,
+				employer.CancelSchedulingRequestRequest{
+					SchedulingRequestID: createResp.SchedulingRequestID,
+				},
+				"/employer/cancel-scheduling-request",
+				http.StatusOK,
+			)
+
+			var starts []time.Time
+			for _, slot := range slots.Slots {
+				starts = append(starts, slot.StartTime.UTC())
+			}
+			for _, hour := range []time.Duration{9, 12, 15} {
+				Expect(starts).Should(ContainElement(BeTemporally(
+					"==",
+					busyDay.Add(hour*time.Hour),
+				)))
+			}
+			for _, minutes := range []time.Duration{570, 600, 630, 660, 690} {
+				Expect(starts).ShouldNot(ContainElement(BeTemporally(
+					"==",
+					busyDay.Add(minutes*time.Minute),
+				)))
+			}
+		})
+
+		It("should write the accepted interviews to the calendar", func() {
+			status, event := radicale(
+				http.MethodGet,
+				calendarPath+radicaleInterviewID+".ics",
+				"",
+			)
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(event).Should(ContainSubstring(
+				"UID:" + radicaleInterviewID + "@vetchi.org",
+			))
+			Expect(event).Should(ContainSubstring(
+				"CalDAV Radicale Opening",
+			))
+			Expect(event).ShouldNot(ContainSubstring("ATTENDEE"))
+		})
+
+		It("should remove the cancelled interviews from the calendar", func() {
+			testPOST(
+				adminToken,
+				employer.CancelInterviewRequest{
+					InterviewID: radicaleInterviewID,
+					Reason:      "Position is on hold",
+				},
+				"/employer/cancel-interview",
+				http.StatusOK,
+			)
+
+			waitForSync(syncNow())
+
+			status, _ := radicale(
+				http.MethodGet,
+				calendarPath+radicaleInterviewID+".ics",
+				"",
+			)
+			Expect(status).Should(Equal(http.StatusNotFound))
+		})
+	})
+})
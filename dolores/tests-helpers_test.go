@@ -474,3 +474,7 @@ func hubSigninAsync(
 func strptr(s string) *string {
 	return &s
 }
+
+func intptr(i int) *int {
+	return &i
+}
@@ -156,6 +156,10 @@ export default function CandidacyDetailPage() {
   const [openRejectDialog, setOpenRejectDialog] = useState(false);
   const [openUnresponsiveDialog, setOpenUnresponsiveDialog] = useState(false);
   const [selectedFile, setSelectedFile] = useState<File | null>(null);
+  const [offerAmount, setOfferAmount] = useState("");
+  const [offerCurrency, setOfferCurrency] = useState("");
+  const [offerStartDate, setOfferStartDate] = useState("");
+  const [offerExpiresAt, setOfferExpiresAt] = useState("");
   const [snackbar, setSnackbar] = useState<{
     open: boolean;
     message: string;
@@ -333,7 +337,7 @@ export default function CandidacyDetailPage() {
       }
 
       // Convert file to base64 if it exists
-      let offerDocument;
+      let attachments;
       if (selectedFile) {
         const reader = new FileReader();
         const base64Promise = new Promise<string>((resolve, reject) => {
@@ -344,12 +348,20 @@ export default function CandidacyDetailPage() {
           reader.onerror = reject;
         });
         reader.readAsDataURL(selectedFile);
-        offerDocument = await base64Promise;
+        attachments = [
+          { filename: selectedFile.name, document: await base64Promise },
+        ];
       }
 
       const request: OfferToCandidateRequest = {
         candidacy_id: params.id as string,
-        offer_document: offerDocument,
+        compensation: {
+          amount: Number(offerAmount),
+          currency: offerCurrency,
+        },
+        start_date: offerStartDate,
+        expires_at: new Date(offerExpiresAt),
+        attachments: attachments,
       };
 
       const response = await fetch(
@@ -1065,6 +1077,42 @@ export default function CandidacyDetailPage() {
                 <li>{t("candidacies.dialogEffects.cancelInterviews")}</li>
                 <li>{t("candidacies.dialogEffects.uploadOffer")}</li>
               </ul>
+              <TextField
+                fullWidth
+                type="number"
+                label={t("candidacies.makeOffer.amount")}
+                value={offerAmount}
+                onChange={(e) => setOfferAmount(e.target.value)}
+                sx={{ mt: 2 }}
+              />
+              <TextField
+                fullWidth
+                label={t("candidacies.makeOffer.currency")}
+                value={offerCurrency}
+                onChange={(e) =>
+                  setOfferCurrency(e.target.value.toUpperCase())
+                }
+                inputProps={{ maxLength: 3 }}
+                sx={{ mt: 2 }}
+              />
+              <TextField
+                fullWidth
+                type="date"
+                label={t("candidacies.makeOffer.startDate")}
+                value={offerStartDate}
+                onChange={(e) => setOfferStartDate(e.target.value)}
+                InputLabelProps={{ shrink: true }}
+                sx={{ mt: 2 }}
+              />
+              <TextField
+                fullWidth
+                type="datetime-local"
+                label={t("candidacies.makeOffer.expiresAt")}
+                value={offerExpiresAt}
+                onChange={(e) => setOfferExpiresAt(e.target.value)}
+                InputLabelProps={{ shrink: true }}
+                sx={{ mt: 2 }}
+              />
               <Button variant="outlined" component="label" sx={{ mt: 2 }}>
                 {t("candidacies.makeOffer.uploadButton")}
                 <input
@@ -1087,7 +1135,12 @@ export default function CandidacyDetailPage() {
               <Button
                 onClick={handleMakeOffer}
                 variant="contained"
-                disabled={!selectedFile}
+                disabled={
+                  !offerAmount ||
+                  offerCurrency.length !== 3 ||
+                  !offerStartDate ||
+                  !offerExpiresAt
+                }
               >
                 {t("candidacies.dialogActions.confirm")}
               </Button>
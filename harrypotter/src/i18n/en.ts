@@ -304,12 +304,16 @@ export const en = {
     makeOffer: {
       title: "Make Offer",
       description:
-        "Upload an offer letter (PDF) and change the candidacy state to OFFERED. All pending interviews will be marked as cancelled.",
+        "Make an offer with the compensation, start date and expiry, and change the candidacy state to OFFERED. An offer letter is generated for the candidate and all pending interviews will be marked as cancelled.",
       button: "Make Offer",
       confirmTitle: "Confirm Make Offer",
       confirmDescription:
         "Are you sure you want to make an offer to this candidate?",
-      uploadButton: "Upload Offer Letter (PDF)",
+      amount: "Compensation Amount",
+      currency: "Currency (e.g. USD)",
+      startDate: "Start Date",
+      expiresAt: "Respond By",
+      uploadButton: "Attach a Document (PDF, optional)",
       selectedFile: "Selected file:",
       error: "Failed to make offer to candidate",
       success: "Offer has been successfully made to the candidate",
@@ -341,7 +345,7 @@ export const en = {
       title: "This action will:",
       cancelInterviews: "Mark all pending interviews as cancelled",
       stateChange: "Change the candidacy state to",
-      uploadOffer: "Upload the offer letter",
+      uploadOffer: "Send the generated offer letter to the candidate",
     },
     states: {
       INTERVIEWING: "Interviewing",
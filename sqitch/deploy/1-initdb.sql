@@ -89,6 +89,12 @@ CREATE TABLE IF NOT EXISTS hub_users (
     long_bio TEXT NOT NULL,
     profile_picture_url TEXT,
     timeline_last_refreshed_at TIMESTAMP WITH TIME ZONE,
+    -- Set by granger when the recommended openings are computed, NULL to
+    -- have them recomputed at the earliest
+    recommendations_refreshed_at TIMESTAMP WITH TIME ZONE,
+    -- Whether the employers may find the hub user for their openings and
+    -- invite them to apply
+    discoverable BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
     CONSTRAINT unique_handle UNIQUE (handle),
     CONSTRAINT unique_email UNIQUE (email)
@@ -131,6 +137,8 @@ CREATE TABLE emails(
 	email_html_body TEXT NOT NULL,
 	email_text_body TEXT NOT NULL,
 	email_state email_states NOT NULL,
+	-- RFC 5545 iCalendar object sent as an attachment along with the email
+	email_calendar_event TEXT,
 	created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
 	processed_at TIMESTAMP WITH TIME ZONE
 );
@@ -144,6 +152,11 @@ CREATE TYPE employer_states AS ENUM (
     'DEBOARDED',
     'HUB_ADDED_EMPLOYER'
 );
+CREATE TYPE meeting_providers AS ENUM (
+    'MANUAL',
+    'JITSI'
+);
+
 CREATE TABLE employers (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     client_id_type client_id_types NOT NULL,
@@ -165,6 +178,35 @@ CREATE TABLE employers (
     cool_off_period_days INTEGER NOT NULL DEFAULT 60,
     CONSTRAINT positive_cool_off_period CHECK (cool_off_period_days >= 0),
 
+    -- Number of days after which an application that is still in the APPLIED
+    -- state is moved to the EXPIRED state. A value of 0 means never expire
+    application_expiry_days INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT positive_application_expiry CHECK (application_expiry_days >= 0),
+
+    -- Whether an opening is closed once all its positions are filled
+    auto_close_filled_openings BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Creates the meeting rooms of the VIDEO_CALL interviews
+    meeting_provider meeting_providers NOT NULL DEFAULT 'MANUAL',
+
+    -- Relative weights of the parts of the candidate in the composite match
+    -- score of the applications
+    match_weight_resume INTEGER NOT NULL DEFAULT 50,
+    match_weight_long_bio INTEGER NOT NULL DEFAULT 20,
+    match_weight_certifications INTEGER NOT NULL DEFAULT 15,
+    match_weight_education INTEGER NOT NULL DEFAULT 5,
+    match_weight_verified_work_history INTEGER NOT NULL DEFAULT 10,
+    CONSTRAINT valid_match_weights CHECK (
+        match_weight_resume BETWEEN 0 AND 100
+        AND match_weight_long_bio BETWEEN 0 AND 100
+        AND match_weight_certifications BETWEEN 0 AND 100
+        AND match_weight_education BETWEEN 0 AND 100
+        AND match_weight_verified_work_history BETWEEN 0 AND 100
+        AND match_weight_resume + match_weight_long_bio
+            + match_weight_certifications + match_weight_education
+            + match_weight_verified_work_history > 0
+    ),
+
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
 );
 
@@ -378,6 +420,33 @@ CREATE TABLE openings (
     salary_currency TEXT,
     state opening_states NOT NULL,
 
+    -- Applied by granger. A DRAFT opening is published at publish_at, an
+    -- ACTIVE or SUSPENDED opening is closed at close_at and an ACTIVE opening
+    -- is suspended once it has max_applications APPLIED or SHORTLISTED
+    -- applications
+    publish_at TIMESTAMP WITH TIME ZONE,
+    close_at TIMESTAMP WITH TIME ZONE,
+    max_applications INTEGER,
+
+    -- Set by granger when it suspends an ACTIVE opening for reaching its
+    -- max_applications, so that a manual reactivation is not undone. Cleared
+    -- when max_applications is changed.
+    max_applications_reached_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT close_after_publish CHECK (close_at IS NULL OR publish_at IS NULL OR close_at > publish_at),
+    CONSTRAINT positive_max_applications CHECK (max_applications IS NULL OR max_applications > 0),
+
+    -- Whether an ACTIVE opening is listed on the public careers page and
+    -- job feed of the employer
+    public_listing BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Set by granger when the hub users suggested for an ACTIVE opening are
+    -- computed, NULL to have them recomputed at the earliest
+    candidate_suggestions_refreshed_at TIMESTAMP WITH TIME ZONE,
+
+    -- When the opening last became ACTIVE, for the saved searches of the hub
+    -- users to match the newly ACTIVE openings
+    activated_at TIMESTAMP WITH TIME ZONE,
+
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
     last_updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
 
@@ -461,6 +530,135 @@ CREATE TABLE candidacies(
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
 );
 
+CREATE TYPE candidacy_actor_types AS ENUM ('ORG_USER', 'HUB_USER', 'SYSTEM');
+
+-- The append-only history of the state changes of the candidacies. The
+-- creation of a candidacy is recorded with a NULL from_state. Rows are never
+-- updated, except for dispatched_at once the hooks have run for the event.
+CREATE TABLE candidacy_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    candidacy_id TEXT REFERENCES candidacies(id) NOT NULL,
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    from_state candidacy_states,
+    to_state candidacy_states NOT NULL,
+
+    -- Only one of the users will be populated based on actor_type and none
+    -- for the SYSTEM actor
+    actor_type candidacy_actor_types NOT NULL,
+    org_user_id UUID REFERENCES org_users(id),
+    hub_user_id UUID REFERENCES hub_users(id),
+    CONSTRAINT valid_actor CHECK (
+        (actor_type = 'ORG_USER' AND org_user_id IS NOT NULL AND hub_user_id IS NULL) OR
+        (actor_type = 'HUB_USER' AND hub_user_id IS NOT NULL AND org_user_id IS NULL) OR
+        (actor_type = 'SYSTEM' AND org_user_id IS NULL AND hub_user_id IS NULL)
+    ),
+
+    reason TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    dispatched_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX idx_candidacy_events_candidacy_id ON candidacy_events (candidacy_id, created_at);
+CREATE INDEX idx_candidacy_events_undispatched ON candidacy_events (created_at) WHERE dispatched_at IS NULL;
+
+-- A filled position of an opening. Created when a candidacy reaches the
+-- OFFER_ACCEPTED state
+CREATE TABLE opening_hires (
+    candidacy_id TEXT PRIMARY KEY REFERENCES candidacies(id),
+
+    employer_id UUID NOT NULL,
+    opening_id TEXT NOT NULL,
+    CONSTRAINT fk_opening FOREIGN KEY (employer_id, opening_id) REFERENCES openings (employer_id, id),
+
+    start_date DATE,
+    -- NULL if the position is a new headcount
+    backfill_reason TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+
+-- Only the latest version of the offers of a candidacy can be PENDING. A
+-- revision supersedes the pending offer with a new version.
+CREATE TYPE offer_states AS ENUM (
+    'PENDING_OFFER',
+    'ACCEPTED_OFFER',
+    'DECLINED_OFFER',
+    'RESCINDED_OFFER',
+    'EXPIRED_OFFER',
+    'SUPERSEDED_OFFER'
+);
+CREATE TABLE candidacy_offers (
+    candidacy_id TEXT REFERENCES candidacies(id) NOT NULL,
+    version INTEGER NOT NULL,
+    PRIMARY KEY (candidacy_id, version),
+
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    compensation_amount NUMERIC NOT NULL,
+    compensation_currency TEXT NOT NULL,
+    compensation_notes TEXT,
+    start_date DATE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Object storage path of the generated offer letter
+    offer_letter_path TEXT NOT NULL,
+
+    offer_state offer_states NOT NULL,
+    -- Reason given by the candidate for declining or by the employer for
+    -- rescinding the offer
+    response_reason TEXT,
+    responded_at TIMESTAMP WITH TIME ZONE,
+
+    created_by UUID REFERENCES org_users(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+CREATE UNIQUE INDEX uniq_pending_offer_per_candidacy ON candidacy_offers (candidacy_id) WHERE offer_state = 'PENDING_OFFER';
+CREATE INDEX idx_candidacy_offers_expires_at ON candidacy_offers (expires_at) WHERE offer_state = 'PENDING_OFFER';
+
+CREATE TABLE candidacy_offer_attachments (
+    candidacy_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    CONSTRAINT fk_offer FOREIGN KEY (candidacy_id, version) REFERENCES candidacy_offers (candidacy_id, version),
+
+    attachment_number INTEGER NOT NULL,
+    PRIMARY KEY (candidacy_id, version, attachment_number),
+
+    filename TEXT NOT NULL,
+    -- Object storage path of the uploaded document
+    file_path TEXT NOT NULL
+);
+
+CREATE TYPE offer_signature_states AS ENUM (
+    'SENT_FOR_SIGNATURE',
+    'SIGNED',
+    'SIGNATURE_DECLINED',
+    'SIGNATURE_VOIDED'
+);
+CREATE TABLE candidacy_offer_signatures (
+    candidacy_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    CONSTRAINT fk_offer FOREIGN KEY (candidacy_id, version) REFERENCES candidacy_offers (candidacy_id, version),
+    PRIMARY KEY (candidacy_id, version),
+
+    -- Name of the e-signature provider and its id for the envelope
+    provider TEXT NOT NULL,
+    envelope_id TEXT NOT NULL UNIQUE,
+
+    signature_state offer_signature_states NOT NULL,
+    -- Object storage path of the document signed by the candidate
+    signed_document_path TEXT,
+
+    -- Granger polls the provider for the status of the envelope at this time.
+    -- Webhooks from the provider move this to now()
+    next_poll_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+
+    sent_by UUID REFERENCES org_users(id) NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX idx_candidacy_offer_signatures_next_poll_at ON candidacy_offer_signatures (next_poll_at) WHERE signature_state = 'SENT_FOR_SIGNATURE';
+
 CREATE TYPE comment_author_types AS ENUM ('ORG_USER', 'HUB_USER');
 CREATE TABLE candidacy_comments (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -475,6 +673,12 @@ CREATE TABLE candidacy_comments (
     comment_text TEXT NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
 
+    -- The comments can be edited or retracted by their authors for a short
+    -- while after their creation. A retracted comment is kept, with its
+    -- edits and attachments, but is not shown anymore.
+    edited_at TIMESTAMP WITH TIME ZONE,
+    retracted_at TIMESTAMP WITH TIME ZONE,
+
     -- Ensure exactly one user type is specified
     CONSTRAINT check_single_author CHECK (
         (author_type = 'ORG_USER' AND org_user_id IS NOT NULL AND hub_user_id IS NULL) OR
@@ -488,6 +692,45 @@ CREATE TABLE candidacy_comments (
     CONSTRAINT fk_employer FOREIGN KEY (employer_id) REFERENCES employers(id)
 );
 
+CREATE TABLE candidacy_comment_attachments (
+    comment_id UUID REFERENCES candidacy_comments(id) ON DELETE CASCADE NOT NULL,
+    attachment_number INTEGER NOT NULL,
+    PRIMARY KEY (comment_id, attachment_number),
+
+    filename TEXT NOT NULL,
+    -- Object storage path of the uploaded file
+    file_path TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL
+);
+
+-- The earlier contents of the edited comments
+CREATE TABLE candidacy_comment_edits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID REFERENCES candidacy_comments(id) ON DELETE CASCADE NOT NULL,
+    previous_text TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+CREATE INDEX idx_candidacy_comment_edits_comment_id ON candidacy_comment_edits (comment_id, edited_at);
+
+-- The read receipts of the participants of a candidacy. A participant has
+-- read all the comments created at or before the last_read_at.
+CREATE TABLE candidacy_comment_reads (
+    candidacy_id TEXT REFERENCES candidacies(id) ON DELETE CASCADE NOT NULL,
+
+    reader_type comment_author_types NOT NULL,
+    org_user_id UUID REFERENCES org_users(id) ON DELETE CASCADE,
+    hub_user_id UUID REFERENCES hub_users(id) ON DELETE CASCADE,
+    CONSTRAINT check_single_reader CHECK (
+        (reader_type = 'ORG_USER' AND org_user_id IS NOT NULL AND hub_user_id IS NULL) OR
+        (reader_type = 'HUB_USER' AND hub_user_id IS NOT NULL AND org_user_id IS NULL)
+    ),
+
+    last_read_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE UNIQUE INDEX uniq_org_user_comment_reads ON candidacy_comment_reads (candidacy_id, org_user_id) WHERE org_user_id IS NOT NULL;
+CREATE UNIQUE INDEX uniq_hub_user_comment_reads ON candidacy_comment_reads (candidacy_id, hub_user_id) WHERE hub_user_id IS NOT NULL;
+
 ---
 
 CREATE TYPE interview_types AS ENUM (
@@ -526,6 +769,15 @@ CREATE TABLE interviews(
 
     description TEXT,
 
+    -- SEQUENCE of the iCalendar event, bumped whenever the calendar entries
+    -- of the attendees need to be updated in place
+    ical_sequence INTEGER NOT NULL DEFAULT 0,
+
+    -- The provider that created the meeting room of a VIDEO_CALL interview
+    -- and the link to the room, which is NULL if there is no room
+    meeting_provider meeting_providers,
+    meeting_url TEXT,
+
     created_by UUID REFERENCES org_users(id) NOT NULL,
 
     candidacy_id TEXT REFERENCES candidacies(id) NOT NULL,
@@ -566,6 +818,277 @@ CREATE TABLE interview_interviewers (
     PRIMARY KEY (interview_id, interviewer_id)
 );
 
+-- The rubric of an Opening, on which each interviewer rates the candidates
+CREATE TABLE opening_competencies (
+    employer_id UUID NOT NULL,
+    opening_id TEXT NOT NULL,
+    CONSTRAINT fk_opening FOREIGN KEY (employer_id, opening_id) REFERENCES openings (employer_id, id),
+
+    name TEXT NOT NULL,
+    description TEXT,
+    display_order INTEGER NOT NULL,
+
+    PRIMARY KEY (employer_id, opening_id, name)
+);
+
+-- The feedback of each interviewer of an interview. Not tied to
+-- interview_interviewers, so that the scorecard of an interviewer is retained
+-- even if they are removed from the interview afterwards.
+CREATE TABLE interview_scorecards (
+    interview_id TEXT REFERENCES interviews(id) NOT NULL,
+    interviewer_id UUID REFERENCES org_users(id) NOT NULL,
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    interviewers_decision interviewers_decisions NOT NULL,
+    positives TEXT,
+    negatives TEXT,
+    overall_assessment TEXT,
+    feedback_to_candidate TEXT,
+
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+
+    PRIMARY KEY (interview_id, interviewer_id)
+);
+
+-- The competency is not a foreign key to opening_competencies, so that the
+-- ratings are retained even if the rubric changes later
+CREATE TABLE scorecard_ratings (
+    interview_id TEXT NOT NULL,
+    interviewer_id UUID NOT NULL,
+    CONSTRAINT fk_scorecard FOREIGN KEY (interview_id, interviewer_id) REFERENCES interview_scorecards (interview_id, interviewer_id) ON DELETE CASCADE,
+
+    competency TEXT NOT NULL,
+    rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    notes TEXT,
+
+    PRIMARY KEY (interview_id, interviewer_id, competency)
+);
+
+CREATE TYPE interview_change_types AS ENUM (
+    'RESCHEDULED',
+    'CANCELLED',
+    'RESCHEDULE_REQUESTED'
+);
+
+-- The history of the changes made to an interview after it was scheduled
+CREATE TABLE interview_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    interview_id TEXT REFERENCES interviews(id) NOT NULL,
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    change_type interview_change_types NOT NULL,
+
+    previous_start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    previous_end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Populated only for the RESCHEDULED changes
+    new_start_time TIMESTAMP WITH TIME ZONE,
+    new_end_time TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT valid_reschedule CHECK (
+        (change_type = 'RESCHEDULED' AND new_start_time IS NOT NULL AND new_end_time IS NOT NULL) OR
+        (change_type != 'RESCHEDULED' AND new_start_time IS NULL AND new_end_time IS NULL)
+    ),
+
+    -- The alternative slots proposed by the candidate, as an array of
+    -- {start_time, end_time} objects. Populated only for RESCHEDULE_REQUESTED
+    proposed_slots JSONB,
+
+    reason TEXT,
+
+    -- Only one of these will be populated based on author_type
+    author_type comment_author_types NOT NULL,
+    org_user_id UUID REFERENCES org_users(id),
+    hub_user_id UUID REFERENCES hub_users(id),
+    CONSTRAINT check_single_author CHECK (
+        (author_type = 'ORG_USER' AND org_user_id IS NOT NULL AND hub_user_id IS NULL) OR
+        (author_type = 'HUB_USER' AND hub_user_id IS NOT NULL AND org_user_id IS NULL)
+    ),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+CREATE INDEX idx_interview_changes_interview_id ON interview_changes(interview_id);
+
+-- The secret tokens of the private iCalendar subscription feeds of the
+-- upcoming interviews of an org user or a hub user
+CREATE TABLE calendar_feeds (
+    token TEXT PRIMARY KEY,
+
+    -- Only one of these will be populated based on owner_type
+    owner_type comment_author_types NOT NULL,
+    org_user_id UUID UNIQUE REFERENCES org_users(id),
+    hub_user_id UUID UNIQUE REFERENCES hub_users(id),
+    CONSTRAINT check_single_owner CHECK (
+        (owner_type = 'ORG_USER' AND org_user_id IS NOT NULL AND hub_user_id IS NULL) OR
+        (owner_type = 'HUB_USER' AND hub_user_id IS NOT NULL AND org_user_id IS NULL)
+    ),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+
+-- The weekly recurring windows, as an array of {day_of_week, start_time,
+-- end_time} objects in the time_zone of the org user, during which the org
+-- user can take interviews
+CREATE TABLE org_user_availability (
+    org_user_id UUID PRIMARY KEY REFERENCES org_users(id),
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    time_zone TEXT NOT NULL,
+    windows JSONB NOT NULL,
+
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+
+-- The CalDAV calendar of an org user, whose free/busy is synced by granger
+-- and to which the confirmed interviews of the org user are written back.
+-- The password is encrypted with the CALENDAR_CREDENTIALS_KEY.
+CREATE TABLE caldav_connections (
+    org_user_id UUID PRIMARY KEY REFERENCES org_users(id),
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    calendar_url TEXT NOT NULL,
+    username TEXT NOT NULL,
+    encrypted_password BYTEA NOT NULL,
+
+    last_synced_at TIMESTAMP WITH TIME ZONE,
+    last_sync_error TEXT,
+    next_sync_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+CREATE INDEX idx_caldav_connections_next_sync_at ON caldav_connections(next_sync_at);
+
+-- The busy periods of the CalDAV calendar as of the last sync
+CREATE TABLE caldav_busy_periods (
+    org_user_id UUID REFERENCES caldav_connections(org_user_id) ON DELETE CASCADE NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT valid_busy_period CHECK (end_time > start_time)
+);
+CREATE INDEX idx_caldav_busy_periods_org_user_id ON caldav_busy_periods(org_user_id);
+
+-- The interviews written back to the CalDAV calendar, along with the
+-- ical_sequence of the interview when it was written
+CREATE TABLE caldav_written_events (
+    org_user_id UUID REFERENCES caldav_connections(org_user_id) ON DELETE CASCADE NOT NULL,
+    interview_id TEXT REFERENCES interviews(id) NOT NULL,
+    ical_sequence INTEGER NOT NULL,
+    written_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+
+    PRIMARY KEY (org_user_id, interview_id)
+);
+
+CREATE TYPE late_submission_policies AS ENUM ('REJECT_LATE', 'ACCEPT_LATE');
+
+-- The assignment of a TAKE_HOME interview. It is available to the candidate
+-- from the start_time of the interview and the end_time of the interview is
+-- the deadline. If time_limit_minutes is set, the candidate has to start the
+-- assignment, after which the deadline is the earlier of the end_time and
+-- the time limit.
+CREATE TABLE take_home_assignments (
+    interview_id TEXT PRIMARY KEY REFERENCES interviews(id),
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    brief TEXT NOT NULL,
+    time_limit_minutes INTEGER CHECK (time_limit_minutes > 0),
+    late_submission_policy late_submission_policies NOT NULL,
+    grace_period_minutes INTEGER NOT NULL DEFAULT 0 CHECK (grace_period_minutes >= 0),
+
+    started_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT valid_start CHECK (started_at IS NULL OR time_limit_minutes IS NOT NULL),
+
+    -- Cleared when the interview is rescheduled, so that the candidate is
+    -- reminded of the new deadline
+    reminder_sent_at TIMESTAMP WITH TIME ZONE,
+
+    created_by UUID REFERENCES org_users(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+
+-- The files of the brief of an assignment, stored in S3 under file_path
+CREATE TABLE take_home_assignment_files (
+    interview_id TEXT REFERENCES take_home_assignments(interview_id) ON DELETE CASCADE NOT NULL,
+    file_number INTEGER NOT NULL,
+    filename TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+
+    PRIMARY KEY (interview_id, file_number)
+);
+
+CREATE TABLE take_home_submissions (
+    interview_id TEXT REFERENCES take_home_assignments(interview_id) NOT NULL,
+    submission_number INTEGER NOT NULL,
+    filename TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    is_late BOOLEAN NOT NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+
+    PRIMARY KEY (interview_id, submission_number)
+);
+
+CREATE TYPE scheduling_request_states AS ENUM (
+    'OPEN',
+    'BOOKED',
+    'CANCELLED'
+);
+
+-- A request to the candidate to pick an interview slot, in which all the
+-- interviewers of the pool are free, between window_start and window_end
+CREATE TABLE interview_scheduling_requests (
+    id TEXT PRIMARY KEY,
+    candidacy_id TEXT REFERENCES candidacies(id) NOT NULL,
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    interview_type interview_types NOT NULL,
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+    description TEXT,
+
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT valid_window CHECK (window_end > window_start),
+
+    -- The meeting room of a VIDEO_CALL interview is created when the
+    -- candidate books a slot. meeting_url is the link pasted for the
+    -- MANUAL provider.
+    meeting_provider meeting_providers,
+    meeting_url TEXT,
+
+    scheduling_request_state scheduling_request_states NOT NULL DEFAULT 'OPEN',
+
+    -- Populated when the candidate books a slot
+    interview_id TEXT REFERENCES interviews(id),
+    CONSTRAINT valid_booking CHECK (
+        (scheduling_request_state = 'BOOKED' AND interview_id IS NOT NULL) OR
+        (scheduling_request_state != 'BOOKED' AND interview_id IS NULL)
+    ),
+
+    created_by UUID REFERENCES org_users(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+CREATE INDEX idx_scheduling_requests_candidacy_id ON interview_scheduling_requests(candidacy_id);
+
+CREATE TABLE scheduling_request_interviewers (
+    scheduling_request_id TEXT REFERENCES interview_scheduling_requests(id) NOT NULL,
+    interviewer_id UUID REFERENCES org_users(id) NOT NULL,
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+
+    PRIMARY KEY (scheduling_request_id, interviewer_id)
+);
+
+-- The slot held by the candidate while confirming the booking. A hold is
+-- ignored once it expires, and is replaced when the candidate holds another
+-- slot of the same scheduling request.
+CREATE TABLE interview_slot_holds (
+    scheduling_request_id TEXT PRIMARY KEY REFERENCES interview_scheduling_requests(id),
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
 CREATE TABLE tags (
     id TEXT PRIMARY KEY,
     display_name TEXT NOT NULL UNIQUE,
@@ -580,6 +1103,83 @@ CREATE TABLE opening_tag_mappings (
     PRIMARY KEY (employer_id, opening_id, tag_id)
 );
 
+CREATE TABLE opening_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    CONSTRAINT uniq_opening_template_name UNIQUE (employer_id, name),
+
+    -- title, jd and employer_notes may contain {{variable}} placeholders
+    -- which are resolved when an opening is created from the template
+    title TEXT NOT NULL,
+    positions INTEGER NOT NULL,
+    jd TEXT NOT NULL,
+    recruiter UUID REFERENCES org_users(id) NOT NULL,
+    hiring_manager UUID REFERENCES org_users(id) NOT NULL,
+    cost_center_id UUID REFERENCES org_cost_centers(id) NOT NULL,
+    employer_notes TEXT,
+    remote_country_codes TEXT[],
+    remote_timezones TEXT[],
+    opening_type opening_types NOT NULL,
+    yoe_min INTEGER NOT NULL,
+    yoe_max INTEGER NOT NULL,
+    min_education_level education_levels NOT NULL,
+    salary_min NUMERIC,
+    salary_max NUMERIC,
+    salary_currency TEXT,
+
+    created_by UUID REFERENCES org_users(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    last_updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+
+CREATE TABLE opening_template_hiring_team (
+    template_id UUID REFERENCES opening_templates(id) ON DELETE CASCADE NOT NULL,
+    hiring_team_mate_id UUID REFERENCES org_users(id) NOT NULL,
+    PRIMARY KEY (template_id, hiring_team_mate_id)
+);
+
+CREATE TABLE opening_template_locations (
+    template_id UUID REFERENCES opening_templates(id) ON DELETE CASCADE NOT NULL,
+    location_id UUID REFERENCES locations(id) NOT NULL,
+    PRIMARY KEY (template_id, location_id)
+);
+
+CREATE TABLE opening_template_tag_mappings (
+    template_id UUID REFERENCES opening_templates(id) ON DELETE CASCADE NOT NULL,
+    tag_id TEXT REFERENCES tags(id) NOT NULL,
+    PRIMARY KEY (template_id, tag_id)
+);
+
+CREATE TABLE cost_center_approvers (
+    cost_center_id UUID REFERENCES org_cost_centers(id) NOT NULL,
+    step_number INTEGER NOT NULL,
+    approver_id UUID REFERENCES org_users(id) NOT NULL,
+    PRIMARY KEY (cost_center_id, step_number),
+    CONSTRAINT uniq_cost_center_approver UNIQUE (cost_center_id, approver_id)
+);
+
+CREATE TYPE opening_approval_states AS ENUM ('PENDING_APPROVAL', 'APPROVED', 'REJECTED');
+-- A snapshot of the approval chain of the cost center, taken when an opening
+-- is submitted for approval. Resubmitting replaces the rows.
+CREATE TABLE opening_approvals (
+    employer_id UUID NOT NULL,
+    opening_id TEXT NOT NULL,
+    CONSTRAINT fk_opening FOREIGN KEY (employer_id, opening_id) REFERENCES openings (employer_id, id),
+
+    step_number INTEGER NOT NULL,
+    approver_id UUID REFERENCES org_users(id) NOT NULL,
+    approval_state opening_approval_states NOT NULL,
+    comment TEXT,
+    decided_at TIMESTAMP WITH TIME ZONE,
+
+    requested_by UUID REFERENCES org_users(id) NOT NULL,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+
+    PRIMARY KEY (employer_id, opening_id, step_number)
+);
+
 CREATE OR REPLACE FUNCTION get_or_create_dummy_employer(p_domain_name text)
 RETURNS UUID AS $$
 DECLARE
@@ -906,16 +1506,302 @@ CREATE TABLE application_scores (
     application_id TEXT REFERENCES applications(id) NOT NULL,
     model_name TEXT REFERENCES application_scoring_models(model_name) NOT NULL,
     score INTEGER NOT NULL, -- 0-100 score
+    -- Why the model gave the score, as a ScoreExplanation, if it tells
+    explanation JSONB,
+    -- Set when the JD changes or the model is activated again. The stale
+    -- scores are shown till they are replaced by the rescoring.
+    stale BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
     CONSTRAINT score_range CHECK (score >= 0 AND score <= 100),
     CONSTRAINT unique_application_model UNIQUE (application_id, model_name)
 );
 
+-- The scoring of each application by granger. An application is leased by
+-- a granger replica while it is scored, so that the replicas do not score
+-- it twice. The attempts are counted when leased, so that a resume that
+-- crashes granger also runs out of its attempts. Failed applications are
+-- retried with a backoff, and are dead lettered after the last attempt.
+-- The scoring of each application by each model is retried and dead
+-- lettered on its own, so that a model that keeps failing does not hold
+-- back the scores of the others
+CREATE TABLE application_scoring_state (
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    model_name TEXT NOT NULL REFERENCES application_scoring_models(model_name),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    leased_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    dead_lettered_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    PRIMARY KEY (application_id, model_name)
+);
+
+CREATE INDEX idx_application_scoring_state_dead_lettered
+    ON application_scoring_state(dead_lettered_at)
+    WHERE dead_lettered_at IS NOT NULL;
+
+-- The applications that granger gave up on scoring with a model, for the
+-- operators. To retry an application after fixing the cause:
+--   UPDATE application_scoring_state
+--   SET attempts = 0, dead_lettered_at = NULL, next_attempt_at = now()
+--   WHERE application_id = '...' AND model_name = '...';
+CREATE OR REPLACE VIEW dead_lettered_application_scorings AS
+SELECT
+    ss.application_id,
+    ss.model_name,
+    a.employer_id,
+    a.opening_id,
+    ss.attempts,
+    ss.last_error,
+    ss.dead_lettered_at
+FROM application_scoring_state ss
+JOIN applications a ON a.id = ss.application_id
+WHERE ss.dead_lettered_at IS NOT NULL;
+
+-- Rescores the applications of an opening when what they are scored
+-- against changes, including those that were dead lettered
+CREATE OR REPLACE FUNCTION rescore_opening_applications()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE application_scores s
+    SET stale = TRUE
+    FROM applications a
+    WHERE a.id = s.application_id
+        AND a.employer_id = NEW.employer_id
+        AND a.opening_id = NEW.id
+        AND a.application_state = 'APPLIED';
+
+    UPDATE application_scoring_state ss
+    SET attempts = 0,
+        dead_lettered_at = NULL,
+        next_attempt_at = timezone('UTC', now()),
+        updated_at = timezone('UTC', now())
+    FROM applications a
+    WHERE a.id = ss.application_id
+        AND a.employer_id = NEW.employer_id
+        AND a.opening_id = NEW.id
+        AND a.application_state = 'APPLIED';
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER rescore_opening_applications_trigger
+AFTER UPDATE OF jd, yoe_min, yoe_max ON openings
+FOR EACH ROW
+WHEN (
+    OLD.jd IS DISTINCT FROM NEW.jd
+    OR OLD.yoe_min IS DISTINCT FROM NEW.yoe_min
+    OR OLD.yoe_max IS DISTINCT FROM NEW.yoe_max
+)
+EXECUTE FUNCTION rescore_opening_applications();
+
+-- Rescores the applications yet to be acted upon with a model that is
+-- activated, as the scores that it gave before its deactivation may be
+-- outdated. The applications without any score from the model are scored
+-- anyway, but the dead lettered ones get another chance with the model.
+CREATE OR REPLACE FUNCTION rescore_with_activated_model()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE application_scores s
+    SET stale = TRUE
+    FROM applications a
+    WHERE a.id = s.application_id
+        AND s.model_name = NEW.model_name
+        AND a.application_state = 'APPLIED';
+
+    UPDATE application_scoring_state ss
+    SET attempts = 0,
+        dead_lettered_at = NULL,
+        next_attempt_at = timezone('UTC', now()),
+        updated_at = timezone('UTC', now())
+    FROM applications a
+    WHERE a.id = ss.application_id
+        AND ss.model_name = NEW.model_name
+        AND a.application_state = 'APPLIED'
+        AND ss.dead_lettered_at IS NOT NULL;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER rescore_with_activated_model_insert_trigger
+AFTER INSERT ON application_scoring_models
+FOR EACH ROW
+WHEN (NEW.is_active)
+EXECUTE FUNCTION rescore_with_activated_model();
+
+CREATE TRIGGER rescore_with_activated_model_update_trigger
+AFTER UPDATE OF is_active ON application_scoring_models
+FOR EACH ROW
+WHEN (NEW.is_active AND NOT OLD.is_active)
+EXECUTE FUNCTION rescore_with_activated_model();
+
 -- Insert default models from sortinghat
 INSERT INTO application_scoring_models (model_name, description, is_active)
 VALUES
     ('Microsoft-E5-Research', 'Microsoft E5-base-v2 model for research-grade embeddings', TRUE),
-    ('Beijing-Academy-BGE', 'Beijing Academy BGE-base-en-v1.5 model for general embeddings', TRUE);
+    ('Beijing-Academy-BGE', 'Beijing Academy BGE-base-en-v1.5 model for general embeddings', TRUE),
+    ('Vetchium-Lexical-BM25', 'Built-in BM25 similarity of the resume text to the JD, with skills weighted up', TRUE),
+    ('Vetchium-Composite-Match', 'Built-in match of the resume, the long bio, the certifications, the education and the verified work history to the JD, weighted by the employer', TRUE);
+
+-- The ACTIVE openings that match a hub user best, as computed by granger.
+-- Replaced as a whole for the hub user on every refresh.
+CREATE TABLE opening_recommendations (
+    hub_user_id UUID NOT NULL REFERENCES hub_users(id) ON DELETE CASCADE,
+    employer_id UUID NOT NULL,
+    opening_id TEXT NOT NULL,
+    CONSTRAINT fk_opening FOREIGN KEY (employer_id, opening_id) REFERENCES openings (employer_id, id) ON DELETE CASCADE,
+
+    score INTEGER NOT NULL CHECK (score BETWEEN 0 AND 100),
+    -- Array of hub.RecommendationReason
+    reasons JSONB NOT NULL DEFAULT '[]'::JSONB,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    PRIMARY KEY (hub_user_id, employer_id, opening_id)
+);
+
+CREATE INDEX idx_opening_recommendations_hub_user_score
+    ON opening_recommendations(hub_user_id, score DESC);
+
+CREATE TYPE recommendation_feedbacks AS ENUM ('DISMISSED', 'NOT_INTERESTED');
+
+-- The recommended openings that the hub users do not want to see again. The
+-- tags of the NOT_INTERESTED openings are not counted as the tags of the
+-- hub user either.
+CREATE TABLE opening_recommendation_feedback (
+    hub_user_id UUID NOT NULL REFERENCES hub_users(id) ON DELETE CASCADE,
+    employer_id UUID NOT NULL,
+    opening_id TEXT NOT NULL,
+    CONSTRAINT fk_opening FOREIGN KEY (employer_id, opening_id) REFERENCES openings (employer_id, id) ON DELETE CASCADE,
+
+    feedback recommendation_feedbacks NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    PRIMARY KEY (hub_user_id, employer_id, opening_id)
+);
+
+-- The discoverable hub users that match an ACTIVE opening best, as
+-- computed by granger. Replaced as a whole for the opening on every refresh.
+CREATE TABLE opening_candidate_suggestions (
+    employer_id UUID NOT NULL,
+    opening_id TEXT NOT NULL,
+    CONSTRAINT fk_opening FOREIGN KEY (employer_id, opening_id) REFERENCES openings (employer_id, id) ON DELETE CASCADE,
+    hub_user_id UUID NOT NULL REFERENCES hub_users(id) ON DELETE CASCADE,
+
+    score INTEGER NOT NULL CHECK (score BETWEEN 0 AND 100),
+    -- Array of employer.CandidateSuggestionReason
+    reasons JSONB NOT NULL DEFAULT '[]'::JSONB,
+    yoe INTEGER,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    PRIMARY KEY (employer_id, opening_id, hub_user_id)
+);
+
+-- The suggestions are computed again when what they are matched against
+-- changes
+CREATE OR REPLACE FUNCTION reset_candidate_suggestions()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.candidate_suggestions_refreshed_at = NULL;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reset_candidate_suggestions_trigger
+BEFORE UPDATE OF title, jd, yoe_min, yoe_max ON openings
+FOR EACH ROW
+WHEN (
+    OLD.title IS DISTINCT FROM NEW.title
+    OR OLD.jd IS DISTINCT FROM NEW.jd
+    OR OLD.yoe_min IS DISTINCT FROM NEW.yoe_min
+    OR OLD.yoe_max IS DISTINCT FROM NEW.yoe_max
+)
+EXECUTE FUNCTION reset_candidate_suggestions();
+
+-- The invitations to apply, sent by the employers to the discoverable hub
+-- users. Also the inbox of the hub users and what the frequency caps are
+-- counted on.
+CREATE TABLE opening_invitations (
+    id TEXT PRIMARY KEY,
+    employer_id UUID NOT NULL,
+    opening_id TEXT NOT NULL,
+    CONSTRAINT fk_opening FOREIGN KEY (employer_id, opening_id) REFERENCES openings (employer_id, id) ON DELETE CASCADE,
+    hub_user_id UUID NOT NULL REFERENCES hub_users(id) ON DELETE CASCADE,
+    invited_by UUID NOT NULL REFERENCES org_users(id),
+    message TEXT,
+    email_key UUID REFERENCES emails(email_key),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    CONSTRAINT uniq_opening_invitation UNIQUE (employer_id, opening_id, hub_user_id)
+);
+
+CREATE INDEX idx_opening_invitations_hub_user
+    ON opening_invitations(hub_user_id, created_at DESC);
+CREATE INDEX idx_opening_invitations_employer
+    ON opening_invitations(employer_id, created_at DESC);
+
+CREATE OR REPLACE FUNCTION set_opening_activated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.state = 'ACTIVE_OPENING_STATE' AND (
+        TG_OP = 'INSERT' OR OLD.state IS DISTINCT FROM NEW.state
+    ) THEN
+        NEW.activated_at = timezone('UTC', now());
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER set_opening_activated_at_trigger
+BEFORE INSERT OR UPDATE OF state ON openings
+FOR EACH ROW
+EXECUTE FUNCTION set_opening_activated_at();
+
+CREATE INDEX idx_openings_activated_at ON openings(activated_at);
+
+CREATE TYPE saved_search_frequencies AS ENUM ('INSTANT', 'DAILY', 'WEEKLY');
+
+-- The searches of the hub users, that granger matches against the newly
+-- ACTIVE openings to send them alerts
+CREATE TABLE saved_searches (
+    id TEXT PRIMARY KEY,
+    hub_user_id UUID NOT NULL REFERENCES hub_users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    -- hub.FindHubOpeningsRequest, without the pagination_key and the limit
+    search JSONB NOT NULL,
+    frequency saved_search_frequencies NOT NULL,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    -- For the one-click unsubscribe link in the alert emails
+    unsubscribe_token TEXT NOT NULL UNIQUE,
+
+    -- The openings that become ACTIVE after this are matched next
+    evaluated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    alerted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    last_viewed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    CONSTRAINT uniq_saved_search_name UNIQUE (hub_user_id, name)
+);
+
+CREATE INDEX idx_saved_searches_evaluated_at
+    ON saved_searches(evaluated_at) WHERE NOT paused;
+
+-- The openings that matched a saved search. alerted_at is NULL until the
+-- opening is sent in an alert.
+CREATE TABLE saved_search_matches (
+    saved_search_id TEXT NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    employer_id UUID NOT NULL,
+    opening_id TEXT NOT NULL,
+    CONSTRAINT fk_opening FOREIGN KEY (employer_id, opening_id) REFERENCES openings (employer_id, id) ON DELETE CASCADE,
+
+    matched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    alerted_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (saved_search_id, employer_id, opening_id)
+);
+
+CREATE INDEX idx_saved_search_matches_unalerted
+    ON saved_search_matches(saved_search_id) WHERE alerted_at IS NULL;
 
 -- Function: can_apply
 --
@@ -1578,4 +2464,154 @@ CREATE TRIGGER update_incognito_post_vote_counts_trigger
 AFTER INSERT OR UPDATE OR DELETE ON incognito_post_votes
 FOR EACH ROW EXECUTE FUNCTION update_incognito_post_vote_counts();
 
+CREATE TYPE bookmark_types AS ENUM (
+    'OPENING',
+    'POST',
+    'EMPLOYER_POST',
+    'INCOGNITO_POST',
+    'POST_COMMENT',
+    'INCOGNITO_POST_COMMENT'
+);
+
+-- The bookmarked items are not foreign keys, as a bookmark outlives its item
+-- and is shown as a tombstone once the item is deleted
+CREATE TABLE bookmarks (
+    id TEXT PRIMARY KEY,
+    hub_user_id UUID NOT NULL REFERENCES hub_users(id) ON DELETE CASCADE,
+    bookmark_type bookmark_types NOT NULL,
+
+    -- The id of the opening, the post or the comment
+    target_id TEXT NOT NULL,
+    -- For an OPENING, the employer of the opening and the domain with which
+    -- it was bookmarked
+    employer_id UUID,
+    company_domain TEXT,
+    -- For a POST_COMMENT or an INCOGNITO_POST_COMMENT, the post of the comment
+    post_id TEXT,
+
+    folder TEXT,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+
+    CONSTRAINT valid_opening_bookmark CHECK (
+        (bookmark_type = 'OPENING') = (employer_id IS NOT NULL)
+    )
+);
+
+CREATE UNIQUE INDEX uniq_bookmark_target ON bookmarks (
+    hub_user_id,
+    bookmark_type,
+    target_id,
+    COALESCE(employer_id, '00000000-0000-0000-0000-000000000000'::UUID)
+);
+
+CREATE INDEX idx_bookmarks_hub_user ON bookmarks (hub_user_id, created_at DESC, id DESC);
+
+CREATE TYPE candidacy_update_types AS ENUM (
+    'COMMENT_ADDED',
+    'COMMENT_EDITED',
+    'INTERVIEW_CHANGED',
+    'STATE_CHANGED'
+);
+
+-- The changes to the candidacies that are streamed to the participants. The
+-- id is the cursor from which a stream resumes after a reconnect. The rows
+-- are written by the triggers below, which also NOTIFY the hermione replicas
+-- on the candidacy_updates channel with the candidacy_id as the payload.
+CREATE TABLE candidacy_updates (
+    id BIGSERIAL PRIMARY KEY,
+    candidacy_id TEXT REFERENCES candidacies(id) ON DELETE CASCADE NOT NULL,
+    employer_id UUID REFERENCES employers(id) NOT NULL,
+    update_type candidacy_update_types NOT NULL,
+
+    -- Populated only for the COMMENT_ADDED and COMMENT_EDITED updates
+    comment_id UUID REFERENCES candidacy_comments(id) ON DELETE CASCADE,
+    -- Populated only for the INTERVIEW_CHANGED updates
+    interview_id TEXT REFERENCES interviews(id) ON DELETE CASCADE,
+    -- Populated only for the STATE_CHANGED updates
+    candidacy_state candidacy_states,
+    CONSTRAINT valid_candidacy_update CHECK (
+        (update_type IN ('COMMENT_ADDED', 'COMMENT_EDITED')) = (comment_id IS NOT NULL) AND
+        (update_type = 'INTERVIEW_CHANGED') = (interview_id IS NOT NULL) AND
+        (update_type = 'STATE_CHANGED') = (candidacy_state IS NOT NULL)
+    ),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+CREATE INDEX idx_candidacy_updates_candidacy_id ON candidacy_updates (candidacy_id, id);
+
+CREATE OR REPLACE FUNCTION record_candidacy_update()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_candidacy_id TEXT;
+BEGIN
+    IF TG_TABLE_NAME = 'candidacies' THEN
+        v_candidacy_id := NEW.id;
+    ELSE
+        v_candidacy_id := NEW.candidacy_id;
+    END IF;
+
+    -- The updates of a candidacy are serialized until the commit, so that
+    -- their ids are in the order of their commits and a stream that has seen
+    -- an update never misses an earlier one
+    PERFORM pg_advisory_xact_lock(hashtext('candidacy_updates'), hashtext(v_candidacy_id));
+
+    IF TG_TABLE_NAME = 'candidacy_comments' AND TG_OP = 'INSERT' THEN
+        INSERT INTO candidacy_updates (candidacy_id, employer_id, update_type, comment_id)
+            VALUES (v_candidacy_id, NEW.employer_id, 'COMMENT_ADDED', NEW.id);
+    ELSIF TG_TABLE_NAME = 'candidacy_comments' THEN
+        INSERT INTO candidacy_updates (candidacy_id, employer_id, update_type, comment_id)
+            VALUES (v_candidacy_id, NEW.employer_id, 'COMMENT_EDITED', NEW.id);
+    ELSIF TG_TABLE_NAME = 'interviews' THEN
+        INSERT INTO candidacy_updates (candidacy_id, employer_id, update_type, interview_id)
+            VALUES (v_candidacy_id, NEW.employer_id, 'INTERVIEW_CHANGED', NEW.id);
+    ELSE
+        INSERT INTO candidacy_updates (candidacy_id, employer_id, update_type, candidacy_state)
+            VALUES (v_candidacy_id, NEW.employer_id, 'STATE_CHANGED', NEW.candidacy_state);
+    END IF;
+
+    -- Delivered to the listeners only when the transaction commits
+    PERFORM pg_notify('candidacy_updates', v_candidacy_id);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER record_candidacy_comment_trigger
+AFTER INSERT ON candidacy_comments
+FOR EACH ROW
+EXECUTE FUNCTION record_candidacy_update();
+
+CREATE TRIGGER record_candidacy_comment_edit_trigger
+AFTER UPDATE OF comment_text, retracted_at ON candidacy_comments
+FOR EACH ROW
+WHEN (
+    OLD.comment_text IS DISTINCT FROM NEW.comment_text
+    OR OLD.retracted_at IS DISTINCT FROM NEW.retracted_at
+)
+EXECUTE FUNCTION record_candidacy_update();
+
+CREATE TRIGGER record_interview_insert_trigger
+AFTER INSERT ON interviews
+FOR EACH ROW
+EXECUTE FUNCTION record_candidacy_update();
+
+-- Only the changes that the candidate can see, and not the feedback
+CREATE TRIGGER record_interview_update_trigger
+AFTER UPDATE OF interview_state, start_time, end_time, candidate_rsvp ON interviews
+FOR EACH ROW
+WHEN (
+    OLD.interview_state IS DISTINCT FROM NEW.interview_state
+    OR OLD.start_time IS DISTINCT FROM NEW.start_time
+    OR OLD.end_time IS DISTINCT FROM NEW.end_time
+    OR OLD.candidate_rsvp IS DISTINCT FROM NEW.candidate_rsvp
+)
+EXECUTE FUNCTION record_candidacy_update();
+
+CREATE TRIGGER record_candidacy_state_trigger
+AFTER UPDATE OF candidacy_state ON candidacies
+FOR EACH ROW
+WHEN (OLD.candidacy_state IS DISTINCT FROM NEW.candidacy_state)
+EXECUTE FUNCTION record_candidacy_update();
+
 COMMIT;
@@ -0,0 +1,103 @@
+package careers
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type GetCareersOpeningsRequest struct {
+	Domain string `json:"domain" validate:"required,validate_domain"`
+}
+
+type GetCareersOpeningRequest struct {
+	Domain    string `json:"domain"     validate:"required,validate_domain"`
+	OpeningID string `json:"opening_id" validate:"required,max=64"`
+}
+
+// CareersOpening is served to unauthenticated clients, so should not carry
+// anything private to the employer
+type CareersOpening struct {
+	OpeningID          string                `json:"opening_id"`
+	Title              string                `json:"title"`
+	OpeningType        common.OpeningType    `json:"opening_type"`
+	YoeMin             int                   `json:"yoe_min"`
+	YoeMax             int                   `json:"yoe_max"`
+	MinEducationLevel  common.EducationLevel `json:"min_education_level"`
+	LocationTitles     []string              `json:"location_titles,omitempty"`
+	RemoteCountryCodes []common.CountryCode  `json:"remote_country_codes,omitempty"`
+	Salary             *common.Salary        `json:"salary,omitempty"`
+	URL                string                `json:"url"`
+	CloseAt            *time.Time            `json:"close_at,omitempty"`
+	CreatedAt          time.Time             `json:"created_at"`
+}
+
+type CareersPage struct {
+	CompanyName   string           `json:"company_name"`
+	CompanyDomain string           `json:"company_domain"`
+	Openings      []CareersOpening `json:"openings"`
+}
+
+type SchemaOrgPropertyValue struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type SchemaOrgOrganization struct {
+	Type   string `json:"@type"`
+	Name   string `json:"name"`
+	SameAs string `json:"sameAs"`
+}
+
+type SchemaOrgPostalAddress struct {
+	Type           string `json:"@type"`
+	StreetAddress  string `json:"streetAddress"`
+	PostalCode     string `json:"postalCode"`
+	AddressCountry string `json:"addressCountry"`
+}
+
+type SchemaOrgPlace struct {
+	Type    string                 `json:"@type"`
+	Name    string                 `json:"name"`
+	Address SchemaOrgPostalAddress `json:"address"`
+}
+
+type SchemaOrgCountry struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type SchemaOrgQuantitativeValue struct {
+	Type     string  `json:"@type"`
+	MinValue float64 `json:"minValue"`
+	MaxValue float64 `json:"maxValue"`
+}
+
+type SchemaOrgMonetaryAmount struct {
+	Type     string                     `json:"@type"`
+	Currency string                     `json:"currency"`
+	Value    SchemaOrgQuantitativeValue `json:"value"`
+}
+
+// JobPosting is a https://schema.org/JobPosting served as JSON-LD
+type JobPosting struct {
+	Context     string                 `json:"@context"`
+	Type        string                 `json:"@type"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Identifier  SchemaOrgPropertyValue `json:"identifier"`
+	// ISO 8601 date
+	DatePosted     string     `json:"datePosted"`
+	ValidThrough   *time.Time `json:"validThrough,omitempty"`
+	EmploymentType string     `json:"employmentType,omitempty"`
+
+	HiringOrganization SchemaOrgOrganization `json:"hiringOrganization"`
+
+	JobLocation                   []SchemaOrgPlace   `json:"jobLocation,omitempty"`
+	JobLocationType               string             `json:"jobLocationType,omitempty"`
+	ApplicantLocationRequirements []SchemaOrgCountry `json:"applicantLocationRequirements,omitempty"`
+
+	BaseSalary *SchemaOrgMonetaryAmount `json:"baseSalary,omitempty"`
+	URL        string                   `json:"url"`
+}
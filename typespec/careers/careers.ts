@@ -0,0 +1,82 @@
+import type { CountryCode } from "../common/common";
+import type { EducationLevel, OpeningType, Salary } from "../common/openings";
+
+export interface CareersOpening {
+  opening_id: string;
+  title: string;
+  opening_type: OpeningType;
+  yoe_min: number;
+  yoe_max: number;
+  min_education_level: EducationLevel;
+  location_titles?: string[];
+  remote_country_codes?: CountryCode[];
+  salary?: Salary;
+  url: string;
+  close_at?: Date;
+  created_at: Date;
+}
+
+export interface CareersPage {
+  company_name: string;
+  company_domain: string;
+  openings: CareersOpening[];
+}
+
+export interface SchemaOrgPropertyValue {
+  "@type": "PropertyValue";
+  name: string;
+  value: string;
+}
+
+export interface SchemaOrgOrganization {
+  "@type": "Organization";
+  name: string;
+  sameAs: string;
+}
+
+export interface SchemaOrgPostalAddress {
+  "@type": "PostalAddress";
+  streetAddress: string;
+  postalCode: string;
+  addressCountry: string;
+}
+
+export interface SchemaOrgPlace {
+  "@type": "Place";
+  name: string;
+  address: SchemaOrgPostalAddress;
+}
+
+export interface SchemaOrgCountry {
+  "@type": "Country";
+  name: string;
+}
+
+export interface SchemaOrgQuantitativeValue {
+  "@type": "QuantitativeValue";
+  minValue: number;
+  maxValue: number;
+}
+
+export interface SchemaOrgMonetaryAmount {
+  "@type": "MonetaryAmount";
+  currency: string;
+  value: SchemaOrgQuantitativeValue;
+}
+
+export interface JobPosting {
+  "@context": "https://schema.org";
+  "@type": "JobPosting";
+  title: string;
+  description: string;
+  identifier: SchemaOrgPropertyValue;
+  datePosted: string;
+  validThrough?: Date;
+  employmentType?: "FULL_TIME" | "PART_TIME" | "CONTRACTOR" | "INTERN";
+  hiringOrganization: SchemaOrgOrganization;
+  jobLocation?: SchemaOrgPlace[];
+  jobLocationType?: "TELECOMMUTE";
+  applicantLocationRequirements?: SchemaOrgCountry[];
+  baseSalary?: SchemaOrgMonetaryAmount;
+  url: string;
+}
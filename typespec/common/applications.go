@@ -21,3 +21,33 @@ func (s ApplicationState) IsValid() bool {
 		s == WithdrawnAppState ||
 		s == ExpiredAppState
 }
+
+type YoeFit string
+
+const (
+	BelowYoeFit   YoeFit = "BELOW"
+	WithinYoeFit  YoeFit = "WITHIN"
+	AboveYoeFit   YoeFit = "ABOVE"
+	UnknownYoeFit YoeFit = "UNKNOWN"
+)
+
+type SectionScore struct {
+	Section string `json:"section"`
+	Score   int    `json:"score"`
+}
+
+type YoeMatch struct {
+	ResumeYoe *int   `json:"resume_yoe,omitempty"`
+	YoeMin    int    `json:"yoe_min"`
+	YoeMax    int    `json:"yoe_max"`
+	Fit       YoeFit `json:"fit"`
+}
+
+// ScoreExplanation is why a model gave a score to an Application. All the
+// fields are optional, as each model explains what it can.
+type ScoreExplanation struct {
+	MatchedTerms  []string       `json:"matched_terms,omitempty"`
+	MissingTerms  []string       `json:"missing_terms,omitempty"`
+	SectionScores []SectionScore `json:"section_scores,omitempty"`
+	YoeMatch      *YoeMatch      `json:"yoe_match,omitempty"`
+}
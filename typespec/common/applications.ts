@@ -18,3 +18,31 @@ export function isValidApplicationState(
 ): state is ApplicationState {
   return Object.values(ApplicationStates).includes(state as ApplicationState);
 }
+
+export type YoeFit = "BELOW" | "WITHIN" | "ABOVE" | "UNKNOWN";
+
+export const YoeFits = {
+  BELOW: "BELOW" as YoeFit,
+  WITHIN: "WITHIN" as YoeFit,
+  ABOVE: "ABOVE" as YoeFit,
+  UNKNOWN: "UNKNOWN" as YoeFit,
+} as const;
+
+export interface SectionScore {
+  section: string;
+  score: number;
+}
+
+export interface YoeMatch {
+  resume_yoe?: number;
+  yoe_min: number;
+  yoe_max: number;
+  fit: YoeFit;
+}
+
+export interface ScoreExplanation {
+  matched_terms?: string[];
+  missing_terms?: string[];
+  section_scores?: SectionScore[];
+  yoe_match?: YoeMatch;
+}
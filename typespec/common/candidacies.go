@@ -12,6 +12,25 @@ type GetCandidacyCommentsRequest struct {
 	CandidacyID string `json:"candidacy_id"`
 }
 
+type CandidacyActorType string
+
+const (
+	OrgUserCandidacyActor CandidacyActorType = "ORG_USER"
+	HubUserCandidacyActor CandidacyActorType = "HUB_USER"
+	SystemCandidacyActor  CandidacyActorType = "SYSTEM"
+)
+
+// CandidacyEvent is a change of the state of a candidacy. FromState is
+// absent for the creation of the candidacy.
+type CandidacyEvent struct {
+	FromState *CandidacyState    `json:"from_state,omitempty"`
+	ToState   CandidacyState     `json:"to_state"`
+	ActorType CandidacyActorType `json:"actor_type"`
+	ActorName *string            `json:"actor_name,omitempty"`
+	Reason    *string            `json:"reason,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
 type CommenterType string
 
 const (
@@ -19,10 +38,88 @@ const (
 	CommenterTypeHubUser CommenterType = "HUB_USER"
 )
 
+type CandidacyCommentAttachment struct {
+	Filename string `json:"filename" validate:"required,min=1,max=256"`
+	Document string `json:"document" validate:"required,base64"`
+}
+
+type CandidacyCommentAttachmentInfo struct {
+	AttachmentNumber int    `json:"attachment_number"`
+	Filename         string `json:"filename"`
+	ContentType      string `json:"content_type"`
+	SizeBytes        int64  `json:"size_bytes"`
+}
+
+type CandidacyCommentEdit struct {
+	PreviousContent string    `json:"previous_content"`
+	EditedAt        time.Time `json:"edited_at"`
+}
+
+type CandidacyCommentReader struct {
+	ReaderName string        `json:"reader_name"`
+	ReaderType CommenterType `json:"reader_type"`
+}
+
+// CandidacyComment is a comment on a candidacy. The Content, Attachments and
+// EditHistory are emptied once the comment is retracted.
 type CandidacyComment struct {
-	CommentID     string        `json:"comment_id"`
-	CommenterName string        `json:"commenter_name"`
-	CommenterType CommenterType `json:"commenter_type"`
-	Content       string        `json:"content"`
-	CreatedAt     time.Time     `json:"created_at"`
+	CommentID     string                           `json:"comment_id"`
+	CommenterName string                           `json:"commenter_name"`
+	CommenterType CommenterType                    `json:"commenter_type"`
+	Content       string                           `json:"content"`
+	CreatedAt     time.Time                        `json:"created_at"`
+	Attachments   []CandidacyCommentAttachmentInfo `json:"attachments"`
+	EditHistory   []CandidacyCommentEdit           `json:"edit_history"`
+	EditedAt      *time.Time                       `json:"edited_at,omitempty"`
+	IsRetracted   bool                             `json:"is_retracted"`
+	ReadBy        []CandidacyCommentReader         `json:"read_by"`
+}
+
+type EditCandidacyCommentRequest struct {
+	CommentID string `json:"comment_id" validate:"required"`
+	Comment   string `json:"comment"    validate:"required,max=2048"`
+}
+
+type RetractCandidacyCommentRequest struct {
+	CommentID string `json:"comment_id" validate:"required"`
+}
+
+type MarkCandidacyCommentsReadRequest struct {
+	CandidacyID string `json:"candidacy_id" validate:"required"`
+	CommentID   string `json:"comment_id"   validate:"required"`
+}
+
+type GetCandidacyCommentAttachmentRequest struct {
+	CommentID        string `json:"comment_id"        validate:"required"`
+	AttachmentNumber int    `json:"attachment_number" validate:"required,min=1"`
+}
+
+type CandidacyUpdateType string
+
+const (
+	CommentAddedUpdate     CandidacyUpdateType = "COMMENT_ADDED"
+	CommentEditedUpdate    CandidacyUpdateType = "COMMENT_EDITED"
+	InterviewChangedUpdate CandidacyUpdateType = "INTERVIEW_CHANGED"
+	StateChangedUpdate     CandidacyUpdateType = "STATE_CHANGED"
+)
+
+type CandidacyInterviewUpdate struct {
+	InterviewID    string         `json:"interview_id"`
+	InterviewState InterviewState `json:"interview_state"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	CandidateRSVP  RSVPStatus     `json:"candidate_rsvp"`
+}
+
+// CandidacyUpdate is a change to a candidacy, pushed to the participants as
+// a Server-Sent Event. The UpdateID is the id of the event and resumes the
+// stream after it, when passed back as the Last-Event-ID header.
+type CandidacyUpdate struct {
+	UpdateID       string                    `json:"update_id"`
+	CandidacyID    string                    `json:"candidacy_id"`
+	UpdateType     CandidacyUpdateType       `json:"update_type"`
+	Comment        *CandidacyComment         `json:"comment,omitempty"`
+	Interview      *CandidacyInterviewUpdate `json:"interview,omitempty"`
+	CandidacyState *CandidacyState           `json:"candidacy_state,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at"`
 }
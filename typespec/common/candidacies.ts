@@ -1,4 +1,4 @@
-import { CandidacyState } from "./interviews";
+import { CandidacyState, InterviewState, RSVPStatus } from "./interviews";
 
 export interface GetCandidacyInfoRequest {
   candidacy_id: string;
@@ -8,6 +8,23 @@ export interface GetCandidacyCommentsRequest {
   candidacy_id: string;
 }
 
+export type CandidacyActorType = "ORG_USER" | "HUB_USER" | "SYSTEM";
+
+export const CandidacyActorTypes = {
+  ORG_USER: "ORG_USER" as CandidacyActorType,
+  HUB_USER: "HUB_USER" as CandidacyActorType,
+  SYSTEM: "SYSTEM" as CandidacyActorType,
+};
+
+export interface CandidacyEvent {
+  from_state?: CandidacyState;
+  to_state: CandidacyState;
+  actor_type: CandidacyActorType;
+  actor_name?: string;
+  reason?: string;
+  created_at: Date;
+}
+
 export type CommenterType = "ORG_USER" | "HUB_USER";
 
 export const CommenterTypes = {
@@ -15,10 +32,87 @@ export const CommenterTypes = {
   HUB_USER: "HUB_USER" as CommenterType,
 };
 
+export interface CandidacyCommentAttachment {
+  filename: string;
+  document: string;
+}
+
+export interface CandidacyCommentAttachmentInfo {
+  attachment_number: number;
+  filename: string;
+  content_type: string;
+  size_bytes: number;
+}
+
+export interface CandidacyCommentEdit {
+  previous_content: string;
+  edited_at: Date;
+}
+
+export interface CandidacyCommentReader {
+  reader_name: string;
+  reader_type: CommenterType;
+}
+
 export interface CandidacyComment {
   comment_id: string;
   commenter_name: string;
   commenter_type: CommenterType;
   content: string;
   created_at: Date;
+  attachments: CandidacyCommentAttachmentInfo[];
+  edit_history: CandidacyCommentEdit[];
+  edited_at?: Date;
+  is_retracted: boolean;
+  read_by: CandidacyCommentReader[];
+}
+
+export interface EditCandidacyCommentRequest {
+  comment_id: string;
+  comment: string;
+}
+
+export interface RetractCandidacyCommentRequest {
+  comment_id: string;
+}
+
+export interface MarkCandidacyCommentsReadRequest {
+  candidacy_id: string;
+  comment_id: string;
+}
+
+export interface GetCandidacyCommentAttachmentRequest {
+  comment_id: string;
+  attachment_number: number;
+}
+
+export type CandidacyUpdateType =
+  | "COMMENT_ADDED"
+  | "COMMENT_EDITED"
+  | "INTERVIEW_CHANGED"
+  | "STATE_CHANGED";
+
+export const CandidacyUpdateTypes = {
+  COMMENT_ADDED: "COMMENT_ADDED" as CandidacyUpdateType,
+  COMMENT_EDITED: "COMMENT_EDITED" as CandidacyUpdateType,
+  INTERVIEW_CHANGED: "INTERVIEW_CHANGED" as CandidacyUpdateType,
+  STATE_CHANGED: "STATE_CHANGED" as CandidacyUpdateType,
+};
+
+export interface CandidacyInterviewUpdate {
+  interview_id: string;
+  interview_state: InterviewState;
+  start_time: Date;
+  end_time: Date;
+  candidate_rsvp: RSVPStatus;
+}
+
+export interface CandidacyUpdate {
+  update_id: string;
+  candidacy_id: string;
+  update_type: CandidacyUpdateType;
+  comment?: CandidacyComment;
+  interview?: CandidacyInterviewUpdate;
+  candidacy_state?: CandidacyState;
+  created_at: Date;
 }
@@ -1,5 +1,7 @@
 package common
 
+import "time"
+
 type InterviewState string
 
 const (
@@ -122,3 +124,36 @@ func (i InterviewType) IsValid() bool {
 	}
 	return false
 }
+
+type InterviewChangeType string
+
+const (
+	RescheduledInterviewChange         InterviewChangeType = "RESCHEDULED"
+	CancelledInterviewChange           InterviewChangeType = "CANCELLED"
+	RescheduleRequestedInterviewChange InterviewChangeType = "RESCHEDULE_REQUESTED"
+)
+
+type InterviewSlot struct {
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time"   validate:"required"`
+}
+
+type InterviewChange struct {
+	ChangeType        InterviewChangeType `json:"change_type"`
+	PreviousStartTime time.Time           `json:"previous_start_time"`
+	PreviousEndTime   time.Time           `json:"previous_end_time"`
+	NewStartTime      *time.Time          `json:"new_start_time,omitempty"`
+	NewEndTime        *time.Time          `json:"new_end_time,omitempty"`
+	ProposedSlots     []InterviewSlot     `json:"proposed_slots,omitempty"`
+	Reason            *string             `json:"reason,omitempty"`
+
+	// Name of the OrgUser or the candidate who made the change
+	ChangedBy string    `json:"changed_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CalendarFeed struct {
+	// Path of the private iCalendar feed of the upcoming interviews, relative
+	// to the API server. Anyone with the path can read the feed.
+	FeedPath string `json:"feed_path"`
+}
@@ -94,3 +94,35 @@ export const InterviewTypes = {
 export function isValidInterviewType(type: string): type is InterviewType {
   return Object.values(InterviewTypes).includes(type as InterviewType);
 }
+
+export type InterviewChangeType =
+  | "RESCHEDULED"
+  | "CANCELLED"
+  | "RESCHEDULE_REQUESTED";
+
+export const InterviewChangeTypes = {
+  RESCHEDULED: "RESCHEDULED" as InterviewChangeType,
+  CANCELLED: "CANCELLED" as InterviewChangeType,
+  RESCHEDULE_REQUESTED: "RESCHEDULE_REQUESTED" as InterviewChangeType,
+} as const;
+
+export interface InterviewSlot {
+  start_time: Date;
+  end_time: Date;
+}
+
+export interface InterviewChange {
+  change_type: InterviewChangeType;
+  previous_start_time: Date;
+  previous_end_time: Date;
+  new_start_time?: Date;
+  new_end_time?: Date;
+  proposed_slots?: InterviewSlot[];
+  reason?: string;
+  changed_by: string;
+  created_at: Date;
+}
+
+export interface CalendarFeed {
+  feed_path: string;
+}
@@ -0,0 +1,66 @@
+package common
+
+import "time"
+
+type OfferState string
+
+const (
+	PendingOffer    OfferState = "PENDING_OFFER"
+	AcceptedOffer   OfferState = "ACCEPTED_OFFER"
+	DeclinedOffer   OfferState = "DECLINED_OFFER"
+	RescindedOffer  OfferState = "RESCINDED_OFFER"
+	ExpiredOffer    OfferState = "EXPIRED_OFFER"
+	SupersededOffer OfferState = "SUPERSEDED_OFFER"
+)
+
+type OfferSignatureState string
+
+const (
+	SentForSignature  OfferSignatureState = "SENT_FOR_SIGNATURE"
+	OfferSigned       OfferSignatureState = "SIGNED"
+	SignatureDeclined OfferSignatureState = "SIGNATURE_DECLINED"
+	SignatureVoided   OfferSignatureState = "SIGNATURE_VOIDED"
+)
+
+type OfferCompensation struct {
+	Amount   float64  `json:"amount"          validate:"required,min=1"`
+	Currency Currency `json:"currency"        validate:"required,validate_currency"`
+	Notes    *string  `json:"notes,omitempty" validate:"omitempty,max=1024"`
+}
+
+type OfferAttachment struct {
+	Filename string `json:"filename" validate:"required,max=256"`
+	Document string `json:"document" validate:"required,base64"`
+}
+
+type OfferAttachmentInfo struct {
+	AttachmentNumber int    `json:"attachment_number"`
+	Filename         string `json:"filename"`
+}
+
+type Offer struct {
+	CandidacyID    string                `json:"candidacy_id"`
+	Version        int                   `json:"version"`
+	Compensation   OfferCompensation     `json:"compensation"`
+	StartDate      string                `json:"start_date"`
+	ExpiresAt      time.Time             `json:"expires_at"`
+	OfferState     OfferState            `json:"offer_state"`
+	Attachments    []OfferAttachmentInfo `json:"attachments"`
+	ResponseReason *string               `json:"response_reason,omitempty"`
+	RespondedAt    *time.Time            `json:"responded_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+
+	SignatureState *OfferSignatureState `json:"signature_state,omitempty"`
+	SignedAt       *time.Time           `json:"signed_at,omitempty"`
+}
+
+type GetOffersRequest struct {
+	CandidacyID string `json:"candidacy_id" validate:"required"`
+}
+
+type GetOfferDocumentRequest struct {
+	CandidacyID      string `json:"candidacy_id"                validate:"required"`
+	Version          int    `json:"version"                     validate:"required,min=1"`
+	AttachmentNumber *int   `json:"attachment_number,omitempty" validate:"omitempty,min=1,excluded_with=Signed"`
+	Signed           *bool  `json:"signed,omitempty"`
+}
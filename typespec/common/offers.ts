@@ -0,0 +1,73 @@
+import type { Currency } from "./common";
+
+export type OfferState =
+  | "PENDING_OFFER"
+  | "ACCEPTED_OFFER"
+  | "DECLINED_OFFER"
+  | "RESCINDED_OFFER"
+  | "EXPIRED_OFFER"
+  | "SUPERSEDED_OFFER";
+
+export const OfferStates = {
+  PENDING: "PENDING_OFFER" as OfferState,
+  ACCEPTED: "ACCEPTED_OFFER" as OfferState,
+  DECLINED: "DECLINED_OFFER" as OfferState,
+  RESCINDED: "RESCINDED_OFFER" as OfferState,
+  EXPIRED: "EXPIRED_OFFER" as OfferState,
+  SUPERSEDED: "SUPERSEDED_OFFER" as OfferState,
+};
+
+export type OfferSignatureState =
+  | "SENT_FOR_SIGNATURE"
+  | "SIGNED"
+  | "SIGNATURE_DECLINED"
+  | "SIGNATURE_VOIDED";
+
+export const OfferSignatureStates = {
+  SENT: "SENT_FOR_SIGNATURE" as OfferSignatureState,
+  SIGNED: "SIGNED" as OfferSignatureState,
+  DECLINED: "SIGNATURE_DECLINED" as OfferSignatureState,
+  VOIDED: "SIGNATURE_VOIDED" as OfferSignatureState,
+};
+
+export interface OfferCompensation {
+  amount: number;
+  currency: Currency;
+  notes?: string;
+}
+
+export interface OfferAttachment {
+  filename: string;
+  document: string;
+}
+
+export interface OfferAttachmentInfo {
+  attachment_number: number;
+  filename: string;
+}
+
+export interface Offer {
+  candidacy_id: string;
+  version: number;
+  compensation: OfferCompensation;
+  start_date: string;
+  expires_at: Date;
+  offer_state: OfferState;
+  attachments: OfferAttachmentInfo[];
+  response_reason?: string;
+  responded_at?: Date;
+  created_at: Date;
+  signature_state?: OfferSignatureState;
+  signed_at?: Date;
+}
+
+export interface GetOffersRequest {
+  candidacy_id: string;
+}
+
+export interface GetOfferDocumentRequest {
+  candidacy_id: string;
+  version: number;
+  attachment_number?: number;
+  signed?: boolean;
+}
@@ -0,0 +1,62 @@
+package common
+
+import "time"
+
+type LateSubmissionPolicy string
+
+const (
+	RejectLateSubmissions LateSubmissionPolicy = "REJECT_LATE"
+	AcceptLateSubmissions LateSubmissionPolicy = "ACCEPT_LATE"
+)
+
+type TakeHomeFile struct {
+	Filename string `json:"filename" validate:"required,min=1,max=256"`
+	Document string `json:"document" validate:"required,base64"`
+}
+
+type TakeHomeFileInfo struct {
+	FileNumber  int    `json:"file_number"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+}
+
+type TakeHomeSubmission struct {
+	SubmissionNumber int       `json:"submission_number"`
+	Filename         string    `json:"filename"`
+	ContentType      string    `json:"content_type"`
+	SizeBytes        int64     `json:"size_bytes"`
+	IsLate           bool      `json:"is_late"`
+	SubmittedAt      time.Time `json:"submitted_at"`
+}
+
+type TakeHomeAssignment struct {
+	InterviewID string `json:"interview_id"`
+
+	// Absent for the candidate until the assignment is available to them
+	Brief *string            `json:"brief,omitempty"`
+	Files []TakeHomeFileInfo `json:"files"`
+
+	AvailableFrom        time.Time            `json:"available_from"`
+	Deadline             time.Time            `json:"deadline"`
+	TimeLimitMinutes     *int                 `json:"time_limit_minutes,omitempty"`
+	StartedAt            *time.Time           `json:"started_at,omitempty"`
+	LateSubmissionPolicy LateSubmissionPolicy `json:"late_submission_policy"`
+	GracePeriodMinutes   int                  `json:"grace_period_minutes"`
+
+	Submissions []TakeHomeSubmission `json:"submissions"`
+}
+
+type GetTakeHomeAssignmentRequest struct {
+	InterviewID string `json:"interview_id" validate:"required"`
+}
+
+type GetTakeHomeFileRequest struct {
+	InterviewID string `json:"interview_id" validate:"required"`
+	FileNumber  int    `json:"file_number"  validate:"required,min=1"`
+}
+
+type GetTakeHomeSubmissionRequest struct {
+	InterviewID      string `json:"interview_id"      validate:"required"`
+	SubmissionNumber int    `json:"submission_number" validate:"required,min=1"`
+}
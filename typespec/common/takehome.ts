@@ -0,0 +1,54 @@
+export type LateSubmissionPolicy = "REJECT_LATE" | "ACCEPT_LATE";
+
+export const LateSubmissionPolicies = {
+  REJECT_LATE: "REJECT_LATE" as LateSubmissionPolicy,
+  ACCEPT_LATE: "ACCEPT_LATE" as LateSubmissionPolicy,
+};
+
+export interface TakeHomeFile {
+  filename: string;
+  document: string;
+}
+
+export interface TakeHomeFileInfo {
+  file_number: number;
+  filename: string;
+  content_type: string;
+  size_bytes: number;
+}
+
+export interface TakeHomeSubmission {
+  submission_number: number;
+  filename: string;
+  content_type: string;
+  size_bytes: number;
+  is_late: boolean;
+  submitted_at: Date;
+}
+
+export interface TakeHomeAssignment {
+  interview_id: string;
+  brief?: string;
+  files: TakeHomeFileInfo[];
+  available_from: Date;
+  deadline: Date;
+  time_limit_minutes?: number;
+  started_at?: Date;
+  late_submission_policy: LateSubmissionPolicy;
+  grace_period_minutes: number;
+  submissions: TakeHomeSubmission[];
+}
+
+export interface GetTakeHomeAssignmentRequest {
+  interview_id: string;
+}
+
+export interface GetTakeHomeFileRequest {
+  interview_id: string;
+  file_number: number;
+}
+
+export interface GetTakeHomeSubmissionRequest {
+  interview_id: string;
+  submission_number: number;
+}
@@ -73,6 +73,17 @@ type RejectApplicationRequest struct {
 	ApplicationID string `json:"application_id" validate:"required"`
 }
 
+type GetApplicationScoreDetailsRequest struct {
+	ApplicationID string `json:"application_id" validate:"required"`
+}
+
+type ApplicationScoreDetails struct {
+	ModelName   string                   `json:"model_name"`
+	Score       int                      `json:"score"`
+	Explanation *common.ScoreExplanation `json:"explanation,omitempty"`
+	ScoredAt    time.Time                `json:"scored_at"`
+}
+
 type GetResumeRequest struct {
 	ApplicationID string `json:"application_id" validate:"required"`
 }
@@ -1,4 +1,4 @@
-import { ApplicationState } from "../common/applications";
+import { ApplicationState, ScoreExplanation } from "../common/applications";
 
 export type ApplicationColorTag = "GREEN" | "YELLOW" | "RED";
 
@@ -60,6 +60,17 @@ export interface RejectApplicationRequest {
   application_id: string;
 }
 
+export interface GetApplicationScoreDetailsRequest {
+  application_id: string;
+}
+
+export interface ApplicationScoreDetails {
+  model_name: string;
+  score: number;
+  explanation?: ScoreExplanation;
+  scored_at: Date;
+}
+
 export interface GetResumeRequest {
   application_id: string;
 }
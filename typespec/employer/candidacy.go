@@ -23,11 +23,18 @@ type Candidacy struct {
 	CandidacyState     common.CandidacyState `json:"candidacy_state"`
 	ApplicantName      string                `json:"applicant_name"`
 	ApplicantHandle    string                `json:"applicant_handle"`
+
+	// The comments by the others that the OrgUser is yet to read
+	UnreadCommentsCount int `json:"unread_comments_count"`
+
+	// Populated only by the get-candidacy-info
+	Timeline []common.CandidacyEvent `json:"timeline,omitempty"`
 }
 
 type AddEmployerCandidacyCommentRequest struct {
-	CandidacyID string `json:"candidacy_id" validate:"required"`
-	Comment     string `json:"comment"      validate:"required,max=2048"`
+	CandidacyID string                              `json:"candidacy_id"          validate:"required"`
+	Comment     string                              `json:"comment"               validate:"required,max=2048"`
+	Attachments []common.CandidacyCommentAttachment `json:"attachments,omitempty" validate:"omitempty,max=5,dive"`
 }
 
 type AddInterviewRequest struct {
@@ -37,6 +44,10 @@ type AddInterviewRequest struct {
 	InterviewType     common.InterviewType `json:"interview_type"     validate:"required,validate_interview_type"`
 	Description       string               `json:"description"        validate:"omitempty,max=2048"`
 	InterviewerEmails []string             `json:"interviewer_emails" validate:"omitempty,dive,email"`
+
+	// Link to the meeting of a VIDEO_CALL interview, if the employer uses the
+	// ManualMeetingProvider
+	MeetingURL string `json:"meeting_url,omitempty" validate:"omitempty,url,max=1024"`
 }
 
 type AddInterviewResponse struct {
@@ -68,9 +79,36 @@ type EmployerInterview struct {
 	FeedbackSubmittedBy  *OrgUserTiny                 `json:"feedback_submitted_by"`
 	FeedbackSubmittedAt  *time.Time                   `json:"feedback_submitted_at"`
 	CreatedAt            time.Time                    `json:"created_at"`
+	MeetingURL           *string                      `json:"meeting_url,omitempty"`
+
+	// Populated only by /employer/get-interview-details
+	Changes []common.InterviewChange `json:"changes,omitempty"`
 }
 
 type OfferToCandidateRequest struct {
-	CandidacyID   string `json:"candidacy_id"   validate:"required"`
-	OfferDocument string `json:"offer_document" validate:"omitempty"`
+	CandidacyID  string                   `json:"candidacy_id" validate:"required"`
+	Compensation common.OfferCompensation `json:"compensation" validate:"required"`
+	StartDate    string                   `json:"start_date"   validate:"required,datetime=2006-01-02"`
+	ExpiresAt    time.Time                `json:"expires_at"   validate:"required"`
+	Attachments  []common.OfferAttachment `json:"attachments"  validate:"omitempty,max=5,dive"`
+
+	// Deprecated: Use Attachments instead
+	OfferDocument string `json:"offer_document" validate:"omitempty,base64"`
+}
+
+type ReviseOfferRequest struct {
+	CandidacyID  string                   `json:"candidacy_id" validate:"required"`
+	Compensation common.OfferCompensation `json:"compensation" validate:"required"`
+	StartDate    string                   `json:"start_date"   validate:"required,datetime=2006-01-02"`
+	ExpiresAt    time.Time                `json:"expires_at"   validate:"required"`
+	Attachments  []common.OfferAttachment `json:"attachments"  validate:"omitempty,max=5,dive"`
+}
+
+type SendOfferForSignatureRequest struct {
+	CandidacyID string `json:"candidacy_id" validate:"required"`
+}
+
+type RescindOfferRequest struct {
+	CandidacyID string `json:"candidacy_id" validate:"required"`
+	Reason      string `json:"reason"       validate:"required,max=1024"`
 }
@@ -1,8 +1,14 @@
 import {
   CandidacyState,
+  InterviewChange,
   InterviewType,
   RSVPStatus,
 } from "../common/interviews";
+import type {
+  CandidacyCommentAttachment,
+  CandidacyEvent,
+} from "../common/candidacies";
+import type { OfferAttachment, OfferCompensation } from "../common/offers";
 import { InterviewState, InterviewersDecision } from "../common/interviews";
 import { OrgUserTiny } from "./orgusers";
 
@@ -23,11 +29,14 @@ export interface Candidacy {
   candidacy_state: CandidacyState;
   applicant_name: string;
   applicant_handle: string;
+  unread_comments_count: number;
+  timeline?: CandidacyEvent[];
 }
 
 export interface AddEmployerCandidacyCommentRequest {
   candidacy_id: string;
   comment: string;
+  attachments?: CandidacyCommentAttachment[];
 }
 
 export interface AddInterviewRequest {
@@ -37,6 +46,7 @@ export interface AddInterviewRequest {
   interview_type: InterviewType;
   description?: string;
   interviewer_emails?: string[];
+  meeting_url?: string;
 }
 
 export interface AddInterviewResponse {
@@ -68,9 +78,33 @@ export interface EmployerInterview {
   feedback_submitted_by?: OrgUserTiny;
   feedback_submitted_at?: Date;
   created_at: Date;
+  meeting_url?: string;
+  changes?: InterviewChange[];
 }
 
 export interface OfferToCandidateRequest {
   candidacy_id: string;
+  compensation: OfferCompensation;
+  start_date: string;
+  expires_at: Date;
+  attachments?: OfferAttachment[];
+  /** @deprecated Use attachments instead */
   offer_document?: string;
 }
+
+export interface ReviseOfferRequest {
+  candidacy_id: string;
+  compensation: OfferCompensation;
+  start_date: string;
+  expires_at: Date;
+  attachments?: OfferAttachment[];
+}
+
+export interface SendOfferForSignatureRequest {
+  candidacy_id: string;
+}
+
+export interface RescindOfferRequest {
+  candidacy_id: string;
+  reason: string;
+}
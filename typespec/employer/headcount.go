@@ -0,0 +1,46 @@
+package employer
+
+import "time"
+
+type BackfillReason string
+
+type RecordOfferAcceptanceRequest struct {
+	CandidacyID    string          `json:"candidacy_id"              validate:"required"`
+	StartDate      *string         `json:"start_date,omitempty"      validate:"omitempty,datetime=2006-01-02"`
+	BackfillReason *BackfillReason `json:"backfill_reason,omitempty" validate:"omitempty,max=256"`
+}
+
+type UpdateOpeningHireRequest struct {
+	CandidacyID    string          `json:"candidacy_id"              validate:"required"`
+	StartDate      *string         `json:"start_date,omitempty"      validate:"omitempty,datetime=2006-01-02"`
+	BackfillReason *BackfillReason `json:"backfill_reason,omitempty" validate:"omitempty,max=256"`
+}
+
+type GetOpeningHiresRequest struct {
+	OpeningID string `json:"opening_id" validate:"required"`
+}
+
+type OpeningHire struct {
+	CandidacyID     string          `json:"candidacy_id"`
+	CandidateName   string          `json:"candidate_name"`
+	CandidateHandle string          `json:"candidate_handle"`
+	StartDate       *string         `json:"start_date,omitempty"`
+	BackfillReason  *BackfillReason `json:"backfill_reason,omitempty"`
+	HiredAt         time.Time       `json:"hired_at"`
+}
+
+type GetHeadcountReportRequest struct {
+	CostCenterName *CostCenterName `json:"cost_center_name,omitempty" validate:"omitempty,min=3,max=64"`
+}
+
+type HeadcountReportRow struct {
+	CostCenterName   CostCenterName `json:"cost_center_name"`
+	LocationTitle    *string        `json:"location_title,omitempty"`
+	OpenPositions    int            `json:"open_positions"`
+	FilledPositions  int            `json:"filled_positions"`
+	InOfferPositions int            `json:"in_offer_positions"`
+}
+
+type AutoCloseFilledOpenings struct {
+	AutoCloseFilledOpenings bool `json:"auto_close_filled_openings"`
+}
@@ -0,0 +1,45 @@
+import type { CostCenterName } from "./costcenters";
+import type { OpeningID } from "./openings";
+
+export type BackfillReason = string;
+
+export interface RecordOfferAcceptanceRequest {
+  candidacy_id: string;
+  start_date?: string;
+  backfill_reason?: BackfillReason;
+}
+
+export interface UpdateOpeningHireRequest {
+  candidacy_id: string;
+  start_date?: string;
+  backfill_reason?: BackfillReason;
+}
+
+export interface GetOpeningHiresRequest {
+  opening_id: OpeningID;
+}
+
+export interface OpeningHire {
+  candidacy_id: string;
+  candidate_name: string;
+  candidate_handle: string;
+  start_date?: string;
+  backfill_reason?: BackfillReason;
+  hired_at: Date;
+}
+
+export interface GetHeadcountReportRequest {
+  cost_center_name?: CostCenterName;
+}
+
+export interface HeadcountReportRow {
+  cost_center_name: CostCenterName;
+  location_title?: string;
+  open_positions: number;
+  filled_positions: number;
+  in_offer_positions: number;
+}
+
+export interface AutoCloseFilledOpenings {
+  auto_close_filled_openings: boolean;
+}
@@ -56,3 +56,19 @@ type Assessment struct {
 type GetAssessmentRequest struct {
 	InterviewID string `json:"interview_id" validate:"required"`
 }
+
+type RescheduleInterviewRequest struct {
+	InterviewID string    `json:"interview_id"     validate:"required"`
+	StartTime   time.Time `json:"start_time"       validate:"required"`
+	EndTime     time.Time `json:"end_time"         validate:"required"`
+	Reason      *string   `json:"reason,omitempty" validate:"omitempty,max=1024"`
+
+	// Replaces the link to the meeting, if the interview uses the
+	// ManualMeetingProvider
+	MeetingURL *string `json:"meeting_url,omitempty" validate:"omitempty,url,max=1024"`
+}
+
+type CancelInterviewRequest struct {
+	InterviewID string `json:"interview_id" validate:"required"`
+	Reason      string `json:"reason"       validate:"required,max=1024"`
+}
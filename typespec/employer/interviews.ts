@@ -50,3 +50,16 @@ export interface Assessment {
 export interface GetAssessmentRequest {
   interview_id: string;
 }
+
+export interface RescheduleInterviewRequest {
+  interview_id: string;
+  start_time: Date;
+  end_time: Date;
+  reason?: string;
+  meeting_url?: string;
+}
+
+export interface CancelInterviewRequest {
+  interview_id: string;
+  reason: string;
+}
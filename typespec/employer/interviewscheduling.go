@@ -0,0 +1,56 @@
+package employer
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+// AvailabilityWindow is a weekly recurring window, in the TimeZone of the
+// OrgUserAvailability, during which the OrgUser can take interviews
+type AvailabilityWindow struct {
+	// 0 is Sunday and 6 is Saturday
+	DayOfWeek int    `json:"day_of_week" validate:"min=0,max=6"`
+	StartTime string `json:"start_time"  validate:"required,datetime=15:04"`
+	EndTime   string `json:"end_time"    validate:"required,datetime=15:04"`
+}
+
+type OrgUserAvailability struct {
+	TimeZone common.TimeZone      `json:"time_zone" validate:"required,validate_timezone"`
+	Windows  []AvailabilityWindow `json:"windows"   validate:"max=50,dive"`
+}
+
+type CreateSchedulingRequestRequest struct {
+	CandidacyID       string               `json:"candidacy_id"       validate:"required"`
+	InterviewType     common.InterviewType `json:"interview_type"     validate:"required,validate_interview_type"`
+	DurationMinutes   int                  `json:"duration_minutes"   validate:"required,min=15,max=480"`
+	Description       string               `json:"description"        validate:"omitempty,max=2048"`
+	WindowStart       time.Time            `json:"window_start"       validate:"required"`
+	WindowEnd         time.Time            `json:"window_end"         validate:"required"`
+	InterviewerEmails []string             `json:"interviewer_emails" validate:"required,min=1,max=5,unique,dive,email"`
+
+	// Link to the meeting of the booked VIDEO_CALL interview, if the employer
+	// uses the ManualMeetingProvider
+	MeetingURL string `json:"meeting_url,omitempty" validate:"omitempty,url,max=1024"`
+}
+
+type CreateSchedulingRequestResponse struct {
+	SchedulingRequestID string `json:"scheduling_request_id"`
+}
+
+type CancelSchedulingRequestRequest struct {
+	SchedulingRequestID string `json:"scheduling_request_id" validate:"required"`
+}
+
+type ConnectCalDAVRequest struct {
+	CalendarURL string `json:"calendar_url" validate:"required,url,max=1024"`
+	Username    string `json:"username"     validate:"required,max=256"`
+	Password    string `json:"password"     validate:"required,max=256"`
+}
+
+type CalDAVConnection struct {
+	CalendarURL   string     `json:"calendar_url"`
+	Username      string     `json:"username"`
+	LastSyncedAt  *time.Time `json:"last_synced_at,omitempty"`
+	LastSyncError string     `json:"last_sync_error,omitempty"`
+}
@@ -0,0 +1,45 @@
+import type { TimeZone } from "../common/common";
+import type { InterviewType } from "../common/interviews";
+
+export interface AvailabilityWindow {
+  day_of_week: number;
+  start_time: string;
+  end_time: string;
+}
+
+export interface OrgUserAvailability {
+  time_zone: TimeZone;
+  windows: AvailabilityWindow[];
+}
+
+export interface CreateSchedulingRequestRequest {
+  candidacy_id: string;
+  interview_type: InterviewType;
+  duration_minutes: number;
+  description?: string;
+  window_start: Date;
+  window_end: Date;
+  interviewer_emails: string[];
+  meeting_url?: string;
+}
+
+export interface CreateSchedulingRequestResponse {
+  scheduling_request_id: string;
+}
+
+export interface CancelSchedulingRequestRequest {
+  scheduling_request_id: string;
+}
+
+export interface ConnectCalDAVRequest {
+  calendar_url: string;
+  username: string;
+  password: string;
+}
+
+export interface CalDAVConnection {
+  calendar_url: string;
+  username: string;
+  last_synced_at?: Date;
+  last_sync_error?: string;
+}
@@ -0,0 +1,61 @@
+package employer
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type CostCenterApprovalChain struct {
+	CostCenterName CostCenterName `json:"cost_center_name"`
+	Approvers      []OrgUserShort `json:"approvers"`
+}
+
+type SetCostCenterApprovalChainRequest struct {
+	CostCenterName CostCenterName        `json:"cost_center_name" validate:"required,min=3,max=64"`
+	Approvers      []common.EmailAddress `json:"approvers"        validate:"max=5,unique,dive,email"`
+}
+
+type GetCostCenterApprovalChainRequest struct {
+	CostCenterName CostCenterName `json:"cost_center_name" validate:"required,min=3,max=64"`
+}
+
+type SubmitOpeningForApprovalRequest struct {
+	OpeningID string `json:"opening_id" validate:"required"`
+}
+
+type ApproveOpeningRequest struct {
+	OpeningID string  `json:"opening_id"        validate:"required"`
+	Comment   *string `json:"comment,omitempty" validate:"omitempty,max=1024"`
+}
+
+type RejectOpeningRequest struct {
+	OpeningID string `json:"opening_id" validate:"required"`
+	Comment   string `json:"comment"    validate:"required,min=1,max=1024"`
+}
+
+type GetOpeningApprovalsRequest struct {
+	OpeningID string `json:"opening_id" validate:"required"`
+}
+
+type OpeningApprovalStep struct {
+	StepNumber int                  `json:"step_number"`
+	Approver   OrgUserShort         `json:"approver"`
+	State      OpeningApprovalState `json:"state"`
+	Comment    *string              `json:"comment,omitempty"`
+	DecidedAt  *time.Time           `json:"decided_at,omitempty"`
+}
+
+type GetMyOpeningApprovalsRequest struct {
+	PaginationKey string `json:"pagination_key,omitempty"`
+	Limit         int    `json:"limit,omitempty"          validate:"max=40"`
+}
+
+type MyOpeningApproval struct {
+	OpeningID      string         `json:"opening_id"`
+	OpeningTitle   string         `json:"opening_title"`
+	CostCenterName CostCenterName `json:"cost_center_name"`
+	RequestedBy    OrgUserShort   `json:"requested_by"`
+	RequestedAt    time.Time      `json:"requested_at"`
+	StepNumber     int            `json:"step_number"`
+}
@@ -0,0 +1,58 @@
+import { EmailAddress } from "../common/common";
+import type { CostCenterName } from "./costcenters";
+import type { OpeningApprovalState, OpeningID } from "./openings";
+import type { OrgUserShort } from "./orgusers";
+
+export interface CostCenterApprovalChain {
+  cost_center_name: CostCenterName;
+  approvers: OrgUserShort[];
+}
+
+export interface SetCostCenterApprovalChainRequest {
+  cost_center_name: CostCenterName;
+  approvers: EmailAddress[];
+}
+
+export interface GetCostCenterApprovalChainRequest {
+  cost_center_name: CostCenterName;
+}
+
+export interface SubmitOpeningForApprovalRequest {
+  opening_id: OpeningID;
+}
+
+export interface ApproveOpeningRequest {
+  opening_id: OpeningID;
+  comment?: string;
+}
+
+export interface RejectOpeningRequest {
+  opening_id: OpeningID;
+  comment: string;
+}
+
+export interface GetOpeningApprovalsRequest {
+  opening_id: OpeningID;
+}
+
+export interface OpeningApprovalStep {
+  step_number: number;
+  approver: OrgUserShort;
+  state: OpeningApprovalState;
+  comment?: string;
+  decided_at?: Date;
+}
+
+export interface GetMyOpeningApprovalsRequest {
+  pagination_key?: OpeningID;
+  limit?: number;
+}
+
+export interface MyOpeningApproval {
+  opening_id: OpeningID;
+  opening_title: string;
+  cost_center_name: CostCenterName;
+  requested_by: OrgUserShort;
+  requested_at: Date;
+  step_number: number;
+}
@@ -8,6 +8,14 @@ import (
 
 type OpeningID string
 
+type OpeningApprovalState string
+
+const (
+	OpeningPendingApproval OpeningApprovalState = "PENDING_APPROVAL"
+	OpeningApproved        OpeningApprovalState = "APPROVED"
+	OpeningRejected        OpeningApprovalState = "REJECTED"
+)
+
 type OpeningInfo struct {
 	ID              string              `json:"id"               db:"id"`
 	Title           string              `json:"title"            db:"title"`
@@ -23,24 +31,29 @@ type OpeningInfo struct {
 }
 
 type Opening struct {
-	ID                 string               `json:"id"`
-	Title              string               `json:"title"`
-	Positions          int                  `json:"positions"`
-	FilledPositions    int                  `json:"filled_positions"`
-	JD                 string               `json:"jd"`
-	Recruiter          OrgUserShort         `json:"recruiter"`
-	HiringManager      OrgUserShort         `json:"hiring_manager"`
-	HiringTeam         []OrgUserShort       `json:"hiring_team,omitempty"`
-	CostCenterName     CostCenterName       `json:"cost_center_name"`
-	LocationTitles     []string             `json:"location_titles,omitempty"`
-	RemoteCountryCodes []common.CountryCode `json:"remote_country_codes,omitempty"`
-	RemoteTimezones    []common.TimeZone    `json:"remote_timezones,omitempty"`
-	OpeningType        common.OpeningType   `json:"opening_type"`
-	YoeMin             int                  `json:"yoe_min"`
-	YoeMax             int                  `json:"yoe_max"`
-	State              common.OpeningState  `json:"state"`
-	CreatedAt          time.Time            `json:"created_at"`
-	LastUpdatedAt      time.Time            `json:"last_updated_at"`
+	ID                 string                `json:"id"`
+	Title              string                `json:"title"`
+	Positions          int                   `json:"positions"`
+	FilledPositions    int                   `json:"filled_positions"`
+	JD                 string                `json:"jd"`
+	Recruiter          OrgUserShort          `json:"recruiter"`
+	HiringManager      OrgUserShort          `json:"hiring_manager"`
+	HiringTeam         []OrgUserShort        `json:"hiring_team,omitempty"`
+	CostCenterName     CostCenterName        `json:"cost_center_name"`
+	LocationTitles     []string              `json:"location_titles,omitempty"`
+	RemoteCountryCodes []common.CountryCode  `json:"remote_country_codes,omitempty"`
+	RemoteTimezones    []common.TimeZone     `json:"remote_timezones,omitempty"`
+	OpeningType        common.OpeningType    `json:"opening_type"`
+	YoeMin             int                   `json:"yoe_min"`
+	YoeMax             int                   `json:"yoe_max"`
+	State              common.OpeningState   `json:"state"`
+	ApprovalState      *OpeningApprovalState `json:"approval_state,omitempty"`
+	PublishAt          *time.Time            `json:"publish_at,omitempty"`
+	CloseAt            *time.Time            `json:"close_at,omitempty"`
+	MaxApplications    *int                  `json:"max_applications,omitempty"`
+	PublicListing      bool                  `json:"public_listing"`
+	CreatedAt          time.Time             `json:"created_at"`
+	LastUpdatedAt      time.Time             `json:"last_updated_at"`
 
 	// Optional fields
 	EmployerNotes     *string               `json:"employer_notes,omitempty"`
@@ -114,6 +127,18 @@ type UpdateOpeningRequest struct {
 	// TODO: Decide what fields are allowed to be updated
 }
 
+type SetOpeningScheduleRequest struct {
+	OpeningID       string     `json:"opening_id"                 validate:"required"`
+	PublishAt       *time.Time `json:"publish_at,omitempty"`
+	CloseAt         *time.Time `json:"close_at,omitempty"`
+	MaxApplications *int       `json:"max_applications,omitempty" validate:"omitempty,min=1,max=100000"`
+}
+
+type SetOpeningPublicListingRequest struct {
+	OpeningID     string `json:"opening_id"     validate:"required"`
+	PublicListing bool   `json:"public_listing"`
+}
+
 type GetOpeningWatchersRequest struct {
 	OpeningID string `json:"opening_id" validate:"required"`
 }
@@ -127,3 +152,8 @@ type RemoveOpeningWatcherRequest struct {
 	OpeningID string              `json:"opening_id" validate:"required"`
 	Email     common.EmailAddress `json:"email"      validate:"required"`
 }
+
+type CloneOpeningRequest struct {
+	OpeningID string  `json:"opening_id"      validate:"required"`
+	Title     *string `json:"title,omitempty" validate:"omitempty,min=3,max=32"`
+}
@@ -11,6 +11,17 @@ import type { OrgUserShort } from "../employer/orgusers";
 
 export type OpeningID = string;
 
+export type OpeningApprovalState =
+  | "PENDING_APPROVAL"
+  | "APPROVED"
+  | "REJECTED";
+
+export const OpeningApprovalStates = {
+  PENDING_APPROVAL: "PENDING_APPROVAL" as OpeningApprovalState,
+  APPROVED: "APPROVED" as OpeningApprovalState,
+  REJECTED: "REJECTED" as OpeningApprovalState,
+} as const;
+
 export interface OpeningInfo {
   id: OpeningID;
   title: string;
@@ -42,6 +53,11 @@ export interface Opening {
   yoe_min: number;
   yoe_max: number;
   state: OpeningState;
+  approval_state?: OpeningApprovalState;
+  publish_at?: Date;
+  close_at?: Date;
+  max_applications?: number;
+  public_listing: boolean;
   created_at: Date;
   last_updated_at: Date;
   employer_notes?: string;
@@ -94,11 +110,23 @@ export interface ChangeOpeningStateRequest {
   to_state: OpeningState;
 }
 
+export interface SetOpeningScheduleRequest {
+  opening_id: OpeningID;
+  publish_at?: Date;
+  close_at?: Date;
+  max_applications?: number;
+}
+
 export interface UpdateOpeningRequest {
   opening_id: OpeningID;
   // TODO: Decide what fields are allowed to be updated
 }
 
+export interface SetOpeningPublicListingRequest {
+  opening_id: OpeningID;
+  public_listing: boolean;
+}
+
 export interface GetOpeningWatchersRequest {
   opening_id: OpeningID;
 }
@@ -112,3 +140,8 @@ export interface RemoveOpeningWatcherRequest {
   opening_id: OpeningID;
   email: EmailAddress;
 }
+
+export interface CloneOpeningRequest {
+  opening_id: OpeningID;
+  title?: string;
+}
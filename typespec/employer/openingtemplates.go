@@ -0,0 +1,122 @@
+package employer
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type OpeningTemplateID string
+type OpeningTemplateName string
+
+type OpeningTemplate struct {
+	ID          OpeningTemplateID   `json:"id"`
+	Name        OpeningTemplateName `json:"name"`
+	Description *string             `json:"description,omitempty"`
+
+	Title              string               `json:"title"`
+	Positions          int                  `json:"positions"`
+	JD                 string               `json:"jd"`
+	Recruiter          OrgUserShort         `json:"recruiter"`
+	HiringManager      OrgUserShort         `json:"hiring_manager"`
+	HiringTeam         []OrgUserShort       `json:"hiring_team,omitempty"`
+	CostCenterName     CostCenterName       `json:"cost_center_name"`
+	LocationTitles     []string             `json:"location_titles,omitempty"`
+	RemoteCountryCodes []common.CountryCode `json:"remote_country_codes,omitempty"`
+	RemoteTimezones    []common.TimeZone    `json:"remote_timezones,omitempty"`
+	OpeningType        common.OpeningType   `json:"opening_type"`
+	YoeMin             int                  `json:"yoe_min"`
+	YoeMax             int                  `json:"yoe_max"`
+
+	// Optional fields
+	EmployerNotes     *string               `json:"employer_notes,omitempty"`
+	MinEducationLevel common.EducationLevel `json:"min_education_level"`
+	Salary            *common.Salary        `json:"salary,omitempty"`
+	Tags              []common.VTag         `json:"tags,omitempty"`
+
+	Placeholders []string `json:"placeholders,omitempty"`
+
+	CreatedAt     time.Time `json:"created_at"`
+	LastUpdatedAt time.Time `json:"last_updated_at"`
+}
+
+type CreateOpeningTemplateRequest struct {
+	Name        OpeningTemplateName `json:"name"                  validate:"required,min=3,max=64"`
+	Description *string             `json:"description,omitempty" validate:"omitempty,max=1024"`
+
+	// Title, JD and EmployerNotes may contain {{variable}} placeholders
+	Title          string                `json:"title"                     validate:"required,min=3,max=64"`
+	Positions      int                   `json:"positions"                 validate:"required,min=1,max=20"`
+	JD             string                `json:"jd"                        validate:"required,min=10,max=8192"`
+	Recruiter      common.EmailAddress   `json:"recruiter"                 validate:"required"`
+	HiringManager  common.EmailAddress   `json:"hiring_manager"            validate:"required"`
+	HiringTeam     []common.EmailAddress `json:"hiring_team,omitempty"     validate:"omitempty,max=10"`
+	CostCenterName CostCenterName        `json:"cost_center_name"          validate:"required"`
+	LocationTitles []string              `json:"location_titles,omitempty" validate:"omitempty,max=10"`
+
+	RemoteCountryCodes []common.CountryCode `json:"remote_country_codes,omitempty" validate:"omitempty,dive,validate_country_code,max=100"`
+	RemoteTimezones    []common.TimeZone    `json:"remote_timezones,omitempty"     validate:"omitempty,max=200"`
+
+	OpeningType common.OpeningType `json:"opening_type" validate:"required,validate_opening_type"`
+	YoeMin      int                `json:"yoe_min"      validate:"min=0,max=100"`
+	YoeMax      int                `json:"yoe_max"      validate:"min=1,max=100"`
+
+	// Optional fields
+	EmployerNotes     *string               `json:"employer_notes,omitempty" validate:"omitempty,max=1024"`
+	MinEducationLevel common.EducationLevel `json:"min_education_level"      validate:"required,validate_education_level"`
+	Salary            *common.Salary        `json:"salary,omitempty"         validate:"omitempty"`
+
+	TagIDs []common.VTagID `json:"tag_ids,omitempty" validate:"omitempty,max=3,min=1"`
+}
+
+// OpeningRequest returns the CreateOpeningRequest that the template would
+// produce, without resolving any of the placeholders
+func (t CreateOpeningTemplateRequest) OpeningRequest() CreateOpeningRequest {
+	return CreateOpeningRequest{
+		Title:              t.Title,
+		Positions:          t.Positions,
+		JD:                 t.JD,
+		Recruiter:          t.Recruiter,
+		HiringManager:      t.HiringManager,
+		HiringTeam:         t.HiringTeam,
+		CostCenterName:     t.CostCenterName,
+		LocationTitles:     t.LocationTitles,
+		RemoteCountryCodes: t.RemoteCountryCodes,
+		RemoteTimezones:    t.RemoteTimezones,
+		OpeningType:        t.OpeningType,
+		YoeMin:             t.YoeMin,
+		YoeMax:             t.YoeMax,
+		EmployerNotes:      t.EmployerNotes,
+		MinEducationLevel:  t.MinEducationLevel,
+		Salary:             t.Salary,
+		TagIDs:             t.TagIDs,
+	}
+}
+
+type CreateOpeningTemplateResponse struct {
+	TemplateID OpeningTemplateID `json:"template_id"`
+}
+
+type UpdateOpeningTemplateRequest struct {
+	ID OpeningTemplateID `json:"id" validate:"required"`
+	CreateOpeningTemplateRequest
+}
+
+type GetOpeningTemplateRequest struct {
+	ID OpeningTemplateID `json:"id" validate:"required"`
+}
+
+type FilterOpeningTemplatesRequest struct {
+	NamePrefix    string              `json:"name_prefix,omitempty"    validate:"omitempty,max=64"`
+	PaginationKey OpeningTemplateName `json:"pagination_key,omitempty"`
+	Limit         int                 `json:"limit,omitempty"          validate:"max=40"`
+}
+
+type DeleteOpeningTemplateRequest struct {
+	ID OpeningTemplateID `json:"id" validate:"required"`
+}
+
+type CreateOpeningFromTemplateRequest struct {
+	TemplateID OpeningTemplateID `json:"template_id"         validate:"required"`
+	Variables  map[string]string `json:"variables,omitempty" validate:"omitempty,max=50"`
+}
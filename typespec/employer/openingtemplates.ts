@@ -0,0 +1,86 @@
+import { CountryCode, EmailAddress, TimeZone } from "../common/common";
+import { EducationLevel, OpeningType, Salary } from "../common/openings";
+import { VTag, VTagID } from "../common/vtags";
+import type { CostCenterName } from "../employer/costcenters";
+import type { OrgUserShort } from "../employer/orgusers";
+
+export type OpeningTemplateID = string;
+export type OpeningTemplateName = string;
+
+export interface OpeningTemplate {
+  id: OpeningTemplateID;
+  name: OpeningTemplateName;
+  description?: string;
+  title: string;
+  positions: number;
+  jd: string;
+  recruiter: OrgUserShort;
+  hiring_manager: OrgUserShort;
+  hiring_team?: OrgUserShort[];
+  cost_center_name: CostCenterName;
+  location_titles?: string[];
+  remote_country_codes?: CountryCode[];
+  remote_timezones?: TimeZone[];
+  opening_type: OpeningType;
+  yoe_min: number;
+  yoe_max: number;
+  employer_notes?: string;
+  min_education_level: EducationLevel;
+  salary?: Salary;
+  tags?: VTag[];
+  placeholders?: string[];
+  created_at: Date;
+  last_updated_at: Date;
+}
+
+export interface CreateOpeningTemplateRequest {
+  name: OpeningTemplateName;
+  description?: string;
+
+  // title, jd and employer_notes may contain {{variable}} placeholders
+  title: string;
+  positions: number;
+  jd: string;
+  recruiter: EmailAddress;
+  hiring_manager: EmailAddress;
+  hiring_team?: EmailAddress[];
+  cost_center_name: CostCenterName;
+  location_titles?: string[];
+  remote_country_codes?: CountryCode[];
+  remote_timezones?: TimeZone[];
+  opening_type: OpeningType;
+  yoe_min: number;
+  yoe_max: number;
+  employer_notes?: string;
+  min_education_level: EducationLevel;
+  salary?: Salary;
+  tag_ids: VTagID[];
+}
+
+export interface CreateOpeningTemplateResponse {
+  template_id: OpeningTemplateID;
+}
+
+export interface UpdateOpeningTemplateRequest
+  extends CreateOpeningTemplateRequest {
+  id: OpeningTemplateID;
+}
+
+export interface GetOpeningTemplateRequest {
+  id: OpeningTemplateID;
+}
+
+export interface FilterOpeningTemplatesRequest {
+  name_prefix?: string;
+  pagination_key?: OpeningTemplateName;
+  limit?: number;
+}
+
+export interface DeleteOpeningTemplateRequest {
+  id: OpeningTemplateID;
+}
+
+export interface CreateOpeningFromTemplateRequest {
+  template_id: OpeningTemplateID;
+  variables?: Record<string, string>;
+}
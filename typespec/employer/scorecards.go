@@ -0,0 +1,91 @@
+package employer
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type Competency struct {
+	Name        string  `json:"name"                  validate:"required,min=1,max=64"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1024"`
+}
+
+type SetOpeningCompetenciesRequest struct {
+	OpeningID    string       `json:"opening_id"   validate:"required"`
+	Competencies []Competency `json:"competencies" validate:"max=20,unique=Name,dive"`
+}
+
+type GetOpeningCompetenciesRequest struct {
+	OpeningID string `json:"opening_id" validate:"required"`
+}
+
+type CompetencyRating struct {
+	Competency string  `json:"competency"      validate:"required,min=1,max=64"`
+	Rating     int     `json:"rating"          validate:"required,min=1,max=5"`
+	Notes      *string `json:"notes,omitempty" validate:"omitempty,max=4096"`
+}
+
+type PutScorecardRequest struct {
+	InterviewID            string                      `json:"interview_id"                    validate:"required"`
+	Decision               common.InterviewersDecision `json:"decision"                        validate:"required,validate_interviewers_decision"`
+	Ratings                []CompetencyRating          `json:"ratings"                         validate:"max=20,unique=Competency,dive"`
+	Positives              *string                     `json:"positives,omitempty"             validate:"omitempty,max=4096"`
+	Negatives              *string                     `json:"negatives,omitempty"             validate:"omitempty,max=4096"`
+	OverallAssessment      *string                     `json:"overall_assessment,omitempty"    validate:"omitempty,max=4096"`
+	FeedbackToCandidate    *string                     `json:"feedback_to_candidate,omitempty" validate:"omitempty,max=4096"`
+	MarkInterviewCompleted bool                        `json:"mark_interview_completed"`
+}
+
+type Scorecard struct {
+	InterviewID         string                      `json:"interview_id"`
+	Interviewer         OrgUserTiny                 `json:"interviewer"`
+	Decision            common.InterviewersDecision `json:"decision"`
+	Ratings             []CompetencyRating          `json:"ratings"`
+	Positives           *string                     `json:"positives,omitempty"`
+	Negatives           *string                     `json:"negatives,omitempty"`
+	OverallAssessment   *string                     `json:"overall_assessment,omitempty"`
+	FeedbackToCandidate *string                     `json:"feedback_to_candidate,omitempty"`
+	SubmittedAt         time.Time                   `json:"submitted_at"`
+}
+
+type GetScorecardsRequest struct {
+	InterviewID string `json:"interview_id" validate:"required"`
+}
+
+type InterviewScorecards struct {
+	InterviewID    string                `json:"interview_id"`
+	InterviewType  common.InterviewType  `json:"interview_type"`
+	InterviewState common.InterviewState `json:"interview_state"`
+	StartTime      time.Time             `json:"start_time"`
+	Scorecards     []Scorecard           `json:"scorecards"`
+
+	// Interviewers who have not submitted their scorecard yet
+	PendingInterviewers []OrgUserTiny `json:"pending_interviewers"`
+
+	// True if the scorecards of the other interviewers are withheld
+	// because the caller has not submitted their own scorecard yet
+	OthersHidden bool `json:"others_hidden"`
+}
+
+type GetCandidacyDebriefRequest struct {
+	CandidacyID string `json:"candidacy_id" validate:"required"`
+}
+
+type CompetencySummary struct {
+	Competency    string  `json:"competency"`
+	AverageRating float64 `json:"average_rating"`
+	RatingsCount  int     `json:"ratings_count"`
+}
+
+type DecisionCount struct {
+	Decision common.InterviewersDecision `json:"decision"`
+	Count    int                         `json:"count"`
+}
+
+type CandidacyDebrief struct {
+	CandidacyID  string                `json:"candidacy_id"`
+	Interviews   []InterviewScorecards `json:"interviews"`
+	Competencies []CompetencySummary   `json:"competencies"`
+	Decisions    []DecisionCount       `json:"decisions"`
+}
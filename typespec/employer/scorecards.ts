@@ -0,0 +1,86 @@
+import type {
+  InterviewersDecision,
+  InterviewState,
+  InterviewType,
+} from "../common/interviews";
+import type { OpeningID } from "./openings";
+import type { OrgUserTiny } from "./orgusers";
+
+export interface Competency {
+  name: string;
+  description?: string;
+}
+
+export interface SetOpeningCompetenciesRequest {
+  opening_id: OpeningID;
+  competencies: Competency[];
+}
+
+export interface GetOpeningCompetenciesRequest {
+  opening_id: OpeningID;
+}
+
+export interface CompetencyRating {
+  competency: string;
+  rating: number;
+  notes?: string;
+}
+
+export interface PutScorecardRequest {
+  interview_id: string;
+  decision: InterviewersDecision;
+  ratings: CompetencyRating[];
+  positives?: string;
+  negatives?: string;
+  overall_assessment?: string;
+  feedback_to_candidate?: string;
+  mark_interview_completed?: boolean;
+}
+
+export interface Scorecard {
+  interview_id: string;
+  interviewer: OrgUserTiny;
+  decision: InterviewersDecision;
+  ratings: CompetencyRating[];
+  positives?: string;
+  negatives?: string;
+  overall_assessment?: string;
+  feedback_to_candidate?: string;
+  submitted_at: Date;
+}
+
+export interface GetScorecardsRequest {
+  interview_id: string;
+}
+
+export interface InterviewScorecards {
+  interview_id: string;
+  interview_type: InterviewType;
+  interview_state: InterviewState;
+  start_time: Date;
+  scorecards: Scorecard[];
+  pending_interviewers: OrgUserTiny[];
+  others_hidden: boolean;
+}
+
+export interface GetCandidacyDebriefRequest {
+  candidacy_id: string;
+}
+
+export interface CompetencySummary {
+  competency: string;
+  average_rating: number;
+  ratings_count: number;
+}
+
+export interface DecisionCount {
+  decision: InterviewersDecision;
+  count: number;
+}
+
+export interface CandidacyDebrief {
+  candidacy_id: string;
+  interviews: InterviewScorecards[];
+  competencies: CompetencySummary[];
+  decisions: DecisionCount[];
+}
@@ -3,3 +3,34 @@ package employer
 type ChangeCoolOffPeriodRequest struct {
 	CoolOffPeriodDays int32 `json:"cool_off_period_days" validate:"min=0,max=365"`
 }
+
+type ChangeApplicationExpiryPeriodRequest struct {
+	ApplicationExpiryDays int32 `json:"application_expiry_days" validate:"min=0,max=365"`
+}
+
+type MeetingProvider string
+
+const (
+	// The interviewers paste the link to a meeting they created elsewhere
+	ManualMeetingProvider MeetingProvider = "MANUAL"
+	JitsiMeetingProvider  MeetingProvider = "JITSI"
+)
+
+type MeetingProviderSetting struct {
+	MeetingProvider MeetingProvider `json:"meeting_provider" validate:"required,oneof=MANUAL JITSI"`
+}
+
+// MatchScoreWeights are relative to each other, so at least one of them
+// must be more than 0
+type MatchScoreWeights struct {
+	Resume              int `json:"resume"                validate:"min=0,max=100"`
+	LongBio             int `json:"long_bio"              validate:"min=0,max=100"`
+	Certifications      int `json:"certifications"        validate:"min=0,max=100"`
+	Education           int `json:"education"             validate:"min=0,max=100"`
+	VerifiedWorkHistory int `json:"verified_work_history" validate:"min=0,max=100"`
+}
+
+func (w MatchScoreWeights) Total() int {
+	return w.Resume + w.LongBio + w.Certifications + w.Education +
+		w.VerifiedWorkHistory
+}
@@ -1,3 +1,26 @@
 export interface ChangeCoolOffPeriodRequest {
   cool_off_period_days: number;
 }
+
+export interface ChangeApplicationExpiryPeriodRequest {
+  application_expiry_days: number;
+}
+
+export type MeetingProvider = "MANUAL" | "JITSI";
+
+export const MeetingProviders = {
+  MANUAL: "MANUAL" as MeetingProvider,
+  JITSI: "JITSI" as MeetingProvider,
+} as const;
+
+export interface MeetingProviderSetting {
+  meeting_provider: MeetingProvider;
+}
+
+export interface MatchScoreWeights {
+  resume: number;
+  long_bio: number;
+  certifications: number;
+  education: number;
+  verified_work_history: number;
+}
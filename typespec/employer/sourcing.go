@@ -0,0 +1,48 @@
+package employer
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type CandidateSuggestionReasonType string
+
+const (
+	MatchesJDReason        CandidateSuggestionReasonType = "MATCHES_JD"
+	MatchesTagReason       CandidateSuggestionReasonType = "MATCHES_TAG"
+	SimilarRoleReason      CandidateSuggestionReasonType = "SIMILAR_ROLE"
+	YoeWithinRangeReason   CandidateSuggestionReasonType = "YOE_WITHIN_RANGE"
+	InOpeningCountryReason CandidateSuggestionReasonType = "IN_OPENING_COUNTRY"
+	InOpeningCityReason    CandidateSuggestionReasonType = "IN_OPENING_CITY"
+)
+
+type CandidateSuggestionReason struct {
+	ReasonType CandidateSuggestionReasonType `json:"reason_type"`
+	Detail     *string                       `json:"detail,omitempty"`
+	Text       string                        `json:"text"`
+}
+
+type GetSuggestedCandidatesRequest struct {
+	OpeningID string `json:"opening_id" validate:"required"`
+	Limit     int64  `json:"limit"      validate:"min=0,max=50"`
+}
+
+type SuggestedCandidate struct {
+	Handle              common.Handle               `json:"handle"`
+	FullName            string                      `json:"full_name"`
+	ShortBio            string                      `json:"short_bio"`
+	ResidentCountryCode common.CountryCode          `json:"resident_country_code"`
+	ResidentCity        *string                     `json:"resident_city,omitempty"`
+	Yoe                 *int                        `json:"yoe,omitempty"`
+	Score               int                         `json:"score"`
+	Reasons             []CandidateSuggestionReason `json:"reasons"`
+	SuggestedAt         time.Time                   `json:"suggested_at"`
+	InvitedAt           *time.Time                  `json:"invited_at,omitempty"`
+}
+
+type InviteToApplyRequest struct {
+	OpeningID string        `json:"opening_id" validate:"required"`
+	Handle    common.Handle `json:"handle"     validate:"required"`
+	Message   *string       `json:"message"    validate:"omitempty,max=1000"`
+}
@@ -0,0 +1,48 @@
+import { CountryCode, Handle } from "../common/common";
+
+export type CandidateSuggestionReasonType =
+  | "MATCHES_JD"
+  | "MATCHES_TAG"
+  | "SIMILAR_ROLE"
+  | "YOE_WITHIN_RANGE"
+  | "IN_OPENING_COUNTRY"
+  | "IN_OPENING_CITY";
+
+export const CandidateSuggestionReasonTypes = {
+  MATCHES_JD: "MATCHES_JD" as CandidateSuggestionReasonType,
+  MATCHES_TAG: "MATCHES_TAG" as CandidateSuggestionReasonType,
+  SIMILAR_ROLE: "SIMILAR_ROLE" as CandidateSuggestionReasonType,
+  YOE_WITHIN_RANGE: "YOE_WITHIN_RANGE" as CandidateSuggestionReasonType,
+  IN_OPENING_COUNTRY: "IN_OPENING_COUNTRY" as CandidateSuggestionReasonType,
+  IN_OPENING_CITY: "IN_OPENING_CITY" as CandidateSuggestionReasonType,
+} as const;
+
+export interface CandidateSuggestionReason {
+  reason_type: CandidateSuggestionReasonType;
+  detail?: string;
+  text: string;
+}
+
+export interface GetSuggestedCandidatesRequest {
+  opening_id: string;
+  limit?: number;
+}
+
+export interface SuggestedCandidate {
+  handle: Handle;
+  full_name: string;
+  short_bio: string;
+  resident_country_code: CountryCode;
+  resident_city?: string;
+  yoe?: number;
+  score: number;
+  reasons: CandidateSuggestionReason[];
+  suggested_at: Date;
+  invited_at?: Date;
+}
+
+export interface InviteToApplyRequest {
+  opening_id: string;
+  handle: Handle;
+  message?: string;
+}
@@ -0,0 +1,12 @@
+package employer
+
+import "github.com/vetchium/vetchium/typespec/common"
+
+type SetTakeHomeAssignmentRequest struct {
+	InterviewID          string                      `json:"interview_id"                   validate:"required"`
+	Brief                string                      `json:"brief"                          validate:"required,min=1,max=16384"`
+	Files                []common.TakeHomeFile       `json:"files,omitempty"                validate:"omitempty,max=5,dive"`
+	TimeLimitMinutes     *int                        `json:"time_limit_minutes,omitempty"   validate:"omitempty,min=15,max=10080"`
+	LateSubmissionPolicy common.LateSubmissionPolicy `json:"late_submission_policy"         validate:"required,oneof=REJECT_LATE ACCEPT_LATE"`
+	GracePeriodMinutes   int                         `json:"grace_period_minutes,omitempty" validate:"omitempty,min=0,max=1440"`
+}
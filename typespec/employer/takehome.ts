@@ -0,0 +1,10 @@
+import type { LateSubmissionPolicy, TakeHomeFile } from "../common/takehome";
+
+export interface SetTakeHomeAssignmentRequest {
+  interview_id: string;
+  brief: string;
+  files?: TakeHomeFile[];
+  time_limit_minutes?: number;
+  late_submission_policy: LateSubmissionPolicy;
+  grace_period_minutes?: number;
+}
@@ -0,0 +1,76 @@
+package hub
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type BookmarkType string
+
+const (
+	OpeningBookmark              BookmarkType = "OPENING"
+	PostBookmark                 BookmarkType = "POST"
+	EmployerPostBookmark         BookmarkType = "EMPLOYER_POST"
+	IncognitoPostBookmark        BookmarkType = "INCOGNITO_POST"
+	PostCommentBookmark          BookmarkType = "POST_COMMENT"
+	IncognitoPostCommentBookmark BookmarkType = "INCOGNITO_POST_COMMENT"
+)
+
+type BookmarkTarget struct {
+	BookmarkType           BookmarkType `json:"bookmark_type"             validate:"required,oneof=OPENING POST EMPLOYER_POST INCOGNITO_POST POST_COMMENT INCOGNITO_POST_COMMENT"`
+	CompanyDomain          *string      `json:"company_domain"`
+	OpeningIDWithinCompany *string      `json:"opening_id_within_company"`
+	PostID                 *string      `json:"post_id"`
+	CommentID              *string      `json:"comment_id"`
+}
+
+type AddBookmarkRequest struct {
+	Target BookmarkTarget `json:"target" validate:"required"`
+	Folder *string        `json:"folder" validate:"omitempty,min=1,max=64"`
+	Note   *string        `json:"note"   validate:"omitempty,max=1024"`
+}
+
+type RemoveBookmarkRequest struct {
+	Target BookmarkTarget `json:"target" validate:"required"`
+}
+
+type ListBookmarksRequest struct {
+	BookmarkTypes []BookmarkType `json:"bookmark_types" validate:"omitempty,dive,oneof=OPENING POST EMPLOYER_POST INCOGNITO_POST POST_COMMENT INCOGNITO_POST_COMMENT"`
+	Folder        *string        `json:"folder"         validate:"omitempty"`
+	PaginationKey *string        `json:"pagination_key" validate:"omitempty"`
+	Limit         int            `json:"limit"          validate:"min=0,max=40"`
+}
+
+func (r ListBookmarksRequest) BookmarkTypesAsStrings() []string {
+	bookmarkTypes := make([]string, len(r.BookmarkTypes))
+	for i, bookmarkType := range r.BookmarkTypes {
+		// Already validated by vator
+		bookmarkTypes[i] = string(bookmarkType)
+	}
+	return bookmarkTypes
+}
+
+type Bookmark struct {
+	BookmarkID   string         `json:"bookmark_id"`
+	Target       BookmarkTarget `json:"target"`
+	Folder       *string        `json:"folder"`
+	Note         *string        `json:"note"`
+	CreatedAt    time.Time      `json:"created_at"`
+	IsDeleted    bool           `json:"is_deleted"`
+	JobTitle     *string        `json:"job_title"`
+	CompanyName  *string        `json:"company_name"`
+	Content      *string        `json:"content"`
+	AuthorName   *string        `json:"author_name"`
+	AuthorHandle *common.Handle `json:"author_handle"`
+}
+
+type ListBookmarksResponse struct {
+	Bookmarks     []Bookmark `json:"bookmarks"`
+	PaginationKey string     `json:"pagination_key"`
+}
+
+type BookmarkFolder struct {
+	Name           string `json:"name"`
+	BookmarksCount int    `json:"bookmarks_count"`
+}
@@ -0,0 +1,67 @@
+import { Handle } from "../common/common";
+
+export type BookmarkType =
+  | "OPENING"
+  | "POST"
+  | "EMPLOYER_POST"
+  | "INCOGNITO_POST"
+  | "POST_COMMENT"
+  | "INCOGNITO_POST_COMMENT";
+
+export const BookmarkTypes = {
+  OPENING: "OPENING" as BookmarkType,
+  POST: "POST" as BookmarkType,
+  EMPLOYER_POST: "EMPLOYER_POST" as BookmarkType,
+  INCOGNITO_POST: "INCOGNITO_POST" as BookmarkType,
+  POST_COMMENT: "POST_COMMENT" as BookmarkType,
+  INCOGNITO_POST_COMMENT: "INCOGNITO_POST_COMMENT" as BookmarkType,
+} as const;
+
+export interface BookmarkTarget {
+  bookmark_type: BookmarkType;
+  company_domain?: string;
+  opening_id_within_company?: string;
+  post_id?: string;
+  comment_id?: string;
+}
+
+export interface AddBookmarkRequest {
+  target: BookmarkTarget;
+  folder?: string;
+  note?: string;
+}
+
+export interface RemoveBookmarkRequest {
+  target: BookmarkTarget;
+}
+
+export interface ListBookmarksRequest {
+  bookmark_types?: BookmarkType[];
+  folder?: string;
+  pagination_key?: string;
+  limit?: number;
+}
+
+export interface Bookmark {
+  bookmark_id: string;
+  target: BookmarkTarget;
+  folder?: string;
+  note?: string;
+  created_at: Date;
+  is_deleted: boolean;
+  job_title?: string;
+  company_name?: string;
+  content?: string;
+  author_name?: string;
+  author_handle?: Handle;
+}
+
+export interface ListBookmarksResponse {
+  bookmarks: Bookmark[];
+  pagination_key: string;
+}
+
+export interface BookmarkFolder {
+  name: string;
+  bookmarks_count: number;
+}
@@ -3,8 +3,23 @@ package hub
 import "github.com/vetchium/vetchium/typespec/common"
 
 type AddHubCandidacyCommentRequest struct {
-	CandidacyID string `json:"candidacy_id" validate:"required"`
-	Comment     string `json:"comment"      validate:"required,max=2048"`
+	CandidacyID string                              `json:"candidacy_id"          validate:"required"`
+	Comment     string                              `json:"comment"               validate:"required,max=2048"`
+	Attachments []common.CandidacyCommentAttachment `json:"attachments,omitempty" validate:"omitempty,max=5,dive"`
+}
+
+type OfferResponse string
+
+const (
+	AcceptOffer  OfferResponse = "ACCEPT"
+	DeclineOffer OfferResponse = "DECLINE"
+)
+
+type RespondToOfferRequest struct {
+	CandidacyID string        `json:"candidacy_id"     validate:"required"`
+	Version     int           `json:"version"          validate:"required,min=1"`
+	Response    OfferResponse `json:"response"         validate:"required,oneof=ACCEPT DECLINE"`
+	Reason      *string       `json:"reason,omitempty" validate:"omitempty,max=1024"`
 }
 
 type MyCandidaciesRequest struct {
@@ -21,4 +36,10 @@ type MyCandidacy struct {
 	OpeningTitle       string                `json:"opening_title"`
 	OpeningDescription string                `json:"opening_description"`
 	CandidacyState     common.CandidacyState `json:"candidacy_state"`
+
+	// The comments by the employer that the HubUser is yet to read
+	UnreadCommentsCount int `json:"unread_comments_count"`
+
+	// Populated only by the get-candidacy-info
+	Timeline []common.CandidacyEvent `json:"timeline,omitempty"`
 }
@@ -1,9 +1,24 @@
 import { CandidacyState } from '../common/interviews';
-import { GetCandidacyCommentsRequest, CandidacyComment } from '../common/candidacies';
+import { GetCandidacyCommentsRequest, CandidacyComment, CandidacyCommentAttachment, CandidacyEvent } from '../common/candidacies';
 
 export interface AddHubCandidacyCommentRequest {
     candidacy_id: string;
     comment: string;
+    attachments?: CandidacyCommentAttachment[];
+}
+
+export type OfferResponse = "ACCEPT" | "DECLINE";
+
+export const OfferResponses = {
+    ACCEPT: "ACCEPT" as OfferResponse,
+    DECLINE: "DECLINE" as OfferResponse,
+};
+
+export interface RespondToOfferRequest {
+    candidacy_id: string;
+    version: number;
+    response: OfferResponse;
+    reason?: string;
 }
 
 export interface MyCandidaciesRequest {
@@ -20,6 +35,8 @@ export interface MyCandidacy {
     opening_title: string;
     opening_description: string;
     candidacy_state: CandidacyState;
+    unread_comments_count: number;
+    timeline?: CandidacyEvent[];
 }
 
 export type { GetCandidacyCommentsRequest, CandidacyComment }; 
\ No newline at end of file
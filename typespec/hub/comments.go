@@ -28,6 +28,7 @@ type PostComment struct {
 	AuthorName   string        `json:"author_name"`
 	AuthorHandle common.Handle `json:"author_handle"`
 	CreatedAt    time.Time     `json:"created_at"`
+	IsBookmarked bool          `json:"is_bookmarked"`
 }
 
 type DisablePostCommentsRequest struct {
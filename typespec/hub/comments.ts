@@ -34,6 +34,7 @@ export interface PostComment {
   author_name: string;
   author_handle: string;
   created_at: Date;
+  is_bookmarked: boolean;
 }
 
 export class DisablePostCommentsRequest {
@@ -105,3 +105,7 @@ type MyDetails struct {
 	FullName string      `json:"full_name"`
 	Tier     HubUserTier `json:"tier"`
 }
+
+type Discoverability struct {
+	Discoverable bool `json:"discoverable"`
+}
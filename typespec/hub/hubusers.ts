@@ -104,3 +104,7 @@ export interface MyDetails {
   full_name: string;
   tier: HubUserTier;
 }
+
+export interface Discoverability {
+  discoverable: boolean;
+}
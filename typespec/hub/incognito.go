@@ -20,6 +20,7 @@ type IncognitoPost struct {
 	CanDownvote     bool          `json:"can_downvote"`
 	IsCreatedByMe   bool          `json:"is_created_by_me"`
 	IsDeleted       bool          `json:"is_deleted"`
+	IsBookmarked    bool          `json:"is_bookmarked"`
 }
 
 type AddIncognitoPostRequest struct {
@@ -14,6 +14,7 @@ export class IncognitoPost {
   can_downvote: boolean = false;
   is_created_by_me: boolean = false;
   is_deleted: boolean = false;
+  is_bookmarked: boolean = false;
 }
 
 export class AddIncognitoPostRequest {
@@ -25,9 +25,17 @@ type HubInterview struct {
 	Description    string                `json:"description"`
 	CandidateRSVP  common.RSVPStatus     `json:"candidate_rsvp_status"`
 	Interviewers   []HubInterviewer      `json:"interviewers"`
+	MeetingURL     *string               `json:"meeting_url,omitempty"`
+
+	Changes []common.InterviewChange `json:"changes"`
 }
 
 type HubRSVPInterviewRequest struct {
 	InterviewID string            `json:"interview_id" validate:"required"`
 	RSVPStatus  common.RSVPStatus `json:"rsvp_status"  validate:"required,validate_rsvp_request"`
+
+	// If the candidate cannot make it at the scheduled time, they can
+	// propose alternative slots along with a NO rsvp_status
+	ProposedSlots []common.InterviewSlot `json:"proposed_slots,omitempty" validate:"omitempty,max=3,dive"`
+	Reason        *string                `json:"reason,omitempty"         validate:"omitempty,max=1024"`
 }
@@ -1,4 +1,6 @@
 import {
+  InterviewChange,
+  InterviewSlot,
   InterviewState,
   InterviewType,
   RSVPStatus,
@@ -12,6 +14,8 @@ export interface GetHubInterviewsByCandidacyRequest {
 export interface HubInterviewer {
   name: string;
   rsvp_status: RSVPStatus;
+  proposed_slots?: InterviewSlot[];
+  reason?: string;
 }
 
 export interface HubInterview {
@@ -23,9 +27,13 @@ export interface HubInterview {
   description?: string;
   candidate_rsvp_status: RSVPStatus;
   interviewers?: HubInterviewer[];
+  meeting_url?: string;
+  changes: InterviewChange[];
 }
 
 export interface HubRSVPInterviewRequest {
   interview_id: string;
   rsvp_status: RSVPStatus;
+  proposed_slots?: InterviewSlot[];
+  reason?: string;
 }
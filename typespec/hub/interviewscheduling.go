@@ -0,0 +1,46 @@
+package hub
+
+import (
+	"time"
+
+	"github.com/vetchium/vetchium/typespec/common"
+)
+
+type GetInterviewSlotsRequest struct {
+	SchedulingRequestID string `json:"scheduling_request_id" validate:"required"`
+}
+
+type InterviewSlots struct {
+	SchedulingRequestID string               `json:"scheduling_request_id"`
+	CandidacyID         string               `json:"candidacy_id"`
+	CompanyName         string               `json:"company_name"`
+	OpeningTitle        string               `json:"opening_title"`
+	InterviewType       common.InterviewType `json:"interview_type"`
+	DurationMinutes     int                  `json:"duration_minutes"`
+	Description         string               `json:"description,omitempty"`
+
+	// The free slots, in the ascending order of the start time
+	Slots []common.InterviewSlot `json:"slots"`
+
+	// The slot currently held by the candidate, if the hold has not expired
+	HeldSlot      *common.InterviewSlot `json:"held_slot,omitempty"`
+	HoldExpiresAt *time.Time            `json:"hold_expires_at,omitempty"`
+}
+
+type HoldInterviewSlotRequest struct {
+	SchedulingRequestID string    `json:"scheduling_request_id" validate:"required"`
+	StartTime           time.Time `json:"start_time"            validate:"required"`
+}
+
+type HoldInterviewSlotResponse struct {
+	HoldExpiresAt time.Time `json:"hold_expires_at"`
+}
+
+type BookInterviewSlotRequest struct {
+	SchedulingRequestID string    `json:"scheduling_request_id" validate:"required"`
+	StartTime           time.Time `json:"start_time"            validate:"required"`
+}
+
+type BookInterviewSlotResponse struct {
+	InterviewID string `json:"interview_id"`
+}
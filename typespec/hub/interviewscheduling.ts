@@ -0,0 +1,36 @@
+import type { InterviewSlot, InterviewType } from "../common/interviews";
+
+export interface GetInterviewSlotsRequest {
+  scheduling_request_id: string;
+}
+
+export interface InterviewSlots {
+  scheduling_request_id: string;
+  candidacy_id: string;
+  company_name: string;
+  opening_title: string;
+  interview_type: InterviewType;
+  duration_minutes: number;
+  description?: string;
+  slots: InterviewSlot[];
+  held_slot?: InterviewSlot;
+  hold_expires_at?: Date;
+}
+
+export interface HoldInterviewSlotRequest {
+  scheduling_request_id: string;
+  start_time: Date;
+}
+
+export interface HoldInterviewSlotResponse {
+  hold_expires_at: Date;
+}
+
+export interface BookInterviewSlotRequest {
+  scheduling_request_id: string;
+  start_time: Date;
+}
+
+export interface BookInterviewSlotResponse {
+  interview_id: string;
+}
@@ -71,6 +71,7 @@ type HubOpeningDetails struct {
 	State                     common.OpeningState   `json:"state"`
 	YoeMax                    int                   `json:"yoe_max"`
 	YoeMin                    int                   `json:"yoe_min"`
+	IsBookmarked              bool                  `json:"is_bookmarked"`
 }
 
 type ApplyForOpeningRequest struct {
@@ -85,3 +86,62 @@ type ApplyForOpeningRequest struct {
 type ApplyForOpeningResponse struct {
 	ApplicationID string `json:"application_id"`
 }
+
+type RecommendationReasonType string
+
+const (
+	MatchesTagReason          RecommendationReasonType = "MATCHES_TAG"
+	MatchesProfileReason      RecommendationReasonType = "MATCHES_PROFILE"
+	MatchesWorkHistoryReason  RecommendationReasonType = "MATCHES_WORK_HISTORY"
+	SimilarToAppliedReason    RecommendationReasonType = "SIMILAR_TO_APPLIED"
+	HiringInYourCountryReason RecommendationReasonType = "HIRING_IN_YOUR_COUNTRY"
+	HiringInYourCityReason    RecommendationReasonType = "HIRING_IN_YOUR_CITY"
+)
+
+type RecommendationReason struct {
+	ReasonType RecommendationReasonType `json:"reason_type"`
+	Detail     *string                  `json:"detail,omitempty"`
+	Text       string                   `json:"text"`
+}
+
+type GetRecommendedOpeningsRequest struct {
+	Limit int64 `json:"limit" validate:"min=0,max=20"`
+}
+
+type RecommendedOpening struct {
+	OpeningIDWithinCompany string                 `json:"opening_id_within_company"`
+	CompanyDomain          string                 `json:"company_domain"`
+	CompanyName            string                 `json:"company_name"`
+	JobTitle               string                 `json:"job_title"`
+	Score                  int                    `json:"score"`
+	Reasons                []RecommendationReason `json:"reasons"`
+	RecommendedAt          time.Time              `json:"recommended_at"`
+}
+
+type RecommendationFeedback string
+
+const (
+	DismissedRecommendation     RecommendationFeedback = "DISMISSED"
+	NotInterestedRecommendation RecommendationFeedback = "NOT_INTERESTED"
+)
+
+type DismissRecommendedOpeningRequest struct {
+	OpeningIDWithinCompany string                 `json:"opening_id_within_company" validate:"required"`
+	CompanyDomain          string                 `json:"company_domain"            validate:"required"`
+	Feedback               RecommendationFeedback `json:"feedback"                  validate:"required,oneof=DISMISSED NOT_INTERESTED"`
+}
+
+type GetOpeningInvitationsRequest struct {
+	PaginationKey *string `json:"pagination_key" validate:"omitempty"`
+	Limit         int64   `json:"limit"          validate:"min=0,max=40"`
+}
+
+type OpeningInvitation struct {
+	InvitationID           string    `json:"invitation_id"`
+	OpeningIDWithinCompany string    `json:"opening_id_within_company"`
+	CompanyDomain          string    `json:"company_domain"`
+	CompanyName            string    `json:"company_name"`
+	JobTitle               string    `json:"job_title"`
+	Message                *string   `json:"message,omitempty"`
+	InvitedAt              time.Time `json:"invited_at"`
+}
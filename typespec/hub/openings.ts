@@ -62,6 +62,7 @@ export interface HubOpeningDetails {
   state: OpeningState;
   yoe_max: number;
   yoe_min: number;
+  is_bookmarked: boolean;
 }
 
 export interface ApplyForOpeningRequest {
@@ -76,3 +77,68 @@ export interface ApplyForOpeningRequest {
 export interface ApplyForOpeningResponse {
   application_id: string;
 }
+
+export type RecommendationReasonType =
+  | "MATCHES_TAG"
+  | "MATCHES_PROFILE"
+  | "MATCHES_WORK_HISTORY"
+  | "SIMILAR_TO_APPLIED"
+  | "HIRING_IN_YOUR_COUNTRY"
+  | "HIRING_IN_YOUR_CITY";
+
+export const RecommendationReasonTypes = {
+  MATCHES_TAG: "MATCHES_TAG" as RecommendationReasonType,
+  MATCHES_PROFILE: "MATCHES_PROFILE" as RecommendationReasonType,
+  MATCHES_WORK_HISTORY: "MATCHES_WORK_HISTORY" as RecommendationReasonType,
+  SIMILAR_TO_APPLIED: "SIMILAR_TO_APPLIED" as RecommendationReasonType,
+  HIRING_IN_YOUR_COUNTRY: "HIRING_IN_YOUR_COUNTRY" as RecommendationReasonType,
+  HIRING_IN_YOUR_CITY: "HIRING_IN_YOUR_CITY" as RecommendationReasonType,
+} as const;
+
+export interface RecommendationReason {
+  reason_type: RecommendationReasonType;
+  detail?: string;
+  text: string;
+}
+
+export interface GetRecommendedOpeningsRequest {
+  limit?: number;
+}
+
+export interface RecommendedOpening {
+  opening_id_within_company: string;
+  company_domain: string;
+  company_name: string;
+  job_title: string;
+  score: number;
+  reasons: RecommendationReason[];
+  recommended_at: Date;
+}
+
+export type RecommendationFeedback = "DISMISSED" | "NOT_INTERESTED";
+
+export const RecommendationFeedbacks = {
+  DISMISSED: "DISMISSED" as RecommendationFeedback,
+  NOT_INTERESTED: "NOT_INTERESTED" as RecommendationFeedback,
+} as const;
+
+export interface DismissRecommendedOpeningRequest {
+  opening_id_within_company: string;
+  company_domain: string;
+  feedback: RecommendationFeedback;
+}
+
+export interface GetOpeningInvitationsRequest {
+  pagination_key?: string;
+  limit?: number;
+}
+
+export interface OpeningInvitation {
+  invitation_id: string;
+  opening_id_within_company: string;
+  company_domain: string;
+  company_name: string;
+  job_title: string;
+  message?: string;
+  invited_at: Date;
+}
@@ -37,6 +37,7 @@ type Post struct {
 	AmIAuthor      bool          `json:"am_i_author"`
 	CanComment     bool          `json:"can_comment"`
 	CommentsCount  int32         `json:"comments_count"`
+	IsBookmarked   bool          `json:"is_bookmarked"`
 }
 
 type GetUserPostsRequest struct {
@@ -49,6 +49,7 @@ export interface Post {
   am_i_author: boolean;
   can_comment: boolean;
   comments_count: number;
+  is_bookmarked: boolean;
 }
 
 export interface GetUserPostsRequest {
@@ -0,0 +1,56 @@
+package hub
+
+import "time"
+
+type SavedSearchFrequency string
+
+const (
+	InstantSavedSearch SavedSearchFrequency = "INSTANT"
+	DailySavedSearch   SavedSearchFrequency = "DAILY"
+	WeeklySavedSearch  SavedSearchFrequency = "WEEKLY"
+)
+
+type SaveSearchRequest struct {
+	Name      string                 `json:"name"      validate:"required,min=1,max=64"`
+	Search    FindHubOpeningsRequest `json:"search"    validate:"required"`
+	Frequency SavedSearchFrequency   `json:"frequency" validate:"required,oneof=INSTANT DAILY WEEKLY"`
+}
+
+type SaveSearchResponse struct {
+	SavedSearchID string `json:"saved_search_id"`
+}
+
+type SavedSearch struct {
+	SavedSearchID    string                 `json:"saved_search_id"`
+	Name             string                 `json:"name"`
+	Search           FindHubOpeningsRequest `json:"search"`
+	Frequency        SavedSearchFrequency   `json:"frequency"`
+	Paused           bool                   `json:"paused"`
+	NewOpeningsCount int                    `json:"new_openings_count"`
+	LastViewedAt     time.Time              `json:"last_viewed_at"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+type UpdateSavedSearchRequest struct {
+	SavedSearchID string                  `json:"saved_search_id" validate:"required"`
+	Name          *string                 `json:"name"            validate:"omitempty,min=1,max=64"`
+	Search        *FindHubOpeningsRequest `json:"search"          validate:"omitempty"`
+	Frequency     *SavedSearchFrequency   `json:"frequency"       validate:"omitempty,oneof=INSTANT DAILY WEEKLY"`
+}
+
+type SavedSearchRequest struct {
+	SavedSearchID string `json:"saved_search_id" validate:"required"`
+}
+
+type SavedSearchOpening struct {
+	OpeningIDWithinCompany string    `json:"opening_id_within_company"`
+	CompanyDomain          string    `json:"company_domain"`
+	CompanyName            string    `json:"company_name"`
+	JobTitle               string    `json:"job_title"`
+	MatchedAt              time.Time `json:"matched_at"`
+	IsNew                  bool      `json:"is_new"`
+}
+
+type UnsubscribeSavedSearchRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,54 @@
+import { FindHubOpeningsRequest } from "./openings";
+
+export type SavedSearchFrequency = "INSTANT" | "DAILY" | "WEEKLY";
+
+export const SavedSearchFrequencies = {
+  INSTANT: "INSTANT" as SavedSearchFrequency,
+  DAILY: "DAILY" as SavedSearchFrequency,
+  WEEKLY: "WEEKLY" as SavedSearchFrequency,
+} as const;
+
+export interface SaveSearchRequest {
+  name: string;
+  search: FindHubOpeningsRequest;
+  frequency: SavedSearchFrequency;
+}
+
+export interface SaveSearchResponse {
+  saved_search_id: string;
+}
+
+export interface SavedSearch {
+  saved_search_id: string;
+  name: string;
+  search: FindHubOpeningsRequest;
+  frequency: SavedSearchFrequency;
+  paused: boolean;
+  new_openings_count: number;
+  last_viewed_at: Date;
+  created_at: Date;
+}
+
+export interface UpdateSavedSearchRequest {
+  saved_search_id: string;
+  name?: string;
+  search?: FindHubOpeningsRequest;
+  frequency?: SavedSearchFrequency;
+}
+
+export interface SavedSearchRequest {
+  saved_search_id: string;
+}
+
+export interface SavedSearchOpening {
+  opening_id_within_company: string;
+  company_domain: string;
+  company_name: string;
+  job_title: string;
+  matched_at: Date;
+  is_new: boolean;
+}
+
+export interface UnsubscribeSavedSearchRequest {
+  token: string;
+}
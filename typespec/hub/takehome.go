@@ -0,0 +1,12 @@
+package hub
+
+import "github.com/vetchium/vetchium/typespec/common"
+
+type StartTakeHomeAssignmentRequest struct {
+	InterviewID string `json:"interview_id" validate:"required"`
+}
+
+type SubmitTakeHomeAssignmentRequest struct {
+	InterviewID string              `json:"interview_id" validate:"required"`
+	Submission  common.TakeHomeFile `json:"submission"   validate:"required"`
+}
@@ -0,0 +1,10 @@
+import type { TakeHomeFile } from "../common/takehome";
+
+export interface StartTakeHomeAssignmentRequest {
+  interview_id: string;
+}
+
+export interface SubmitTakeHomeAssignmentRequest {
+  interview_id: string;
+  submission: TakeHomeFile;
+}
@@ -5,13 +5,16 @@ export * from "./common/candidacies";
 export * from "./common/common";
 export * from "./common/education";
 export * from "./common/interviews";
+export * from "./common/offers";
 export * from "./common/openings";
 export * from "./common/posts";
+export * from "./common/takehome";
 export * from "./common/vtags";
 
 // Export hub types
 export * from "./hub/achievements";
 export * from "./hub/applications";
+export * from "./hub/bookmarks";
 export * from "./hub/candidacy";
 export * from "./hub/colleagues";
 export * from "./hub/comments";
@@ -20,9 +23,12 @@ export * from "./hub/employers";
 export * from "./hub/hubusers";
 export * from "./hub/incognito";
 export * from "./hub/interviews";
+export * from "./hub/interviewscheduling";
 export * from "./hub/openings";
 export * from "./hub/posts";
 export * from "./hub/profilepage";
+export * from "./hub/savedsearches";
+export * from "./hub/takehome";
 export * from "./hub/workhistory";
 
 // Export employer types
@@ -32,9 +38,19 @@ export * from "./employer/candidacy";
 export * from "./employer/costcenters";
 export * from "./employer/education";
 export * from "./employer/interviews";
+export * from "./employer/interviewscheduling";
 export * from "./employer/locations";
+export * from "./employer/openingapprovals";
+export * from "./employer/headcount";
 export * from "./employer/openings";
+export * from "./employer/openingtemplates";
 export * from "./employer/orgusers";
 export * from "./employer/posts";
 export * from "./employer/profilepage";
+export * from "./employer/scorecards";
 export * from "./employer/settings";
+export * from "./employer/sourcing";
+export * from "./employer/takehome";
+
+// Export careers types
+export * from "./careers/careers";
@@ -1,5 +1,7 @@
 package sortinghat
 
+import "github.com/vetchium/vetchium/typespec/common"
+
 // ApplicationSortRequest represents a request to score a single application's resume
 type ApplicationSortRequest struct {
 	// ApplicationID is the unique identifier for the application
@@ -14,6 +16,10 @@ type SortingHatRequest struct {
 	JobDescription string `json:"job_description"`
 	// ApplicationSortRequests is the list of applications to score
 	ApplicationSortRequests []ApplicationSortRequest `json:"application_sort_requests"`
+	// YoeMin and YoeMax are the years of experience needed by the opening,
+	// for explaining the fit of the resumes
+	YoeMin *int `json:"yoe_min,omitempty"`
+	YoeMax *int `json:"yoe_max,omitempty"`
 }
 
 // ModelScore represents a score from a specific model
@@ -22,6 +28,9 @@ type ModelScore struct {
 	ModelName string `json:"model_name"`
 	// Score is the score value from 0 to 100
 	Score int32 `json:"score"`
+	// Explanation is why the model gave the score. Optional, so that the
+	// models that do not explain their scores remain compatible.
+	Explanation *common.ScoreExplanation `json:"explanation,omitempty"`
 }
 
 // SortingHatScore represents scores for a single application from all models
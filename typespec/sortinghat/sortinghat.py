@@ -1,4 +1,4 @@
-from typing import List
+from typing import List, Optional
 from pydantic import BaseModel, Field
 
 
@@ -12,12 +12,37 @@ class SortingHatRequest(BaseModel):
     """Request to score multiple resumes against a job description in a batch"""
     job_description: str = Field(..., description="The job description to score resumes against")
     application_sort_requests: List[ApplicationSortRequest] = Field(..., description="List of applications to score")
+    yoe_min: Optional[int] = Field(None, description="Minimum years of experience needed by the opening")
+    yoe_max: Optional[int] = Field(None, description="Maximum years of experience needed by the opening")
+
+
+class SectionScore(BaseModel):
+    """Similarity of a section of the resume to the job description"""
+    section: str = Field(..., description="Name of the section, like SKILLS or EXPERIENCE")
+    score: int = Field(..., ge=0, le=100, description="Score value from 0 to 100")
+
+
+class YoeMatch(BaseModel):
+    """Years of experience in the resume against those needed by the opening"""
+    resume_yoe: Optional[int] = Field(None, description="Estimated from the resume, if possible")
+    yoe_min: int = Field(..., description="Minimum years of experience needed by the opening")
+    yoe_max: int = Field(..., description="Maximum years of experience needed by the opening")
+    fit: str = Field(..., description="One of BELOW, WITHIN, ABOVE or UNKNOWN")
+
+
+class ScoreExplanation(BaseModel):
+    """Why a model gave a score. All the fields are optional."""
+    matched_terms: Optional[List[str]] = Field(None, description="Terms of the job description found in the resume")
+    missing_terms: Optional[List[str]] = Field(None, description="Terms of the job description not found in the resume")
+    section_scores: Optional[List[SectionScore]] = Field(None, description="Similarity of each section of the resume")
+    yoe_match: Optional[YoeMatch] = Field(None, description="Years of experience fit")
 
 
 class ModelScore(BaseModel):
     """Score from a specific model"""
     model_name: str = Field(..., description="Name of the model that generated the score")
     score: int = Field(..., ge=0, le=100, description="Score value from 0 to 100")
+    explanation: Optional[ScoreExplanation] = Field(None, description="Why the model gave the score, if it explains")
 
 
 class SortingHatScore(BaseModel):
@@ -35,6 +60,9 @@ class SortingHatResponse(BaseModel):
 __all__ = [
     "ApplicationSortRequest",
     "SortingHatRequest",
+    "SectionScore",
+    "YoeMatch",
+    "ScoreExplanation",
     "ModelScore", 
     "SortingHatScore",
     "SortingHatResponse",